@@ -0,0 +1,188 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Licensed as a CockroachDB Enterprise file under the Cockroach Community
+// License (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+//     https://github.com/cockroachdb/cockroach/blob/master/licenses/CCL.txt
+
+package changefeedccl
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/cdcevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/changefeedbase"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvevent"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/kvfeed"
+	"github.com/cockroachdb/cockroach/pkg/ccl/changefeedccl/schemafeed"
+	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
+	"github.com/cockroachdb/cockroach/pkg/util/span"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	sql.ReplicationFeedHook = runReplicationFeed
+}
+
+// runReplicationFeed implements sql.ReplicationFeedHook. It runs a kvfeed over
+// the primary indexes of the tables, decodes the row changes with their
+// previous values, and reports the timestamps at which all the spans are
+// resolved.
+func runReplicationFeed(
+	ctx context.Context,
+	execCfg *sql.ExecutorConfig,
+	tables []catalog.TableDescriptor,
+	cursor hlc.Timestamp,
+	sink sql.ReplicationFeedSink,
+) error {
+	// Like changefeeds, the feed requires rangefeeds to be enabled.
+	if !kvserver.RangefeedEnabled.Get(&execCfg.Settings.SV) {
+		return errors.Errorf("rangefeeds require the kv.rangefeed.enabled setting")
+	}
+	cfg := &execCfg.DistSQLSrv.ServerConfig
+	metrics := execCfg.JobRegistry.MetricsStruct().Changefeed.(*Metrics)
+
+	var targets changefeedbase.Targets
+	spans := make([]roachpb.Span, 0, len(tables))
+	for _, table := range tables {
+		targets.Add(changefeedbase.Target{
+			Type:              jobspb.ChangefeedTargetSpecification_PRIMARY_FAMILY_ONLY,
+			TableID:           table.GetID(),
+			StatementTimeName: changefeedbase.StatementTimeName(table.GetName()),
+		})
+		spans = append(spans, table.PrimaryIndexSpan(execCfg.Codec))
+	}
+	frontier, err := span.MakeFrontierAt(cursor, spans...)
+	if err != nil {
+		return err
+	}
+	decoder, err := cdcevent.NewEventDecoder(
+		ctx, execCfg, targets, false /* includeVirtual */, false /* keyOnly */)
+	if err != nil {
+		return err
+	}
+
+	pool := cfg.BackfillerMonitor
+	limit := changefeedbase.PerChangefeedMemLimit.Get(&cfg.Settings.SV)
+	memMon := mon.NewMonitorInheritWithLimit("replicationFeed", limit, pool)
+	memMon.StartNoReserved(ctx, pool)
+	defer memMon.Stop(ctx)
+	buf := kvevent.NewMemBuffer(memMon.MakeBoundAccount(), &cfg.Settings.SV, &metrics.KVFeedMetrics)
+
+	// Rows are decoded with the descriptor version at their timestamp, and
+	// the stream continues across schema changes without backfills.
+	schemaFeed := schemafeed.New(
+		ctx, cfg, changefeedbase.OptSchemaChangeEventClassColumnChange, targets,
+		cursor, &metrics.SchemaFeedMetrics, changefeedbase.CanHandle{},
+	)
+	kvfeedCfg := kvfeed.Config{
+		Writer:             buf,
+		Settings:           cfg.Settings,
+		DB:                 cfg.DB.KV(),
+		Codec:              cfg.Codec,
+		Clock:              cfg.DB.KV().Clock(),
+		Gossip:             cfg.Gossip,
+		Spans:              spans,
+		Targets:            targets,
+		Metrics:            &metrics.KVFeedMetrics,
+		MM:                 memMon,
+		InitialHighWater:   cursor,
+		WithDiff:           true,
+		SchemaChangeEvents: changefeedbase.OptSchemaChangeEventClassColumnChange,
+		SchemaChangePolicy: changefeedbase.OptSchemaChangePolicyNoBackfill,
+		SchemaFeed:         schemaFeed,
+		UseMux:             changefeedbase.UseMuxRangeFeed.Get(&cfg.Settings.SV),
+	}
+
+	g := ctxgroup.WithContext(ctx)
+	g.GoCtx(func(ctx context.Context) error {
+		return kvfeed.Run(ctx, kvfeedCfg)
+	})
+	g.GoCtx(func(ctx context.Context) error {
+		for {
+			ev, err := buf.Get(ctx)
+			if err != nil {
+				return err
+			}
+			switch ev.Type() {
+			case kvevent.TypeKV:
+				err = emitReplicationChange(ctx, decoder, &ev, sink)
+			case kvevent.TypeResolved:
+				resolved := ev.Resolved()
+				var advanced bool
+				if advanced, err = frontier.Forward(resolved.Span, resolved.Timestamp); err == nil && advanced {
+					err = sink.Resolved(ctx, frontier.Frontier())
+				}
+			}
+			a := ev.DetachAlloc()
+			a.Release(ctx)
+			if err != nil {
+				return err
+			}
+		}
+	})
+	return g.Wait()
+}
+
+// emitReplicationChange decodes the row change of a KV event and delivers it
+// to the sink.
+func emitReplicationChange(
+	ctx context.Context,
+	decoder cdcevent.Decoder,
+	ev *kvevent.Event,
+	sink sql.ReplicationFeedSink,
+) error {
+	schemaTS := ev.KV().Value.Timestamp
+	row, err := decoder.DecodeKV(ctx, ev.KV(), cdcevent.CurrentRow, schemaTS, false /* keyOnly */)
+	if err != nil {
+		return err
+	}
+	prevRow, err := decoder.DecodeKV(ctx, ev.PrevKeyValue(), cdcevent.PrevRow, schemaTS, false /* keyOnly */)
+	if err != nil {
+		return err
+	}
+	change := sql.ReplicationChange{
+		Table:     row.TableDescriptor(),
+		Timestamp: schemaTS,
+	}
+	if !row.IsDeleted() {
+		if change.Row, err = replicationRowDatums(row, change.Table); err != nil {
+			return err
+		}
+	}
+	if prevRow.IsInitialized() && prevRow.HasValues() && !prevRow.IsDeleted() {
+		if change.PrevRow, err = replicationRowDatums(prevRow, change.Table); err != nil {
+			return err
+		}
+	}
+	return sink.AddChange(ctx, change)
+}
+
+// replicationRowDatums returns the values of the sql.ReplicationColumns of a
+// decoded row.
+func replicationRowDatums(row cdcevent.Row, table catalog.TableDescriptor) (tree.Datums, error) {
+	cols := sql.ReplicationColumns(table)
+	datums := make(tree.Datums, len(cols))
+	for i, col := range cols {
+		it, err := row.DatumNamed(col.GetName())
+		if err != nil {
+			return nil, err
+		}
+		if err := it.Datum(func(d tree.Datum, _ cdcevent.ResultColumn) error {
+			datums[i] = d
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return datums, nil
+}
@@ -563,6 +563,13 @@ func TestTenantLogic_default(
 	runLogicTest(t, "default")
 }
 
+func TestTenantLogic_deferrable_constraints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "deferrable_constraints")
+}
+
 func TestTenantLogic_delete(
 	t *testing.T,
 ) {
@@ -647,6 +654,13 @@ func TestTenantLogic_distsql_tenant(
 	runLogicTest(t, "distsql_tenant")
 }
 
+func TestTenantLogic_domains(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "domains")
+}
+
 func TestTenantLogic_drop_database(
 	t *testing.T,
 ) {
@@ -766,6 +780,13 @@ func TestTenantLogic_exclude_data_from_backup(
 	runLogicTest(t, "exclude_data_from_backup")
 }
 
+func TestTenantLogic_exclusion_constraints(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "exclusion_constraints")
+}
+
 func TestTenantLogic_experimental_distsql_planning(
 	t *testing.T,
 ) {
@@ -822,6 +843,13 @@ func TestTenantLogic_float(
 	runLogicTest(t, "float")
 }
 
+func TestTenantLogic_foreign_table(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "foreign_table")
+}
+
 func TestTenantLogic_format(
 	t *testing.T,
 ) {
@@ -927,6 +955,13 @@ func TestTenantLogic_group_join(
 	runLogicTest(t, "group_join")
 }
 
+func TestTenantLogic_grouping_sets(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "grouping_sets")
+}
+
 func TestTenantLogic_hash_join(
 	t *testing.T,
 ) {
@@ -1116,6 +1151,13 @@ func TestTenantLogic_limit(
 	runLogicTest(t, "limit")
 }
 
+func TestTenantLogic_listen_notify(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "listen_notify")
+}
+
 func TestTenantLogic_lock_timeout(
 	t *testing.T,
 ) {
@@ -1340,6 +1382,13 @@ func TestTenantLogic_pgoidtype(
 	runLogicTest(t, "pgoidtype")
 }
 
+func TestTenantLogic_plpgsql(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "plpgsql")
+}
+
 func TestTenantLogic_poison_after_push(
 	t *testing.T,
 ) {
@@ -1382,6 +1431,13 @@ func TestTenantLogic_privileges_table(
 	runLogicTest(t, "privileges_table")
 }
 
+func TestTenantLogic_procedure(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "procedure")
+}
+
 func TestTenantLogic_propagate_input_ordering(
 	t *testing.T,
 ) {
@@ -1389,6 +1445,20 @@ func TestTenantLogic_propagate_input_ordering(
 	runLogicTest(t, "propagate_input_ordering")
 }
 
+func TestTenantLogic_publication(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "publication")
+}
+
+func TestTenantLogic_range_types(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "range_types")
+}
+
 func TestTenantLogic_reassign_owned_by(
 	t *testing.T,
 ) {
@@ -1494,6 +1564,13 @@ func TestTenantLogic_role(
 	runLogicTest(t, "role")
 }
 
+func TestTenantLogic_row_level_security(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "row_level_security")
+}
+
 func TestTenantLogic_row_level_ttl(
 	t *testing.T,
 ) {
@@ -1977,6 +2054,13 @@ func TestTenantLogic_tenant_span_stats(
 	runLogicTest(t, "tenant_span_stats")
 }
 
+func TestTenantLogic_text_search_config(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "text_search_config")
+}
+
 func TestTenantLogic_time(
 	t *testing.T,
 ) {
@@ -2089,6 +2173,13 @@ func TestTenantLogic_udf(
 	runLogicTest(t, "udf")
 }
 
+func TestTenantLogic_udf_aggregate(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_aggregate")
+}
+
 func TestTenantLogic_udf_in_column_defaults(
 	t *testing.T,
 ) {
@@ -2110,6 +2201,13 @@ func TestTenantLogic_udf_oid_ref(
 	runLogicTest(t, "udf_oid_ref")
 }
 
+func TestTenantLogic_udf_out_params(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runLogicTest(t, "udf_out_params")
+}
+
 func TestTenantLogic_udf_record(
 	t *testing.T,
 ) {
@@ -114,6 +114,13 @@ func TestCCLLogic_explain_redact(
 	runCCLLogicTest(t, "explain_redact")
 }
 
+func TestCCLLogic_materialized_view_incremental(
+	t *testing.T,
+) {
+	defer leaktest.AfterTest(t)()
+	runCCLLogicTest(t, "materialized_view_incremental")
+}
+
 func TestCCLLogic_new_schema_changer(
 	t *testing.T,
 ) {
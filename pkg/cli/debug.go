@@ -38,6 +38,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/gossip"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/gc"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/liveness/livenesspb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/rditer"
@@ -1286,7 +1287,13 @@ func runDebugIntentCount(cmd *cobra.Command, args []string) error {
 		}
 		atomic.AddUint64(&keysCount, 1)
 		if key.IsLockTableKey() {
-			intentCount++
+			ltKey, err := key.ToLockTableKey()
+			if err != nil {
+				return err
+			}
+			if ltKey.Strength == lock.Intent {
+				intentCount++
+			}
 		}
 	}
 	if err != nil {
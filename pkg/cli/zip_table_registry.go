@@ -397,6 +397,17 @@ var zipInternalTablesPerCluster = DebugZipTableRegistry{
 			to_hex(progress) AS "hex_progress"
 			FROM crdb_internal.system_jobs`,
 	},
+	"crdb_internal.kv_hot_keys": {
+		// `key` and `pretty_key` columns contain user data.
+		nonSensitiveCols: NonSensitiveColumns{
+			"node_id",
+			"store_id",
+			"range_id",
+			"reads_per_second",
+			"writes_per_second",
+			"contention_events",
+		},
+	},
 	"crdb_internal.kv_node_liveness": {
 		nonSensitiveCols: NonSensitiveColumns{
 			"node_id",
@@ -538,6 +538,86 @@ const (
 	// the system tenant.
 	V23_2_EnableRangeCoalescingForSystemTenant
 
+	// V23_2_ReadCommittedIsolation is the version where transactions can be run
+	// under READ COMMITTED isolation.
+	V23_2_ReadCommittedIsolation
+
+	// V23_2_Triggers is the version where tables can have triggers defined on
+	// them with CREATE TRIGGER.
+	V23_2_Triggers
+
+	// V23_2_StoredProcedures is the version where procedures can be created
+	// with CREATE PROCEDURE and invoked with CALL.
+	V23_2_StoredProcedures
+
+	// V23_2_GroupingSets is the version where GROUP BY can contain GROUPING
+	// SETS, ROLLUP and CUBE, which requires aggregators that understand grouping
+	// sets on all nodes.
+	V23_2_GroupingSets
+
+	// V23_2_ListenNotify is the version where the system.notifications table
+	// has been created, which is used to deliver NOTIFY payloads to sessions
+	// that LISTEN on a channel.
+	V23_2_ListenNotify
+
+	// V23_2_RoutineOutParams is the version where user-defined functions can
+	// have OUT and INOUT parameters, and can be declared with RETURNS TABLE.
+	V23_2_RoutineOutParams
+
+	// V23_2_RangeTypes is the version where range and multirange types, such
+	// as INT4RANGE and TSTZMULTIRANGE, can be used as column types.
+	V23_2_RangeTypes
+
+	// V23_2_Domains is the version where domain types can be created with
+	// CREATE DOMAIN.
+	V23_2_Domains
+
+	// V23_2_UserDefinedAggregates is the version where user-defined aggregate
+	// functions can be created with CREATE AGGREGATE.
+	V23_2_UserDefinedAggregates
+
+	// V23_2_RowLevelSecurity is the version where tables can have row-level
+	// security enabled and policies defined with CREATE POLICY.
+	V23_2_RowLevelSecurity
+
+	// V23_2_ForeignTables is the version where foreign tables over files in
+	// external storage can be created with CREATE FOREIGN TABLE.
+	V23_2_ForeignTables
+
+	// V23_2_LogicalReplication is the version where publications can be
+	// created and the system.replication_slots table has been created, which
+	// tracks the confirmed position of logical replication streams.
+	V23_2_LogicalReplication
+
+	// V23_2_IncrementalMaterializedViewRefresh is the version where
+	// materialized views record the time of their last refresh, and can be
+	// refreshed incrementally.
+	V23_2_IncrementalMaterializedViewRefresh
+
+	// V23_2_TextSearchConfigurations is the version where schemas can define
+	// text search configurations and dictionaries.
+	V23_2_TextSearchConfigurations
+
+	// V23_2_SharedLocks is the version where locking reads with FOR SHARE
+	// strength acquire Shared locks, instead of being performed as non-locking
+	// reads.
+	V23_2_SharedLocks
+
+	// V23_2_DeferrableConstraints is the version where foreign key and unique
+	// without index constraints can be declared DEFERRABLE, which is persisted
+	// in their descriptors.
+	V23_2_DeferrableConstraints
+
+	// V23_2_ExclusionConstraints is the version where tables can have
+	// exclusion constraints, whose operators are persisted in the descriptor of
+	// the index that backs them.
+	V23_2_ExclusionConstraints
+
+	// V23_2_ReplicatedLocks is the version where locking reads can acquire
+	// Shared and Exclusive locks with the Replicated durability, which are
+	// written to the replicated lock table keyspace.
+	V23_2_ReplicatedLocks
+
 	// *************************************************
 	// Step (1) Add new versions here.
 	// Do not add new versions to a patch release.
@@ -935,6 +1015,78 @@ var rawVersionsSingleton = keyedVersions{
 		Key:     V23_2_EnableRangeCoalescingForSystemTenant,
 		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 8},
 	},
+	{
+		Key:     V23_2_ReadCommittedIsolation,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 10},
+	},
+	{
+		Key:     V23_2_Triggers,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 12},
+	},
+	{
+		Key:     V23_2_StoredProcedures,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 14},
+	},
+	{
+		Key:     V23_2_GroupingSets,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 16},
+	},
+	{
+		Key:     V23_2_ListenNotify,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 18},
+	},
+	{
+		Key:     V23_2_RoutineOutParams,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 20},
+	},
+	{
+		Key:     V23_2_RangeTypes,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 22},
+	},
+	{
+		Key:     V23_2_Domains,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 24},
+	},
+	{
+		Key:     V23_2_UserDefinedAggregates,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 26},
+	},
+	{
+		Key:     V23_2_RowLevelSecurity,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 28},
+	},
+	{
+		Key:     V23_2_ForeignTables,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 30},
+	},
+	{
+		Key:     V23_2_LogicalReplication,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 32},
+	},
+	{
+		Key:     V23_2_IncrementalMaterializedViewRefresh,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 34},
+	},
+	{
+		Key:     V23_2_TextSearchConfigurations,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 36},
+	},
+	{
+		Key:     V23_2_SharedLocks,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 38},
+	},
+	{
+		Key:     V23_2_DeferrableConstraints,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 40},
+	},
+	{
+		Key:     V23_2_ExclusionConstraints,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 42},
+	},
+	{
+		Key:     V23_2_ReplicatedLocks,
+		Version: roachpb.Version{Major: 23, Minor: 1, Internal: 44},
+	},
 
 	// *************************************************
 	// Step (2): Add new versions here.
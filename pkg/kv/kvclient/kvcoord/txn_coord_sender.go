@@ -839,6 +839,16 @@ func (tc *TxnCoordSender) handleRetryableErrLocked(
 		tc.metrics.RestartsUnknown.Inc()
 	}
 	errTxnID := pErr.GetTxn().ID
+
+	// If the transaction establishes a new read snapshot for each statement,
+	// it may be able to recover from the error by retrying only the current
+	// statement at a higher read timestamp, instead of restarting from the
+	// beginning. In that case, the transaction keeps its epoch and all of the
+	// writes that it performed in earlier statements.
+	if ok, refreshTS := tc.canPartiallyRetryLocked(pErr); ok {
+		return tc.handlePartialRetryableErrLocked(ctx, pErr, refreshTS)
+	}
+
 	newTxn := kvpb.PrepareTransactionForRetry(ctx, pErr, tc.mu.userPriority, tc.clock)
 
 	// We'll pass a TransactionRetryWithProtoRefreshError up to the next layer.
@@ -878,6 +888,54 @@ func (tc *TxnCoordSender) handleRetryableErrLocked(
 	return retErr
 }
 
+// canPartiallyRetryLocked returns whether the transaction can recover from the
+// provided retryable error by retrying the current statement at a higher read
+// timestamp, without restarting from the beginning. If so, it also returns the
+// timestamp that the retry should read at.
+//
+// Partial retries are only possible for root transactions run under isolation
+// levels that establish a new read snapshot for each statement. For these
+// transactions, the previous statements did not need to observe a consistent
+// snapshot with the current statement, so only the current statement's reads
+// are invalidated by a read timestamp bump.
+func (tc *TxnCoordSender) canPartiallyRetryLocked(pErr *kvpb.Error) (bool, hlc.Timestamp) {
+	if !tc.shouldStepReadTimestampLocked() {
+		return false, hlc.Timestamp{}
+	}
+	if errTxn := pErr.GetTxn(); errTxn.ID != tc.mu.txn.ID || errTxn.Epoch != tc.mu.txn.Epoch {
+		return false, hlc.Timestamp{}
+	}
+	return kvpb.TransactionRefreshTimestamp(pErr)
+}
+
+// handlePartialRetryableErrLocked handles a retryable error that can be
+// recovered from by retrying the current statement at the provided timestamp.
+// The transaction's read timestamp is advanced, but its epoch is not bumped, so
+// the writes performed by earlier statements are retained. The transaction is
+// moved to the txnRetryableError state, from which it is expected to be rolled
+// back to a savepoint taken at the beginning of the current statement before
+// the retryable error is cleared.
+func (tc *TxnCoordSender) handlePartialRetryableErrLocked(
+	ctx context.Context, pErr *kvpb.Error, refreshTS hlc.Timestamp,
+) *kvpb.TransactionRetryWithProtoRefreshError {
+	newTxn := pErr.GetTxn().Clone()
+	newTxn.Refresh(refreshTS)
+
+	retErr := kvpb.NewTransactionRetryWithProtoRefreshError(
+		redact.Sprint(pErr),
+		newTxn.ID, // the id of the transaction that encountered the error
+		*newTxn)
+	retErr.PartialRetryAllowed = true
+
+	tc.mu.txnState = txnRetryableError
+	tc.mu.storedRetryableErr = retErr
+
+	log.VEventf(ctx, 2, "advancing read timestamp to %s for partial retry", newTxn.ReadTimestamp)
+	tc.mu.txn.Update(newTxn)
+	tc.interceptorAlloc.txnSpanRefresher.resetRefreshSpansLocked(tc.mu.txn.ReadTimestamp)
+	return retErr
+}
+
 // updateStateLocked updates the transaction state in both the success and error
 // cases. It also updates retryable errors with the updated transaction for use
 // by client restarts.
@@ -1334,12 +1392,39 @@ func (tc *TxnCoordSender) PrepareRetryableError(
 }
 
 // Step is part of the TxnSender interface.
-func (tc *TxnCoordSender) Step(ctx context.Context) error {
+func (tc *TxnCoordSender) Step(ctx context.Context, allowReadTimestampStep bool) error {
 	tc.mu.Lock()
 	defer tc.mu.Unlock()
+	if allowReadTimestampStep && tc.shouldStepReadTimestampLocked() {
+		tc.manualStepReadTimestampLocked()
+	}
 	return tc.interceptorAlloc.txnSeqNumAllocator.stepLocked(ctx)
 }
 
+// shouldStepReadTimestampLocked returns whether the transaction should advance
+// its read timestamp when stepping. This is the case for root transactions run
+// under isolation levels that establish a new read snapshot for each statement,
+// as long as the transaction's timestamp has not been fixed.
+func (tc *TxnCoordSender) shouldStepReadTimestampLocked() bool {
+	return tc.typ == kv.RootTxn &&
+		tc.mu.txn.IsoLevel.PerStatementReadSnapshot() &&
+		!tc.mu.txn.CommitTimestampFixed
+}
+
+// manualStepReadTimestampLocked advances the transaction's read timestamp to a
+// timestamp taken from the local clock, establishing a new read snapshot.
+//
+// Reads performed at earlier read snapshots do not need to be refreshed when
+// the transaction's timestamp is later pushed, because the isolation levels
+// that step their read timestamp tolerate write skew. The span refresher's
+// state is therefore reset, so that it only tracks reads performed at the new
+// read snapshot.
+func (tc *TxnCoordSender) manualStepReadTimestampLocked() {
+	now := tc.clock.Now()
+	tc.mu.txn.Refresh(now)
+	tc.interceptorAlloc.txnSpanRefresher.resetRefreshSpansLocked(tc.mu.txn.ReadTimestamp)
+}
+
 // GetReadSeqNum is part of the TxnSender interface.
 func (tc *TxnCoordSender) GetReadSeqNum() enginepb.TxnSeq {
 	tc.mu.Lock()
@@ -2886,3 +2886,93 @@ func TestTxnSetIsoLevel(t *testing.T) {
 		require.Equal(t, prev, txn.IsoLevel())
 	}
 }
+
+// TestTxnStepReadTimestamp tests that stepping a transaction advances its read
+// timestamp if and only if the transaction runs under an isolation level that
+// establishes a new read snapshot for each statement.
+func TestTxnStepReadTimestamp(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	s := createTestDB(t)
+	defer s.Stop()
+	ctx := context.Background()
+
+	for _, isoLevel := range isolation.Levels() {
+		t.Run(isoLevel.String(), func(t *testing.T) {
+			txn := kv.NewTxn(ctx, s.DB, 0 /* gatewayNodeID */)
+			require.NoError(t, txn.SetIsoLevel(isoLevel))
+			txn.ConfigureStepping(ctx, kv.SteppingEnabled)
+
+			// Stepping without allowing the read timestamp to be stepped never
+			// changes the read timestamp.
+			before := txn.ReadTimestamp()
+			s.Manual.Advance(1)
+			require.NoError(t, txn.Step(ctx, false /* allowReadTimestampStep */))
+			require.Equal(t, before, txn.ReadTimestamp())
+
+			s.Manual.Advance(1)
+			require.NoError(t, txn.Step(ctx, true /* allowReadTimestampStep */))
+			if isoLevel.PerStatementReadSnapshot() {
+				require.True(t, before.Less(txn.ReadTimestamp()))
+			} else {
+				require.Equal(t, before, txn.ReadTimestamp())
+			}
+			require.NoError(t, txn.Rollback(ctx))
+		})
+	}
+}
+
+// TestTxnPartialRetryReadCommitted tests that a READ COMMITTED transaction can
+// recover from a retryable error by retrying only the current statement,
+// without losing the writes performed by earlier statements.
+func TestTxnPartialRetryReadCommitted(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+	s := createTestDB(t)
+	defer s.Stop()
+	ctx := context.Background()
+	keyA, keyB := roachpb.Key("a"), roachpb.Key("b")
+
+	txn := kv.NewTxn(ctx, s.DB, 0 /* gatewayNodeID */)
+	require.NoError(t, txn.SetIsoLevel(isolation.ReadCommitted))
+	txn.ConfigureStepping(ctx, kv.SteppingEnabled)
+
+	// The first statement writes to keyB.
+	require.NoError(t, txn.Step(ctx, true /* allowReadTimestampStep */))
+	require.NoError(t, txn.Put(ctx, keyB, "first"))
+
+	// The second statement reads keyA and then writes to it, but another
+	// transaction writes to keyA in between.
+	require.NoError(t, txn.Step(ctx, true /* allowReadTimestampStep */))
+	sp, err := txn.CreateSavepoint(ctx)
+	require.NoError(t, err)
+	_, err = txn.Get(ctx, keyA)
+	require.NoError(t, err)
+	require.NoError(t, s.DB.Put(ctx, keyA, "other"))
+	err = txn.Put(ctx, keyA, "mine")
+	require.Error(t, err)
+
+	// The error allows for the statement to be retried on its own.
+	var retryErr *kvpb.TransactionRetryWithProtoRefreshError
+	require.True(t, errors.As(err, &retryErr))
+	require.False(t, retryErr.TxnMustRestartFromBeginning())
+	require.Equal(t, enginepb.TxnEpoch(0), txn.Epoch())
+
+	// Retry the statement.
+	require.NoError(t, txn.RollbackToSavepoint(ctx, sp))
+	require.NoError(t, txn.PrepareForPartialRetry(ctx))
+	res, err := txn.Get(ctx, keyA)
+	require.NoError(t, err)
+	require.Equal(t, []byte("other"), res.ValueBytes())
+	require.NoError(t, txn.Put(ctx, keyA, "mine"))
+	require.NoError(t, txn.ReleaseSavepoint(ctx, sp))
+	require.NoError(t, txn.Commit(ctx))
+	require.Equal(t, enginepb.TxnEpoch(0), txn.Epoch())
+
+	// The writes of both statements are committed.
+	for key, exp := range map[string]string{"a": "mine", "b": "first"} {
+		res, err := s.DB.Get(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, []byte(exp), res.ValueBytes())
+	}
+}
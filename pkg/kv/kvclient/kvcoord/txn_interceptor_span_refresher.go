@@ -709,6 +709,16 @@ func (sr *txnSpanRefresher) assertRefreshSpansAtInvalidTimestamp(
 	return nil
 }
 
+// resetRefreshSpansLocked discards the refresh spans tracked by the interceptor
+// and forwards its refreshed timestamp to the provided read timestamp. It is
+// called when the transaction establishes a new read snapshot and the reads
+// performed at earlier read snapshots no longer need to be refreshed.
+func (sr *txnSpanRefresher) resetRefreshSpansLocked(readTimestamp hlc.Timestamp) {
+	sr.refreshFootprint.clear()
+	sr.refreshInvalid = false
+	sr.refreshedTimestamp.Forward(readTimestamp)
+}
+
 // maxRefreshAttempts returns the configured number of times that a transaction
 // should attempt to refresh its spans for a single batch.
 func (sr *txnSpanRefresher) maxRefreshAttempts() int {
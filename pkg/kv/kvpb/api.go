@@ -193,10 +193,11 @@ type Request interface {
 }
 
 // LockingReadRequest is an interface used to expose the key-level locking
-// strength of a read-only request.
+// strength and durability of a read-only request.
 type LockingReadRequest interface {
 	Request
 	KeyLockingStrength() lock.Strength
+	KeyLockingDurability() lock.Durability
 }
 
 var _ LockingReadRequest = (*GetRequest)(nil)
@@ -206,6 +207,11 @@ func (gr *GetRequest) KeyLockingStrength() lock.Strength {
 	return gr.KeyLocking
 }
 
+// KeyLockingDurability implements the LockingReadRequest interface.
+func (gr *GetRequest) KeyLockingDurability() lock.Durability {
+	return keyLockingDurability(gr.KeyLockingReplicated)
+}
+
 var _ LockingReadRequest = (*ScanRequest)(nil)
 
 // KeyLockingStrength implements the LockingReadRequest interface.
@@ -213,6 +219,11 @@ func (sr *ScanRequest) KeyLockingStrength() lock.Strength {
 	return sr.KeyLocking
 }
 
+// KeyLockingDurability implements the LockingReadRequest interface.
+func (sr *ScanRequest) KeyLockingDurability() lock.Durability {
+	return keyLockingDurability(sr.KeyLockingReplicated)
+}
+
 var _ LockingReadRequest = (*ReverseScanRequest)(nil)
 
 // KeyLockingStrength implements the LockingReadRequest interface.
@@ -220,6 +231,18 @@ func (rsr *ReverseScanRequest) KeyLockingStrength() lock.Strength {
 	return rsr.KeyLocking
 }
 
+// KeyLockingDurability implements the LockingReadRequest interface.
+func (rsr *ReverseScanRequest) KeyLockingDurability() lock.Durability {
+	return keyLockingDurability(rsr.KeyLockingReplicated)
+}
+
+func keyLockingDurability(replicated bool) lock.Durability {
+	if replicated {
+		return lock.Replicated
+	}
+	return lock.Unreplicated
+}
+
 // SizedWriteRequest is an interface used to expose the number of bytes a
 // request might write.
 type SizedWriteRequest interface {
@@ -1312,9 +1335,19 @@ func flagForLockStrength(l lock.Strength) flag {
 	return 0
 }
 
+// flagForLockDurability returns isWrite for locking reads that acquire
+// replicated locks, as those locks must be proposed through raft.
+func flagForLockDurability(l lock.Strength, replicated bool) flag {
+	if l != lock.None && replicated {
+		return isWrite
+	}
+	return 0
+}
+
 func (gr *GetRequest) flags() flag {
 	maybeLocking := flagForLockStrength(gr.KeyLocking)
-	return isRead | isTxn | maybeLocking | updatesTSCache | needsRefresh | canSkipLocked
+	maybeWrite := flagForLockDurability(gr.KeyLocking, gr.KeyLockingReplicated)
+	return isRead | isTxn | maybeLocking | maybeWrite | updatesTSCache | needsRefresh | canSkipLocked
 }
 
 func (*PutRequest) flags() flag {
@@ -1404,12 +1437,14 @@ func (*RevertRangeRequest) flags() flag {
 
 func (sr *ScanRequest) flags() flag {
 	maybeLocking := flagForLockStrength(sr.KeyLocking)
-	return isRead | isRange | isTxn | maybeLocking | updatesTSCache | needsRefresh | canSkipLocked
+	maybeWrite := flagForLockDurability(sr.KeyLocking, sr.KeyLockingReplicated)
+	return isRead | isRange | isTxn | maybeLocking | maybeWrite | updatesTSCache | needsRefresh | canSkipLocked
 }
 
 func (rsr *ReverseScanRequest) flags() flag {
 	maybeLocking := flagForLockStrength(rsr.KeyLocking)
-	return isRead | isRange | isReverse | isTxn | maybeLocking | updatesTSCache | needsRefresh | canSkipLocked
+	maybeWrite := flagForLockDurability(rsr.KeyLocking, rsr.KeyLockingReplicated)
+	return isRead | isRange | isReverse | isTxn | maybeLocking | maybeWrite | updatesTSCache | needsRefresh | canSkipLocked
 }
 
 // EndTxn updates the timestamp cache to prevent replays.
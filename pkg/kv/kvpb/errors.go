@@ -750,6 +750,15 @@ func (e *TransactionRetryWithProtoRefreshError) PrevTxnAborted() bool {
 	return !e.TxnID.Equal(e.Transaction.ID)
 }
 
+// TxnMustRestartFromBeginning returns true if the transaction must restart
+// from the beginning (i.e. its epoch was bumped or it was aborted and replaced)
+// to recover from the error. If false, the transaction may instead roll back to
+// a savepoint taken at the beginning of the current statement and retry the
+// statement from there.
+func (e *TransactionRetryWithProtoRefreshError) TxnMustRestartFromBeginning() bool {
+	return !e.PartialRetryAllowed || e.PrevTxnAborted()
+}
+
 // NewTransactionPushError initializes a new TransactionPushError.
 func NewTransactionPushError(pusheeTxn roachpb.Transaction) *TransactionPushError {
 	// Note: this error will cause a txn restart. The error that the client
@@ -110,6 +110,23 @@ func (bl BasicLoad) Generate(seed int64, settings *config.SimulationSettings) []
 	}
 }
 
+// TraceLoad implements the LoadGen interface.
+type TraceLoad struct {
+	Trace workload.Trace
+	// KeysPerSpan is the maximum number of distinct keys within a span of the
+	// trace that receive load in a single tick.
+	KeysPerSpan int64
+}
+
+// Generate returns a new list of workload generators containing a single
+// generator, which replays the trace from the start time of the simulation.
+// The trace is replayed identically regardless of the seed provided.
+func (tl TraceLoad) Generate(seed int64, settings *config.SimulationSettings) []workload.Generator {
+	return []workload.Generator{
+		workload.NewTraceGenerator(settings.StartTime, tl.Trace, tl.KeysPerSpan),
+	}
+}
+
 // BasicState implements the StateGen interface.
 type BasicState struct {
 	Stores            int
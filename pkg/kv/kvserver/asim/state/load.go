@@ -70,6 +70,9 @@ func (rl *ReplicaLoadCounter) ApplyLoad(le workload.LoadEvent) {
 	rl.WriteKeys += le.Writes
 
 	rl.loadStats.RecordBatchRequests(LoadEventQPS(le), 0)
+	if le.RequestCPU > 0 {
+		rl.loadStats.RecordReqCPUNanos(float64(le.RequestCPU))
+	}
 	// TODO(kvoli): Recording the load on every load counter is horribly
 	// inefficient at the moment. It multiplies the time taken per test almost
 	// linearly by the number of load stats counters we bump. The other load
@@ -83,9 +86,10 @@ func (rl *ReplicaLoadCounter) Load() allocator.RangeUsageInfo {
 	stats := rl.loadStats.Stats()
 
 	return allocator.RangeUsageInfo{
-		LogicalBytes:     rl.WriteBytes,
-		QueriesPerSecond: stats.QueriesPerSecond,
-		WritesPerSecond:  float64(rl.WriteKeys),
+		LogicalBytes:             rl.WriteBytes,
+		QueriesPerSecond:         stats.QueriesPerSecond,
+		WritesPerSecond:          float64(rl.WriteKeys),
+		RequestCPUNanosPerSecond: stats.RequestCPUNanosPerSecond,
 	}
 }
 
@@ -136,6 +140,7 @@ func Capacity(state State, storeID StoreID) roachpb.StoreCapacity {
 			usage := state.ReplicaLoad(rng.RangeID(), storeID).Load()
 			capacity.QueriesPerSecond += usage.QueriesPerSecond
 			capacity.WritesPerSecond += usage.WritesPerSecond
+			capacity.CPUPerSecond += usage.RequestCPUNanosPerSecond
 			capacity.LogicalBytes += usage.LogicalBytes
 			capacity.LeaseCount++
 		}
@@ -151,6 +156,7 @@ type StoreUsageInfo struct {
 	WriteBytes         int64
 	ReadKeys           int64
 	ReadBytes          int64
+	RequestCPU         int64
 	LeaseTransfers     int64
 	Rebalances         int64
 	RebalanceSentBytes int64
@@ -185,8 +191,8 @@ func (u *ClusterUsageInfo) storeRef(storeID StoreID) *StoreUsageInfo {
 func (u *ClusterUsageInfo) ApplyLoad(r *rng, le workload.LoadEvent) {
 	for _, rep := range r.replicas {
 		s := u.storeRef(rep.storeID)
-		// Writes are added to all replicas, reads are added to the leaseholder
-		// only.
+		// Writes are added to all replicas, reads and request CPU are added to
+		// the leaseholder only.
 		// Note that the accounting here is different from ReplicaLoadCounter above:
 		// here we try to track the actual load on the store, regardless of the
 		// allocator implementation details, and ReplicaLoadCounter tries to follow
@@ -197,6 +203,7 @@ func (u *ClusterUsageInfo) ApplyLoad(r *rng, le workload.LoadEvent) {
 		if rep.holdsLease {
 			s.ReadBytes += le.ReadSize
 			s.ReadKeys += le.Reads
+			s.RequestCPU += le.RequestCPU
 		}
 	}
 }
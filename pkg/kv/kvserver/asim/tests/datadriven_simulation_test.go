@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 	"math/rand"
+	"os"
 	"strconv"
 	"strings"
 	"testing"
@@ -23,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/config"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/gen"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/metrics"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/asim/workload"
 	"github.com/cockroachdb/cockroach/pkg/testutils/datapathutils"
 	"github.com/cockroachdb/datadriven"
 	"github.com/guptarohit/asciigraph"
@@ -42,6 +44,31 @@ import (
 //     simulation. The default values are: rw_ratio=0 rate=0 min_block=1
 //     max_block=1 min_key=1 max_key=10_000 access_skew=false.
 //
+//   - "gen_trace" [file=<string>] [format=<string>] [keyspace=<int>]
+//     [keys_per_span=<int>]
+//     Initialize the load generator to replay a recorded load trace, in place
+//     of the load generator initialized by gen_load. The trace is read from
+//     the file (e.g. file=/tmp/trace.csv) if given, otherwise it is read from
+//     the command input. Reading from a file allows replaying traces offline,
+//     which are too large to check in. With format=trace, the trace is in CSV
+//     format, where each record contains the per-second rate of reads, writes,
+//     bytes read and written and request CPU nanoseconds on a span of the
+//     keyspace, starting at an offset into the simulation, e.g.
+//
+//     offset,start_key,end_key,reads,writes,read_bytes,write_bytes,cpu_nanos
+//     0s,0,5000,3000,1000,0,100000,40000000
+//     5m,0,5000,1000,200,0,20000,8000000
+//
+//     The load on a span applies from the record's offset until a later
+//     record for the same span. See workload.ParseTrace for the format. With
+//     format=tsdump, the store metrics output by cockroach debug tsdump
+//     --format=csv are replayed, see workload.ParseTSDump. With format=keyvis,
+//     the samples collected by the key visualizer are replayed, see
+//     workload.ParseKeyVisSamples. Recorded store metrics and samples are
+//     mapped onto the keyspace (e.g. keyspace=10000). The load on a span is
+//     spread over at most keys_per_span keys each tick. The default values
+//     are: format=trace keyspace=10000 keys_per_span=16.
+//
 //   - "gen_state" [stores=<int>] [ranges=<int>] [placement_skew=<bool>]
 //     [repl_factor=<int>] [keyspace=<int>]
 //     Initialize the state generator parameters. On the next call to eval, the
@@ -94,7 +121,7 @@ func TestDataDriven(t *testing.T) {
 	dir := datapathutils.TestDataPath(t, ".")
 	datadriven.Walk(t, dir, func(t *testing.T, path string) {
 		const defaultKeyspace = 10000
-		loadGen := gen.BasicLoad{}
+		var loadGen gen.LoadGen = gen.BasicLoad{}
 		stateGen := gen.BasicState{}
 		settingsGen := gen.StaticSettings{Settings: config.DefaultSimulationSettings()}
 		assertions := []SimulationAssertion{}
@@ -115,13 +142,46 @@ func TestDataDriven(t *testing.T) {
 				scanIfExists(t, d, "min_key", &minKey)
 				scanIfExists(t, d, "max_key", &maxKey)
 
-				loadGen.SkewedAccess = accessSkew
-				loadGen.MinKey = minKey
-				loadGen.MaxKey = maxKey
-				loadGen.RWRatio = rwRatio
-				loadGen.Rate = rate
-				loadGen.MaxBlockSize = maxBlock
-				loadGen.MinBlockSize = minBlock
+				loadGen = gen.BasicLoad{
+					SkewedAccess: accessSkew,
+					MinKey:       minKey,
+					MaxKey:       maxKey,
+					RWRatio:      rwRatio,
+					Rate:         rate,
+					MaxBlockSize: maxBlock,
+					MinBlockSize: minBlock,
+				}
+				return ""
+			case "gen_trace":
+				var file, format = "", "trace"
+				var keyspace, keysPerSpan int64 = defaultKeyspace, workload.DefaultTraceKeysPerSpan
+				input := d.Input
+				scanIfExists(t, d, "file", &file)
+				scanIfExists(t, d, "format", &format)
+				scanIfExists(t, d, "keyspace", &keyspace)
+				scanIfExists(t, d, "keys_per_span", &keysPerSpan)
+				require.Positive(t, keysPerSpan)
+				if file != "" {
+					b, err := os.ReadFile(file)
+					require.NoError(t, err)
+					input = string(b)
+				}
+				var trace workload.Trace
+				var err error
+				switch format {
+				case "trace":
+					trace, err = workload.ParseTrace(strings.NewReader(input))
+				case "tsdump":
+					trace, err = workload.ParseTSDump(strings.NewReader(input), keyspace)
+				case "keyvis":
+					trace, err = workload.ParseKeyVisSamples(strings.NewReader(input), keyspace)
+				default:
+					return fmt.Sprintf("unknown trace format: %s", format)
+				}
+				if err != nil {
+					return err.Error()
+				}
+				loadGen = gen.TraceLoad{Trace: trace, KeysPerSpan: keysPerSpan}
 				return ""
 			case "gen_state":
 				var stores, ranges, replFactor, keyspace = 3, 1, 3, defaultKeyspace
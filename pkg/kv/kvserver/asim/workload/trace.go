@@ -0,0 +1,365 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package workload
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// DefaultTraceKeysPerSpan is the default maximum number of distinct keys
+// within a span that receive load in a single tick. The load on a span is
+// spread over multiple keys so that load-based splitting is able to find a
+// split key within the span.
+const DefaultTraceKeysPerSpan = 16
+
+// TraceRecord is the load recorded against a span of the keyspace, starting at
+// an offset from the beginning of the trace. The load applies until a later
+// record for the same span, or until the end of the replay.
+type TraceRecord struct {
+	Offset              time.Duration
+	StartKey, EndKey    int64
+	ReadsPerSecond      float64
+	WritesPerSecond     float64
+	ReadBytesPerSecond  float64
+	WriteBytesPerSecond float64
+	// RequestCPUPerSecond is the CPU time, in nanoseconds per second, spent
+	// evaluating the reads and writes.
+	RequestCPUPerSecond float64
+}
+
+// idle returns true if the record carries no load.
+func (rec TraceRecord) idle() bool {
+	return rec.ReadsPerSecond == 0 && rec.WritesPerSecond == 0
+}
+
+// Trace is a recorded load trace, ordered by offset.
+type Trace []TraceRecord
+
+// Trace column names, as expected in the header of a CSV trace.
+const (
+	traceColOffset     = "offset"
+	traceColStartKey   = "start_key"
+	traceColEndKey     = "end_key"
+	traceColReads      = "reads"
+	traceColWrites     = "writes"
+	traceColReadBytes  = "read_bytes"
+	traceColWriteBytes = "write_bytes"
+	traceColCPUNanos   = "cpu_nanos"
+)
+
+// ParseTrace parses a load trace in CSV format. The first record is a header
+// naming the columns, which may appear in any order:
+//
+//   - offset: the offset of the record from the start of the trace, as a
+//     duration (e.g. 10s).
+//   - start_key, end_key: the span [start_key, end_key) of the simulated
+//     keyspace which the load was recorded against.
+//   - reads, writes: the rate of read and write requests per second.
+//   - read_bytes, write_bytes: the rate of bytes read and written per second.
+//   - cpu_nanos: the CPU time, in nanoseconds per second, spent evaluating the
+//     reads and writes.
+//
+// The offset, start_key and end_key columns are required, the rate columns
+// default to zero when omitted. Lines beginning with # are ignored. Recorded
+// store metrics and key visualizer samples are converted into a trace by
+// ParseTSDump and ParseKeyVisSamples respectively.
+func ParseTrace(r io.Reader) (Trace, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.New("trace is missing a header")
+		}
+		return nil, errors.Wrap(err, "reading trace header")
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.TrimSpace(name)
+		switch name {
+		case traceColOffset, traceColStartKey, traceColEndKey, traceColReads,
+			traceColWrites, traceColReadBytes, traceColWriteBytes, traceColCPUNanos:
+		default:
+			return nil, errors.Newf("unknown trace column %q", name)
+		}
+		if _, ok := cols[name]; ok {
+			return nil, errors.Newf("duplicate trace column %q", name)
+		}
+		cols[name] = i
+	}
+	for _, name := range []string{traceColOffset, traceColStartKey, traceColEndKey} {
+		if _, ok := cols[name]; !ok {
+			return nil, errors.Newf("trace is missing required column %q", name)
+		}
+	}
+
+	var trace Trace
+	for {
+		fields, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading trace")
+		}
+		line, _ := cr.FieldPos(0)
+		rec, err := parseTraceRecord(cols, fields)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", line)
+		}
+		trace = append(trace, rec)
+	}
+	trace.sort()
+	return trace, nil
+}
+
+// sort orders the records in the trace by offset and then by span, retaining
+// the order of records for the same span at the same offset.
+func (t Trace) sort() {
+	sort.SliceStable(t, func(i, j int) bool {
+		if t[i].Offset != t[j].Offset {
+			return t[i].Offset < t[j].Offset
+		}
+		if t[i].StartKey != t[j].StartKey {
+			return t[i].StartKey < t[j].StartKey
+		}
+		return t[i].EndKey < t[j].EndKey
+	})
+}
+
+func parseTraceRecord(cols map[string]int, fields []string) (TraceRecord, error) {
+	field := func(name string) (string, bool) {
+		i, ok := cols[name]
+		if !ok {
+			return "", false
+		}
+		return strings.TrimSpace(fields[i]), true
+	}
+
+	var rec TraceRecord
+	var err error
+	s, _ := field(traceColOffset)
+	if rec.Offset, err = time.ParseDuration(s); err != nil {
+		return TraceRecord{}, errors.Wrapf(err, "parsing %s", traceColOffset)
+	}
+	if rec.Offset < 0 {
+		return TraceRecord{}, errors.Newf("%s must not be negative", traceColOffset)
+	}
+	s, _ = field(traceColStartKey)
+	if rec.StartKey, err = strconv.ParseInt(s, 10, 64); err != nil {
+		return TraceRecord{}, errors.Wrapf(err, "parsing %s", traceColStartKey)
+	}
+	s, _ = field(traceColEndKey)
+	if rec.EndKey, err = strconv.ParseInt(s, 10, 64); err != nil {
+		return TraceRecord{}, errors.Wrapf(err, "parsing %s", traceColEndKey)
+	}
+	if rec.EndKey <= rec.StartKey {
+		return TraceRecord{}, errors.Newf("%s (%d) must be greater than %s (%d)",
+			traceColEndKey, rec.EndKey, traceColStartKey, rec.StartKey)
+	}
+	for _, rate := range []struct {
+		name string
+		dest *float64
+	}{
+		{traceColReads, &rec.ReadsPerSecond},
+		{traceColWrites, &rec.WritesPerSecond},
+		{traceColReadBytes, &rec.ReadBytesPerSecond},
+		{traceColWriteBytes, &rec.WriteBytesPerSecond},
+		{traceColCPUNanos, &rec.RequestCPUPerSecond},
+	} {
+		s, ok := field(rate.name)
+		if !ok {
+			continue
+		}
+		if *rate.dest, err = strconv.ParseFloat(s, 64); err != nil {
+			return TraceRecord{}, errors.Wrapf(err, "parsing %s", rate.name)
+		}
+		if *rate.dest < 0 {
+			return TraceRecord{}, errors.Newf("%s must not be negative", rate.name)
+		}
+	}
+	if rec.RequestCPUPerSecond > 0 && rec.idle() {
+		return TraceRecord{}, errors.Newf("%s requires %s or %s",
+			traceColCPUNanos, traceColReads, traceColWrites)
+	}
+	return rec, nil
+}
+
+// traceSpan is the replayed load on a span of the keyspace.
+type traceSpan struct {
+	rec TraceRecord
+	// readCarry and writeCarry are the fractional reads and writes that were
+	// not yet generated, carried over to the next tick so that low rates are
+	// not lost to rounding.
+	readCarry, writeCarry float64
+	// cursor is the offset within the span of the next key to receive load,
+	// which rotates every tick so that load is spread over the span.
+	cursor int64
+	// keys is the maximum number of distinct keys within the span that
+	// receive load in a single tick.
+	keys int64
+}
+
+// TraceGenerator generates the load recorded in a trace, replaying it from
+// the start time of the simulation.
+type TraceGenerator struct {
+	start       time.Time
+	lastRun     time.Time
+	trace       Trace
+	keysPerSpan int64
+	// next is the index of the next record in the trace to take effect.
+	next  int
+	spans map[[2]int64]*traceSpan
+}
+
+// NewTraceGenerator returns a generator that replays the load recorded in the
+// trace, where the start time corresponds to a trace offset of zero. The load
+// on each span is spread over at most keysPerSpan keys per tick, which must
+// be positive.
+func NewTraceGenerator(start time.Time, trace Trace, keysPerSpan int64) Generator {
+	return newTraceGenerator(start, trace, keysPerSpan)
+}
+
+func newTraceGenerator(start time.Time, trace Trace, keysPerSpan int64) *TraceGenerator {
+	if keysPerSpan <= 0 {
+		panic(fmt.Sprintf("keys per span must be positive, found %d", keysPerSpan))
+	}
+	return &TraceGenerator{
+		start:       start,
+		lastRun:     start,
+		trace:       trace,
+		keysPerSpan: keysPerSpan,
+		spans:       make(map[[2]int64]*traceSpan),
+	}
+}
+
+// Tick returns the load events up till time tick, from the last time the
+// workload generator was called.
+func (tg *TraceGenerator) Tick(maxTime time.Time) LoadBatch {
+	elapsed := maxTime.Sub(tg.lastRun).Seconds()
+	if elapsed <= 0 {
+		return LoadBatch{}
+	}
+	// The load generated for this tick is determined by the records which
+	// were in effect at the last run. Records which take effect during the
+	// tick apply from the next tick onwards.
+	tg.advance(tg.lastRun)
+
+	next := make(map[int64]LoadEvent)
+	keys := make([][2]int64, 0, len(tg.spans))
+	for key := range tg.spans {
+		keys = append(keys, key)
+	}
+	// Iterate over the spans in a deterministic order.
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	for _, key := range keys {
+		tg.spans[key].generate(elapsed, next)
+	}
+
+	ret := make(LoadBatch, 0, len(next))
+	for k, v := range next {
+		v.Key = k
+		ret = append(ret, v)
+	}
+	sort.Sort(ret)
+	tg.lastRun = maxTime
+	return ret
+}
+
+// advance applies every record in the trace with an offset up to and
+// including the time given. Spans whose load stops are no longer tracked.
+func (tg *TraceGenerator) advance(now time.Time) {
+	offset := now.Sub(tg.start)
+	for ; tg.next < len(tg.trace) && tg.trace[tg.next].Offset <= offset; tg.next++ {
+		rec := tg.trace[tg.next]
+		key := [2]int64{rec.StartKey, rec.EndKey}
+		if rec.idle() {
+			delete(tg.spans, key)
+		} else if span, ok := tg.spans[key]; ok {
+			span.rec = rec
+		} else {
+			tg.spans[key] = &traceSpan{rec: rec, keys: tg.keysPerSpan}
+		}
+	}
+}
+
+// generate adds the load on the span over the elapsed seconds to the events.
+func (ts *traceSpan) generate(elapsed float64, events map[int64]LoadEvent) {
+	reads := ts.rec.ReadsPerSecond*elapsed + ts.readCarry
+	writes := ts.rec.WritesPerSecond*elapsed + ts.writeCarry
+	readCount, writeCount := int64(reads), int64(writes)
+	ts.readCarry = reads - float64(readCount)
+	ts.writeCarry = writes - float64(writeCount)
+
+	var readSize, writeSize int64
+	if ts.rec.ReadsPerSecond > 0 {
+		readSize = int64(ts.rec.ReadBytesPerSecond / ts.rec.ReadsPerSecond)
+	}
+	if ts.rec.WritesPerSecond > 0 {
+		writeSize = int64(ts.rec.WriteBytesPerSecond / ts.rec.WritesPerSecond)
+	}
+	// The request CPU is attributed evenly to the reads and writes.
+	cpuPerRequest := int64(ts.rec.RequestCPUPerSecond / (ts.rec.ReadsPerSecond + ts.rec.WritesPerSecond))
+	ts.spread(readCount, func(key, count int64) {
+		event := events[key]
+		event.Reads += count
+		event.ReadSize += count * readSize
+		event.RequestCPU += count * cpuPerRequest
+		events[key] = event
+	})
+	ts.spread(writeCount, func(key, count int64) {
+		event := events[key]
+		event.Writes += count
+		event.WriteSize += count * writeSize
+		event.RequestCPU += count * cpuPerRequest
+		events[key] = event
+	})
+	if width := ts.rec.EndKey - ts.rec.StartKey; width > 0 {
+		ts.cursor = (ts.cursor + 1) % width
+	}
+}
+
+// spread divides count accesses evenly over up to the span's maximum number of
+// keys per tick, calling f with each key and the number of accesses to it.
+func (ts *traceSpan) spread(count int64, f func(key, count int64)) {
+	if count <= 0 {
+		return
+	}
+	width := ts.rec.EndKey - ts.rec.StartKey
+	n := count
+	if n > width {
+		n = width
+	}
+	if n > ts.keys {
+		n = ts.keys
+	}
+	for i := int64(0); i < n; i++ {
+		keyCount := count / n
+		if i < count%n {
+			keyCount++
+		}
+		f(ts.rec.StartKey+(ts.cursor+i*width/n)%width, keyCount)
+	}
+}
@@ -0,0 +1,317 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package workload
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/hex"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// tsdumpStoreMetrics maps the names of the store metrics in a tsdump, which
+// are used when replaying the load recorded in it, to the trace record field
+// they are replayed as.
+var tsdumpStoreMetrics = map[string]func(*TraceRecord) *float64{
+	"cr.store.rebalancing.readspersecond":      func(r *TraceRecord) *float64 { return &r.ReadsPerSecond },
+	"cr.store.rebalancing.writespersecond":     func(r *TraceRecord) *float64 { return &r.WritesPerSecond },
+	"cr.store.rebalancing.readbytespersecond":  func(r *TraceRecord) *float64 { return &r.ReadBytesPerSecond },
+	"cr.store.rebalancing.writebytespersecond": func(r *TraceRecord) *float64 { return &r.WriteBytesPerSecond },
+	"cr.store.rebalancing.cpunanospersecond":   func(r *TraceRecord) *float64 { return &r.RequestCPUPerSecond },
+}
+
+// ParseTSDump converts the store metrics recorded in a tsdump, as output by
+// cockroach debug tsdump --format=csv, into a trace. Every line of the dump
+// contains the metric name, the timestamp in RFC3339 format, the source and
+// the value, e.g.
+//
+//	cr.store.rebalancing.readspersecond,2023-05-10T12:00:00Z,1,3512.5
+//
+// Only the store rebalancing metrics for reads, writes, bytes read and
+// written and CPU time are used, all other metrics are ignored. The time
+// series only record the load of each store, not of the ranges on it, so the
+// load of each store is replayed against an equal share of the keyspace
+// [0, keyspace), in the order of the store IDs. This retains the skew of the
+// load between stores, and over time, but not within a store.
+func ParseTSDump(r io.Reader, keyspace int64) (Trace, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 4
+	type sample struct {
+		ts    time.Time
+		store int64
+	}
+	samples := make(map[sample]*TraceRecord)
+	stores := make(map[int64]struct{})
+	for {
+		fields, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading tsdump")
+		}
+		field, ok := tsdumpStoreMetrics[fields[0]]
+		if !ok {
+			continue
+		}
+		line, _ := cr.FieldPos(0)
+		ts, err := time.Parse(time.RFC3339Nano, fields[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d: parsing timestamp", line)
+		}
+		store, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d: parsing store", line)
+		}
+		value, err := strconv.ParseFloat(fields[3], 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d: parsing value", line)
+		}
+		if value < 0 {
+			return nil, errors.Newf("line %d: %s must not be negative", line, fields[0])
+		}
+		key := sample{ts: ts.UTC(), store: store}
+		rec, ok := samples[key]
+		if !ok {
+			rec = &TraceRecord{}
+			samples[key] = rec
+		}
+		*field(rec) = value
+		stores[store] = struct{}{}
+	}
+	if len(samples) == 0 {
+		return nil, errors.New("tsdump contains no store rebalancing metrics")
+	}
+	if int64(len(stores)) > keyspace {
+		return nil, errors.Newf(
+			"keyspace (%d) is smaller than the number of stores (%d)", keyspace, len(stores))
+	}
+
+	storeIDs := make([]int64, 0, len(stores))
+	for store := range stores {
+		storeIDs = append(storeIDs, store)
+	}
+	sort.Slice(storeIDs, func(i, j int) bool { return storeIDs[i] < storeIDs[j] })
+	spans := make(map[int64][2]int64, len(storeIDs))
+	for i, store := range storeIDs {
+		spans[store] = [2]int64{
+			int64(i) * keyspace / int64(len(storeIDs)),
+			int64(i+1) * keyspace / int64(len(storeIDs)),
+		}
+	}
+
+	var start time.Time
+	for key := range samples {
+		if start.IsZero() || key.ts.Before(start) {
+			start = key.ts
+		}
+	}
+	trace := make(Trace, 0, len(samples))
+	for key, rec := range samples {
+		rec.Offset = key.ts.Sub(start)
+		rec.StartKey, rec.EndKey = spans[key.store][0], spans[key.store][1]
+		// The CPU time of a store without reads or writes can't be attributed
+		// to any requests.
+		if rec.idle() {
+			rec.RequestCPUPerSecond = 0
+		}
+		trace = append(trace, *rec)
+	}
+	trace.sort()
+	return trace, nil
+}
+
+// Key visualizer sample column names, as expected in the header of the CSV
+// samples.
+const (
+	keyVisColSampleTime = "sample_time"
+	keyVisColStartKey   = "start_key"
+	keyVisColEndKey     = "end_key"
+	keyVisColRequests   = "requests"
+)
+
+// keyVisTimeFormats are the formats accepted for the sample time of key
+// visualizer samples, which include the format of timestamps output by the
+// SQL shell.
+var keyVisTimeFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// ParseKeyVisSamples converts the samples collected by the key visualizer
+// into a trace. The samples are in CSV format, with a header naming the
+// sample_time, start_key, end_key and requests columns, where the keys are
+// hex encoded. The samples may be exported with:
+//
+//	cockroach sql --format=csv -e "
+//	  SELECT s.sample_time,
+//	         encode(sk.key_bytes, 'hex') AS start_key,
+//	         encode(ek.key_bytes, 'hex') AS end_key,
+//	         b.requests
+//	    FROM system.span_stats_buckets AS b
+//	    JOIN system.span_stats_samples AS s ON s.id = b.sample_id
+//	    JOIN system.span_stats_unique_keys AS sk ON sk.id = b.start_key_id
+//	    JOIN system.span_stats_unique_keys AS ek ON ek.id = b.end_key_id"
+//
+// Each sample records the number of requests to a span over the sample
+// interval ending at the sample time. The interval is taken as the time since
+// the previous sample, which requires at least two samples. The key visualizer
+// doesn't distinguish between reads and writes, so requests are replayed as
+// reads. The distinct span boundaries over all samples are mapped, in order,
+// onto evenly spaced keys in the keyspace [0, keyspace].
+func ParseKeyVisSamples(r io.Reader, keyspace int64) (Trace, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		if err == io.EOF {
+			return nil, errors.New("key visualizer samples are missing a header")
+		}
+		return nil, errors.Wrap(err, "reading key visualizer samples header")
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		cols[strings.TrimSpace(name)] = i
+	}
+	for _, name := range []string{
+		keyVisColSampleTime, keyVisColStartKey, keyVisColEndKey, keyVisColRequests,
+	} {
+		if _, ok := cols[name]; !ok {
+			return nil, errors.Newf("key visualizer samples are missing required column %q", name)
+		}
+	}
+
+	type bucket struct {
+		startKey, endKey string
+		requests         float64
+	}
+	samples := make(map[time.Time][]bucket)
+	boundaries := make(map[string]struct{})
+	for {
+		fields, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "reading key visualizer samples")
+		}
+		line, _ := cr.FieldPos(0)
+		ts, err := parseKeyVisTime(strings.TrimSpace(fields[cols[keyVisColSampleTime]]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d: parsing %s", line, keyVisColSampleTime)
+		}
+		var b bucket
+		for _, key := range []struct {
+			name string
+			dest *string
+		}{
+			{keyVisColStartKey, &b.startKey},
+			{keyVisColEndKey, &b.endKey},
+		} {
+			raw, err := hex.DecodeString(strings.TrimSpace(fields[cols[key.name]]))
+			if err != nil {
+				return nil, errors.Wrapf(err, "line %d: parsing %s", line, key.name)
+			}
+			*key.dest = string(raw)
+		}
+		if bytes.Compare([]byte(b.endKey), []byte(b.startKey)) <= 0 {
+			return nil, errors.Newf("line %d: %s must be greater than %s",
+				line, keyVisColEndKey, keyVisColStartKey)
+		}
+		if b.requests, err = strconv.ParseFloat(
+			strings.TrimSpace(fields[cols[keyVisColRequests]]), 64); err != nil {
+			return nil, errors.Wrapf(err, "line %d: parsing %s", line, keyVisColRequests)
+		}
+		if b.requests < 0 {
+			return nil, errors.Newf("line %d: %s must not be negative", line, keyVisColRequests)
+		}
+		ts = ts.UTC()
+		samples[ts] = append(samples[ts], b)
+		boundaries[b.startKey] = struct{}{}
+		boundaries[b.endKey] = struct{}{}
+	}
+	if len(samples) < 2 {
+		return nil, errors.Newf(
+			"at least 2 key visualizer samples are required, found %d", len(samples))
+	}
+	if int64(len(boundaries)-1) > keyspace {
+		return nil, errors.Newf(
+			"keyspace (%d) is smaller than the number of distinct spans (%d)",
+			keyspace, len(boundaries)-1)
+	}
+
+	sortedBoundaries := make([]string, 0, len(boundaries))
+	for boundary := range boundaries {
+		sortedBoundaries = append(sortedBoundaries, boundary)
+	}
+	sort.Strings(sortedBoundaries)
+	keys := make(map[string]int64, len(sortedBoundaries))
+	for i, boundary := range sortedBoundaries {
+		keys[boundary] = int64(i) * keyspace / int64(len(sortedBoundaries)-1)
+	}
+
+	times := make([]time.Time, 0, len(samples))
+	for ts := range samples {
+		times = append(times, ts)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	var trace Trace
+	var prev map[[2]int64]struct{}
+	for i, ts := range times {
+		// The first sample's interval is assumed to be the same as the second's.
+		interval := times[1].Sub(times[0])
+		offset := time.Duration(0)
+		if i > 0 {
+			interval = ts.Sub(times[i-1])
+			offset = times[i-1].Sub(times[0]) + times[1].Sub(times[0])
+		}
+		cur := make(map[[2]int64]struct{}, len(samples[ts]))
+		for _, b := range samples[ts] {
+			span := [2]int64{keys[b.startKey], keys[b.endKey]}
+			trace = append(trace, TraceRecord{
+				Offset:         offset,
+				StartKey:       span[0],
+				EndKey:         span[1],
+				ReadsPerSecond: b.requests / interval.Seconds(),
+			})
+			cur[span] = struct{}{}
+		}
+		// The load on spans which are absent from this sample stops.
+		for span := range prev {
+			if _, ok := cur[span]; !ok {
+				trace = append(trace, TraceRecord{Offset: offset, StartKey: span[0], EndKey: span[1]})
+			}
+		}
+		prev = cur
+	}
+	trace.sort()
+	return trace, nil
+}
+
+func parseKeyVisTime(s string) (time.Time, error) {
+	var err error
+	for _, format := range keyVisTimeFormats {
+		var ts time.Time
+		if ts, err = time.Parse(format, s); err == nil {
+			return ts, nil
+		}
+	}
+	return time.Time{}, err
+}
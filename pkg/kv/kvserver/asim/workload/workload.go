@@ -26,6 +26,9 @@ type LoadEvent struct {
 	WriteSize int64
 	Reads     int64
 	ReadSize  int64
+	// RequestCPU is the CPU time, in nanoseconds, spent evaluating the reads
+	// and writes.
+	RequestCPU int64
 }
 
 // LoadBatch is a sorted list of load events.
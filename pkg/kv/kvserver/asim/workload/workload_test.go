@@ -14,6 +14,7 @@ import (
 	"math"
 	"math/rand"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -162,3 +163,185 @@ func TestRandWorkloadGenerator(t *testing.T) {
 		require.Equal(t, math.Round(tc.readRatio*100), math.Round((float64(stats.reads)/float64(stats.reads+stats.writes))*100))
 	}
 }
+
+func TestParseTrace(t *testing.T) {
+	trace, err := ParseTrace(strings.NewReader(`
+offset,start_key,end_key,reads,writes,read_bytes,write_bytes
+# Records need not be ordered by offset.
+5s,0,100,0,0,0,0
+0s,0,100,10,2,1000,400
+0s,100,102,0,0.5,0,50
+`))
+	require.NoError(t, err)
+	require.Equal(t, Trace{
+		{Offset: 0, StartKey: 0, EndKey: 100, ReadsPerSecond: 10, WritesPerSecond: 2, ReadBytesPerSecond: 1000, WriteBytesPerSecond: 400},
+		{Offset: 0, StartKey: 100, EndKey: 102, WritesPerSecond: 0.5, WriteBytesPerSecond: 50},
+		{Offset: 5 * time.Second, StartKey: 0, EndKey: 100},
+	}, trace)
+
+	// The rate columns are optional and may appear in any order.
+	trace, err = ParseTrace(strings.NewReader("writes,end_key,offset,start_key\n3,20,1m,10\n"))
+	require.NoError(t, err)
+	require.Equal(t, Trace{
+		{Offset: time.Minute, StartKey: 10, EndKey: 20, WritesPerSecond: 3},
+	}, trace)
+
+	for _, tc := range []struct {
+		input  string
+		expErr string
+	}{
+		{input: "", expErr: "trace is missing a header"},
+		{input: "offset,start_key\n", expErr: `trace is missing required column "end_key"`},
+		{input: "offset,start_key,end_key,cpu\n", expErr: `unknown trace column "cpu"`},
+		{input: "offset,start_key,end_key,cpu_nanos\n0s,0,1,100\n", expErr: "line 2: cpu_nanos requires reads or writes"},
+		{input: "offset,start_key,end_key,reads,reads\n", expErr: `duplicate trace column "reads"`},
+		{input: "offset,start_key,end_key\n1x,0,1\n", expErr: "line 2: parsing offset"},
+		{input: "offset,start_key,end_key\n0s,5,5\n", expErr: "line 2: end_key (5) must be greater than start_key (5)"},
+		{input: "offset,start_key,end_key,writes\n0s,0,1,-1\n", expErr: "line 2: writes must not be negative"},
+	} {
+		_, err := ParseTrace(strings.NewReader(tc.input))
+		require.ErrorContains(t, err, tc.expErr)
+	}
+}
+
+// TestTraceGenerator asserts that the load replayed from a trace matches the
+// recorded rates, is spread over the recorded spans and follows changes in the
+// recorded rates over time.
+func TestTraceGenerator(t *testing.T) {
+	trace := Trace{
+		{Offset: 0, StartKey: 0, EndKey: 100, ReadsPerSecond: 10, WritesPerSecond: 2, ReadBytesPerSecond: 1000, WriteBytesPerSecond: 400},
+		{Offset: 0, StartKey: 100, EndKey: 102, WritesPerSecond: 0.5, WriteBytesPerSecond: 50},
+		{Offset: 5 * time.Second, StartKey: 0, EndKey: 100},
+	}
+	start := time.Date(2022, 03, 21, 11, 0, 0, 0, time.UTC)
+	gen := newTraceGenerator(start, trace, DefaultTraceKeysPerSpan)
+
+	// The reads and writes to the first span are spread over its keys, while
+	// the fractional write to the second span is carried over to the next tick.
+	require.Equal(t, LoadBatch{
+		{Key: 0, Reads: 1, ReadSize: 100, Writes: 1, WriteSize: 200},
+		{Key: 10, Reads: 1, ReadSize: 100},
+		{Key: 20, Reads: 1, ReadSize: 100},
+		{Key: 30, Reads: 1, ReadSize: 100},
+		{Key: 40, Reads: 1, ReadSize: 100},
+		{Key: 50, Reads: 1, ReadSize: 100, Writes: 1, WriteSize: 200},
+		{Key: 60, Reads: 1, ReadSize: 100},
+		{Key: 70, Reads: 1, ReadSize: 100},
+		{Key: 80, Reads: 1, ReadSize: 100},
+		{Key: 90, Reads: 1, ReadSize: 100},
+	}, gen.Tick(start.Add(time.Second)))
+
+	var ops []LoadEvent
+	for i := 2; i <= 10; i++ {
+		batch := gen.Tick(start.Add(time.Duration(i) * time.Second))
+		for _, op := range batch {
+			require.Less(t, op.Key, int64(102))
+		}
+		ops = append(ops, batch...)
+	}
+	// The first span's load stops after 5s, whereas the second span's load
+	// continues until the end of the replay.
+	stats := summary(ops, 0)
+	require.Equal(t, 40, stats.reads)
+	require.Equal(t, 8+5, stats.writes)
+	require.Equal(t, 40*100+8*200+5*100, stats.size)
+}
+
+// TestTraceGeneratorCPU asserts that the request CPU recorded in a trace is
+// attributed to the replayed reads and writes, and that the load on a span is
+// spread over at most the given number of keys per tick.
+func TestTraceGeneratorCPU(t *testing.T) {
+	trace, err := ParseTrace(strings.NewReader(`
+offset,start_key,end_key,reads,writes,cpu_nanos
+0s,0,100,6,2,8000
+`))
+	require.NoError(t, err)
+	start := time.Date(2022, 03, 21, 11, 0, 0, 0, time.UTC)
+	gen := newTraceGenerator(start, trace, 2 /* keysPerSpan */)
+	require.Equal(t, LoadBatch{
+		{Key: 0, Reads: 3, Writes: 1, RequestCPU: 4000},
+		{Key: 50, Reads: 3, Writes: 1, RequestCPU: 4000},
+	}, gen.Tick(start.Add(time.Second)))
+	// The keys receiving load rotate every tick.
+	require.Equal(t, LoadBatch{
+		{Key: 1, Reads: 3, Writes: 1, RequestCPU: 4000},
+		{Key: 51, Reads: 3, Writes: 1, RequestCPU: 4000},
+	}, gen.Tick(start.Add(2*time.Second)))
+}
+
+func TestParseTSDump(t *testing.T) {
+	trace, err := ParseTSDump(strings.NewReader(`cr.store.rebalancing.readspersecond,2023-05-10T12:00:00Z,2,300
+cr.store.rebalancing.readspersecond,2023-05-10T12:00:00Z,1,100
+cr.store.rebalancing.writespersecond,2023-05-10T12:00:00Z,1,50
+cr.store.rebalancing.cpunanospersecond,2023-05-10T12:00:00Z,1,1500000
+cr.store.rebalancing.cpunanospersecond,2023-05-10T12:00:00Z,3,200000
+cr.node.sql.select.count,2023-05-10T12:00:00Z,1,7
+cr.store.rebalancing.readbytespersecond,2023-05-10T12:00:10Z,2,6000
+cr.store.rebalancing.readspersecond,2023-05-10T12:00:10Z,2,60
+`), 300 /* keyspace */)
+	require.NoError(t, err)
+	// Each store replays its load against a third of the keyspace. The CPU
+	// time of store 3, which has no reads or writes, is dropped.
+	require.Equal(t, Trace{
+		{Offset: 0, StartKey: 0, EndKey: 100, ReadsPerSecond: 100, WritesPerSecond: 50, RequestCPUPerSecond: 1500000},
+		{Offset: 0, StartKey: 100, EndKey: 200, ReadsPerSecond: 300},
+		{Offset: 0, StartKey: 200, EndKey: 300},
+		{Offset: 10 * time.Second, StartKey: 100, EndKey: 200, ReadsPerSecond: 60, ReadBytesPerSecond: 6000},
+	}, trace)
+
+	for _, tc := range []struct {
+		input  string
+		expErr string
+	}{
+		{input: "", expErr: "tsdump contains no store rebalancing metrics"},
+		{input: "cr.node.sql.select.count,2023-05-10T12:00:00Z,1,7\n", expErr: "tsdump contains no store rebalancing metrics"},
+		{input: "cr.store.rebalancing.readspersecond,yesterday,1,7\n", expErr: "line 1: parsing timestamp"},
+		{input: "cr.store.rebalancing.readspersecond,2023-05-10T12:00:00Z,s1,7\n", expErr: "line 1: parsing store"},
+		{input: "cr.store.rebalancing.readspersecond,2023-05-10T12:00:00Z,1,-7\n", expErr: "line 1: cr.store.rebalancing.readspersecond must not be negative"},
+	} {
+		_, err := ParseTSDump(strings.NewReader(tc.input), 300 /* keyspace */)
+		require.ErrorContains(t, err, tc.expErr)
+	}
+	_, err = ParseTSDump(strings.NewReader(
+		"cr.store.rebalancing.readspersecond,2023-05-10T12:00:00Z,1,7\n"+
+			"cr.store.rebalancing.readspersecond,2023-05-10T12:00:00Z,2,7\n"), 1 /* keyspace */)
+	require.ErrorContains(t, err, "keyspace (1) is smaller than the number of stores (2)")
+}
+
+func TestParseKeyVisSamples(t *testing.T) {
+	trace, err := ParseKeyVisSamples(strings.NewReader(`sample_time,start_key,end_key,requests
+2023-05-10 12:00:00+00,0a,0b,600
+2023-05-10 12:00:00+00,0b,0c,60
+2023-05-10 12:01:00+00,0a,0b,1200
+2023-05-10 12:03:00+00,0a,0c,2400
+`), 100 /* keyspace */)
+	require.NoError(t, err)
+	// The boundaries 0a, 0b and 0c are mapped to the keys 0, 50 and 100. The
+	// first sample's interval is assumed to be the same as the second's, 1m,
+	// while the third sample's interval is 2m. The spans of the earlier samples
+	// stop receiving load once they are absent from a sample.
+	require.Equal(t, Trace{
+		{Offset: 0, StartKey: 0, EndKey: 50, ReadsPerSecond: 10},
+		{Offset: 0, StartKey: 50, EndKey: 100, ReadsPerSecond: 1},
+		{Offset: time.Minute, StartKey: 0, EndKey: 50, ReadsPerSecond: 20},
+		{Offset: time.Minute, StartKey: 50, EndKey: 100},
+		{Offset: 2 * time.Minute, StartKey: 0, EndKey: 50},
+		{Offset: 2 * time.Minute, StartKey: 0, EndKey: 100, ReadsPerSecond: 20},
+	}, trace)
+
+	for _, tc := range []struct {
+		input  string
+		expErr string
+	}{
+		{input: "", expErr: "key visualizer samples are missing a header"},
+		{input: "sample_time,start_key,end_key\n", expErr: `missing required column "requests"`},
+		{input: "sample_time,start_key,end_key,requests\n2023-05-10 12:00:00,0a,0b,1\n", expErr: "at least 2 key visualizer samples are required, found 1"},
+		{input: "sample_time,start_key,end_key,requests\nnow,0a,0b,1\n", expErr: "line 2: parsing sample_time"},
+		{input: "sample_time,start_key,end_key,requests\n2023-05-10 12:00:00,zz,0b,1\n", expErr: "line 2: parsing start_key"},
+		{input: "sample_time,start_key,end_key,requests\n2023-05-10 12:00:00,0b,0a,1\n", expErr: "line 2: end_key must be greater than start_key"},
+		{input: "sample_time,start_key,end_key,requests\n2023-05-10 12:00:00,0a,0b,-1\n", expErr: "line 2: requests must not be negative"},
+	} {
+		_, err := ParseKeyVisSamples(strings.NewReader(tc.input), 100 /* keyspace */)
+		require.ErrorContains(t, err, tc.expErr)
+	}
+}
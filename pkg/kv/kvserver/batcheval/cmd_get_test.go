@@ -15,10 +15,14 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
 	"github.com/stretchr/testify/require"
 )
@@ -109,3 +113,55 @@ func TestGetResumeSpan(t *testing.T) {
 		})
 	}
 }
+
+// TestGetReplicatedLocksVersionGate tests that a GetRequest which acquires a
+// replicated lock is rejected until the cluster version that supports
+// replicated locks is active.
+func TestGetReplicatedLocksVersionGate(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	ctx := context.Background()
+	key := roachpb.Key("a")
+
+	db := storage.NewDefaultInMemForTesting()
+	defer db.Close()
+	require.NoError(t, storage.MVCCPut(
+		ctx, db, nil /* ms */, key, hlc.Timestamp{WallTime: 1}, hlc.ClockTimestamp{},
+		roachpb.MakeValueFromString("val"), nil, /* txn */
+	))
+
+	txn := roachpb.MakeTransaction(
+		"test", key, isolation.Serializable, roachpb.NormalUserPriority,
+		hlc.Timestamp{WallTime: 2}, 0 /* maxOffsetNs */, 1, /* coordinatorNodeID */
+	)
+	for _, active := range []bool{false, true} {
+		t.Run(fmt.Sprintf("active=%t", active), func(t *testing.T) {
+			version := clusterversion.ByKey(clusterversion.V23_2_ReplicatedLocks - 1)
+			if active {
+				version = clusterversion.ByKey(clusterversion.V23_2_ReplicatedLocks)
+			}
+			settings := cluster.MakeTestingClusterSettingsWithVersions(
+				clusterversion.ByKey(clusterversion.BinaryVersionKey), version, true, /* initializeVersion */
+			)
+
+			batch := db.NewBatch()
+			defer batch.Close()
+			resp := kvpb.GetResponse{}
+			res, err := Get(ctx, batch, CommandArgs{
+				EvalCtx: (&MockEvalCtx{ClusterSettings: settings}).EvalContext(),
+				Header:  kvpb.Header{Txn: &txn, Timestamp: txn.ReadTimestamp},
+				Args: &kvpb.GetRequest{
+					RequestHeader:        kvpb.RequestHeader{Key: key},
+					KeyLocking:           lock.Exclusive,
+					KeyLockingReplicated: true,
+				},
+			}, &resp)
+			if !active {
+				require.ErrorContains(t, err, "replicated Exclusive locks are not supported")
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, res.Local.AcquiredLocks, 1)
+			require.Equal(t, lock.Replicated, res.Local.AcquiredLocks[0].Durability)
+		})
+	}
+}
@@ -35,6 +35,10 @@ func Scan(
 	h := cArgs.Header
 	reply := resp.(*kvpb.ScanResponse)
 
+	if err := checkReplicatedLocksSupported(ctx, cArgs, args); err != nil {
+		return result.Result{}, err
+	}
+
 	var res result.Result
 	var scanRes storage.MVCCScanResult
 	var err error
@@ -110,7 +114,7 @@ func Scan(
 	}
 
 	if args.KeyLocking != lock.None && h.Txn != nil {
-		err = acquireUnreplicatedLocksOnKeys(&res, h.Txn, args.ScanFormat, &scanRes)
+		err = acquireLocksOnKeys(ctx, reader, &res, h.Txn, args.KeyLocking, args.KeyLockingDurability(), args.ScanFormat, &scanRes)
 		if err != nil {
 			return result.Result{}, err
 		}
@@ -57,10 +57,20 @@ func DefaultDeclareIsolatedKeys(
 	maxOffset time.Duration,
 ) {
 	access := spanset.SpanReadWrite
-	// TODO(arul): pass in the correct lock strength here based on the request.
 	str := lock.Intent
 	timestamp := header.Timestamp
-	if kvpb.IsReadOnly(req) && !kvpb.IsLocking(req) {
+	if kvpb.IsReadOnly(req) && kvpb.IsLocking(req) {
+		str = req.(kvpb.LockingReadRequest).KeyLockingStrength()
+		if str == lock.Shared {
+			// Shared locking reads are compatible with each other and with
+			// non-locking reads, so they acquire read latches. However, they must
+			// conflict with writes at any timestamp, because the locks they acquire
+			// conflict with writes irrespective of the writer's timestamp. Declaring
+			// the read latch at the maximum timestamp ensures this.
+			access = spanset.SpanReadOnly
+			timestamp = hlc.MaxTimestamp
+		}
+	} else if kvpb.IsReadOnly(req) {
 		access = spanset.SpanReadOnly
 		str = lock.None
 
@@ -13,6 +13,7 @@ package batcheval
 import (
 	"context"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/batcheval/result"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
@@ -104,11 +105,35 @@ func readProvisionalVal(
 
 }
 
-// acquireUnreplicatedLocksOnKeys adds an unreplicated lock acquisition by the
-// transaction to the provided result.Result for each key in the scan result.
-func acquireUnreplicatedLocksOnKeys(
+// checkReplicatedLocksSupported returns an error if the locking read request
+// acquires replicated locks before the cluster version that supports them is
+// active. Nodes of the previous version don't know about the replicated Shared
+// and Exclusive locks in the lock table keyspace, so they must not be written
+// until all nodes understand them.
+func checkReplicatedLocksSupported(
+	ctx context.Context, cArgs CommandArgs, req kvpb.LockingReadRequest,
+) error {
+	if req.KeyLockingStrength() == lock.None || req.KeyLockingDurability() != lock.Replicated {
+		return nil
+	}
+	if !cArgs.EvalCtx.ClusterSettings().Version.IsActive(ctx, clusterversion.V23_2_ReplicatedLocks) {
+		return errors.Errorf(
+			"replicated %s locks are not supported until the cluster version is finalized",
+			req.KeyLockingStrength())
+	}
+	return nil
+}
+
+// acquireLocksOnKeys acquires locks with the given strength and durability
+// by the transaction on each key in the scan result, adding a lock acquisition
+// for each key to the provided result.Result.
+func acquireLocksOnKeys(
+	ctx context.Context,
+	readWriter storage.Reader,
 	res *result.Result,
 	txn *roachpb.Transaction,
+	str lock.Strength,
+	dur lock.Durability,
 	scanFmt kvpb.ScanFormat,
 	scanRes *storage.MVCCScanResult,
 ) error {
@@ -117,24 +142,72 @@ func acquireUnreplicatedLocksOnKeys(
 	case kvpb.BATCH_RESPONSE:
 		var i int
 		return storage.MVCCScanDecodeKeyValues(scanRes.KVData, func(key storage.MVCCKey, _ []byte) error {
-			res.Local.AcquiredLocks[i] = roachpb.MakeLockAcquisition(txn, copyKey(key.Key), lock.Unreplicated)
+			acq, err := acquireLockOnKey(ctx, readWriter, txn, str, dur, copyKey(key.Key))
+			if err != nil {
+				return err
+			}
+			res.Local.AcquiredLocks[i] = acq
 			i++
 			return nil
 		})
 	case kvpb.KEY_VALUES:
 		for i, row := range scanRes.KVs {
-			res.Local.AcquiredLocks[i] = roachpb.MakeLockAcquisition(txn, copyKey(row.Key), lock.Unreplicated)
+			acq, err := acquireLockOnKey(ctx, readWriter, txn, str, dur, copyKey(row.Key))
+			if err != nil {
+				return err
+			}
+			res.Local.AcquiredLocks[i] = acq
 		}
 		return nil
 	case kvpb.COL_BATCH_RESPONSE:
-		return errors.AssertionFailedf("unexpectedly acquiring unreplicated locks with COL_BATCH_RESPONSE scan format")
+		return errors.AssertionFailedf("unexpectedly acquiring locks with COL_BATCH_RESPONSE scan format")
 	default:
 		panic("unexpected scanFormat")
 	}
 }
 
+// acquireLockOnKey acquires a lock with the given strength and durability by
+// the transaction on the key, returning the corresponding lock acquisition.
+// Unreplicated locks are only acquired in the in-memory lock table, which does
+// not remember uncontended replicated locks, so the replicated lock table
+// keyspace is checked for conflicting replicated locks before acquiring them.
+// Replicated locks are written to the replicated lock table keyspace, which
+// requires the request to be evaluated on the write path with a
+// storage.ReadWriter.
+//
+// Conflicting replicated locks are returned in a WriteIntentError, which leads
+// the request to add them to the in-memory lock table and wait for them to be
+// released.
+func acquireLockOnKey(
+	ctx context.Context,
+	readWriter storage.Reader,
+	txn *roachpb.Transaction,
+	str lock.Strength,
+	dur lock.Durability,
+	key roachpb.Key,
+) (roachpb.LockAcquisition, error) {
+	switch dur {
+	case lock.Unreplicated:
+		if err := storage.MVCCCheckForAcquireLock(ctx, readWriter, txn, str, key); err != nil {
+			return roachpb.LockAcquisition{}, err
+		}
+	case lock.Replicated:
+		rw, ok := readWriter.(storage.ReadWriter)
+		if !ok {
+			return roachpb.LockAcquisition{}, errors.AssertionFailedf(
+				"expected storage.ReadWriter for replicated lock acquisition, found %T", readWriter)
+		}
+		if err := storage.MVCCAcquireLock(ctx, rw, txn, str, key); err != nil {
+			return roachpb.LockAcquisition{}, err
+		}
+	default:
+		panic("unexpected lock durability")
+	}
+	return roachpb.MakeLockAcquisition(txn, key, dur, str), nil
+}
+
 // copyKey copies the provided roachpb.Key into a new byte slice, returning the
-// copy. It is used in acquireUnreplicatedLocksOnKeys for two reasons:
+// copy. It is used in acquireLocksOnKeys for two reasons:
 //  1. the keys in an MVCCScanResult, regardless of the scan format used, point
 //     to a small number of large, contiguous byte slices. These "MVCCScan
 //     batches" contain keys and their associated values in the same backing
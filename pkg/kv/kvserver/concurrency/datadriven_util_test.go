@@ -167,12 +167,46 @@ func scanSingleRequest(
 		}
 		return enginepb.TxnSeq(n)
 	}
+	maybeGetStr := func() lock.Strength {
+		s, ok := fields["str"]
+		if !ok {
+			return lock.None
+		}
+		switch s {
+		case "shared":
+			return lock.Shared
+		case "update":
+			return lock.Update
+		case "exclusive":
+			return lock.Exclusive
+		default:
+			d.Fatalf(t, "unknown locking strength: %s", s)
+			return 0
+		}
+	}
+	maybeGetReplicated := func() bool {
+		s, ok := fields["dur"]
+		if !ok {
+			return false
+		}
+		switch s {
+		case "r":
+			return true
+		case "u":
+			return false
+		default:
+			d.Fatalf(t, "unknown lock durability: %s", s)
+			return false
+		}
+	}
 
 	switch cmd {
 	case "get":
 		var r kvpb.GetRequest
 		r.Sequence = maybeGetSeq()
 		r.Key = roachpb.Key(mustGetField("key"))
+		r.KeyLocking = maybeGetStr()
+		r.KeyLockingReplicated = maybeGetReplicated()
 		return &r
 
 	case "scan":
@@ -182,6 +216,8 @@ func scanSingleRequest(
 		if v, ok := fields["endkey"]; ok {
 			r.EndKey = roachpb.Key(v)
 		}
+		r.KeyLocking = maybeGetStr()
+		r.KeyLockingReplicated = maybeGetReplicated()
 		return &r
 
 	case "put":
@@ -602,7 +602,7 @@ func (g *lockTableGuardImpl) IsKeyLockedByConflictingTxn(
 		// The lock is empty but has not yet been deleted.
 		return false, nil
 	}
-	if !l.holder.locked {
+	if !l.isHeld() {
 		// Key reserved.
 		if strength == lock.None {
 			// Non-locking reads only care about locks, not reservations.
@@ -616,16 +616,11 @@ func (g *lockTableGuardImpl) IsKeyLockedByConflictingTxn(
 		return true, nil
 	}
 	// Key locked.
-	txn, ts := l.getLockHolder()
+	txn, _ := l.conflictingHolder(g, strength)
 	if txn == nil {
-		panic("non-empty lockState with nil lock holder and nil reservation")
-	}
-	if strength == lock.None && g.ts.Less(ts) {
-		// Non-locking read below lock's timestamp.
-		return false, nil
-	}
-	if g.isSameTxn(txn) {
-		// Already locked by this txn.
+		// Already locked by this txn, locked by other transactions with strengths
+		// that are compatible with the desired strength, or a non-locking read
+		// below the lock's timestamp.
 		return false, nil
 	}
 	// "If the key is locked, the lock holder is also returned."
@@ -759,7 +754,8 @@ func (g *lockTableGuardImpl) findNextLockAfter(notify bool) {
 // non-transactional requests.
 type queuedGuard struct {
 	guard  *lockTableGuardImpl
-	active bool // protected by lockState.mu
+	str    lock.Strength // the strength at which the guard is trying to lock
+	active bool          // protected by lockState.mu
 }
 
 // Information about a lock holder.
@@ -781,12 +777,94 @@ type lockHolderInfo struct {
 
 	// The timestamp at which the lock is held.
 	ts hlc.Timestamp
+
+	// replicated is set for the shared lock holders which hold the lock with the
+	// Replicated durability. The durability of the other holders is implied by
+	// their index in lockHolderState.holder.
+	replicated bool
 }
 
 func (lh *lockHolderInfo) isEmpty() bool {
 	return lh.txn == nil && lh.seqs == nil && lh.ts.IsEmpty()
 }
 
+// sharedDurability returns the durability with which a shared lock holder
+// holds the lock.
+func (lh *lockHolderInfo) sharedDurability() lock.Durability {
+	if lh.replicated {
+		return lock.Replicated
+	}
+	return lock.Unreplicated
+}
+
+// acquire records the acquisition of the lock by txn at timestamp ts.
+func (lh *lockHolderInfo) acquire(txn *enginepb.TxnMeta, ts hlc.Timestamp) {
+	seqs := lh.seqs
+	if lh.txn != nil && lh.txn.Epoch < txn.Epoch {
+		// Clear the sequences for the older epoch.
+		seqs = seqs[:0]
+	}
+	if len(seqs) > 0 && seqs[len(seqs)-1] >= txn.Sequence {
+		// Idempotent lock acquisition. In this case, we simply ignore the lock
+		// acquisition as long as it corresponds to an existing sequence number.
+		// If the sequence number is not being tracked yet, insert it into the
+		// sequence history. The validity of such a lock re-acquisition should
+		// have already been determined at the MVCC level.
+		if i := sort.Search(len(seqs), func(i int) bool {
+			return seqs[i] >= txn.Sequence
+		}); i == len(seqs) {
+			panic("lockTable bug - search value <= last element")
+		} else if seqs[i] != txn.Sequence {
+			seqs = append(seqs, 0)
+			copy(seqs[i+1:], seqs[i:])
+			seqs[i] = txn.Sequence
+			lh.seqs = seqs
+		}
+		return
+	}
+	lh.txn = txn
+	// Forward the lock's timestamp instead of assigning to it blindly.
+	// While lock acquisition uses monotonically increasing timestamps
+	// from the perspective of the transaction's coordinator, this does
+	// not guarantee that a lock will never be acquired at a higher
+	// epoch and/or sequence number but with a lower timestamp when in
+	// the presence of transaction pushes. Consider the following
+	// sequence of events:
+	//
+	//  - txn A acquires lock at sequence 1, ts 10
+	//  - txn B pushes txn A to ts 20
+	//  - txn B updates lock to ts 20
+	//  - txn A's coordinator does not immediately learn of the push
+	//  - txn A re-acquires lock at sequence 2, ts 15
+	//
+	// A lock's timestamp at a given durability level is not allowed to
+	// regress, so by forwarding its timestamp during the second acquisition
+	// instead if assigning to it blindly, it remains at 20.
+	//
+	// However, a lock's timestamp as reported by getLockHolder can regress
+	// if it is acquired at a lower timestamp and a different durability
+	// than it was previously held with. This is necessary to support
+	// because the hard constraint which we must uphold here that the
+	// lockHolderInfo for a replicated lock cannot diverge from the
+	// replicated state machine in such a way that its timestamp in the
+	// lockTable exceeds that in the replicated keyspace. If this invariant
+	// were to be violated, we'd risk infinite lock-discovery loops for
+	// requests that conflict with the lock as is written in the replicated
+	// state machine but not as is reflected in the lockTable.
+	//
+	// Lock timestamp regressions are safe from the perspective of other
+	// transactions because the request which re-acquired the lock at the
+	// lower timestamp must have been holding a write latch at or below the
+	// new lock's timestamp. This means that no conflicting requests could
+	// be evaluating concurrently. Instead, all will need to re-scan the
+	// lockTable once they acquire latches and will notice the reduced
+	// timestamp at that point, which may cause them to conflict with the
+	// lock even if they had not conflicted before. In a sense, it is no
+	// different than the first time a lock is added to the lockTable.
+	lh.ts.Forward(ts)
+	lh.seqs = append(seqs, txn.Sequence)
+}
+
 // Per lock state in lockTableImpl.
 //
 // NOTE: we can't easily pool lockState objects without some form of reference
@@ -805,23 +883,31 @@ type lockState struct {
 	mu syncutil.Mutex // Protects everything below.
 
 	// Invariant summary (see detailed comments below):
-	// - both holder.locked and waitQ.reservation != nil cannot be true.
+	// - both isHeld() and waitQ.reservation != nil cannot be true.
 	// - if holder.locked and multiple holderInfos have txn != nil: all the
 	//   txns must have the same txn.ID.
-	// - !holder.locked => waitingReaders.Len() == 0. That is, readers wait
+	// - if holder.locked and sharedHolders is non-empty: holder.strength is
+	//   lock.Update, and none of the sharedHolders is the holder's txn.
+	// - !isHeld() => waitingReaders.Len() == 0. That is, readers wait
 	//   only if the lock is held. They do not wait for a reservation.
 	// - If reservation != nil, that request is not in queuedWriters.
 
-	// Information about whether the lock is held and the holder. We track
-	// information for each durability level separately since a transaction can
-	// go through multiple epochs and TxnSeq and may acquire the same lock in
-	// replicated and unreplicated mode at different stages.
+	// Information about whether the lock is held with a strength of Update,
+	// Exclusive or Intent, and the holder. At most one transaction can hold the
+	// lock with these strengths. We track information for each durability level
+	// separately since a transaction can go through multiple epochs and TxnSeq
+	// and may acquire the same lock in replicated and unreplicated mode at
+	// different stages.
 	holder struct {
 		locked bool
-		// Lock strength is always lock.Intent.
-		holder [lock.MaxDurability + 1]lockHolderInfo
+		// The highest strength with which the holder has acquired the lock. It is
+		// lock.Intent for replicated locks.
+		strength lock.Strength
+		holder   [lock.MaxDurability + 1]lockHolderInfo
 
 		// The start time of the lockholder being marked as held in the lock table.
+		// It is also used for shared holders, and is reset once neither holder
+		// nor sharedHolders hold the lock.
 		// NB: In the case of a replicated lock that is held by a transaction, if
 		// there is no wait-queue, the lock is not tracked by the in-memory lock
 		// table; thus for uncontended replicated locks, the startTime may not
@@ -830,6 +916,15 @@ type lockState struct {
 		startTime time.Time
 	}
 
+	// The transactions, other than the holder above, which hold the lock with
+	// Shared strength. Shared locks are compatible with each other and with an
+	// Update lock, so any number of transactions can hold them at the same time.
+	// Replicated Shared locks are only tracked once they are contended, like
+	// other replicated locks, and can be forgotten at any time, since
+	// conflicting requests rediscover them during evaluation. Ordered by
+	// acquisition.
+	sharedHolders []lockHolderInfo
+
 	// Information about the requests waiting on the lock.
 	lockWaitQueue
 
@@ -848,12 +943,11 @@ type lockWaitQueue struct {
 	// A not-held lock can be "reserved". A reservation is just a claim that
 	// prevents multiple requests from racing when the lock is released. A
 	// reservation by req2 can be broken by req1 is req1 has a smaller seqNum
-	// than req2. Only requests that specify SpanReadWrite for a key can make
-	// reservations. This means a reservation can only be made when the lock is
-	// not held, since the reservation (which can acquire an Exclusive lock) and
-	// the lock holder (which is an Exclusive lock) conflict.
+	// than req2. Only locking requests can make reservations. A reservation
+	// can only be made when the lock is not held; requests that are compatible
+	// with the lock holders do not need one.
 	//
-	// Read reservations are not permitted due to the complexities discussed in
+	// Non-locking read reservations are not permitted due to the complexities discussed in
 	// the review for #43740. Additionally, reads do not queue for their turn at
 	// all -- they are held in the waitingReaders list while the lock is held
 	// and removed when the lock is not released, so they race with
@@ -871,9 +965,8 @@ type lockWaitQueue struct {
 	// req1 and req2 both want to write at one key and so get ordered by their
 	// seqnums but at another key req2 wants to read and req1 wants to write and
 	// since req2 does not wait in the queue it acquires a read reservation
-	// before req1. See the discussion at the end of this comment section on how
-	// the behavior will extend when we start supporting Shared and Update
-	// locks.
+	// before req1. See the discussion at the end of this comment section on
+	// Shared and Update locks.
 	//
 	// Non-transactional requests can do both reads and writes but cannot be
 	// depended on since they don't have a transaction that can be pushed.
@@ -935,51 +1028,49 @@ type lockWaitQueue struct {
 	//   This is a deadlock caused by the lock table unless req2 partially
 	//   breaks the reservation at A.
 	//
-	// Extension for Shared and Update locks:
+	// Shared and Update locks:
 	// There are 3 aspects to consider: holders; reservers; the dependencies
 	// that need to be captured when waiting.
 	//
-	// - Holders: only shared locks are compatible with themselves, so there can
-	//   be one of (a) no holder (b) multiple shared lock holders, (c) one
-	//   exclusive holder, (d) one upgrade holder. Non-locking reads will
-	//   wait in waitingReaders for only an incompatible exclusive holder.
-	//
-	// - Reservers: This follows the same pattern as holders. Non-locking reads
-	//   do not wait on reservers.
+	// - Holders: only shared locks are compatible with themselves, and update
+	//   locks are compatible with shared locks. So there can be (a) no holder,
+	//   (b) multiple shared lock holders, (c) one update holder along with
+	//   multiple shared lock holders, or (d) one exclusive (or intent) holder.
+	//   Non-locking reads wait in waitingReaders for only an incompatible
+	//   exclusive (or intent) holder.
 	//
-	// - Queueing and dependencies: All potential lockers and non-transactional
-	//   writers will wait in the same queue. A sequence of consecutive requests
-	//   that have the potential to acquire a shared lock will jointly reserve
-	//   that shared lock. Such requests cannot jump ahead of requests with a
-	//   lower seqnum just because there is currently a shared lock reservation
-	//   (this can cause lockTable induced deadlocks). Such joint reservations
-	//   can be partially broken by a waiter desiring an exclusive or upgrade
-	//   lock. Like the current code, non-transactional writes will wait for
-	//   reservations that have a lower sequence num, but not make their own
-	//   reservation. Additionally, they can partially break joint reservations.
+	// - Reservers: a reservation is made by any locking request, regardless of
+	//   the strength it is trying to lock with, and there is at most one
+	//   reservation. Joint reservations by multiple requests that are trying to
+	//   acquire shared locks are not supported; a request that is trying to
+	//   acquire a shared lock waits for a reservation even if the reservation
+	//   is held by a request that is also trying to acquire a shared lock. Such
+	//   waiting is brief, since the reservation holder will proceed to
+	//   evaluation and either acquire the lock, after which the compatible
+	//   waiters are released, or drop its reservation. Non-locking reads do not
+	//   wait on reservers.
 	//
-	//   Reservations that are (partially or fully) broken cause requests to
-	//   reenter the queue as inactive waiters. This is no different than the
-	//   current behavior. Each request can specify the same key in spans for
-	//   ReadOnly, ReadShared, ReadUpgrade, ReadWrite. The spans will be
-	//   iterated over in decreasing order of strength, to only wait at a lock
-	//   at the highest strength (this is similar to the current behavior using
-	//   accessDecreasingStrength).
+	// - Queueing and dependencies: all locking requests and non-transactional
+	//   writers wait in queuedWriters, which records the strength each request
+	//   is trying to lock with. A locking request that is compatible with all
+	//   the lock holders does not wait, even if there are conflicting requests
+	//   in the queue. This allows shared lockers to proceed concurrently, at
+	//   the cost of fairness towards queued requests that are trying to acquire
+	//   stronger locks. When holders release the lock, queued requests that are
+	//   compatible with the remaining holders stop waiting; when there are no
+	//   remaining holders the first queued transactional request acquires the
+	//   reservation, as before.
 	//
-	//   For dependencies, a waiter desiring an exclusive or upgrade lock always
-	//   conflicts with the holder(s) or reserver(s) so that is the dependency
-	//   that will be captured. A waiter desiring a shared lock may encounter a
-	//   situation where it does not conflict with the holder(s) or reserver(s)
-	//   since those are also shared lockers. In that case it will depend on the
-	//   first waiter since that waiter must be desiring a lock that is
-	//   incompatible with a shared lock.
-	//
-	// TODO(arul): The paragraph above still talks about declaring access on keys
-	// in terms of SpanAccess instead of lock strength. Switch over this verbiage
-	// to reference locking/non-locking requests once we support multiple lock
-	// strengths and add support for joint reservations.
+	//   A waiter always waits for a holder that it conflicts with, or for the
+	//   reservation holder, so that is the dependency that is captured. A
+	//   request that is trying to acquire an exclusive lock on a key which is
+	//   held by multiple shared lock holders waits for (and pushes) them one at
+	//   a time.
 
 	reservation *lockTableGuardImpl
+	// The strength with which the reservation holder is trying to lock. Only
+	// meaningful if reservation != nil.
+	reservationStr lock.Strength
 
 	// TODO(sbhola): There are a number of places where we iterate over these
 	// lists looking for something, as described below. If some of these turn
@@ -1055,11 +1146,22 @@ func (l *lockState) safeFormat(sb *redact.StringBuilder, finalizedTxnCache *txnC
 		sb.Printf("txn: %v, ts: %v, seq: %v\n",
 			redact.Safe(txn.ID), redact.Safe(ts), redact.Safe(txn.Sequence))
 	}
-	writeHolderInfo := func(sb *redact.StringBuilder, txn *enginepb.TxnMeta, ts hlc.Timestamp) {
-		sb.Printf("  holder: txn: %v, ts: %v, info: ", redact.Safe(txn.ID), redact.Safe(ts))
+	writeHolderInfo := func(
+		sb *redact.StringBuilder,
+		txn *enginepb.TxnMeta,
+		ts hlc.Timestamp,
+		str lock.Strength,
+		holders *[lock.MaxDurability + 1]lockHolderInfo,
+	) {
+		sb.Printf("  holder: txn: %v, ts: %v, ", redact.Safe(txn.ID), redact.Safe(ts))
+		if str != lock.Intent {
+			// Intents are the common case, so their strength is left implicit.
+			sb.Printf("str: %s, ", redact.Safe(str))
+		}
+		sb.SafeString("info: ")
 		first := true
-		for i := range l.holder.holder {
-			h := &l.holder.holder[i]
+		for i := range holders {
+			h := &holders[i]
 			if h.txn == nil {
 				continue
 			}
@@ -1093,12 +1195,23 @@ func (l *lockState) safeFormat(sb *redact.StringBuilder, finalizedTxnCache *txnC
 		}
 		sb.SafeString("\n")
 	}
-	txn, ts := l.getLockHolder()
-	if txn == nil {
+	if !l.isHeld() {
 		sb.Printf("  res: req: %d, ", l.reservation.seqNum)
+		if l.reservationStr != lock.Intent {
+			sb.Printf("str: %s, ", redact.Safe(l.reservationStr))
+		}
 		writeResInfo(sb, l.reservation.txn, l.reservation.ts)
 	} else {
-		writeHolderInfo(sb, txn, ts)
+		if l.holder.locked {
+			txn, ts := l.getLockHolder()
+			writeHolderInfo(sb, txn, ts, l.holder.strength, &l.holder.holder)
+		}
+		for i := range l.sharedHolders {
+			h := &l.sharedHolders[i]
+			var holders [lock.MaxDurability + 1]lockHolderInfo
+			holders[h.sharedDurability()] = *h
+			writeHolderInfo(sb, h.txn, h.ts, lock.Shared, &holders)
+		}
 	}
 	// TODO(sumeer): Add an optional `description string` field to Request and
 	// lockTableGuardImpl that tests can set to avoid relying on the seqNum to
@@ -1120,7 +1233,11 @@ func (l *lockState) safeFormat(sb *redact.StringBuilder, finalizedTxnCache *txnC
 		for e := l.queuedWriters.Front(); e != nil; e = e.Next() {
 			qg := e.Value.(*queuedGuard)
 			g := qg.guard
-			sb.Printf("    active: %t req: %d, txn: ", redact.Safe(qg.active), redact.Safe(qg.guard.seqNum))
+			sb.Printf("    active: %t req: %d, ", redact.Safe(qg.active), redact.Safe(qg.guard.seqNum))
+			if qg.str != lock.Intent {
+				sb.Printf("str: %s, ", redact.Safe(qg.str))
+			}
+			sb.SafeString("txn: ")
 			if g.txn == nil {
 				sb.SafeString("none\n")
 			} else {
@@ -1170,6 +1287,10 @@ func (l *lockState) lockStateInfo(now time.Time) roachpb.LockStateInfo {
 		} else if l.holder.holder[lock.Unreplicated].txn != nil {
 			txnHolder = l.holder.holder[lock.Unreplicated].txn
 		}
+	} else if len(l.sharedHolders) > 0 {
+		// Only held with Shared strength. Report the first shared lock holder.
+		durability = l.sharedHolders[0].sharedDurability()
+		txnHolder = l.sharedHolders[0].txn
 	}
 
 	waiterCount := l.waitingReaders.Len() + l.queuedWriters.Len()
@@ -1185,7 +1306,7 @@ func (l *lockState) lockStateInfo(now time.Time) roachpb.LockStateInfo {
 		lockWaiters = append(lockWaiters, lock.Waiter{
 			WaitingTxn:   l.reservation.txn,
 			ActiveWaiter: false,
-			Strength:     lock.Exclusive,
+			Strength:     waiterStrength(l.reservationStr),
 			WaitDuration: now.Sub(l.reservation.mu.curLockWaitStart),
 		})
 		l.reservation.mu.Unlock()
@@ -1212,7 +1333,7 @@ func (l *lockState) lockStateInfo(now time.Time) roachpb.LockStateInfo {
 		lockWaiters = append(lockWaiters, lock.Waiter{
 			WaitingTxn:   writerGuard.txn,
 			ActiveWaiter: qg.active,
-			Strength:     lock.Exclusive,
+			Strength:     waiterStrength(qg.str),
 			WaitDuration: now.Sub(writerGuard.mu.curLockWaitStart),
 		})
 		writerGuard.mu.Unlock()
@@ -1227,6 +1348,16 @@ func (l *lockState) lockStateInfo(now time.Time) roachpb.LockStateInfo {
 	}
 }
 
+// waiterStrength returns the lock strength that is reported for a request that
+// is queued to lock with the given strength. Writers are reported as waiting
+// for an Exclusive lock.
+func waiterStrength(str lock.Strength) lock.Strength {
+	if str == lock.Intent {
+		return lock.Exclusive
+	}
+	return str
+}
+
 // addToMetrics adds the receiver's state to the provided metrics struct.
 func (l *lockState) addToMetrics(m *LockTableMetrics, now time.Time) {
 	l.mu.Lock()
@@ -1237,7 +1368,7 @@ func (l *lockState) addToMetrics(m *LockTableMetrics, now time.Time) {
 	totalWaitDuration, maxWaitDuration := l.totalAndMaxWaitDuration(now)
 	lm := LockMetrics{
 		Key:                  l.key,
-		Held:                 l.holder.locked,
+		Held:                 l.isHeld(),
 		HoldDurationNanos:    l.lockHeldDuration(now).Nanoseconds(),
 		WaitingReaders:       int64(l.waitingReaders.Len()),
 		WaitingWriters:       int64(l.queuedWriters.Len()),
@@ -1255,20 +1386,28 @@ func (l *lockState) tryBreakReservation(seqNum uint64) bool {
 	if l.reservation.seqNum > seqNum {
 		qg := &queuedGuard{
 			guard:  l.reservation,
+			str:    l.reservationStr,
 			active: false,
 		}
 		l.queuedWriters.PushFront(qg)
 		l.reservation = nil
+		l.reservationStr = lock.None
 		return true
 	}
 	return false
 }
 
-// Informs active waiters about reservation or lock holder. The reservation
-// may have changed so this needs to fix any inconsistencies wrt waitSelf and
-// waitForDistinguished states.
+// Informs active waiters about reservation or lock holders. The reservation
+// or the lock holders may have changed so this needs to fix any
+// inconsistencies wrt waitSelf and waitForDistinguished states. If the lock is
+// held, waiters that no longer conflict with any of the lock holders stop
+// waiting.
 // REQUIRES: l.mu is locked.
 func (l *lockState) informActiveWaiters() {
+	held := l.isHeld()
+	if held {
+		l.releaseNonConflictingWaiters()
+	}
 	waitForState := waitingState{
 		kind:          waitFor,
 		key:           l.key,
@@ -1276,8 +1415,7 @@ func (l *lockState) informActiveWaiters() {
 		queuedReaders: l.waitingReaders.Len(),
 	}
 	findDistinguished := l.distinguishedWaiter == nil
-	if lockHolderTxn, _ := l.getLockHolder(); lockHolderTxn != nil {
-		waitForState.txn = lockHolderTxn
+	if held {
 		waitForState.held = true
 	} else {
 		waitForState.txn = l.reservation.txn
@@ -1296,8 +1434,10 @@ func (l *lockState) informActiveWaiters() {
 			l.distinguishedWaiter = g
 			findDistinguished = false
 		}
+		state := waitForState
+		state.txn, _ = l.conflictingHolder(g, lock.None)
 		g.mu.Lock()
-		g.updateWaitingStateLocked(waitForState)
+		g.updateWaitingStateLocked(state)
 		if l.distinguishedWaiter == g {
 			g.mu.state.kind = waitForDistinguished
 		}
@@ -1311,6 +1451,9 @@ func (l *lockState) informActiveWaiters() {
 		}
 		g := qg.guard
 		state := waitForState
+		if held {
+			state.txn, _ = l.conflictingHolder(g, qg.str)
+		}
 		if g.isSameTxnAsReservation(state) {
 			state.kind = waitSelf
 		} else {
@@ -1329,8 +1472,48 @@ func (l *lockState) informActiveWaiters() {
 	}
 }
 
+// releaseNonConflictingWaiters removes the active waiters that no longer
+// conflict with any of the lock holders, so that they can proceed. This is the
+// case when some of the lock holders have released the lock, or when the lock
+// has been acquired with a strength that is compatible with the waiters. The
+// distinguished waiter is reset if it was removed, but the other waiters are not
+// informed of the change.
+// REQUIRES: l.mu is locked and the lock is held.
+func (l *lockState) releaseNonConflictingWaiters() {
+	for e := l.waitingReaders.Front(); e != nil; {
+		g := e.Value.(*lockTableGuardImpl)
+		curr := e
+		e = e.Next()
+		if txn, _ := l.conflictingHolder(g, lock.None); txn == nil {
+			l.waitingReaders.Remove(curr)
+			if g == l.distinguishedWaiter {
+				l.distinguishedWaiter = nil
+			}
+			g.doneWaitingAtLock(false, l)
+		}
+	}
+	for e := l.queuedWriters.Front(); e != nil; {
+		qg := e.Value.(*queuedGuard)
+		curr := e
+		e = e.Next()
+		if !qg.active {
+			// Inactive waiters are waiting elsewhere, and will notice that they no
+			// longer conflict when they scan the lock table again.
+			continue
+		}
+		if txn, _ := l.conflictingHolder(qg.guard, qg.str); txn == nil {
+			l.queuedWriters.Remove(curr)
+			if qg.guard == l.distinguishedWaiter {
+				l.distinguishedWaiter = nil
+			}
+			qg.guard.doneWaitingAtLock(false, l)
+		}
+	}
+}
+
 // releaseWritersFromTxn removes all waiting writers for the lockState that are
-// part of the specified transaction.
+// part of the specified transaction, and which no longer conflict with any of
+// the lock holders.
 // REQUIRES: l.mu is locked.
 func (l *lockState) releaseWritersFromTxn(txn *enginepb.TxnMeta) {
 	for e := l.queuedWriters.Front(); e != nil; {
@@ -1338,7 +1521,10 @@ func (l *lockState) releaseWritersFromTxn(txn *enginepb.TxnMeta) {
 		curr := e
 		e = e.Next()
 		g := qg.guard
-		if g.isSameTxn(txn) {
+		if !g.isSameTxn(txn) {
+			continue
+		}
+		if conflictingTxn, _ := l.conflictingHolder(g, qg.str); conflictingTxn == nil {
 			if qg.active {
 				if g == l.distinguishedWaiter {
 					l.distinguishedWaiter = nil
@@ -1385,7 +1571,7 @@ func (l *lockState) tryMakeNewDistinguished() {
 // reservation.
 // REQUIRES: l.mu is locked.
 func (l *lockState) isEmptyLock() bool {
-	if !l.holder.locked && l.reservation == nil {
+	if !l.isHeld() && l.reservation == nil {
 		for i := range l.holder.holder {
 			if !l.holder.holder[i].isEmpty() {
 				panic("lockState with !locked but non-zero lockHolderInfo")
@@ -1421,7 +1607,7 @@ func (l *lockState) assertEmptyLockUnlocked() {
 // Returns the duration of time the lock has been tracked as held in the lock table.
 // REQUIRES: l.mu is locked.
 func (l *lockState) lockHeldDuration(now time.Time) time.Duration {
-	if !l.holder.locked {
+	if !l.isHeld() {
 		return time.Duration(0)
 	}
 
@@ -1458,10 +1644,24 @@ func (l *lockState) totalAndMaxWaitDuration(now time.Time) (time.Duration, time.
 	return totalWaitDuration, maxWaitDuration
 }
 
+// Returns true iff the lock is currently held, with any strength, by one or
+// more transactions.
+// REQUIRES: l.mu is locked.
+func (l *lockState) isHeld() bool {
+	return l.holder.locked || len(l.sharedHolders) > 0
+}
+
 // Returns true iff the lock is currently held by the transaction with the
-// given id.
+// given id, with any strength.
 // REQUIRES: l.mu is locked.
 func (l *lockState) isLockedBy(id uuid.UUID) bool {
+	return l.isHolder(id) || l.sharedHolderIndex(id) >= 0
+}
+
+// Returns true iff the lock is currently held with a strength stronger than
+// Shared by the transaction with the given id.
+// REQUIRES: l.mu is locked.
+func (l *lockState) isHolder(id uuid.UUID) bool {
 	if l.holder.locked {
 		var holderID uuid.UUID
 		if l.holder.holder[lock.Unreplicated].txn != nil {
@@ -1474,8 +1674,45 @@ func (l *lockState) isLockedBy(id uuid.UUID) bool {
 	return false
 }
 
-// Returns information about the current lock holder if the lock is held, else
-// returns nil.
+// Returns the index in sharedHolders of the transaction with the given id, or
+// -1 if it does not hold the lock with Shared strength.
+// REQUIRES: l.mu is locked.
+func (l *lockState) sharedHolderIndex(id uuid.UUID) int {
+	for i := range l.sharedHolders {
+		if l.sharedHolders[i].txn.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// moveSharedHolderToHolder moves the shared lock holder at the given index in
+// sharedHolders to the holder, retaining the sequence numbers at which it
+// acquired the shared lock.
+// REQUIRES: l.mu is locked.
+func (l *lockState) moveSharedHolderToHolder(i int) {
+	h := l.sharedHolders[i]
+	dur := h.sharedDurability()
+	h.replicated = false
+	l.holder.holder[dur] = h
+	l.removeSharedHolder(i)
+	l.holder.locked = true
+}
+
+// Removes the shared lock holder at the given index in sharedHolders.
+// REQUIRES: l.mu is locked.
+func (l *lockState) removeSharedHolder(i int) {
+	l.sharedHolders = append(l.sharedHolders[:i], l.sharedHolders[i+1:]...)
+	if len(l.sharedHolders) == 0 {
+		l.sharedHolders = nil
+		if !l.holder.locked {
+			l.holder.startTime = time.Time{}
+		}
+	}
+}
+
+// Returns information about the current lock holder if the lock is held with
+// a strength stronger than Shared, else returns nil.
 // REQUIRES: l.mu is locked.
 func (l *lockState) getLockHolder() (*enginepb.TxnMeta, hlc.Timestamp) {
 	if !l.holder.locked {
@@ -1499,16 +1736,94 @@ func (l *lockState) getLockHolder() (*enginepb.TxnMeta, hlc.Timestamp) {
 	return l.holder.holder[index].txn, l.holder.holder[index].ts
 }
 
-// Removes the current lock holder from the lock.
+// Removes the current lock holder from the lock. Shared lock holders are not
+// removed.
 // REQUIRES: l.mu is locked.
 func (l *lockState) clearLockHolder() {
 	l.holder.locked = false
-	l.holder.startTime = time.Time{}
+	l.holder.strength = lock.None
+	if len(l.sharedHolders) == 0 {
+		l.holder.startTime = time.Time{}
+	}
 	for i := range l.holder.holder {
 		l.holder.holder[i] = lockHolderInfo{}
 	}
 }
 
+// conflictingHolder returns a transaction, other than the transaction of the
+// request g, which holds the lock with a strength that is incompatible with an
+// access by g with strength str, along with the timestamp at which it holds
+// the lock. If there are multiple such transactions, the holder with a
+// strength stronger than Shared is returned first, followed by the shared lock
+// holders in the order in which they acquired the lock. Returns nil if the
+// access does not conflict with any of the lock holders.
+//
+// The compatibility rules are those of lock.Conflicts, except that Exclusive
+// locks are treated like Intents: non-locking reads at or above the timestamp
+// of an Exclusive lock conflict with it.
+// REQUIRES: l.mu is locked.
+func (l *lockState) conflictingHolder(
+	g *lockTableGuardImpl, str lock.Strength,
+) (*enginepb.TxnMeta, hlc.Timestamp) {
+	if l.holder.locked {
+		txn, ts := l.getLockHolder()
+		if !g.isSameTxn(txn) {
+			var conflicts bool
+			switch str {
+			case lock.None:
+				conflicts = l.holder.strength >= lock.Exclusive && ts.LessEq(g.ts)
+			case lock.Shared:
+				conflicts = l.holder.strength >= lock.Exclusive
+			default:
+				conflicts = true
+			}
+			if conflicts {
+				return txn, ts
+			}
+		}
+	}
+	if str >= lock.Exclusive {
+		for i := range l.sharedHolders {
+			if h := &l.sharedHolders[i]; !g.isSameTxn(h.txn) {
+				return h.txn, h.ts
+			}
+		}
+	}
+	return nil, hlc.Timestamp{}
+}
+
+// holdersReleased is called when one or more transactions have stopped holding
+// the lock. If the lock is no longer held by any transaction, it transitions
+// to free. Otherwise, the waiters are informed about the remaining lock
+// holders. Returns whether the lockState can be garbage collected.
+// REQUIRES: l.mu is locked.
+func (l *lockState) holdersReleased() (gc bool) {
+	if !l.isHeld() {
+		return l.lockIsFree()
+	}
+	l.informActiveWaiters()
+	return false
+}
+
+// releaseFinalizedSharedHolders removes the shared lock holders whose
+// transactions are known to be finalized. Unreplicated shared locks do not need
+// to be resolved. Replicated shared locks are rediscovered by the requests that
+// conflict with them during evaluation, at which point they are resolved using
+// the finalizedTxnCache. Returns whether any holder was removed.
+// REQUIRES: l.mu is locked.
+func (l *lockState) releaseFinalizedSharedHolders(finalizedTxnCache *txnCache) bool {
+	removed := false
+	for i := 0; i < len(l.sharedHolders); {
+		if _, ok := finalizedTxnCache.get(l.sharedHolders[i].txn.ID); ok {
+			l.removeSharedHolder(i)
+			removed = true
+			continue
+		}
+		i++
+	}
+	return removed
+}
+
 // tryActiveWait decides whether the request g, with locking strength str,
 // should actively wait at this lock or not. It adjusts the data-structures
 // appropriately if the request needs to wait. The notify parameter is true iff
@@ -1576,7 +1891,7 @@ func (l *lockState) tryActiveWait(
 	defer l.mu.Unlock()
 
 	switch str {
-	case lock.None, lock.Intent:
+	case lock.None, lock.Shared, lock.Update, lock.Exclusive, lock.Intent:
 	default:
 		panic(errors.AssertionFailedf("unexpected lock strength %s", str))
 	}
@@ -1587,31 +1902,37 @@ func (l *lockState) tryActiveWait(
 	}
 
 	// Lock is not empty.
-	lockHolderTxn, lockHolderTS := l.getLockHolder()
-	if lockHolderTxn != nil && g.isSameTxn(lockHolderTxn) {
-		// Already locked by this txn.
-		return false, false
-	}
-
 	var replicatedLockFinalizedTxn *roachpb.Transaction
-	if lockHolderTxn != nil {
+	if lockHolderTxn, _ := l.getLockHolder(); lockHolderTxn != nil && !g.isSameTxn(lockHolderTxn) {
 		finalizedTxn, ok := g.lt.finalizedTxnCache.get(lockHolderTxn.ID)
 		if ok {
 			if l.holder.holder[lock.Replicated].txn == nil {
 				// Only held unreplicated. Release immediately.
 				l.clearLockHolder()
-				if l.lockIsFree() {
+				if l.holdersReleased() {
 					// Empty lock.
 					return false, true
 				}
-				lockHolderTxn = nil
-				// There is a reservation holder, which may be the caller itself,
-				// so fall through to the processing below.
+				// There may be a reservation holder, which may be the caller itself,
+				// or shared lock holders, so fall through to the processing below.
 			} else {
 				replicatedLockFinalizedTxn = finalizedTxn
 			}
 		}
 	}
+	// Shared locks can also be released immediately if their holders are
+	// finalized. Replicated Shared locks are resolved once they are rediscovered.
+	if l.releaseFinalizedSharedHolders(&g.lt.finalizedTxnCache) && l.holdersReleased() {
+		// Empty lock.
+		return false, true
+	}
+
+	lockHolderTxn, _ := l.conflictingHolder(g, str)
+	if l.isHeld() && lockHolderTxn == nil {
+		// Already locked by this txn, or locked by other transactions with
+		// strengths that are compatible with str.
+		return false, false
+	}
 
 	if str == lock.None {
 		if lockHolderTxn == nil {
@@ -1619,11 +1940,6 @@ func (l *lockState) tryActiveWait(
 			return false, false
 		}
 		// Locked by some other txn.
-		// TODO(arul): this will need to change once we start supporting different
-		// lock strengths.
-		if g.ts.Less(lockHolderTS) {
-			return false, false
-		}
 		g.mu.Lock()
 		_, alsoLocksWithHigherStrength := g.mu.locks[l]
 		g.mu.Unlock()
@@ -1691,8 +2007,9 @@ func (l *lockState) tryActiveWait(
 
 	// Incompatible with whoever is holding lock or reservation.
 
-	if l.reservation != nil && str == lock.Intent && l.tryBreakReservation(g.seqNum) {
+	if l.reservation != nil && str != lock.None && l.tryBreakReservation(g.seqNum) {
 		l.reservation = g
+		l.reservationStr = str
 		g.mu.Lock()
 		g.mu.locks[l] = struct{}{}
 		g.mu.Unlock()
@@ -1707,7 +2024,7 @@ func (l *lockState) tryActiveWait(
 	wait = true
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	if str == lock.Intent {
+	if str != lock.None {
 		var qg *queuedGuard
 		if _, inQueue := g.mu.locks[l]; inQueue {
 			// Already in queue and must be in the right position, so mark as active
@@ -1724,11 +2041,15 @@ func (l *lockState) tryActiveWait(
 			}
 			// Tentative. See below.
 			qg.active = true
+			if qg.str < str {
+				qg.str = str
+			}
 		} else {
 			// Not in queue so insert as active waiter. The active waiter
 			// designation is tentative (see below).
 			qg = &queuedGuard{
 				guard:  g,
+				str:    str,
 				active: true,
 			}
 			if curLen := l.queuedWriters.Len(); curLen == 0 {
@@ -1820,8 +2141,7 @@ func (l *lockState) isNonConflictingLock(g *lockTableGuardImpl, str lock.Strengt
 		return true
 	}
 	// Lock is not empty.
-	lockHolderTxn, lockHolderTS := l.getLockHolder()
-	if lockHolderTxn == nil {
+	if !l.isHeld() {
 		// Reservation holders are non-conflicting.
 		//
 		// When optimistic evaluation holds latches, there cannot be a conflicting
@@ -1835,19 +2155,13 @@ func (l *lockState) isNonConflictingLock(g *lockTableGuardImpl, str lock.Strengt
 		// will retry as pessimistic.
 		return true
 	}
-	if g.isSameTxn(lockHolderTxn) {
-		// Already locked by this txn.
-		return true
-	}
 	// NB: We do not look at the finalizedTxnCache in this optimistic evaluation
 	// path. A conflict with a finalized txn will be noticed when retrying
 	// pessimistically.
-
-	if str == lock.None && g.ts.Less(lockHolderTS) {
-		return true
-	}
-	// Conflicts.
-	return false
+	lockHolderTxn, _ := l.conflictingHolder(g, str)
+	// If lockHolderTxn is nil, the lock is already locked by this txn, or it is
+	// locked by other transactions with strengths that are compatible with str.
+	return lockHolderTxn == nil
 }
 
 // Acquires this lock. Returns the list of guards that are done actively
@@ -1855,7 +2169,7 @@ func (l *lockState) isNonConflictingLock(g *lockTableGuardImpl, str lock.Strengt
 // that is acquiring the lock.
 // Acquires l.mu.
 func (l *lockState) acquireLock(
-	_ lock.Strength,
+	str lock.Strength,
 	durability lock.Durability,
 	txn *enginepb.TxnMeta,
 	ts hlc.Timestamp,
@@ -1863,81 +2177,51 @@ func (l *lockState) acquireLock(
 ) error {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	if l.holder.locked {
+	if l.isHeld() {
 		// Already held.
-		beforeTxn, beforeTs := l.getLockHolder()
-		if txn.ID != beforeTxn.ID {
-			return errors.AssertionFailedf("existing lock cannot be acquired by different transaction")
-		}
-		seqs := l.holder.holder[durability].seqs
-		if l.holder.holder[durability].txn != nil && l.holder.holder[durability].txn.Epoch < txn.Epoch {
-			// Clear the sequences for the older epoch.
-			seqs = seqs[:0]
-		}
-		if len(seqs) > 0 && seqs[len(seqs)-1] >= txn.Sequence {
-			// Idempotent lock acquisition. In this case, we simply ignore the lock
-			// acquisition as long as it corresponds to an existing sequence number.
-			// If the sequence number is not being tracked yet, insert it into the
-			// sequence history. The validity of such a lock re-acquisition should
-			// have already been determined at the MVCC level.
-			if i := sort.Search(len(seqs), func(i int) bool {
-				return seqs[i] >= txn.Sequence
-			}); i == len(seqs) {
-				panic("lockTable bug - search value <= last element")
-			} else if seqs[i] != txn.Sequence {
-				seqs = append(seqs, 0)
-				copy(seqs[i+1:], seqs[i:])
-				seqs[i] = txn.Sequence
-				l.holder.holder[durability].seqs = seqs
+		if err := l.checkCompatibleAcquisition(str, txn); err != nil {
+			return err
+		}
+		if l.isHolder(txn.ID) {
+			// Re-acquisition by the holder, possibly with a higher strength.
+			_, beforeTs := l.getLockHolder()
+			if l.holder.strength < str {
+				l.holder.strength = str
+			}
+			l.holder.holder[durability].acquire(txn, ts)
+			_, afterTs := l.getLockHolder()
+			if beforeTs.Less(afterTs) {
+				l.increasedLockTs(afterTs)
 			}
 			return nil
 		}
-		l.holder.holder[durability].txn = txn
-		// Forward the lock's timestamp instead of assigning to it blindly.
-		// While lock acquisition uses monotonically increasing timestamps
-		// from the perspective of the transaction's coordinator, this does
-		// not guarantee that a lock will never be acquired at a higher
-		// epoch and/or sequence number but with a lower timestamp when in
-		// the presence of transaction pushes. Consider the following
-		// sequence of events:
-		//
-		//  - txn A acquires lock at sequence 1, ts 10
-		//  - txn B pushes txn A to ts 20
-		//  - txn B updates lock to ts 20
-		//  - txn A's coordinator does not immediately learn of the push
-		//  - txn A re-acquires lock at sequence 2, ts 15
-		//
-		// A lock's timestamp at a given durability level is not allowed to
-		// regress, so by forwarding its timestamp during the second acquisition
-		// instead if assigning to it blindly, it remains at 20.
-		//
-		// However, a lock's timestamp as reported by getLockHolder can regress
-		// if it is acquired at a lower timestamp and a different durability
-		// than it was previously held with. This is necessary to support
-		// because the hard constraint which we must uphold here that the
-		// lockHolderInfo for a replicated lock cannot diverge from the
-		// replicated state machine in such a way that its timestamp in the
-		// lockTable exceeds that in the replicated keyspace. If this invariant
-		// were to be violated, we'd risk infinite lock-discovery loops for
-		// requests that conflict with the lock as is written in the replicated
-		// state machine but not as is reflected in the lockTable.
-		//
-		// Lock timestamp regressions are safe from the perspective of other
-		// transactions because the request which re-acquired the lock at the
-		// lower timestamp must have been holding a write latch at or below the
-		// new lock's timestamp. This means that no conflicting requests could
-		// be evaluating concurrently. Instead, all will need to re-scan the
-		// lockTable once they acquire latches and will notice the reduced
-		// timestamp at that point, which may cause them to conflict with the
-		// lock even if they had not conflicted before. In a sense, it is no
-		// different than the first time a lock is added to the lockTable.
-		l.holder.holder[durability].ts.Forward(ts)
-		l.holder.holder[durability].seqs = append(seqs, txn.Sequence)
-
-		_, afterTs := l.getLockHolder()
-		if beforeTs.Less(afterTs) {
-			l.increasedLockTs(afterTs)
+		if i := l.sharedHolderIndex(txn.ID); i >= 0 {
+			if str == lock.Shared {
+				// Re-acquisition by a shared lock holder.
+				l.sharedHolders[i].acquire(txn, ts)
+				if durability == lock.Replicated {
+					l.sharedHolders[i].replicated = true
+				}
+				return nil
+			}
+			// The shared lock holder is upgrading its lock.
+			l.moveSharedHolderToHolder(i)
+		} else if str == lock.Shared {
+			// A new shared lock holder.
+			l.sharedHolders = append(l.sharedHolders, lockHolderInfo{replicated: durability == lock.Replicated})
+			l.sharedHolders[len(l.sharedHolders)-1].acquire(txn, ts)
+			l.releaseWritersFromTxn(txn)
+			l.informActiveWaiters()
+			return nil
 		}
+		// A new holder, which was either holding a shared lock before, or is
+		// acquiring an Update lock on a key locked only with Shared strength by
+		// other transactions.
+		l.holder.locked = true
+		l.holder.strength = str
+		l.holder.holder[durability].acquire(txn, ts)
+		l.releaseWritersFromTxn(txn)
+		l.informActiveWaiters()
 		return nil
 	}
 	// Not already held, so may have been reserved by this request. There is also
@@ -1949,6 +2233,7 @@ func (l *lockState) acquireLock(
 			// Reservation is broken.
 			qg := &queuedGuard{
 				guard:  l.reservation,
+				str:    l.reservationStr,
 				active: false,
 			}
 			l.queuedWriters.PushFront(qg)
@@ -1972,10 +2257,15 @@ func (l *lockState) acquireLock(
 		}
 	}
 	l.reservation = nil
-	l.holder.locked = true
-	l.holder.holder[durability].txn = txn
-	l.holder.holder[durability].ts = ts
-	l.holder.holder[durability].seqs = append([]enginepb.TxnSeq(nil), txn.Sequence)
+	l.reservationStr = lock.None
+	if str == lock.Shared {
+		l.sharedHolders = append(l.sharedHolders, lockHolderInfo{replicated: durability == lock.Replicated})
+		l.sharedHolders[0].acquire(txn, ts)
+	} else {
+		l.holder.locked = true
+		l.holder.strength = str
+		l.holder.holder[durability].acquire(txn, ts)
+	}
 	l.holder.startTime = clock.PhysicalTime()
 
 	// If there are waiting requests from the same txn, they no longer need to wait.
@@ -1986,12 +2276,36 @@ func (l *lockState) acquireLock(
 	return nil
 }
 
-// A replicated lock held by txn with timestamp ts was discovered by guard g
-// where g is trying to access this key with strength accessStrength.
+// checkCompatibleAcquisition returns an error if the acquisition of this lock
+// by txn with strength str conflicts with the locks held by other
+// transactions. Lock acquisitions are serialized with conflicting requests by
+// latching and are preceded by a scan of the lock table, so such a conflict
+// indicates a bug.
+// REQUIRES: l.mu is locked.
+func (l *lockState) checkCompatibleAcquisition(str lock.Strength, txn *enginepb.TxnMeta) error {
+	if l.holder.locked && !l.isHolder(txn.ID) &&
+		(l.holder.strength != lock.Update || str != lock.Shared) {
+		return errors.AssertionFailedf("existing lock cannot be acquired by different transaction")
+	}
+	if str >= lock.Exclusive {
+		for i := range l.sharedHolders {
+			if l.sharedHolders[i].txn.ID != txn.ID {
+				return errors.AssertionFailedf(
+					"existing shared lock cannot be acquired with strength %s by different transaction", str)
+			}
+		}
+	}
+	return nil
+}
+
+// A replicated lock with strength heldStrength held by txn with timestamp ts
+// was discovered by guard g where g is trying to access this key with strength
+// accessStrength.
 // Acquires l.mu.
 func (l *lockState) discoveredLock(
 	txn *enginepb.TxnMeta,
 	ts hlc.Timestamp,
+	heldStrength lock.Strength,
 	g *lockTableGuardImpl,
 	accessStrength lock.Strength,
 	notRemovable bool,
@@ -2003,21 +2317,36 @@ func (l *lockState) discoveredLock(
 	if notRemovable {
 		l.notRemovable++
 	}
-	if l.holder.locked {
-		if !l.isLockedBy(txn.ID) {
-			return errors.AssertionFailedf(
-				"discovered lock by different transaction (%s) than existing lock (see issue #63592): %s",
-				txn, l)
+	if heldStrength == lock.Shared {
+		if err := l.discoveredSharedLockLocked(txn, ts, clock); err != nil {
+			return err
 		}
 	} else {
-		l.holder.locked = true
-		l.holder.startTime = clock.PhysicalTime()
-	}
-	holder := &l.holder.holder[lock.Replicated]
-	if holder.txn == nil {
-		holder.txn = txn
-		holder.ts = ts
-		holder.seqs = append(holder.seqs, txn.Sequence)
+		if l.isHeld() {
+			if !l.isLockedBy(txn.ID) || len(l.sharedHolders) > 1 ||
+				(l.holder.locked && len(l.sharedHolders) > 0) {
+				return errors.AssertionFailedf(
+					"discovered lock by different transaction (%s) than existing lock (see issue #63592): %s",
+					txn, l)
+			}
+			if len(l.sharedHolders) > 0 {
+				// The transaction's shared lock is subsumed by the discovered replicated
+				// lock, so move it to the holder.
+				l.moveSharedHolderToHolder(0)
+			}
+		} else {
+			l.holder.locked = true
+			l.holder.startTime = clock.PhysicalTime()
+		}
+		if l.holder.strength < heldStrength {
+			l.holder.strength = heldStrength
+		}
+		holder := &l.holder.holder[lock.Replicated]
+		if holder.txn == nil {
+			holder.txn = txn
+			holder.ts = ts
+			holder.seqs = append(holder.seqs, txn.Sequence)
+		}
 	}
 
 	// Queue the existing reservation holder. Note that this reservation
@@ -2028,10 +2357,12 @@ func (l *lockState) discoveredLock(
 	if l.reservation != nil {
 		qg := &queuedGuard{
 			guard:  l.reservation,
+			str:    l.reservationStr,
 			active: false,
 		}
 		l.queuedWriters.PushFront(qg)
 		l.reservation = nil
+		l.reservationStr = lock.None
 	}
 
 	switch accessStrength {
@@ -2047,7 +2378,7 @@ func (l *lockState) discoveredLock(
 			return errors.AssertionFailedf("discovered non-conflicting lock")
 		}
 
-	case lock.Intent:
+	case lock.Shared, lock.Update, lock.Exclusive, lock.Intent:
 		// Immediately enter the lock's queuedWriters list.
 		// NB: this inactive waiter can be non-transactional.
 		g.mu.Lock()
@@ -2062,6 +2393,7 @@ func (l *lockState) discoveredLock(
 			// Put self in queue as inactive waiter.
 			qg := &queuedGuard{
 				guard:  g,
+				str:    accessStrength,
 				active: false,
 			}
 			// g is not necessarily first in the queue in the (rare) case (a) above.
@@ -2130,6 +2462,8 @@ func (l *lockState) tryClearLock(force bool) bool {
 		}
 	}
 	l.clearLockHolder()
+	l.sharedHolders = nil
+	l.holder.startTime = time.Time{}
 
 	// Clear reservation.
 	if l.reservation != nil {
@@ -2138,6 +2472,7 @@ func (l *lockState) tryClearLock(force bool) bool {
 		delete(g.mu.locks, l)
 		g.mu.Unlock()
 		l.reservation = nil
+		l.reservationStr = lock.None
 	}
 
 	// Clear waitingReaders.
@@ -2181,6 +2516,40 @@ func (l *lockState) tryClearLock(force bool) bool {
 	return true
 }
 
+// discoveredSharedLockLocked records a replicated Shared lock held by txn with
+// timestamp ts, which was discovered during evaluation. Shared locks can be
+// held by multiple transactions, and alongside an Update lock, so unlike other
+// discovered locks, the lock may already be held by other transactions.
+// REQUIRES: l.mu is locked.
+func (l *lockState) discoveredSharedLockLocked(
+	txn *enginepb.TxnMeta, ts hlc.Timestamp, clock *hlc.Clock,
+) error {
+	if l.holder.locked && !l.isHolder(txn.ID) && l.holder.strength != lock.Update {
+		return errors.AssertionFailedf(
+			"discovered shared lock by different transaction (%s) than existing lock: %s", txn, l)
+	}
+	if !l.isHeld() {
+		l.holder.startTime = clock.PhysicalTime()
+	}
+	if l.isHolder(txn.ID) {
+		// The transaction already holds the lock with a higher strength.
+		holder := &l.holder.holder[lock.Replicated]
+		if holder.txn == nil {
+			holder.txn = txn
+			holder.ts = ts
+			holder.seqs = append(holder.seqs, txn.Sequence)
+		}
+		return nil
+	}
+	if i := l.sharedHolderIndex(txn.ID); i >= 0 {
+		l.sharedHolders[i].replicated = true
+		return nil
+	}
+	l.sharedHolders = append(l.sharedHolders, lockHolderInfo{replicated: true})
+	l.sharedHolders[len(l.sharedHolders)-1].acquire(txn, ts)
+	return nil
+}
+
 // Removes the TxnSeqs in heldSeqNums that are contained in ignoredSeqNums.
 // REQUIRES: ignoredSeqNums contains non-overlapping ranges and sorted in
 // increasing seq order.
@@ -2215,13 +2584,24 @@ func (l *lockState) tryUpdateLock(up *roachpb.LockUpdate) (heldByTxn, gc bool) {
 	if !l.isLockedBy(up.Txn.ID) {
 		return false, false
 	}
+	if i := l.sharedHolderIndex(up.Txn.ID); i >= 0 {
+		// Shared locks only conflict with locking requests with a higher strength,
+		// irrespective of their timestamp, so there is no need to inform the
+		// waiters unless the lock is released.
+		sh := &l.sharedHolders[i]
+		if up.Status.IsFinalized() || !sh.update(sh.sharedDurability(), up, sh.ts.Less(up.Txn.WriteTimestamp)) {
+			l.removeSharedHolder(i)
+			gc = l.holdersReleased()
+			return true, gc
+		}
+		return true, false
+	}
 	if up.Status.IsFinalized() {
 		l.clearLockHolder()
-		gc = l.lockIsFree()
+		gc = l.holdersReleased()
 		return true, gc
 	}
 
-	txn := &up.Txn
 	ts := up.Txn.WriteTimestamp
 	_, beforeTs := l.getLockHolder()
 	advancedTs := beforeTs.Less(ts)
@@ -2231,51 +2611,14 @@ func (l *lockState) tryUpdateLock(up *roachpb.LockUpdate) (heldByTxn, gc bool) {
 		if holder.txn == nil {
 			continue
 		}
-		// Note that mvccResolveWriteIntent() has special handling of the case
-		// where the pusher is using an epoch lower than the epoch of the intent
-		// (replicated lock), but is trying to push to a higher timestamp. The
-		// replicated lock gets written with the newer epoch (not the epoch known
-		// to the pusher) but a higher timestamp. Then the pusher will call into
-		// this function with that lower epoch. Instead of trying to be consistent
-		// with mvccResolveWriteIntent() in the current state of the replicated
-		// lock we simply forget the replicated lock since it is no longer in the
-		// way of this request. Eventually, once we have segregated locks, the
-		// lock table will be the source of truth for replicated locks too, and
-		// this forgetting behavior will go away.
-		//
-		// For unreplicated locks the lock table is the source of truth, so we
-		// best-effort mirror the behavior of mvccResolveWriteIntent() by updating
-		// the timestamp.
-		if lock.Durability(i) == lock.Replicated || txn.Epoch > holder.txn.Epoch {
-			*holder = lockHolderInfo{}
-			continue
-		}
-		// Unreplicated lock held in same epoch or a higher epoch.
-		if advancedTs {
-			// We may advance ts here but not update the holder.txn object below
-			// for the reason stated in the comment about mvccResolveWriteIntent().
-			// The lockHolderInfo.ts is the source of truth regarding the timestamp
-			// of the lock, and not TxnMeta.WriteTimestamp.
-			holder.ts = ts
+		if holder.update(lock.Durability(i), up, advancedTs) {
+			isLocked = true
 		}
-		if txn.Epoch == holder.txn.Epoch {
-			holder.seqs = removeIgnored(holder.seqs, up.IgnoredSeqNums)
-			if len(holder.seqs) == 0 {
-				*holder = lockHolderInfo{}
-				continue
-			}
-			if advancedTs {
-				holder.txn = txn
-			}
-		}
-		// Else txn.Epoch < lockHolderTxn.Epoch, so only the timestamp has been
-		// potentially updated.
-		isLocked = true
 	}
 
 	if !isLocked {
 		l.clearLockHolder()
-		gc = l.lockIsFree()
+		gc = l.holdersReleased()
 		return true, gc
 	}
 
@@ -2288,6 +2631,57 @@ func (l *lockState) tryUpdateLock(up *roachpb.LockUpdate) (heldByTxn, gc bool) {
 	return true, false
 }
 
+// update updates the lockHolderInfo, which is held with durability dur, using
+// the LockUpdate of its transaction. advancedTs is true iff the LockUpdate
+// advances the timestamp of the lock. Returns whether the lock is still held
+// by the transaction with this durability.
+func (lh *lockHolderInfo) update(
+	dur lock.Durability, up *roachpb.LockUpdate, advancedTs bool,
+) bool {
+	txn := &up.Txn
+	ts := up.Txn.WriteTimestamp
+	// Note that mvccResolveWriteIntent() has special handling of the case
+	// where the pusher is using an epoch lower than the epoch of the intent
+	// (replicated lock), but is trying to push to a higher timestamp. The
+	// replicated lock gets written with the newer epoch (not the epoch known
+	// to the pusher) but a higher timestamp. Then the pusher will call into
+	// this function with that lower epoch. Instead of trying to be consistent
+	// with mvccResolveWriteIntent() in the current state of the replicated
+	// lock we simply forget the replicated lock since it is no longer in the
+	// way of this request. Eventually, once we have segregated locks, the
+	// lock table will be the source of truth for replicated locks too, and
+	// this forgetting behavior will go away.
+	//
+	// For unreplicated locks the lock table is the source of truth, so we
+	// best-effort mirror the behavior of mvccResolveWriteIntent() by updating
+	// the timestamp.
+	if dur == lock.Replicated || txn.Epoch > lh.txn.Epoch {
+		*lh = lockHolderInfo{}
+		return false
+	}
+	// Unreplicated lock held in same epoch or a higher epoch.
+	if advancedTs {
+		// We may advance ts here but not update the holder.txn object below
+		// for the reason stated in the comment about mvccResolveWriteIntent().
+		// The lockHolderInfo.ts is the source of truth regarding the timestamp
+		// of the lock, and not TxnMeta.WriteTimestamp.
+		lh.ts = ts
+	}
+	if txn.Epoch == lh.txn.Epoch {
+		lh.seqs = removeIgnored(lh.seqs, up.IgnoredSeqNums)
+		if len(lh.seqs) == 0 {
+			*lh = lockHolderInfo{}
+			return false
+		}
+		if advancedTs {
+			lh.txn = txn
+		}
+	}
+	// Else txn.Epoch < lockHolderTxn.Epoch, so only the timestamp has been
+	// potentially updated.
+	return true
+}
+
 // The lock holder timestamp has increased. Some of the waiters may no longer
 // need to wait.
 // REQUIRES: l.mu is locked.
@@ -2334,6 +2728,7 @@ func (l *lockState) requestDone(g *lockTableGuardImpl) (gc bool) {
 
 	if l.reservation == g {
 		l.reservation = nil
+		l.reservationStr = lock.None
 		return l.lockIsFree()
 	}
 	// May be in queuedWriters or waitingReaders.
@@ -2390,7 +2785,7 @@ func (l *lockState) requestDone(g *lockTableGuardImpl) (gc bool) {
 // concurrency discussed in #49973.
 //
 // Acquires l.mu.
-func (l *lockState) tryFreeLockOnReplicatedAcquire() bool {
+func (l *lockState) tryFreeLockOnReplicatedAcquire(str lock.Strength) bool {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 
@@ -2399,6 +2794,17 @@ func (l *lockState) tryFreeLockOnReplicatedAcquire() bool {
 		return false
 	}
 
+	// Bail if the unreplicated lock is stronger than the replicated lock being
+	// acquired, which does not protect the key to the same extent.
+	if l.holder.strength > str {
+		return false
+	}
+
+	// Bail if the lock is also held by other transactions with Shared strength.
+	if len(l.sharedHolders) > 0 {
+		return false
+	}
+
 	// Bail if the lock has waiting writers. It is not uncontended.
 	if l.queuedWriters.Len() != 0 {
 		return false
@@ -2418,7 +2824,7 @@ func (l *lockState) tryFreeLockOnReplicatedAcquire() bool {
 // waiters, but there cannot be a reservation.
 // REQUIRES: l.mu is locked.
 func (l *lockState) lockIsFree() (gc bool) {
-	if l.holder.locked {
+	if l.isHeld() {
 		panic("called lockIsFree on lock with holder")
 	}
 	if l.reservation != nil {
@@ -2472,6 +2878,7 @@ func (l *lockState) lockIsFree() (gc bool) {
 	qg := e.Value.(*queuedGuard)
 	g := qg.guard
 	l.reservation = g
+	l.reservationStr = qg.str
 	l.queuedWriters.Remove(e)
 	if qg.active {
 		if g == l.distinguishedWaiter {
@@ -2699,7 +3106,9 @@ func (t *lockTableImpl) AddDiscoveredLock(
 		g.notRemovableLock = l
 		notRemovableLock = true
 	}
-	err = l.discoveredLock(&intent.Txn, intent.Txn.WriteTimestamp, g, str, notRemovableLock, g.lt.clock)
+	err = l.discoveredLock(
+		&intent.Txn, intent.Txn.WriteTimestamp, intent.LockStrength(), g, str, notRemovableLock, g.lt.clock,
+	)
 	// Can't release tree.mu until call l.discoveredLock() since someone may
 	// find an empty lock and remove it from the tree.
 	t.locks.mu.Unlock()
@@ -2717,8 +3126,15 @@ func (t *lockTableImpl) AcquireLock(acq *roachpb.LockAcquisition) error {
 		// If not enabled, don't track any locks.
 		return nil
 	}
-	if acq.Strength != lock.Intent {
-		return errors.AssertionFailedf("lock strength not Intent")
+	switch acq.Strength {
+	case lock.Shared, lock.Exclusive:
+	case lock.Update:
+		if acq.Durability == lock.Replicated {
+			return errors.AssertionFailedf("replicated %s locks are not supported", acq.Strength)
+		}
+	case lock.Intent:
+	default:
+		return errors.AssertionFailedf("unexpected lock strength %s", acq.Strength)
 	}
 	var l *lockState
 	t.locks.mu.Lock()
@@ -2749,7 +3165,7 @@ func (t *lockTableImpl) AcquireLock(acq *roachpb.LockAcquisition) error {
 		atomic.AddInt64(&t.locks.numLocks, 1)
 	} else {
 		l = iter.Cur()
-		if acq.Durability == lock.Replicated && l.tryFreeLockOnReplicatedAcquire() {
+		if acq.Durability == lock.Replicated && l.tryFreeLockOnReplicatedAcquire(acq.Strength) {
 			// Don't remember uncontended replicated locks. Just like in the
 			// case where the lock is initially added as replicated, we drop
 			// replicated locks from the lockTable when being upgraded from
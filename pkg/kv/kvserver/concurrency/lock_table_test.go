@@ -83,11 +83,12 @@ start-waiting: <bool>
  Calls lockTable.ScanOptimistic. The request must not have an existing guard.
  If a guard is returned, stores it for later use.
 
-acquire r=<name> k=<key> durability=r|u
+acquire r=<name> k=<key> durability=r|u [strength=shared|update|exclusive|intent]
 ----
 <error string>
 
- Acquires lock for the request, using the existing guard for that request.
+ Acquires lock for the request, using the existing guard for that request. The
+ lock is acquired with Intent strength if no strength is specified.
 
 release txn=<name> span=<start>[,<end>]
 ----
@@ -371,7 +372,11 @@ func TestLockTableBasic(t *testing.T) {
 				if s[0] == 'r' {
 					durability = lock.Replicated
 				}
-				acq := roachpb.MakeLockAcquisition(req.Txn, roachpb.Key(key), durability)
+				str := lock.Intent
+				if d.HasArg("strength") {
+					str = ScanLockStrength(t, d)
+				}
+				acq := roachpb.MakeLockAcquisition(req.Txn, roachpb.Key(key), durability, str)
 				if err := lt.AcquireLock(&acq); err != nil {
 					return err.Error()
 				}
@@ -709,15 +714,19 @@ func scanSpans(
 		str := getStrength(t, d, strS)
 		// Compute latch span access based on the supplied strength.
 		var sa spanset.SpanAccess
+		latchTS := ts
 		switch str {
 		case lock.None:
 			sa = spanset.SpanReadOnly
-		case lock.Intent:
+		case lock.Shared:
+			sa = spanset.SpanReadOnly
+			latchTS = hlc.MaxTimestamp
+		case lock.Update, lock.Exclusive, lock.Intent:
 			sa = spanset.SpanReadWrite
 		default:
 			d.Fatalf(t, "unsupported lock strength: %s", str)
 		}
-		latchSpans.AddMVCC(sa, getSpan(t, d, spanStr), ts)
+		latchSpans.AddMVCC(sa, getSpan(t, d, spanStr), latchTS)
 		lockSpans.Add(str, getSpan(t, d, spanStr))
 	}
 	return latchSpans, lockSpans
@@ -1144,7 +1153,7 @@ func newWorkLoadExecutor(items []workloadItem, concurrency int) *workloadExecuto
 }
 
 func (e *workloadExecutor) acquireLock(txn *roachpb.Transaction, k roachpb.Key) error {
-	acq := roachpb.MakeLockAcquisition(txn, k, lock.Unreplicated)
+	acq := roachpb.MakeLockAcquisition(txn, k, lock.Unreplicated, lock.Intent)
 	err := e.lt.AcquireLock(&acq)
 	if err != nil {
 		return err
@@ -1573,7 +1582,7 @@ func doBenchWork(item *benchWorkItem, env benchEnv, doneCh chan<- error) {
 		}
 	}
 	for _, k := range item.locksToAcquire {
-		acq := roachpb.MakeLockAcquisition(item.Txn, k, lock.Unreplicated)
+		acq := roachpb.MakeLockAcquisition(item.Txn, k, lock.Unreplicated, lock.Intent)
 		if err = env.lt.AcquireLock(&acq); err != nil {
 			doneCh <- err
 			return
@@ -1760,7 +1769,7 @@ func BenchmarkLockTableMetrics(b *testing.B) {
 			}
 			for i := 0; i < locks; i++ {
 				k := roachpb.Key(fmt.Sprintf("%03d", i))
-				acq := roachpb.MakeLockAcquisition(txn, k, lock.Unreplicated)
+				acq := roachpb.MakeLockAcquisition(txn, k, lock.Unreplicated, lock.Intent)
 				err := lt.AcquireLock(&acq)
 				if err != nil {
 					b.Fatal(err)
@@ -1792,6 +1801,7 @@ func TestLockStateSafeFormat(t *testing.T) {
 		endKey: []byte("END"),
 	}
 	l.holder.locked = true
+	l.holder.strength = lock.Intent
 	l.holder.holder[lock.Replicated] = lockHolderInfo{
 		txn:  &enginepb.TxnMeta{ID: uuid.NamespaceDNS},
 		ts:   hlc.Timestamp{WallTime: 123, Logical: 7},
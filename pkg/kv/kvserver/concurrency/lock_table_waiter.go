@@ -111,6 +111,9 @@ type lockTableWaiterImpl struct {
 	disableTxnPushing bool
 	// When set, called just before each push timer event is processed.
 	onPushTimer func()
+	// When set, called each time a request begins waiting on a conflicting
+	// lock on a new key.
+	onContention func(key roachpb.Key)
 }
 
 // IntentResolver is an interface used by lockTableWaiterImpl to push
@@ -145,6 +148,8 @@ func (w *lockTableWaiterImpl) WaitOn(
 	var timerWaitingState waitingState
 	// Used to enforce lock timeouts.
 	var lockDeadline time.Time
+	// Used to report contention on each key at most once.
+	var contendedKey roachpb.Key
 
 	tracer := newContentionEventTracer(tracing.SpanFromContext(ctx), w.clock)
 	// Make sure the contention time info is finalized when exiting the function.
@@ -164,6 +169,10 @@ func (w *lockTableWaiterImpl) WaitOn(
 			tracer.notify(ctx, state)
 			switch state.kind {
 			case waitFor, waitForDistinguished:
+				if w.onContention != nil && !state.key.Equal(contendedKey) {
+					contendedKey = state.key
+					w.onContention(state.key)
+				}
 				if req.WaitPolicy == lock.WaitPolicy_Error {
 					// If the waiter has an Error wait policy, resolve the conflict
 					// immediately without waiting. If the conflict is a lock then
@@ -499,7 +508,7 @@ func (w *lockTableWaiterImpl) pushLockTxn(
 			// round-trip and would lose the local timestamp if rewritten later.
 			log.VEventf(ctx, 2, "pushing timestamp of txn %s above %s", ws.txn.ID.Short(), h.Timestamp)
 
-		case lock.Intent:
+		case lock.Shared, lock.Update, lock.Exclusive, lock.Intent:
 			pushType = kvpb.PUSH_ABORT
 			log.VEventf(ctx, 2, "pushing txn %s to abort", ws.txn.ID.Short())
 		default:
@@ -0,0 +1,281 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package hotkeys samples the keys accessed by requests to a replica in order
+// to identify the hottest keys within a hot range.
+package hotkeys
+
+import (
+	"math/rand"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// maxTrackedKeys is the maximum number of distinct keys tracked by a Sampler
+// in a single window. Once the limit is reached, the least accessed key is
+// evicted to make room for a newly sampled key.
+const maxTrackedKeys = 256
+
+// minWindowDuration is the minimum duration over which per-second rates are
+// computed, which prevents a window that has just started from reporting
+// inflated rates.
+const minWindowDuration = time.Second
+
+// AccessType classifies a sampled key access.
+type AccessType int
+
+const (
+	// Read is a non-mutating access to a key.
+	Read AccessType = iota
+	// Write is a mutating access to a key.
+	Write
+)
+
+// Config configures a Sampler.
+type Config interface {
+	// SampleProbability returns the probability with which each key access is
+	// sampled.
+	SampleProbability() float64
+	// WindowDuration returns the duration of the window over which key
+	// accesses are aggregated.
+	WindowDuration() time.Duration
+}
+
+// RandSource is a source of randomness used to sample key accesses.
+type RandSource interface {
+	// Float64 returns, as a float64, a pseudo-random number in the half-open
+	// interval [0.0,1.0) from the RandSource.
+	Float64() float64
+}
+
+// globalRandSource implements the RandSource interface.
+type globalRandSource struct{}
+
+// Float64 implements the RandSource interface.
+func (globalRandSource) Float64() float64 {
+	return rand.Float64()
+}
+
+// GlobalRandSource returns an implementation of the RandSource interface that
+// redirects calls to the global rand.
+func GlobalRandSource() RandSource {
+	return globalRandSource{}
+}
+
+// KeyStats are the statistics collected for a single sampled key.
+type KeyStats struct {
+	Key roachpb.Key
+	// ReadsPerSecond and WritesPerSecond are estimates of the rate of read and
+	// write requests to the key, extrapolated from the sampled accesses.
+	ReadsPerSecond  float64
+	WritesPerSecond float64
+	// ContentionEvents is the number of times a request waited on a
+	// conflicting lock on the key. Contention is not sampled.
+	ContentionEvents int64
+}
+
+// QueriesPerSecond returns the combined rate of reads and writes to the key.
+func (ks KeyStats) QueriesPerSecond() float64 {
+	return ks.ReadsPerSecond + ks.WritesPerSecond
+}
+
+type keyCounts struct {
+	reads, writes, contention int64
+}
+
+func (c *keyCounts) weight() int64 {
+	return c.reads + c.writes + c.contention
+}
+
+type window struct {
+	start, end time.Time
+	keys       map[string]*keyCounts
+}
+
+func (w *window) record(key roachpb.Key, f func(*keyCounts)) {
+	if w.keys == nil {
+		w.keys = make(map[string]*keyCounts)
+	}
+	c, ok := w.keys[string(key)]
+	if !ok {
+		if len(w.keys) >= maxTrackedKeys {
+			w.evictLeastAccessed()
+		}
+		c = &keyCounts{}
+		w.keys[string(key)] = c
+	}
+	f(c)
+}
+
+func (w *window) evictLeastAccessed() {
+	var minKey string
+	var minCounts *keyCounts
+	for k, c := range w.keys {
+		if minCounts == nil || c.weight() < minCounts.weight() {
+			minKey, minCounts = k, c
+		}
+	}
+	delete(w.keys, minKey)
+}
+
+// A Sampler samples the keys accessed by requests to a replica. It is disabled
+// by default, in which case recording is a cheap no-op. Once enabled, each key
+// access is sampled with a configurable probability and aggregated over a
+// window. The keys sampled in the most recently completed window are reported
+// by TopKeys, along with their estimated read and write rates and contention.
+//
+// The Sampler only tracks a bounded number of keys per window, so the reported
+// statistics are approximate for ranges that serve a diverse set of keys. This
+// is not a concern for its intended purpose, which is to identify a small
+// number of very hot keys.
+type Sampler struct {
+	config  Config     // supplied to NewSampler
+	rand    RandSource // supplied to NewSampler
+	enabled atomic.Bool
+	mu      struct {
+		syncutil.Mutex
+		cur, prev window
+	}
+}
+
+// NewSampler returns a new Sampler. The Sampler is initially disabled.
+func NewSampler(config Config, rand RandSource) *Sampler {
+	return &Sampler{
+		config: config,
+		rand:   rand,
+	}
+}
+
+// Enabled returns whether the Sampler is currently sampling key accesses.
+func (s *Sampler) Enabled() bool {
+	return s.enabled.Load()
+}
+
+// SetEnabled enables or disables the Sampler. Disabling the Sampler discards
+// all sampled keys.
+func (s *Sampler) SetEnabled(now time.Time, enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.enabled.Load() == enabled {
+		return
+	}
+	s.enabled.Store(enabled)
+	s.mu.prev = window{}
+	s.mu.cur = window{start: now}
+}
+
+// Record records an access of the given type to the key, if the Sampler is
+// enabled and the access is sampled.
+func (s *Sampler) Record(now time.Time, key roachpb.Key, typ AccessType) {
+	if !s.Enabled() || s.rand.Float64() >= s.config.SampleProbability() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.enabled.Load() {
+		return
+	}
+	s.maybeRotateLocked(now)
+	s.mu.cur.record(key, func(c *keyCounts) {
+		switch typ {
+		case Read:
+			c.reads++
+		case Write:
+			c.writes++
+		}
+	})
+}
+
+// RecordContention records that a request waited on a conflicting lock on the
+// key, if the Sampler is enabled.
+func (s *Sampler) RecordContention(now time.Time, key roachpb.Key) {
+	if !s.Enabled() {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.enabled.Load() {
+		return
+	}
+	s.maybeRotateLocked(now)
+	s.mu.cur.record(key, func(c *keyCounts) { c.contention++ })
+}
+
+// TopKeys returns up to n of the hottest sampled keys, ordered by their
+// combined read and write rate. The keys are taken from the most recently
+// completed window or, if no window has completed since the Sampler was
+// enabled, from the current window.
+func (s *Sampler) TopKeys(now time.Time, n int) []KeyStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.enabled.Load() {
+		return nil
+	}
+	s.maybeRotateLocked(now)
+	w := s.mu.prev
+	if w.keys == nil {
+		w = s.mu.cur
+		w.end = now
+	}
+	duration := w.end.Sub(w.start)
+	if duration < minWindowDuration {
+		duration = minWindowDuration
+	}
+	// Extrapolate the sampled counts to per-second rates. No accesses are
+	// sampled with a probability of zero.
+	var scale float64
+	if p := s.config.SampleProbability(); p > 0 {
+		scale = 1 / (p * duration.Seconds())
+	}
+	res := make([]KeyStats, 0, len(w.keys))
+	for k, c := range w.keys {
+		res = append(res, KeyStats{
+			Key:              roachpb.Key(k),
+			ReadsPerSecond:   float64(c.reads) * scale,
+			WritesPerSecond:  float64(c.writes) * scale,
+			ContentionEvents: c.contention,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if qi, qj := res[i].QueriesPerSecond(), res[j].QueriesPerSecond(); qi != qj {
+			return qi > qj
+		}
+		if res[i].ContentionEvents != res[j].ContentionEvents {
+			return res[i].ContentionEvents > res[j].ContentionEvents
+		}
+		return res[i].Key.Compare(res[j].Key) < 0
+	})
+	if len(res) > n {
+		res = res[:n]
+	}
+	return res
+}
+
+func (s *Sampler) maybeRotateLocked(now time.Time) {
+	duration := s.config.WindowDuration()
+	if now.Sub(s.mu.cur.start) < duration {
+		return
+	}
+	if now.Sub(s.mu.cur.start) < 2*duration {
+		// The current window is complete and becomes the previous window.
+		s.mu.prev = s.mu.cur
+		s.mu.prev.end = s.mu.cur.start.Add(duration)
+		s.mu.cur = window{start: s.mu.prev.end}
+		return
+	}
+	// No accesses were recorded for more than a full window, so the current
+	// window's accesses are stale.
+	s.mu.prev = window{}
+	s.mu.cur = window{start: now}
+}
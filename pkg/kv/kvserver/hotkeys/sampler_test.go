@@ -0,0 +1,129 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package hotkeys
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+// testConfig implements the Config interface and may be used in testing.
+type testConfig struct {
+	sampleProbability float64
+	windowDuration    time.Duration
+}
+
+func (c testConfig) SampleProbability() float64    { return c.sampleProbability }
+func (c testConfig) WindowDuration() time.Duration { return c.windowDuration }
+
+// constRandSource implements the RandSource interface and always returns the
+// same value.
+type constRandSource float64
+
+func (r constRandSource) Float64() float64 { return float64(r) }
+
+func TestSampler(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	start := time.Unix(100, 0)
+	ms := func(i int) time.Time {
+		return start.Add(time.Duration(i) * time.Millisecond)
+	}
+	keyA, keyB, keyC := roachpb.Key("a"), roachpb.Key("b"), roachpb.Key("c")
+
+	s := NewSampler(testConfig{sampleProbability: 0.5, windowDuration: 10 * time.Second}, constRandSource(0))
+
+	// A disabled Sampler does not record anything.
+	s.Record(ms(0), keyA, Read)
+	s.RecordContention(ms(0), keyA)
+	require.False(t, s.Enabled())
+	require.Empty(t, s.TopKeys(ms(0), 10))
+
+	s.SetEnabled(ms(0), true)
+	require.True(t, s.Enabled())
+	for i := 0; i < 10; i++ {
+		s.Record(ms(i), keyA, Read)
+		s.Record(ms(i), keyB, Write)
+		s.Record(ms(i), keyB, Write)
+	}
+	s.Record(ms(10), keyC, Read)
+	s.RecordContention(ms(10), keyC)
+	s.RecordContention(ms(11), keyB)
+
+	// No window has completed yet, so the rates are computed over the minimum
+	// window duration.
+	require.Equal(t, []KeyStats{
+		{Key: keyB, WritesPerSecond: 40, ContentionEvents: 1},
+		{Key: keyA, ReadsPerSecond: 20},
+		{Key: keyC, ReadsPerSecond: 2, ContentionEvents: 1},
+	}, s.TopKeys(ms(500), 10))
+	require.Equal(t, []KeyStats{
+		{Key: keyB, WritesPerSecond: 40, ContentionEvents: 1},
+	}, s.TopKeys(ms(500), 1))
+
+	// Once the window completes, its keys are reported with rates computed over
+	// the full window duration, while new accesses accumulate in a new window.
+	s.Record(ms(10500), keyC, Write)
+	require.Equal(t, []KeyStats{
+		{Key: keyB, WritesPerSecond: 4, ContentionEvents: 1},
+		{Key: keyA, ReadsPerSecond: 2},
+		{Key: keyC, ReadsPerSecond: 0.2, ContentionEvents: 1},
+	}, s.TopKeys(ms(10500), 10))
+
+	// After more than a full idle window, the stale accesses are discarded.
+	require.Empty(t, s.TopKeys(ms(40000), 10))
+
+	// Disabling the Sampler discards all sampled keys.
+	s.Record(ms(40000), keyA, Read)
+	s.SetEnabled(ms(40000), false)
+	s.SetEnabled(ms(40000), true)
+	require.Empty(t, s.TopKeys(ms(40000), 10))
+}
+
+func TestSamplerSampleProbability(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	now := time.Unix(100, 0)
+	s := NewSampler(testConfig{sampleProbability: 0.1, windowDuration: time.Minute}, constRandSource(0.5))
+	s.SetEnabled(now, true)
+
+	// Accesses are not sampled, but contention always is.
+	s.Record(now, roachpb.Key("a"), Read)
+	s.RecordContention(now, roachpb.Key("b"))
+	require.Equal(t, []KeyStats{
+		{Key: roachpb.Key("b"), ContentionEvents: 1},
+	}, s.TopKeys(now, 10))
+}
+
+func TestSamplerMaxTrackedKeys(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	now := time.Unix(100, 0)
+	s := NewSampler(testConfig{sampleProbability: 1, windowDuration: time.Minute}, constRandSource(0))
+	s.SetEnabled(now, true)
+
+	hot := roachpb.Key("hot")
+	for i := 0; i < 10; i++ {
+		s.Record(now, hot, Write)
+	}
+	for i := 0; i < 2*maxTrackedKeys; i++ {
+		s.Record(now, roachpb.Key(fmt.Sprintf("cold%04d", i)), Read)
+	}
+	keys := s.TopKeys(now, 2*maxTrackedKeys)
+	require.Len(t, keys, maxTrackedKeys)
+	require.Equal(t, hot, keys[0].Key)
+	require.Equal(t, float64(10), keys[0].WritesPerSecond)
+}
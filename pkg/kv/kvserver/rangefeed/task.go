@@ -15,6 +15,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/storage"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
@@ -121,10 +122,16 @@ func (s *SeparatedIntentScanner) ConsumeIntents(
 		if err != nil {
 			return err
 		}
-		lockedKey, err := keys.DecodeLockTableSingleKey(engineKey.Key)
+		ltKey, err := engineKey.ToLockTableKey()
 		if err != nil {
-			return errors.Wrapf(err, "decoding LockTable key: %s", lockedKey)
+			return errors.Wrapf(err, "decoding LockTable key: %s", engineKey)
 		}
+		if ltKey.Strength != lock.Intent {
+			// Replicated locks that are not intents never produce a committed
+			// value, so they are not tracked by the rangefeed.
+			continue
+		}
+		lockedKey := ltKey.Key
 
 		v, err := s.iter.UnsafeValue()
 		if err != nil {
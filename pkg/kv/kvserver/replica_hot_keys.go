@@ -0,0 +1,172 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package kvserver
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/allocator/load"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/hotkeys"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// HotKeysReplicasPerStore wraps "kv.hot_keys.replicas_per_store".
+var HotKeysReplicasPerStore = settings.RegisterIntSetting(
+	settings.SystemOnly,
+	"kv.hot_keys.replicas_per_store",
+	"the number of replicas with the highest QPS on each store for which the "+
+		"leaseholder samples the keys accessed by requests; set to 0 to disable "+
+		"hot key sampling",
+	8,
+	settings.NonNegativeInt,
+)
+
+// HotKeysSampleProbability wraps "kv.hot_keys.sample_probability".
+var HotKeysSampleProbability = settings.RegisterFloatSetting(
+	settings.SystemOnly,
+	"kv.hot_keys.sample_probability",
+	"the probability with which each request to a replica that is sampling hot "+
+		"keys is recorded",
+	0.05,
+	settings.NonNegativeFloatWithMaximum(1),
+)
+
+// hotKeysWindowDuration is the duration over which sampled key accesses are
+// aggregated before being reported.
+const hotKeysWindowDuration = time.Minute
+
+// replicaHotKeysConfig implements the hotkeys.Config interface.
+type replicaHotKeysConfig struct {
+	st *cluster.Settings
+}
+
+func newReplicaHotKeysConfig(st *cluster.Settings) *replicaHotKeysConfig {
+	return &replicaHotKeysConfig{st: st}
+}
+
+// SampleProbability returns the probability with which each key access is
+// sampled.
+func (c *replicaHotKeysConfig) SampleProbability() float64 {
+	return HotKeysSampleProbability.Get(&c.st.SV)
+}
+
+// WindowDuration returns the duration of the window over which key accesses
+// are aggregated.
+func (c *replicaHotKeysConfig) WindowDuration() time.Duration {
+	return hotKeysWindowDuration
+}
+
+// recordHotKeys records the keys accessed by the point requests in the batch
+// with the replica's hot key sampler, if it is enabled. Gets are recorded as
+// reads and requests that write intents are recorded as writes. Ranged
+// requests are not recorded, as they do not point at a single hot key.
+func (r *Replica) recordHotKeys(ba *kvpb.BatchRequest) {
+	if !r.hotKeys.Enabled() {
+		return
+	}
+	now := r.Clock().PhysicalTime()
+	for _, union := range ba.Requests {
+		req := union.GetInner()
+		if kvpb.IsRange(req) {
+			continue
+		}
+		var typ hotkeys.AccessType
+		switch {
+		case kvpb.IsIntentWrite(req):
+			typ = hotkeys.Write
+		case req.Method() == kvpb.Get:
+			typ = hotkeys.Read
+		default:
+			continue
+		}
+		r.hotKeys.Record(now, req.Header().Key, typ)
+	}
+}
+
+// hotKeySamplers tracks the replicas on a store whose hot key samplers are
+// enabled.
+type hotKeySamplers struct {
+	mu struct {
+		syncutil.Mutex
+		sampled map[roachpb.RangeID]*Replica
+	}
+}
+
+// update enables the hot key samplers of up to n of the replicas with the
+// highest QPS in the accumulator and disables the samplers of all other
+// replicas that were previously sampling.
+func (h *hotKeySamplers) update(now time.Time, acc *RRAccumulator, n int) {
+	sampled := make(map[roachpb.RangeID]*Replica, n)
+	for _, cr := range consumeAccumulator(acc.dims[load.Queries]) {
+		if len(sampled) >= n {
+			break
+		}
+		// Do not sample ranges that are accessed once or less per second.
+		if cr.RangeUsageInfo().QueriesPerSecond <= 1 {
+			break
+		}
+		sampled[cr.GetRangeID()] = cr.Repl()
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for rangeID, r := range h.mu.sampled {
+		if _, ok := sampled[rangeID]; !ok {
+			r.hotKeys.SetEnabled(now, false)
+		}
+	}
+	for _, r := range sampled {
+		r.hotKeys.SetEnabled(now, true)
+	}
+	h.mu.sampled = sampled
+}
+
+// replicas returns the replicas whose hot key samplers are enabled.
+func (h *hotKeySamplers) replicas() []*Replica {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	repls := make([]*Replica, 0, len(h.mu.sampled))
+	for _, r := range h.mu.sampled {
+		repls = append(repls, r)
+	}
+	return repls
+}
+
+// HotReplicaKeys contains the hottest keys sampled by a replica.
+type HotReplicaKeys struct {
+	Desc *roachpb.RangeDescriptor
+	Keys []hotkeys.KeyStats
+}
+
+// HottestKeys returns up to n of the hottest keys sampled by each of the
+// replicas on the store that are sampling hot keys, ordered by range ID. Only
+// the leaseholder replicas with the highest QPS on the store sample hot keys;
+// see kv.hot_keys.replicas_per_store.
+func (s *Store) HottestKeys(n int) []HotReplicaKeys {
+	now := s.Clock().PhysicalTime()
+	var res []HotReplicaKeys
+	for _, r := range s.hotKeySamplers.replicas() {
+		keys := r.hotKeys.TopKeys(now, n)
+		if len(keys) == 0 {
+			continue
+		}
+		res = append(res, HotReplicaKeys{Desc: r.Desc(), Keys: keys})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Desc.RangeID < res[j].Desc.RangeID
+	})
+	return res
+}
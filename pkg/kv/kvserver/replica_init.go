@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/abortspan"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/closedts/tracker"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/hotkeys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvserverbase"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/kvstorage"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/load"
@@ -80,6 +81,7 @@ func newUninitializedReplica(
 	store *Store, rangeID roachpb.RangeID, replicaID roachpb.ReplicaID,
 ) *Replica {
 	uninitState := stateloader.UninitializedReplicaState(rangeID)
+	hotKeys := hotkeys.NewSampler(newReplicaHotKeysConfig(store.ClusterSettings()), hotkeys.GlobalRandSource())
 	r := &Replica{
 		AmbientContext: store.cfg.AmbientCtx,
 		RangeID:        rangeID,
@@ -87,6 +89,7 @@ func newUninitializedReplica(
 		creationTime:   timeutil.Now(),
 		store:          store,
 		abortSpan:      abortspan.New(rangeID),
+		hotKeys:        hotKeys,
 		concMgr: concurrency.NewManager(concurrency.Config{
 			NodeDesc:          store.nodeDesc,
 			RangeDesc:         uninitState.Desc,
@@ -99,6 +102,9 @@ func newUninitializedReplica(
 			SlowLatchGauge:    store.metrics.SlowLatchRequests,
 			DisableTxnPushing: store.TestingKnobs().DontPushOnWriteIntentError,
 			TxnWaitKnobs:      store.TestingKnobs().TxnWaitKnobs,
+			OnContention: func(key roachpb.Key) {
+				hotKeys.RecordContention(store.Clock().PhysicalTime(), key)
+			},
 		}),
 	}
 	r.sideTransportClosedTimestamp.init(store.cfg.ClosedTimestampReceiver, rangeID)
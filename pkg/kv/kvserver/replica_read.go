@@ -561,6 +561,7 @@ func (r *Replica) collectSpansRead(
 				})
 				getAlloc.get.Key = key
 				getAlloc.get.KeyLocking = req.(kvpb.LockingReadRequest).KeyLockingStrength()
+				getAlloc.get.KeyLockingReplicated = req.(kvpb.LockingReadRequest).KeyLockingDurability() == lock.Replicated
 				getAlloc.union.Get = &getAlloc.get
 				ru := kvpb.RequestUnion{Value: &getAlloc.union}
 				baCopy.Requests = append(baCopy.Requests, ru)
@@ -768,6 +768,7 @@ type Store struct {
 	allocator            allocatorimpl.Allocator // Makes allocation decisions
 	replRankings         *ReplicaRankings
 	replRankingsByTenant *ReplicaRankingMap
+	hotKeySamplers       hotKeySamplers // Replicas sampling hot keys
 	storeRebalancer      *StoreRebalancer
 	rangeIDAlloc         *idalloc.Allocator // Range ID allocator
 	mvccGCQueue          *mvccGCQueue       // MVCC GC queue
@@ -2711,12 +2712,15 @@ func (s *Store) Capacity(ctx context.Context, useCached bool) (roachpb.StoreCapa
 	// QPS.
 	rankingsAccumulator := NewReplicaAccumulator(load.CPU, load.Queries)
 	rankingsByTenantAccumulator := NewTenantReplicaAccumulator()
+	// Hot keys are sampled by the leaseholder replicas with the highest QPS.
+	hotKeysAccumulator := NewReplicaAccumulator(load.Queries)
 
 	// Query the current L0 sublevels and record the updated maximum to metrics.
 	l0SublevelsMax = int64(syncutil.LoadFloat64(&s.metrics.l0SublevelsWindowedMax))
 	newStoreReplicaVisitor(s).Visit(func(r *Replica) bool {
 		rangeCount++
-		if r.OwnsValidLease(ctx, now) {
+		ownsValidLease := r.OwnsValidLease(ctx, now)
+		if ownsValidLease {
 			leaseCount++
 		}
 		usage := r.RangeUsageInfo()
@@ -2737,6 +2741,9 @@ func (s *Store) Capacity(ctx context.Context, useCached bool) (roachpb.StoreCapa
 		}
 		rankingsAccumulator.AddReplica(cr)
 		rankingsByTenantAccumulator.AddReplica(cr)
+		if ownsValidLease {
+			hotKeysAccumulator.AddReplica(cr)
+		}
 		return true
 	})
 
@@ -2769,6 +2776,8 @@ func (s *Store) Capacity(ctx context.Context, useCached bool) (roachpb.StoreCapa
 	s.storeGossip.RecordNewPerSecondStats(totalQueriesPerSecond, totalWritesPerSecond)
 	s.replRankings.Update(rankingsAccumulator)
 	s.replRankingsByTenant.Update(rankingsByTenantAccumulator)
+	s.hotKeySamplers.update(s.Clock().PhysicalTime(), hotKeysAccumulator,
+		int(HotKeysReplicasPerStore.Get(&s.cfg.Settings.SV)))
 
 	s.storeGossip.UpdateCachedCapacity(capacity)
 
@@ -282,8 +282,14 @@ type TxnSender interface {
 	// Step() can only be called after stepping mode has been enabled
 	// using ConfigureStepping(SteppingEnabled).
 	//
+	// If allowReadTimestampStep is set and the transaction's isolation level
+	// establishes a new read snapshot for each statement (see
+	// isolation.Level.PerStatementReadSnapshot), the transaction's read
+	// timestamp is also advanced to the current time, so that subsequent
+	// reads observe all writes committed before the step.
+	//
 	// The method is idempotent.
-	Step(context.Context) error
+	Step(ctx context.Context, allowReadTimestampStep bool) error
 
 	// GetReadSeqNum gets the read sequence point for the current transaction.
 	GetReadSeqNum() enginepb.TxnSeq
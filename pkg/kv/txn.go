@@ -1041,6 +1041,16 @@ func (txn *Txn) PrepareForRetry(ctx context.Context) {
 		)
 	}
 
+	if !retryErr.TxnMustRestartFromBeginning() {
+		// The retryable error allowed for a partial retry of the transaction, but
+		// the caller is retrying the transaction from the beginning. Restart the
+		// transaction manually so that the writes performed by the previous
+		// attempt are discarded.
+		txn.mu.sender.ClearTxnRetryableErr(ctx)
+		txn.mu.sender.ManualRestart(ctx, txn.mu.userPriority, retryErr.Transaction.WriteTimestamp)
+		return
+	}
+
 	if !retryErr.PrevTxnAborted() {
 		// If the retryable error doesn't correspond to an aborted transaction,
 		// there's no need to switch out the transaction. We simply clear the
@@ -1052,6 +1062,35 @@ func (txn *Txn) PrepareForRetry(ctx context.Context) {
 	txn.handleTransactionAbortedErrorLocked(ctx, retryErr)
 }
 
+// PrepareForPartialRetry is like PrepareForRetry, except that it is used when
+// only the current statement of the transaction is being retried. It must only
+// be called after a retryable error that allows for partial retries (see
+// TransactionRetryWithProtoRefreshError.TxnMustRestartFromBeginning) and after
+// the transaction has been rolled back to a savepoint taken at the beginning of
+// the statement.
+func (txn *Txn) PrepareForPartialRetry(ctx context.Context) error {
+	if txn.typ != RootTxn {
+		return errors.WithContextTags(
+			errors.AssertionFailedf("PrepareForPartialRetry() called on leaf txn"), ctx)
+	}
+
+	txn.mu.Lock()
+	defer txn.mu.Unlock()
+
+	retryErr := txn.mu.sender.GetTxnRetryableErr(ctx)
+	if retryErr == nil {
+		return nil
+	}
+	if retryErr.TxnMustRestartFromBeginning() {
+		return errors.WithContextTags(errors.NewAssertionErrorWithWrappedErrf(
+			retryErr, "PrepareForPartialRetry() called on txn that must restart from the beginning"), ctx)
+	}
+	log.VEventf(ctx, 2, "partially retrying transaction: %s because of a retryable error: %s",
+		txn.debugNameLocked(), retryErr)
+	txn.mu.sender.ClearTxnRetryableErr(ctx)
+	return nil
+}
+
 // Send runs the specified calls synchronously in a single batch and
 // returns any errors. If the transaction is read-only or has already
 // been successfully committed or aborted, a potential trailing
@@ -1462,10 +1501,14 @@ func (txn *Txn) Active() bool {
 //
 // In step-wise execution, reads operate at a snapshot established at
 // the last step, instead of the latest write if not yet enabled.
-func (txn *Txn) Step(ctx context.Context) error {
+//
+// If allowReadTimestampStep is set and the transaction runs under an isolation
+// level that establishes a new read snapshot for each statement, the step also
+// advances the transaction's read timestamp. See TxnSender.Step.
+func (txn *Txn) Step(ctx context.Context, allowReadTimestampStep bool) error {
 	txn.mu.Lock()
 	defer txn.mu.Unlock()
-	return txn.mu.sender.Step(ctx)
+	return txn.mu.sender.Step(ctx, allowReadTimestampStep)
 }
 
 // GetReadSeqNum gets the read sequence number for this transaction.
@@ -1946,6 +1946,24 @@ func MakeIntent(txn *enginepb.TxnMeta, key Key) Intent {
 	return i
 }
 
+// MakeReplicatedLock makes an Intent which describes a replicated, non-intent
+// lock with the given strength held by the txn on the key.
+func MakeReplicatedLock(txn *enginepb.TxnMeta, key Key, str lock.Strength) Intent {
+	i := MakeIntent(txn, key)
+	i.Strength = str
+	return i
+}
+
+// LockStrength returns the strength of the replicated lock described by the
+// Intent, which is lock.Intent unless the Intent was made by
+// MakeReplicatedLock.
+func (i *Intent) LockStrength() lock.Strength {
+	if i.Strength == lock.None {
+		return lock.Intent
+	}
+	return i.Strength
+}
+
 // AsIntents takes a transaction and a slice of keys and
 // returns it as a slice of intents.
 func AsIntents(txn *enginepb.TxnMeta, keys []Key) []Intent {
@@ -1957,16 +1975,15 @@ func AsIntents(txn *enginepb.TxnMeta, keys []Key) []Intent {
 }
 
 // MakeLockAcquisition makes a lock acquisition message from the given
-// txn, key, and durability level.
-func MakeLockAcquisition(txn *Transaction, key Key, dur lock.Durability) LockAcquisition {
+// txn, key, durability level, and lock strength.
+func MakeLockAcquisition(
+	txn *Transaction, key Key, dur lock.Durability, str lock.Strength,
+) LockAcquisition {
 	return LockAcquisition{
-		Span:       Span{Key: key},
-		Txn:        txn.TxnMeta,
-		Durability: dur,
-		// TODO(arul): The lock table only supports/expects locks with Intent lock
-		// strength. This will change once we generalize the lock table for
-		// different strengths.
-		Strength:       lock.Intent,
+		Span:           Span{Key: key},
+		Txn:            txn.TxnMeta,
+		Durability:     dur,
+		Strength:       str,
 		IgnoredSeqNums: txn.IgnoredSeqNums,
 	}
 }
@@ -84,6 +84,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/gcjob/gcjobnotifier"
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire"
 	"github.com/cockroachdb/cockroach/pkg/sql/querycache"
 	"github.com/cockroachdb/cockroach/pkg/sql/rangeprober"
@@ -1031,6 +1032,12 @@ func newSQLServer(ctx context.Context, cfg sqlServerArgs) (*SQLServer, error) {
 		AutoConfigProvider:         cfg.AutoConfigProvider,
 	}
 
+	execCfg.NotificationRegistry = pgnotify.NewRegistry(
+		cfg.AmbientCtx, codec, cfg.clock, cfg.stopper, cfg.internalDB,
+		execCfg.SystemTableIDResolver, cfg.rangeFeedFactory,
+		cfg.isMeta1Leaseholder, cfg.nodeIDContainer, cfg.sqlInstanceReader,
+	)
+
 	if sqlSchemaChangerTestingKnobs := cfg.TestingKnobs.SQLSchemaChanger; sqlSchemaChangerTestingKnobs != nil {
 		execCfg.SchemaChangerTestingKnobs = sqlSchemaChangerTestingKnobs.(*sql.SchemaChangerTestingKnobs)
 	} else {
@@ -1530,6 +1537,9 @@ func (s *SQLServer) preStart(
 	s.sqlInstanceReader.Start(ctx, instance)
 
 	s.execCfg.GCJobNotifier.Start(ctx)
+	if err := s.execCfg.NotificationRegistry.Start(ctx); err != nil {
+		return err
+	}
 	s.temporaryObjectCleaner.Start(ctx, stopper)
 	s.distSQLServer.Start()
 	s.pgServer.Start(ctx, stopper)
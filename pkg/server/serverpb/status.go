@@ -67,10 +67,11 @@ func MakeOptionalNodesStatusServer(s NodesStatusServer) OptionalNodesStatusServe
 }
 
 // NodesStatusServer is an endpoint that allows the SQL subsystem
-// to observe node descriptors.
+// to observe node descriptors and the hot keys on each node.
 // It is unavailable to tenants.
 type NodesStatusServer interface {
 	ListNodesInternal(context.Context, *NodesRequest) (*NodesResponse, error)
+	HotKeys(context.Context, *HotKeysRequest) (*HotKeysResponse, error)
 }
 
 // TenantStatusServer is the subset of the serverpb.StatusServer that is
@@ -2722,6 +2722,102 @@ func (s *systemStatusServer) localHotRanges(
 	return resp
 }
 
+// defaultHotKeysPerRange is the number of keys returned for each range by
+// HotKeys if the request does not specify a limit.
+const defaultHotKeysPerRange = 10
+
+// HotKeys returns the hottest keys sampled by the leaseholders of the hottest
+// ranges on each store on the requested node(s).
+func (s *systemStatusServer) HotKeys(
+	ctx context.Context, req *serverpb.HotKeysRequest,
+) (*serverpb.HotKeysResponse, error) {
+	ctx = forwardSQLIdentityThroughRPCCalls(ctx)
+	ctx = s.AnnotateCtx(ctx)
+
+	if err := s.privilegeChecker.requireViewClusterMetadataPermission(ctx); err != nil {
+		// NB: not using serverError() here since the priv checker
+		// already returns a proper gRPC error status.
+		return nil, err
+	}
+
+	if len(req.NodeID) > 0 {
+		requestedNodeID, local, err := s.parseNodeID(req.NodeID)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, err.Error())
+		}
+
+		// Only hot keys from the local node.
+		if local {
+			return s.localHotKeys(requestedNodeID, req.KeysPerRange)
+		}
+
+		// Only hot keys from one non-local node.
+		status, err := s.dialNode(ctx, requestedNodeID)
+		if err != nil {
+			return nil, serverError(ctx, err)
+		}
+		return status.HotKeys(ctx, req)
+	}
+
+	// Hot keys from all nodes.
+	response := &serverpb.HotKeysResponse{}
+	dialFn := func(ctx context.Context, nodeID roachpb.NodeID) (interface{}, error) {
+		client, err := s.dialNode(ctx, nodeID)
+		return client, err
+	}
+	remoteRequest := serverpb.HotKeysRequest{NodeID: "local", KeysPerRange: req.KeysPerRange}
+	nodeFn := func(ctx context.Context, client interface{}, _ roachpb.NodeID) (interface{}, error) {
+		status := client.(serverpb.StatusClient)
+		return status.HotKeys(ctx, &remoteRequest)
+	}
+	responseFn := func(nodeID roachpb.NodeID, resp interface{}) {
+		hotKeysResp := resp.(*serverpb.HotKeysResponse)
+		response.HotKeys = append(response.HotKeys, hotKeysResp.HotKeys...)
+		response.Errors = append(response.Errors, hotKeysResp.Errors...)
+	}
+	errorFn := func(nodeID roachpb.NodeID, err error) {
+		response.Errors = append(response.Errors, serverpb.ListActivityError{
+			NodeID:  nodeID,
+			Message: err.Error(),
+		})
+	}
+
+	if err := s.iterateNodes(ctx, "hot keys", dialFn, nodeFn, responseFn, errorFn); err != nil {
+		return nil, serverError(ctx, err)
+	}
+	return response, nil
+}
+
+func (s *systemStatusServer) localHotKeys(
+	nodeID roachpb.NodeID, keysPerRange int32,
+) (*serverpb.HotKeysResponse, error) {
+	n := int(keysPerRange)
+	if n <= 0 {
+		n = defaultHotKeysPerRange
+	}
+	resp := &serverpb.HotKeysResponse{}
+	err := s.stores.VisitStores(func(store *kvserver.Store) error {
+		for _, r := range store.HottestKeys(n) {
+			for _, k := range r.Keys {
+				resp.HotKeys = append(resp.HotKeys, serverpb.HotKeysResponse_HotKey{
+					NodeID:           nodeID,
+					StoreID:          store.StoreID(),
+					RangeID:          r.Desc.RangeID,
+					Key:              k.Key,
+					ReadsPerSecond:   k.ReadsPerSecond,
+					WritesPerSecond:  k.WritesPerSecond,
+					ContentionEvents: k.ContentionEvents,
+				})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (s *statusServer) KeyVisSamples(
 	ctx context.Context, req *serverpb.KeyVisSamplesRequest,
 ) (*serverpb.KeyVisSamplesResponse, error) {
@@ -22,7 +22,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
-	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
 )
@@ -63,7 +62,7 @@ func (p *planner) AlterFunctionOptions(
 }
 
 func (n *alterFunctionOptionsNode) startExec(params runParams) error {
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, false /* isAggregate */)
 	if err != nil {
 		return err
 	}
@@ -150,7 +149,7 @@ func (n *alterFunctionRenameNode) startExec(params runParams) error {
 	// TODO(chengxiong): add validation that a function can not be altered if it's
 	// referenced by other objects. This is needed when want to allow function
 	// references.
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, n.n.IsAggregate)
 	if err != nil {
 		return err
 	}
@@ -220,7 +219,7 @@ func (p *planner) AlterFunctionSetOwner(
 }
 
 func (n *alterFunctionSetOwnerNode) startExec(params runParams) error {
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, n.n.IsAggregate)
 	if err != nil {
 		return err
 	}
@@ -283,7 +282,7 @@ func (n *alterFunctionSetSchemaNode) startExec(params runParams) error {
 	// TODO(chengxiong): add validation that a function can not be altered if it's
 	// referenced by other objects. This is needed when want to allow function
 	// references.
-	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function)
+	fnDesc, err := params.p.mustGetMutableFunctionForAlter(params.ctx, &n.n.Function, n.n.IsAggregate)
 	if err != nil {
 		return err
 	}
@@ -392,12 +391,18 @@ func (n *alterFunctionDepExtensionNode) Values() tree.Datums                 { r
 func (n *alterFunctionDepExtensionNode) Close(ctx context.Context)           {}
 
 func (p *planner) mustGetMutableFunctionForAlter(
-	ctx context.Context, funcObj *tree.FuncObj,
+	ctx context.Context, funcObj *tree.FuncObj, isAggregate bool,
 ) (*funcdesc.Mutable, error) {
 	ol, err := p.matchUDF(ctx, funcObj, true /*required*/)
 	if err != nil {
 		return nil, err
 	}
+	if err := checkRoutineKind(ol, funcObj, false /* isProcedure */); err != nil {
+		return nil, err
+	}
+	if err := checkAggregateKind(ol, funcObj, isAggregate, "ALTER"); err != nil {
+		return nil, err
+	}
 	fnID := funcdesc.UserDefinedFunctionOIDToID(ol.Oid)
 	mut, err := p.checkPrivilegesForDropFunction(ctx, fnID)
 	if err != nil {
@@ -408,13 +413,12 @@ func (p *planner) mustGetMutableFunctionForAlter(
 
 func toSchemaOverloadSignature(fnDesc *funcdesc.Mutable) descpb.SchemaDescriptor_FunctionSignature {
 	ret := descpb.SchemaDescriptor_FunctionSignature{
-		ID:         fnDesc.GetID(),
-		ArgTypes:   make([]*types.T, len(fnDesc.GetParams())),
-		ReturnType: fnDesc.ReturnType.Type,
-		ReturnSet:  fnDesc.ReturnType.ReturnSet,
-	}
-	for i := range fnDesc.Params {
-		ret.ArgTypes[i] = fnDesc.Params[i].Type
+		ID:          fnDesc.GetID(),
+		ArgTypes:    funcdesc.InParamTypes(fnDesc.Params),
+		ReturnType:  fnDesc.ReturnType.Type,
+		ReturnSet:   fnDesc.ReturnType.ReturnSet,
+		IsProcedure: fnDesc.IsProcedure,
+		IsAggregate: fnDesc.Aggregate != nil,
 	}
 	return ret
 }
@@ -0,0 +1,124 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+type alterPolicyNode struct {
+	n         *tree.AlterPolicy
+	tableDesc *tabledesc.Mutable
+	// policy is the updated policy, which replaces the policy with the same ID
+	// in the table descriptor.
+	policy descpb.PolicyDescriptor
+}
+
+// AlterPolicy renames a row-level security policy or changes its roles or
+// expressions.
+// Privileges: CREATE on table.
+//
+//	notes: postgres requires ownership of the table.
+func (p *planner) AlterPolicy(ctx context.Context, n *tree.AlterPolicy) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER POLICY",
+	); err != nil {
+		return nil, err
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &n.Table, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	// Disallow schema changes if this table's schema is locked.
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, err
+	}
+	existing := tableDesc.FindPolicyByName(string(n.Name))
+	if existing == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"policy %q for table %q does not exist", n.Name, tableDesc.Name)
+	}
+	policy := *existing
+	policy.RoleNames = append([]string(nil), existing.RoleNames...)
+
+	if n.NewName != "" {
+		if n.NewName != n.Name && tableDesc.FindPolicyByName(string(n.NewName)) != nil {
+			return nil, pgerror.Newf(pgcode.DuplicateObject,
+				"policy %q for table %q already exists", n.NewName, tableDesc.Name)
+		}
+		policy.Name = string(n.NewName)
+		return &alterPolicyNode{n: n, tableDesc: tableDesc, policy: policy}, nil
+	}
+
+	switch policy.Command {
+	case descpb.PolicyDescriptor_SELECT, descpb.PolicyDescriptor_DELETE:
+		if n.WithCheck != nil {
+			return nil, pgerror.New(pgcode.Syntax,
+				"WITH CHECK cannot be applied to SELECT or DELETE")
+		}
+	case descpb.PolicyDescriptor_INSERT:
+		if n.Using != nil {
+			return nil, pgerror.New(pgcode.Syntax,
+				"only WITH CHECK expression allowed for INSERT")
+		}
+	}
+	if len(n.Roles) > 0 {
+		if policy.RoleNames, err = p.resolvePolicyRoles(ctx, n.Roles); err != nil {
+			return nil, err
+		}
+	}
+	if n.Using != nil {
+		if policy.UsingExpr, err = p.validatePolicyExpr(ctx, tableDesc, &n.Table, n.Using); err != nil {
+			return nil, err
+		}
+	}
+	if n.WithCheck != nil {
+		if policy.WithCheckExpr, err = p.validatePolicyExpr(ctx, tableDesc, &n.Table, n.WithCheck); err != nil {
+			return nil, err
+		}
+	}
+	return &alterPolicyNode{n: n, tableDesc: tableDesc, policy: policy}, nil
+}
+
+func (n *alterPolicyNode) ReadingOwnWrites() {}
+
+func (n *alterPolicyNode) startExec(params runParams) error {
+	for i := range n.tableDesc.Policies {
+		if n.tableDesc.Policies[i].ID == n.policy.ID {
+			n.tableDesc.Policies[i] = n.policy
+			break
+		}
+	}
+	return params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, descpb.InvalidMutationID,
+		fmt.Sprintf("altering policy %q on table %q", n.n.Name, n.tableDesc.GetName()),
+	)
+}
+
+func (*alterPolicyNode) Next(params runParams) (bool, error) { return false, nil }
+func (*alterPolicyNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*alterPolicyNode) Close(ctx context.Context)           {}
@@ -0,0 +1,150 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+type alterPublicationNode struct {
+	n      *tree.AlterPublication
+	dbDesc *dbdesc.Mutable
+	// publication points into the publications of dbDesc, and is updated in
+	// place.
+	publication *descpb.PublicationDescriptor
+}
+
+// AlterPublication alters a publication of the current database.
+// Privileges: ownership of the publication and of the added tables.
+func (p *planner) AlterPublication(ctx context.Context, n *tree.AlterPublication) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER PUBLICATION",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.mutablePublicationDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	publication := dbDesc.FindPublicationByName(string(n.Name))
+	if publication == nil {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"publication %q does not exist", n.Name)
+	}
+	if err := p.checkPublicationOwnership(ctx, publication); err != nil {
+		return nil, err
+	}
+
+	switch n.Cmd {
+	case tree.AlterPublicationAddTables, tree.AlterPublicationDropTables,
+		tree.AlterPublicationSetTables:
+		if publication.AllTables {
+			return nil, errors.WithDetail(
+				pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+					"publication %q is defined as FOR ALL TABLES", publication.Name),
+				"Tables cannot be added to or dropped from FOR ALL TABLES publications.")
+		}
+	}
+
+	switch n.Cmd {
+	case tree.AlterPublicationAddTables:
+		ids, err := p.resolvePublicationTables(ctx, dbDesc, n.Tables)
+		if err != nil {
+			return nil, err
+		}
+		for i, id := range ids {
+			if publicationHasTable(publication, id) {
+				return nil, pgerror.Newf(pgcode.DuplicateObject,
+					"relation %q is already member of publication %q",
+					n.Tables[i].ObjectName, publication.Name)
+			}
+		}
+		publication.TableIDs = append(publication.TableIDs, ids...)
+
+	case tree.AlterPublicationDropTables:
+		for i := range n.Tables {
+			tn := &n.Tables[i]
+			table, err := p.ResolveExistingObjectEx(
+				ctx, tn.ToUnresolvedObjectName(), true /* required */, tree.ResolveRequireTableDesc,
+			)
+			if err != nil {
+				return nil, err
+			}
+			if !publicationHasTable(publication, table.GetID()) {
+				return nil, pgerror.Newf(pgcode.UndefinedObject,
+					"relation %q is not part of the publication", table.GetName())
+			}
+			tableIDs := publication.TableIDs[:0]
+			for _, id := range publication.TableIDs {
+				if id != table.GetID() {
+					tableIDs = append(tableIDs, id)
+				}
+			}
+			publication.TableIDs = tableIDs
+		}
+
+	case tree.AlterPublicationSetTables:
+		if publication.TableIDs, err = p.resolvePublicationTables(ctx, dbDesc, n.Tables); err != nil {
+			return nil, err
+		}
+
+	case tree.AlterPublicationSetOptions:
+		if err := p.evalPublicationOptions(ctx, "ALTER PUBLICATION", n.Options, publication); err != nil {
+			return nil, err
+		}
+
+	case tree.AlterPublicationRename:
+		if dbDesc.FindPublicationByName(string(n.NewName)) != nil {
+			return nil, pgerror.Newf(pgcode.DuplicateObject,
+				"publication %q already exists", n.NewName)
+		}
+		publication.Name = string(n.NewName)
+
+	default:
+		return nil, errors.AssertionFailedf("unknown ALTER PUBLICATION command %d", n.Cmd)
+	}
+
+	return &alterPublicationNode{n: n, dbDesc: dbDesc, publication: publication}, nil
+}
+
+// publicationHasTable returns whether the table is listed in the publication.
+func publicationHasTable(publication *descpb.PublicationDescriptor, id descpb.ID) bool {
+	for _, tableID := range publication.TableIDs {
+		if tableID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *alterPublicationNode) ReadingOwnWrites() {}
+
+func (n *alterPublicationNode) startExec(params runParams) error {
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx, n.dbDesc,
+		fmt.Sprintf("altering publication %q in database %q", n.n.Name, n.dbDesc.GetName()),
+	)
+}
+
+func (*alterPublicationNode) Next(params runParams) (bool, error) { return false, nil }
+func (*alterPublicationNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*alterPublicationNode) Close(ctx context.Context)           {}
@@ -103,6 +103,13 @@ func (p *planner) AlterTable(ctx context.Context, n *tree.AlterTable) (planNode,
 			tree.Name(tableDesc.GetName()), tree.Name(tableDesc.GetName()))
 	}
 
+	// The schema of a foreign table describes the file it is read from, so it
+	// cannot be altered.
+	if tableDesc.IsForeignTable() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"cannot alter foreign table %q", tableDesc.GetName())
+	}
+
 	// Disallow schema changes if this table's schema is locked, unless it is to
 	// set/reset the "schema_locked" storage parameter.
 	if err = checkTableSchemaUnlocked(tableDesc); err != nil && !isSetOrResetSchemaLocked(n) {
@@ -256,6 +263,9 @@ func (n *alterTableNode) startExec(params runParams) error {
 					continue
 				}
 
+				if d.Deferrability != tree.ConstraintNotDeferrable {
+					return sqlerrors.NewUnsupportedDeferrableUniqueIndexError()
+				}
 				if t.ValidationBehavior == tree.ValidationSkip {
 					return sqlerrors.NewUnsupportedUnvalidatedConstraintError(catconstants.ConstraintTypeUnique)
 				}
@@ -367,6 +377,27 @@ func (n *alterTableNode) startExec(params runParams) error {
 						return err
 					}
 				}
+			case *tree.ExclusionConstraintTableDef:
+				if t.ValidationBehavior == tree.ValidationSkip {
+					return sqlerrors.NewUnsupportedUnvalidatedConstraintError(catconstants.ConstraintTypeExclusion)
+				}
+				idx, err := makeExclusionConstraintIndexDescriptor(
+					params.ctx, params.ExecCfg().Settings, n.tableDesc, d,
+				)
+				if err != nil {
+					return err
+				}
+				idx.CreatedAtNanos = params.EvalContext().GetTxnTimestamp(time.Microsecond).UnixNano()
+				if err := n.tableDesc.AddIndexMutationMaybeWithTempIndex(
+					&idx, descpb.DescriptorMutation_ADD,
+				); err != nil {
+					return err
+				}
+				version := params.ExecCfg().Settings.Version.ActiveVersion(params.ctx)
+				if err := n.tableDesc.AllocateIDs(params.ctx, version); err != nil {
+					return err
+				}
+
 			case *tree.CheckConstraintTableDef:
 				var err error
 				params.p.runWithOptions(resolveFlags{contextDatabaseID: n.tableDesc.ParentID}, func() {
@@ -704,6 +735,26 @@ func (n *alterTableNode) startExec(params runParams) error {
 			}
 			descriptorChanged = descriptorChanged || changed
 
+		case *tree.AlterTableRowLevelSecurity:
+			if !params.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.V23_2_RowLevelSecurity) {
+				return pgerror.Newf(pgcode.FeatureNotSupported,
+					"%s ROW LEVEL SECURITY is not supported until the cluster version is finalized", t.Mode)
+			}
+			switch t.Mode {
+			case tree.RowLevelSecurityEnable:
+				descriptorChanged = descriptorChanged || !n.tableDesc.RowLevelSecurityEnabled
+				n.tableDesc.RowLevelSecurityEnabled = true
+			case tree.RowLevelSecurityDisable:
+				descriptorChanged = descriptorChanged || n.tableDesc.RowLevelSecurityEnabled
+				n.tableDesc.RowLevelSecurityEnabled = false
+			case tree.RowLevelSecurityForce:
+				descriptorChanged = descriptorChanged || !n.tableDesc.RowLevelSecurityForced
+				n.tableDesc.RowLevelSecurityForced = true
+			case tree.RowLevelSecurityNoForce:
+				descriptorChanged = descriptorChanged || n.tableDesc.RowLevelSecurityForced
+				n.tableDesc.RowLevelSecurityForced = false
+			}
+
 		case *tree.AlterTableInjectStats:
 			sd, ok := n.statsData[i]
 			if !ok {
@@ -1500,6 +1551,25 @@ func validateConstraintNameIsNotUsed(
 		}
 		return false, pgerror.Newf(pgcode.DuplicateRelation, "constraint with name %q already exists", name)
 
+	case *tree.ExclusionConstraintTableDef:
+		name = d.Name
+		hasIfNotExists = d.IfNotExists
+		if name == "" {
+			return false, nil
+		}
+		// Exclusion constraints are backed by an index with the same name.
+		idx := catalog.FindIndexByName(tableDesc, string(name))
+		if idx == nil {
+			break
+		}
+		if d.IfNotExists {
+			return true, nil
+		}
+		if idx.Dropped() {
+			return false, pgerror.Newf(pgcode.DuplicateObject, "constraint with name %q already exists and is being dropped, try again later", name)
+		}
+		return false, pgerror.Newf(pgcode.DuplicateRelation, "constraint with name %q already exists", name)
+
 	default:
 		return false, errors.AssertionFailedf(
 			"unsupported constraint: %T", cmd.ConstraintDef)
@@ -1683,6 +1753,9 @@ func dropColumnImpl(
 	if err := schemaexpr.ValidateTTLExpressionDoesNotDependOnColumn(tableDesc, rowLevelTTL, colToDrop); err != nil {
 		return nil, err
 	}
+	if err := schemaexpr.ValidatePoliciesDoNotDependOnColumn(tableDesc, colToDrop); err != nil {
+		return nil, err
+	}
 
 	if tableDesc.GetPrimaryIndex().CollectKeyColumnIDs().Contains(colToDrop.GetID()) {
 		return nil, sqlerrors.NewColumnReferencedByPrimaryKeyError(colToDrop.GetName())
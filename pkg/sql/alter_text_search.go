@@ -0,0 +1,303 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+)
+
+type alterTextSearchConfigNode struct {
+	schema *schemadesc.Mutable
+	name   string
+}
+
+// AlterTextSearchConfig changes the mappings of a text search configuration.
+// Privileges: ownership of the configuration.
+func (p *planner) AlterTextSearchConfig(
+	ctx context.Context, n *tree.AlterTextSearchConfig,
+) (planNode, error) {
+	if err := p.checkTextSearchObjectsSupported(ctx, "ALTER TEXT SEARCH CONFIGURATION"); err != nil {
+		return nil, err
+	}
+	schema, err := p.textSearchSchema(ctx, n.Name, false /* create */)
+	if err != nil {
+		return nil, err
+	}
+	// config points into the configurations of schema, and is updated in
+	// place.
+	config := schema.FindTextSearchConfigByName(n.Name.Object())
+	if config == nil {
+		return nil, tsearch.UndefinedConfigError(n.Name.String())
+	}
+	if err := p.checkTextSearchObjectOwnership(
+		ctx, "configuration", config.Name, config.OwnerProto,
+	); err != nil {
+		return nil, err
+	}
+
+	tokenTypes := make([]tsearch.TokenType, len(n.TokenTypes))
+	for i, name := range n.TokenTypes {
+		if tokenTypes[i], err = tsearch.TokenTypeByName(string(name)); err != nil {
+			return nil, err
+		}
+	}
+	var dicts []descpb.TextSearchConfigDescriptor_DictionaryRef
+	for i := range n.Dictionaries {
+		ref, err := p.textSearchDictionaryRef(ctx, schema, &n.Dictionaries[i])
+		if err != nil {
+			return nil, err
+		}
+		dicts = append(dicts, ref)
+	}
+
+	switch n.Cmd {
+	case tree.AlterTextSearchConfigAddMapping:
+		for _, t := range tokenTypes {
+			if findTextSearchMapping(config, t) != nil {
+				return nil, pgerror.Newf(pgcode.DuplicateObject,
+					"mapping for token type %q already exists", t)
+			}
+			config.Mappings = append(config.Mappings, descpb.TextSearchConfigDescriptor_Mapping{
+				TokenType:    t.String(),
+				Dictionaries: append([]descpb.TextSearchConfigDescriptor_DictionaryRef(nil), dicts...),
+			})
+		}
+
+	case tree.AlterTextSearchConfigAlterMapping:
+		for _, t := range tokenTypes {
+			m := findTextSearchMapping(config, t)
+			if m == nil {
+				return nil, pgerror.Newf(pgcode.UndefinedObject,
+					"mapping for token type %q does not exist", t)
+			}
+			m.Dictionaries = append([]descpb.TextSearchConfigDescriptor_DictionaryRef(nil), dicts...)
+		}
+
+	case tree.AlterTextSearchConfigReplaceDictionary:
+		oldName := n.OldDictionary.ToTableName()
+		oldDict, err := p.textSearchDictionaryRef(ctx, schema, &oldName)
+		if err != nil {
+			return nil, err
+		}
+		newName := n.NewDictionary.ToTableName()
+		newDict, err := p.textSearchDictionaryRef(ctx, schema, &newName)
+		if err != nil {
+			return nil, err
+		}
+		for i := range config.Mappings {
+			m := &config.Mappings[i]
+			if len(tokenTypes) > 0 && !mappingHasTokenType(m, tokenTypes) {
+				continue
+			}
+			for j := range m.Dictionaries {
+				if ref := m.Dictionaries[j]; ref.Name == oldDict.Name && ref.Builtin == oldDict.Builtin {
+					m.Dictionaries[j] = newDict
+				}
+			}
+		}
+
+	case tree.AlterTextSearchConfigDropMapping:
+		for _, t := range tokenTypes {
+			if findTextSearchMapping(config, t) == nil {
+				if n.IfExists {
+					continue
+				}
+				return nil, pgerror.Newf(pgcode.UndefinedObject,
+					"mapping for token type %q does not exist", t)
+			}
+			removeTextSearchMapping(config, t)
+		}
+	}
+	sortTextSearchMappings(config)
+
+	return &alterTextSearchConfigNode{schema: schema, name: config.Name}, nil
+}
+
+// textSearchDictionaryRef returns a reference to the dictionary with the given
+// name, for use in a configuration of the given schema. As pg_catalog comes
+// first in the search path, an unqualified name refers to a built-in
+// dictionary if there is one with that name. Otherwise, the dictionary must be
+// defined in the schema of the configuration.
+func (p *planner) textSearchDictionaryRef(
+	ctx context.Context, schema *schemadesc.Mutable, tn *tree.TableName,
+) (descpb.TextSearchConfigDescriptor_DictionaryRef, error) {
+	name := tn.Object()
+	if !tn.ExplicitSchema || tn.Schema() == catconstants.PgCatalogName {
+		if _, ok := tsearch.BuiltinDictionary(name); ok {
+			return descpb.TextSearchConfigDescriptor_DictionaryRef{Name: name, Builtin: true}, nil
+		}
+		if tn.ExplicitSchema {
+			return descpb.TextSearchConfigDescriptor_DictionaryRef{}, tsearch.UndefinedDictionaryError(tn.String())
+		}
+	} else {
+		dictSchema, err := p.textSearchSchema(ctx, tn.ToUnresolvedObjectName(), false /* create */)
+		if err != nil {
+			return descpb.TextSearchConfigDescriptor_DictionaryRef{}, err
+		}
+		if dictSchema.GetID() != schema.GetID() {
+			return descpb.TextSearchConfigDescriptor_DictionaryRef{}, pgerror.Newf(pgcode.FeatureNotSupported,
+				"text search dictionary %s is not in the schema of the configuration", tn)
+		}
+	}
+	if schema.FindTextSearchDictionaryByName(name) == nil {
+		return descpb.TextSearchConfigDescriptor_DictionaryRef{}, tsearch.UndefinedDictionaryError(tn.String())
+	}
+	return descpb.TextSearchConfigDescriptor_DictionaryRef{Name: name}, nil
+}
+
+// findTextSearchMapping returns the mapping of the given token type in the
+// configuration, or nil if there is none.
+func findTextSearchMapping(
+	config *descpb.TextSearchConfigDescriptor, t tsearch.TokenType,
+) *descpb.TextSearchConfigDescriptor_Mapping {
+	for i := range config.Mappings {
+		if config.Mappings[i].TokenType == t.String() {
+			return &config.Mappings[i]
+		}
+	}
+	return nil
+}
+
+// removeTextSearchMapping removes the mapping of the given token type from the
+// configuration.
+func removeTextSearchMapping(config *descpb.TextSearchConfigDescriptor, t tsearch.TokenType) {
+	for i := range config.Mappings {
+		if config.Mappings[i].TokenType == t.String() {
+			config.Mappings = append(config.Mappings[:i], config.Mappings[i+1:]...)
+			return
+		}
+	}
+}
+
+// mappingHasTokenType returns whether the mapping is for one of the given
+// token types.
+func mappingHasTokenType(
+	m *descpb.TextSearchConfigDescriptor_Mapping, tokenTypes []tsearch.TokenType,
+) bool {
+	for _, t := range tokenTypes {
+		if m.TokenType == t.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// sortTextSearchMappings orders the mappings of the configuration by the IDs
+// of their token types.
+func sortTextSearchMappings(config *descpb.TextSearchConfigDescriptor) {
+	tokenTypeID := func(i int) tsearch.TokenType {
+		// The token types were validated when the mappings were added.
+		t, _ := tsearch.TokenTypeByName(config.Mappings[i].TokenType)
+		return t
+	}
+	sort.SliceStable(config.Mappings, func(i, j int) bool {
+		return tokenTypeID(i) < tokenTypeID(j)
+	})
+}
+
+func (n *alterTextSearchConfigNode) ReadingOwnWrites() {}
+
+func (n *alterTextSearchConfigNode) startExec(params runParams) error {
+	return params.p.writeSchemaDescChange(
+		params.ctx, n.schema,
+		fmt.Sprintf("altering text search configuration %q in schema %q", n.name, n.schema.GetName()),
+	)
+}
+
+func (*alterTextSearchConfigNode) Next(params runParams) (bool, error) { return false, nil }
+func (*alterTextSearchConfigNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*alterTextSearchConfigNode) Close(ctx context.Context)           {}
+
+type alterTextSearchDictionaryNode struct {
+	schema *schemadesc.Mutable
+	name   string
+}
+
+// AlterTextSearchDictionary changes the options of a text search dictionary.
+// The given options replace the options of the dictionary with the same name,
+// and the other options are kept.
+// Privileges: ownership of the dictionary.
+func (p *planner) AlterTextSearchDictionary(
+	ctx context.Context, n *tree.AlterTextSearchDictionary,
+) (planNode, error) {
+	if err := p.checkTextSearchObjectsSupported(ctx, "ALTER TEXT SEARCH DICTIONARY"); err != nil {
+		return nil, err
+	}
+	schema, err := p.textSearchSchema(ctx, n.Name, false /* create */)
+	if err != nil {
+		return nil, err
+	}
+	// dict points into the dictionaries of schema, and is updated in place.
+	dict := schema.FindTextSearchDictionaryByName(n.Name.Object())
+	if dict == nil {
+		return nil, tsearch.UndefinedDictionaryError(n.Name.String())
+	}
+	if err := p.checkTextSearchObjectOwnership(
+		ctx, "dictionary", dict.Name, dict.OwnerProto,
+	); err != nil {
+		return nil, err
+	}
+
+	opts, err := p.evalTextSearchOptions(ctx, "ALTER TEXT SEARCH DICTIONARY", n.Options)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := opts[textSearchDictOptionTemplate]; ok {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"cannot change the template of a text search dictionary")
+	}
+	altered := *dict
+	altered.Options = append([]descpb.TextSearchDictionaryDescriptor_Option(nil), dict.Options...)
+	for _, opt := range n.Options {
+		key := strings.ToLower(string(opt.Key))
+		replaced := false
+		for i := range altered.Options {
+			if altered.Options[i].Name == key {
+				altered.Options[i].Value = opts[key]
+				replaced = true
+			}
+		}
+		if !replaced {
+			altered.Options = append(altered.Options,
+				descpb.TextSearchDictionaryDescriptor_Option{Name: key, Value: opts[key]})
+		}
+	}
+	if _, err := schemadesc.NewTextSearchDictionary(&altered); err != nil {
+		return nil, err
+	}
+	*dict = altered
+
+	return &alterTextSearchDictionaryNode{schema: schema, name: dict.Name}, nil
+}
+
+func (n *alterTextSearchDictionaryNode) ReadingOwnWrites() {}
+
+func (n *alterTextSearchDictionaryNode) startExec(params runParams) error {
+	return params.p.writeSchemaDescChange(
+		params.ctx, n.schema,
+		fmt.Sprintf("altering text search dictionary %q in schema %q", n.name, n.schema.GetName()),
+	)
+}
+
+func (*alterTextSearchDictionaryNode) Next(params runParams) (bool, error) { return false, nil }
+func (*alterTextSearchDictionaryNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*alterTextSearchDictionaryNode) Close(ctx context.Context)           {}
@@ -344,6 +344,27 @@ func runPlanInsidePlan(
 	execCfg.DistSQLPlanner.PlanAndRun(
 		ctx, evalCtx, planCtx, plannerCopy.Txn(), plan.main, recv, finishedSetupFn,
 	)
+	if err := resultWriter.Err(); err != nil {
+		return err
+	}
+
+	// Run any cascades and checks of the inner plan. These are only present if
+	// the inner plan contains a mutation, for example when it is a statement in
+	// the body of a routine.
+	if len(plan.cascades) != 0 || len(plan.checkPlans) != 0 {
+		// The outer statement is still running, so the inner plan must not
+		// commit the transaction.
+		plannerCopy.autoCommit = false
+		evalCtxFactory := func(usedConcurrently bool) *extendedEvalContext {
+			factoryEvalCtx := plannerCopy.ExtendedEvalContextCopy()
+			factoryEvalCtx.Planner = &plannerCopy
+			factoryEvalCtx.StreamManagerFactory = &plannerCopy
+			return factoryEvalCtx
+		}
+		execCfg.DistSQLPlanner.PlanAndRunCascadesAndChecks(
+			ctx, &plannerCopy, evalCtxFactory, &plannerCopy.curPlan.planComponents, recv,
+		)
+	}
 	return resultWriter.Err()
 }
 
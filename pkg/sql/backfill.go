@@ -1474,7 +1474,7 @@ func (sc *SchemaChanger) validateIndexes(ctx context.Context) error {
 		return err
 	}
 
-	var forwardIndexes, invertedIndexes []catalog.Index
+	var forwardIndexes, invertedIndexes, exclusionIndexes []catalog.Index
 
 	for _, m := range tableDesc.AllMutations() {
 		if sc.mutationID != m.MutationID() {
@@ -1491,6 +1491,9 @@ func (sc *SchemaChanger) validateIndexes(ctx context.Context) error {
 		case descpb.IndexDescriptor_INVERTED:
 			invertedIndexes = append(invertedIndexes, idx)
 		}
+		if idx.IsExclusion() {
+			exclusionIndexes = append(exclusionIndexes, idx)
+		}
 	}
 	if len(forwardIndexes) == 0 && len(invertedIndexes) == 0 {
 		return nil
@@ -1530,6 +1533,20 @@ func (sc *SchemaChanger) validateIndexes(ctx context.Context) error {
 			)
 		})
 	}
+	if len(exclusionIndexes) > 0 {
+		grp.GoCtx(func(ctx context.Context) error {
+			return runHistoricalTxn.Exec(ctx, func(ctx context.Context, txn descs.Txn) error {
+				for _, idx := range exclusionIndexes {
+					if err := validateExclusionConstraint(
+						ctx, tableDesc, idx, txn, username.NodeUserName(),
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		})
+	}
 	if err := grp.Wait(); err != nil {
 		return err
 	}
@@ -2497,6 +2514,13 @@ func runSchemaChangesInTxn(
 				if err := indexBackfillInTxn(ctx, planner.Txn(), planner.EvalContext(), planner.SemaCtx(), immutDesc, traceKV); err != nil {
 					return err
 				}
+				if idx.IsExclusion() {
+					if err := validateExclusionConstraintInTxn(
+						ctx, planner.InternalSQLTxn(), tableDesc, planner.User(), idx,
+					); err != nil {
+						return err
+					}
+				}
 			} else if c := m.AsConstraintWithoutIndex(); c != nil {
 				// This is processed later. Do not proceed to MakeMutationComplete.
 				constraintAdditionMutations = append(constraintAdditionMutations, c)
@@ -2838,6 +2862,29 @@ func validateUniqueWithoutIndexConstraintInTxn(
 		})
 }
 
+// validateExclusionConstraintInTxn validates the exclusion constraint backed
+// by the given index for a table that was created in the same transaction.
+//
+// It operates entirely on the current goroutine and is thus able to
+// reuse an existing kv.Txn safely.
+func validateExclusionConstraintInTxn(
+	ctx context.Context,
+	txn isql.Txn,
+	tableDesc *tabledesc.Mutable,
+	user username.SQLUsername,
+	idx catalog.Index,
+) error {
+	var syntheticDescs []catalog.Descriptor
+	if tableDesc.Version > tableDesc.ClusterVersion().Version {
+		syntheticDescs = append(syntheticDescs, tableDesc)
+	}
+	return txn.WithSyntheticDescriptors(
+		syntheticDescs,
+		func() error {
+			return validateExclusionConstraint(ctx, tableDesc, idx, txn, user)
+		})
+}
+
 // columnBackfillInTxn backfills columns for all mutation columns in
 // the mutation list.
 //
@@ -40,7 +40,21 @@ func (c *rowContainerHelper) Init(
 	evalContext *extendedEvalContext,
 	opName redact.RedactableString,
 ) {
-	c.initMonitors(ctx, evalContext, opName)
+	c.InitWithParentMon(ctx, typs, evalContext.Planner.Mon(), evalContext, opName)
+}
+
+// InitWithParentMon is a variant of Init that accounts for the memory usage of
+// the container against the given monitor, rather than the monitor of the
+// planner. It is used for containers which outlive the transaction that
+// creates them.
+func (c *rowContainerHelper) InitWithParentMon(
+	ctx context.Context,
+	typs []*types.T,
+	parentMon *mon.BytesMonitor,
+	evalContext *extendedEvalContext,
+	opName redact.RedactableString,
+) {
+	c.initMonitors(ctx, parentMon, evalContext, opName)
 	distSQLCfg := &evalContext.DistSQLPlanner.distSQLSrv.ServerConfig
 	c.rows = &rowcontainer.DiskBackedRowContainer{}
 	c.rows.Init(
@@ -58,7 +72,7 @@ func (c *rowContainerHelper) InitWithDedup(
 	evalContext *extendedEvalContext,
 	opName redact.RedactableString,
 ) {
-	c.initMonitors(ctx, evalContext, opName)
+	c.initMonitors(ctx, evalContext.Planner.Mon(), evalContext, opName)
 	distSQLCfg := &evalContext.DistSQLPlanner.distSQLSrv.ServerConfig
 	c.rows = &rowcontainer.DiskBackedRowContainer{}
 	// The DiskBackedRowContainer can be configured to deduplicate along the
@@ -78,13 +92,16 @@ func (c *rowContainerHelper) InitWithDedup(
 }
 
 func (c *rowContainerHelper) initMonitors(
-	ctx context.Context, evalContext *extendedEvalContext, opName redact.RedactableString,
+	ctx context.Context,
+	parentMon *mon.BytesMonitor,
+	evalContext *extendedEvalContext,
+	opName redact.RedactableString,
 ) {
 	distSQLCfg := &evalContext.DistSQLPlanner.distSQLSrv.ServerConfig
 	// TODO(yuzefovich): currently the memory usage of c.memMonitor doesn't
 	// count against sql.mem.distsql.current metric. Fix it.
 	c.memMonitor = execinfra.NewLimitedMonitorNoFlowCtx(
-		ctx, evalContext.Planner.Mon(), distSQLCfg, evalContext.SessionData(),
+		ctx, parentMon, distSQLCfg, evalContext.SessionData(),
 		redact.Sprintf("%s-limited", opName),
 	)
 	c.diskMonitor = execinfra.NewMonitor(
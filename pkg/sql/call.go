@@ -0,0 +1,41 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// callNode represents a CALL statement. It invokes a procedure when it is
+// started and produces no rows.
+type callNode struct {
+	proc *tree.RoutineExpr
+}
+
+// startExec implements the planNode interface.
+func (n *callNode) startExec(params runParams) error {
+	// The result of the procedure is discarded. Procedures do not return a
+	// value, so the result is always NULL.
+	_, err := eval.Expr(params.ctx, params.EvalContext(), n.proc)
+	return err
+}
+
+// Next implements the planNode interface.
+func (n *callNode) Next(params runParams) (bool, error) { return false, nil }
+
+// Values implements the planNode interface.
+func (n *callNode) Values() tree.Datums { return nil }
+
+// Close implements the planNode interface.
+func (n *callNode) Close(ctx context.Context) {}
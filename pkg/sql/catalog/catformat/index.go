@@ -95,6 +95,13 @@ func indexForDisplay(
 	}
 
 	f := tree.NewFmtCtx(formatFlags)
+	if displayMode == IndexDisplayDefOnly && !f.HasFlags(tree.FmtPGCatalog) &&
+		len(index.ExclusionOperators) > 0 {
+		// Indexes that back exclusion constraints are displayed as the
+		// constraint that they back.
+		f.FormatNode(exclusionConstraintTableDef(index))
+		return f.CloseAndGetString(), nil
+	}
 	if displayMode == IndexDisplayShowCreate {
 		f.WriteString("CREATE ")
 	}
@@ -193,6 +200,25 @@ func indexForDisplay(
 	return f.CloseAndGetString(), nil
 }
 
+// exclusionConstraintTableDef returns the definition of the exclusion
+// constraint backed by the given index. For example:
+//
+//	CONSTRAINT t_a_b_excl EXCLUDE USING gist (a WITH =, b WITH &&)
+func exclusionConstraintTableDef(index *descpb.IndexDescriptor) *tree.ExclusionConstraintTableDef {
+	def := &tree.ExclusionConstraintTableDef{
+		Name:     tree.Name(index.Name),
+		Inverted: index.Type == descpb.IndexDescriptor_INVERTED,
+		Elems:    make(tree.ExcludeElemList, len(index.KeyColumnNames)),
+	}
+	for i := range index.KeyColumnNames {
+		def.Elems[i] = tree.ExcludeElem{
+			Column:   tree.Name(index.KeyColumnNames[i]),
+			Operator: tree.ExclusionOperator(index.ExclusionOperators[i]),
+		}
+	}
+	return def
+}
+
 // FormatIndexElements formats the key columns an index. If the column is an
 // inaccessible computed column, the computed column expression is formatted.
 // Otherwise, the column name is formatted. Each column is separated by commas
@@ -74,6 +74,8 @@ var (
 		catconstants.SpanStatsBuckets,
 		catconstants.SpanStatsSamples,
 		catconstants.SpanStatsTenantBoundaries,
+		catconstants.NotificationsTableName,
+		catconstants.ReplicationSlotsTableName,
 	}
 
 	readWriteSystemSequences = []catconstants.SystemTableName{
@@ -57,6 +57,8 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		return true
 	case types.ArrayFamily:
 		return CanHaveCompositeKeyEncoding(typ.ArrayContents())
+	case types.RangeFamily, types.MultirangeFamily:
+		return CanHaveCompositeKeyEncoding(typ.RangeContents())
 	case types.TupleFamily:
 		for _, t := range typ.TupleContents() {
 			if CanHaveCompositeKeyEncoding(t) {
@@ -83,6 +85,7 @@ func CanHaveCompositeKeyEncoding(typ *types.T) bool {
 		types.EnumFamily,
 		types.Box2DFamily,
 		types.VoidFamily,
+		types.TriggerFamily,
 		types.EncodedKeyFamily,
 		types.TSQueryFamily,
 		types.TSVectorFamily:
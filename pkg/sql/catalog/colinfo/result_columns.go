@@ -208,6 +208,24 @@ var ShowCompletionsColumns = ResultColumns{
 	{Name: "end", Typ: types.Int},
 }
 
+// IdentifySystemColumns are the result columns of an IDENTIFY_SYSTEM
+// replication command.
+var IdentifySystemColumns = ResultColumns{
+	{Name: "systemid", Typ: types.String},
+	{Name: "timeline", Typ: types.Int4},
+	{Name: "xlogpos", Typ: types.String},
+	{Name: "dbname", Typ: types.String},
+}
+
+// CreateReplicationSlotColumns are the result columns of a
+// CREATE_REPLICATION_SLOT replication command.
+var CreateReplicationSlotColumns = ResultColumns{
+	{Name: "slot_name", Typ: types.String},
+	{Name: "consistent_point", Typ: types.String},
+	{Name: "snapshot_name", Typ: types.String},
+	{Name: "output_plugin", Typ: types.String},
+}
+
 // AlterTableSplitColumns are the result columns of an
 // ALTER TABLE/INDEX .. SPLIT AT statement.
 var AlterTableSplitColumns = ResultColumns{
@@ -253,6 +253,37 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	if desc.IsMultiRegion() {
 		desc.validateMultiRegion(vea)
 	}
+
+	desc.validatePublications(vea)
+}
+
+// validatePublications validates that the database's publications have unique
+// names and IDs.
+func (desc *immutable) validatePublications(vea catalog.ValidationErrorAccumulator) {
+	names := make(map[string]struct{}, len(desc.Publications))
+	ids := make(map[descpb.PublicationID]struct{}, len(desc.Publications))
+	for i := range desc.Publications {
+		p := &desc.Publications[i]
+		if p.ID == 0 {
+			vea.Report(errors.AssertionFailedf("publication ID was missing for publication %q", p.Name))
+		} else if p.ID >= desc.NextPublicationID {
+			vea.Report(errors.AssertionFailedf(
+				"publication %q has ID %d not less than NextPublicationID value %d for database",
+				p.Name, p.ID, desc.NextPublicationID))
+		}
+		if p.Name == "" {
+			vea.Report(errors.AssertionFailedf("empty publication name"))
+		}
+		if _, found := names[p.Name]; found {
+			vea.Report(errors.AssertionFailedf("duplicate publication name: %q", p.Name))
+		}
+		names[p.Name] = struct{}{}
+		if _, found := ids[p.ID]; found {
+			vea.Report(errors.AssertionFailedf(
+				"publication ID %d in publication %q already in use", p.ID, p.Name))
+		}
+		ids[p.ID] = struct{}{}
+	}
 }
 
 // validateMultiRegion performs checks specific to multi-region DBs.
@@ -506,6 +537,41 @@ func (desc *Mutable) AddSchemaToDatabase(
 	desc.Schemas[schemaName] = schemaInfo
 }
 
+// FindPublicationByName implements the DatabaseDescriptor interface.
+func (desc *immutable) FindPublicationByName(name string) *descpb.PublicationDescriptor {
+	for i := range desc.Publications {
+		if desc.Publications[i].Name == name {
+			return &desc.Publications[i]
+		}
+	}
+	return nil
+}
+
+// AddPublication adds a publication to the database, allocating it a new
+// publication ID.
+func (desc *Mutable) AddPublication(
+	publication descpb.PublicationDescriptor,
+) descpb.PublicationID {
+	if desc.NextPublicationID == 0 {
+		desc.NextPublicationID = 1
+	}
+	publication.ID = desc.NextPublicationID
+	desc.NextPublicationID++
+	desc.Publications = append(desc.Publications, publication)
+	return publication.ID
+}
+
+// RemovePublication removes the publication with the given ID from the
+// database.
+func (desc *Mutable) RemovePublication(id descpb.PublicationID) {
+	for i := range desc.Publications {
+		if desc.Publications[i].ID == id {
+			desc.Publications = append(desc.Publications[:i], desc.Publications[i+1:]...)
+			return
+		}
+	}
+}
+
 // GetDeclarativeSchemaChangerState is part of the catalog.MutableDescriptor
 // interface.
 func (desc *immutable) GetDeclarativeSchemaChangerState() *scpb.DescriptorState {
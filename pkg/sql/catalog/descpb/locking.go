@@ -80,3 +80,15 @@ func ToScanLockingWaitPolicy(wp tree.LockingWaitPolicy) ScanLockingWaitPolicy {
 		panic(errors.AssertionFailedf("unknown locking wait policy %s", wp))
 	}
 }
+
+// PrettyString returns the locking durability as a user-readable string.
+func (d ScanLockingDurability) PrettyString() string {
+	switch d {
+	case ScanLockingDurability_BEST_EFFORT:
+		return "best effort"
+	case ScanLockingDurability_GUARANTEED:
+		return "guaranteed"
+	default:
+		panic(errors.AssertionFailedf("unexpected locking durability"))
+	}
+}
@@ -74,6 +74,15 @@ type IndexID = catid.IndexID
 // ConstraintID is a custom type for TableDescriptor constraint IDs.
 type ConstraintID = catid.ConstraintID
 
+// TriggerID is a custom type for TableDescriptor trigger IDs.
+type TriggerID = catid.TriggerID
+
+// PolicyID is a custom type for TableDescriptor policy IDs.
+type PolicyID = catid.PolicyID
+
+// PublicationID is a custom type for DatabaseDescriptor publication IDs.
+type PublicationID = catid.PublicationID
+
 // DescriptorVersion is a custom type for TableDescriptor Versions.
 type DescriptorVersion uint64
 
@@ -276,6 +276,12 @@ type DatabaseDescriptor interface {
 	// HasPublicSchemaWithDescriptor returns true iff the database has a public
 	// schema which itself has a descriptor.
 	HasPublicSchemaWithDescriptor() bool
+	// GetPublications returns the publications defined in this database, in
+	// creation order.
+	GetPublications() []descpb.PublicationDescriptor
+	// FindPublicationByName returns the publication with the given name, or nil
+	// if no such publication exists.
+	FindPublicationByName(name string) *descpb.PublicationDescriptor
 }
 
 // TableDescriptor is an interface around the table descriptor types.
@@ -328,6 +334,10 @@ type TableDescriptor interface {
 	// created at, for materialized views and CREATE TABLE AS. Only valid if
 	// IsAs or MaterializedView returns true.
 	GetCreateAsOfTime() hlc.Timestamp
+	// GetLastRefreshTime returns the timestamp as of which the data of a
+	// materialized view was last computed, or an empty timestamp if unknown.
+	// Only valid if MaterializedView returns true.
+	GetLastRefreshTime() hlc.Timestamp
 
 	// GetViewQuery returns this view's CREATE VIEW declaration. Only valid if
 	// IsView is true.
@@ -610,6 +620,30 @@ type TableDescriptor interface {
 	// GetDependsOnFunctions returns the IDs of all functions that this view
 	// depends on. It's only non-nil if IsView is true.
 	GetDependsOnFunctions() []descpb.ID
+	// GetTriggers returns the triggers defined on this table, in creation
+	// order.
+	GetTriggers() []descpb.TriggerDescriptor
+	// FindTriggerByName returns the trigger with the given name, or nil if no
+	// such trigger exists.
+	FindTriggerByName(name string) *descpb.TriggerDescriptor
+	// IsRowLevelSecurityEnabled returns true if row-level security policies
+	// are enforced on the table.
+	IsRowLevelSecurityEnabled() bool
+	// IsRowLevelSecurityForced returns true if row-level security policies
+	// are enforced on the table even for the table owner.
+	IsRowLevelSecurityForced() bool
+	// GetPolicies returns the row-level security policies defined on this
+	// table, in creation order.
+	GetPolicies() []descpb.PolicyDescriptor
+	// FindPolicyByName returns the policy with the given name, or nil if no
+	// such policy exists.
+	FindPolicyByName(name string) *descpb.PolicyDescriptor
+	// IsForeignTable returns true if the rows of the table are read from a file
+	// in external storage rather than stored in the KV layer.
+	IsForeignTable() bool
+	// GetForeignTable returns the description of the file backing a foreign
+	// table. It's only non-nil if IsForeignTable is true.
+	GetForeignTable() *descpb.ForeignTableDescriptor
 
 	// AllConstraints returns all constraints in this table, regardless if
 	// they're enforced yet or not. The ordering of the constraints within this
@@ -799,6 +833,11 @@ type TypeDescriptor interface {
 	// nil otherwise.
 	AsCompositeTypeDescriptor() CompositeTypeDescriptor
 
+	// AsDomainTypeDescriptor returns this instance cast to
+	// DomainTypeDescriptor if this type is a domain type,
+	// nil otherwise.
+	AsDomainTypeDescriptor() DomainTypeDescriptor
+
 	// AsTableImplicitRecordTypeDescriptor returns this instance cast to
 	// TableImplicitRecordTypeDescriptor if this type is an implicit table record
 	// type, nil otherwise.
@@ -886,6 +925,33 @@ type CompositeTypeDescriptor interface {
 	GetElementType(ordinal int) *types.T
 }
 
+// DomainTypeDescriptor is the TypeDescriptor subtype for domains, which are
+// built-in base types carrying additional constraints.
+type DomainTypeDescriptor interface {
+	TypeDescriptor
+
+	// DomainBaseType returns the base type of the domain.
+	DomainBaseType() *types.T
+
+	// DomainNotNull returns true if the domain does not allow NULL values.
+	DomainNotNull() bool
+
+	// DomainDefaultExpr returns the serialized default expression of the
+	// domain, if any.
+	DomainDefaultExpr() (string, bool)
+
+	// NumDomainChecks returns the number of CHECK constraints on the domain.
+	NumDomainChecks() int
+
+	// GetDomainCheckName returns the name of the CHECK constraint at the given
+	// ordinal.
+	GetDomainCheckName(ordinal int) string
+
+	// GetDomainCheckExpr returns the serialized expression of the CHECK
+	// constraint at the given ordinal.
+	GetDomainCheckExpr(ordinal int) string
+}
+
 // TableImplicitRecordTypeDescriptor is the TypeDescriptor subtype for the
 // record type implicitly defined by a table.
 type TableImplicitRecordTypeDescriptor interface {
@@ -958,6 +1024,13 @@ type FunctionDescriptor interface {
 	// GetLanguage returns the language of this function.
 	GetLanguage() catpb.Function_Language
 
+	// GetIsProcedure returns true if the descriptor represents a procedure.
+	GetIsProcedure() bool
+
+	// GetAggregate returns the aggregate properties of the function, or nil if
+	// the descriptor does not represent a user-defined aggregate.
+	GetAggregate() *descpb.FunctionDescriptor_Aggregate
+
 	// ToCreateExpr converts a function descriptor back to a CREATE FUNCTION
 	// statement. This is mainly used for formatting, e.g. SHOW CREATE FUNCTION.
 	ToCreateExpr() (*tree.CreateFunction, error)
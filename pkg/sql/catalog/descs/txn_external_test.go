@@ -64,7 +64,7 @@ func TestTxnWithStepping(t *testing.T) {
 				return errors.AssertionFailedf("expected no value, got %v", got)
 			}
 		}
-		if err := txn.KV().Step(ctx); err != nil {
+		if err := txn.KV().Step(ctx, false /* allowReadTimestampStep */); err != nil {
 			return err
 		}
 		{
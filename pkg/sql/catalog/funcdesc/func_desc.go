@@ -173,6 +173,9 @@ func (desc *immutable) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, dep := range desc.DependedOnBy {
 		ret.Add(dep.ID)
 	}
+	for _, id := range desc.aggregateSupportFunctionIDs() {
+		ret.Add(id)
+	}
 
 	return ret, nil
 }
@@ -226,6 +229,18 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			vea.Report(errors.AssertionFailedf("invalid type id %d in depends-on-types references #%d", typeID, i))
 		}
 	}
+
+	if agg := desc.Aggregate; agg != nil {
+		if agg.TransitionFunctionID == descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("aggregate transition function not set"))
+		}
+		if agg.StateType == nil {
+			vea.Report(errors.AssertionFailedf("aggregate state type not set"))
+		}
+		if desc.IsProcedure {
+			vea.Report(errors.AssertionFailedf("procedure cannot be an aggregate"))
+		}
+	}
 }
 
 // ValidateForwardReferences implements the catalog.Descriptor interface.
@@ -255,6 +270,16 @@ func (desc *immutable) ValidateForwardReferences(
 	for _, typeID := range desc.DependsOnTypes {
 		vea.Report(catalog.ValidateOutboundTypeRef(typeID, vdg))
 	}
+
+	for _, fnID := range desc.aggregateSupportFunctionIDs() {
+		fn, err := vdg.GetFunctionDescriptor(fnID)
+		if err != nil {
+			vea.Report(errors.NewAssertionErrorWithWrappedErrf(err, "invalid aggregate support function reference"))
+		} else if fn.Dropped() {
+			vea.Report(errors.AssertionFailedf("aggregate support function %q (%d) is dropped",
+				fn.GetName(), fn.GetID()))
+		}
+	}
 }
 
 // ValidateBackReferences implements the catalog.Descriptor interface.
@@ -276,13 +301,79 @@ func (desc *immutable) ValidateBackReferences(
 		vea.Report(catalog.ValidateOutboundTypeRefBackReference(desc.GetID(), typ))
 	}
 
-	// Currently, we don't support cross function references yet.
-	// So here we assume that all inbound references are from tables.
+	for _, fnID := range desc.aggregateSupportFunctionIDs() {
+		fn, _ := vdg.GetFunctionDescriptor(fnID)
+		if fn == nil || fn.Dropped() {
+			continue
+		}
+		var found bool
+		for _, by := range fn.GetDependedOnBy() {
+			if by.ID == desc.GetID() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			vea.Report(errors.AssertionFailedf("aggregate support function %q (%d) has no corresponding depended-on-by back reference",
+				fn.GetName(), fn.GetID()))
+		}
+	}
+
+	// The only cross function references are from user-defined aggregates to
+	// their support functions. All other inbound references are from tables.
 	for _, by := range desc.DependedOnBy {
+		if backRef, err := vdg.GetDescriptor(by.ID); err == nil && backRef.DescriptorType() == catalog.Function {
+			vea.Report(desc.validateInboundFunctionRef(by, vdg))
+			continue
+		}
 		vea.Report(desc.validateInboundTableRef(by, vdg))
 	}
 }
 
+// validateInboundFunctionRef validates a back-reference from a user-defined
+// aggregate that uses this function as one of its support functions.
+func (desc *immutable) validateInboundFunctionRef(
+	by descpb.FunctionDescriptor_Reference, vdg catalog.ValidationDescGetter,
+) error {
+	backRefFn, err := vdg.GetFunctionDescriptor(by.ID)
+	if err != nil {
+		return errors.NewAssertionErrorWithWrappedErrf(err, "invalid depended-on-by function back reference")
+	}
+	if backRefFn.Dropped() {
+		return errors.AssertionFailedf("depended-on-by function %q (%d) is dropped",
+			backRefFn.GetName(), backRefFn.GetID())
+	}
+	if len(by.IndexIDs) > 0 || len(by.ColumnIDs) > 0 || len(by.ConstraintIDs) > 0 || len(by.TriggerIDs) > 0 {
+		return errors.AssertionFailedf("depended-on-by function %q (%d) has unexpected table references",
+			backRefFn.GetName(), backRefFn.GetID())
+	}
+	if agg := backRefFn.GetAggregate(); agg != nil {
+		for _, id := range []descpb.ID{agg.TransitionFunctionID, agg.FinalFunctionID, agg.CombineFunctionID} {
+			if id == desc.GetID() {
+				return nil
+			}
+		}
+	}
+	return errors.AssertionFailedf("depended-on-by function %q (%d) has no corresponding depends-on forward reference",
+		backRefFn.GetName(), by.ID)
+}
+
+// aggregateSupportFunctionIDs returns the IDs of the support functions of a
+// user-defined aggregate, or nil if the descriptor is not an aggregate.
+func (desc *immutable) aggregateSupportFunctionIDs() []descpb.ID {
+	agg := desc.Aggregate
+	if agg == nil {
+		return nil
+	}
+	ids := make([]descpb.ID, 0, 3)
+	for _, id := range []descpb.ID{agg.TransitionFunctionID, agg.FinalFunctionID, agg.CombineFunctionID} {
+		if id != descpb.InvalidID {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
 func (desc *immutable) validateFuncExistsInSchema(scDesc catalog.SchemaDescriptor) error {
 	// Check that parent Schema contains the matching function signature.
 	if _, ok := scDesc.GetFunction(desc.GetName()); !ok {
@@ -369,6 +460,28 @@ func (desc *immutable) validateInboundTableRef(
 			cstID, backRefTbl.GetName(), backRefTbl.GetID(), desc.GetName(), desc.GetID(),
 		)
 	}
+	for _, triggerID := range by.TriggerIDs {
+		var found bool
+		for _, t := range backRefTbl.GetTriggers() {
+			if t.ID != triggerID {
+				continue
+			}
+			if t.FuncID != desc.GetID() {
+				return errors.AssertionFailedf(
+					"trigger %d in depended-on-by relation %q (%d) does not have reference to function %q (%d)",
+					triggerID, backRefTbl.GetName(), backRefTbl.GetID(), desc.GetName(), desc.GetID(),
+				)
+			}
+			found = true
+			break
+		}
+		if !found {
+			return errors.AssertionFailedf("depended-on-by relation %q (%d) does not have a trigger with ID %d",
+				backRefTbl.GetName(), by.ID, triggerID)
+		}
+		foundInTable = true
+	}
+
 	if foundInTable {
 		return nil
 	}
@@ -527,6 +640,17 @@ func (desc *Mutable) SetFuncBody(v string) {
 	desc.FunctionBody = v
 }
 
+// SetIsProcedure sets whether the descriptor represents a procedure.
+func (desc *Mutable) SetIsProcedure(v bool) {
+	desc.IsProcedure = v
+}
+
+// SetAggregate sets the aggregate properties of the descriptor, marking it as
+// a user-defined aggregate function.
+func (desc *Mutable) SetAggregate(agg *descpb.FunctionDescriptor_Aggregate) {
+	desc.Aggregate = agg
+}
+
 // SetName sets the function name.
 func (desc *Mutable) SetName(n string) {
 	desc.Name = n
@@ -624,14 +748,69 @@ func (desc *Mutable) RemoveColumnReference(id descpb.ID, colID descpb.ColumnID)
 	}
 }
 
+// AddTriggerReference adds back reference to a trigger to the function.
+func (desc *Mutable) AddTriggerReference(id descpb.ID, triggerID descpb.TriggerID) error {
+	for _, dep := range desc.DependsOn {
+		if dep == id {
+			return errors.Errorf(
+				"cannot add dependency from descriptor %d to function %s (%d) because there will be a dependency cycle", id, desc.GetName(), desc.GetID(),
+			)
+		}
+	}
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			for _, existing := range desc.DependedOnBy[i].TriggerIDs {
+				if existing == triggerID {
+					return nil
+				}
+			}
+			desc.DependedOnBy[i].TriggerIDs = append(desc.DependedOnBy[i].TriggerIDs, triggerID)
+			sort.Slice(desc.DependedOnBy[i].TriggerIDs, func(a, b int) bool {
+				return desc.DependedOnBy[i].TriggerIDs[a] < desc.DependedOnBy[i].TriggerIDs[b]
+			})
+			return nil
+		}
+	}
+	desc.DependedOnBy = append(
+		desc.DependedOnBy,
+		descpb.FunctionDescriptor_Reference{
+			ID:         id,
+			TriggerIDs: []descpb.TriggerID{triggerID},
+		},
+	)
+	sort.Slice(desc.DependedOnBy, func(i, j int) bool {
+		return desc.DependedOnBy[i].ID < desc.DependedOnBy[j].ID
+	})
+	return nil
+}
+
+// RemoveTriggerReference removes back reference to a trigger from the
+// function.
+func (desc *Mutable) RemoveTriggerReference(id descpb.ID, triggerID descpb.TriggerID) {
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			ids := desc.DependedOnBy[i].TriggerIDs[:0]
+			for _, existing := range desc.DependedOnBy[i].TriggerIDs {
+				if existing != triggerID {
+					ids = append(ids, existing)
+				}
+			}
+			desc.DependedOnBy[i].TriggerIDs = ids
+			desc.maybeRemoveTableReference(id)
+			return
+		}
+	}
+}
+
 // maybeRemoveTableReference removes a table's references from the function if
-// the column, index and constraint references are all empty. This function is
-// only used internally when removing an individual column, index or constraint
-// reference.
+// the column, index, constraint and trigger references are all empty. This
+// function is only used internally when removing an individual column, index,
+// constraint or trigger reference.
 func (desc *Mutable) maybeRemoveTableReference(id descpb.ID) {
 	var ret []descpb.FunctionDescriptor_Reference
 	for _, ref := range desc.DependedOnBy {
-		if ref.ID == id && len(ref.ColumnIDs) == 0 && len(ref.IndexIDs) == 0 && len(ref.ConstraintIDs) == 0 {
+		if ref.ID == id && len(ref.ColumnIDs) == 0 && len(ref.IndexIDs) == 0 &&
+			len(ref.ConstraintIDs) == 0 && len(ref.TriggerIDs) == 0 {
 			continue
 		}
 		ret = append(ret, ref)
@@ -639,6 +818,26 @@ func (desc *Mutable) maybeRemoveTableReference(id descpb.ID) {
 	desc.DependedOnBy = ret
 }
 
+// AddFunctionReference adds a back reference from a user-defined aggregate
+// that uses the function as one of its support functions.
+func (desc *Mutable) AddFunctionReference(id descpb.ID) error {
+	if desc.GetID() == id {
+		return errors.Errorf(
+			"cannot add dependency from function %s (%d) to itself", desc.GetName(), desc.GetID(),
+		)
+	}
+	for i := range desc.DependedOnBy {
+		if desc.DependedOnBy[i].ID == id {
+			return nil
+		}
+	}
+	desc.DependedOnBy = append(desc.DependedOnBy, descpb.FunctionDescriptor_Reference{ID: id})
+	sort.Slice(desc.DependedOnBy, func(i, j int) bool {
+		return desc.DependedOnBy[i].ID < desc.DependedOnBy[j].ID
+	})
+	return nil
+}
+
 func (desc *Mutable) RemoveReference(id descpb.ID) {
 	var ret []descpb.FunctionDescriptor_Reference
 	for _, ref := range desc.DependedOnBy {
@@ -653,12 +852,16 @@ func (desc *Mutable) RemoveReference(id descpb.ID) {
 func (desc *immutable) ToFuncObj() *tree.FuncObj {
 	ret := &tree.FuncObj{
 		FuncName: tree.MakeFunctionNameFromPrefix(tree.ObjectNamePrefix{}, tree.Name(desc.Name)),
-		Params:   make(tree.FuncParams, len(desc.Params)),
+		Params:   make(tree.FuncParams, 0, len(desc.Params)),
 	}
+	// Only input parameters identify the function.
 	for i := range desc.Params {
-		ret.Params[i] = tree.FuncParam{
-			Type: desc.Params[i].Type,
+		if !IsInParam(desc.Params[i]) {
+			continue
 		}
+		ret.Params = append(ret.Params, tree.FuncParam{
+			Type: desc.Params[i].Type,
+		})
 	}
 	return ret
 }
@@ -680,22 +883,43 @@ func (desc *immutable) GetLanguage() catpb.Function_Language {
 
 func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	ret = &tree.Overload{
-		Oid:        catid.FuncIDToOID(desc.ID),
-		ReturnType: tree.FixedReturnType(desc.ReturnType.Type),
-		ReturnSet:  desc.ReturnType.ReturnSet,
-		Body:       desc.FunctionBody,
-		IsUDF:      true,
-		Version:    uint64(desc.Version),
+		Oid:         catid.FuncIDToOID(desc.ID),
+		ReturnType:  tree.FixedReturnType(desc.ReturnType.Type),
+		ReturnSet:   desc.ReturnType.ReturnSet,
+		Body:        desc.FunctionBody,
+		IsUDF:       true,
+		IsProcedure: desc.IsProcedure,
+		Language:    desc.getCreateExprLang(),
+		Version:     uint64(desc.Version),
 	}
 
 	argTypes := make(tree.ParamTypes, 0, len(desc.Params))
 	for _, param := range desc.Params {
-		argTypes = append(
-			argTypes,
-			tree.ParamType{Name: param.Name, Typ: param.Type},
-		)
+		class := toTreeNodeParamClass(param.Class)
+		if tree.IsOutParamClass(class) {
+			inputOrdinal := -1
+			if tree.IsInParamClass(class) {
+				inputOrdinal = len(argTypes)
+			}
+			ret.OutParams = append(ret.OutParams, tree.OutParam{
+				Name:         param.Name,
+				Typ:          param.Type,
+				InputOrdinal: inputOrdinal,
+			})
+		}
+		if tree.IsInParamClass(class) {
+			argTypes = append(
+				argTypes,
+				tree.ParamType{Name: param.Name, Typ: param.Type},
+			)
+		}
 	}
 	ret.Types = argTypes
+	if len(ret.OutParams) == 1 && ret.OutParams[0].Name != "" {
+		// A function with a single named OUT parameter produces a column with
+		// the name of the parameter when it is used as a data source.
+		ret.ReturnLabels = []string{ret.OutParams[0].Name}
+	}
 	ret.Volatility, err = desc.getOverloadVolatility()
 	if err != nil {
 		return nil, err
@@ -707,6 +931,20 @@ func (desc *immutable) ToOverload() (ret *tree.Overload, err error) {
 	if desc.ReturnType.ReturnSet {
 		ret.Class = tree.GeneratorClass
 	}
+	if agg := desc.Aggregate; agg != nil {
+		ret.Class = tree.AggregateClass
+		ret.UDFAggregate = &tree.UDFAggregate{
+			TransitionFunc: catid.FuncIDToOID(agg.TransitionFunctionID),
+			StateType:      agg.StateType,
+			InitCond:       agg.InitCond,
+		}
+		if agg.FinalFunctionID != descpb.InvalidID {
+			ret.UDFAggregate.FinalFunc = catid.FuncIDToOID(agg.FinalFunctionID)
+		}
+		if agg.CombineFunctionID != descpb.InvalidID {
+			ret.UDFAggregate.CombineFunc = catid.FuncIDToOID(agg.CombineFunctionID)
+		}
+	}
 
 	return ret, nil
 }
@@ -754,6 +992,7 @@ func (desc *immutable) ToCreateExpr() (ret *tree.CreateFunction, err error) {
 			Type:  desc.ReturnType.Type,
 			IsSet: desc.ReturnType.ReturnSet,
 		},
+		IsProcedure: desc.IsProcedure,
 	}
 	ret.Params = make(tree.FuncParams, len(desc.Params))
 	for i := range desc.Params {
@@ -772,9 +1011,13 @@ func (desc *immutable) ToCreateExpr() (ret *tree.CreateFunction, err error) {
 	// We only store 5 function attributes at the moment. We may extend the
 	// pre-allocated capacity in the future.
 	ret.Options = make(tree.FunctionOptions, 0, 5)
-	ret.Options = append(ret.Options, desc.getCreateExprVolatility())
-	ret.Options = append(ret.Options, tree.FunctionLeakproof(desc.LeakProof))
-	ret.Options = append(ret.Options, desc.getCreateExprNullInputBehavior())
+	// Procedures do not have volatility, leakproof, or null input behavior
+	// attributes.
+	if !desc.IsProcedure {
+		ret.Options = append(ret.Options, desc.getCreateExprVolatility())
+		ret.Options = append(ret.Options, tree.FunctionLeakproof(desc.LeakProof))
+		ret.Options = append(ret.Options, desc.getCreateExprNullInputBehavior())
+	}
 	ret.Options = append(ret.Options, tree.FunctionBodyStr(desc.FunctionBody))
 	ret.Options = append(ret.Options, desc.getCreateExprLang())
 	return ret, nil
@@ -784,6 +1027,8 @@ func (desc *immutable) getCreateExprLang() tree.FunctionLanguage {
 	switch desc.Lang {
 	case catpb.Function_SQL:
 		return tree.FunctionLangSQL
+	case catpb.Function_PLPGSQL:
+		return tree.FunctionLangPLpgSQL
 	}
 	return tree.FunctionLangUnknown
 }
@@ -826,6 +1071,29 @@ func toTreeNodeParamClass(class catpb.Function_Param_Class) tree.FuncParamClass
 	return 0
 }
 
+// IsInParam returns true if the given parameter is an input of the function,
+// i.e. its type is part of the function's signature.
+func IsInParam(param descpb.FunctionDescriptor_Parameter) bool {
+	return tree.IsInParamClass(toTreeNodeParamClass(param.Class))
+}
+
+// IsOutParam returns true if the given parameter is an output of the function.
+func IsOutParam(param descpb.FunctionDescriptor_Parameter) bool {
+	return tree.IsOutParamClass(toTreeNodeParamClass(param.Class))
+}
+
+// InParamTypes returns the types of the input parameters of the function,
+// which make up its signature.
+func InParamTypes(params []descpb.FunctionDescriptor_Parameter) []*types.T {
+	ret := make([]*types.T, 0, len(params))
+	for i := range params {
+		if IsInParam(params[i]) {
+			ret = append(ret, params[i].Type)
+		}
+	}
+	return ret
+}
+
 // UserDefinedFunctionOIDToID converts a UDF OID into a descriptor ID.
 // Returns zero if the OID is not for something user-defined.
 func UserDefinedFunctionOIDToID(oid oid.Oid) descpb.ID {
@@ -83,6 +83,10 @@ func TableDescs(
 			return err
 		}
 
+		// Drop triggers whose functions are not being restored, and rewrite
+		// the function IDs of the remaining ones.
+		rewriteTriggers(table, descriptorRewrites)
+
 		// Remap type IDs and sequence IDs in all serialized expressions within the TableDescriptor.
 		// TODO (rohany): This needs tests once partial indexes are ready.
 		if err := tabledesc.ForEachExprStringInTableDesc(table, func(expr *string) error {
@@ -832,6 +836,22 @@ func dropColumnExpressionsMissingDeps(
 	return nil
 }
 
+// rewriteTriggers drops any triggers on the table which reference functions
+// that are missing from descriptorRewrites, and rewrites the function IDs of
+// all other triggers.
+func rewriteTriggers(table *tabledesc.Mutable, descriptorRewrites jobspb.DescRewriteMap) {
+	triggers := table.Triggers[:0]
+	for _, t := range table.Triggers {
+		rw, ok := descriptorRewrites[t.FuncID]
+		if !ok {
+			continue
+		}
+		t.FuncID = rw.ID
+		triggers = append(triggers, t)
+	}
+	table.Triggers = triggers
+}
+
 // DatabaseDescs rewrites all ID's in the input slice of DatabaseDescriptors
 // using the input ID rewrite mapping. The function elides remapping offline schemas,
 // since they will not get restored into the cluster.
@@ -876,6 +896,19 @@ func DatabaseDescs(
 			return err
 		}
 		db.Schemas = newSchemas
+
+		// Rewrite the tables of the database's publications. Tables which are not
+		// being restored are removed from the publications.
+		for i := range db.Publications {
+			p := &db.Publications[i]
+			tableIDs := p.TableIDs[:0]
+			for _, id := range p.TableIDs {
+				if rewrite, ok := descriptorRewrites[id]; ok {
+					tableIDs = append(tableIDs, rewrite.ID)
+				}
+			}
+			p.TableIDs = tableIDs
+		}
 	}
 	return nil
 }
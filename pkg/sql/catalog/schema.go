@@ -45,6 +45,14 @@ type SchemaDescriptor interface {
 	// ForEachFunctionSignature iterates through all function signatures within
 	// the schema and calls fn on each signature.
 	ForEachFunctionSignature(fn func(sig descpb.SchemaDescriptor_FunctionSignature) error) error
+
+	// FindTextSearchDictionaryByName returns the text search dictionary with
+	// the given name defined in the schema, or nil if there is none.
+	FindTextSearchDictionaryByName(name string) *descpb.TextSearchDictionaryDescriptor
+
+	// FindTextSearchConfigByName returns the text search configuration with the
+	// given name defined in the schema, or nil if there is none.
+	FindTextSearchConfigByName(name string) *descpb.TextSearchConfigDescriptor
 }
 
 // ResolvedSchemaKind is an enum that represents what kind of schema
@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 )
@@ -238,6 +239,62 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 			}
 		}
 	}
+
+	desc.validateTextSearchObjects(vea)
+}
+
+// validateTextSearchObjects validates that the schema's text search
+// dictionaries and configurations have unique names, that the dictionaries
+// have valid options, and that the configurations map valid token types to
+// dictionaries which exist.
+func (desc *immutable) validateTextSearchObjects(vea catalog.ValidationErrorAccumulator) {
+	dictNames := make(map[string]struct{}, len(desc.TextSearchDictionaries))
+	for i := range desc.TextSearchDictionaries {
+		d := &desc.TextSearchDictionaries[i]
+		if d.Name == "" {
+			vea.Report(errors.AssertionFailedf("empty text search dictionary name"))
+		}
+		if _, found := dictNames[d.Name]; found {
+			vea.Report(errors.AssertionFailedf("duplicate text search dictionary name: %q", d.Name))
+		}
+		dictNames[d.Name] = struct{}{}
+		if _, err := NewTextSearchDictionary(d); err != nil {
+			vea.Report(errors.NewAssertionErrorWithWrappedErrf(err,
+				"invalid text search dictionary %q", d.Name))
+		}
+	}
+	configNames := make(map[string]struct{}, len(desc.TextSearchConfigs))
+	for i := range desc.TextSearchConfigs {
+		c := &desc.TextSearchConfigs[i]
+		if c.Name == "" {
+			vea.Report(errors.AssertionFailedf("empty text search configuration name"))
+		}
+		if _, found := configNames[c.Name]; found {
+			vea.Report(errors.AssertionFailedf("duplicate text search configuration name: %q", c.Name))
+		}
+		configNames[c.Name] = struct{}{}
+		if c.Parser != TextSearchDefaultParser {
+			vea.Report(errors.AssertionFailedf(
+				"text search configuration %q has unknown parser %q", c.Name, c.Parser))
+		}
+		for _, m := range c.Mappings {
+			if _, err := tsearch.TokenTypeByName(m.TokenType); err != nil {
+				vea.Report(errors.NewAssertionErrorWithWrappedErrf(err,
+					"invalid mapping of text search configuration %q", c.Name))
+			}
+			for _, ref := range m.Dictionaries {
+				if ref.Builtin {
+					if _, ok := tsearch.BuiltinDictionary(ref.Name); !ok {
+						vea.Report(errors.AssertionFailedf(
+							"text search configuration %q refers to unknown built-in dictionary %q", c.Name, ref.Name))
+					}
+				} else if _, ok := dictNames[ref.Name]; !ok {
+					vea.Report(errors.AssertionFailedf(
+						"text search configuration %q refers to unknown dictionary %q", c.Name, ref.Name))
+				}
+			}
+		}
+	}
 }
 
 // GetReferencedDescIDs returns the IDs of all descriptors referenced by
@@ -475,6 +532,60 @@ func (desc *Mutable) RemoveFunction(name string, id descpb.ID) {
 	}
 }
 
+// FindTextSearchDictionaryByName implements the SchemaDescriptor interface.
+func (desc *immutable) FindTextSearchDictionaryByName(
+	name string,
+) *descpb.TextSearchDictionaryDescriptor {
+	for i := range desc.TextSearchDictionaries {
+		if desc.TextSearchDictionaries[i].Name == name {
+			return &desc.TextSearchDictionaries[i]
+		}
+	}
+	return nil
+}
+
+// FindTextSearchConfigByName implements the SchemaDescriptor interface.
+func (desc *immutable) FindTextSearchConfigByName(name string) *descpb.TextSearchConfigDescriptor {
+	for i := range desc.TextSearchConfigs {
+		if desc.TextSearchConfigs[i].Name == name {
+			return &desc.TextSearchConfigs[i]
+		}
+	}
+	return nil
+}
+
+// AddTextSearchDictionary adds a text search dictionary to the schema.
+func (desc *Mutable) AddTextSearchDictionary(dict descpb.TextSearchDictionaryDescriptor) {
+	desc.TextSearchDictionaries = append(desc.TextSearchDictionaries, dict)
+}
+
+// RemoveTextSearchDictionary removes the text search dictionary with the given
+// name from the schema.
+func (desc *Mutable) RemoveTextSearchDictionary(name string) {
+	for i := range desc.TextSearchDictionaries {
+		if desc.TextSearchDictionaries[i].Name == name {
+			desc.TextSearchDictionaries = append(desc.TextSearchDictionaries[:i], desc.TextSearchDictionaries[i+1:]...)
+			return
+		}
+	}
+}
+
+// AddTextSearchConfig adds a text search configuration to the schema.
+func (desc *Mutable) AddTextSearchConfig(config descpb.TextSearchConfigDescriptor) {
+	desc.TextSearchConfigs = append(desc.TextSearchConfigs, config)
+}
+
+// RemoveTextSearchConfig removes the text search configuration with the given
+// name from the schema.
+func (desc *Mutable) RemoveTextSearchConfig(name string) {
+	for i := range desc.TextSearchConfigs {
+		if desc.TextSearchConfigs[i].Name == name {
+			desc.TextSearchConfigs = append(desc.TextSearchConfigs[:i], desc.TextSearchConfigs[i+1:]...)
+			return
+		}
+	}
+}
+
 // GetObjectType implements the Object interface.
 func (desc *immutable) GetObjectType() privilege.ObjectType {
 	return privilege.Schema
@@ -503,11 +614,15 @@ func (desc *immutable) GetResolvedFuncDefinition(
 				return retType
 			},
 			IsUDF:                    true,
+			IsProcedure:              sig.IsProcedure,
 			UDFContainsOnlySignature: true,
 		}
 		if funcDescPb.Signatures[i].ReturnSet {
 			overload.Class = tree.GeneratorClass
 		}
+		if funcDescPb.Signatures[i].IsAggregate {
+			overload.Class = tree.AggregateClass
+		}
 		paramTypes := make(tree.ParamTypes, 0, len(sig.ArgTypes))
 		for _, paramType := range sig.ArgTypes {
 			paramTypes = append(
@@ -118,6 +118,51 @@ func TestValidateSchemaSelf(t *testing.T) {
 				},
 			},
 		},
+		{ // 5
+			err: `duplicate text search dictionary name: "d"`,
+			desc: descpb.SchemaDescriptor{
+				ID:         52,
+				ParentID:   51,
+				Name:       "schema1",
+				Privileges: defaultPrivilege,
+				TextSearchDictionaries: []descpb.TextSearchDictionaryDescriptor{
+					{Name: "d", Template: "simple"},
+					{Name: "d", Template: "simple"},
+				},
+			},
+		},
+		{ // 6
+			err: `text search configuration "c" has unknown parser "ngram"`,
+			desc: descpb.SchemaDescriptor{
+				ID:         52,
+				ParentID:   51,
+				Name:       "schema1",
+				Privileges: defaultPrivilege,
+				TextSearchConfigs: []descpb.TextSearchConfigDescriptor{
+					{Name: "c", Parser: "ngram"},
+				},
+			},
+		},
+		{ // 7
+			err: `text search configuration "c" refers to unknown dictionary "d"`,
+			desc: descpb.SchemaDescriptor{
+				ID:         52,
+				ParentID:   51,
+				Name:       "schema1",
+				Privileges: defaultPrivilege,
+				TextSearchConfigs: []descpb.TextSearchConfigDescriptor{{
+					Name:   "c",
+					Parser: "default",
+					Mappings: []descpb.TextSearchConfigDescriptor_Mapping{{
+						TokenType: "asciiword",
+						Dictionaries: []descpb.TextSearchConfigDescriptor_DictionaryRef{
+							{Name: "english_stem", Builtin: true},
+							{Name: "d"},
+						},
+					}},
+				}},
+			},
+		},
 	}
 
 	for i, test := range tests {
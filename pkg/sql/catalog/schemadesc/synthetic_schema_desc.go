@@ -139,6 +139,18 @@ func (p synthetic) ForEachFunctionSignature(
 	return nil
 }
 
+// FindTextSearchDictionaryByName implements the SchemaDescriptor interface.
+func (p synthetic) FindTextSearchDictionaryByName(
+	name string,
+) *descpb.TextSearchDictionaryDescriptor {
+	return nil
+}
+
+// FindTextSearchConfigByName implements the SchemaDescriptor interface.
+func (p synthetic) FindTextSearchConfigByName(name string) *descpb.TextSearchConfigDescriptor {
+	return nil
+}
+
 // ForEachUDTDependentForHydration implements the catalog.Descriptor interface.
 func (p synthetic) ForEachUDTDependentForHydration(fn func(t *types.T) error) error {
 	return nil
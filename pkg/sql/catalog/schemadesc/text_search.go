@@ -0,0 +1,76 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemadesc
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/errors"
+)
+
+// TextSearchDefaultParser is the name of the only text search parser, which is
+// the parser of every text search configuration.
+const TextSearchDefaultParser = "default"
+
+// NewTextSearchDictionary returns the text search dictionary described by the
+// given descriptor.
+func NewTextSearchDictionary(
+	d *descpb.TextSearchDictionaryDescriptor,
+) (*tsearch.Dictionary, error) {
+	options := make([]tsearch.DictionaryOption, len(d.Options))
+	for i, o := range d.Options {
+		options[i] = tsearch.DictionaryOption{Name: o.Name, Value: o.Value}
+	}
+	return tsearch.NewDictionary(d.Name, d.Template, options)
+}
+
+// NewTextSearchConfig returns the text search configuration described by the
+// given descriptor, which is defined in the given schema, and is named with
+// the given qualified name.
+func NewTextSearchConfig(
+	schema catalog.SchemaDescriptor, qualifiedName string, c *descpb.TextSearchConfigDescriptor,
+) (*tsearch.Config, error) {
+	config := tsearch.NewConfig(qualifiedName)
+	dicts := make(map[string]*tsearch.Dictionary)
+	for _, m := range c.Mappings {
+		tokenType, err := tsearch.TokenTypeByName(m.TokenType)
+		if err != nil {
+			return nil, err
+		}
+		mapping := make([]*tsearch.Dictionary, 0, len(m.Dictionaries))
+		for _, ref := range m.Dictionaries {
+			if ref.Builtin {
+				d, ok := tsearch.BuiltinDictionary(ref.Name)
+				if !ok {
+					return nil, tsearch.UndefinedDictionaryError(ref.Name)
+				}
+				mapping = append(mapping, d)
+				continue
+			}
+			d, ok := dicts[ref.Name]
+			if !ok {
+				desc := schema.FindTextSearchDictionaryByName(ref.Name)
+				if desc == nil {
+					return nil, errors.AssertionFailedf(
+						"text search configuration %q refers to unknown dictionary %q", c.Name, ref.Name)
+				}
+				if d, err = NewTextSearchDictionary(desc); err != nil {
+					return nil, err
+				}
+				dicts[ref.Name] = d
+			}
+			mapping = append(mapping, d)
+		}
+		config.SetMapping(tokenType, mapping)
+	}
+	return config, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// domainValueName is the name by which a domain CHECK expression refers to
+// the value being checked.
+const domainValueName = "value"
+
+// ReplaceDomainValue returns a copy of the given domain CHECK expression in
+// which every reference to VALUE is replaced with the given expression.
+func ReplaceDomainValue(expr tree.Expr, value tree.Expr) (tree.Expr, error) {
+	return tree.SimpleVisit(expr, func(e tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		if n, ok := e.(*tree.UnresolvedName); ok && n.NumParts == 1 && n.Parts[0] == domainValueName {
+			return false, value, nil
+		}
+		return true, e, nil
+	})
+}
+
+// ParseDomainCheckExpr parses the serialized CHECK expression of a domain and
+// replaces VALUE with the given expression.
+func ParseDomainCheckExpr(checkExpr string, value tree.Expr) (tree.Expr, error) {
+	expr, err := parser.ParseExpr(checkExpr)
+	if err != nil {
+		return nil, err
+	}
+	return ReplaceDomainValue(expr, value)
+}
+
+// ValidateDomainCheckExpr verifies that the given CHECK expression of a domain
+// over baseType is a valid boolean expression which references no variables
+// other than VALUE. It returns the serialized form of the expression.
+func ValidateDomainCheckExpr(
+	ctx context.Context, expr tree.Expr, baseType *types.T, semaCtx *tree.SemaContext,
+) (string, error) {
+	// Type check the expression with a typed NULL standing in for VALUE.
+	replaced, err := ReplaceDomainValue(expr, tree.NewTypedCastExpr(tree.DNull, baseType))
+	if err != nil {
+		return "", err
+	}
+	if _, err := SanitizeVarFreeExpr(
+		ctx, replaced, types.Bool, tree.DomainCheckExpr, semaCtx, volatility.Volatile, false, /* allowAssignmentCast */
+	); err != nil {
+		return "", err
+	}
+	return tree.Serialize(expr), nil
+}
+
+// ValidateDomainDefaultExpr verifies that the given DEFAULT expression of a
+// domain over baseType is valid. It returns the serialized form of the
+// type-checked expression.
+func ValidateDomainDefaultExpr(
+	ctx context.Context, expr tree.Expr, baseType *types.T, semaCtx *tree.SemaContext,
+) (string, error) {
+	typedExpr, err := SanitizeVarFreeExpr(
+		ctx, expr, baseType, tree.DomainDefaultExpr, semaCtx, volatility.Volatile, true, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return "", err
+	}
+	return tree.Serialize(typedExpr), nil
+}
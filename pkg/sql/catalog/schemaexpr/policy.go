@@ -0,0 +1,91 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// ValidatePolicyExpr verifies that an expression is a valid USING or WITH
+// CHECK expression of a row-level security policy. If the expression is
+// valid, it returns the serialized expression with the columns dequalified.
+//
+// A policy expression is valid if it results in a boolean, refers only to
+// columns in the table, and does not include subqueries or aggregate, window,
+// or set returning functions.
+func ValidatePolicyExpr(
+	ctx context.Context,
+	desc catalog.TableDescriptor,
+	e tree.Expr,
+	tn *tree.TableName,
+	semaCtx *tree.SemaContext,
+	version clusterversion.ClusterVersion,
+) (string, error) {
+	expr, _, _, err := DequalifyAndValidateExpr(
+		ctx,
+		desc,
+		e,
+		types.Bool,
+		tree.PolicyExpr,
+		semaCtx,
+		volatility.Volatile,
+		tn,
+		version,
+	)
+	if err != nil {
+		return "", err
+	}
+	return expr, nil
+}
+
+// ValidatePoliciesDoNotDependOnColumn verifies that no USING or WITH CHECK
+// expression of the table's row-level security policies references the given
+// column.
+func ValidatePoliciesDoNotDependOnColumn(
+	tableDesc catalog.TableDescriptor, col catalog.Column,
+) error {
+	policies := tableDesc.GetPolicies()
+	for i := range policies {
+		p := &policies[i]
+		for _, exprStr := range []string{p.UsingExpr, p.WithCheckExpr} {
+			if exprStr == "" {
+				continue
+			}
+			expr, err := parser.ParseExpr(exprStr)
+			if err != nil {
+				// At this point, we should be able to parse the policy expression.
+				return errors.WithAssertionFailure(err)
+			}
+			referencedCols, err := ExtractColumnIDs(tableDesc, expr)
+			if err != nil {
+				return err
+			}
+			if referencedCols.Contains(col.GetID()) {
+				return pgerror.Newf(
+					pgcode.DependentObjectsStillExist,
+					"cannot drop column %q because policy %q on table %q depends on it",
+					col.ColName(), p.Name, tableDesc.GetName(),
+				)
+			}
+		}
+	}
+	return nil
+}
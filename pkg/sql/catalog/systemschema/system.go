@@ -992,6 +992,35 @@ CREATE TABLE system.span_stats_tenant_boundaries (
 	CONSTRAINT "primary" PRIMARY KEY (tenant_id),
 	FAMILY "primary" (tenant_id, boundaries)
 );`
+
+	// NotificationsTableSchema stores the payloads published with NOTIFY. Rows
+	// are written in the notifying transaction, delivered to the listening
+	// sessions through a rangefeed once it commits, and deleted shortly after.
+	NotificationsTableSchema = `
+CREATE TABLE system.notifications (
+	id       UUID NOT NULL DEFAULT gen_random_uuid(),
+	channel  STRING NOT NULL,
+	payload  STRING NOT NULL,
+	pid      INT4 NOT NULL,
+	created  TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	CONSTRAINT "primary" PRIMARY KEY (id),
+	FAMILY "primary" (id, channel, payload, pid, created)
+);`
+
+	// ReplicationSlotsTableSchema stores the logical replication slots created
+	// with CREATE_REPLICATION_SLOT. The confirmed_flush_lsn column records the
+	// position up to which the consumer has acknowledged changes, and is where
+	// the next START_REPLICATION on the slot resumes from.
+	ReplicationSlotsTableSchema = `
+CREATE TABLE system.replication_slots (
+	slot_name           STRING NOT NULL,
+	database_id         INT8 NOT NULL,
+	plugin              STRING NOT NULL,
+	confirmed_flush_lsn INT8 NOT NULL,
+	created             TIMESTAMPTZ NOT NULL DEFAULT now():::TIMESTAMPTZ,
+	CONSTRAINT "primary" PRIMARY KEY (slot_name),
+	FAMILY "primary" (slot_name, database_id, plugin, confirmed_flush_lsn, created)
+);`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1201,6 +1230,8 @@ func MakeSystemTables() []SystemTable {
 		SystemTenantTasksTable,
 		StatementActivityTable,
 		TransactionActivityTable,
+		NotificationsTable,
+		ReplicationSlotsTable,
 	}
 }
 
@@ -4022,6 +4053,68 @@ var (
 			},
 		),
 	)
+
+	NotificationsTable = makeSystemTable(
+		NotificationsTableSchema,
+		systemTable(
+			catconstants.NotificationsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "id", ID: 1, Type: types.Uuid, DefaultExpr: &genRandomUUIDString},
+				{Name: "channel", ID: 2, Type: types.String},
+				{Name: "payload", ID: 3, Type: types.String},
+				{Name: "pid", ID: 4, Type: types.Int4},
+				{Name: "created", ID: 5, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"id", "channel", "payload", "pid", "created"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:                "primary",
+				ID:                  1,
+				Unique:              true,
+				KeyColumnNames:      []string{"id"},
+				KeyColumnDirections: singleASC,
+				KeyColumnIDs:        singleID1,
+			},
+		),
+	)
+
+	ReplicationSlotsTable = makeSystemTable(
+		ReplicationSlotsTableSchema,
+		systemTable(
+			catconstants.ReplicationSlotsTableName,
+			descpb.InvalidID, // dynamically assigned table ID
+			[]descpb.ColumnDescriptor{
+				{Name: "slot_name", ID: 1, Type: types.String},
+				{Name: "database_id", ID: 2, Type: types.Int},
+				{Name: "plugin", ID: 3, Type: types.String},
+				{Name: "confirmed_flush_lsn", ID: 4, Type: types.Int},
+				{Name: "created", ID: 5, Type: types.TimestampTZ, DefaultExpr: &nowTZString},
+			},
+			[]descpb.ColumnFamilyDescriptor{
+				{
+					Name:        "primary",
+					ID:          0,
+					ColumnNames: []string{"slot_name", "database_id", "plugin", "confirmed_flush_lsn", "created"},
+					ColumnIDs:   []descpb.ColumnID{1, 2, 3, 4, 5},
+				},
+			},
+			descpb.IndexDescriptor{
+				Name:                "primary",
+				ID:                  1,
+				Unique:              true,
+				KeyColumnNames:      []string{"slot_name"},
+				KeyColumnDirections: singleASC,
+				KeyColumnIDs:        singleID1,
+			},
+		),
+	)
 )
 
 // SpanConfigurationsTableName represents system.span_configurations.
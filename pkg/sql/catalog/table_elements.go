@@ -170,6 +170,7 @@ type Index interface {
 	GetName() string
 	IsPartial() bool
 	IsUnique() bool
+	IsExclusion() bool
 	IsDisabled() bool
 	IsSharded() bool
 	IsNotVisible() bool
@@ -198,6 +199,7 @@ type Index interface {
 	GetKeyColumnID(columnOrdinal int) descpb.ColumnID
 	GetKeyColumnName(columnOrdinal int) string
 	GetKeyColumnDirection(columnOrdinal int) catenumpb.IndexColumn_Direction
+	GetExclusionOperator(columnOrdinal int) semenumpb.ExclusionOperator
 
 	CollectKeyColumnIDs() TableColSet
 	CollectKeySuffixColumnIDs() TableColSet
@@ -586,6 +588,10 @@ type ForeignKeyConstraint interface {
 
 	// Match returns the type of algorithm used to match composite keys.
 	Match() semenumpb.Match
+
+	// Deferrability returns whether the checks for this foreign key can be
+	// deferred until the end of the transaction.
+	Deferrability() semenumpb.Deferrability
 }
 
 // UniqueWithoutIndexConstraint is an interface around a unique constraint
@@ -599,6 +605,10 @@ type UniqueWithoutIndexConstraint interface {
 
 	// ParentTableID returns the ID of the table this constraint applies to.
 	ParentTableID() descpb.ID
+
+	// Deferrability returns whether the checks for this constraint can be
+	// deferred until the end of the transaction.
+	Deferrability() semenumpb.Deferrability
 }
 
 // PrimaryKeySwap is an interface around a primary key swap mutation.
@@ -381,7 +381,7 @@ func newColumnCache(desc *descpb.TableDescriptor, mutations *mutationCache) *col
 		if col.Public() && !col.IsInaccessible() {
 			lazyAllocAppendColumn(&c.accessible, col, numPublic)
 		}
-		if col.HasType() && col.GetType().UserDefined() {
+		if col.HasType() && (col.GetType().UserDefined() || col.GetType().DomainOID() != 0) {
 			lazyAllocAppendColumn(&c.withUDTs, col, numDeletable)
 		}
 	}
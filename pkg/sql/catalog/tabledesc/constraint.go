@@ -146,6 +146,12 @@ func (c uniqueWithoutIndexConstraint) ParentTableID() descpb.ID {
 	return c.desc.TableID
 }
 
+// Deferrability implements the catalog.UniqueWithoutIndexConstraint
+// interface.
+func (c uniqueWithoutIndexConstraint) Deferrability() semenumpb.Deferrability {
+	return c.desc.Deferrability
+}
+
 // IsValidReferencedUniqueConstraint implements the catalog.UniqueConstraint
 // interface.
 func (c uniqueWithoutIndexConstraint) IsValidReferencedUniqueConstraint(
@@ -292,6 +298,11 @@ func (c foreignKeyConstraint) Match() semenumpb.Match {
 	return c.desc.Match
 }
 
+// Deferrability implements the catalog.ForeignKeyConstraint interface.
+func (c foreignKeyConstraint) Deferrability() semenumpb.Deferrability {
+	return c.desc.Deferrability
+}
+
 // GetConstraintID implements the catalog.Constraint interface.
 func (c foreignKeyConstraint) GetConstraintID() descpb.ConstraintID {
 	return c.desc.ConstraintID
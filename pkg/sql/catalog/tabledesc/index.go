@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
@@ -97,6 +98,11 @@ func (w index) IsUnique() bool {
 	return w.desc.Unique
 }
 
+// IsExclusion returns true iff the index backs an exclusion constraint.
+func (w index) IsExclusion() bool {
+	return len(w.desc.ExclusionOperators) > 0
+}
+
 // IsDisabled returns true iff the index is disabled.
 func (w index) IsDisabled() bool {
 	return w.desc.Disabled
@@ -319,6 +325,13 @@ func (w index) GetKeyColumnDirection(columnOrdinal int) catenumpb.IndexColumn_Di
 	return w.desc.KeyColumnDirections[columnOrdinal]
 }
 
+// GetExclusionOperator returns the operator that the exclusion constraint
+// backed by the index uses to compare values of the columnOrdinal-th column in
+// the index key. Panics if the index does not back an exclusion constraint.
+func (w index) GetExclusionOperator(columnOrdinal int) semenumpb.ExclusionOperator {
+	return w.desc.ExclusionOperators[columnOrdinal]
+}
+
 // NumPrimaryStoredColumns returns the number of columns which the index
 // stores in addition to the columns which are part of the primary key.
 // Returns 0 if the index isn't primary.
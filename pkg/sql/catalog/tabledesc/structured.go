@@ -135,6 +135,9 @@ func (desc *wrapper) IndexKeysPerRow(idx catalog.Index) int {
 //	CREATE UNIQUE INDEX ON t (a, b)
 //	=> t_a_b_key
 //
+//	ALTER TABLE t ADD EXCLUDE (a WITH =)
+//	=> t_a_excl
+//
 //	CREATE INDEX ON t ((a + b), c, lower(d))
 //	=> t_expr_c_expr1_idx
 func BuildIndexName(tableDesc *Mutable, idx *descpb.IndexDescriptor) (string, error) {
@@ -179,6 +182,8 @@ func BuildIndexName(tableDesc *Mutable, idx *descpb.IndexDescriptor) (string, er
 	// Add the final segment.
 	if idx.Unique {
 		segments = append(segments, "key")
+	} else if len(idx.ExclusionOperators) > 0 {
+		segments = append(segments, "excl")
 	} else {
 		segments = append(segments, "idx")
 	}
@@ -283,6 +288,17 @@ func ForEachExprStringInTableDesc(descI catalog.TableDescriptor, f func(expr *st
 		}
 		return nil
 	}
+	doPolicy := func(p *descpb.PolicyDescriptor) error {
+		if p.UsingExpr != "" {
+			if err := f(&p.UsingExpr); err != nil {
+				return err
+			}
+		}
+		if p.WithCheckExpr != "" {
+			return f(&p.WithCheckExpr)
+		}
+		return nil
+	}
 
 	// Process columns.
 	for i := range desc.Columns {
@@ -314,6 +330,13 @@ func ForEachExprStringInTableDesc(descI catalog.TableDescriptor, f func(expr *st
 		}
 	}
 
+	// Process policies.
+	for i := range desc.Policies {
+		if err := doPolicy(&desc.Policies[i]); err != nil {
+			return err
+		}
+	}
+
 	// Process all non-index mutations.
 	for _, mut := range desc.Mutations {
 		if c := mut.GetColumn(); c != nil {
@@ -381,6 +404,9 @@ func (desc *wrapper) GetAllReferencedFunctionIDs() (catalog.DescriptorIDSet, err
 			ret.Add(id)
 		}
 	}
+	for i := range desc.Triggers {
+		ret.Add(desc.Triggers[i].FuncID)
+	}
 	// TODO(chengxiong): add logic to extract references from indexes when UDFs
 	// are allowed in them.
 	return ret.Union(catalog.MakeDescriptorIDSet(desc.DependsOnFunctions...)), nil
@@ -1406,6 +1432,41 @@ func (desc *wrapper) FindFKByName(name string) (*descpb.ForeignKeyConstraint, er
 	return nil, fmt.Errorf("fk %q does not exist", name)
 }
 
+// FindTriggerByName implements the TableDescriptor interface.
+func (desc *wrapper) FindTriggerByName(name string) *descpb.TriggerDescriptor {
+	for i := range desc.Triggers {
+		if desc.Triggers[i].Name == name {
+			return &desc.Triggers[i]
+		}
+	}
+	return nil
+}
+
+// IsRowLevelSecurityEnabled implements the TableDescriptor interface.
+func (desc *wrapper) IsRowLevelSecurityEnabled() bool {
+	return desc.RowLevelSecurityEnabled
+}
+
+// IsRowLevelSecurityForced implements the TableDescriptor interface.
+func (desc *wrapper) IsRowLevelSecurityForced() bool {
+	return desc.RowLevelSecurityForced
+}
+
+// FindPolicyByName implements the TableDescriptor interface.
+func (desc *wrapper) FindPolicyByName(name string) *descpb.PolicyDescriptor {
+	for i := range desc.Policies {
+		if desc.Policies[i].Name == name {
+			return &desc.Policies[i]
+		}
+	}
+	return nil
+}
+
+// IsForeignTable implements the TableDescriptor interface.
+func (desc *wrapper) IsForeignTable() bool {
+	return desc.ForeignTable != nil
+}
+
 // IsPrimaryIndexDefaultRowID returns whether or not the table's primary
 // index is the default primary key on the hidden rowid column.
 func (desc *wrapper) IsPrimaryIndexDefaultRowID() bool {
@@ -2215,6 +2276,49 @@ func (desc *wrapper) GetFamilyOfColumn(
 	return nil, errors.Newf("no column family found for column id %v", colID)
 }
 
+// AddTrigger adds a trigger to the table, allocating it a new trigger ID.
+func (desc *Mutable) AddTrigger(trigger descpb.TriggerDescriptor) descpb.TriggerID {
+	if desc.NextTriggerID == 0 {
+		desc.NextTriggerID = 1
+	}
+	trigger.ID = desc.NextTriggerID
+	desc.NextTriggerID++
+	desc.Triggers = append(desc.Triggers, trigger)
+	return trigger.ID
+}
+
+// RemoveTrigger removes the trigger with the given ID from the table.
+func (desc *Mutable) RemoveTrigger(id descpb.TriggerID) {
+	for i := range desc.Triggers {
+		if desc.Triggers[i].ID == id {
+			desc.Triggers = append(desc.Triggers[:i], desc.Triggers[i+1:]...)
+			return
+		}
+	}
+}
+
+// AddPolicy adds a row-level security policy to the table, allocating it a
+// new policy ID.
+func (desc *Mutable) AddPolicy(policy descpb.PolicyDescriptor) descpb.PolicyID {
+	if desc.NextPolicyID == 0 {
+		desc.NextPolicyID = 1
+	}
+	policy.ID = desc.NextPolicyID
+	desc.NextPolicyID++
+	desc.Policies = append(desc.Policies, policy)
+	return policy.ID
+}
+
+// RemovePolicy removes the policy with the given ID from the table.
+func (desc *Mutable) RemovePolicy(id descpb.PolicyID) {
+	for i := range desc.Policies {
+		if desc.Policies[i].ID == id {
+			desc.Policies = append(desc.Policies[:i], desc.Policies[i+1:]...)
+			return
+		}
+	}
+}
+
 // SetAuditMode configures the audit mode on the descriptor.
 func (desc *Mutable) SetAuditMode(mode tree.AuditMode) (bool, error) {
 	prev := desc.AuditMode
@@ -2256,6 +2360,10 @@ func (desc *wrapper) FindAllReferences() (map[descpb.ID]struct{}, error) {
 	for _, c := range desc.DependedOnBy {
 		refs[c.ID] = struct{}{}
 	}
+
+	for i := range desc.Triggers {
+		refs[desc.Triggers[i].FuncID] = struct{}{}
+	}
 	return refs, nil
 }
 
@@ -521,6 +521,21 @@ func RenameColumnInTable(
 		}
 	}
 
+	// Rename the column in row-level security policy expressions.
+	for i := range tableDesc.Policies {
+		p := &tableDesc.Policies[i]
+		if p.UsingExpr != "" {
+			if err := renameInExpr(&p.UsingExpr); err != nil {
+				return err
+			}
+		}
+		if p.WithCheckExpr != "" {
+			if err := renameInExpr(&p.WithCheckExpr); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Do all of the above renames inside check constraints, computed expressions,
 	// and idx predicates that are in mutations.
 	for i := range tableDesc.Mutations {
@@ -115,6 +115,9 @@ func (desc *wrapper) GetReferencedDescIDs() (catalog.DescriptorIDSet, error) {
 	for _, id := range desc.GetDependsOnFunctions() {
 		ids.Add(id)
 	}
+	for i := range desc.Triggers {
+		ids.Add(desc.Triggers[i].FuncID)
+	}
 	for _, ref := range desc.GetDependedOnBy() {
 		ids.Add(ref.ID)
 	}
@@ -199,6 +202,11 @@ func (desc *wrapper) ValidateForwardReferences(
 		}
 	}
 
+	// Check all functions referenced by triggers exist.
+	for i := range desc.Triggers {
+		vea.Report(desc.validateOutboundFuncRef(desc.Triggers[i].FuncID, vdg))
+	}
+
 	// Check enforced outbound foreign keys.
 	for _, fk := range desc.EnforcedOutboundForeignKeys() {
 		vea.Report(desc.validateOutboundFK(fk.ForeignKeyDesc(), vdg))
@@ -251,6 +259,16 @@ func (desc *wrapper) ValidateBackReferences(
 		}
 	}
 
+	// Check back-references in functions referenced by triggers.
+	for i := range desc.Triggers {
+		fn, err := vdg.GetFunctionDescriptor(desc.Triggers[i].FuncID)
+		if err != nil {
+			vea.Report(err)
+			continue
+		}
+		vea.Report(desc.validateOutboundFuncRefBackReferenceForTrigger(fn, desc.Triggers[i].ID))
+	}
+
 	// Check back-references in functions referenced by columns.
 	for _, col := range desc.Columns {
 		for _, fnID := range col.UsesFunctionIds {
@@ -376,6 +394,23 @@ func (desc *wrapper) validateOutboundFuncRefBackReferenceForColumn(
 		ref.GetName(), ref.GetID())
 }
 
+func (desc *wrapper) validateOutboundFuncRefBackReferenceForTrigger(
+	ref catalog.FunctionDescriptor, triggerID descpb.TriggerID,
+) error {
+	for _, dep := range ref.GetDependedOnBy() {
+		if dep.ID != desc.GetID() {
+			continue
+		}
+		for _, id := range dep.TriggerIDs {
+			if id == triggerID {
+				return nil
+			}
+		}
+	}
+	return errors.AssertionFailedf("depends-on function %q (%d) has no corresponding depended-on-by back reference",
+		ref.GetName(), ref.GetID())
+}
+
 func (desc *wrapper) validateInboundFunctionRef(
 	by descpb.TableDescriptor_Reference, vdg catalog.ValidationDescGetter,
 ) error {
@@ -750,6 +785,9 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	// actually a table, not if it's just a view.
 	if desc.IsPhysicalTable() {
 		desc.validateConstraintNamesAndIDs(vea)
+		desc.validateTriggers(vea)
+		desc.validatePolicies(vea)
+		desc.validateForeignTable(vea)
 		newErrs := []error{
 			desc.validateColumnFamilies(columnsByID),
 			desc.validateCheckConstraints(columnsByID),
@@ -987,6 +1025,81 @@ func (desc *wrapper) validateConstraintNamesAndIDs(vea catalog.ValidationErrorAc
 
 }
 
+// validateTriggers validates that the table's triggers have unique names and
+// IDs, and that they fire on at least one event.
+func (desc *wrapper) validateTriggers(vea catalog.ValidationErrorAccumulator) {
+	names := make(map[string]struct{}, len(desc.Triggers))
+	ids := make(map[descpb.TriggerID]struct{}, len(desc.Triggers))
+	for i := range desc.Triggers {
+		t := &desc.Triggers[i]
+		if t.ID == 0 {
+			vea.Report(errors.AssertionFailedf("trigger ID was missing for trigger %q", t.Name))
+		} else if t.ID >= desc.NextTriggerID {
+			vea.Report(errors.AssertionFailedf(
+				"trigger %q has ID %d not less than NextTriggerID value %d for table",
+				t.Name, t.ID, desc.NextTriggerID))
+		}
+		if t.Name == "" {
+			vea.Report(pgerror.Newf(pgcode.Syntax, "empty trigger name"))
+		}
+		if _, found := names[t.Name]; found {
+			vea.Report(pgerror.Newf(pgcode.DuplicateObject, "duplicate trigger name: %q", t.Name))
+		}
+		names[t.Name] = struct{}{}
+		if _, found := ids[t.ID]; found {
+			vea.Report(pgerror.Newf(pgcode.DuplicateObject,
+				"trigger ID %d in trigger %q already in use", t.ID, t.Name))
+		}
+		ids[t.ID] = struct{}{}
+		if len(t.Events) == 0 {
+			vea.Report(errors.AssertionFailedf("trigger %q has no events", t.Name))
+		}
+	}
+}
+
+// validatePolicies validates that the table's row-level security policies have
+// unique names and IDs.
+func (desc *wrapper) validatePolicies(vea catalog.ValidationErrorAccumulator) {
+	names := make(map[string]struct{}, len(desc.Policies))
+	ids := make(map[descpb.PolicyID]struct{}, len(desc.Policies))
+	for i := range desc.Policies {
+		p := &desc.Policies[i]
+		if p.ID == 0 {
+			vea.Report(errors.AssertionFailedf("policy ID was missing for policy %q", p.Name))
+		} else if p.ID >= desc.NextPolicyID {
+			vea.Report(errors.AssertionFailedf(
+				"policy %q has ID %d not less than NextPolicyID value %d for table",
+				p.Name, p.ID, desc.NextPolicyID))
+		}
+		if p.Name == "" {
+			vea.Report(pgerror.Newf(pgcode.Syntax, "empty policy name"))
+		}
+		if _, found := names[p.Name]; found {
+			vea.Report(pgerror.Newf(pgcode.DuplicateObject, "duplicate policy name: %q", p.Name))
+		}
+		names[p.Name] = struct{}{}
+		if _, found := ids[p.ID]; found {
+			vea.Report(pgerror.Newf(pgcode.DuplicateObject,
+				"policy ID %d in policy %q already in use", p.ID, p.Name))
+		}
+		ids[p.ID] = struct{}{}
+	}
+}
+
+// validateForeignTable validates that a foreign table has a location and no
+// secondary indexes, since its rows are not stored in the KV layer.
+func (desc *wrapper) validateForeignTable(vea catalog.ValidationErrorAccumulator) {
+	if desc.ForeignTable == nil {
+		return
+	}
+	if desc.ForeignTable.Location == "" {
+		vea.Report(errors.AssertionFailedf("foreign table %q has no location", desc.Name))
+	}
+	if len(desc.Indexes) > 0 {
+		vea.Report(errors.AssertionFailedf("foreign table %q has secondary indexes", desc.Name))
+	}
+}
+
 func (desc *wrapper) validateColumns() error {
 	columnIDs := make(map[descpb.ColumnID]*descpb.ColumnDescriptor, len(desc.Columns))
 	columnNames := make(map[string]descpb.ColumnID, len(desc.Columns))
@@ -1334,6 +1447,15 @@ func (desc *wrapper) validateTableIndexes(columnsByID map[descpb.ColumnID]catalo
 			return errors.Newf("mismatched column IDs (%d) and directions (%d)",
 				len(idx.IndexDesc().KeyColumnIDs), len(idx.IndexDesc().KeyColumnDirections))
 		}
+		if idx.IsExclusion() {
+			if len(idx.IndexDesc().KeyColumnIDs) != len(idx.IndexDesc().ExclusionOperators) {
+				return errors.Newf("mismatched column IDs (%d) and exclusion operators (%d)",
+					len(idx.IndexDesc().KeyColumnIDs), len(idx.IndexDesc().ExclusionOperators))
+			}
+			if idx.Primary() || idx.IsUnique() {
+				return errors.Newf("exclusion constraint index %q cannot be unique", idx.GetName())
+			}
+		}
 		// In the old STORING encoding, stored columns are in ExtraColumnIDs;
 		// tolerate a longer list of column names.
 		if len(idx.IndexDesc().StoreColumnIDs) > len(idx.IndexDesc().StoreColumnNames) {
@@ -138,6 +138,14 @@ var validationMap = []struct {
 			"HistogramBuckets":              {status: thisFieldReferencesNoObjects},
 			"HistogramSamples":              {status: thisFieldReferencesNoObjects},
 			"SchemaLocked":                  {status: thisFieldReferencesNoObjects},
+			"LastRefreshTime":               {status: thisFieldReferencesNoObjects},
+			"Triggers":                      {status: iSolemnlySwearThisFieldIsValidated},
+			"NextTriggerID":                 {status: iSolemnlySwearThisFieldIsValidated},
+			"RowLevelSecurityEnabled":       {status: thisFieldReferencesNoObjects},
+			"RowLevelSecurityForced":        {status: thisFieldReferencesNoObjects},
+			"Policies":                      {status: iSolemnlySwearThisFieldIsValidated},
+			"NextPolicyID":                  {status: iSolemnlySwearThisFieldIsValidated},
+			"ForeignTable":                  {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -183,6 +191,7 @@ var validationMap = []struct {
 			"UseDeletePreservingEncoding": {status: thisFieldReferencesNoObjects},
 			"ConstraintID":                {status: iSolemnlySwearThisFieldIsValidated},
 			"CreatedAtNanos":              {status: thisFieldReferencesNoObjects},
+			"ExclusionOperators":          {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -233,17 +242,19 @@ var validationMap = []struct {
 			"OnUpdate":          {status: thisFieldReferencesNoObjects},
 			"Match":             {status: thisFieldReferencesNoObjects},
 			"ConstraintID":      {status: iSolemnlySwearThisFieldIsValidated},
+			"Deferrability":     {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
 		obj: descpb.UniqueWithoutIndexConstraint{},
 		fieldMap: map[string]validationStatusInfo{
-			"TableID":      {status: iSolemnlySwearThisFieldIsValidated},
-			"ColumnIDs":    {status: iSolemnlySwearThisFieldIsValidated},
-			"Name":         {status: thisFieldReferencesNoObjects},
-			"Validity":     {status: thisFieldReferencesNoObjects},
-			"Predicate":    {status: iSolemnlySwearThisFieldIsValidated},
-			"ConstraintID": {status: iSolemnlySwearThisFieldIsValidated},
+			"TableID":       {status: iSolemnlySwearThisFieldIsValidated},
+			"ColumnIDs":     {status: iSolemnlySwearThisFieldIsValidated},
+			"Name":          {status: thisFieldReferencesNoObjects},
+			"Validity":      {status: thisFieldReferencesNoObjects},
+			"Predicate":     {status: iSolemnlySwearThisFieldIsValidated},
+			"ConstraintID":  {status: iSolemnlySwearThisFieldIsValidated},
+			"Deferrability": {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
@@ -267,6 +278,7 @@ var validationMap = []struct {
 			"RegionConfig":                  {status: iSolemnlySwearThisFieldIsValidated},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
 			"Composite":                     {status: iSolemnlySwearThisFieldIsValidated},
+			"Domain":                        {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -283,6 +295,8 @@ var validationMap = []struct {
 			"RegionConfig":                  {status: iSolemnlySwearThisFieldIsValidated},
 			"DefaultPrivileges":             {status: iSolemnlySwearThisFieldIsValidated},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
+			"Publications":                  {status: iSolemnlySwearThisFieldIsValidated},
+			"NextPublicationID":             {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -300,6 +314,8 @@ var validationMap = []struct {
 			"DefaultPrivileges":             {status: iSolemnlySwearThisFieldIsValidated},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
 			"Functions":                     {status: iSolemnlySwearThisFieldIsValidated},
+			"TextSearchDictionaries":        {status: iSolemnlySwearThisFieldIsValidated},
+			"TextSearchConfigs":             {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 	{
@@ -333,6 +349,8 @@ var validationMap = []struct {
 			"ModificationTime":              {status: thisFieldReferencesNoObjects},
 			"Version":                       {status: thisFieldReferencesNoObjects},
 			"DeclarativeSchemaChangerState": {status: thisFieldReferencesNoObjects},
+			"IsProcedure":                   {status: thisFieldReferencesNoObjects},
+			"Aggregate":                     {status: iSolemnlySwearThisFieldIsValidated},
 		},
 	},
 }
@@ -88,7 +88,7 @@ func ensureTypeIsHydratedRecursive(
 	// Ensure that we have the descriptor for a user-defined type.
 	// Note that non-user-defined types may or may not have descriptors
 	// but still need to be hydrated using the name.
-	if t.UserDefined() {
+	if t.UserDefined() || t.DomainOID() != 0 {
 		id := GetUserDefinedTypeDescID(t)
 		if maybeDesc == nil || maybeDesc.GetID() != id {
 			if res == nil {
@@ -136,6 +136,22 @@ func ensureTypeMetadataIsHydrated(
 		tm.ImplicitRecordType = true
 		return
 	}
+	if d := maybeDesc.AsDomainTypeDescriptor(); d != nil {
+		tm.DomainData = &types.DomainMetadata{
+			NotNull: d.DomainNotNull(),
+			Checks:  make([]types.DomainCheck, d.NumDomainChecks()),
+		}
+		if expr, ok := d.DomainDefaultExpr(); ok {
+			tm.DomainData.DefaultExpr = &expr
+		}
+		for i := range tm.DomainData.Checks {
+			tm.DomainData.Checks[i] = types.DomainCheck{
+				Name: d.GetDomainCheckName(i),
+				Expr: d.GetDomainCheckExpr(i),
+			}
+		}
+		return
+	}
 	if e := maybeDesc.AsEnumTypeDescriptor(); e != nil {
 		n := e.NumEnumMembers()
 		tm.EnumData = &types.EnumMetadata{
@@ -43,6 +43,7 @@ var _ catalog.EnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.RegionEnumTypeDescriptor = (*immutable)(nil)
 var _ catalog.AliasTypeDescriptor = (*immutable)(nil)
 var _ catalog.CompositeTypeDescriptor = (*immutable)(nil)
+var _ catalog.DomainTypeDescriptor = (*immutable)(nil)
 var _ catalog.TypeDescriptor = (*Mutable)(nil)
 var _ catalog.MutableDescriptor = (*Mutable)(nil)
 
@@ -145,7 +146,11 @@ func UserDefinedTypeOIDToID(oid oid.Oid) descpb.ID {
 }
 
 // GetUserDefinedTypeDescID gets the type descriptor ID from a user defined type.
+// For domains, this is the ID of the domain's type descriptor.
 func GetUserDefinedTypeDescID(t *types.T) descpb.ID {
+	if t.DomainOID() != 0 {
+		return UserDefinedTypeOIDToID(t.DomainOID())
+	}
 	return UserDefinedTypeOIDToID(t.Oid())
 }
 
@@ -508,6 +513,16 @@ func (desc *immutable) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 		if desc.Composite == nil {
 			vea.Report(errors.AssertionFailedf("COMPOSITE type desc has nil composite type"))
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		if desc.Domain == nil || desc.Domain.BaseType == nil {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has nil base type"))
+		} else if desc.Domain.BaseType.UserDefined() {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has user-defined base type %s",
+				desc.Domain.BaseType.SQLStringForError()))
+		}
+		if desc.ArrayTypeID != descpb.InvalidID {
+			vea.Report(errors.AssertionFailedf("DOMAIN type desc has array type ID %d", desc.ArrayTypeID))
+		}
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		vea.Report(errors.AssertionFailedf("invalid type descriptor: kind %s should never be serialized or validated", desc.Kind.String()))
 	default:
@@ -768,6 +783,8 @@ func (desc *immutable) AsTypesT() *types.T {
 			contents,
 			labels,
 		)
+	case descpb.TypeDescriptor_DOMAIN:
+		return types.MakeDomain(desc.Domain.BaseType, catid.TypeIDToOID(desc.GetID()))
 	}
 	panic(errors.AssertionFailedf("unsupported descriptor kind %s", desc.Kind.String()))
 }
@@ -916,6 +933,8 @@ func (desc *immutable) GetIDClosure() (ret catalog.DescriptorIDSet) {
 		for _, e := range desc.Composite.Elements {
 			GetTypeDescriptorClosure(e.ElementType).ForEach(ret.Add)
 		}
+	case descpb.TypeDescriptor_DOMAIN:
+		// Domains have no array type and their base type is always built-in.
 	default:
 		// Otherwise, take the array type ID.
 		ret.Add(desc.ArrayTypeID)
@@ -931,6 +950,11 @@ func (desc *immutable) GetObjectType() privilege.ObjectType {
 // GetTypeDescriptorClosure returns all type descriptor IDs that are
 // referenced by this input types.T.
 func GetTypeDescriptorClosure(typ *types.T) (ret catalog.DescriptorIDSet) {
+	if typ.DomainOID() != 0 {
+		// Domains have no array type, and their base type is built-in.
+		ret.Add(GetUserDefinedTypeDescID(typ))
+		return ret
+	}
 	if !typ.UserDefined() {
 		return catalog.DescriptorIDSet{}
 	}
@@ -985,6 +1009,14 @@ func (desc *immutable) AsCompositeTypeDescriptor() catalog.CompositeTypeDescript
 	return nil
 }
 
+// AsDomainTypeDescriptor implements the catalog.TypeDescriptor interface.
+func (desc *immutable) AsDomainTypeDescriptor() catalog.DomainTypeDescriptor {
+	if desc.Kind == descpb.TypeDescriptor_DOMAIN {
+		return desc
+	}
+	return nil
+}
+
 // AsTableImplicitRecordTypeDescriptor implements the catalog.TypeDescriptor
 // interface.
 func (desc *immutable) AsTableImplicitRecordTypeDescriptor() catalog.TableImplicitRecordTypeDescriptor {
@@ -1011,6 +1043,39 @@ func (desc *immutable) GetElementType(ordinal int) *types.T {
 	return desc.Composite.Elements[ordinal].ElementType
 }
 
+// DomainBaseType implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) DomainBaseType() *types.T {
+	return desc.Domain.BaseType
+}
+
+// DomainNotNull implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) DomainNotNull() bool {
+	return desc.Domain.NotNull
+}
+
+// DomainDefaultExpr implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) DomainDefaultExpr() (string, bool) {
+	if desc.Domain.DefaultExpr == nil {
+		return "", false
+	}
+	return *desc.Domain.DefaultExpr, true
+}
+
+// NumDomainChecks implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) NumDomainChecks() int {
+	return len(desc.Domain.Checks)
+}
+
+// GetDomainCheckName implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetDomainCheckName(ordinal int) string {
+	return desc.Domain.Checks[ordinal].Name
+}
+
+// GetDomainCheckExpr implements the catalog.DomainTypeDescriptor interface.
+func (desc *immutable) GetDomainCheckExpr(ordinal int) string {
+	return desc.Domain.Checks[ordinal].Expr
+}
+
 // ForEachRegionInSuperRegion implements the catalog.RegionEnumTypeDescriptor
 // interface.
 func (desc *immutable) ForEachRegionInSuperRegion(
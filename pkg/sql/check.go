@@ -695,6 +695,103 @@ func validateUniqueConstraint(
 	return nil
 }
 
+// conflictingRowQuery generates and returns a SELECT query that returns the
+// values of the columns of the given exclusion constraint index for a row
+// that conflicts with another row in the table. Also returns the names of
+// those columns.
+func conflictingRowQuery(
+	srcTbl catalog.TableDescriptor, idx catalog.Index,
+) (sql string, colNames []string, _ error) {
+	colNames, err := catalog.ColumnNamesForIDs(srcTbl, idx.IndexDesc().KeyColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+	pkColNames, err := catalog.ColumnNamesForIDs(srcTbl, srcTbl.GetPrimaryIndex().IndexDesc().KeyColumnIDs)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// There will be an expression in the ON clause for each of the columns of
+	// the constraint, and one that prevents a row from matching itself.
+	srcCols := make([]string, len(colNames))
+	onExprs := make([]string, 0, len(colNames)+1)
+	for i, n := range colNames {
+		col := tree.NameString(n)
+		srcCols[i] = fmt.Sprintf("a.%s", col)
+		op := tree.ExclusionOperator(idx.GetExclusionOperator(i))
+		onExprs = append(onExprs, fmt.Sprintf("a.%[1]s %[2]s b.%[1]s", col, op))
+	}
+	pkA := make([]string, len(pkColNames))
+	pkB := make([]string, len(pkColNames))
+	for i, n := range pkColNames {
+		pkA[i] = fmt.Sprintf("a.%s", tree.NameString(n))
+		pkB[i] = fmt.Sprintf("b.%s", tree.NameString(n))
+	}
+	onExprs = append(onExprs, fmt.Sprintf(
+		"(%s) != (%s)", strings.Join(pkA, ", "), strings.Join(pkB, ", "),
+	))
+
+	query := fmt.Sprintf(
+		`SELECT %[1]s FROM [%[2]d AS a] JOIN [%[2]d AS b] ON %[3]s LIMIT 1`,
+		strings.Join(srcCols, ", "),    // 1
+		srcTbl.GetID(),                 // 2
+		strings.Join(onExprs, " AND "), // 3
+	)
+	return query, colNames, nil
+}
+
+// validateExclusionConstraint verifies that no two rows in the srcTable
+// conflict according to the exclusion constraint backed by the given index.
+//
+// It operates entirely on the current goroutine and is thus able to
+// reuse an existing kv.Txn safely.
+func validateExclusionConstraint(
+	ctx context.Context,
+	srcTable catalog.TableDescriptor,
+	idx catalog.Index,
+	txn isql.Txn,
+	user username.SQLUsername,
+) error {
+	query, colNames, err := conflictingRowQuery(srcTable, idx)
+	if err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "validating exclusion constraint %q (%q [%v]) with query %q",
+		idx.GetName(),
+		srcTable.GetName(),
+		colNames,
+		query,
+	)
+
+	sessionDataOverride := sessiondata.NoSessionDataOverride
+	sessionDataOverride.User = user
+	values, err := txn.QueryRowEx(ctx, "validate exclusion constraint", txn.KV(), sessionDataOverride, query)
+	if err != nil {
+		return err
+	}
+	if values.Len() > 0 {
+		valuesStr := make([]string, len(values))
+		for i := range values {
+			valuesStr[i] = values[i].String()
+		}
+		// Note: this error message mirrors the message produced by Postgres
+		// when it fails to add an exclusion constraint due to conflicting keys.
+		return errors.WithDetail(
+			pgerror.WithConstraintName(
+				pgerror.Newf(
+					pgcode.ExclusionViolation, "could not create exclusion constraint %q", idx.GetName(),
+				),
+				idx.GetName(),
+			),
+			fmt.Sprintf(
+				"Key (%s)=(%s) conflicts with another key.", strings.Join(colNames, ","), strings.Join(valuesStr, ","),
+			),
+		)
+	}
+	return nil
+}
+
 // ValidateTTLScheduledJobsInCurrentDB is part of the EvalPlanner interface.
 func (p *planner) ValidateTTLScheduledJobsInCurrentDB(ctx context.Context) error {
 	dbName := p.CurrentDatabase()
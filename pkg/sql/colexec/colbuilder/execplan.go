@@ -315,6 +315,7 @@ func canWrap(mode sessiondatapb.VectorizeExecMode, core *execinfrapb.ProcessorCo
 	case core.StreamIngestionData != nil:
 	case core.StreamIngestionFrontier != nil:
 	case core.HashGroupJoiner != nil:
+	case core.ForeignScan != nil:
 	default:
 		return errors.AssertionFailedf("unexpected processor core %q", core)
 	}
@@ -1001,17 +1002,25 @@ func NewColOperator(
 				break
 			}
 
+			input := inputs[0].Root
+			inputTypes := make([]*types.T, len(spec.Input[0].ColumnTypes))
+			copy(inputTypes, spec.Input[0].ColumnTypes)
+			if len(aggSpec.GroupingSets) > 0 {
+				// Grouping sets are computed by a regular hash aggregator over
+				// the input expanded for each grouping set.
+				input, inputTypes, aggSpec = colexec.PlanGroupingSets(
+					getStreamingAllocator(ctx, args), input, inputTypes, aggSpec,
+				)
+			}
 			var needHash bool
 			needHash, err = needHashAggregator(aggSpec)
 			if err != nil {
 				return r, err
 			}
-			inputTypes := make([]*types.T, len(spec.Input[0].ColumnTypes))
-			copy(inputTypes, spec.Input[0].ColumnTypes)
 			// Make a copy of the evalCtx since we're modifying it below.
 			evalCtx := flowCtx.NewEvalCtx()
 			newAggArgs := &colexecagg.NewAggregatorArgs{
-				Input:      inputs[0].Root,
+				Input:      input,
 				InputTypes: inputTypes,
 				Spec:       aggSpec,
 				EvalCtx:    evalCtx,
@@ -1078,7 +1087,7 @@ func NewColOperator(
 					// error even when used by the external hash aggregator).
 					evalCtx.SingleDatumAggMemAccount = ehaMemAccount
 					diskSpiller := colexecdisk.NewOneInputDiskSpiller(
-						inputs[0].Root, inMemoryHashAggregator.(colexecop.BufferingInMemoryOperator),
+						input, inMemoryHashAggregator.(colexecop.BufferingInMemoryOperator),
 						hashAggregatorMemMonitorName,
 						func(input colexecop.Operator) colexecop.Operator {
 							newAggArgs := *newAggArgs
@@ -1119,6 +1128,12 @@ func NewColOperator(
 				result.Root = colexec.NewOrderedAggregator(ctx, newAggArgs)
 				result.ToClose = append(result.ToClose, result.Root.(colexecop.Closer))
 			}
+			if len(core.Aggregator.GroupingSets) > 0 {
+				result.Root = colexec.NewGroupingSetsEmptyInputOp(
+					getStreamingAllocator(ctx, args), result.Root, evalCtx, core.Aggregator,
+					newAggArgs.Constructors, newAggArgs.ConstArguments, newAggArgs.OutputTypes,
+				)
+			}
 
 		case core.Distinct != nil:
 			if err := checkNumIn(inputs, 1); err != nil {
@@ -0,0 +1,275 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package colexec
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/col/coldata"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/colexecop"
+	"github.com/cockroachdb/cockroach/pkg/sql/colmem"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra/execagg"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+)
+
+// The vectorized engine computes GROUPING SETS, ROLLUP and CUBE with a single
+// pass over the input as follows:
+//   - the groupingSetsExpanderOp emits every input batch once for each of the
+//     grouping sets. Each of the emitted batches contains the input columns,
+//     then a copy of each of the group columns where the columns not in the
+//     grouping set are NULL, and then the ordinal of the grouping set;
+//   - a regular hash aggregator groups the expanded batches by the copies of
+//     the group columns and the ordinal of the grouping set (see
+//     PlanGroupingSets for how the aggregator spec is rewritten);
+//   - the groupingSetsEmptyInputOp produces a row for each empty grouping set
+//     if the input is empty (in which case the hash aggregator doesn't produce
+//     any groups).
+
+// PlanGroupingSets returns an operator that expands the input for each of the
+// grouping sets of the aggregator spec, along with the types of the expanded
+// batches and the spec of a regular aggregator that computes the grouping sets
+// over the expanded input. The output of the aggregator contains the results
+// of the aggregations of the original spec followed by the ordinal of the
+// grouping set.
+func PlanGroupingSets(
+	allocator *colmem.Allocator,
+	input colexecop.Operator,
+	inputTypes []*types.T,
+	spec *execinfrapb.AggregatorSpec,
+) (colexecop.Operator, []*types.T, *execinfrapb.AggregatorSpec) {
+	numInputCols := len(inputTypes)
+	expandedTypes := make([]*types.T, 0, numInputCols+len(spec.GroupCols)+1)
+	expandedTypes = append(expandedTypes, inputTypes...)
+	// maskedCols maps a group column to the index of its masked copy.
+	maskedCols := make(map[uint32]uint32, len(spec.GroupCols))
+	newGroupCols := make([]uint32, 0, len(spec.GroupCols)+1)
+	for _, c := range spec.GroupCols {
+		maskedCols[c] = uint32(len(expandedTypes))
+		newGroupCols = append(newGroupCols, uint32(len(expandedTypes)))
+		expandedTypes = append(expandedTypes, inputTypes[c])
+	}
+	ordinalCol := uint32(len(expandedTypes))
+	newGroupCols = append(newGroupCols, ordinalCol)
+	expandedTypes = append(expandedTypes, types.Int)
+
+	inSet := make([][]bool, len(spec.GroupingSets))
+	for i := range spec.GroupingSets {
+		var cols intsets.Fast
+		for _, c := range spec.GroupingSets[i].Cols {
+			cols.Add(int(c))
+		}
+		inSet[i] = make([]bool, len(spec.GroupCols))
+		for j, c := range spec.GroupCols {
+			inSet[i][j] = cols.Contains(int(c))
+		}
+	}
+
+	// ANY_NOT_NULL aggregations over the group columns read the masked copies
+	// so that they produce NULL for the columns not in the grouping set. The
+	// ordinal of the grouping set is output after the aggregations.
+	newAggs := make([]execinfrapb.AggregatorSpec_Aggregation, len(spec.Aggregations), len(spec.Aggregations)+1)
+	copy(newAggs, spec.Aggregations)
+	for i := range newAggs {
+		agg := &newAggs[i]
+		if agg.Func != execinfrapb.AnyNotNull || len(agg.ColIdx) != 1 {
+			continue
+		}
+		if masked, ok := maskedCols[agg.ColIdx[0]]; ok {
+			agg.ColIdx = []uint32{masked}
+		}
+	}
+	newAggs = append(newAggs, execinfrapb.AggregatorSpec_Aggregation{
+		Func:   execinfrapb.AnyNotNull,
+		ColIdx: []uint32{ordinalCol},
+	})
+
+	newSpec := &execinfrapb.AggregatorSpec{
+		Type:         execinfrapb.AggregatorSpec_NON_SCALAR,
+		GroupCols:    newGroupCols,
+		Aggregations: newAggs,
+	}
+	expander := &groupingSetsExpanderOp{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		allocator:      allocator,
+		numInputCols:   numInputCols,
+		outputTypes:    expandedTypes,
+		groupCols:      spec.GroupCols,
+		inSet:          inSet,
+	}
+	return expander, expandedTypes, newSpec
+}
+
+// groupingSetsExpanderOp emits every input batch once for each grouping set.
+// See the comment at the top of the file for the layout of the output.
+type groupingSetsExpanderOp struct {
+	colexecop.OneInputHelper
+
+	allocator    *colmem.Allocator
+	numInputCols int
+	outputTypes  []*types.T
+	groupCols    []uint32
+	// inSet[i][j] is true if groupCols[j] is part of the i-th grouping set.
+	inSet [][]bool
+
+	// batch is the current input batch, and nextSet is the ordinal of the next
+	// grouping set to emit it for.
+	batch   coldata.Batch
+	nextSet int
+	output  coldata.Batch
+}
+
+var _ colexecop.Operator = &groupingSetsExpanderOp{}
+
+func (e *groupingSetsExpanderOp) Next() coldata.Batch {
+	if e.batch == nil || e.nextSet == len(e.inSet) {
+		e.batch = e.Input.Next()
+		e.nextSet = 0
+	}
+	n := e.batch.Length()
+	if n == 0 {
+		return coldata.ZeroBatch
+	}
+	setIdx := e.nextSet
+	e.nextSet++
+	sel := e.batch.Selection()
+	if sel != nil {
+		sel = sel[:n]
+	}
+	e.output, _ = e.allocator.ResetMaybeReallocateNoMemLimit(e.outputTypes, e.output, n)
+	e.allocator.PerformOperation(e.output.ColVecs(), func() {
+		for i := 0; i < e.numInputCols; i++ {
+			e.output.ColVec(i).Copy(coldata.SliceArgs{
+				Src:       e.batch.ColVec(i),
+				Sel:       sel,
+				SrcEndIdx: n,
+			})
+		}
+		for j, c := range e.groupCols {
+			masked := e.output.ColVec(e.numInputCols + j)
+			if e.inSet[setIdx][j] {
+				masked.Copy(coldata.SliceArgs{
+					Src:       e.batch.ColVec(int(c)),
+					Sel:       sel,
+					SrcEndIdx: n,
+				})
+			} else {
+				masked.Nulls().SetNullRange(0 /* startIdx */, n)
+			}
+		}
+		ordinals := e.output.ColVec(len(e.outputTypes) - 1).Int64()[:n]
+		for i := range ordinals {
+			ordinals[i] = int64(setIdx)
+		}
+	})
+	e.output.SetLength(n)
+	return e.output
+}
+
+// NewGroupingSetsEmptyInputOp returns an operator that passes through the
+// output of the aggregator planned by PlanGroupingSets and, if the aggregator
+// didn't produce any rows (meaning that its input was empty), produces a row
+// for each empty grouping set. constructors and constArguments describe the
+// aggregations of the aggregator, and outputTypes are its output types.
+func NewGroupingSetsEmptyInputOp(
+	allocator *colmem.Allocator,
+	input colexecop.Operator,
+	evalCtx *eval.Context,
+	spec *execinfrapb.AggregatorSpec,
+	constructors []execagg.AggregateConstructor,
+	constArguments []tree.Datums,
+	outputTypes []*types.T,
+) colexecop.Operator {
+	var emptySets []int
+	for i := range spec.GroupingSets {
+		if len(spec.GroupingSets[i].Cols) == 0 {
+			emptySets = append(emptySets, i)
+		}
+	}
+	if len(emptySets) == 0 {
+		return input
+	}
+	return &groupingSetsEmptyInputOp{
+		OneInputHelper: colexecop.MakeOneInputHelper(input),
+		allocator:      allocator,
+		evalCtx:        evalCtx,
+		emptySets:      emptySets,
+		constructors:   constructors,
+		constArguments: constArguments,
+		outputTypes:    outputTypes,
+	}
+}
+
+// groupingSetsEmptyInputOp produces a row for each empty grouping set if its
+// input is empty. See NewGroupingSetsEmptyInputOp for more details.
+type groupingSetsEmptyInputOp struct {
+	colexecop.OneInputHelper
+
+	allocator      *colmem.Allocator
+	evalCtx        *eval.Context
+	emptySets      []int
+	constructors   []execagg.AggregateConstructor
+	constArguments []tree.Datums
+	outputTypes    []*types.T
+
+	seenRows   bool
+	done       bool
+	datumAlloc tree.DatumAlloc
+}
+
+var _ colexecop.Operator = &groupingSetsEmptyInputOp{}
+
+func (o *groupingSetsEmptyInputOp) Next() coldata.Batch {
+	if o.done {
+		return coldata.ZeroBatch
+	}
+	batch := o.Input.Next()
+	if batch.Length() > 0 {
+		o.seenRows = true
+		return batch
+	}
+	o.done = true
+	if o.seenRows {
+		return coldata.ZeroBatch
+	}
+	// The input was empty, so we produce a row for each of the empty grouping
+	// sets where each aggregation has the result it would have over no rows.
+	// The last output column is the ordinal of the grouping set.
+	row := make(rowenc.EncDatumRow, len(o.outputTypes))
+	for i := 0; i < len(o.outputTypes)-1; i++ {
+		agg := o.constructors[i](o.evalCtx, o.constArguments[i])
+		result, err := agg.Result()
+		agg.Close(o.Ctx)
+		if err != nil {
+			colexecerror.ExpectedError(err)
+		}
+		if result == nil {
+			result = tree.DNull
+		}
+		row[i] = rowenc.DatumToEncDatum(o.outputTypes[i], result)
+	}
+	output, _ := o.allocator.ResetMaybeReallocateNoMemLimit(
+		o.outputTypes, nil /* oldBatch */, len(o.emptySets),
+	)
+	var vecs coldata.TypedVecs
+	vecs.SetBatch(output)
+	o.allocator.PerformOperation(output.ColVecs(), func() {
+		for rowIdx, setIdx := range o.emptySets {
+			row[len(row)-1] = rowenc.DatumToEncDatum(types.Int, tree.NewDInt(tree.DInt(setIdx)))
+			EncDatumRowToColVecs(row, rowIdx, vecs, o.outputTypes, &o.datumAlloc)
+		}
+	})
+	output.SetLength(len(o.emptySets))
+	return output
+}
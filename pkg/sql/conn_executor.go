@@ -21,15 +21,18 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/jobs/jobspb"
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/multitenant"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/multitenantcpu"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/security/username"
 	"github.com/cockroachdb/cockroach/pkg/server/serverpb"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/appstatspb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catsessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
@@ -45,8 +48,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/idxusage"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirecancel"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scrun"
@@ -1206,8 +1211,18 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 		}
 	}
 
+	if ex.notificationListener != nil {
+		ex.notificationListener.UnlistenAll()
+	}
+
+	// Close all cursors, including the persisted cursors WITH HOLD, on every
+	// shutdown path so that their disk-backed row containers are released.
+	if err := ex.extraTxnState.sqlCursors.closeAll(ctx, true /* includePersisted */); err != nil {
+		log.Warningf(ctx, "error closing cursors: %v", err)
+	}
+
 	if closeType != panicClose {
-		// Close all statements, prepared portals, and cursors.
+		// Close all statements and prepared portals.
 		ex.extraTxnState.prepStmtsNamespace.resetToEmpty(
 			ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc,
 		)
@@ -1215,9 +1230,6 @@ func (ex *connExecutor) close(ctx context.Context, closeType closeType) {
 			ctx, &ex.extraTxnState.prepStmtsNamespaceMemAcc,
 		)
 		ex.extraTxnState.prepStmtsNamespaceMemAcc.Close(ctx)
-		if err := ex.extraTxnState.sqlCursors.closeAll(false /* errorOnWithHold */); err != nil {
-			log.Warningf(ctx, "error closing cursors: %v", err)
-		}
 	}
 
 	if ex.sessionTracing.Enabled() {
@@ -1405,8 +1417,10 @@ type connExecutor struct {
 
 		// sqlCursors contains the list of SQL CURSORs the session currently has
 		// access to.
-		// Cursors are bound to an explicit transaction and they're all destroyed
-		// once the transaction finishes.
+		// Cursors are bound to the transaction which declared them and they're
+		// destroyed once it finishes, except for cursors WITH HOLD, which are
+		// persisted when the transaction commits and remain until they are
+		// closed or the session ends.
 		sqlCursors cursorMap
 
 		// shouldExecuteOnTxnFinish indicates that ex.onTxnFinish will be called
@@ -1497,6 +1511,10 @@ type connExecutor struct {
 		// createdSequences keeps track of sequences created in the current transaction.
 		// The map key is the sequence descpb.ID.
 		createdSequences map[descpb.ID]struct{}
+
+		// deferredConstraints tracks the modes set by SET CONSTRAINTS and the
+		// pending violations of deferred constraints in the current transaction.
+		deferredConstraints deferredConstraintsState
 	}
 
 	// sessionDataStack contains the user-configurable connection variables.
@@ -1581,6 +1599,11 @@ type connExecutor struct {
 	// pgwire cancellation protocol.
 	queryCancelKey pgwirecancel.BackendKeyData
 
+	// notificationListener tracks the channels the session listens on and
+	// queues the notifications published on them. It is created by the first
+	// LISTEN statement of the session.
+	notificationListener *pgnotify.Listener
+
 	sessionID clusterunique.ID
 
 	// activated determines whether activate() was called already.
@@ -1927,11 +1950,12 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) {
 	)
 
 	// Close all cursors.
-	if err := ex.extraTxnState.sqlCursors.closeAll(false /* errorOnWithHold */); err != nil {
+	if err := ex.extraTxnState.sqlCursors.closeAll(ctx, false /* includePersisted */); err != nil {
 		log.Warningf(ctx, "error closing cursors: %v", err)
 	}
 
 	ex.extraTxnState.createdSequences = make(map[descpb.ID]struct{})
+	ex.extraTxnState.deferredConstraints.reset()
 
 	switch ev.eventType {
 	case txnCommit, txnRollback:
@@ -2360,6 +2384,15 @@ func (ex *connExecutor) execCmd() (retErr error) {
 		//   was created when the statement started executing (via the
 		//   reset() method).
 		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, timeutil.Now())
+	case StartReplication:
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionQueryReceived, tcmd.TimeReceived)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionStartParse, tcmd.ParseStart)
+		ex.phaseTimes.SetSessionPhaseTime(sessionphase.SessionEndParse, tcmd.ParseEnd)
+		replRes := ex.clientComm.CreateStartReplicationResult(tcmd, pos)
+		res = replRes
+		stmtCtx := withStatement(ctx, tcmd.Stmt)
+		ev, payload = ex.execStartReplication(stmtCtx, tcmd, replRes)
+		ex.statsCollector.PhaseTimes().SetSessionPhaseTime(sessionphase.SessionQueryServiced, timeutil.Now())
 	case DrainRequest:
 		// We received a drain request. We terminate immediately if we're not in a
 		// transaction. If we are in a transaction, we'll finish as soon as a Sync
@@ -2373,6 +2406,10 @@ func (ex *connExecutor) execCmd() (retErr error) {
 	case Flush:
 		// Closing the res will flush the connection's buffer.
 		res = ex.clientComm.CreateFlushResult(pos)
+	case DeliverNotifications:
+		// The notifications are buffered below, right before the result is
+		// closed, if the session is not in a transaction.
+		res = ex.clientComm.CreateDeliverNotificationsResult(pos)
 	default:
 		panic(errors.AssertionFailedf("unsupported command type: %T", cmd))
 	}
@@ -2459,6 +2496,18 @@ func (ex *connExecutor) execCmd() (retErr error) {
 				}
 			}
 		}
+		// Notifications are sent to the client outside of transactions, either
+		// when they are received by an idle session or at the end of the next
+		// batch of commands that doesn't leave a transaction open.
+		switch cmd.(type) {
+		case Sync, DeliverNotifications:
+			if ex.notificationListener != nil && ex.idleConn() {
+				nb := res.(NotificationBuffer)
+				for _, n := range ex.notificationListener.TakePending() {
+					nb.BufferNotification(n)
+				}
+			}
+		}
 		res.Close(ctx, stateToTxnStatusIndicator(ex.machine.CurState()))
 	} else {
 		res.Discard()
@@ -2613,10 +2662,14 @@ func (ex *connExecutor) updateTxnRewindPosMaybe(
 				// Can't advance.
 			case CopyOut:
 				// Can't advance.
+			case StartReplication:
+				// Can't advance.
 			case DrainRequest:
 				canAdvance = true
 			case Flush:
 				canAdvance = true
+			case DeliverNotifications:
+				canAdvance = true
 			default:
 				panic(errors.AssertionFailedf("unsupported cmd: %T", cmd))
 			}
@@ -3294,9 +3347,17 @@ func (ex *connExecutor) setTransactionModes(
 			return err
 		}
 	}
-	if modes.Isolation != tree.UnspecifiedIsolation && modes.Isolation != tree.SerializableIsolation {
-		return errors.AssertionFailedf(
-			"unknown isolation level: %s", errors.Safe(modes.Isolation))
+	if modes.Isolation != tree.UnspecifiedIsolation {
+		level, upgraded, err := ex.txnIsolationLevelToKV(ctx, modes.Isolation)
+		if err != nil {
+			return err
+		}
+		if err := ex.state.setIsolationLevel(level); err != nil {
+			return err
+		}
+		if upgraded {
+			ex.planner.BufferClientNotice(ctx, isolationLevelUpgradedNotice(modes.Isolation, level))
+		}
 	}
 	rwMode := modes.ReadWriteMode
 	if modes.AsOf.Expr != nil && asOfTs.IsEmpty() {
@@ -3349,6 +3410,57 @@ func (ex *connExecutor) QualityOfService() sessiondatapb.QoSLevel {
 	return ex.sessionData().DefaultTxnQualityOfService
 }
 
+// txnIsolationLevelToKV maps a SQL isolation level to the isolation level of
+// the KV transaction that executes it, using the session's default isolation
+// level if the level is unspecified. See isolationLevelToKV.
+func (ex *connExecutor) txnIsolationLevelToKV(
+	ctx context.Context, level tree.IsolationLevel,
+) (isolation.Level, bool, error) {
+	if level == tree.UnspecifiedIsolation {
+		level = tree.IsolationLevel(ex.sessionData().DefaultTxnIsolationLevel)
+	}
+	return isolationLevelToKV(ctx, ex.server.cfg.Settings, level)
+}
+
+// isolationLevelToKV maps a SQL isolation level to the isolation level of the
+// KV transaction that executes it. Isolation levels that are not supported, or
+// that are not enabled in the cluster, are upgraded to a stronger level; the
+// returned bool is true if this happened.
+func isolationLevelToKV(
+	ctx context.Context, st *cluster.Settings, level tree.IsolationLevel,
+) (isolation.Level, bool, error) {
+	switch level {
+	case tree.UnspecifiedIsolation, tree.SerializableIsolation:
+		return isolation.Serializable, false, nil
+	case tree.ReadUncommittedIsolation, tree.ReadCommittedIsolation:
+		if allowReadCommittedIsolation.Get(&st.SV) &&
+			st.Version.IsActive(ctx, clusterversion.V23_2_ReadCommittedIsolation) {
+			// READ UNCOMMITTED is treated as READ COMMITTED, as in Postgres.
+			return isolation.ReadCommitted, false, nil
+		}
+		return isolation.Serializable, true, nil
+	case tree.RepeatableReadIsolation, tree.SnapshotIsolation:
+		return isolation.Serializable, true, nil
+	default:
+		return 0, false, errors.AssertionFailedf("unknown isolation level: %s", level)
+	}
+}
+
+// isolationLevelUpgradedNotice returns the notice sent to the client when the
+// requested isolation level is upgraded to a stronger one.
+func isolationLevelUpgradedNotice(
+	requested tree.IsolationLevel, upgradedTo isolation.Level,
+) pgnotice.Notice {
+	notice := pgnotice.Newf("%s isolation level is not allowed; upgrading to %s",
+		requested, tree.FromKVIsoLevel(upgradedTo))
+	if requested == tree.ReadUncommittedIsolation || requested == tree.ReadCommittedIsolation {
+		notice = pgnotice.Notice(errors.WithHintf(notice,
+			"READ COMMITTED transactions can be enabled with the %s cluster setting",
+			allowReadCommittedIsolation.Key()))
+	}
+	return notice
+}
+
 func (ex *connExecutor) readWriteModeWithSessionDefault(
 	mode tree.ReadWriteMode,
 ) tree.ReadWriteMode {
@@ -3496,6 +3608,14 @@ func (ex *connExecutor) initPlanner(ctx context.Context, p *planner) {
 	p.preparedStatements = ex.getPrepStmtsAccessor()
 	p.sqlCursors = ex.getCursorAccessor()
 	p.createdSequences = ex.getCreatedSequencesAccessor()
+	p.listenChannels = connExListenChannelsAccessor{ex: ex}
+	if ex.executorType == executorTypeExec {
+		p.deferredConstraints = connExDeferredConstraintsAccessor{ex: ex}
+	} else {
+		// Internal executors don't necessarily commit the transactions they run
+		// in, so all constraints are checked immediately.
+		p.deferredConstraints = emptyDeferredConstraints{}
+	}
 
 	p.queryCacheSession.Init()
 	p.optPlanningCtx.init(p)
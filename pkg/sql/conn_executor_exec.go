@@ -21,6 +21,8 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/jobs"
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/multitenant"
 	"github.com/cockroachdb/cockroach/pkg/multitenant/multitenantcpu"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
@@ -886,7 +888,13 @@ func (ex *connExecutor) execStmtInOpenState(
 	// placed. There are also sequencing point after every stage of
 	// constraint checks and cascading actions at the _end_ of a
 	// statement's execution.
-	if err := ex.state.mu.txn.Step(ctx); err != nil {
+	//
+	// This is also where transactions that run under an isolation level that
+	// uses a new read snapshot for each statement (READ COMMITTED) advance
+	// their read timestamp. A pausable portal that is being resumed continues
+	// to read from the snapshot that it was originally executed with.
+	stepReadTimestamp := !isPausablePortal() || !portal.pauseInfo.execStmtInOpenState.cleanup.isComplete
+	if err := ex.state.mu.txn.Step(ctx, stepReadTimestamp); err != nil {
 		return makeErrEvent(err)
 	}
 
@@ -961,7 +969,22 @@ func (ex *connExecutor) execStmtInOpenState(
 		}()
 	}
 
-	if err = ex.dispatchToExecutionEngine(stmtCtx, p, res); err != nil {
+	// Statements in READ COMMITTED transactions are retried individually on
+	// retryable errors, with two exceptions that fall back to the same handling
+	// as in SERIALIZABLE transactions:
+	// - in an implicit transaction the statement is the whole transaction, so
+	//   the automatic retry of the transaction from the beginning already covers
+	//   it, and also gives the statement a new read snapshot.
+	// - a pausable portal returns control to the client in the middle of its
+	//   execution after having sent some of its rows, so the statement can never
+	//   be rolled back and executed again; retryable errors are returned to the
+	//   client instead.
+	if ex.state.mu.txn.IsoLevel() == isolation.ReadCommitted && !ex.implicitTxn() && !isPausablePortal() {
+		err = ex.dispatchReadCommittedStmtToExecutionEngine(stmtCtx, p, res)
+	} else {
+		err = ex.dispatchToExecutionEngine(stmtCtx, p, res)
+	}
+	if err != nil {
 		stmtThresholdSpan.Finish()
 		return nil, nil, err
 	}
@@ -1289,11 +1312,30 @@ func (ex *connExecutor) reportSessionDataChanges(fn func() error) error {
 	return nil
 }
 
-func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error {
+func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) (retErr error) {
 	ctx, sp := tracing.EnsureChildSpan(ctx, ex.server.cfg.AmbientCtx.Tracer, "commit sql txn")
 	defer sp.Finish()
 
-	if err := ex.extraTxnState.sqlCursors.closeAll(true /* errorOnWithHold */); err != nil {
+	// Cursors WITH HOLD outlive the transaction, so their remaining rows are
+	// materialized before it commits, and accounted for against the session.
+	// They are closed if the transaction fails to commit.
+	heldCursors, err := ex.extraTxnState.sqlCursors.persistHeldCursors(
+		ctx, ex.planner.ExtendedEvalContext(), ex.sessionMon,
+	)
+	defer func() {
+		if retErr == nil {
+			return
+		}
+		for _, n := range heldCursors {
+			if err := ex.extraTxnState.sqlCursors.closeCursor(ctx, n); err != nil {
+				log.Warningf(ctx, "error closing cursor %s: %v", n, err)
+			}
+		}
+	}()
+	if err != nil {
+		return err
+	}
+	if err := ex.extraTxnState.sqlCursors.closeAll(ctx, false /* includePersisted */); err != nil {
 		return err
 	}
 
@@ -1304,13 +1346,19 @@ func (ex *connExecutor) commitSQLTransactionInternal(ctx context.Context) error
 	// stepping mode back to what it was.
 	prevSteppingMode := ex.state.mu.txn.ConfigureStepping(ctx, kv.SteppingEnabled)
 	if prevSteppingMode == kv.SteppingEnabled {
-		if err := ex.state.mu.txn.Step(ctx); err != nil {
+		if err := ex.state.mu.txn.Step(ctx, false /* allowReadTimestampStep */); err != nil {
 			return err
 		}
 	} else {
 		ex.state.mu.txn.ConfigureStepping(ctx, prevSteppingMode)
 	}
 
+	if err := ex.extraTxnState.deferredConstraints.validate(
+		ctx, ex.planner.InternalSQLTxn(), nil, /* names */
+	); err != nil {
+		return err
+	}
+
 	if err := ex.createJobs(ctx); err != nil {
 		return err
 	}
@@ -1384,7 +1432,7 @@ func (ex *connExecutor) createJobs(ctx context.Context) error {
 func (ex *connExecutor) rollbackSQLTransaction(
 	ctx context.Context, stmt tree.Statement,
 ) (fsm.Event, fsm.EventPayload) {
-	if err := ex.extraTxnState.sqlCursors.closeAll(false /* errorOnWithHold */); err != nil {
+	if err := ex.extraTxnState.sqlCursors.closeAll(ctx, false /* includePersisted */); err != nil {
 		return ex.makeErrEvent(err, stmt)
 	}
 
@@ -1403,6 +1451,67 @@ func (ex *connExecutor) rollbackSQLTransaction(
 	return eventTxnFinishAborted{}, nil
 }
 
+// dispatchReadCommittedStmtToExecutionEngine executes the statement in a READ
+// COMMITTED transaction using dispatchToExecutionEngine. If the statement
+// encounters a retryable error that allows for only the statement to be
+// retried, the transaction is rolled back to a savepoint taken before the
+// statement and the statement is executed again, up to
+// max_retries_for_read_committed times.
+func (ex *connExecutor) dispatchReadCommittedStmtToExecutionEngine(
+	ctx context.Context, p *planner, res RestrictedCommandResult,
+) error {
+	savepoint, err := ex.state.mu.txn.CreateSavepoint(ctx)
+	if err != nil {
+		return err
+	}
+	maxRetries := int(ex.sessionData().MaxRetriesForReadCommitted)
+	var maybeRetryableErr error
+	for attemptNum := 0; attemptNum <= maxRetries; attemptNum++ {
+		if attemptNum > 0 {
+			if err := ex.state.mu.txn.RollbackToSavepoint(ctx, savepoint); err != nil {
+				return err
+			}
+			if err := ex.state.mu.txn.PrepareForPartialRetry(ctx); err != nil {
+				return err
+			}
+			log.VEventf(ctx, 2, "retrying statement in read committed transaction after error: %v",
+				maybeRetryableErr)
+		}
+		bufferPos := res.BufferedResultsLen()
+		if err := ex.dispatchToExecutionEngine(ctx, p, res); err != nil {
+			return err
+		}
+		maybeRetryableErr = res.Err()
+		if maybeRetryableErr == nil {
+			return ex.state.mu.txn.ReleaseSavepoint(ctx, savepoint)
+		}
+		// If the error does not allow for the statement to be retried on its
+		// own, then stop. The error is already set on the result, and causes
+		// the transaction to be restarted from the beginning.
+		var retryErr *kvpb.TransactionRetryWithProtoRefreshError
+		if !errors.As(maybeRetryableErr, &retryErr) || retryErr.TxnMustRestartFromBeginning() {
+			return nil
+		}
+		// In order to retry the statement, any results that it buffered need to
+		// be discarded. This is not possible if they were already sent to the
+		// client.
+		if !res.TruncateBufferedResults(bufferPos) {
+			res.SetError(errors.Wrap(
+				maybeRetryableErr,
+				"cannot automatically retry since some results were already sent to the client",
+			))
+			return nil
+		}
+		res.SetError(nil)
+	}
+	res.SetError(errors.Wrapf(
+		maybeRetryableErr,
+		"read committed retry limit exceeded; set by max_retries_for_read_committed=%d",
+		maxRetries,
+	))
+	return nil
+}
+
 // dispatchToExecutionEngine executes the statement, writes the result to res
 // and returns an event for the connection's state machine.
 //
@@ -2172,10 +2281,18 @@ func (ex *connExecutor) execStmtInNoTxnState(
 		if err != nil {
 			return ex.makeErrEvent(err, s)
 		}
+		isoLevel, upgraded, err := ex.txnIsolationLevelToKV(ctx, s.Modes.Isolation)
+		if err != nil {
+			return ex.makeErrEvent(err, s)
+		}
 		ex.sessionDataStack.PushTopClone()
+		if upgraded && s.Modes.Isolation != tree.UnspecifiedIsolation {
+			res.BufferNotice(isolationLevelUpgradedNotice(s.Modes.Isolation, isoLevel))
+		}
 		return eventStartExplicitTxn,
 			makeEventTxnStartPayload(
 				ex.txnPriorityWithSessionDefault(s.Modes.UserPriority),
+				isoLevel,
 				mode,
 				sqlTs,
 				historicalTs,
@@ -2196,9 +2313,14 @@ func (ex *connExecutor) execStmtInNoTxnState(
 		if err != nil {
 			return ex.makeErrEvent(err, s)
 		}
+		isoLevel, _, err := ex.txnIsolationLevelToKV(ctx, tree.UnspecifiedIsolation)
+		if err != nil {
+			return ex.makeErrEvent(err, s)
+		}
 		return eventStartImplicitTxn,
 			makeEventTxnStartPayload(
 				ex.txnPriorityWithSessionDefault(tree.UnspecifiedUserPriority),
+				isoLevel,
 				mode,
 				sqlTs,
 				historicalTs,
@@ -2226,9 +2348,14 @@ func (ex *connExecutor) beginImplicitTxn(
 	if err != nil {
 		return ex.makeErrEvent(err, ast)
 	}
+	isoLevel, _, err := ex.txnIsolationLevelToKV(ctx, tree.UnspecifiedIsolation)
+	if err != nil {
+		return ex.makeErrEvent(err, ast)
+	}
 	return eventStartImplicitTxn,
 		makeEventTxnStartPayload(
 			ex.txnPriorityWithSessionDefault(tree.UnspecifiedUserPriority),
+			isoLevel,
 			mode,
 			sqlTs,
 			historicalTs,
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/col/coldata"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -386,6 +387,40 @@ func (c CopyOut) String() string {
 
 var _ Command = CopyOut{}
 
+// StartReplication is the command for the execution of a START_REPLICATION
+// command of the streaming replication protocol. Its execution streams changes
+// to the client until either side ends the stream.
+type StartReplication struct {
+	ParsedStmt statements.Statement[tree.Statement]
+	Stmt       *tree.StartReplication
+	// ClientMessages receives the payload of the CopyData messages sent by the
+	// client during streaming. It is closed when the client ends the stream.
+	ClientMessages <-chan []byte
+	// StreamDone is closed once execution finishes, signaling that the network
+	// routine must stop delivering messages to ClientMessages.
+	StreamDone chan<- struct{}
+	// TimeReceived is the time at which the message was received
+	// from the client. Used to compute the service latency.
+	TimeReceived time.Time
+	// ParseStart/ParseEnd are the timing info for parsing of the query. Used for
+	// stats reporting.
+	ParseStart time.Time
+	ParseEnd   time.Time
+}
+
+// command implements the Command interface.
+func (StartReplication) command() string { return "start replication" }
+
+func (c StartReplication) String() string {
+	s := "(empty)"
+	if c.Stmt != nil {
+		s = c.Stmt.String()
+	}
+	return fmt.Sprintf("StartReplication: %s", s)
+}
+
+var _ Command = StartReplication{}
+
 // DrainRequest represents a notice that the server is draining and command
 // processing should stop soon.
 //
@@ -401,6 +436,21 @@ func (DrainRequest) String() string {
 
 var _ Command = DrainRequest{}
 
+// DeliverNotifications represents a notice that notifications were received on
+// a channel that the session listens on. The notifications are sent to the
+// client if the session is not in a transaction; otherwise, they are sent when
+// the next Sync command is processed outside of a transaction.
+type DeliverNotifications struct{}
+
+// command implements the Command interface.
+func (DeliverNotifications) command() string { return "deliver notifications" }
+
+func (DeliverNotifications) String() string {
+	return "DeliverNotifications"
+}
+
+var _ Command = DeliverNotifications{}
+
 // SendError is a command that, upon execution, send a specific error to the
 // client. This is used by pgwire to schedule errors to be sent at an
 // appropriate time.
@@ -680,8 +730,14 @@ type ClientComm interface {
 	CreateCopyInResult(cmd CopyIn, pos CmdPos) CopyInResult
 	// CreateCopyOutResult creates a result for a Copy-out command.
 	CreateCopyOutResult(cmd CopyOut, pos CmdPos) CopyOutResult
+	// CreateStartReplicationResult creates a result for a StartReplication
+	// command.
+	CreateStartReplicationResult(cmd StartReplication, pos CmdPos) StartReplicationResult
 	// CreateDrainResult creates a result for a Drain command.
 	CreateDrainResult(pos CmdPos) DrainResult
+	// CreateDeliverNotificationsResult creates a result for a
+	// DeliverNotifications command.
+	CreateDeliverNotificationsResult(pos CmdPos) DeliverNotificationsResult
 
 	// LockCommunication ensures that no further results are delivered to the
 	// client. The returned ClientLock can be queried to see what results have
@@ -819,6 +875,17 @@ type RestrictedCommandResult interface {
 	// we find the underlying query is not supported for a pausable portal.
 	// This method is implemented only by pgwire.limitedCommandResult.
 	RevokePortalPausability() error
+
+	// BufferedResultsLen returns the length of the results that have been
+	// buffered so far but not yet sent to the client. It can be passed to
+	// TruncateBufferedResults to discard results buffered after this point.
+	BufferedResultsLen() int
+
+	// TruncateBufferedResults discards all results that were buffered after the
+	// position idx, which was previously returned by BufferedResultsLen. It
+	// returns false if these results can no longer be discarded, for instance
+	// because they have already been sent to the client.
+	TruncateBufferedResults(idx int) bool
 }
 
 // DescribeResult represents the result of a Describe command (for either
@@ -864,6 +931,7 @@ type DeleteResult interface {
 // flushed.
 type SyncResult interface {
 	ResultBase
+	NotificationBuffer
 }
 
 // FlushResult represents the result of a Flush command. When this result is
@@ -878,6 +946,22 @@ type DrainResult interface {
 	ResultBase
 }
 
+// DeliverNotificationsResult represents the result of a DeliverNotifications
+// command. Closing this result sends the buffered notifications to the client
+// and flushes all previously accumulated results.
+type DeliverNotificationsResult interface {
+	ResultBase
+	NotificationBuffer
+}
+
+// NotificationBuffer is implemented by the results that can deliver the
+// notifications received on the channels that the session listens on.
+type NotificationBuffer interface {
+	// BufferNotification buffers a notification to be sent to the client when
+	// the result is closed.
+	BufferNotification(n pgnotify.Notification)
+}
+
 // EmptyQueryResult represents the result of an empty query (a query
 // representing a blank string).
 type EmptyQueryResult interface {
@@ -910,6 +994,24 @@ type CopyOutResult interface {
 	SendCopyDone(ctx context.Context) error
 }
 
+// StartReplicationResult represents the result of a StartReplication command.
+// Closing this result sends a CommandComplete message to the client.
+type StartReplicationResult interface {
+	ResultBase
+
+	// SendCopyBothResponse sends the response which starts the streaming of
+	// changes to the client.
+	SendCopyBothResponse(ctx context.Context) error
+
+	// SendReplicationData sends a message of the replication stream to the
+	// client, and flushes it.
+	SendReplicationData(ctx context.Context, data []byte) error
+
+	// SendCopyDone sends the copy done response to the client, which ends the
+	// replication stream.
+	SendCopyDone(ctx context.Context) error
+}
+
 // ClientLock is an interface returned by ClientComm.lockCommunication(). It
 // represents a lock on the delivery of results to a SQL client. While such a
 // lock is used, no more results are delivered. The lock itself can be used to
@@ -1023,6 +1125,11 @@ func (r *streamingCommandResult) BufferNotice(notice pgnotice.Notice) {
 	// Unimplemented: the internal executor does not support notices.
 }
 
+// BufferNotification is part of the NotificationBuffer interface.
+func (r *streamingCommandResult) BufferNotification(n pgnotify.Notification) {
+	// Unimplemented: the internal executor does not support notifications.
+}
+
 // ResetStmtType is part of the RestrictedCommandResult interface.
 func (r *streamingCommandResult) ResetStmtType(stmt tree.Statement) {
 	panic("unimplemented")
@@ -1093,6 +1200,22 @@ func (r *streamingCommandResult) RowsAffected() int {
 	return r.rowsAffected
 }
 
+// BufferedResultsLen is part of the RestrictedCommandResult interface.
+func (r *streamingCommandResult) BufferedResultsLen() int {
+	// Results are written directly into the ieResultWriter, so nothing is ever
+	// buffered here.
+	return 0
+}
+
+// TruncateBufferedResults is part of the RestrictedCommandResult interface.
+func (r *streamingCommandResult) TruncateBufferedResults(idx int) bool {
+	if r.cannotRewind {
+		return false
+	}
+	r.rowsAffected = 0
+	return true
+}
+
 // Close is part of the CommandResultClose interface.
 func (r *streamingCommandResult) Close(context.Context, TransactionStatusIndicator) {
 	if r.closeCallback != nil {
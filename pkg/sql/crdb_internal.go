@@ -159,6 +159,7 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalJobsTableID:                        crdbInternalJobsTable,
 		catconstants.CrdbInternalSystemJobsTableID:                  crdbInternalSystemJobsTable,
 		catconstants.CrdbInternalKVNodeStatusTableID:                crdbInternalKVNodeStatusTable,
+		catconstants.CrdbInternalKVHotKeysTableID:                   crdbInternalKVHotKeysTable,
 		catconstants.CrdbInternalKVStoreStatusTableID:               crdbInternalKVStoreStatusTable,
 		catconstants.CrdbInternalLeasesTableID:                      crdbInternalLeasesTable,
 		catconstants.CrdbInternalLocalContentionEventsTableID:       crdbInternalLocalContentionEventsTable,
@@ -3229,6 +3230,24 @@ CREATE TABLE crdb_internal.create_function_statements (
 			if err != nil {
 				return err
 			}
+			if fnDesc.GetAggregate() != nil {
+				aggNode, err := p.makeCreateAggregateStatement(ctx, fnDesc, fnIDToScName[fnDesc.GetID()])
+				if err != nil {
+					return err
+				}
+				if err := addRow(
+					tree.NewDInt(tree.DInt(fnIDToDBID[fnDesc.GetID()])), // database_id
+					tree.NewDString(fnIDToDBName[fnDesc.GetID()]),       // database_name
+					tree.NewDInt(tree.DInt(fnIDToScID[fnDesc.GetID()])), // schema_id
+					tree.NewDString(fnIDToScName[fnDesc.GetID()]),       // schema_name
+					tree.NewDInt(tree.DInt(fnDesc.GetID())),             // function_id
+					tree.NewDString(fnDesc.GetName()),                   // function_name
+					tree.NewDString(tree.AsString(aggNode)),             // create_statement
+				); err != nil {
+					return err
+				}
+				continue
+			}
 			treeNode, err := fnDesc.ToCreateExpr()
 			treeNode.FuncName.ObjectNamePrefix = tree.ObjectNamePrefix{
 				ExplicitSchema: true,
@@ -5289,6 +5308,57 @@ CREATE TABLE crdb_internal.kv_store_status (
 	},
 }
 
+// crdbInternalKVHotKeysTable exposes the hottest keys sampled by the
+// leaseholders of the hottest ranges on each store.
+var crdbInternalKVHotKeysTable = virtualSchemaTable{
+	comment: "hottest keys sampled by the hottest ranges on each store (cluster RPC; expensive!)",
+	schema: `
+CREATE TABLE crdb_internal.kv_hot_keys (
+  node_id           INT NOT NULL,
+  store_id          INT NOT NULL,
+  range_id          INT NOT NULL,
+  key               BYTES NOT NULL,
+  pretty_key        STRING NOT NULL,
+  reads_per_second  FLOAT NOT NULL,
+  writes_per_second FLOAT NOT NULL,
+  contention_events INT NOT NULL
+)
+	`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.kv_hot_keys"); err != nil {
+			return err
+		}
+		ss, err := p.ExecCfg().NodesStatusServer.OptionalNodesStatusServer(
+			errorutil.FeatureNotAvailableToNonSystemTenantsIssue)
+		if err != nil {
+			return err
+		}
+		response, err := ss.HotKeys(ctx, &serverpb.HotKeysRequest{})
+		if err != nil {
+			return err
+		}
+
+		for _, k := range response.HotKeys {
+			if err := addRow(
+				tree.NewDInt(tree.DInt(k.NodeID)),
+				tree.NewDInt(tree.DInt(k.StoreID)),
+				tree.NewDInt(tree.DInt(k.RangeID)),
+				tree.NewDBytes(tree.DBytes(k.Key)),
+				tree.NewDString(keys.PrettyPrint(nil /* valDirs */, k.Key)),
+				tree.NewDFloat(tree.DFloat(k.ReadsPerSecond)),
+				tree.NewDFloat(tree.DFloat(k.WritesPerSecond)),
+				tree.NewDInt(tree.DInt(k.ContentionEvents)),
+			); err != nil {
+				return err
+			}
+		}
+		for _, rpcErr := range response.Errors {
+			log.Warningf(ctx, "%v", rpcErr.Message)
+		}
+		return nil
+	},
+}
+
 var crdbInternalCatalogDescriptorTable = virtualSchemaTable{
 	comment: `like system.descriptor but overlaid with in-txn in-memory changes and including virtual objects`,
 	schema: `
@@ -0,0 +1,563 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
+)
+
+type createAggregateNode struct {
+	n *tree.CreateAggregate
+
+	dbDesc catalog.DatabaseDescriptor
+	scDesc catalog.SchemaDescriptor
+}
+
+// CreateAggregate creates a user-defined aggregate function.
+func (p *planner) CreateAggregate(
+	ctx context.Context, n *tree.CreateAggregate,
+) (planNode, error) {
+	if !p.execCfg.Settings.Version.IsActive(ctx, clusterversion.V23_2_UserDefinedAggregates) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to create aggregate functions",
+			clusterversion.ByKey(clusterversion.V23_2_UserDefinedAggregates))
+	}
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE AGGREGATE",
+	); err != nil {
+		return nil, err
+	}
+
+	if n.FuncName.ExplicitCatalog && string(n.FuncName.CatalogName) != p.CurrentDatabase() {
+		return nil, unimplemented.New("CREATE AGGREGATE", "cross-db references not supported")
+	}
+	un := n.FuncName.ToUnresolvedObjectName()
+	db, sc, prefix, err := p.ResolveTargetObject(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+	if db.GetID() == keys.SystemDatabaseID {
+		return nil, errors.New("cannot create an aggregate function in the system database")
+	}
+	if sc.SchemaKind() == catalog.SchemaTemporary {
+		return nil, unimplemented.New("CREATE AGGREGATE", "cannot create aggregate functions in temporary schemas")
+	}
+	if sc.SchemaKind() == catalog.SchemaVirtual {
+		return nil, pgerror.Newf(pgcode.InsufficientPrivilege, "schema cannot be modified: %q", sc.GetName())
+	}
+	n.FuncName.ObjectNamePrefix = prefix
+
+	return &createAggregateNode{
+		n:      n,
+		dbDesc: db,
+		scDesc: sc,
+	}, nil
+}
+
+func (n *createAggregateNode) ReadingOwnWrites() {}
+
+func (n *createAggregateNode) startExec(params runParams) error {
+	if err := params.p.canCreateOnSchema(
+		params.ctx, n.scDesc.GetID(), n.dbDesc.GetID(), params.p.User(), skipCheckPublicSchema,
+	); err != nil {
+		return err
+	}
+
+	if len(n.n.Params) == 0 {
+		return unimplemented.NewWithIssue(74775, "aggregate functions without arguments")
+	}
+	pbParams := make([]descpb.FunctionDescriptor_Parameter, len(n.n.Params))
+	paramTypes := make([]*types.T, len(n.n.Params))
+	for i, param := range n.n.Params {
+		if param.IsOutParam() {
+			return pgerror.Newf(pgcode.InvalidFunctionDefinition, "aggregate functions do not support OUT parameters")
+		}
+		pbParam, err := makeFunctionParam(params.ctx, param, params.p)
+		if err != nil {
+			return err
+		}
+		pbParams[i] = pbParam
+		paramTypes[i] = pbParam.Type
+	}
+
+	var transitionName, finalName, combineName *tree.UnresolvedObjectName
+	var stateType *types.T
+	var initCond *string
+	var initCondExpr tree.Expr
+	for _, opt := range n.n.Options {
+		var err error
+		switch strings.ToLower(string(opt.Name)) {
+		case "sfunc":
+			transitionName, err = aggregateOptionFuncName(opt)
+		case "finalfunc":
+			finalName, err = aggregateOptionFuncName(opt)
+		case "combinefunc":
+			combineName, err = aggregateOptionFuncName(opt)
+		case "stype":
+			if opt.TypeVal == nil {
+				return pgerror.Newf(pgcode.Syntax, "aggregate stype must be a type name")
+			}
+			stateType, err = tree.ResolveType(params.ctx, opt.TypeVal, params.p)
+		case "initcond":
+			if opt.ExprVal != nil {
+				// The value of the expression can only be computed once the state
+				// type is known.
+				initCondExpr, initCond = opt.ExprVal, nil
+				break
+			}
+			initCondExpr = nil
+			var s string
+			if opt.StrVal != nil {
+				s = opt.StrVal.RawString()
+			} else if name, ok := opt.TypeVal.(*tree.UnresolvedObjectName); ok {
+				s = tree.AsStringWithFlags(name, tree.FmtBareIdentifiers)
+			} else if opt.TypeVal != nil {
+				s = opt.TypeVal.SQLString()
+			} else {
+				return pgerror.Newf(pgcode.Syntax, "aggregate initcond must be a string constant")
+			}
+			initCond = &s
+		default:
+			return pgerror.Newf(pgcode.Syntax, "aggregate attribute %q not recognized", opt.Name)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if stateType == nil {
+		return pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate stype must be specified")
+	}
+	if transitionName == nil {
+		return pgerror.New(pgcode.InvalidFunctionDefinition, "aggregate sfunc must be specified")
+	}
+	if stateType.Family() == types.VoidFamily || stateType.Family() == types.TriggerFamily {
+		return pgerror.Newf(pgcode.InvalidFunctionDefinition,
+			"aggregate transition data type cannot be %s", stateType.SQLString())
+	}
+	if initCondExpr != nil {
+		s, err := evalAggregateInitCond(params, initCondExpr, stateType)
+		if err != nil {
+			return errors.Wrapf(err, "invalid initial value for aggregate")
+		}
+		initCond = &s
+	}
+
+	// Resolve the support functions. The transition function is called with the
+	// current state and the arguments of the aggregate, and must return the new
+	// state.
+	transition, err := n.resolveSupportFunc(
+		params, transitionName, append([]*types.T{stateType}, paramTypes...), stateType,
+	)
+	if err != nil {
+		return err
+	}
+	if initCond == nil && transition.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT &&
+		(len(paramTypes) != 1 || !paramTypes[0].Equivalent(stateType)) {
+		return pgerror.New(pgcode.InvalidFunctionDefinition,
+			"must not omit initial value when transition function is strict and transition type is not compatible with input type")
+	}
+	returnType := stateType
+	supportFuncs := []*funcdesc.Mutable{transition}
+	agg := &descpb.FunctionDescriptor_Aggregate{
+		TransitionFunctionID: transition.GetID(),
+		StateType:            stateType,
+		InitCond:             initCond,
+	}
+	if finalName != nil {
+		final, err := n.resolveSupportFunc(params, finalName, []*types.T{stateType}, nil /* returnType */)
+		if err != nil {
+			return err
+		}
+		returnType = final.ReturnType.Type
+		agg.FinalFunctionID = final.GetID()
+		supportFuncs = append(supportFuncs, final)
+	}
+	if combineName != nil {
+		combine, err := n.resolveSupportFunc(params, combineName, []*types.T{stateType, stateType}, stateType)
+		if err != nil {
+			return err
+		}
+		agg.CombineFunctionID = combine.GetID()
+		supportFuncs = append(supportFuncs, combine)
+	}
+	if initCond != nil {
+		if _, _, err := tree.ParseAndRequireString(stateType, *initCond, params.EvalContext()); err != nil {
+			return errors.Wrapf(err, "invalid initial value for aggregate")
+		}
+	}
+
+	mutScDesc, err := params.p.descCollection.MutableByName(params.p.Txn()).Schema(params.ctx, n.dbDesc, n.scDesc.GetName())
+	if err != nil {
+		return err
+	}
+
+	fuObj := tree.FuncObj{
+		FuncName: n.n.FuncName,
+		Params:   n.n.Params,
+	}
+	existing, err := params.p.matchUDF(params.ctx, &fuObj, false /* required */)
+	if err != nil {
+		return err
+	}
+
+	var aggDesc *funcdesc.Mutable
+	isNew := existing == nil
+	if isNew {
+		aggDesc, err = n.newAggregateDesc(params, mutScDesc, pbParams, returnType)
+		if err != nil {
+			return err
+		}
+	} else {
+		if !n.n.Replace {
+			return pgerror.Newf(
+				pgcode.DuplicateFunction,
+				"function %q already exists with same argument types",
+				n.n.FuncName.Object(),
+			)
+		}
+		aggDesc, err = params.p.checkPrivilegesForDropFunction(
+			params.ctx, funcdesc.UserDefinedFunctionOIDToID(existing.Oid),
+		)
+		if err != nil {
+			return err
+		}
+		if aggDesc.Aggregate == nil {
+			kind := "function"
+			if aggDesc.IsProcedure {
+				kind = "procedure"
+			}
+			return errors.WithDetailf(
+				pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+				"%q is a %s.", aggDesc.GetName(), kind,
+			)
+		}
+		if !returnType.Equivalent(aggDesc.ReturnType.Type) {
+			return pgerror.Newf(pgcode.InvalidFunctionDefinition, "cannot change return type of existing function")
+		}
+		aggDesc.Params = pbParams
+		// Remove the references to the old support functions and types before
+		// adding the new ones.
+		if err := params.p.removeAggregateSupportFuncReferences(params.ctx, aggDesc); err != nil {
+			return err
+		}
+		jobDesc := fmt.Sprintf("updating type back reference %d for function %d", aggDesc.DependsOnTypes, aggDesc.ID)
+		if err := params.p.removeTypeBackReferences(params.ctx, aggDesc.DependsOnTypes, aggDesc.ID, jobDesc); err != nil {
+			return err
+		}
+	}
+
+	// The aggregate is as volatile as the most volatile of its support
+	// functions.
+	volatility := catpb.Function_IMMUTABLE
+	for _, fn := range supportFuncs {
+		switch fn.GetVolatility() {
+		case catpb.Function_VOLATILE:
+			volatility = catpb.Function_VOLATILE
+		case catpb.Function_STABLE:
+			if volatility == catpb.Function_IMMUTABLE {
+				volatility = catpb.Function_STABLE
+			}
+		}
+	}
+	aggDesc.SetVolatility(volatility)
+	aggDesc.SetAggregate(agg)
+
+	// Add back-references from the support functions to the aggregate, so that
+	// they cannot be dropped while the aggregate exists.
+	for _, fn := range supportFuncs {
+		if err := fn.AddFunctionReference(aggDesc.GetID()); err != nil {
+			return err
+		}
+		if err := params.p.writeFuncSchemaChange(params.ctx, fn); err != nil {
+			return err
+		}
+	}
+
+	typeDepIDs := catalog.DescriptorIDSet{}
+	for _, t := range append(paramTypes, stateType) {
+		addUserDefinedTypeIDs(&typeDepIDs, t)
+	}
+	for _, id := range typeDepIDs.Ordered() {
+		jobDesc := fmt.Sprintf("updating type back reference %d for function %d", id, aggDesc.ID)
+		if err := params.p.addTypeBackReference(params.ctx, id, aggDesc.ID, jobDesc); err != nil {
+			return err
+		}
+	}
+	aggDesc.DependsOnTypes = typeDepIDs.Ordered()
+
+	if isNew {
+		if err := params.p.createDescriptor(
+			params.ctx,
+			aggDesc,
+			tree.AsStringWithFQNames(&n.n.FuncName, params.Ann()),
+		); err != nil {
+			return err
+		}
+		mutScDesc.AddFunction(
+			aggDesc.GetName(),
+			descpb.SchemaDescriptor_FunctionSignature{
+				ID:          aggDesc.GetID(),
+				ArgTypes:    paramTypes,
+				ReturnType:  returnType,
+				IsAggregate: true,
+			},
+		)
+		if err := params.p.writeSchemaDescChange(params.ctx, mutScDesc, "Create Aggregate"); err != nil {
+			return err
+		}
+	} else {
+		if err := params.p.writeFuncSchemaChange(params.ctx, aggDesc); err != nil {
+			return err
+		}
+	}
+
+	fnName := tree.MakeQualifiedFunctionName(n.dbDesc.GetName(), n.scDesc.GetName(), n.n.FuncName.String())
+	return params.p.logEvent(params.ctx, aggDesc.GetID(), &eventpb.CreateFunction{
+		FunctionName: fnName.FQString(),
+		IsReplace:    !isNew,
+	})
+}
+
+func (*createAggregateNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createAggregateNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createAggregateNode) Close(ctx context.Context)           {}
+
+// newAggregateDesc creates the descriptor of a new user-defined aggregate.
+func (n *createAggregateNode) newAggregateDesc(
+	params runParams,
+	scDesc catalog.SchemaDescriptor,
+	pbParams []descpb.FunctionDescriptor_Parameter,
+	returnType *types.T,
+) (*funcdesc.Mutable, error) {
+	id, err := params.EvalContext().DescIDGenerator.GenerateUniqueDescID(params.ctx)
+	if err != nil {
+		return nil, err
+	}
+	privileges, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		n.dbDesc.GetDefaultPrivilegeDescriptor(),
+		scDesc.GetDefaultPrivilegeDescriptor(),
+		n.dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Functions,
+	)
+	if err != nil {
+		return nil, err
+	}
+	desc := funcdesc.NewMutableFunctionDescriptor(
+		id,
+		n.dbDesc.GetID(),
+		scDesc.GetID(),
+		string(n.n.FuncName.ObjectName),
+		pbParams,
+		returnType,
+		false, /* returnSet */
+		privileges,
+	)
+	return &desc, nil
+}
+
+// resolveSupportFunc resolves a support function of the aggregate with the
+// given parameter types. If returnType is non-nil, the function must return
+// that type.
+func (n *createAggregateNode) resolveSupportFunc(
+	params runParams, name *tree.UnresolvedObjectName, paramTypes []*types.T, returnType *types.T,
+) (*funcdesc.Mutable, error) {
+	fnName := name.ToFunctionName()
+	typeNames := make([]string, len(paramTypes))
+	for i, t := range paramTypes {
+		typeNames[i] = t.SQLString()
+	}
+	sig := fmt.Sprintf("%s(%s)", fnName.String(), strings.Join(typeNames, ", "))
+
+	path := params.p.CurrentSearchPath()
+	def, err := params.p.ResolveFunction(params.ctx, name.ToUnresolvedName(), &path)
+	if err != nil {
+		if errors.Is(err, tree.ErrFunctionUndefined) {
+			return nil, pgerror.Newf(pgcode.UndefinedFunction, "function %s does not exist", sig)
+		}
+		return nil, err
+	}
+	ol, err := def.MatchOverload(paramTypes, fnName.Schema(), &path)
+	if err != nil {
+		if errors.Is(err, tree.ErrFunctionUndefined) {
+			return nil, pgerror.Newf(pgcode.UndefinedFunction, "function %s does not exist", sig)
+		}
+		return nil, err
+	}
+	if !ol.IsUDF {
+		return nil, unimplemented.NewWithIssuef(74775,
+			"built-in function %s cannot be used as an aggregate support function", sig)
+	}
+	desc, err := params.p.Descriptors().MutableByID(params.p.Txn()).Function(
+		params.ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if desc.GetParentID() != n.dbDesc.GetID() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"the aggregate cannot refer to functions in other databases")
+	}
+	if desc.IsProcedure || desc.Aggregate != nil || desc.ReturnType.ReturnSet {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"%s must be a non-aggregate, non-set-returning function", sig)
+	}
+	if returnType != nil && !desc.ReturnType.Type.Equivalent(returnType) {
+		return nil, pgerror.Newf(pgcode.DatatypeMismatch,
+			"return type of function %s is not %s", sig, returnType.SQLString())
+	}
+	if err := params.p.CheckPrivilege(params.ctx, desc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+	return desc, nil
+}
+
+// aggregateOptionFuncName returns the function name specified in an option of
+// CREATE AGGREGATE that names a support function.
+// evalAggregateInitCond evaluates an expression given as the INITCOND of an
+// aggregate with the given state type, and returns the textual form of its
+// value, which is how the initial state is stored.
+func evalAggregateInitCond(params runParams, expr tree.Expr, stateType *types.T) (string, error) {
+	typedExpr, err := schemaexpr.SanitizeVarFreeExpr(
+		params.ctx, expr, stateType, "INITCOND" /* context */, params.p.SemaCtx(),
+		volatility.Immutable, false, /* allowAssignmentCast */
+	)
+	if err != nil {
+		return "", err
+	}
+	d, err := eval.Expr(params.ctx, params.EvalContext(), typedExpr)
+	if err != nil {
+		return "", err
+	}
+	if d == tree.DNull {
+		return "", pgerror.New(pgcode.InvalidParameterValue, "aggregate initcond must not be NULL")
+	}
+	return tree.AsStringWithFlags(d, tree.FmtPgwireText), nil
+}
+
+func aggregateOptionFuncName(opt tree.AggregateOption) (*tree.UnresolvedObjectName, error) {
+	if name, ok := opt.TypeVal.(*tree.UnresolvedObjectName); ok {
+		return name, nil
+	}
+	return nil, pgerror.Newf(pgcode.Syntax, "aggregate %s must be a function name", opt.Name)
+}
+
+// addUserDefinedTypeIDs adds the IDs of the user-defined types referenced by
+// the given type to ids.
+func addUserDefinedTypeIDs(ids *catalog.DescriptorIDSet, t *types.T) {
+	if !t.UserDefined() {
+		return
+	}
+	ids.Add(typedesc.GetUserDefinedTypeDescID(t))
+	if t.Family() == types.ArrayFamily {
+		addUserDefinedTypeIDs(ids, t.ArrayContents())
+	}
+}
+
+// removeAggregateSupportFuncReferences removes the back-references from the
+// support functions of a user-defined aggregate to the aggregate.
+func (p *planner) removeAggregateSupportFuncReferences(
+	ctx context.Context, aggDesc *funcdesc.Mutable,
+) error {
+	agg := aggDesc.Aggregate
+	for _, id := range []descpb.ID{agg.TransitionFunctionID, agg.FinalFunctionID, agg.CombineFunctionID} {
+		if id == descpb.InvalidID {
+			continue
+		}
+		fnDesc, err := p.Descriptors().MutableByID(p.txn).Function(ctx, id)
+		if err != nil {
+			return err
+		}
+		fnDesc.RemoveReference(aggDesc.GetID())
+		if err := p.writeFuncSchemaChange(ctx, fnDesc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// makeCreateAggregateStatement returns a CREATE AGGREGATE statement that
+// recreates the given user-defined aggregate, which lives in the schema with
+// the given name.
+func (p *planner) makeCreateAggregateStatement(
+	ctx context.Context, fnDesc catalog.FunctionDescriptor, scName string,
+) (*tree.CreateAggregate, error) {
+	agg := fnDesc.GetAggregate()
+	ret := &tree.CreateAggregate{
+		FuncName: tree.MakeFunctionNameFromPrefix(
+			tree.ObjectNamePrefix{SchemaName: tree.Name(scName), ExplicitSchema: true},
+			tree.Name(fnDesc.GetName()),
+		),
+		Params: make(tree.FuncParams, len(fnDesc.GetParams())),
+	}
+	for i, param := range fnDesc.GetParams() {
+		ret.Params[i] = tree.FuncParam{Name: tree.Name(param.Name), Type: param.Type}
+	}
+	funcOption := func(name string, id descpb.ID) error {
+		if id == descpb.InvalidID {
+			return nil
+		}
+		supportFn, err := p.Descriptors().ByID(p.txn).WithoutNonPublic().Get().Function(ctx, id)
+		if err != nil {
+			return err
+		}
+		sc, err := p.Descriptors().ByIDWithLeased(p.txn).WithoutNonPublic().Get().Schema(ctx, supportFn.GetParentSchemaID())
+		if err != nil {
+			return err
+		}
+		fnName, err := tree.NewUnresolvedObjectName(
+			2 /* numParts */, [3]string{supportFn.GetName(), sc.GetName()}, tree.NoAnnotation,
+		)
+		if err != nil {
+			return err
+		}
+		ret.Options = append(ret.Options, tree.AggregateOption{Name: tree.Name(name), TypeVal: fnName})
+		return nil
+	}
+	if err := funcOption("sfunc", agg.TransitionFunctionID); err != nil {
+		return nil, err
+	}
+	ret.Options = append(ret.Options, tree.AggregateOption{Name: "stype", TypeVal: agg.StateType})
+	if err := funcOption("finalfunc", agg.FinalFunctionID); err != nil {
+		return nil, err
+	}
+	if err := funcOption("combinefunc", agg.CombineFunctionID); err != nil {
+		return nil, err
+	}
+	if agg.InitCond != nil {
+		ret.Options = append(ret.Options, tree.AggregateOption{Name: "initcond", StrVal: tree.NewStrVal(*agg.InitCond)})
+	}
+	return ret, nil
+}
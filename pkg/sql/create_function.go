@@ -31,6 +31,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/errors"
 )
 
 type createFunctionNode struct {
@@ -105,11 +106,8 @@ func (n *createFunctionNode) createNewFunction(
 		return err
 	}
 
-	for _, option := range n.cf.Options {
-		err := setFuncOption(params, udfDesc, option)
-		if err != nil {
-			return err
-		}
+	if err := setFuncOptions(params, udfDesc, n.cf.Options); err != nil {
+		return err
 	}
 
 	if err := n.addUDFReferences(udfDesc, params); err != nil {
@@ -129,17 +127,14 @@ func (n *createFunctionNode) createNewFunction(
 	if err != nil {
 		return err
 	}
-	paramTypes := make([]*types.T, len(udfDesc.Params))
-	for i, param := range udfDesc.Params {
-		paramTypes[i] = param.Type
-	}
 	scDesc.AddFunction(
 		udfDesc.GetName(),
 		descpb.SchemaDescriptor_FunctionSignature{
-			ID:         udfDesc.GetID(),
-			ArgTypes:   paramTypes,
-			ReturnType: returnType,
-			ReturnSet:  udfDesc.ReturnType.ReturnSet,
+			ID:          udfDesc.GetID(),
+			ArgTypes:    funcdesc.InParamTypes(udfDesc.Params),
+			ReturnType:  returnType,
+			ReturnSet:   udfDesc.ReturnType.ReturnSet,
+			IsProcedure: udfDesc.IsProcedure,
 		},
 	)
 	if err := params.p.writeSchemaDescChange(params.ctx, scDesc, "Create Function"); err != nil {
@@ -153,6 +148,32 @@ func (n *createFunctionNode) replaceFunction(udfDesc *funcdesc.Mutable, params r
 	// TODO(chengxiong): add validation that the function is not referenced. This
 	// is needed when we start allowing function references from other objects.
 
+	// Make sure a function is not replaced by a procedure or vice versa, and
+	// that an aggregate function is not replaced by a regular function.
+	if udfDesc.Aggregate != nil {
+		return errors.WithDetailf(
+			pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+			"%q is an aggregate function.", udfDesc.GetName(),
+		)
+	}
+	if n.cf.IsProcedure != udfDesc.IsProcedure {
+		kind := "function"
+		if udfDesc.IsProcedure {
+			kind = "procedure"
+		}
+		return errors.WithDetailf(
+			pgerror.Newf(pgcode.WrongObjectType, "cannot change routine kind"),
+			"%q is a %s.", udfDesc.GetName(), kind,
+		)
+	}
+
+	// Make sure OUT parameters are not added or removed. Together with the
+	// check of the return type below, this ensures that the result columns of
+	// the function do not change.
+	if len(n.cf.Params) != len(udfDesc.Params) {
+		return pgerror.Newf(pgcode.InvalidFunctionDefinition, "cannot change return type of existing function")
+	}
+
 	// Make sure parameter names are not changed.
 	for i := range n.cf.Params {
 		if string(n.cf.Params[i].Name) != udfDesc.Params[i].Name {
@@ -182,11 +203,8 @@ func (n *createFunctionNode) replaceFunction(udfDesc *funcdesc.Mutable, params r
 	if err := validateVolatilityInOptions(n.cf.Options, udfDesc); err != nil {
 		return err
 	}
-	for _, option := range n.cf.Options {
-		err := setFuncOption(params, udfDesc, option)
-		if err != nil {
-			return err
-		}
+	if err := setFuncOptions(params, udfDesc, n.cf.Options); err != nil {
+		return err
 	}
 
 	// Removing all existing references before adding new references.
@@ -299,6 +317,7 @@ func (n *createFunctionNode) getMutableFuncDesc(
 		n.cf.ReturnType.IsSet,
 		privileges,
 	)
+	newUdfDesc.SetIsProcedure(n.cf.IsProcedure)
 
 	return &newUdfDesc, true, nil
 }
@@ -392,6 +411,29 @@ func (n *createFunctionNode) addUDFReferences(udfDesc *funcdesc.Mutable, params
 	return nil
 }
 
+// setFuncOptions sets all the given options on the function descriptor. The
+// language is set before any other option because the function body is
+// processed differently depending on the language.
+func setFuncOptions(
+	params runParams, udfDesc *funcdesc.Mutable, options tree.FunctionOptions,
+) error {
+	for _, option := range options {
+		if _, ok := option.(tree.FunctionLanguage); ok {
+			if err := setFuncOption(params, udfDesc, option); err != nil {
+				return err
+			}
+		}
+	}
+	for _, option := range options {
+		if _, ok := option.(tree.FunctionLanguage); !ok {
+			if err := setFuncOption(params, udfDesc, option); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func setFuncOption(params runParams, udfDesc *funcdesc.Mutable, option tree.FunctionOption) error {
 	switch t := option.(type) {
 	case tree.FunctionVolatility:
@@ -415,6 +457,12 @@ func setFuncOption(params runParams, udfDesc *funcdesc.Mutable, option tree.Func
 		}
 		udfDesc.SetLang(v)
 	case tree.FunctionBodyStr:
+		if udfDesc.GetLanguage() == catpb.Function_PLPGSQL {
+			// TODO(drewk): Replace sequence names and serialize user-defined
+			// types in the SQL statements of PL/pgSQL function bodies.
+			udfDesc.SetFuncBody(string(t))
+			break
+		}
 		// Replace any sequence names in the function body with IDs.
 		seqReplacedFuncBody, err := replaceSeqNamesWithIDs(params.ctx, params.p, string(t), true)
 		if err != nil {
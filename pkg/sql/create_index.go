@@ -68,6 +68,9 @@ func (p *planner) CreateIndex(ctx context.Context, n *tree.CreateIndex) (planNod
 	if tableDesc.IsView() && !tableDesc.MaterializedView() {
 		return nil, pgerror.Newf(pgcode.WrongObjectType, "%q is not a table or materialized view", tableDesc.Name)
 	}
+	if tableDesc.IsForeignTable() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType, "cannot create index on foreign table %q", tableDesc.Name)
+	}
 
 	if tableDesc.MaterializedView() {
 		if n.Sharded != nil {
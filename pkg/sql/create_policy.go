@@ -0,0 +1,183 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/decodeusername"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+)
+
+type createPolicyNode struct {
+	n         *tree.CreatePolicy
+	tableDesc *tabledesc.Mutable
+	policy    descpb.PolicyDescriptor
+}
+
+// CreatePolicy creates a row-level security policy.
+// Privileges: CREATE on table.
+//
+//	notes: postgres requires ownership of the table.
+func (p *planner) CreatePolicy(ctx context.Context, n *tree.CreatePolicy) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_RowLevelSecurity) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"CREATE POLICY is not supported until the cluster version is finalized")
+	}
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE POLICY",
+	); err != nil {
+		return nil, err
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &n.Table, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	// Disallow schema changes if this table's schema is locked.
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, err
+	}
+	if tableDesc.FindPolicyByName(string(n.Name)) != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"policy %q for table %q already exists", n.Name, tableDesc.Name)
+	}
+
+	// Postgres rejects the clauses which cannot apply to the command of the
+	// policy: SELECT and DELETE never add rows, and INSERT never reads existing
+	// ones.
+	switch n.Command {
+	case tree.PolicyCommandSelect, tree.PolicyCommandDelete:
+		if n.WithCheck != nil {
+			return nil, pgerror.New(pgcode.Syntax,
+				"WITH CHECK cannot be applied to SELECT or DELETE")
+		}
+	case tree.PolicyCommandInsert:
+		if n.Using != nil {
+			return nil, pgerror.New(pgcode.Syntax,
+				"only WITH CHECK expression allowed for INSERT")
+		}
+	}
+
+	policy := descpb.PolicyDescriptor{
+		Name: string(n.Name),
+		Type: descpb.PolicyDescriptor_PERMISSIVE,
+	}
+	if n.Type == tree.PolicyTypeRestrictive {
+		policy.Type = descpb.PolicyDescriptor_RESTRICTIVE
+	}
+	switch n.Command {
+	case tree.PolicyCommandAll:
+		policy.Command = descpb.PolicyDescriptor_ALL
+	case tree.PolicyCommandSelect:
+		policy.Command = descpb.PolicyDescriptor_SELECT
+	case tree.PolicyCommandInsert:
+		policy.Command = descpb.PolicyDescriptor_INSERT
+	case tree.PolicyCommandUpdate:
+		policy.Command = descpb.PolicyDescriptor_UPDATE
+	case tree.PolicyCommandDelete:
+		policy.Command = descpb.PolicyDescriptor_DELETE
+	}
+	if policy.RoleNames, err = p.resolvePolicyRoles(ctx, n.Roles); err != nil {
+		return nil, err
+	}
+	if policy.UsingExpr, err = p.validatePolicyExpr(ctx, tableDesc, &n.Table, n.Using); err != nil {
+		return nil, err
+	}
+	if policy.WithCheckExpr, err = p.validatePolicyExpr(ctx, tableDesc, &n.Table, n.WithCheck); err != nil {
+		return nil, err
+	}
+
+	return &createPolicyNode{n: n, tableDesc: tableDesc, policy: policy}, nil
+}
+
+// resolvePolicyRoles returns the normalized names of the roles to which a
+// policy applies. If no roles are given, the policy applies to public.
+func (p *planner) resolvePolicyRoles(
+	ctx context.Context, roles tree.RoleSpecList,
+) ([]string, error) {
+	if len(roles) == 0 {
+		return []string{username.PublicRole}, nil
+	}
+	users, err := decodeusername.FromRoleSpecList(
+		p.SessionData(), username.PurposeValidation, roles,
+	)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(users))
+	for _, user := range users {
+		if !user.IsPublicRole() {
+			if err := p.checkPolicyRoleExists(ctx, user); err != nil {
+				return nil, err
+			}
+		}
+		names = append(names, user.Normalized())
+	}
+	return names, nil
+}
+
+// validatePolicyExpr validates the given USING or WITH CHECK expression of a
+// policy and returns its serialized form. It returns an empty string if the
+// expression is nil.
+func (p *planner) validatePolicyExpr(
+	ctx context.Context, tableDesc *tabledesc.Mutable, tn *tree.TableName, expr tree.Expr,
+) (string, error) {
+	if expr == nil {
+		return "", nil
+	}
+	return schemaexpr.ValidatePolicyExpr(
+		ctx, tableDesc, expr, tn, p.SemaCtx(), p.ExecCfg().Settings.Version.ActiveVersion(ctx),
+	)
+}
+
+// checkPolicyRoleExists returns an error if the given role does not exist.
+func (p *planner) checkPolicyRoleExists(ctx context.Context, role username.SQLUsername) error {
+	exists, err := p.RoleExists(ctx, role)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return sqlerrors.NewUndefinedUserError(role)
+	}
+	return nil
+}
+
+func (n *createPolicyNode) ReadingOwnWrites() {}
+
+func (n *createPolicyNode) startExec(params runParams) error {
+	n.tableDesc.AddPolicy(n.policy)
+	return params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, descpb.InvalidMutationID,
+		fmt.Sprintf("creating policy %q on table %q", n.policy.Name, n.tableDesc.GetName()),
+	)
+}
+
+func (*createPolicyNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createPolicyNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createPolicyNode) Close(ctx context.Context)           {}
@@ -0,0 +1,244 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+type createPublicationNode struct {
+	n           *tree.CreatePublication
+	dbDesc      *dbdesc.Mutable
+	publication descpb.PublicationDescriptor
+}
+
+const publicationOptionPublish = "publish"
+
+var publicationOptionExpectValues = map[string]exprutil.KVStringOptValidate{
+	publicationOptionPublish: exprutil.KVStringOptRequireValue,
+}
+
+// CreatePublication creates a publication in the current database.
+// Privileges: CREATE on database, ownership of the published tables, and
+// admin for FOR ALL TABLES.
+func (p *planner) CreatePublication(
+	ctx context.Context, n *tree.CreatePublication,
+) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_LogicalReplication) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"CREATE PUBLICATION is not supported until the cluster version is finalized")
+	}
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE PUBLICATION",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.mutablePublicationDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, dbDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	if dbDesc.FindPublicationByName(string(n.Name)) != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"publication %q already exists", n.Name)
+	}
+
+	publication := descpb.PublicationDescriptor{
+		Name:          string(n.Name),
+		OwnerProto:    p.User().EncodeProto(),
+		AllTables:     n.AllTables,
+		PublishInsert: true,
+		PublishUpdate: true,
+		PublishDelete: true,
+	}
+	if n.AllTables {
+		if err := p.checkPublicationAllTablesPrivilege(ctx); err != nil {
+			return nil, err
+		}
+	}
+	if publication.TableIDs, err = p.resolvePublicationTables(ctx, dbDesc, n.Tables); err != nil {
+		return nil, err
+	}
+	if err := p.evalPublicationOptions(ctx, "CREATE PUBLICATION", n.Options, &publication); err != nil {
+		return nil, err
+	}
+
+	return &createPublicationNode{n: n, dbDesc: dbDesc, publication: publication}, nil
+}
+
+// mutablePublicationDatabase returns the current database, in which the
+// publications referred to by a statement are defined.
+func (p *planner) mutablePublicationDatabase(ctx context.Context) (*dbdesc.Mutable, error) {
+	if p.CurrentDatabase() == "" {
+		return nil, errNoDatabase
+	}
+	return p.Descriptors().MutableByName(p.txn).Database(ctx, p.CurrentDatabase())
+}
+
+// checkPublicationAllTablesPrivilege checks that the user can create or alter
+// a publication which includes all the tables of a database.
+func (p *planner) checkPublicationAllTablesPrivilege(ctx context.Context) error {
+	isAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return pgerror.New(pgcode.InsufficientPrivilege,
+			"must be admin to create FOR ALL TABLES publication")
+	}
+	return nil
+}
+
+// checkPublicationOwnership checks that the user owns the publication, or is
+// an admin.
+func (p *planner) checkPublicationOwnership(
+	ctx context.Context, publication *descpb.PublicationDescriptor,
+) error {
+	if publication.OwnerProto.Decode() == p.User() {
+		return nil
+	}
+	isAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of publication %s", publication.Name)
+	}
+	return nil
+}
+
+// resolvePublicationTables returns the IDs of the given tables, which must be
+// owned by the user and belong to the database of the publication. Tables
+// which are listed more than once are only returned once.
+func (p *planner) resolvePublicationTables(
+	ctx context.Context, dbDesc catalog.DatabaseDescriptor, tables tree.TableNames,
+) ([]descpb.ID, error) {
+	var ids []descpb.ID
+	seen := make(map[descpb.ID]struct{}, len(tables))
+	for i := range tables {
+		tn := &tables[i]
+		table, err := p.ResolveExistingObjectEx(
+			ctx, tn.ToUnresolvedObjectName(), true /* required */, tree.ResolveRequireTableDesc,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if err := p.checkPublicationTable(ctx, dbDesc, table); err != nil {
+			return nil, err
+		}
+		if _, ok := seen[table.GetID()]; ok {
+			continue
+		}
+		seen[table.GetID()] = struct{}{}
+		ids = append(ids, table.GetID())
+	}
+	return ids, nil
+}
+
+// checkPublicationTable checks that a table can be added to a publication of
+// the given database.
+func (p *planner) checkPublicationTable(
+	ctx context.Context, dbDesc catalog.DatabaseDescriptor, table catalog.TableDescriptor,
+) error {
+	if table.IsVirtualTable() || catalog.IsSystemDescriptor(table) {
+		return errors.WithDetail(
+			pgerror.Newf(pgcode.InvalidParameterValue,
+				"cannot add relation %q to publication", table.GetName()),
+			"This operation is not supported for system tables.")
+	}
+	if table.IsTemporary() {
+		return errors.WithDetail(
+			pgerror.Newf(pgcode.InvalidParameterValue,
+				"cannot add relation %q to publication", table.GetName()),
+			"This operation is not supported for temporary tables.")
+	}
+	if table.GetParentID() != dbDesc.GetID() {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot add relation %q from another database to publication", table.GetName())
+	}
+	hasOwnership, err := p.HasOwnership(ctx, table)
+	if err != nil {
+		return err
+	}
+	if !hasOwnership {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of table %s", table.GetName())
+	}
+	return nil
+}
+
+// evalPublicationOptions evaluates the WITH options of a publication and sets
+// them on the publication descriptor.
+func (p *planner) evalPublicationOptions(
+	ctx context.Context, op string, opts tree.KVOptions, publication *descpb.PublicationDescriptor,
+) error {
+	optVals, err := p.ExprEvaluator(op).KVOptions(ctx, opts, publicationOptionExpectValues)
+	if err != nil {
+		return err
+	}
+	publish, ok := optVals[publicationOptionPublish]
+	if !ok {
+		return nil
+	}
+	publication.PublishInsert = false
+	publication.PublishUpdate = false
+	publication.PublishDelete = false
+	for _, action := range strings.Split(publish, ",") {
+		switch strings.ToLower(strings.TrimSpace(action)) {
+		case "insert":
+			publication.PublishInsert = true
+		case "update":
+			publication.PublishUpdate = true
+		case "delete":
+			publication.PublishDelete = true
+		case "truncate":
+			// Truncations are not streamed to replication clients, so there is
+			// nothing to publish.
+		default:
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized %q value: %q", publicationOptionPublish, action)
+		}
+	}
+	return nil
+}
+
+func (n *createPublicationNode) ReadingOwnWrites() {}
+
+func (n *createPublicationNode) startExec(params runParams) error {
+	n.dbDesc.AddPublication(n.publication)
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx, n.dbDesc,
+		fmt.Sprintf("creating publication %q in database %q", n.publication.Name, n.dbDesc.GetName()),
+	)
+}
+
+func (*createPublicationNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createPublicationNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createPublicationNode) Close(ctx context.Context)           {}
@@ -48,6 +48,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
@@ -70,6 +71,8 @@ type createTableNode struct {
 	n          *tree.CreateTable
 	dbDesc     catalog.DatabaseDescriptor
 	sourcePlan planNode
+	// foreignTable is set for CREATE FOREIGN TABLE.
+	foreignTable *descpb.ForeignTableDescriptor
 }
 
 // ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
@@ -394,6 +397,12 @@ func (n *createTableNode) startExec(params runParams) error {
 		if err != nil {
 			return err
 		}
+		if n.foreignTable != nil {
+			if err := checkForeignTableColumns(desc); err != nil {
+				return err
+			}
+			desc.ForeignTable = n.foreignTable
+		}
 
 		if desc.Adding() {
 			// if this table and all its references are created in the same
@@ -668,6 +677,7 @@ func addUniqueWithoutIndexColumnTableDef(
 		string(d.Unique.ConstraintName),
 		[]string{string(d.Name)},
 		"", /* predicate */
+		tree.ConstraintNotDeferrable,
 		ts,
 		validationBehavior,
 	); err != nil {
@@ -712,6 +722,9 @@ func addUniqueWithoutIndexTableDef(
 			"creating a unique constraint using UNIQUE WITH NOT VISIBLE INDEX is not supported",
 		)
 	}
+	if err := checkDeferrabilityIsSupported(ctx, evalCtx.Settings, d.Deferrability); err != nil {
+		return err
+	}
 
 	// If there is a predicate, validate it.
 	var predicate string
@@ -731,7 +744,7 @@ func addUniqueWithoutIndexTableDef(
 		colNames[i] = string(d.Columns[i].Column)
 	}
 	if err := ResolveUniqueWithoutIndexConstraint(
-		ctx, desc, string(d.Name), colNames, predicate, ts, validationBehavior,
+		ctx, desc, string(d.Name), colNames, predicate, d.Deferrability, ts, validationBehavior,
 	); err != nil {
 		return err
 	}
@@ -751,6 +764,7 @@ func ResolveUniqueWithoutIndexConstraint(
 	constraintName string,
 	colNames []string,
 	predicate string,
+	deferrability tree.ConstraintDeferrability,
 	ts TableState,
 	validationBehavior tree.ValidationBehavior,
 ) error {
@@ -799,12 +813,13 @@ func ResolveUniqueWithoutIndexConstraint(
 	}
 
 	uc := descpb.UniqueWithoutIndexConstraint{
-		Name:         constraintName,
-		TableID:      tbl.ID,
-		ColumnIDs:    columnIDs,
-		Predicate:    predicate,
-		Validity:     validity,
-		ConstraintID: tbl.NextConstraintID,
+		Name:          constraintName,
+		TableID:       tbl.ID,
+		ColumnIDs:     columnIDs,
+		Predicate:     predicate,
+		Validity:      validity,
+		ConstraintID:  tbl.NextConstraintID,
+		Deferrability: semenumpb.Deferrability(deferrability),
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -854,6 +869,9 @@ func ResolveFK(
 	validationBehavior tree.ValidationBehavior,
 	evalCtx *eval.Context,
 ) error {
+	if err := checkDeferrabilityIsSupported(ctx, evalCtx.Settings, d.Deferrability); err != nil {
+		return err
+	}
 	var originColSet catalog.TableColSet
 	originCols := make([]catalog.Column, len(d.FromCols))
 	for i, fromCol := range d.FromCols {
@@ -887,6 +905,10 @@ func ResolveFK(
 			)
 		}
 	}
+	if target.IsForeignTable() {
+		return pgerror.Newf(pgcode.InvalidForeignKey,
+			"foreign keys cannot reference foreign table %q", target.Name)
+	}
 	if tbl.Temporary != target.Temporary {
 		persistenceType := "permanent"
 		if tbl.Temporary {
@@ -1049,6 +1071,7 @@ func ResolveFK(
 		OnUpdate:            tree.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               tree.CompositeKeyMatchMethodValue[d.Match],
 		ConstraintID:        tbl.NextConstraintID,
+		Deferrability:       semenumpb.Deferrability(d.Deferrability),
 	}
 	tbl.NextConstraintID++
 	if ts == NewTable {
@@ -1882,6 +1905,9 @@ func NewTableDesc(
 				// We will add the unique constraint below.
 				break
 			}
+			if d.Deferrability != tree.ConstraintNotDeferrable {
+				return nil, sqlerrors.NewUnsupportedDeferrableUniqueIndexError()
+			}
 			// If the index is named, ensure that the name is unique. Unnamed
 			// indexes will be given a unique auto-generated name later on when
 			// AllocateIDs is called.
@@ -1997,6 +2023,19 @@ func NewTableDesc(
 					return nil, err
 				}
 			}
+		case *tree.ExclusionConstraintTableDef:
+			if d.Name != "" {
+				if idx := catalog.FindIndexByName(&desc, d.Name.String()); idx != nil {
+					return nil, pgerror.Newf(pgcode.DuplicateRelation, "duplicate index name: %q", d.Name)
+				}
+			}
+			idx, err := makeExclusionConstraintIndexDescriptor(ctx, st, &desc, d)
+			if err != nil {
+				return nil, err
+			}
+			if err := desc.AddSecondaryIndex(idx); err != nil {
+				return nil, err
+			}
 		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
 			// pass, handled below.
 
@@ -2165,7 +2204,8 @@ func NewTableDesc(
 				}
 			}
 
-		case *tree.IndexTableDef, *tree.FamilyTableDef, *tree.LikeTableDef:
+		case *tree.IndexTableDef, *tree.ExclusionConstraintTableDef, *tree.FamilyTableDef,
+			*tree.LikeTableDef:
 			// Pass, handled above.
 
 		case *tree.CheckConstraintTableDef:
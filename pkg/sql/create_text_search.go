@@ -0,0 +1,310 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+)
+
+type createTextSearchConfigNode struct {
+	schema *schemadesc.Mutable
+	config descpb.TextSearchConfigDescriptor
+}
+
+const (
+	textSearchConfigOptionParser = "parser"
+	textSearchConfigOptionCopy   = "copy"
+
+	textSearchDictOptionTemplate = "template"
+)
+
+// CreateTextSearchConfig creates a text search configuration.
+// Privileges: CREATE on the schema of the configuration.
+func (p *planner) CreateTextSearchConfig(
+	ctx context.Context, n *tree.CreateTextSearchConfig,
+) (planNode, error) {
+	if err := p.checkTextSearchObjectsSupported(ctx, "CREATE TEXT SEARCH CONFIGURATION"); err != nil {
+		return nil, err
+	}
+	schema, err := p.textSearchSchema(ctx, n.Name, true /* create */)
+	if err != nil {
+		return nil, err
+	}
+	name := n.Name.Object()
+	if schema.FindTextSearchConfigByName(name) != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"text search configuration %q already exists", name)
+	}
+
+	opts, err := p.evalTextSearchOptions(ctx, "CREATE TEXT SEARCH CONFIGURATION", n.Options)
+	if err != nil {
+		return nil, err
+	}
+	config := descpb.TextSearchConfigDescriptor{
+		Name:       name,
+		OwnerProto: p.User().EncodeProto(),
+		Parser:     schemadesc.TextSearchDefaultParser,
+	}
+	for _, opt := range n.Options {
+		switch key := strings.ToLower(string(opt.Key)); key {
+		case textSearchConfigOptionParser, textSearchConfigOptionCopy:
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"text search configuration parameter %q not recognized", key)
+		}
+	}
+	parserName, hasParser := opts[textSearchConfigOptionParser]
+	source, hasCopy := opts[textSearchConfigOptionCopy]
+	switch {
+	case hasParser && hasCopy:
+		return nil, pgerror.New(pgcode.Syntax,
+			"cannot specify both PARSER and COPY options")
+	case hasParser:
+		if strings.TrimPrefix(parserName, catconstants.PgCatalogName+".") != schemadesc.TextSearchDefaultParser {
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"text search parser %q does not exist", parserName)
+		}
+	case hasCopy:
+		if config.Mappings, err = p.copyTextSearchConfigMappings(ctx, schema, source); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"text search parser is required")
+	}
+
+	return &createTextSearchConfigNode{schema: schema, config: config}, nil
+}
+
+// checkTextSearchObjectsSupported checks that user-defined text search objects
+// can be created and changed.
+func (p *planner) checkTextSearchObjectsSupported(ctx context.Context, op string) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_TextSearchConfigurations) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"%s is not supported until the cluster version is finalized", op)
+	}
+	return checkSchemaChangeEnabled(ctx, p.ExecCfg(), op)
+}
+
+// textSearchSchema returns the schema in which the text search object with the
+// given name is defined, or is to be created. Like other objects, an
+// unqualified name refers to the first schema of the search path.
+func (p *planner) textSearchSchema(
+	ctx context.Context, un *tree.UnresolvedObjectName, create bool,
+) (*schemadesc.Mutable, error) {
+	_, sc, _, err := p.ResolveTargetObject(ctx, un)
+	if err != nil {
+		return nil, err
+	}
+	if sc.SchemaKind() != catalog.SchemaUserDefined {
+		return nil, pgerror.Newf(pgcode.InvalidSchemaName,
+			"%s is not a physical schema", sc.GetName())
+	}
+	if create {
+		if err := p.CheckPrivilege(ctx, sc, privilege.CREATE); err != nil {
+			return nil, err
+		}
+	}
+	return p.Descriptors().MutableByID(p.txn).Schema(ctx, sc.GetID())
+}
+
+// checkTextSearchObjectOwnership checks that the user owns the text search
+// object, or is an admin.
+func (p *planner) checkTextSearchObjectOwnership(
+	ctx context.Context, kind, name string, owner username.SQLUsernameProto,
+) error {
+	if owner.Decode() == p.User() {
+		return nil
+	}
+	isAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if !isAdmin {
+		return pgerror.Newf(pgcode.InsufficientPrivilege,
+			"must be owner of text search %s %s", kind, name)
+	}
+	return nil
+}
+
+// evalTextSearchOptions evaluates the options of a text search object. The
+// option names are case-insensitive, and must be given at most once.
+func (p *planner) evalTextSearchOptions(
+	ctx context.Context, op string, opts tree.KVOptions,
+) (map[string]string, error) {
+	vals := make(map[string]string, len(opts))
+	for _, opt := range opts {
+		key := strings.ToLower(string(opt.Key))
+		if _, ok := vals[key]; ok {
+			return nil, pgerror.Newf(pgcode.Syntax, "option %q specified more than once", key)
+		}
+		val, err := p.ExprEvaluator(op).String(ctx, opt.Value)
+		if err != nil {
+			return nil, err
+		}
+		vals[key] = val
+	}
+	return vals, nil
+}
+
+// copyTextSearchConfigMappings returns the mappings of the given text search
+// configuration, to be copied into a configuration of the given schema.
+func (p *planner) copyTextSearchConfigMappings(
+	ctx context.Context, schema *schemadesc.Mutable, source string,
+) ([]descpb.TextSearchConfigDescriptor_Mapping, error) {
+	if builtin, ok := tsearch.BuiltinConfig(source); ok {
+		var mappings []descpb.TextSearchConfigDescriptor_Mapping
+		for _, t := range tsearch.TokenTypes() {
+			dicts := builtin.Mapping(t)
+			if len(dicts) == 0 {
+				continue
+			}
+			m := descpb.TextSearchConfigDescriptor_Mapping{TokenType: t.String()}
+			for _, d := range dicts {
+				m.Dictionaries = append(m.Dictionaries,
+					descpb.TextSearchConfigDescriptor_DictionaryRef{Name: d.Name(), Builtin: true})
+			}
+			mappings = append(mappings, m)
+		}
+		return mappings, nil
+	}
+	un, err := parser.ParseTableName(source)
+	if err != nil {
+		return nil, err
+	}
+	if un.HasExplicitSchema() && un.Schema() == catconstants.PgCatalogName {
+		return nil, tsearch.UndefinedConfigError(source)
+	}
+	sourceSchema, err := p.textSearchSchema(ctx, un, false /* create */)
+	if err != nil {
+		return nil, err
+	}
+	config := sourceSchema.FindTextSearchConfigByName(un.Object())
+	if config == nil {
+		return nil, tsearch.UndefinedConfigError(source)
+	}
+	mappings := make([]descpb.TextSearchConfigDescriptor_Mapping, len(config.Mappings))
+	for i, m := range config.Mappings {
+		mappings[i] = descpb.TextSearchConfigDescriptor_Mapping{
+			TokenType:    m.TokenType,
+			Dictionaries: append([]descpb.TextSearchConfigDescriptor_DictionaryRef(nil), m.Dictionaries...),
+		}
+		if sourceSchema.GetID() == schema.GetID() {
+			continue
+		}
+		for _, ref := range m.Dictionaries {
+			if !ref.Builtin {
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"cannot copy text search configuration %q, which uses dictionary %q of another schema",
+					source, ref.Name)
+			}
+		}
+	}
+	return mappings, nil
+}
+
+func (n *createTextSearchConfigNode) ReadingOwnWrites() {}
+
+func (n *createTextSearchConfigNode) startExec(params runParams) error {
+	n.schema.AddTextSearchConfig(n.config)
+	return params.p.writeSchemaDescChange(
+		params.ctx, n.schema,
+		fmt.Sprintf("creating text search configuration %q in schema %q",
+			n.config.Name, n.schema.GetName()),
+	)
+}
+
+func (*createTextSearchConfigNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createTextSearchConfigNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createTextSearchConfigNode) Close(ctx context.Context)           {}
+
+type createTextSearchDictionaryNode struct {
+	schema *schemadesc.Mutable
+	dict   descpb.TextSearchDictionaryDescriptor
+}
+
+// CreateTextSearchDictionary creates a text search dictionary.
+// Privileges: CREATE on the schema of the dictionary.
+func (p *planner) CreateTextSearchDictionary(
+	ctx context.Context, n *tree.CreateTextSearchDictionary,
+) (planNode, error) {
+	if err := p.checkTextSearchObjectsSupported(ctx, "CREATE TEXT SEARCH DICTIONARY"); err != nil {
+		return nil, err
+	}
+	schema, err := p.textSearchSchema(ctx, n.Name, true /* create */)
+	if err != nil {
+		return nil, err
+	}
+	name := n.Name.Object()
+	if schema.FindTextSearchDictionaryByName(name) != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"text search dictionary %q already exists", name)
+	}
+
+	opts, err := p.evalTextSearchOptions(ctx, "CREATE TEXT SEARCH DICTIONARY", n.Options)
+	if err != nil {
+		return nil, err
+	}
+	template, ok := opts[textSearchDictOptionTemplate]
+	if !ok {
+		return nil, pgerror.New(pgcode.InvalidParameterValue,
+			"text search template is required")
+	}
+	delete(opts, textSearchDictOptionTemplate)
+	dict := descpb.TextSearchDictionaryDescriptor{
+		Name:       name,
+		OwnerProto: p.User().EncodeProto(),
+		Template:   strings.ToLower(strings.TrimPrefix(template, catconstants.PgCatalogName+".")),
+	}
+	// Keep the options in the order in which they were given.
+	for _, opt := range n.Options {
+		key := strings.ToLower(string(opt.Key))
+		if val, ok := opts[key]; ok {
+			dict.Options = append(dict.Options,
+				descpb.TextSearchDictionaryDescriptor_Option{Name: key, Value: val})
+		}
+	}
+	if _, err := schemadesc.NewTextSearchDictionary(&dict); err != nil {
+		return nil, err
+	}
+
+	return &createTextSearchDictionaryNode{schema: schema, dict: dict}, nil
+}
+
+func (n *createTextSearchDictionaryNode) ReadingOwnWrites() {}
+
+func (n *createTextSearchDictionaryNode) startExec(params runParams) error {
+	n.schema.AddTextSearchDictionary(n.dict)
+	return params.p.writeSchemaDescChange(
+		params.ctx, n.schema,
+		fmt.Sprintf("creating text search dictionary %q in schema %q",
+			n.dict.Name, n.schema.GetName()),
+	)
+}
+
+func (*createTextSearchDictionaryNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createTextSearchDictionaryNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createTextSearchDictionaryNode) Close(ctx context.Context)           {}
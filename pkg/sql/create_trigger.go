@@ -0,0 +1,152 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+type createTriggerNode struct {
+	n         *tree.CreateTrigger
+	tableDesc *tabledesc.Mutable
+	fnDesc    *funcdesc.Mutable
+}
+
+// CreateTrigger creates a trigger.
+// Privileges: CREATE on table and EXECUTE on the trigger function.
+//
+//	notes: postgres requires TRIGGER on the table and EXECUTE on the function.
+func (p *planner) CreateTrigger(ctx context.Context, n *tree.CreateTrigger) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_Triggers) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"CREATE TRIGGER is not supported until the cluster version is finalized")
+	}
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"CREATE TRIGGER",
+	); err != nil {
+		return nil, err
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &n.Table, true /* required */, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc.IsTemporary() {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot create trigger on temporary table %q", tableDesc.Name)
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	// Disallow schema changes if this table's schema is locked.
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, err
+	}
+	if tableDesc.FindTriggerByName(string(n.Name)) != nil {
+		return nil, pgerror.Newf(pgcode.DuplicateObject,
+			"trigger %q for relation %q already exists", n.Name, tableDesc.Name)
+	}
+
+	fnDesc, err := p.resolveTriggerFunction(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckPrivilege(ctx, fnDesc, privilege.EXECUTE); err != nil {
+		return nil, err
+	}
+
+	return &createTriggerNode{n: n, tableDesc: tableDesc, fnDesc: fnDesc}, nil
+}
+
+// resolveTriggerFunction resolves the function executed by the given trigger,
+// which must be a function without arguments that returns TRIGGER. The new and
+// old rows are bound to the implicit NEW and OLD parameters of the function
+// when the trigger fires.
+func (p *planner) resolveTriggerFunction(
+	ctx context.Context, n *tree.CreateTrigger,
+) (*funcdesc.Mutable, error) {
+	path := p.CurrentSearchPath()
+	fnDef, err := p.ResolveFunction(ctx, n.FuncName, &path)
+	if err != nil {
+		return nil, err
+	}
+	fnName, err := n.FuncName.ToFunctionName()
+	if err != nil {
+		return nil, err
+	}
+	// A trigger function has no declared arguments.
+	ol, err := fnDef.MatchOverload([]*types.T{}, fnName.Schema(), &path)
+	if err != nil {
+		return nil, err
+	}
+	if !ol.IsUDF || ol.FixedReturnType().Family() != types.TriggerFamily {
+		return nil, pgerror.Newf(pgcode.InvalidObjectDefinition,
+			"function %s must return type trigger", fnDef.Name)
+	}
+	return p.Descriptors().MutableByID(p.Txn()).Function(ctx, funcdesc.UserDefinedFunctionOIDToID(ol.Oid))
+}
+
+func (n *createTriggerNode) ReadingOwnWrites() {}
+
+func (n *createTriggerNode) startExec(params runParams) error {
+	trigger := descpb.TriggerDescriptor{
+		Name:       string(n.n.Name),
+		ActionTime: descpb.TriggerDescriptor_BEFORE,
+		ForEachRow: n.n.ForEachRow,
+		FuncID:     n.fnDesc.GetID(),
+	}
+	if n.n.ActionTime == tree.TriggerActionTimeAfter {
+		trigger.ActionTime = descpb.TriggerDescriptor_AFTER
+	}
+	for _, event := range n.n.Events {
+		var e descpb.TriggerDescriptor_Event
+		switch event {
+		case tree.TriggerEventInsert:
+			e = descpb.TriggerDescriptor_INSERT
+		case tree.TriggerEventUpdate:
+			e = descpb.TriggerDescriptor_UPDATE
+		case tree.TriggerEventDelete:
+			e = descpb.TriggerDescriptor_DELETE
+		}
+		trigger.Events = append(trigger.Events, e)
+	}
+
+	triggerID := n.tableDesc.AddTrigger(trigger)
+	if err := n.fnDesc.AddTriggerReference(n.tableDesc.GetID(), triggerID); err != nil {
+		return err
+	}
+	if err := params.p.writeFuncSchemaChange(params.ctx, n.fnDesc); err != nil {
+		return err
+	}
+	return params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, descpb.InvalidMutationID,
+		fmt.Sprintf("creating trigger %q on table %q", trigger.Name, n.tableDesc.GetName()),
+	)
+}
+
+func (*createTriggerNode) Next(params runParams) (bool, error) { return false, nil }
+func (*createTriggerNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*createTriggerNode) Close(ctx context.Context)           {}
@@ -19,8 +19,10 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catprivilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/enum"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
@@ -320,6 +322,13 @@ func (p *planner) createUserDefinedType(params runParams, n *createTypeNode) err
 		return params.p.createCompositeWithID(
 			params, id, n.n.CompositeTypeList, n.dbDesc, n.typeName,
 		)
+	case tree.Domain:
+		if !p.execCfg.Settings.Version.IsActive(params.ctx, clusterversion.V23_2_Domains) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to create domains",
+				clusterversion.ByKey(clusterversion.V23_2_Domains))
+		}
+		return params.p.createDomainWithID(params, id, n.n, n.dbDesc, n.typeName)
 	}
 	return unimplemented.NewWithIssue(25123, "CREATE TYPE")
 }
@@ -457,6 +466,98 @@ func CreateCompositeTypeDesc(
 	}).BuildCreatedMutableType(), nil
 }
 
+// CreateDomainTypeDesc creates a new domain type descriptor.
+func CreateDomainTypeDesc(
+	params runParams,
+	id descpb.ID,
+	n *tree.CreateType,
+	dbDesc catalog.DatabaseDescriptor,
+	schema catalog.SchemaDescriptor,
+	typeName *tree.TypeName,
+) (*typedesc.Mutable, error) {
+	baseType, err := tree.ResolveType(params.ctx, n.DomainType, params.p.semaCtx.TypeResolver)
+	if err != nil {
+		return nil, err
+	}
+	if baseType.UserDefined() || baseType.DomainOID() != 0 {
+		return nil, unimplemented.NewWithIssue(27796,
+			"domains over user-defined types not yet supported")
+	}
+	if err := colinfo.ValidateColumnDefType(params.ctx, params.EvalContext().Settings.Version, baseType); err != nil {
+		return nil, err
+	}
+
+	domain := &descpb.TypeDescriptor_Domain{
+		BaseType: baseType,
+		NotNull:  n.DomainNotNull,
+	}
+	if n.DomainDefault != nil {
+		defaultExpr, err := schemaexpr.ValidateDomainDefaultExpr(
+			params.ctx, n.DomainDefault, baseType, params.p.SemaCtx(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		domain.DefaultExpr = &defaultExpr
+	}
+
+	// Unnamed constraints are named like in Postgres: <domain>_check,
+	// <domain>_check1, and so on.
+	inuseNames := make(map[string]struct{}, len(n.DomainChecks))
+	for _, c := range n.DomainChecks {
+		if c.Name != "" {
+			if _, ok := inuseNames[string(c.Name)]; ok {
+				return nil, pgerror.Newf(pgcode.DuplicateObject,
+					"constraint %q for domain %q already exists", c.Name, typeName.Type())
+			}
+			inuseNames[string(c.Name)] = struct{}{}
+		}
+	}
+	for _, c := range n.DomainChecks {
+		expr, err := schemaexpr.ValidateDomainCheckExpr(params.ctx, c.Expr, baseType, params.p.SemaCtx())
+		if err != nil {
+			return nil, err
+		}
+		name := string(c.Name)
+		if name == "" {
+			name = typeName.Type() + "_check"
+			for i := 1; ; i++ {
+				if _, ok := inuseNames[name]; !ok {
+					break
+				}
+				name = fmt.Sprintf("%s_check%d", typeName.Type(), i)
+			}
+			inuseNames[name] = struct{}{}
+		}
+		domain.Checks = append(domain.Checks, descpb.TypeDescriptor_Domain_DomainCheck{
+			Name: name,
+			Expr: expr,
+		})
+	}
+
+	privs, err := catprivilege.CreatePrivilegesFromDefaultPrivileges(
+		dbDesc.GetDefaultPrivilegeDescriptor(),
+		schema.GetDefaultPrivilegeDescriptor(),
+		dbDesc.GetID(),
+		params.SessionData().User(),
+		privilege.Types,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return typedesc.NewBuilder(&descpb.TypeDescriptor{
+		Name:           typeName.Type(),
+		ID:             id,
+		ParentID:       dbDesc.GetID(),
+		ParentSchemaID: schema.GetID(),
+		Kind:           descpb.TypeDescriptor_DOMAIN,
+		Domain:         domain,
+		Version:        1,
+		Privileges:     privs,
+	}).BuildCreatedMutableType(), nil
+}
+
 func (p *planner) createEnumWithID(
 	params runParams,
 	id descpb.ID,
@@ -509,6 +610,27 @@ func (p *planner) createCompositeWithID(
 	return nil
 }
 
+func (p *planner) createDomainWithID(
+	params runParams,
+	id descpb.ID,
+	n *tree.CreateType,
+	dbDesc catalog.DatabaseDescriptor,
+	typeName *tree.TypeName,
+) error {
+	// Generate a key in the namespace table and a new id for this type.
+	schema, err := getCreateTypeParams(params, typeName, dbDesc)
+	if err != nil {
+		return err
+	}
+
+	typeDesc, err := CreateDomainTypeDesc(params, id, n, dbDesc, schema, typeName)
+	if err != nil {
+		return err
+	}
+
+	return p.finishCreateType(params, id, typeName, typeDesc, dbDesc, schema)
+}
+
 func (p *planner) finishCreateType(
 	params runParams,
 	id descpb.ID,
@@ -518,13 +640,16 @@ func (p *planner) finishCreateType(
 	schema catalog.SchemaDescriptor,
 ) error {
 	// Create the implicit array type for this type before finishing the type.
-	arrayTypeID, err := p.createArrayType(params, typeName, typeDesc, dbDesc, schema.GetID())
-	if err != nil {
-		return err
-	}
+	// Domains do not have an implicit array type.
+	if typeDesc.Kind != descpb.TypeDescriptor_DOMAIN {
+		arrayTypeID, err := p.createArrayType(params, typeName, typeDesc, dbDesc, schema.GetID())
+		if err != nil {
+			return err
+		}
 
-	// Update the typeDesc with the created array type ID.
-	typeDesc.ArrayTypeID = arrayTypeID
+		// Update the typeDesc with the created array type ID.
+		typeDesc.ArrayTypeID = arrayTypeID
+	}
 
 	// Now create the type after the implicit array type as been created.
 	if err := p.createDescriptor(params.ctx, typeDesc, typeName.String()); err != nil {
@@ -0,0 +1,396 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+)
+
+// checkDeferrabilityIsSupported returns an error if a constraint with the given
+// deferrability cannot be created yet, because the cluster version where it is
+// persisted in the constraint descriptor is not active.
+func checkDeferrabilityIsSupported(
+	ctx context.Context, st *cluster.Settings, deferrability tree.ConstraintDeferrability,
+) error {
+	if deferrability != tree.ConstraintNotDeferrable &&
+		!st.Version.IsActive(ctx, clusterversion.V23_2_DeferrableConstraints) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"DEFERRABLE constraints are not supported until the cluster version is finalized")
+	}
+	return nil
+}
+
+// deferredConstraints is used by the planner to access the state of the
+// DEFERRABLE constraints in the current transaction.
+type deferredConstraints interface {
+	// isDeferred returns true if the check for the constraint should be
+	// deferred until the transaction commits.
+	isDeferred(check *exec.DeferrableCheck) bool
+	// addViolation records a violation of a deferred constraint. The key
+	// contains the values of the constraint columns of the violating row, and
+	// err is the error to return if the violation still exists at commit time.
+	addViolation(check *exec.DeferrableCheck, key tree.Datums, err error)
+	// setMode sets the mode of the given constraints (or of all constraints if
+	// names is empty) for the rest of the transaction.
+	setMode(names tree.NameList, deferred bool)
+	// validate checks the pending violations of the given constraints (or of
+	// all constraints if names is empty), and returns an error if any of them
+	// still exist.
+	validate(ctx context.Context, txn descs.Txn, names tree.NameList) error
+}
+
+// deferredConstraintsState is the state of the DEFERRABLE constraints in a
+// transaction, which is set by SET CONSTRAINTS and by the checks of deferred
+// constraints.
+type deferredConstraintsState struct {
+	// allMode is the mode set by SET CONSTRAINTS ALL, if any.
+	allMode struct {
+		set      bool
+		deferred bool
+	}
+
+	// modes contains the modes set by SET CONSTRAINTS for individual
+	// constraints, keyed by constraint name. They take precedence over allMode.
+	modes map[tree.Name]bool
+
+	// violations contains the pending violations of deferred constraints.
+	violations []*deferredViolations
+}
+
+// deferredViolations contains the pending violations of a deferred constraint.
+type deferredViolations struct {
+	tableID descpb.ID
+	name    string
+
+	// keys contains the constraint key of each violating row, and errs the
+	// corresponding errors.
+	keys []tree.Datums
+	errs []error
+
+	// seen is used to de-duplicate the keys.
+	seen map[string]struct{}
+}
+
+// reset clears the state at the end of a transaction.
+func (s *deferredConstraintsState) reset() {
+	*s = deferredConstraintsState{}
+}
+
+func (s *deferredConstraintsState) isDeferred(check *exec.DeferrableCheck) bool {
+	if deferred, ok := s.modes[tree.Name(check.ConstraintName)]; ok {
+		return deferred
+	}
+	if s.allMode.set {
+		return s.allMode.deferred
+	}
+	return check.InitiallyDeferred
+}
+
+func (s *deferredConstraintsState) addViolation(
+	check *exec.DeferrableCheck, key tree.Datums, err error,
+) {
+	tableID := descpb.ID(check.TableID)
+	var v *deferredViolations
+	for _, other := range s.violations {
+		if other.tableID == tableID && other.name == check.ConstraintName {
+			v = other
+			break
+		}
+	}
+	if v == nil {
+		v = &deferredViolations{
+			tableID: tableID,
+			name:    check.ConstraintName,
+			seen:    make(map[string]struct{}),
+		}
+		s.violations = append(s.violations, v)
+	}
+	keyStr := tree.AsString(&key)
+	if _, ok := v.seen[keyStr]; ok {
+		return
+	}
+	v.seen[keyStr] = struct{}{}
+	v.keys = append(v.keys, key)
+	v.errs = append(v.errs, err)
+}
+
+func (s *deferredConstraintsState) setMode(names tree.NameList, deferred bool) {
+	if len(names) == 0 {
+		s.allMode.set = true
+		s.allMode.deferred = deferred
+		s.modes = nil
+		return
+	}
+	if s.modes == nil {
+		s.modes = make(map[tree.Name]bool)
+	}
+	for _, name := range names {
+		s.modes[name] = deferred
+	}
+}
+
+func (s *deferredConstraintsState) validate(
+	ctx context.Context, txn descs.Txn, names tree.NameList,
+) error {
+	var remaining []*deferredViolations
+	for _, v := range s.violations {
+		if len(names) > 0 && !nameListContains(names, v.name) {
+			remaining = append(remaining, v)
+			continue
+		}
+		// The violations are kept if validation fails, since the transaction
+		// can still continue after ROLLBACK TO SAVEPOINT.
+		if err := v.validate(ctx, txn); err != nil {
+			return err
+		}
+	}
+	s.violations = remaining
+	return nil
+}
+
+func nameListContains(names tree.NameList, name string) bool {
+	for _, n := range names {
+		if string(n) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// validate checks whether any of the recorded violations still exist, and
+// returns the corresponding error if so. Violations can be resolved by later
+// statements in the transaction, for example by inserting the missing
+// referenced row of a foreign key.
+func (v *deferredViolations) validate(ctx context.Context, txn descs.Txn) error {
+	tbl, err := txn.Descriptors().ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(ctx, v.tableID)
+	if err != nil {
+		if errors.Is(err, catalog.ErrDescriptorDropped) {
+			// The table was dropped later in the transaction.
+			return nil
+		}
+		return err
+	}
+	c := catalog.FindConstraintByName(tbl, v.name)
+	if c == nil {
+		// The constraint was dropped later in the transaction.
+		return nil
+	}
+	var query string
+	if fk := c.AsForeignKey(); fk != nil {
+		refTbl, err := txn.Descriptors().ByIDWithLeased(txn.KV()).WithoutNonPublic().Get().Table(
+			ctx, fk.GetReferencedTableID(),
+		)
+		if err != nil {
+			return err
+		}
+		query, err = deferredForeignKeyQuery(tbl, fk, refTbl)
+		if err != nil {
+			return err
+		}
+	} else if uc := c.AsUniqueWithoutIndex(); uc != nil {
+		query, err = deferredUniqueQuery(tbl, uc)
+		if err != nil {
+			return err
+		}
+	} else {
+		return errors.AssertionFailedf("constraint %q cannot be deferred", v.name)
+	}
+
+	log.VEventf(ctx, 2, "validating deferred constraint %q with query %q", v.name, query)
+	for i, key := range v.keys {
+		args := make([]interface{}, len(key))
+		for j := range key {
+			args[j] = key[j]
+		}
+		row, err := txn.QueryRowEx(
+			ctx, "validate deferred constraint", txn.KV(),
+			sessiondata.NodeUserSessionDataOverride, query, args...,
+		)
+		if err != nil {
+			return err
+		}
+		if row != nil {
+			return v.errs[i]
+		}
+	}
+	return nil
+}
+
+// deferredForeignKeyQuery returns a query that returns a row if a row in the
+// origin table with the given key has no match in the referenced table. The
+// query has a placeholder for each FK column.
+func deferredForeignKeyQuery(
+	srcTbl catalog.TableDescriptor, fk catalog.ForeignKeyConstraint, targetTbl catalog.TableDescriptor,
+) (string, error) {
+	originColNames, err := catalog.ColumnNamesForIDs(srcTbl, fk.ForeignKeyDesc().OriginColumnIDs)
+	if err != nil {
+		return "", err
+	}
+	referencedColNames, err := catalog.ColumnNamesForIDs(targetTbl, fk.ForeignKeyDesc().ReferencedColumnIDs)
+	if err != nil {
+		return "", err
+	}
+	srcWhere := make([]string, len(originColNames))
+	on := make([]string, len(originColNames))
+	for i := range originColNames {
+		// s and t are table aliases used in the query.
+		srcWhere[i] = fmt.Sprintf("s.%s = $%d", tree.NameString(originColNames[i]), i+1)
+		on[i] = fmt.Sprintf(
+			"t.%s = s.%s", tree.NameString(referencedColNames[i]), tree.NameString(originColNames[i]),
+		)
+	}
+	return fmt.Sprintf(
+		`SELECT 1 FROM [%[1]d AS s] WHERE %[2]s AND NOT EXISTS (SELECT 1 FROM [%[3]d AS t] WHERE %[4]s) LIMIT 1`,
+		srcTbl.GetID(),                  // 1
+		strings.Join(srcWhere, " AND "), // 2
+		targetTbl.GetID(),               // 3
+		strings.Join(on, " AND "),       // 4
+	), nil
+}
+
+// deferredUniqueQuery returns a query that returns a row if more than one row
+// in the table has the given key. The query has a placeholder for each column
+// in the unique constraint.
+func deferredUniqueQuery(
+	tbl catalog.TableDescriptor, uc catalog.UniqueWithoutIndexConstraint,
+) (string, error) {
+	colNames, err := catalog.ColumnNamesForIDs(tbl, uc.CollectKeyColumnIDs().Ordered())
+	if err != nil {
+		return "", err
+	}
+	where := make([]string, 0, len(colNames)+1)
+	for i := range colNames {
+		where = append(where, fmt.Sprintf("%s = $%d", tree.NameString(colNames[i]), i+1))
+	}
+	if uc.IsPartial() {
+		where = append(where, fmt.Sprintf("(%s)", uc.GetPredicate()))
+	}
+	return fmt.Sprintf(
+		`SELECT 1 FROM [%[1]d AS t] WHERE %[2]s HAVING count(*) > 1`,
+		tbl.GetID(),                  // 1
+		strings.Join(where, " AND "), // 2
+	), nil
+}
+
+type connExDeferredConstraintsAccessor struct {
+	ex *connExecutor
+}
+
+var _ deferredConstraints = connExDeferredConstraintsAccessor{}
+
+func (c connExDeferredConstraintsAccessor) isDeferred(check *exec.DeferrableCheck) bool {
+	return c.ex.extraTxnState.deferredConstraints.isDeferred(check)
+}
+
+func (c connExDeferredConstraintsAccessor) addViolation(
+	check *exec.DeferrableCheck, key tree.Datums, err error,
+) {
+	c.ex.extraTxnState.deferredConstraints.addViolation(check, key, err)
+}
+
+func (c connExDeferredConstraintsAccessor) setMode(names tree.NameList, deferred bool) {
+	c.ex.extraTxnState.deferredConstraints.setMode(names, deferred)
+}
+
+func (c connExDeferredConstraintsAccessor) validate(
+	ctx context.Context, txn descs.Txn, names tree.NameList,
+) error {
+	return c.ex.extraTxnState.deferredConstraints.validate(ctx, txn, names)
+}
+
+// emptyDeferredConstraints is the default impl used by the planner when the
+// connExecutor is not available. All constraints are checked immediately.
+type emptyDeferredConstraints struct{}
+
+var _ deferredConstraints = emptyDeferredConstraints{}
+
+func (emptyDeferredConstraints) isDeferred(check *exec.DeferrableCheck) bool {
+	return false
+}
+
+func (emptyDeferredConstraints) addViolation(
+	check *exec.DeferrableCheck, key tree.Datums, err error,
+) {
+}
+
+func (emptyDeferredConstraints) setMode(names tree.NameList, deferred bool) {}
+
+func (emptyDeferredConstraints) validate(
+	ctx context.Context, txn descs.Txn, names tree.NameList,
+) error {
+	return nil
+}
+
+// SetConstraints sets the mode of DEFERRABLE constraints for the current
+// transaction.
+func (p *planner) SetConstraints(ctx context.Context, n *tree.SetConstraints) (planNode, error) {
+	for _, name := range n.Names {
+		row, err := p.QueryRowEx(
+			ctx, "set-constraints-lookup", sessiondata.NoSessionDataOverride,
+			`SELECT count(*), count(*) FILTER (WHERE NOT condeferrable) FROM pg_catalog.pg_constraint WHERE conname = $1`,
+			string(name),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if tree.MustBeDInt(row[0]) == 0 {
+			return nil, pgerror.Newf(pgcode.UndefinedObject, "constraint %q does not exist", name)
+		}
+		if tree.MustBeDInt(row[1]) > 0 {
+			return nil, pgerror.Newf(pgcode.WrongObjectType, "constraint %q is not deferrable", name)
+		}
+	}
+	if p.extendedEvalCtx.TxnImplicit {
+		p.BufferClientNotice(ctx, pgnotice.NewWithSeverityf(
+			"WARNING", "SET CONSTRAINTS can only be used in transaction blocks",
+		))
+	}
+	return &setConstraintsNode{n: n}, nil
+}
+
+// setConstraintsNode represents a SET CONSTRAINTS statement.
+type setConstraintsNode struct {
+	n *tree.SetConstraints
+}
+
+func (n *setConstraintsNode) startExec(params runParams) error {
+	p := params.p
+	var names tree.NameList
+	if !n.n.All {
+		names = n.n.Names
+	}
+	p.deferredConstraints.setMode(names, n.n.Deferred)
+	if n.n.Deferred {
+		return nil
+	}
+	// Constraints that are switched to IMMEDIATE are checked right away.
+	return p.deferredConstraints.validate(params.ctx, p.InternalSQLTxn(), names)
+}
+
+func (*setConstraintsNode) Next(runParams) (bool, error) { return false, nil }
+func (*setConstraintsNode) Values() tree.Datums          { return nil }
+func (*setConstraintsNode) Close(context.Context)        {}
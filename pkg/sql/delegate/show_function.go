@@ -12,8 +12,11 @@ package delegate
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/errors"
 )
@@ -26,6 +29,7 @@ SELECT function_name, create_statement
 FROM crdb_internal.create_function_statements
 WHERE schema_name = %[1]s
 AND function_name = %[2]s
+AND function_id IN (%[3]s)
 `
 	un, ok := n.Name.FunctionReference.(*tree.UnresolvedName)
 	if !ok {
@@ -38,15 +42,27 @@ AND function_name = %[2]s
 	}
 
 	var udfSchema string
+	var fnIDs []string
 	for _, o := range fn.Overloads {
-		if o.IsUDF {
+		// Only show functions for SHOW CREATE FUNCTION and only show procedures
+		// for SHOW CREATE PROCEDURE.
+		if o.IsUDF && o.IsProcedure == n.IsProcedure {
 			udfSchema = o.Schema
+			fnIDs = append(fnIDs, strconv.Itoa(int(catid.UserDefinedOIDToID(o.Oid))))
 		}
 	}
 	if udfSchema == "" {
+		if n.IsProcedure {
+			return nil, errors.Errorf("procedure %s does not exist", tree.AsString(un))
+		}
 		return nil, errors.Errorf("function %s does not exist", tree.AsString(un))
 	}
 
-	fullQuery := fmt.Sprintf(query, lexbase.EscapeSQLString(udfSchema), lexbase.EscapeSQLString(un.Parts[0]))
+	fullQuery := fmt.Sprintf(
+		query,
+		lexbase.EscapeSQLString(udfSchema),
+		lexbase.EscapeSQLString(un.Parts[0]),
+		strings.Join(fnIDs, ","),
+	)
 	return d.parse(fullQuery)
 }
@@ -247,11 +247,15 @@ SELECT database_name,
 				strings.Join(params, ","),
 			)
 		}
-	} else if n.Targets != nil && len(n.Targets.Functions) > 0 {
+	} else if n.Targets != nil && (len(n.Targets.Functions) > 0 || len(n.Targets.Procedures) > 0) {
 		fmt.Fprint(&source, udfQuery)
 		orderBy = "1,2,3,4,5,6"
 		fnResolved := intsets.MakeFast()
-		for _, fn := range n.Targets.Functions {
+		funcObjs := n.Targets.Functions
+		if len(n.Targets.Procedures) > 0 {
+			funcObjs = n.Targets.Procedures
+		}
+		for _, fn := range funcObjs {
 			un := fn.FuncName.ToUnresolvedObjectName().ToUnresolvedName()
 			fd, err := d.catalog.ResolveFunction(d.ctx, un, &d.evalCtx.SessionData().SearchPath)
 			if err != nil {
@@ -55,6 +55,9 @@ func (n *discardNode) startExec(params runParams) error {
 		// DEALLOCATE ALL
 		params.p.preparedStatements.DeleteAll(params.ctx)
 
+		// UNLISTEN *
+		params.p.listenChannels.unlistenAll()
+
 		// DISCARD SEQUENCES
 		params.p.sessionDataMutatorIterator.applyOnEachMutator(func(m sessionDataMutator) {
 			m.data.SequenceState = sessiondata.NewSequenceState()
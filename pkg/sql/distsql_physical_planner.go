@@ -426,6 +426,10 @@ func newQueryNotSupportedErrorf(format string, args ...interface{}) error {
 // execution.
 var planNodeNotSupportedErr = newQueryNotSupportedError("unsupported node")
 
+var cannotDistributeUserDefinedAggErr = newQueryNotSupportedError(
+	"user-defined aggregates cannot be executed with distsql",
+)
+
 var cannotDistributeRowLevelLockingErr = newQueryNotSupportedError(
 	"scans with row-level locking are not supported by distsql",
 )
@@ -439,6 +443,7 @@ func (dsp *DistSQLPlanner) mustWrapNode(planCtx *PlanningCtx, node planNode) boo
 	case *distinctNode:
 	case *exportNode:
 	case *filterNode:
+	case *foreignScanNode:
 	case *groupNode:
 	case *indexJoinNode:
 	case *invertedFilterNode:
@@ -509,11 +514,23 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		}
 		return checkSupportForPlanNode(n.source.plan)
 
+	case *foreignScanNode:
+		// The file of a foreign table is always read on the gateway, but the
+		// rest of the plan can be distributed.
+		return canDistribute, nil
+
 	case *groupNode:
 		rec, err := checkSupportForPlanNode(n.plan)
 		if err != nil {
 			return cannotDistribute, err
 		}
+		for _, f := range n.funcs {
+			if f.userDefined != nil {
+				// TODO(#74775): distribute user-defined aggregates that have a
+				// combine function.
+				return cannotDistribute, cannotDistributeUserDefinedAggErr
+			}
+		}
 		// Distribute aggregations if possible.
 		return rec.compose(shouldDistribute), nil
 
@@ -705,6 +722,11 @@ func checkSupportForPlanNode(node planNode) (distRecommendation, error) {
 		if err != nil {
 			return cannotDistribute, err
 		}
+		for _, f := range n.funcs {
+			if f.userDefined != nil {
+				return cannotDistribute, cannotDistributeUserDefinedAggErr
+			}
+		}
 		for _, f := range n.funcs {
 			if len(f.partitionIdxs) > 0 {
 				// If at least one function has PARTITION BY clause, then we
@@ -1655,6 +1677,7 @@ func initTableReaderSpecTemplate(
 		TableDescriptorModificationTime: n.desc.GetModificationTime(),
 		LockingStrength:                 n.lockingStrength,
 		LockingWaitPolicy:               n.lockingWaitPolicy,
+		LockingDurability:               n.lockingDurability,
 	}
 	if err := rowenc.InitIndexFetchSpec(&s.FetchSpec, codec, n.desc, n.index, colIDs); err != nil {
 		return nil, execinfrapb.PostProcessSpec{}, err
@@ -2013,11 +2036,20 @@ func (dsp *DistSQLPlanner) addAggregators(
 	aggregations := make([]execinfrapb.AggregatorSpec_Aggregation, len(n.funcs))
 	argumentsColumnTypes := make([][]*types.T, len(n.funcs))
 	for i, fholder := range n.funcs {
-		funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
-		if err != nil {
-			return err
+		if fholder.userDefined != nil {
+			udAgg, err := makeUserDefinedAggregateSpec(ctx, planCtx, fholder.userDefined)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.UserDefined
+			aggregations[i].UserDefined = udAgg
+		} else {
+			funcIdx, err := execinfrapb.GetAggregateFuncIdx(fholder.funcName)
+			if err != nil {
+				return err
+			}
+			aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		}
-		aggregations[i].Func = execinfrapb.AggregatorSpec_Func(funcIdx)
 		aggregations[i].Distinct = fholder.isDistinct
 		for _, renderIdx := range fholder.argRenderIdxs {
 			aggregations[i].ColIdx = append(aggregations[i].ColIdx, uint32(p.PlanToStreamColMap[renderIdx]))
@@ -2038,6 +2070,10 @@ func (dsp *DistSQLPlanner) addAggregators(
 		}
 	}
 
+	if n.groupingSets != nil {
+		return dsp.planGroupingSets(ctx, p, n, aggregations, argumentsColumnTypes)
+	}
+
 	return dsp.planAggregators(ctx, planCtx, p, &aggregatorPlanningInfo{
 		aggregations:         aggregations,
 		argumentsColumnTypes: argumentsColumnTypes,
@@ -2049,6 +2085,110 @@ func (dsp *DistSQLPlanner) addAggregators(
 	})
 }
 
+// makeUserDefinedAggregateSpec builds the specification of a user-defined
+// aggregate. The support routines are only ever available as local
+// expressions; checkSupportForPlanNode ensures that plans with user-defined
+// aggregates are not distributed.
+func makeUserDefinedAggregateSpec(
+	ctx context.Context, planCtx *PlanningCtx, info *exec.UserDefinedAggInfo,
+) (*execinfrapb.AggregatorSpec_UserDefinedAggregate, error) {
+	var spec execinfrapb.AggregatorSpec_UserDefinedAggregate
+	var err error
+	if spec.Transition, err = physicalplan.MakeExpression(ctx, info.Transition, planCtx, nil); err != nil {
+		return nil, err
+	}
+	if info.Final != nil {
+		if spec.Final, err = physicalplan.MakeExpression(ctx, info.Final, planCtx, nil); err != nil {
+			return nil, err
+		}
+	}
+	if spec.InitCond, err = physicalplan.MakeExpression(ctx, info.InitCond, planCtx, nil); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// getAggregationReturnType returns the type of the result of the given
+// aggregation when applied to arguments of the given types.
+func getAggregationReturnType(
+	agg *execinfrapb.AggregatorSpec_Aggregation, argTypes []*types.T,
+) (*types.T, error) {
+	var returnTyp *types.T
+	var err error
+	if agg.Func == execinfrapb.UserDefined {
+		_, returnTyp, err = execagg.GetUserDefinedAggregateInfo(agg.UserDefined)
+	} else {
+		_, returnTyp, err = execagg.GetAggregateInfo(agg.Func, argTypes...)
+	}
+	return returnTyp, err
+}
+
+// planGroupingSets plans a single aggregator that computes the aggregations
+// for all of the grouping sets of the groupNode over one pass of its input.
+// Unlike planAggregators, no local aggregation stage is planned since the
+// partial results of different grouping sets cannot be routed by the grouping
+// columns.
+func (dsp *DistSQLPlanner) planGroupingSets(
+	ctx context.Context,
+	p *PhysicalPlan,
+	n *groupNode,
+	aggregations []execinfrapb.AggregatorSpec_Aggregation,
+	argumentsColumnTypes [][]*types.T,
+) error {
+	inputTypes := p.GetResultTypes()
+	groupCols := make([]uint32, len(n.groupCols))
+	for i, idx := range n.groupCols {
+		groupCols[i] = uint32(p.PlanToStreamColMap[idx])
+	}
+	groupingSets := make([]execinfrapb.AggregatorSpec_GroupingSet, len(n.groupingSets))
+	for i, set := range n.groupingSets {
+		groupingSets[i].Cols = make([]uint32, len(set))
+		for j, idx := range set {
+			groupingSets[i].Cols[j] = uint32(p.PlanToStreamColMap[idx])
+		}
+	}
+
+	// The output contains one column for each aggregation followed by the
+	// ordinal of the grouping set.
+	outTypes := make([]*types.T, len(aggregations)+1)
+	for i, agg := range aggregations {
+		argTypes := make([]*types.T, len(agg.ColIdx)+len(agg.Arguments))
+		for j, c := range agg.ColIdx {
+			argTypes[j] = inputTypes[c]
+		}
+		copy(argTypes[len(agg.ColIdx):], argumentsColumnTypes[i])
+		returnTyp, err := getAggregationReturnType(&agg, argTypes)
+		if err != nil {
+			return err
+		}
+		outTypes[i] = returnTyp
+	}
+	outTypes[len(aggregations)] = types.Int
+
+	spec := execinfrapb.AggregatorSpec{
+		Type:         execinfrapb.AggregatorSpec_NON_SCALAR,
+		Aggregations: aggregations,
+		GroupCols:    groupCols,
+		GroupingSets: groupingSets,
+	}
+
+	// If the previous stage was all on a single node, put the aggregator there.
+	// Otherwise, bring the results back on this node.
+	node := dsp.gatewaySQLInstanceID
+	if len(p.ResultRouters) == 1 {
+		node = p.Processors[p.ResultRouters[0]].SQLInstanceID
+	}
+	p.PlanToStreamColMap = identityMap(p.PlanToStreamColMap, len(outTypes))
+	p.AddSingleGroupStage(
+		ctx,
+		node,
+		execinfrapb.ProcessorCoreUnion{Aggregator: &spec},
+		execinfrapb.PostProcessSpec{},
+		outTypes,
+	)
+	return nil
+}
+
 // planAggregators plans the aggregator processors. An evaluator stage is added
 // if necessary.
 // Invariants assumed:
@@ -2596,8 +2736,7 @@ func (dsp *DistSQLPlanner) planAggregators(
 			argTypes[j] = inputTypes[c]
 		}
 		copy(argTypes[len(agg.ColIdx):], info.argumentsColumnTypes[i])
-		var err error
-		_, returnTyp, err := execagg.GetAggregateInfo(agg.Func, argTypes...)
+		returnTyp, err := getAggregationReturnType(&info.aggregations[i], argTypes)
 		if err != nil {
 			return err
 		}
@@ -2730,6 +2869,7 @@ func (dsp *DistSQLPlanner) createPlanForIndexJoin(
 		Type:              descpb.InnerJoin,
 		LockingStrength:   n.table.lockingStrength,
 		LockingWaitPolicy: n.table.lockingWaitPolicy,
+		LockingDurability: n.table.lockingDurability,
 		MaintainOrdering:  len(n.reqOrdering) > 0,
 		LimitHint:         n.limitHint,
 	}
@@ -2807,6 +2947,7 @@ func (dsp *DistSQLPlanner) createPlanForLookupJoin(
 		Type:              n.joinType,
 		LockingStrength:   n.table.lockingStrength,
 		LockingWaitPolicy: n.table.lockingWaitPolicy,
+		LockingDurability: n.table.lockingDurability,
 		// TODO(sumeer): specifying ordering here using isFirstJoinInPairedJoiner
 		// is late in the sense that the cost of this has not been taken into
 		// account. Make this decision earlier in CustomFuncs.GenerateLookupJoins.
@@ -2939,6 +3080,7 @@ func (dsp *DistSQLPlanner) createPlanForInvertedJoin(
 		OutputGroupContinuationForLeftRow: n.isFirstJoinInPairedJoiner,
 		LockingStrength:                   n.table.lockingStrength,
 		LockingWaitPolicy:                 n.table.lockingWaitPolicy,
+		LockingDurability:                 n.table.lockingDurability,
 	}
 
 	fetchColIDs := make([]descpb.ColumnID, len(n.table.cols))
@@ -3035,6 +3177,7 @@ func (dsp *DistSQLPlanner) createPlanForZigzagJoin(
 			fixedValues:       valuesSpec,
 			lockingStrength:   side.scan.lockingStrength,
 			lockingWaitPolicy: side.scan.lockingWaitPolicy,
+			lockingDurability: side.scan.lockingDurability,
 		}
 	}
 
@@ -3054,6 +3197,7 @@ type zigzagPlanningSide struct {
 	fixedValues       *execinfrapb.ValuesCoreSpec
 	lockingStrength   descpb.ScanLockingStrength
 	lockingWaitPolicy descpb.ScanLockingWaitPolicy
+	lockingDurability descpb.ScanLockingDurability
 }
 
 type zigzagPlanningInfo struct {
@@ -3411,6 +3555,9 @@ func (dsp *DistSQLPlanner) createPhysPlanForPlanNode(
 	case *exportNode:
 		plan, err = dsp.createPlanForExport(ctx, planCtx, n)
 
+	case *foreignScanNode:
+		plan, err = dsp.createPlanForForeignScan(planCtx, n)
+
 	case *filterNode:
 		plan, err = dsp.createPhysPlanForPlanNode(ctx, planCtx, n.source.plan)
 		if err != nil {
@@ -4432,6 +4579,47 @@ func (dsp *DistSQLPlanner) createPlanForExport(
 	return plan, nil
 }
 
+// createPlanForForeignScan creates a physical plan for a scan over a foreign
+// table, which consists of a single ForeignScan processor on the gateway.
+func (dsp *DistSQLPlanner) createPlanForForeignScan(
+	planCtx *PlanningCtx, n *foreignScanNode,
+) (*PhysicalPlan, error) {
+	colIDs := make([]descpb.ColumnID, len(n.cols))
+	resultTypes := make([]*types.T, len(n.cols))
+	for i, col := range n.cols {
+		colIDs[i] = col.GetID()
+		resultTypes[i] = col.GetType()
+	}
+	ft := n.desc.GetForeignTable()
+	spec := &execinfrapb.ForeignScanSpec{
+		Table:         *n.desc.TableDesc(),
+		URI:           ft.Location,
+		Format:        ft.Format,
+		OutputColumns: colIDs,
+		WalltimeNanos: planCtx.EvalContext().GetStmtTimestamp().UnixNano(),
+		// The file is read on behalf of the owner of the foreign table, which
+		// had access to its location when the table was created.
+		UserProto: n.desc.GetPrivileges().Owner().EncodeProto(),
+	}
+
+	p := planCtx.NewPhysicalPlan()
+	pIdx := p.AddProcessor(physicalplan.Processor{
+		SQLInstanceID: dsp.gatewaySQLInstanceID,
+		Spec: execinfrapb.ProcessorSpec{
+			Core: execinfrapb.ProcessorCoreUnion{ForeignScan: spec},
+			Post: execinfrapb.PostProcessSpec{
+				Limit: uint64(n.hardLimit),
+			},
+			Output:      []execinfrapb.OutputRouterSpec{{Type: execinfrapb.OutputRouterSpec_PASS_THROUGH}},
+			ResultTypes: resultTypes,
+		},
+	})
+	p.ResultRouters = []physicalplan.ProcessorIdx{pIdx}
+	p.Distribution = physicalplan.LocalPlan
+	p.PlanToStreamColMap = identityMapInPlace(make([]int, len(resultTypes)))
+	return p, nil
+}
+
 func logAndSanitizeExportDestination(ctx context.Context, dest string) error {
 	clean, err := cloud.SanitizeExternalStorageURI(dest, nil)
 	if err != nil {
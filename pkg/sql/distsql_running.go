@@ -1092,6 +1092,25 @@ func (w *errOnlyResultWriter) SetRowsAffected(ctx context.Context, n int) {
 	panic("SetRowsAffected not supported by errOnlyResultWriter")
 }
 
+// droppingRowsResultWriter is an errOnlyResultWriter that also accepts rows
+// and batches and drops them. It is used for postqueries, which can produce
+// rows that are never returned to the client (for example, cascades that
+// execute triggers).
+type droppingRowsResultWriter struct {
+	errOnlyResultWriter
+}
+
+var _ rowResultWriter = &droppingRowsResultWriter{}
+var _ batchResultWriter = &droppingRowsResultWriter{}
+
+func (w *droppingRowsResultWriter) AddRow(ctx context.Context, row tree.Datums) error {
+	return nil
+}
+
+func (w *droppingRowsResultWriter) AddBatch(ctx context.Context, batch coldata.Batch) error {
+	return nil
+}
+
 // RowResultWriter is a thin wrapper around a RowContainer.
 type RowResultWriter struct {
 	rowContainer *rowContainerHelper
@@ -1961,7 +1980,7 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 		// We place a sequence point before every cascade, so
 		// that each subsequent cascade can observe the writes
 		// by the previous step.
-		if err := planner.Txn().Step(ctx); err != nil {
+		if err := planner.Txn().Step(ctx, false /* allowReadTimestampStep */); err != nil {
 			recv.SetError(err)
 			return false
 		}
@@ -1985,8 +2004,27 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 		cp := cascadePlan.(*planComponents)
 		plan.cascades[i].plan = cp.main
 		if len(cp.subqueryPlans) > 0 {
-			recv.SetError(errors.AssertionFailedf("cascades should not have subqueries"))
-			return false
+			// The only subqueries in cascades are the With expressions that
+			// execute BEFORE statement-level triggers of the mutation in the
+			// cascade. They are not referenced by index, so they can be run
+			// without replacing the subqueries of the planner's current plan.
+			subqueryResultMemAcc := planner.Mon().MakeBoundAccount()
+			ok := dsp.PlanAndRunSubqueries(
+				ctx,
+				planner,
+				func() *extendedEvalContext { return evalCtxFactory(false /* usedConcurrently */) },
+				cp.subqueryPlans,
+				recv,
+				&subqueryResultMemAcc,
+				false, /* skipDistSQLDiagramGeneration */
+				false, /* mustUseLeafTxn */
+			)
+			// The results of the subqueries are not referenced after they have
+			// been run, since the With expressions are read from buffers.
+			subqueryResultMemAcc.Close(ctx)
+			if !ok {
+				return false
+			}
 		}
 
 		// Queue any new cascades.
@@ -2031,7 +2069,7 @@ func (dsp *DistSQLPlanner) PlanAndRunCascadesAndChecks(
 
 	// We place a sequence point before the checks, so that they observe the
 	// writes of the main query and/or any cascades.
-	if err := planner.Txn().Step(ctx); err != nil {
+	if err := planner.Txn().Step(ctx, false /* allowReadTimestampStep */); err != nil {
 		recv.SetError(err)
 		return false
 	}
@@ -2157,7 +2195,7 @@ func (dsp *DistSQLPlanner) planAndRunPostquery(
 	postqueryRecv := recv.clone()
 	defer postqueryRecv.Release()
 	defer addTopLevelQueryStats(&postqueryRecv.stats)
-	postqueryResultWriter := &errOnlyResultWriter{}
+	postqueryResultWriter := &droppingRowsResultWriter{}
 	postqueryRecv.resultWriterMu.row = postqueryResultWriter
 	postqueryRecv.resultWriterMu.batch = postqueryResultWriter
 	finishedSetupFn, cleanup := getFinishedSetupFn(planner)
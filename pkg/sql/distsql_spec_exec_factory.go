@@ -178,6 +178,9 @@ func (e *distSQLSpecExecFactory) ConstructScan(
 			},
 		)
 	}
+	if table.IsForeignTable() {
+		return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: foreign tables")
+	}
 
 	// Although we don't yet recommend distributing plans where soft limits
 	// propagate to scan nodes because we don't have infrastructure to only
@@ -254,8 +257,11 @@ func (e *distSQLSpecExecFactory) ConstructScan(
 	if err := rowenc.InitIndexFetchSpec(&trSpec.FetchSpec, e.planner.ExecCfg().Codec, tabDesc, idx, columnIDs); err != nil {
 		return nil, err
 	}
-	trSpec.LockingStrength = descpb.ToScanLockingStrength(params.Locking.Strength)
+	trSpec.LockingStrength = toScanLockingStrength(e.ctx, e.planner.ExecCfg().Settings, params.Locking.Strength)
 	trSpec.LockingWaitPolicy = descpb.ToScanLockingWaitPolicy(params.Locking.WaitPolicy)
+	trSpec.LockingDurability = toScanLockingDurability(
+		e.ctx, e.planner.ExecCfg().Settings, e.planner.SessionData(), trSpec.LockingStrength,
+	)
 	if trSpec.LockingStrength != descpb.ScanLockingStrength_FOR_NONE {
 		// Scans that are performing row-level locking cannot currently be
 		// distributed because their locks would not be propagated back to
@@ -500,6 +506,11 @@ func (e *distSQLSpecExecFactory) constructAggregators(
 	reqOrdering exec.OutputOrdering,
 	isScalar bool,
 ) (exec.Node, error) {
+	for i := range aggregations {
+		if aggregations[i].UserDefined != nil {
+			return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: user-defined aggregates")
+		}
+	}
 	physPlan, plan := getPhysPlan(input)
 	// planAggregators() itself decides whether to distribute the aggregation.
 	planCtx := e.getPlanCtx(shouldDistribute)
@@ -584,6 +595,15 @@ func (e *distSQLSpecExecFactory) ConstructScalarGroupBy(
 	)
 }
 
+func (e *distSQLSpecExecFactory) ConstructGroupingSets(
+	input exec.Node,
+	groupCols []exec.NodeColumnOrdinal,
+	groupingSets []exec.NodeColumnOrdinalSet,
+	aggregations []exec.AggInfo,
+) (exec.Node, error) {
+	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: grouping sets")
+}
+
 func (e *distSQLSpecExecFactory) ConstructDistinct(
 	input exec.Node,
 	distinctCols, orderedCols exec.NodeColumnOrdinalSet,
@@ -728,14 +748,18 @@ func (e *distSQLSpecExecFactory) constructZigzagJoinSide(
 
 	// TODO (cucaroach): update indexUsageStats.
 
+	lockingStrength := toScanLockingStrength(e.ctx, e.planner.ExecCfg().Settings, locking.Strength)
 	return zigzagPlanningSide{
 		desc:              desc,
 		index:             index.(*optIndex).idx,
 		cols:              cols,
 		eqCols:            eqColOrdinals,
 		fixedValues:       valuesSpec,
-		lockingStrength:   descpb.ToScanLockingStrength(locking.Strength),
+		lockingStrength:   lockingStrength,
 		lockingWaitPolicy: descpb.ToScanLockingWaitPolicy(locking.WaitPolicy),
+		lockingDurability: toScanLockingDurability(
+			e.ctx, e.planner.ExecCfg().Settings, e.planner.SessionData(), lockingStrength,
+		),
 	}, nil
 }
 
@@ -1063,6 +1087,10 @@ func (e *distSQLSpecExecFactory) ConstructCreateFunction(
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: create function")
 }
 
+func (e *distSQLSpecExecFactory) ConstructCall(proc *tree.RoutineExpr) (exec.Node, error) {
+	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: call")
+}
+
 func (e *distSQLSpecExecFactory) ConstructSequenceSelect(sequence cat.Sequence) (exec.Node, error) {
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: sequence select")
 }
@@ -1074,7 +1102,7 @@ func (e *distSQLSpecExecFactory) ConstructSaveTable(
 }
 
 func (e *distSQLSpecExecFactory) ConstructErrorIfRows(
-	input exec.Node, mkErr exec.MkErrFn,
+	input exec.Node, mkErr exec.MkErrFn, deferrable *exec.DeferrableCheck,
 ) (exec.Node, error) {
 	return nil, unimplemented.NewWithIssue(47473, "experimental opt-driven distsql planning: error if rows")
 }
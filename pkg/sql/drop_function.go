@@ -41,10 +41,16 @@ type dropFunctionNode struct {
 func (p *planner) DropFunction(
 	ctx context.Context, n *tree.DropFunction,
 ) (ret planNode, err error) {
+	stmtName := "DROP FUNCTION"
+	if n.IsProcedure {
+		stmtName = "DROP PROCEDURE"
+	} else if n.IsAggregate {
+		stmtName = "DROP AGGREGATE"
+	}
 	if err := checkSchemaChangeEnabled(
 		ctx,
 		p.ExecCfg(),
-		"DROP FUNCTION",
+		stmtName,
 	); err != nil {
 		return nil, err
 	}
@@ -66,6 +72,12 @@ func (p *planner) DropFunction(
 		if ol == nil {
 			continue
 		}
+		if err := checkRoutineKind(ol, &fn, n.IsProcedure); err != nil {
+			return nil, err
+		}
+		if err := checkAggregateKind(ol, &fn, n.IsAggregate, "DROP"); err != nil {
+			return nil, err
+		}
 		fnID := funcdesc.UserDefinedFunctionOIDToID(ol.Oid)
 		if fnResolved.Contains(int(fnID)) {
 			continue
@@ -154,6 +166,39 @@ func (p *planner) matchUDF(
 	return &ol, nil
 }
 
+// checkRoutineKind returns an error if the resolved overload is a function
+// and isProcedure is true, or if it is a procedure and isProcedure is false.
+func checkRoutineKind(ol *tree.QualifiedOverload, fn *tree.FuncObj, isProcedure bool) error {
+	if ol.IsProcedure == isProcedure {
+		return nil
+	}
+	kind := "function"
+	if isProcedure {
+		kind = "procedure"
+	}
+	return pgerror.Newf(pgcode.WrongObjectType, "%s is not a %s", fn.FuncName.Object(), kind)
+}
+
+// checkAggregateKind returns an error if the resolved overload is a
+// user-defined aggregate and isAggregate is false, or if it is not an aggregate
+// and isAggregate is true. stmtVerb is used in the hint of the error, e.g.
+// "DROP".
+func checkAggregateKind(
+	ol *tree.QualifiedOverload, fn *tree.FuncObj, isAggregate bool, stmtVerb string,
+) error {
+	olIsAggregate := ol.Class == tree.AggregateClass
+	if olIsAggregate == isAggregate {
+		return nil
+	}
+	if isAggregate {
+		return pgerror.Newf(pgcode.WrongObjectType, "%s is not an aggregate", fn.FuncName.Object())
+	}
+	return errors.WithHintf(
+		pgerror.Newf(pgcode.WrongObjectType, "%s is an aggregate function", fn.FuncName.Object()),
+		"Use %s AGGREGATE to %s aggregate functions.", stmtVerb, strings.ToLower(stmtVerb),
+	)
+}
+
 func (p *planner) checkPrivilegesForDropFunction(
 	ctx context.Context, fnID descpb.ID,
 ) (*funcdesc.Mutable, error) {
@@ -217,6 +262,13 @@ func (p *planner) dropFunctionImpl(ctx context.Context, fnMutable *funcdesc.Muta
 		}
 	}
 
+	// Remove backreferences from the support functions of an aggregate.
+	if fnMutable.Aggregate != nil {
+		if err := p.removeAggregateSupportFuncReferences(ctx, fnMutable); err != nil {
+			return err
+		}
+	}
+
 	// Remove backreference from types referenced by this UDF.
 	jobDesc := fmt.Sprintf(
 		"updating type backreference %v for function %s(%d)",
@@ -0,0 +1,83 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+type dropPolicyNode struct {
+	n         *tree.DropPolicy
+	tableDesc *tabledesc.Mutable
+	policy    descpb.PolicyDescriptor
+}
+
+// DropPolicy drops a row-level security policy.
+// Privileges: CREATE on table.
+//
+//	notes: postgres requires ownership of the table.
+func (p *planner) DropPolicy(ctx context.Context, n *tree.DropPolicy) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP POLICY",
+	); err != nil {
+		return nil, err
+	}
+
+	_, tableDesc, err := p.ResolveMutableTableDescriptor(
+		ctx, &n.Table, !n.IfExists, tree.ResolveRequireTableDesc,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if tableDesc == nil {
+		return newZeroNode(nil /* columns */), nil
+	}
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+	// Disallow schema changes if this table's schema is locked.
+	if err := checkTableSchemaUnlocked(tableDesc); err != nil {
+		return nil, err
+	}
+	policy := tableDesc.FindPolicyByName(string(n.Name))
+	if policy == nil {
+		if n.IfExists {
+			return newZeroNode(nil /* columns */), nil
+		}
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"policy %q for table %q does not exist", n.Name, tableDesc.Name)
+	}
+	return &dropPolicyNode{n: n, tableDesc: tableDesc, policy: *policy}, nil
+}
+
+func (n *dropPolicyNode) ReadingOwnWrites() {}
+
+func (n *dropPolicyNode) startExec(params runParams) error {
+	n.tableDesc.RemovePolicy(n.policy.ID)
+	return params.p.writeSchemaChange(
+		params.ctx, n.tableDesc, descpb.InvalidMutationID,
+		fmt.Sprintf("dropping policy %q on table %q", n.policy.Name, n.tableDesc.GetName()),
+	)
+}
+
+func (*dropPolicyNode) Next(params runParams) (bool, error) { return false, nil }
+func (*dropPolicyNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*dropPolicyNode) Close(ctx context.Context)           {}
@@ -0,0 +1,84 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+type dropPublicationNode struct {
+	n      *tree.DropPublication
+	dbDesc *dbdesc.Mutable
+	ids    []descpb.PublicationID
+	names  []string
+}
+
+// DropPublication drops publications of the current database.
+// Privileges: ownership of the publications.
+func (p *planner) DropPublication(ctx context.Context, n *tree.DropPublication) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"DROP PUBLICATION",
+	); err != nil {
+		return nil, err
+	}
+
+	dbDesc, err := p.mutablePublicationDatabase(ctx)
+	if err != nil {
+		return nil, err
+	}
+	node := &dropPublicationNode{n: n, dbDesc: dbDesc}
+	for _, name := range n.Names {
+		publication := dbDesc.FindPublicationByName(string(name))
+		if publication == nil {
+			if n.IfExists {
+				continue
+			}
+			return nil, pgerror.Newf(pgcode.UndefinedObject,
+				"publication %q does not exist", name)
+		}
+		if err := p.checkPublicationOwnership(ctx, publication); err != nil {
+			return nil, err
+		}
+		node.ids = append(node.ids, publication.ID)
+		node.names = append(node.names, publication.Name)
+	}
+	if len(node.ids) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return node, nil
+}
+
+func (n *dropPublicationNode) ReadingOwnWrites() {}
+
+func (n *dropPublicationNode) startExec(params runParams) error {
+	for _, id := range n.ids {
+		n.dbDesc.RemovePublication(id)
+	}
+	return params.p.writeNonDropDatabaseChange(
+		params.ctx, n.dbDesc,
+		fmt.Sprintf("dropping publications %s in database %q",
+			strings.Join(n.names, ", "), n.dbDesc.GetName()),
+	)
+}
+
+func (*dropPublicationNode) Next(params runParams) (bool, error) { return false, nil }
+func (*dropPublicationNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*dropPublicationNode) Close(ctx context.Context)           {}
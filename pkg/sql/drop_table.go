@@ -23,6 +23,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -70,6 +72,10 @@ func (p *planner) DropTable(ctx context.Context, n *tree.DropTable) (planNode, e
 		if droppedDesc == nil {
 			continue
 		}
+		if n.IsForeign && !droppedDesc.IsForeignTable() {
+			return nil, pgerror.Newf(pgcode.WrongObjectType,
+				"%q is not a foreign table", tn.Table())
+		}
 
 		td[droppedDesc.ID] = toDelete{tn, droppedDesc}
 	}
@@ -0,0 +1,224 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+	"github.com/cockroachdb/errors"
+)
+
+// textSearchObjectToDrop is a text search object to be dropped from a schema.
+type textSearchObjectToDrop struct {
+	schema *schemadesc.Mutable
+	name   string
+}
+
+type dropTextSearchConfigNode struct {
+	toDrop []textSearchObjectToDrop
+}
+
+// DropTextSearchConfig drops text search configurations.
+// Privileges: ownership of the configurations.
+func (p *planner) DropTextSearchConfig(
+	ctx context.Context, n *tree.DropTextSearchConfig,
+) (planNode, error) {
+	if err := p.checkTextSearchObjectsSupported(ctx, "DROP TEXT SEARCH CONFIGURATION"); err != nil {
+		return nil, err
+	}
+
+	node := &dropTextSearchConfigNode{}
+	for i := range n.Names {
+		schema, err := p.textSearchSchema(ctx, n.Names[i].ToUnresolvedObjectName(), false /* create */)
+		if err != nil {
+			return nil, err
+		}
+		config := schema.FindTextSearchConfigByName(n.Names[i].Object())
+		if config == nil {
+			if n.IfExists {
+				continue
+			}
+			return nil, tsearch.UndefinedConfigError(n.Names[i].String())
+		}
+		if err := p.checkTextSearchObjectOwnership(
+			ctx, "configuration", config.Name, config.OwnerProto,
+		); err != nil {
+			return nil, err
+		}
+		node.toDrop = append(node.toDrop, textSearchObjectToDrop{schema: schema, name: config.Name})
+	}
+	if len(node.toDrop) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return node, nil
+}
+
+func (n *dropTextSearchConfigNode) ReadingOwnWrites() {}
+
+func (n *dropTextSearchConfigNode) startExec(params runParams) error {
+	for _, d := range n.toDrop {
+		d.schema.RemoveTextSearchConfig(d.name)
+	}
+	return writeTextSearchSchemaChanges(params, n.toDrop, "dropping text search configuration")
+}
+
+func (*dropTextSearchConfigNode) Next(params runParams) (bool, error) { return false, nil }
+func (*dropTextSearchConfigNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*dropTextSearchConfigNode) Close(ctx context.Context)           {}
+
+type dropTextSearchDictionaryNode struct {
+	toDrop  []textSearchObjectToDrop
+	cascade bool
+}
+
+// DropTextSearchDictionary drops text search dictionaries. With CASCADE, the
+// dictionaries are also removed from the mappings of the configurations which
+// use them.
+// Privileges: ownership of the dictionaries.
+func (p *planner) DropTextSearchDictionary(
+	ctx context.Context, n *tree.DropTextSearchDictionary,
+) (planNode, error) {
+	if err := p.checkTextSearchObjectsSupported(ctx, "DROP TEXT SEARCH DICTIONARY"); err != nil {
+		return nil, err
+	}
+
+	node := &dropTextSearchDictionaryNode{cascade: n.DropBehavior == tree.DropCascade}
+	for i := range n.Names {
+		schema, err := p.textSearchSchema(ctx, n.Names[i].ToUnresolvedObjectName(), false /* create */)
+		if err != nil {
+			return nil, err
+		}
+		dict := schema.FindTextSearchDictionaryByName(n.Names[i].Object())
+		if dict == nil {
+			if n.IfExists {
+				continue
+			}
+			return nil, tsearch.UndefinedDictionaryError(n.Names[i].String())
+		}
+		if err := p.checkTextSearchObjectOwnership(
+			ctx, "dictionary", dict.Name, dict.OwnerProto,
+		); err != nil {
+			return nil, err
+		}
+		if !node.cascade {
+			if deps := textSearchConfigsUsingDictionary(schema, dict.Name); len(deps) > 0 {
+				return nil, errors.WithHint(
+					pgerror.Newf(pgcode.DependentObjectsStillExist,
+						"cannot drop text search dictionary %q because other objects ([%v]) still depend on it",
+						dict.Name, strings.Join(deps, ", ")),
+					"use DROP ... CASCADE to remove the dictionary from the configurations")
+			}
+		}
+		node.toDrop = append(node.toDrop, textSearchObjectToDrop{schema: schema, name: dict.Name})
+	}
+	if len(node.toDrop) == 0 {
+		return newZeroNode(nil /* columns */), nil
+	}
+	return node, nil
+}
+
+// textSearchConfigsUsingDictionary returns the names of the text search
+// configurations of the schema which use the given dictionary.
+func textSearchConfigsUsingDictionary(schema *schemadesc.Mutable, dict string) []string {
+	var names []string
+	for i := range schema.TextSearchConfigs {
+		config := &schema.TextSearchConfigs[i]
+		if textSearchConfigUsesDictionary(config, dict) {
+			names = append(names, config.Name)
+		}
+	}
+	return names
+}
+
+func textSearchConfigUsesDictionary(config *descpb.TextSearchConfigDescriptor, dict string) bool {
+	for _, m := range config.Mappings {
+		for _, ref := range m.Dictionaries {
+			if !ref.Builtin && ref.Name == dict {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// removeTextSearchDictionaryFromMappings removes the given dictionary from the
+// mappings of the configurations of the schema. Mappings which are left
+// without dictionaries are removed.
+func removeTextSearchDictionaryFromMappings(schema *schemadesc.Mutable, dict string) {
+	for i := range schema.TextSearchConfigs {
+		config := &schema.TextSearchConfigs[i]
+		mappings := config.Mappings[:0]
+		for _, m := range config.Mappings {
+			dicts := m.Dictionaries[:0]
+			for _, ref := range m.Dictionaries {
+				if ref.Builtin || ref.Name != dict {
+					dicts = append(dicts, ref)
+				}
+			}
+			if len(dicts) > 0 {
+				m.Dictionaries = dicts
+				mappings = append(mappings, m)
+			}
+		}
+		config.Mappings = mappings
+	}
+}
+
+func (n *dropTextSearchDictionaryNode) ReadingOwnWrites() {}
+
+func (n *dropTextSearchDictionaryNode) startExec(params runParams) error {
+	for _, d := range n.toDrop {
+		if n.cascade {
+			removeTextSearchDictionaryFromMappings(d.schema, d.name)
+		}
+		d.schema.RemoveTextSearchDictionary(d.name)
+	}
+	return writeTextSearchSchemaChanges(params, n.toDrop, "dropping text search dictionary")
+}
+
+func (*dropTextSearchDictionaryNode) Next(params runParams) (bool, error) { return false, nil }
+func (*dropTextSearchDictionaryNode) Values() tree.Datums                 { return tree.Datums{} }
+func (*dropTextSearchDictionaryNode) Close(ctx context.Context)           {}
+
+// writeTextSearchSchemaChanges writes the schemas from which the given text
+// search objects were dropped, once per schema.
+func writeTextSearchSchemaChanges(
+	params runParams, dropped []textSearchObjectToDrop, op string,
+) error {
+	written := make(map[descpb.ID]struct{}, len(dropped))
+	for _, d := range dropped {
+		if _, ok := written[d.schema.GetID()]; ok {
+			continue
+		}
+		written[d.schema.GetID()] = struct{}{}
+		var names []string
+		for _, other := range dropped {
+			if other.schema.GetID() == d.schema.GetID() {
+				names = append(names, fmt.Sprintf("%q", other.name))
+			}
+		}
+		if err := params.p.writeSchemaDescChange(
+			params.ctx, d.schema,
+			fmt.Sprintf("%s %s in schema %q", op, strings.Join(names, ", "), d.schema.GetName()),
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDurableLocking verifies that the durable_locking session variable
+// controls whether the locking Get, Scan and ReverseScan requests issued by
+// SELECT ... FOR UPDATE/SHARE acquire replicated locks.
+func TestDurableLocking(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	type lockingRead struct {
+		method kvpb.Method
+		str    lock.Strength
+		dur    lock.Durability
+	}
+	var mu struct {
+		syncutil.Mutex
+		prefix roachpb.Key
+		reads  []lockingRead
+	}
+	filter := func(_ context.Context, ba *kvpb.BatchRequest) *kvpb.Error {
+		mu.Lock()
+		defer mu.Unlock()
+		if mu.prefix == nil {
+			return nil
+		}
+		for _, ru := range ba.Requests {
+			var read lockingRead
+			switch req := ru.GetInner().(type) {
+			case *kvpb.GetRequest:
+				read = lockingRead{kvpb.Get, req.KeyLockingStrength(), req.KeyLockingDurability()}
+			case *kvpb.ScanRequest:
+				read = lockingRead{kvpb.Scan, req.KeyLockingStrength(), req.KeyLockingDurability()}
+			case *kvpb.ReverseScanRequest:
+				read = lockingRead{kvpb.ReverseScan, req.KeyLockingStrength(), req.KeyLockingDurability()}
+			default:
+				continue
+			}
+			if read.str != lock.None && bytes.HasPrefix(ru.GetInner().Header().Key, mu.prefix) {
+				mu.reads = append(mu.reads, read)
+			}
+		}
+		return nil
+	}
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{
+		// The request filter matches the keys of the system tenant.
+		DefaultTestTenant: base.TestTenantDisabled,
+		Knobs: base.TestingKnobs{
+			Store: &kvserver.StoreTestingKnobs{TestingRequestFilter: filter},
+		},
+	})
+	defer s.Stopper().Stop(ctx)
+
+	r := sqlutils.MakeSQLRunner(db)
+	r.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v INT)`)
+	r.Exec(t, `INSERT INTO t VALUES (1, 1), (2, 2), (3, 3)`)
+	tableID := sqlutils.QueryTableID(t, db, "defaultdb", "public", "t")
+	mu.Lock()
+	mu.prefix = keys.SystemSQLCodec.TablePrefix(tableID)
+	mu.Unlock()
+
+	for _, tc := range []struct {
+		query  string
+		method kvpb.Method
+		str    lock.Strength
+	}{
+		{`SELECT * FROM t WHERE k = 1 FOR UPDATE`, kvpb.Get, lock.Exclusive},
+		{`SELECT * FROM t WHERE k = 1 FOR NO KEY UPDATE`, kvpb.Get, lock.Exclusive},
+		{`SELECT * FROM t WHERE k = 1 FOR SHARE`, kvpb.Get, lock.Shared},
+		{`SELECT * FROM t FOR UPDATE`, kvpb.Scan, lock.Exclusive},
+		{`SELECT * FROM t FOR SHARE`, kvpb.Scan, lock.Shared},
+		{`SELECT * FROM t ORDER BY k DESC FOR UPDATE`, kvpb.ReverseScan, lock.Exclusive},
+	} {
+		for _, durable := range []bool{false, true} {
+			r.Exec(t, fmt.Sprintf(`SET durable_locking = %t`, durable))
+			mu.Lock()
+			mu.reads = nil
+			mu.Unlock()
+
+			r.Exec(t, tc.query)
+
+			exp := lockingRead{method: tc.method, str: tc.str, dur: lock.Unreplicated}
+			if durable {
+				exp.dur = lock.Replicated
+			}
+			mu.Lock()
+			reads := mu.reads
+			mu.Unlock()
+			require.NotEmpty(t, reads, "%s: durable_locking=%t", tc.query, durable)
+			for _, read := range reads {
+				require.Equal(t, exp, read, "%s: durable_locking=%t", tc.query, durable)
+			}
+		}
+	}
+}
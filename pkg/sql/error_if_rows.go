@@ -26,6 +26,9 @@ type errorIfRowsNode struct {
 	// produced.
 	mkErr exec.MkErrFn
 
+	// deferrable is set if the check is for a DEFERRABLE constraint.
+	deferrable *exec.DeferrableCheck
+
 	nexted bool
 }
 
@@ -39,6 +42,10 @@ func (n *errorIfRowsNode) Next(params runParams) (bool, error) {
 	}
 	n.nexted = true
 
+	if n.deferrable != nil && params.p.deferredConstraints.isDeferred(n.deferrable) {
+		return false, n.deferViolations(params)
+	}
+
 	ok, err := n.plan.Next(params)
 	if err != nil {
 		return false, err
@@ -49,6 +56,29 @@ func (n *errorIfRowsNode) Next(params runParams) (bool, error) {
 	return false, nil
 }
 
+// deferViolations records all the rows produced by the wrapped node as
+// violations of a deferred constraint, to be checked again when the
+// transaction commits.
+func (n *errorIfRowsNode) deferViolations(params runParams) error {
+	for {
+		ok, err := n.plan.Next(params)
+		if err != nil || !ok {
+			return err
+		}
+		row := n.plan.Values()
+		key := make(tree.Datums, len(n.deferrable.KeyCols))
+		for i, ord := range n.deferrable.KeyCols {
+			if row[ord] == tree.DNull {
+				// Keys with NULLs are MATCH FULL violations, which cannot be
+				// resolved by later statements.
+				return n.mkErr(row)
+			}
+			key[i] = row[ord]
+		}
+		params.p.deferredConstraints.addViolation(n.deferrable, key, n.mkErr(row))
+	}
+}
+
 func (n *errorIfRowsNode) Values() tree.Datums {
 	return nil
 }
@@ -0,0 +1,69 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package evalcatalog
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemadesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
+)
+
+// ResolveTextSearchConfig is part of the eval.CatalogBuiltins interface.
+func (b *Builtins) ResolveTextSearchConfig(
+	ctx context.Context, name *tree.TableName,
+) (*tsearch.Config, error) {
+	schema, err := b.textSearchSchema(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		return nil, tsearch.UndefinedConfigError(name.FQString())
+	}
+	desc := schema.FindTextSearchConfigByName(name.Object())
+	if desc == nil {
+		return nil, tsearch.UndefinedConfigError(name.FQString())
+	}
+	return schemadesc.NewTextSearchConfig(schema, name.FQString(), desc)
+}
+
+// ResolveTextSearchDictionary is part of the eval.CatalogBuiltins interface.
+func (b *Builtins) ResolveTextSearchDictionary(
+	ctx context.Context, name *tree.TableName,
+) (*tsearch.Dictionary, error) {
+	schema, err := b.textSearchSchema(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if schema == nil {
+		return nil, tsearch.UndefinedDictionaryError(name.FQString())
+	}
+	desc := schema.FindTextSearchDictionaryByName(name.Object())
+	if desc == nil {
+		return nil, tsearch.UndefinedDictionaryError(name.FQString())
+	}
+	return schemadesc.NewTextSearchDictionary(desc)
+}
+
+// textSearchSchema returns the schema in which the text search object with
+// the given fully-qualified name is defined, or nil if the database or the
+// schema do not exist.
+func (b *Builtins) textSearchSchema(
+	ctx context.Context, name *tree.TableName,
+) (catalog.SchemaDescriptor, error) {
+	db, err := b.dc.ByNameWithLeased(b.txn).MaybeGet().Database(ctx, name.Catalog())
+	if err != nil || db == nil {
+		return nil, err
+	}
+	return b.dc.ByNameWithLeased(b.txn).MaybeGet().Schema(ctx, db, name.Schema())
+}
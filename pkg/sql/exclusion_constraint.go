@@ -0,0 +1,117 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// makeExclusionConstraintIndexDescriptor builds the descriptor of the index
+// that backs the given exclusion constraint. The index is used by mutations to
+// efficiently find rows that conflict with new rows.
+//
+// If the constraint was defined with USING gist, the last column is compared
+// with &&, all other columns are compared with =, and the type of the last
+// column can be inverted indexed, an inverted index is built. Otherwise a
+// forward index is built.
+func makeExclusionConstraintIndexDescriptor(
+	ctx context.Context,
+	st *cluster.Settings,
+	desc *tabledesc.Mutable,
+	d *tree.ExclusionConstraintTableDef,
+) (descpb.IndexDescriptor, error) {
+	// Nodes running an older binary would ignore the exclusion operators of the
+	// index and treat it as a regular index.
+	if !st.Version.IsActive(ctx, clusterversion.V23_2_ExclusionConstraints) {
+		return descpb.IndexDescriptor{}, pgerror.New(pgcode.FeatureNotSupported,
+			"exclusion constraints are not supported until the cluster version is finalized")
+	}
+	if desc.PartitionAllBy || desc.IsLocalityRegionalByRow() {
+		return descpb.IndexDescriptor{}, pgerror.New(
+			pgcode.FeatureNotSupported,
+			"exclusion constraints are not supported on tables that are implicitly partitioned with PARTITION ALL BY or LOCALITY REGIONAL BY ROW definition",
+		)
+	}
+
+	columns := d.Elems.IndexElems()
+	if err := validateColumnsAreAccessible(desc, columns); err != nil {
+		return descpb.IndexDescriptor{}, err
+	}
+	if err := checkIndexColumns(desc, columns, nil /* storing */, d.Inverted); err != nil {
+		return descpb.IndexDescriptor{}, err
+	}
+
+	ops := make([]semenumpb.ExclusionOperator, len(d.Elems))
+	colTypes := make([]*types.T, len(d.Elems))
+	for i, elem := range d.Elems {
+		col, err := catalog.MustFindColumnByTreeName(desc, elem.Column)
+		if err != nil {
+			return descpb.IndexDescriptor{}, err
+		}
+		typ := col.GetType()
+		cmpOp := elem.Operator.ComparisonOperator()
+		if _, ok := tree.CmpOps[cmpOp.Symbol].LookupImpl(typ, typ); !ok {
+			return descpb.IndexDescriptor{}, pgerror.Newf(
+				pgcode.UndefinedFunction,
+				"operator %s is not defined for column %q of type %s",
+				cmpOp, col.GetName(), typ.SQLString(),
+			)
+		}
+		ops[i] = semenumpb.ExclusionOperator(elem.Operator)
+		colTypes[i] = typ
+	}
+
+	inverted := d.Inverted
+	for i, elem := range d.Elems {
+		if i == len(d.Elems)-1 {
+			inverted = inverted && elem.Operator == tree.ExcludeOverlaps &&
+				colinfo.ColumnTypeIsInvertedIndexable(colTypes[i])
+		} else {
+			inverted = inverted && elem.Operator == tree.ExcludeEquals
+		}
+	}
+
+	idx := descpb.IndexDescriptor{
+		Name:               string(d.Name),
+		Version:            descpb.StrictIndexColumnIDGuaranteesVersion,
+		ExclusionOperators: ops,
+	}
+	if inverted {
+		idx.Type = descpb.IndexDescriptor_INVERTED
+	}
+	if err := idx.FillColumns(columns); err != nil {
+		return descpb.IndexDescriptor{}, err
+	}
+	if inverted {
+		column, err := catalog.MustFindColumnByName(desc, idx.InvertedColumnName())
+		if err != nil {
+			return descpb.IndexDescriptor{}, err
+		}
+		if err := populateInvertedIndexDescriptor(
+			ctx, st, column, &idx, columns[len(columns)-1],
+		); err != nil {
+			return descpb.IndexDescriptor{}, err
+		}
+	}
+	return idx, nil
+}
@@ -77,6 +77,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/optionalnodeliveness"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -735,6 +736,14 @@ var overrideAlterPrimaryRegionInSuperRegion = settings.RegisterBoolSetting(
 	false,
 ).WithPublic()
 
+var allowReadCommittedIsolation = settings.RegisterBoolSetting(
+	settings.TenantWritable,
+	"sql.txn.read_committed_isolation.enabled",
+	"set to true to allow transactions to use the READ COMMITTED isolation "+
+		"level if specified by BEGIN/SET commands",
+	false,
+).WithPublic()
+
 var errNoTransactionInProgress = errors.New("there is no transaction in progress")
 var errTransactionInProgress = errors.New("there is already a transaction in progress")
 
@@ -1325,6 +1334,10 @@ type ExecutorConfig struct {
 
 	RangeFeedFactory *rangefeed.Factory
 
+	// NotificationRegistry delivers the notifications published with NOTIFY
+	// to the sessions that LISTEN on their channel.
+	NotificationRegistry *pgnotify.Registry
+
 	// VersionUpgradeHook is called after validating a `SET CLUSTER SETTING
 	// version` but before executing it. It can carry out arbitrary upgrades
 	// that allow us to eventually remove legacy code.
@@ -2140,6 +2153,10 @@ type SessionArgs struct {
 	// JWTAuthEnabled indicates if the customer is passing a JWT token in the
 	// password field.
 	JWTAuthEnabled bool
+	// LogicalReplication indicates that the connection uses the streaming
+	// replication protocol, in which the client can issue replication commands
+	// as well as SQL statements.
+	LogicalReplication bool
 }
 
 // SessionRegistry stores a set of all sessions on this node.
@@ -3084,6 +3101,10 @@ func (m *sessionDataMutator) SetDefaultTransactionPriority(val tree.UserPriority
 	m.data.DefaultTxnPriority = int64(val)
 }
 
+func (m *sessionDataMutator) SetDefaultTransactionIsolationLevel(val tree.IsolationLevel) {
+	m.data.DefaultTxnIsolationLevel = int64(val)
+}
+
 func (m *sessionDataMutator) SetDefaultTransactionReadOnly(val bool) {
 	m.data.DefaultTxnReadOnly = val
 }
@@ -3546,6 +3567,18 @@ func (m *sessionDataMutator) SetOptimizerUseImprovedComputedColumnFiltersDerivat
 	m.data.OptimizerUseImprovedComputedColumnFiltersDerivation = val
 }
 
+func (m *sessionDataMutator) SetDurableLocking(val bool) {
+	m.data.DurableLocking = val
+}
+
+func (m *sessionDataMutator) SetMaxRetriesForReadCommitted(val int32) {
+	m.data.MaxRetriesForReadCommitted = val
+}
+
+func (m *sessionDataMutator) SetPlanCacheMode(val sessiondatapb.PlanCacheMode) {
+	m.data.PlanCacheMode = val
+}
+
 func (m *sessionDataMutator) SetEnableCreateStatsUsingExtremes(val bool) {
 	m.data.EnableCreateStatsUsingExtremes = val
 }
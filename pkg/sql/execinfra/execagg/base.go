@@ -106,6 +106,10 @@ func GetAggregateConstructor(
 		argTypes[len(aggInfo.ColIdx)+j] = d.ResolvedType()
 		arguments[j] = d
 	}
+	if aggInfo.Func == execinfrapb.UserDefined {
+		constructor, outputType, err = GetUserDefinedAggregateInfo(aggInfo.UserDefined)
+		return
+	}
 	constructor, outputType, err = GetAggregateInfo(aggInfo.Func, argTypes...)
 	return
 }
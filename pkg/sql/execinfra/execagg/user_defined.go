@@ -0,0 +1,170 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package execagg
+
+import (
+	"context"
+	"unsafe"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// GetUserDefinedAggregateInfo returns the aggregate constructor and the return
+// type for the given user-defined aggregate. The support routines of the
+// aggregate must be present as local expressions, which is always the case
+// since user-defined aggregates are never distributed.
+func GetUserDefinedAggregateInfo(
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregate,
+) (aggregateConstructor AggregateConstructor, returnType *types.T, err error) {
+	if spec == nil {
+		return nil, nil, errors.AssertionFailedf("missing user-defined aggregate specification")
+	}
+	transition, ok := spec.Transition.LocalExpr.(*tree.RoutineExpr)
+	if !ok {
+		return nil, nil, errors.AssertionFailedf(
+			"user-defined aggregate requires a local transition routine, found %T", spec.Transition.LocalExpr,
+		)
+	}
+	var final *tree.RoutineExpr
+	if spec.Final.LocalExpr != nil {
+		if final, ok = spec.Final.LocalExpr.(*tree.RoutineExpr); !ok {
+			return nil, nil, errors.AssertionFailedf(
+				"user-defined aggregate requires a local final routine, found %T", spec.Final.LocalExpr,
+			)
+		}
+	}
+	initCond := tree.DNull
+	if spec.InitCond.LocalExpr != nil {
+		if initCond, ok = spec.InitCond.LocalExpr.(tree.Datum); !ok {
+			return nil, nil, errors.AssertionFailedf(
+				"user-defined aggregate requires a constant initial condition, found %T", spec.InitCond.LocalExpr,
+			)
+		}
+	}
+	returnType = transition.ResolvedType()
+	if final != nil {
+		returnType = final.ResolvedType()
+	}
+	constructAgg := func(evalCtx *eval.Context, _ tree.Datums) eval.AggregateFunc {
+		return newUserDefinedAggregate(evalCtx, transition, final, initCond)
+	}
+	return constructAgg, returnType, nil
+}
+
+// GetUserDefinedWindowFunctionInfo returns the window function constructor
+// and the return type for the given user-defined aggregate used as a window
+// function.
+func GetUserDefinedWindowFunctionInfo(
+	spec *execinfrapb.AggregatorSpec_UserDefinedAggregate,
+) (windowConstructor func(*eval.Context) eval.WindowFunc, returnType *types.T, err error) {
+	constructAgg, returnType, err := GetUserDefinedAggregateInfo(spec)
+	if err != nil {
+		return nil, nil, err
+	}
+	return builtins.NewAggregateWindowFunc(constructAgg), returnType, nil
+}
+
+// userDefinedAggregate implements eval.AggregateFunc by invoking the support
+// routines of a user-defined aggregate. The state is threaded through the
+// transition routine for every input row, and the final routine (if any) is
+// applied to the state when the result is requested.
+type userDefinedAggregate struct {
+	evalCtx    *eval.Context
+	transition *tree.RoutineExpr
+	final      *tree.RoutineExpr
+	initCond   tree.Datum
+
+	// ctx is the context of the most recent call to Add or Reset. It is
+	// needed because Result does not take a context, yet evaluating the final
+	// routine requires one.
+	ctx   context.Context
+	state tree.Datum
+	// args is reused across calls to the transition routine.
+	args tree.Datums
+}
+
+var _ eval.AggregateFunc = &userDefinedAggregate{}
+
+const sizeOfUserDefinedAggregate = int64(unsafe.Sizeof(userDefinedAggregate{}))
+
+func newUserDefinedAggregate(
+	evalCtx *eval.Context, transition, final *tree.RoutineExpr, initCond tree.Datum,
+) *userDefinedAggregate {
+	return &userDefinedAggregate{
+		evalCtx:    evalCtx,
+		transition: transition,
+		final:      final,
+		initCond:   initCond,
+		ctx:        context.Background(),
+		state:      initCond,
+	}
+}
+
+// Add is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Add(
+	ctx context.Context, firstArg tree.Datum, otherArgs ...tree.Datum,
+) error {
+	a.ctx = ctx
+	if !a.transition.CalledOnNullInput {
+		// Rows with NULL inputs are ignored by strict transition functions.
+		if firstArg == tree.DNull {
+			return nil
+		}
+		for _, arg := range otherArgs {
+			if arg == tree.DNull {
+				return nil
+			}
+		}
+		// If the state is NULL, the first non-NULL input becomes the state
+		// without calling the transition function. This mirrors Postgres and
+		// makes it possible to write, e.g., max-like aggregates without an
+		// initial condition.
+		if a.state == tree.DNull {
+			a.state = firstArg
+			return nil
+		}
+	}
+	a.args = append(a.args[:0], a.state, firstArg)
+	a.args = append(a.args, otherArgs...)
+	state, err := a.evalCtx.Planner.EvalRoutineExpr(ctx, a.transition, a.args)
+	if err != nil {
+		return err
+	}
+	a.state = state
+	return nil
+}
+
+// Result is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Result() (tree.Datum, error) {
+	if a.final == nil {
+		return a.state, nil
+	}
+	return a.evalCtx.Planner.EvalRoutineExpr(a.ctx, a.final, tree.Datums{a.state})
+}
+
+// Reset is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Reset(ctx context.Context) {
+	a.ctx = ctx
+	a.state = a.initCond
+}
+
+// Close is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Close(context.Context) {}
+
+// Size is part of the eval.AggregateFunc interface.
+func (a *userDefinedAggregate) Size() int64 {
+	return sizeOfUserDefinedAggregate
+}
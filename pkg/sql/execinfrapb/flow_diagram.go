@@ -105,6 +105,13 @@ func (a *AggregatorSpec) summary() (string, []string) {
 	if len(a.OrderedGroupCols) > 0 {
 		details = append(details, fmt.Sprintf("Ordered: %s", colListStr(a.OrderedGroupCols)))
 	}
+	if len(a.GroupingSets) > 0 {
+		sets := make([]string, len(a.GroupingSets))
+		for i := range a.GroupingSets {
+			sets[i] = fmt.Sprintf("(%s)", colListStr(a.GroupingSets[i].Cols))
+		}
+		details = append(details, fmt.Sprintf("Grouping sets: %s", strings.Join(sets, ", ")))
+	}
 	for _, agg := range a.Aggregations {
 		var buf bytes.Buffer
 		buf.WriteString(agg.Func.String())
@@ -520,6 +527,11 @@ func (c *ReadImportDataSpec) summary() (string, []string) {
 	return "ReadImportData", ss
 }
 
+// summary implements the diagramCellType interface.
+func (c *ForeignScanSpec) summary() (string, []string) {
+	return "ForeignScan", []string{c.Table.Name, c.URI}
+}
+
 // summary implements the diagramCellType interface.
 func (s *StreamIngestionDataSpec) summary() (string, []string) {
 	return "StreamIngestionData", []string{}
@@ -59,6 +59,11 @@ func (a AggregatorSpec_Aggregation) Equals(b AggregatorSpec_Aggregation) bool {
 			return false
 		}
 	}
+	if a.UserDefined != nil || b.UserDefined != nil {
+		// User-defined aggregates are compared by identity since their
+		// support routines cannot be compared structurally.
+		return a.UserDefined == b.UserDefined
+	}
 	return true
 }
 
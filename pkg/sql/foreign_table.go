@@ -0,0 +1,361 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/cloud"
+	"github.com/cockroachdb/cockroach/pkg/cloud/cloudpb"
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/exprutil"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+)
+
+// The options of CREATE FOREIGN TABLE. Apart from location and format, they
+// match the IMPORT options of the same name.
+const (
+	foreignTableOptionLocation         = "location"
+	foreignTableOptionFormat           = "format"
+	foreignTableOptionDecompress       = "decompress"
+	foreignTableOptionDelimiter        = "delimiter"
+	foreignTableOptionComment          = "comment"
+	foreignTableOptionNullIf           = "nullif"
+	foreignTableOptionSkip             = "skip"
+	foreignTableOptionStrictValidation = "strict_validation"
+
+	foreignTableFormatCSV  = "csv"
+	foreignTableFormatAvro = "avro"
+)
+
+var foreignTableOptionExpectValues = map[string]exprutil.KVStringOptValidate{
+	foreignTableOptionLocation:         exprutil.KVStringOptRequireValue,
+	foreignTableOptionFormat:           exprutil.KVStringOptRequireValue,
+	foreignTableOptionDecompress:       exprutil.KVStringOptRequireValue,
+	foreignTableOptionDelimiter:        exprutil.KVStringOptRequireValue,
+	foreignTableOptionComment:          exprutil.KVStringOptRequireValue,
+	foreignTableOptionNullIf:           exprutil.KVStringOptRequireValue,
+	foreignTableOptionSkip:             exprutil.KVStringOptRequireValue,
+	foreignTableOptionStrictValidation: exprutil.KVStringOptRequireNoValue,
+}
+
+// foreignTableCSVOptions and foreignTableAvroOptions are the options that are
+// only valid for the respective formats.
+var (
+	foreignTableCSVOptions = []string{
+		foreignTableOptionDelimiter, foreignTableOptionComment, foreignTableOptionNullIf,
+		foreignTableOptionSkip,
+	}
+	foreignTableAvroOptions = []string{foreignTableOptionStrictValidation}
+)
+
+// makeForeignTableDescriptor validates the definition of a CREATE FOREIGN
+// TABLE statement and evaluates its options. Foreign tables only support plain
+// columns; the primary key is always the hidden row ID column, whose values
+// are generated from the position of each row in the file.
+func (p *planner) makeForeignTableDescriptor(
+	ctx context.Context, n *tree.CreateTable,
+) (*descpb.ForeignTableDescriptor, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_ForeignTables) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"CREATE FOREIGN TABLE is not supported until the cluster version is finalized")
+	}
+	if err := checkForeignTableDefs(n.Defs); err != nil {
+		return nil, err
+	}
+
+	opts, err := p.ExprEvaluator("CREATE FOREIGN TABLE").KVOptions(
+		ctx, n.ForeignOptions, foreignTableOptionExpectValues,
+	)
+	if err != nil {
+		return nil, err
+	}
+	ft := &descpb.ForeignTableDescriptor{Location: opts[foreignTableOptionLocation]}
+	if ft.Location == "" {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"option %q is required", foreignTableOptionLocation)
+	}
+	if err := p.checkForeignTableLocationPrivileges(ctx, ft.Location); err != nil {
+		return nil, err
+	}
+
+	format := foreignTableFormatCSV
+	if override, ok := opts[foreignTableOptionFormat]; ok {
+		format = strings.ToLower(override)
+	}
+	var invalidOpts []string
+	switch format {
+	case foreignTableFormatCSV:
+		ft.Format.Format = roachpb.IOFileFormat_CSV
+		invalidOpts = foreignTableAvroOptions
+		if override, ok := opts[foreignTableOptionDelimiter]; ok {
+			if ft.Format.Csv.Comma, err = util.GetSingleRune(override); err != nil {
+				return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid delimiter value")
+			}
+		}
+		if override, ok := opts[foreignTableOptionComment]; ok {
+			if ft.Format.Csv.Comment, err = util.GetSingleRune(override); err != nil {
+				return nil, pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid comment value")
+			}
+		}
+		if override, ok := opts[foreignTableOptionNullIf]; ok {
+			ft.Format.Csv.NullEncoding = &override
+		}
+		if override, ok := opts[foreignTableOptionSkip]; ok {
+			skip, err := strconv.Atoi(override)
+			if err != nil {
+				return nil, pgerror.Wrapf(err, pgcode.InvalidParameterValue,
+					"invalid %s value", foreignTableOptionSkip)
+			}
+			if skip < 0 {
+				return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+					"%s must be >= 0", foreignTableOptionSkip)
+			}
+			ft.Format.Csv.Skip = uint32(skip)
+		}
+	case foreignTableFormatAvro:
+		ft.Format.Format = roachpb.IOFileFormat_Avro
+		invalidOpts = foreignTableCSVOptions
+		_, ft.Format.Avro.StrictMode = opts[foreignTableOptionStrictValidation]
+	default:
+		return nil, unimplemented.Newf("foreign_table.format",
+			"foreign tables do not support the %q format", format)
+	}
+	for _, opt := range invalidOpts {
+		if _, ok := opts[opt]; ok {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"option %q is not supported for the %s format", opt, format)
+		}
+	}
+
+	if override, ok := opts[foreignTableOptionDecompress]; ok {
+		found := false
+		for name, value := range roachpb.IOFileFormat_Compression_value {
+			if strings.EqualFold(name, override) {
+				ft.Format.Compression = roachpb.IOFileFormat_Compression(value)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unsupported compression value: %q", override)
+		}
+	}
+	return ft, nil
+}
+
+// foreignTableOptions returns the options of a CREATE FOREIGN TABLE statement
+// which creates a foreign table with the given descriptor. Options with their
+// default value are omitted.
+func foreignTableOptions(ft *descpb.ForeignTableDescriptor) tree.KVOptions {
+	opts := tree.KVOptions{{
+		Key:   foreignTableOptionLocation,
+		Value: tree.NewStrVal(ft.Location),
+	}}
+	addOpt := func(key, value string) {
+		opts = append(opts, tree.KVOption{Key: tree.Name(key), Value: tree.NewStrVal(value)})
+	}
+	switch ft.Format.Format {
+	case roachpb.IOFileFormat_CSV:
+		addOpt(foreignTableOptionFormat, foreignTableFormatCSV)
+		csvOpts := &ft.Format.Csv
+		if csvOpts.Comma != 0 {
+			addOpt(foreignTableOptionDelimiter, string(csvOpts.Comma))
+		}
+		if csvOpts.Comment != 0 {
+			addOpt(foreignTableOptionComment, string(csvOpts.Comment))
+		}
+		if csvOpts.NullEncoding != nil {
+			addOpt(foreignTableOptionNullIf, *csvOpts.NullEncoding)
+		}
+		if csvOpts.Skip != 0 {
+			addOpt(foreignTableOptionSkip, strconv.Itoa(int(csvOpts.Skip)))
+		}
+	case roachpb.IOFileFormat_Avro:
+		addOpt(foreignTableOptionFormat, foreignTableFormatAvro)
+		if ft.Format.Avro.StrictMode {
+			opts = append(opts, tree.KVOption{Key: foreignTableOptionStrictValidation})
+		}
+	}
+	if ft.Format.Compression != roachpb.IOFileFormat_Auto {
+		addOpt(foreignTableOptionDecompress, strings.ToLower(ft.Format.Compression.String()))
+	}
+	return opts
+}
+
+// checkForeignTableDefs returns an error if the given table definitions
+// contain anything but plain columns, which are the only definitions supported
+// by foreign tables.
+func checkForeignTableDefs(defs tree.TableDefs) error {
+	for _, def := range defs {
+		d, ok := def.(*tree.ColumnTableDef)
+		if !ok {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"foreign tables do not support constraints or indexes")
+		}
+		var unsupported string
+		switch {
+		case d.PrimaryKey.IsPrimaryKey:
+			unsupported = "PRIMARY KEY constraints"
+		case d.Unique.IsUnique:
+			unsupported = "UNIQUE constraints"
+		case len(d.CheckExprs) > 0:
+			unsupported = "CHECK constraints"
+		case d.References.Table != nil:
+			unsupported = "foreign key constraints"
+		case d.HasDefaultExpr() || d.HasOnUpdateExpr():
+			unsupported = "default expressions"
+		case d.IsComputed():
+			unsupported = "computed columns"
+		case d.IsSerial || d.GeneratedIdentity.IsGeneratedAsIdentity:
+			unsupported = "serial or identity columns"
+		case d.HasColumnFamily():
+			unsupported = "column families"
+		}
+		if unsupported != "" {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"foreign tables do not support %s", unsupported)
+		}
+	}
+	return nil
+}
+
+// checkForeignTableColumns returns an error if any of the columns of the given
+// foreign table has a type which cannot be read from a file.
+func checkForeignTableColumns(desc catalog.TableDescriptor) error {
+	for _, col := range desc.PublicColumns() {
+		if col.GetType().UserDefined() {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"foreign tables do not support columns of user-defined type %s",
+				col.GetType().SQLString())
+		}
+	}
+	return nil
+}
+
+// checkForeignTableLocationPrivileges checks that the current user may access
+// the given location. The rows of the foreign table are later read on behalf
+// of the table owner.
+//
+// Like the checks for EXPORT, this duplicates
+// cloudprivilege.CheckDestinationPrivileges, which cannot be used from this
+// package because of a circular dependency.
+func (p *planner) checkForeignTableLocationPrivileges(ctx context.Context, location string) error {
+	admin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if admin {
+		return nil
+	}
+	conf, err := cloud.ExternalStorageConfFromURI(location, p.User())
+	if err != nil {
+		return err
+	}
+	hasExternalIOImplicitAccess := p.CheckPrivilege(
+		ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.EXTERNALIOIMPLICITACCESS,
+	) == nil
+	if !conf.AccessIsWithExplicitAuth() &&
+		!p.ExecCfg().ExternalIODirConfig.EnableNonAdminImplicitAndArbitraryOutbound &&
+		!hasExternalIOImplicitAccess {
+		return pgerror.Newf(
+			pgcode.InsufficientPrivilege,
+			"only users with the admin role or the EXTERNALIOIMPLICITACCESS system privilege "+
+				"are allowed to access the specified %s URI", conf.Provider.String())
+	}
+	if conf.Provider == cloudpb.ExternalStorageProvider_external {
+		ecPrivilege := &syntheticprivilege.ExternalConnectionPrivilege{
+			ConnectionName: conf.ExternalConnectionConfig.Name,
+		}
+		if err := p.CheckPrivilege(ctx, ecPrivilege, privilege.USAGE); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// foreignScanNode reads the rows of a foreign table from its file in external
+// storage. Like exportNode, it cannot be run in local mode; it is always
+// planned as a ForeignScan processor on the gateway.
+type foreignScanNode struct {
+	desc catalog.TableDescriptor
+	cols []catalog.Column
+	// There is a 1-1 correspondence between cols and resultColumns.
+	resultColumns colinfo.ResultColumns
+
+	// hardLimit, if non-zero, is the maximum number of rows to read.
+	hardLimit int64
+
+	reqOrdering ReqOrdering
+}
+
+func (n *foreignScanNode) startExec(params runParams) error {
+	panic("foreignScanNode cannot be run in local mode")
+}
+
+func (n *foreignScanNode) Next(params runParams) (bool, error) {
+	panic("foreignScanNode cannot be run in local mode")
+}
+
+func (n *foreignScanNode) Values() tree.Datums {
+	panic("foreignScanNode cannot be run in local mode")
+}
+
+func (n *foreignScanNode) Close(context.Context) {}
+
+// constructForeignScan builds a foreignScanNode for a scan over the primary
+// index of a foreign table. The optimizer never generates constrained or
+// reverse scans over foreign tables, but they can still be requested with
+// index hints.
+func (ef *execFactory) constructForeignScan(
+	table cat.Table, params exec.ScanParams, reqOrdering exec.OutputOrdering,
+) (exec.Node, error) {
+	if params.Reverse || params.InvertedConstraint != nil ||
+		(params.IndexConstraint != nil && !params.IndexConstraint.IsUnconstrained()) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"foreign table %q can only be scanned in full, in file order", table.Name())
+	}
+	if params.Locking.IsLocking() {
+		return nil, pgerror.Newf(pgcode.WrongObjectType,
+			"cannot lock rows in foreign table %q", table.Name())
+	}
+	desc := table.(*optTable).desc
+	colCfg := makeScanColumnsConfig(table, params.NeededCols)
+	cols, err := initColsForScan(desc, colCfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := colCfg.assertValidReqOrdering(reqOrdering); err != nil {
+		return nil, err
+	}
+	return &foreignScanNode{
+		desc:          desc,
+		cols:          cols,
+		resultColumns: colinfo.ResultColumnsFromColumns(desc.GetID(), cols),
+		hardLimit:     params.HardLimit,
+		reqOrdering:   ReqOrdering(reqOrdering),
+	}, nil
+}
@@ -485,7 +485,7 @@ func (p *planner) getGrantOnObject(
 	case targets.Types != nil:
 		incIAMFunc(sqltelemetry.OnType)
 		return privilege.Type, nil
-	case targets.Functions != nil:
+	case targets.Functions != nil, targets.Procedures != nil:
 		incIAMFunc(sqltelemetry.OnFunction)
 		return privilege.Function, nil
 	case targets.System:
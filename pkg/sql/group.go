@@ -14,6 +14,7 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 )
 
@@ -37,6 +38,13 @@ type groupNode struct {
 	// even if there are no input rows, e.g. SELECT MIN(x) FROM t.
 	isScalar bool
 
+	// groupingSets, if set, contains the grouping sets of a GROUPING SETS,
+	// ROLLUP or CUBE aggregation. Each set holds indices of the source plan
+	// columns and is a subset of groupCols. When set, the output contains an
+	// extra INT column after the aggregations with the ordinal of the grouping
+	// set that produced the row.
+	groupingSets [][]int
+
 	// funcs contains the information about all aggregate functions.
 	funcs []*aggregateFuncHolder
 
@@ -74,6 +82,9 @@ type aggregateFuncHolder struct {
 	arguments tree.Datums
 	// isDistinct indicates whether only distinct values are aggregated.
 	isDistinct bool
+	// userDefined is set if this is a user-defined aggregate, in which case
+	// funcName is only used for display purposes.
+	userDefined *exec.UserDefinedAggInfo
 }
 
 // newAggregateFuncHolder creates an aggregateFuncHolder.
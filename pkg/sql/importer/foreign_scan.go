@@ -0,0 +1,208 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package importer
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfra"
+	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowexec"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/errors"
+)
+
+const foreignScanProcessorName = "foreignScanProcessor"
+
+// foreignScanProcessor reads the rows of a foreign table from its file in
+// external storage. The file is parsed with the same input readers as IMPORT,
+// but the converted rows are emitted rather than ingested. A single worker
+// converts the rows so that they are emitted in file order, which matches the
+// order of the generated row IDs that make up the primary key of the table.
+type foreignScanProcessor struct {
+	execinfra.ProcessorBase
+
+	flowCtx *execinfra.FlowCtx
+	spec    execinfrapb.ForeignScanSpec
+
+	// colOrds maps each output column to the ordinal of the corresponding
+	// public column of the table, or -1 if the column is not read from the
+	// file and is always NULL.
+	colOrds     []int
+	outputTypes []*types.T
+
+	cancel context.CancelFunc
+	wg     ctxgroup.Group
+	rowCh  chan tree.Datums
+
+	readErr error
+}
+
+var (
+	_ execinfra.Processor = &foreignScanProcessor{}
+	_ execinfra.RowSource = &foreignScanProcessor{}
+)
+
+func newForeignScanProcessor(
+	ctx context.Context,
+	flowCtx *execinfra.FlowCtx,
+	processorID int32,
+	spec execinfrapb.ForeignScanSpec,
+	post *execinfrapb.PostProcessSpec,
+) (execinfra.Processor, error) {
+	fsp := &foreignScanProcessor{
+		flowCtx: flowCtx,
+		spec:    spec,
+		rowCh:   make(chan tree.Datums),
+	}
+	tableDesc := tabledesc.NewBuilder(&spec.Table).BuildImmutableTable()
+	publicCols := tableDesc.PublicColumns()
+	fsp.outputTypes = make([]*types.T, len(spec.OutputColumns))
+	fsp.colOrds = make([]int, len(spec.OutputColumns))
+	for i, id := range spec.OutputColumns {
+		col, err := catalog.MustFindColumnByID(tableDesc, id)
+		if err != nil {
+			return nil, err
+		}
+		fsp.outputTypes[i] = col.GetType()
+		fsp.colOrds[i] = -1
+		if col.Public() && !col.IsSystemColumn() {
+			for ord := range publicCols {
+				if publicCols[ord].GetID() == id {
+					fsp.colOrds[i] = ord
+					break
+				}
+			}
+		}
+	}
+	if err := fsp.Init(ctx, fsp, post, fsp.outputTypes, flowCtx, processorID, nil, /* memMonitor */
+		execinfra.ProcStateOpts{
+			// This processor doesn't have any inputs to drain.
+			InputsToDrain: nil,
+			TrailingMetaCallback: func() []execinfrapb.ProducerMetadata {
+				fsp.close()
+				return nil
+			},
+		}); err != nil {
+		return nil, err
+	}
+	return fsp, nil
+}
+
+// Start is part of the RowSource interface.
+func (fsp *foreignScanProcessor) Start(ctx context.Context) {
+	ctx = fsp.StartInternal(ctx, foreignScanProcessorName)
+
+	grpCtx, cancel := context.WithCancel(ctx)
+	fsp.cancel = cancel
+	fsp.wg = ctxgroup.WithContext(grpCtx)
+	fsp.wg.GoCtx(func(ctx context.Context) error {
+		defer close(fsp.rowCh)
+		fsp.readErr = fsp.readFile(ctx)
+		return nil
+	})
+}
+
+// readFile reads the file of the foreign table, sending each converted row on
+// rowCh.
+func (fsp *foreignScanProcessor) readFile(ctx context.Context) error {
+	tableDesc := tabledesc.NewBuilder(&fsp.spec.Table).BuildImmutableTable()
+	evalCtx := fsp.flowCtx.NewEvalCtx()
+	injectTimeIntoEvalCtx(evalCtx, fsp.spec.WalltimeNanos)
+	semaCtx := tree.MakeSemaContext()
+
+	var importCtx *parallelImportContext
+	var conv inputConverter
+	switch fsp.spec.Format.Format {
+	case roachpb.IOFileFormat_CSV:
+		r := newCSVInputReader(
+			&semaCtx, nil /* kvCh */, fsp.spec.Format.Csv, fsp.spec.WalltimeNanos,
+			1 /* parallelism */, tableDesc, nil /* targetCols */, evalCtx,
+			nil /* seqChunkProvider */, fsp.flowCtx.Cfg.DB.KV())
+		importCtx, conv = r.importCtx, r
+	case roachpb.IOFileFormat_Avro:
+		r, err := newAvroInputReader(
+			&semaCtx, nil /* kvCh */, tableDesc, fsp.spec.Format.Avro, fsp.spec.WalltimeNanos,
+			1 /* parallelism */, evalCtx, fsp.flowCtx.Cfg.DB.KV())
+		if err != nil {
+			return err
+		}
+		importCtx, conv = r.importContext, r
+	default:
+		return errors.Errorf("foreign tables do not support the %s format", fsp.spec.Format.Format)
+	}
+	importCtx.rowFn = func(ctx context.Context, row tree.Datums) error {
+		select {
+		case fsp.rowCh <- row:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	group := ctxgroup.WithContext(ctx)
+	conv.start(group)
+	group.GoCtx(func(ctx context.Context) error {
+		return conv.readFiles(ctx, map[int32]string{0: fsp.spec.URI}, nil, /* resumePos */
+			fsp.spec.Format, fsp.flowCtx.Cfg.ExternalStorage, fsp.spec.User())
+	})
+	return group.Wait()
+}
+
+// Next is part of the RowSource interface.
+func (fsp *foreignScanProcessor) Next() (rowenc.EncDatumRow, *execinfrapb.ProducerMetadata) {
+	for fsp.State == execinfra.StateRunning {
+		row, ok := <-fsp.rowCh
+		if !ok {
+			fsp.MoveToDraining(fsp.readErr)
+			break
+		}
+		encRow := make(rowenc.EncDatumRow, len(fsp.colOrds))
+		for i, ord := range fsp.colOrds {
+			d := tree.DNull
+			if ord >= 0 {
+				d = row[ord]
+			}
+			encRow[i] = rowenc.DatumToEncDatum(fsp.outputTypes[i], d)
+		}
+		if outRow := fsp.ProcessRowHelper(encRow); outRow != nil {
+			return outRow, nil
+		}
+	}
+	return nil, fsp.DrainHelper()
+}
+
+// ConsumerClosed is part of the RowSource interface.
+func (fsp *foreignScanProcessor) ConsumerClosed() {
+	fsp.close()
+}
+
+func (fsp *foreignScanProcessor) close() {
+	// fsp.Closed is set by fsp.InternalClose().
+	if fsp.Closed {
+		return
+	}
+	if fsp.cancel != nil {
+		fsp.cancel()
+		_ = fsp.wg.Wait()
+	}
+	fsp.InternalClose()
+}
+
+func init() {
+	rowexec.NewForeignScanProcessor = newForeignScanProcessor
+}
@@ -468,6 +468,9 @@ type parallelImportContext struct {
 	kvCh             chan row.KVBatch        // Channel for sending KV batches.
 	seqChunkProvider *row.SeqChunkProvider   // Used to reserve chunks of sequence values.
 	db               *kv.DB
+	// rowFn, if set, receives the converted rows instead of kvCh receiving
+	// their KVs. See row.DatumRowConverter.RowFn.
+	rowFn func(ctx context.Context, row tree.Datums) error
 }
 
 // importFileContext describes state specific to a file being imported.
@@ -499,6 +502,7 @@ func makeDatumConverter(
 		importCtx.kvCh, importCtx.seqChunkProvider, nil /* metrics */, db)
 	if err == nil {
 		conv.KvBatch.Source = fileCtx.source
+		conv.RowFn = importCtx.rowFn
 	}
 	return conv, err
 }
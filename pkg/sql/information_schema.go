@@ -484,10 +484,21 @@ https://www.postgresql.org/docs/9.5/infoschema-columns.html`,
 				// the schema it is under.
 				udtSchema := pgCatalogNameDString
 				typeMetaName := column.GetType().TypeMeta.Name
-				if typeMetaName != nil {
+				if typeMetaName != nil && column.GetType().DomainOID() == 0 {
 					udtSchema = tree.NewDString(typeMetaName.Schema)
 				}
 
+				// The domain columns are only set if the column has a domain type.
+				// In that case, the udt columns describe the base type of the domain.
+				domainCatalog := tree.DNull
+				domainSchema := tree.DNull
+				domainName := tree.DNull
+				if typeMetaName != nil && column.GetType().DomainOID() != 0 {
+					domainCatalog = dbNameStr
+					domainSchema = tree.NewDString(typeMetaName.Schema)
+					domainName = tree.NewDString(typeMetaName.Basename())
+				}
+
 				// Get the sequence option if it's an identity column.
 				identityStart := tree.DNull
 				identityIncrement := tree.DNull
@@ -528,9 +539,9 @@ https://www.postgresql.org/docs/9.5/infoschema-columns.html`,
 					collationCatalog,                                          // collation_catalog
 					collationSchema,                                           // collation_schema
 					collationName,                                             // collation_name
-					tree.DNull,                                                // domain_catalog
-					tree.DNull,                                                // domain_schema
-					tree.DNull,                                                // domain_name
+					domainCatalog,                                             // domain_catalog
+					domainSchema,                                              // domain_schema
+					domainName,                                                // domain_name
 					dbNameStr,                                                 // udt_catalog
 					udtSchema,                                                 // udt_schema
 					tree.NewDString(column.GetType().PGName()), // udt_name
@@ -1299,16 +1310,19 @@ https://www.postgresql.org/docs/9.5/infoschema-table-constraints.html`,
 					} else if u := c.AsUniqueWithIndex(); u != nil && u.Primary() {
 						kind = catconstants.ConstraintTypePK
 					}
+					deferrability := constraintDeferrability(c)
+					isDeferrable := deferrability != semenumpb.Deferrability_NOT_DEFERRABLE
+					initiallyDeferred := deferrability == semenumpb.Deferrability_INITIALLY_DEFERRED
 					if err := addRow(
-						dbNameStr,                     // constraint_catalog
-						scNameStr,                     // constraint_schema
-						tree.NewDString(c.GetName()),  // constraint_name
-						dbNameStr,                     // table_catalog
-						scNameStr,                     // table_schema
-						tbNameStr,                     // table_name
-						tree.NewDString(string(kind)), // constraint_type
-						yesOrNoDatum(false),           // is_deferrable
-						yesOrNoDatum(false),           // initially_deferred
+						dbNameStr,                       // constraint_catalog
+						scNameStr,                       // constraint_schema
+						tree.NewDString(c.GetName()),    // constraint_name
+						dbNameStr,                       // table_catalog
+						scNameStr,                       // table_schema
+						tbNameStr,                       // table_name
+						tree.NewDString(string(kind)),   // constraint_type
+						yesOrNoDatum(isDeferrable),      // is_deferrable
+						yesOrNoDatum(initiallyDeferred), // initially_deferred
 					); err != nil {
 						return err
 					}
@@ -1840,12 +1854,34 @@ var informationSchemaAttributesTable = virtualSchemaTable{
 }
 
 var informationSchemaDomainConstraintsTable = virtualSchemaTable{
-	comment: "domain_constraints was created for compatibility and is currently unimplemented",
-	schema:  vtable.InformationSchemaDomainConstraints,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `domain constraints
+https://www.postgresql.org/docs/current/infoschema-domain-constraints.html`,
+	schema: vtable.InformationSchemaDomainConstraints,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTypeDesc(ctx, p, dbContext, func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, typDesc catalog.TypeDescriptor) error {
+			domainDesc := typDesc.AsDomainTypeDescriptor()
+			if domainDesc == nil {
+				return nil
+			}
+			dbNameStr := tree.NewDString(db.GetName())
+			scNameStr := tree.NewDString(sc.GetName())
+			for i := 0; i < domainDesc.NumDomainChecks(); i++ {
+				if err := addRow(
+					dbNameStr, // constraint_catalog
+					scNameStr, // constraint_schema
+					tree.NewDString(domainDesc.GetDomainCheckName(i)), // constraint_name
+					dbNameStr,                             // domain_catalog
+					scNameStr,                             // domain_schema
+					tree.NewDString(domainDesc.GetName()), // domain_name
+					noString,                              // is_deferrable
+					noString,                              // initially_deferred
+				); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	},
-	unimplemented: true,
 }
 
 var informationSchemaUserMappingsTable = virtualSchemaTable{
@@ -1912,12 +1948,60 @@ var informationSchemaUsagePrivilegesTable = virtualSchemaTable{
 }
 
 var informationSchemaDomainsTable = virtualSchemaTable{
-	comment: "domains was created for compatibility and is currently unimplemented",
-	schema:  vtable.InformationSchemaDomains,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `domains
+https://www.postgresql.org/docs/current/infoschema-domains.html`,
+	schema: vtable.InformationSchemaDomains,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTypeDesc(ctx, p, dbContext, func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, typDesc catalog.TypeDescriptor) error {
+			domainDesc := typDesc.AsDomainTypeDescriptor()
+			if domainDesc == nil {
+				return nil
+			}
+			dbNameStr := tree.NewDString(db.GetName())
+			base := domainDesc.DomainBaseType()
+			collationCatalog := tree.DNull
+			collationSchema := tree.DNull
+			collationName := tree.DNull
+			if locale := base.Locale(); locale != "" {
+				collationCatalog = dbNameStr
+				collationSchema = pgCatalogNameDString
+				collationName = tree.NewDString(locale)
+			}
+			domainDefault := tree.DNull
+			if expr, ok := domainDesc.DomainDefaultExpr(); ok {
+				domainDefault = tree.NewDString(expr)
+			}
+			return addRow(
+				dbNameStr,                                     // domain_catalog
+				tree.NewDString(sc.GetName()),                 // domain_schema
+				tree.NewDString(domainDesc.GetName()),         // domain_name
+				tree.NewDString(base.InformationSchemaName()), // data_type
+				characterMaximumLength(base),                  // character_maximum_length
+				characterOctetLength(base),                    // character_octet_length
+				tree.DNull,                                    // character_set_catalog
+				tree.DNull,                                    // character_set_schema
+				tree.DNull,                                    // character_set_name
+				collationCatalog,                              // collation_catalog
+				collationSchema,                               // collation_schema
+				collationName,                                 // collation_name
+				numericPrecision(base),                        // numeric_precision
+				numericPrecisionRadix(base),                   // numeric_precision_radix
+				numericScale(base),                            // numeric_scale
+				datetimePrecision(base),                       // datetime_precision
+				tree.DNull,                                    // interval_type
+				tree.DNull,                                    // interval_precision
+				domainDefault,                                 // domain_default
+				dbNameStr,                                     // udt_catalog
+				pgCatalogNameDString,                          // udt_schema
+				tree.NewDString(base.PGName()),                // udt_name
+				tree.DNull,                                    // scope_catalog
+				tree.DNull,                                    // scope_schema
+				tree.DNull,                                    // scope_name
+				tree.DNull,                                    // maximum_cardinality
+				tree.DNull,                                    // dtd_identifier
+			)
+		})
 	},
-	unimplemented: true,
 }
 
 var informationSchemaSQLImplementationInfoTable = virtualSchemaTable{
@@ -2701,6 +2785,11 @@ func (r roleOptions) createRole() (tree.DBool, error) {
 	return tree.DBool(createRole), err
 }
 
+func (r roleOptions) bypassRLS() (tree.DBool, error) {
+	bypassRLS, err := r.Exists("BYPASSRLS")
+	return tree.DBool(bypassRLS), err
+}
+
 func forEachRoleQuery(ctx context.Context, p *planner) string {
 	return `
 SELECT
@@ -150,6 +150,11 @@ type instrumentationHelper struct {
 	// costEstimate is the cost of the query as estimated by the optimizer.
 	costEstimate float64
 
+	// planType is "generic" or "custom", indicating which type of query plan
+	// was used to execute a prepared statement. It is only set when the
+	// plan_cache_mode session setting permits generic query plans.
+	planType string
+
 	// indexRecs contains index recommendations for the planned statement. It
 	// will only be populated if recommendations are requested for the statement
 	// for populating the statement_statistics table.
@@ -548,6 +553,9 @@ func (ih *instrumentationHelper) emitExplainAnalyzePlanToOutputBuilder(
 	ob.AddExecutionTime(phaseTimes.GetRunLatency())
 	ob.AddDistribution(ih.distribution.String())
 	ob.AddVectorized(ih.vectorized)
+	if ih.planType != "" {
+		ob.AddPlanType(ih.planType)
+	}
 
 	if queryStats != nil {
 		if queryStats.KVRowsRead != 0 {
@@ -0,0 +1,107 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// Listen starts listening for notifications on a channel.
+//
+// Unlike Postgres, where LISTEN takes effect when the transaction commits, the
+// session starts listening immediately.
+func (p *planner) Listen(ctx context.Context, n *tree.Listen) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_ListenNotify) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"LISTEN is not supported until the cluster version is finalized")
+	}
+	channel, err := channelName(n.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.listenChannels.listen(ctx, channel); err != nil {
+		return nil, err
+	}
+	return newZeroNode(nil /* columns */), nil
+}
+
+// channelName returns the name of a LISTEN, NOTIFY or UNLISTEN channel.
+func channelName(n *tree.UnresolvedObjectName) (string, error) {
+	if n.NumParts != 1 {
+		return "", pgerror.Newf(pgcode.Syntax, "invalid channel name: %s", n)
+	}
+	return n.Object(), nil
+}
+
+// listenChannels is used by the planner to manage the channels that the
+// session listens on.
+type listenChannels interface {
+	// listen starts listening on the channel.
+	listen(ctx context.Context, channel string) error
+	// unlisten stops listening on the channel.
+	unlisten(channel string)
+	// unlistenAll stops listening on all channels.
+	unlistenAll()
+}
+
+type connExListenChannelsAccessor struct {
+	ex *connExecutor
+}
+
+var _ listenChannels = connExListenChannelsAccessor{}
+
+func (c connExListenChannelsAccessor) listen(ctx context.Context, channel string) error {
+	ex := c.ex
+	if ex.notificationListener == nil {
+		ex.notificationListener = ex.server.cfg.NotificationRegistry.NewListener(
+			func(ctx context.Context) {
+				// The notifications are delivered once the command is processed,
+				// or when the next Sync is processed outside a transaction,
+				// whichever comes first. The error is ignored since it means that
+				// the session is closing.
+				_ /* err */ = ex.stmtBuf.Push(ctx, DeliverNotifications{})
+			},
+		)
+	}
+	return ex.notificationListener.Listen(ctx, channel)
+}
+
+func (c connExListenChannelsAccessor) unlisten(channel string) {
+	if l := c.ex.notificationListener; l != nil {
+		l.Unlisten(channel)
+	}
+}
+
+func (c connExListenChannelsAccessor) unlistenAll() {
+	if l := c.ex.notificationListener; l != nil {
+		l.UnlistenAll()
+	}
+}
+
+// emptyListenChannels is the default impl used by the planner when the
+// connExecutor is not available.
+type emptyListenChannels struct{}
+
+var _ listenChannels = emptyListenChannels{}
+
+func (emptyListenChannels) listen(ctx context.Context, channel string) error {
+	return errors.AssertionFailedf("listen not supported in emptyListenChannels")
+}
+
+func (emptyListenChannels) unlisten(channel string) {}
+
+func (emptyListenChannels) unlistenAll() {}
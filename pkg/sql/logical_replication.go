@@ -0,0 +1,705 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/util/contextutil"
+	"github.com/cockroachdb/cockroach/pkg/util/ctxgroup"
+	"github.com/cockroachdb/cockroach/pkg/util/fsm"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/lsn"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
+)
+
+// ReplicationFeedHook runs a feed of the changes committed to the given
+// tables after cursor, and delivers them to sink until ctx is canceled or an
+// error occurs. It is set by changefeedccl, which builds the feed on
+// rangefeeds.
+var ReplicationFeedHook func(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	tables []catalog.TableDescriptor,
+	cursor hlc.Timestamp,
+	sink ReplicationFeedSink,
+) error
+
+// ReplicationFeedSink receives the events of a replication feed. Its methods
+// are called from a single goroutine.
+type ReplicationFeedSink interface {
+	// AddChange delivers a change to a row. Changes are not delivered in
+	// timestamp order.
+	AddChange(ctx context.Context, change ReplicationChange) error
+	// Resolved signals that all the changes committed at or below ts have been
+	// delivered.
+	Resolved(ctx context.Context, ts hlc.Timestamp) error
+}
+
+// ReplicationChange is a change to a row of a table of a replication feed.
+type ReplicationChange struct {
+	// Table is the version of the table descriptor with which the row was
+	// decoded.
+	Table     catalog.TableDescriptor
+	Timestamp hlc.Timestamp
+	// Row holds the values of the ReplicationColumns of the table after the
+	// change. It is nil if the row was deleted.
+	Row tree.Datums
+	// PrevRow holds the values of the ReplicationColumns of the table before
+	// the change. It is nil if the row did not exist.
+	PrevRow tree.Datums
+}
+
+// ReplicationColumns returns the columns of a table which are streamed to
+// replication clients, which are the visible stored columns.
+func ReplicationColumns(table catalog.TableDescriptor) []catalog.Column {
+	var cols []catalog.Column
+	for _, col := range table.VisibleColumns() {
+		if !col.IsVirtual() {
+			cols = append(cols, col)
+		}
+	}
+	return cols
+}
+
+// replicationKeepaliveInterval is the interval at which keepalive messages
+// carrying the resolved position of the stream are sent to the client.
+const replicationKeepaliveInterval = 10 * time.Second
+
+// errReplicationStreamEnded is returned when the client ends the stream.
+var errReplicationStreamEnded = errors.New("replication stream ended by client")
+
+// execStartReplication runs a START_REPLICATION command, which streams the
+// changes to the tables of the requested publications to the client as
+// pgoutput messages until the client ends the stream.
+func (ex *connExecutor) execStartReplication(
+	ctx context.Context, cmd StartReplication, res StartReplicationResult,
+) (fsm.Event, fsm.EventPayload) {
+	// Once execution finishes, the network routine stops forwarding the
+	// messages of the client to the stream.
+	defer close(cmd.StreamDone)
+
+	if _, isNoTxn := ex.machine.CurState().(stateNoTxn); !isNoTxn {
+		return ex.makeErrEvent(pgerror.New(pgcode.ActiveSQLTransaction,
+			"START_REPLICATION cannot run inside a transaction block"), cmd.Stmt)
+	}
+
+	ex.incrementStartedStmtCounter(cmd.Stmt)
+	var cancelQuery context.CancelFunc
+	ctx, cancelQuery = contextutil.WithCancel(ctx)
+	queryID := ex.generateID()
+	ex.addActiveQuery(cmd.ParsedStmt, nil /* placeholders */, queryID, cancelQuery)
+	ex.metrics.EngineMetrics.SQLActiveStatements.Inc(1)
+	defer func() {
+		ex.removeActiveQuery(queryID, cmd.Stmt)
+		cancelQuery()
+		ex.metrics.EngineMetrics.SQLActiveStatements.Dec(1)
+	}()
+
+	stream, err := ex.planLogicalReplication(ctx, cmd.Stmt)
+	if err == nil {
+		err = stream.run(ctx, cmd.ClientMessages, res)
+	}
+	if err != nil {
+		return eventNonRetriableErr{IsCommit: fsm.False}, eventNonRetriableErrPayload{err: err}
+	}
+	ex.incrementExecutedStmtCounter(cmd.Stmt)
+	return nil, nil
+}
+
+// logicalReplicationStream is the state of the stream of a START_REPLICATION
+// command.
+type logicalReplicationStream struct {
+	execCfg *ExecutorConfig
+	slot    string
+
+	// tables are the streamed tables, by ID.
+	tables map[descpb.ID]*replicationTable
+	// descs are the descriptors of the streamed tables as of the start of the
+	// stream.
+	descs []catalog.TableDescriptor
+
+	// start is the LSN after which transactions are streamed.
+	start lsn.LSN
+	// confirmed is the confirmed_flush_lsn of the slot.
+	confirmed lsn.LSN
+	// resolved is the LSN up to which all the transactions have been sent.
+	resolved lsn.LSN
+	// lastCommit is the LSN of the last transaction sent.
+	lastCommit lsn.LSN
+	// pending holds the changes which aren't resolved yet.
+	pending []ReplicationChange
+
+	xid uint32
+	buf []byte
+	msg []byte
+}
+
+// replicationTable is a table of a logical replication stream.
+type replicationTable struct {
+	schemaName string
+	// The operations published for the table, by any of the publications of
+	// the stream.
+	publishInsert, publishUpdate, publishDelete bool
+	// sentVersion is the version of the descriptor of the table described by
+	// the last Relation message sent to the client, if any.
+	sentVersion descpb.DescriptorVersion
+}
+
+// planLogicalReplication loads the replication slot of a START_REPLICATION
+// command and resolves the tables of its publications.
+func (ex *connExecutor) planLogicalReplication(
+	ctx context.Context, stmt *tree.StartReplication,
+) (*logicalReplicationStream, error) {
+	if ReplicationFeedHook == nil {
+		return nil, sqlerrors.NewCCLRequiredError(
+			errors.New("logical replication requires a CCL binary"))
+	}
+	publications, err := evalStartReplicationOptions(stmt.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	execCfg := ex.server.cfg
+	s := &logicalReplicationStream{
+		execCfg: execCfg,
+		slot:    string(stmt.Slot),
+		tables:  make(map[descpb.ID]*replicationTable),
+	}
+	if err := execCfg.InternalDB.DescsTxn(ctx, func(ctx context.Context, txn descs.Txn) error {
+		s.tables = make(map[descpb.ID]*replicationTable)
+		s.descs = s.descs[:0]
+		p, cleanup := newInternalPlanner(
+			"start-replication", txn.KV(), ex.sessionData().User(), &MemoryMetrics{},
+			execCfg, ex.sessionData().Clone(), WithDescCollection(txn.Descriptors()),
+		)
+		defer cleanup()
+		if err := p.checkReplicationCommand(ctx); err != nil {
+			return err
+		}
+		dbDesc, err := txn.Descriptors().ByNameWithLeased(txn.KV()).Get().Database(
+			ctx, p.CurrentDatabase(),
+		)
+		if err != nil {
+			return err
+		}
+
+		row, err := txn.QueryRowEx(
+			ctx, "load-replication-slot", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+			`SELECT database_id, plugin, confirmed_flush_lsn FROM system.replication_slots WHERE slot_name = $1`,
+			s.slot,
+		)
+		if err != nil {
+			return err
+		}
+		if row == nil {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"replication slot %q does not exist", s.slot)
+		}
+		if descpb.ID(tree.MustBeDInt(row[0])) != dbDesc.GetID() {
+			return pgerror.Newf(pgcode.ObjectNotInPrerequisiteState,
+				"replication slot %q was not created in this database", s.slot)
+		}
+		if plugin := string(tree.MustBeDString(row[1])); plugin != pgrepl.PluginPgoutput {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"output plugin %q is not supported", plugin)
+		}
+		s.confirmed = lsn.LSN(tree.MustBeDInt(row[2]))
+
+		return s.resolvePublications(ctx, txn.Descriptors(), txn.KV(), dbDesc, publications)
+	}); err != nil {
+		return nil, err
+	}
+
+	// As in Postgres, the stream starts after the confirmed position of the
+	// slot if the client requests an earlier position.
+	s.start = stmt.LSN
+	if s.start < s.confirmed {
+		s.start = s.confirmed
+	}
+	s.resolved = s.start
+	s.lastCommit = s.start
+	return s, nil
+}
+
+// evalStartReplicationOptions evaluates the pgoutput options of a
+// START_REPLICATION command, and returns the names of the publications to
+// stream.
+func evalStartReplicationOptions(opts tree.ReplicationOptions) ([]string, error) {
+	var publications []string
+	sawProtoVersion := false
+	for _, opt := range opts {
+		switch opt.Key {
+		case "proto_version":
+			if opt.Value != strconv.Itoa(pgrepl.ProtoVersion) {
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"client sent proto_version=%s but server only supports protocol %d",
+					opt.Value, pgrepl.ProtoVersion)
+			}
+			sawProtoVersion = true
+		case "publication_names":
+			names, err := splitPublicationNames(opt.Value)
+			if err != nil {
+				return nil, err
+			}
+			publications = append(publications, names...)
+		case "binary", "messages", "streaming", "two_phase":
+			enabled := true
+			if opt.Value != "" {
+				var err error
+				if enabled, err = tree.ParseBool(opt.Value); err != nil {
+					return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+						"invalid value for option %q: %q", opt.Key, opt.Value)
+				}
+			}
+			// Logical decoding messages are never sent, so they can be requested.
+			if enabled && opt.Key != "messages" {
+				return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+					"option %q is not supported", opt.Key)
+			}
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized pgoutput option: %s", opt.Key)
+		}
+	}
+	if !sawProtoVersion {
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "proto_version option missing")
+	}
+	if len(publications) == 0 {
+		return nil, pgerror.New(pgcode.InvalidParameterValue, "publication_names parameter missing")
+	}
+	return publications, nil
+}
+
+// splitPublicationNames splits the comma-separated list of identifiers of the
+// publication_names option. Unquoted identifiers are normalized.
+func splitPublicationNames(s string) ([]string, error) {
+	invalid := func() error {
+		return pgerror.Newf(pgcode.InvalidName, "invalid publication_names syntax: %q", s)
+	}
+	var names []string
+	rest := s
+	for {
+		rest = strings.TrimLeft(rest, " \t\r\n")
+		var name string
+		if strings.HasPrefix(rest, `"`) {
+			var b strings.Builder
+			i := 1
+			for {
+				j := strings.IndexByte(rest[i:], '"')
+				if j < 0 {
+					return nil, invalid()
+				}
+				b.WriteString(rest[i : i+j])
+				i += j + 1
+				// A doubled quote is an escaped quote.
+				if i < len(rest) && rest[i] == '"' {
+					b.WriteByte('"')
+					i++
+					continue
+				}
+				break
+			}
+			name = b.String()
+			rest = rest[i:]
+		} else {
+			end := strings.IndexByte(rest, ',')
+			if end < 0 {
+				end = len(rest)
+			}
+			name = lexbase.NormalizeName(strings.TrimRight(rest[:end], " \t\r\n"))
+			rest = rest[end:]
+		}
+		if name == "" {
+			return nil, invalid()
+		}
+		names = append(names, name)
+		rest = strings.TrimLeft(rest, " \t\r\n")
+		if rest == "" {
+			return names, nil
+		}
+		if rest[0] != ',' {
+			return nil, invalid()
+		}
+		rest = rest[1:]
+	}
+}
+
+// resolvePublications resolves the tables of the publications of the stream.
+// Tables which were dropped after being added to a publication are skipped.
+func (s *logicalReplicationStream) resolvePublications(
+	ctx context.Context,
+	descriptors *descs.Collection,
+	txn *kv.Txn,
+	dbDesc catalog.DatabaseDescriptor,
+	names []string,
+) error {
+	for _, name := range names {
+		publication := dbDesc.FindPublicationByName(name)
+		if publication == nil {
+			return pgerror.Newf(pgcode.UndefinedObject,
+				"publication %q does not exist", name)
+		}
+		ids := publication.TableIDs
+		if publication.AllTables {
+			ids = nil
+			all, err := descriptors.GetAllTablesInDatabase(ctx, txn, dbDesc)
+			if err != nil {
+				return err
+			}
+			if err := all.ForEachDescriptor(func(desc catalog.Descriptor) error {
+				table, ok := desc.(catalog.TableDescriptor)
+				if ok && table.IsTable() && !table.IsVirtualTable() && !table.IsTemporary() {
+					ids = append(ids, table.GetID())
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+		}
+		for _, id := range ids {
+			table, err := descriptors.ByIDWithLeased(txn).WithoutNonPublic().Get().Table(ctx, id)
+			if err != nil {
+				if errors.Is(err, catalog.ErrDescriptorNotFound) || catalog.HasInactiveDescriptorError(err) {
+					continue
+				}
+				return err
+			}
+			t, ok := s.tables[id]
+			if !ok {
+				if len(table.GetFamilies()) > 1 {
+					return pgerror.Newf(pgcode.FeatureNotSupported,
+						"logical replication of table %q with multiple column families is not supported",
+						table.GetName())
+				}
+				schema, err := descriptors.ByIDWithLeased(txn).Get().Schema(ctx, table.GetParentSchemaID())
+				if err != nil {
+					return err
+				}
+				t = &replicationTable{schemaName: schema.GetName()}
+				s.tables[id] = t
+				s.descs = append(s.descs, table)
+			}
+			t.publishInsert = t.publishInsert || publication.PublishInsert
+			t.publishUpdate = t.publishUpdate || publication.PublishUpdate
+			t.publishDelete = t.publishDelete || publication.PublishDelete
+		}
+	}
+	return nil
+}
+
+// replicationFeedEvent is a change or a resolved timestamp delivered by the
+// replication feed.
+type replicationFeedEvent struct {
+	change   ReplicationChange
+	resolved hlc.Timestamp
+}
+
+// replicationFeedSink is the ReplicationFeedSink of a logical replication
+// stream, which forwards the events of the feed to the goroutine serving the
+// stream.
+type replicationFeedSink struct {
+	events chan<- replicationFeedEvent
+}
+
+var _ ReplicationFeedSink = replicationFeedSink{}
+
+// AddChange implements the ReplicationFeedSink interface.
+func (s replicationFeedSink) AddChange(ctx context.Context, change ReplicationChange) error {
+	return s.send(ctx, replicationFeedEvent{change: change})
+}
+
+// Resolved implements the ReplicationFeedSink interface.
+func (s replicationFeedSink) Resolved(ctx context.Context, ts hlc.Timestamp) error {
+	return s.send(ctx, replicationFeedEvent{resolved: ts})
+}
+
+func (s replicationFeedSink) send(ctx context.Context, ev replicationFeedEvent) error {
+	select {
+	case s.events <- ev:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run streams the changes to the client until the client ends the stream.
+func (s *logicalReplicationStream) run(
+	ctx context.Context, clientMsgs <-chan []byte, res StartReplicationResult,
+) error {
+	if err := res.SendCopyBothResponse(ctx); err != nil {
+		return err
+	}
+	events := make(chan replicationFeedEvent)
+	g := ctxgroup.WithContext(ctx)
+	if len(s.descs) > 0 {
+		g.GoCtx(func(ctx context.Context) error {
+			return ReplicationFeedHook(
+				ctx, s.execCfg, s.descs, pgrepl.ResumeTimestamp(s.start),
+				replicationFeedSink{events: events},
+			)
+		})
+	}
+	g.GoCtx(func(ctx context.Context) error {
+		return s.serve(ctx, events, clientMsgs, res)
+	})
+	if err := g.Wait(); !errors.Is(err, errReplicationStreamEnded) {
+		return err
+	}
+	return res.SendCopyDone(ctx)
+}
+
+// serve sends the changes delivered by the feed to the client once they are
+// resolved, and handles the messages of the client.
+func (s *logicalReplicationStream) serve(
+	ctx context.Context,
+	events <-chan replicationFeedEvent,
+	clientMsgs <-chan []byte,
+	res StartReplicationResult,
+) error {
+	ticker := time.NewTicker(replicationKeepaliveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case ev := <-events:
+			if ev.change.Table != nil {
+				s.pending = append(s.pending, ev.change)
+			} else if err := s.flush(ctx, res, ev.resolved); err != nil {
+				return err
+			}
+		case msg, ok := <-clientMsgs:
+			if !ok {
+				return errReplicationStreamEnded
+			}
+			if err := s.handleClientMessage(ctx, res, msg); err != nil {
+				return err
+			}
+		case <-ticker.C:
+			if len(s.descs) == 0 {
+				// Without tables to stream, the stream is always resolved.
+				if resolved := pgrepl.ResolvedLSN(s.execCfg.Clock.Now()); resolved > s.resolved {
+					s.resolved = resolved
+				}
+			}
+			if err := s.sendKeepalive(ctx, res, false /* replyRequested */); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// flush sends the transactions which are resolved once the feed is resolved
+// at ts.
+func (s *logicalReplicationStream) flush(
+	ctx context.Context, res StartReplicationResult, ts hlc.Timestamp,
+) error {
+	resolved := pgrepl.ResolvedLSN(ts)
+	if resolved <= s.resolved {
+		return nil
+	}
+	sort.SliceStable(s.pending, func(i, j int) bool {
+		return s.pending[i].Timestamp.Less(s.pending[j].Timestamp)
+	})
+	n := 0
+	for n < len(s.pending) && pgrepl.CommitLSN(s.pending[n].Timestamp) <= resolved {
+		n++
+	}
+	for ready := s.pending[:n]; len(ready) > 0; {
+		commitLSN := pgrepl.CommitLSN(ready[0].Timestamp)
+		i := 1
+		for i < len(ready) && pgrepl.CommitLSN(ready[i].Timestamp) == commitLSN {
+			i++
+		}
+		if commitLSN > s.lastCommit {
+			if err := s.sendTransaction(ctx, res, commitLSN, ready[:i]); err != nil {
+				return err
+			}
+		}
+		ready = ready[i:]
+	}
+	remaining := copy(s.pending, s.pending[n:])
+	for i := remaining; i < len(s.pending); i++ {
+		s.pending[i] = ReplicationChange{}
+	}
+	s.pending = s.pending[:remaining]
+	s.resolved = resolved
+	return nil
+}
+
+// sendTransaction sends the changes committed with the given LSN as a
+// transaction. Nothing is sent if none of the changes is published.
+func (s *logicalReplicationStream) sendTransaction(
+	ctx context.Context, res StartReplicationResult, commitLSN lsn.LSN, changes []ReplicationChange,
+) error {
+	commitTime := changes[0].Timestamp.GoTime()
+	began := false
+	for i := range changes {
+		change := &changes[i]
+		t, ok := s.tables[change.Table.GetID()]
+		if !ok {
+			continue
+		}
+		relID := oid.Oid(change.Table.GetID())
+		switch {
+		case change.Row == nil && change.PrevRow == nil:
+			// The deletion of a row which didn't exist.
+			continue
+		case change.Row == nil:
+			if !t.publishDelete {
+				continue
+			}
+		case change.PrevRow == nil:
+			if !t.publishInsert {
+				continue
+			}
+		default:
+			if !t.publishUpdate {
+				continue
+			}
+		}
+
+		if !began {
+			s.xid++
+			s.msg = pgrepl.AppendBegin(s.msg[:0], commitLSN, commitTime, s.xid)
+			if err := s.send(ctx, res, commitLSN, s.msg); err != nil {
+				return err
+			}
+			began = true
+		}
+		if t.sentVersion != change.Table.GetVersion() {
+			s.msg = pgrepl.AppendRelation(s.msg[:0], replicationRelation(change.Table, t.schemaName))
+			if err := s.send(ctx, res, commitLSN, s.msg); err != nil {
+				return err
+			}
+			t.sentVersion = change.Table.GetVersion()
+		}
+		switch {
+		case change.Row == nil:
+			s.msg = pgrepl.AppendDelete(s.msg[:0], relID, replicationTuple(change.PrevRow))
+		case change.PrevRow == nil:
+			s.msg = pgrepl.AppendInsert(s.msg[:0], relID, replicationTuple(change.Row))
+		default:
+			s.msg = pgrepl.AppendUpdate(
+				s.msg[:0], relID, replicationTuple(change.PrevRow), replicationTuple(change.Row),
+			)
+		}
+		if err := s.send(ctx, res, commitLSN, s.msg); err != nil {
+			return err
+		}
+	}
+	if began {
+		s.msg = pgrepl.AppendCommit(s.msg[:0], commitLSN, commitTime)
+		if err := s.send(ctx, res, commitLSN, s.msg); err != nil {
+			return err
+		}
+	}
+	s.lastCommit = commitLSN
+	return nil
+}
+
+// replicationRelation returns the Relation describing a table.
+func replicationRelation(table catalog.TableDescriptor, schemaName string) *pgrepl.Relation {
+	keyCols := table.GetPrimaryIndex().CollectKeyColumnIDs()
+	rel := &pgrepl.Relation{
+		ID:        oid.Oid(table.GetID()),
+		Namespace: schemaName,
+		Name:      table.GetName(),
+	}
+	for _, col := range ReplicationColumns(table) {
+		rel.Columns = append(rel.Columns, pgrepl.RelationColumn{
+			Name:    col.GetName(),
+			TypeOID: col.GetType().Oid(),
+			TypeMod: col.GetType().TypeModifier(),
+			Key:     keyCols.Contains(col.GetID()),
+		})
+	}
+	return rel
+}
+
+// replicationTuple returns the text encoding of the values of a row.
+func replicationTuple(row tree.Datums) pgrepl.Tuple {
+	tuple := make(pgrepl.Tuple, len(row))
+	for i, d := range row {
+		if d != tree.DNull {
+			tuple[i] = []byte(tree.AsStringWithFlags(d, tree.FmtPgwireText))
+		}
+	}
+	return tuple
+}
+
+// send sends a pgoutput message of the transaction committed at commitLSN.
+func (s *logicalReplicationStream) send(
+	ctx context.Context, res StartReplicationResult, commitLSN lsn.LSN, msg []byte,
+) error {
+	s.buf = pgrepl.AppendXLogData(s.buf[:0], commitLSN, commitLSN, timeutil.Now(), msg)
+	return res.SendReplicationData(ctx, s.buf)
+}
+
+// sendKeepalive sends a keepalive message with the resolved position of the
+// stream.
+func (s *logicalReplicationStream) sendKeepalive(
+	ctx context.Context, res StartReplicationResult, replyRequested bool,
+) error {
+	s.buf = pgrepl.AppendKeepalive(s.buf[:0], s.resolved, timeutil.Now(), replyRequested)
+	return res.SendReplicationData(ctx, s.buf)
+}
+
+// handleClientMessage handles a message sent by the client during streaming.
+// The flushed position of standby status updates is persisted as the
+// confirmed_flush_lsn of the slot.
+func (s *logicalReplicationStream) handleClientMessage(
+	ctx context.Context, res StartReplicationResult, msg []byte,
+) error {
+	status, ok, err := pgrepl.ParseStandbyMessage(msg)
+	if err != nil || !ok {
+		return err
+	}
+	// The client can't have flushed the transactions which weren't resolved
+	// yet, even if it reports a later position.
+	flushed := status.Flushed
+	if flushed > s.resolved {
+		flushed = s.resolved
+	}
+	if flushed > s.confirmed {
+		if _, err := s.execCfg.InternalDB.Executor().ExecEx(
+			ctx, "confirm-replication-slot", nil /* txn */, sessiondata.NodeUserSessionDataOverride,
+			`UPDATE system.replication_slots SET confirmed_flush_lsn = $2
+WHERE slot_name = $1 AND confirmed_flush_lsn < $2`,
+			s.slot, int64(flushed),
+		); err != nil {
+			return err
+		}
+		s.confirmed = flushed
+	}
+	if status.ReplyRequested {
+		return s.sendKeepalive(ctx, res, false /* replyRequested */)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Notify publishes a notification on a channel. The notification is written
+// in the current transaction, so it is only delivered to the listening
+// sessions if the transaction commits.
+func (p *planner) Notify(ctx context.Context, n *tree.Notify) (planNode, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_ListenNotify) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"NOTIFY is not supported until the cluster version is finalized")
+	}
+	channel, err := channelName(n.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	return &notifyNode{
+		notification: pgnotify.Notification{
+			Channel: channel,
+			Payload: n.Payload,
+			PID:     int32(p.EvalContext().QueryCancelKey.GetPGBackendPID()),
+		},
+	}, nil
+}
+
+type notifyNode struct {
+	notification pgnotify.Notification
+}
+
+func (n *notifyNode) startExec(params runParams) error {
+	return params.ExecCfg().NotificationRegistry.Notify(
+		params.ctx, params.p.InternalSQLTxn(), n.notification,
+	)
+}
+
+func (n *notifyNode) Next(runParams) (bool, error) { return false, nil }
+func (n *notifyNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *notifyNode) Close(context.Context)        {}
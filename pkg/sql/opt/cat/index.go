@@ -56,6 +56,19 @@ type Index interface {
 	// IsInverted returns true if this is an inverted index.
 	IsInverted() bool
 
+	// IsExclusion returns true if this index backs an exclusion constraint.
+	// Exclusion constraints are not enforced by the index itself, so a
+	// mutation of the table must check that its new rows do not conflict with
+	// any existing rows.
+	IsExclusion() bool
+
+	// ExclusionOperator returns the operator that the exclusion constraint
+	// backed by this index uses to compare the values of the ith column in the
+	// index, where i < ExplicitColumnCount. Two rows conflict if the operator
+	// returns true for all the explicit columns. Panics if IsExclusion returns
+	// false.
+	ExclusionOperator(i int) tree.ExclusionOperator
+
 	// GetInvisibility returns index invisibility.
 	GetInvisibility() float64
 
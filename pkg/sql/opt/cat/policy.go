@@ -0,0 +1,47 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cat
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// Policy is an interface to a row-level security policy defined on a table,
+// exposing only the information needed by the query optimizer.
+type Policy interface {
+	// Name is the name of the policy. It is unique within the table.
+	Name() tree.Name
+
+	// Type returns whether the policy is permissive or restrictive.
+	Type() tree.PolicyType
+
+	// Command returns the kind of statement to which the policy applies.
+	Command() tree.PolicyCommand
+
+	// RoleCount returns the number of roles to which the policy applies.
+	RoleCount() int
+
+	// Role returns the ith role to which the policy applies, where
+	// i < RoleCount. The role may be the public pseudo-role, in which case the
+	// policy applies to every user.
+	Role(i int) username.SQLUsername
+
+	// UsingExpr returns the serialized USING expression of the policy, which
+	// filters the existing rows visible to a statement. It returns the empty
+	// string if the policy has no USING expression.
+	UsingExpr() string
+
+	// WithCheckExpr returns the serialized WITH CHECK expression of the policy,
+	// which must hold for the rows written by a statement. It returns the empty
+	// string if the policy has no WITH CHECK expression.
+	WithCheckExpr() string
+}
@@ -139,6 +139,34 @@ type Table interface {
 	// i < UniqueCount.
 	Unique(i UniqueOrdinal) UniqueConstraint
 
+	// TriggerCount returns the number of triggers defined on this table.
+	TriggerCount() int
+
+	// Trigger returns the ith trigger defined on this table, where
+	// i < TriggerCount. Triggers are returned in the order they should fire.
+	Trigger(i int) Trigger
+
+	// IsRowLevelSecurityEnabled returns true if the table's row-level security
+	// policies are enforced.
+	IsRowLevelSecurityEnabled() bool
+
+	// IsRowLevelSecurityForced returns true if the table's row-level security
+	// policies are enforced even for the table owner.
+	IsRowLevelSecurityForced() bool
+
+	// PolicyCount returns the number of row-level security policies defined on
+	// this table.
+	PolicyCount() int
+
+	// Policy returns the ith row-level security policy defined on this table,
+	// where i < PolicyCount.
+	Policy(i int) Policy
+
+	// IsForeignTable returns true if the table is a foreign table, whose rows
+	// are read from a file in external storage rather than from the KV layer.
+	// Foreign tables can only be scanned in full, in primary index order.
+	IsForeignTable() bool
+
 	// Zone returns a table's zone.
 	Zone() Zone
 
@@ -314,6 +342,9 @@ type ForeignKeyConstraint interface {
 	// UpdateReferenceAction returns the action to be performed if the foreign key
 	// constraint would be violated by an update.
 	UpdateReferenceAction() tree.ReferenceAction
+	// Deferrability returns whether checks for this constraint may be deferred
+	// until the end of the transaction.
+	Deferrability() tree.ConstraintDeferrability
 }
 
 // UniqueConstraint represents a uniqueness constraint. UniqueConstraints may
@@ -361,6 +392,9 @@ type UniqueConstraint interface {
 	// satisfied when building functional dependencies for the table. This enables
 	// additional optimizations, such as omission of uniqueness checks.
 	UniquenessGuaranteedByAnotherIndex() bool
+	// Deferrability returns whether checks for this constraint may be deferred
+	// until the end of the transaction.
+	Deferrability() tree.ConstraintDeferrability
 }
 
 // UniqueOrdinal identifies a unique constraint (in the context of a Table).
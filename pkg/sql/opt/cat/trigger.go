@@ -0,0 +1,39 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package cat
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/lib/pq/oid"
+)
+
+// Trigger is an interface to a trigger defined on a table, exposing only the
+// information needed by the query optimizer.
+type Trigger interface {
+	// Name is the name of the trigger. It is unique within the table.
+	Name() tree.Name
+
+	// ActionTime returns whether the trigger fires before or after the
+	// triggering mutation is applied.
+	ActionTime() tree.TriggerActionTime
+
+	// HasEvent returns true if the trigger fires for the given kind of
+	// mutation.
+	HasEvent(event tree.TriggerEventType) bool
+
+	// ForEachRow returns true if the trigger fires once for each row affected by
+	// the mutation, and false if it fires once for each statement.
+	ForEachRow() bool
+
+	// FuncOID returns the OID of the user-defined function executed by the
+	// trigger.
+	FuncOID() oid.Oid
+}
@@ -160,6 +160,10 @@ func formatCatalogIndex(tab Table, ord int, tp treeprinter.Node, redactableValue
 			fmt.Fprintf(&buf, " desc")
 		}
 
+		if idx.IsExclusion() && i < idx.ExplicitColumnCount() {
+			fmt.Fprintf(&buf, " (exclude with %s)", idx.ExclusionOperator(i))
+		}
+
 		if i >= idx.LaxKeyColumnCount() {
 			fmt.Fprintf(&buf, " (storing)")
 		}
@@ -153,9 +153,15 @@ func makeCascadeBuilder(b *Builder, mutationWithID opt.WithID) (*cascadeBuilder,
 
 // setupCascade fills in an exec.Cascade struct for the given cascade.
 func (cb *cascadeBuilder) setupCascade(cascade *memo.FKCascade) exec.Cascade {
+	buffer := cb.mutationBuffer
+	if cascade.WithID == 0 {
+		// The cascade does not require the buffered input, e.g. a
+		// statement-level trigger.
+		buffer = nil
+	}
 	return exec.Cascade{
 		FKName: cascade.FKName,
-		Buffer: cb.mutationBuffer,
+		Buffer: buffer,
 		PlanFn: func(
 			ctx context.Context,
 			semaCtx *tree.SemaContext,
@@ -127,6 +127,10 @@ func (b *Builder) buildInsert(ins *memo.InsertExpr) (execPlan, error) {
 		return execPlan{}, err
 	}
 
+	if err := b.buildFKCascades(ins.WithID, ins.FKCascades); err != nil {
+		return execPlan{}, err
+	}
+
 	return ep, nil
 }
 
@@ -163,6 +167,12 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 		return execPlan{}, false, nil
 	}
 
+	// We cannot use the fast path if the insert has AFTER triggers, which are
+	// executed as cascades.
+	if len(ins.FKCascades) > 0 {
+		return execPlan{}, false, nil
+	}
+
 	md := b.mem.Metadata()
 	tab := md.Table(ins.Table)
 
@@ -176,6 +186,10 @@ func (b *Builder) tryBuildFastPathInsert(ins *memo.InsertExpr) (_ execPlan, ok b
 			return execPlan{}, false, nil
 		}
 		fk := tab.OutboundForeignKey(c.FKOrdinal)
+		if fk.Deferrability() != tree.ConstraintNotDeferrable {
+			// The fast path cannot defer the check until commit time.
+			return execPlan{}, false, nil
+		}
 		lookupJoin, isLookupJoin := c.Check.(*memo.LookupJoinExpr)
 		if !isLookupJoin || lookupJoin.JoinType != opt.AntiJoinOp {
 			// Not a lookup anti-join.
@@ -728,9 +742,23 @@ func (b *Builder) buildUniqueChecks(checks memo.UniqueChecksExpr) error {
 				}
 				keyVals[i] = row[ord]
 			}
+			if c.Exclusion {
+				return mkExclusionCheckErr(md, c, keyVals)
+			}
 			return mkUniqueCheckErr(md, c, keyVals)
 		}
-		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
+		var deferrable *exec.DeferrableCheck
+		tab := md.Table(c.Table)
+		// Exclusion constraints cannot be deferred.
+		if !c.Exclusion {
+			if uc := tab.Unique(c.CheckOrdinal); uc.Deferrability() != tree.ConstraintNotDeferrable {
+				deferrable, err = makeDeferrableCheck(uc.Name(), tab.ID(), uc.Deferrability(), &query, c.KeyCols)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr, deferrable)
 		if err != nil {
 			return err
 		}
@@ -760,7 +788,30 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 			}
 			return mkFKCheckErr(md, c, keyVals)
 		}
-		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr)
+		var deferrable *exec.DeferrableCheck
+		var fk cat.ForeignKeyConstraint
+		if c.FKOutbound {
+			fk = md.Table(c.OriginTable).OutboundForeignKey(c.FKOrdinal)
+		} else {
+			fk = md.Table(c.ReferencedTable).InboundForeignKey(c.FKOrdinal)
+		}
+		// As in Postgres, checks for RESTRICT actions are never deferred. For
+		// simplicity, removals from the referenced table are checked immediately
+		// if either action is RESTRICT.
+		canDefer := fk.Deferrability() != tree.ConstraintNotDeferrable
+		if !c.FKOutbound && (fk.DeleteReferenceAction() == tree.Restrict ||
+			fk.UpdateReferenceAction() == tree.Restrict) {
+			canDefer = false
+		}
+		if canDefer {
+			deferrable, err = makeDeferrableCheck(
+				fk.Name(), md.Table(c.OriginTable).ID(), fk.Deferrability(), &query, c.KeyCols,
+			)
+			if err != nil {
+				return err
+			}
+		}
+		node, err := b.factory.ConstructErrorIfRows(query.root, mkErr, deferrable)
 		if err != nil {
 			return err
 		}
@@ -769,6 +820,32 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 	return nil
 }
 
+// makeDeferrableCheck returns the information needed to defer a check for the
+// given DEFERRABLE constraint until the transaction commits. The keyCols are
+// the columns of the check query that form the constraint key.
+func makeDeferrableCheck(
+	name string,
+	tabID cat.StableID,
+	deferrability tree.ConstraintDeferrability,
+	query *execPlan,
+	keyCols opt.ColList,
+) (*exec.DeferrableCheck, error) {
+	res := &exec.DeferrableCheck{
+		ConstraintName:    name,
+		TableID:           tabID,
+		InitiallyDeferred: deferrability == tree.ConstraintInitiallyDeferred,
+		KeyCols:           make([]exec.NodeColumnOrdinal, len(keyCols)),
+	}
+	for i, col := range keyCols {
+		ord, err := query.getNodeColumnOrdinal(col)
+		if err != nil {
+			return nil, err
+		}
+		res.KeyCols[i] = ord
+	}
+	return res, nil
+}
+
 // mkUniqueCheckErr generates a user-friendly error describing a uniqueness
 // violation. The keyVals are the values that correspond to the
 // cat.UniqueConstraint columns.
@@ -811,6 +888,51 @@ func mkUniqueCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.D
 	)
 }
 
+// mkExclusionCheckErr generates a user-friendly error describing an exclusion
+// constraint violation. The keyVals are the values that correspond to the
+// columns of the index that backs the constraint.
+func mkExclusionCheckErr(md *opt.Metadata, c *memo.UniqueChecksItem, keyVals tree.Datums) error {
+	tabMeta := md.TableMeta(c.Table)
+	index := tabMeta.Table.Index(c.CheckOrdinal)
+	constraintName := string(index.Name())
+	var msg, details bytes.Buffer
+
+	// Generate an error of the form:
+	//   ERROR:  conflicting key value violates exclusion constraint "foo"
+	//   DETAIL: Key (k)=(2) conflicts with an existing key.
+	msg.WriteString("conflicting key value violates exclusion constraint ")
+	lexbase.EncodeEscapedSQLIdent(&msg, constraintName)
+
+	details.WriteString("Key (")
+	for i := 0; i < index.ExplicitColumnCount(); i++ {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		ord := index.Column(i).Ordinal()
+		if index.Column(i).Kind() == cat.Inverted {
+			ord = index.Column(i).InvertedSourceColumnOrdinal()
+		}
+		details.WriteString(string(tabMeta.Table.Column(ord).ColName()))
+	}
+	details.WriteString(")=(")
+	for i, d := range keyVals {
+		if i > 0 {
+			details.WriteString(", ")
+		}
+		details.WriteString(d.String())
+	}
+
+	details.WriteString(") conflicts with an existing key.")
+
+	return errors.WithDetail(
+		pgerror.WithConstraintName(
+			pgerror.Newf(pgcode.ExclusionViolation, "%s", msg.String()),
+			constraintName,
+		),
+		details.String(),
+	)
+}
+
 // mkFKCheckErr generates a user-friendly error describing a foreign key
 // violation. The keyVals are the values that correspond to the
 // cat.ForeignKeyConstraint columns.
@@ -228,6 +228,9 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.GroupByExpr, *memo.ScalarGroupByExpr:
 		ep, err = b.buildGroupBy(e)
 
+	case *memo.GroupingSetsExpr:
+		ep, err = b.buildGroupingSets(t)
+
 	case *memo.DistinctOnExpr, *memo.EnsureDistinctOnExpr, *memo.UpsertDistinctOnExpr,
 		*memo.EnsureUpsertDistinctOnExpr:
 		ep, err = b.buildDistinct(t)
@@ -298,6 +301,9 @@ func (b *Builder) buildRelational(e memo.RelExpr) (execPlan, error) {
 	case *memo.CreateFunctionExpr:
 		ep, err = b.buildCreateFunction(t)
 
+	case *memo.CallExpr:
+		ep, err = b.buildCall(t)
+
 	case *memo.WithExpr:
 		ep, err = b.buildWith(t)
 
@@ -1544,7 +1550,88 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 		groupingColIdx = append(groupingColIdx, ord)
 	}
 
-	aggregations := *groupBy.Child(1).(*memo.AggregationsExpr)
+	aggInfos, err := b.buildAggInfos(
+		input, *groupBy.Child(1).(*memo.AggregationsExpr), &ep.outputCols, len(groupingColIdx),
+	)
+	if err != nil {
+		return execPlan{}, err
+	}
+
+	if groupBy.Op() == opt.ScalarGroupByOp {
+		ep.root, err = b.factory.ConstructScalarGroupBy(input.root, aggInfos)
+	} else {
+		groupBy := groupBy.(*memo.GroupByExpr)
+		var groupingColOrder colinfo.ColumnOrdering
+		groupingColOrder, err = input.sqlOrdering(ordering.StreamingGroupingColOrdering(
+			&groupBy.GroupingPrivate, &groupBy.RequiredPhysical().Ordering,
+		))
+		if err != nil {
+			return execPlan{}, err
+		}
+		var reqOrdering exec.OutputOrdering
+		reqOrdering, err = ep.reqOrdering(groupBy)
+		if err != nil {
+			return execPlan{}, err
+		}
+		orderType := exec.GroupingOrderType(groupBy.GroupingOrderType(&groupBy.RequiredPhysical().Ordering))
+		ep.root, err = b.factory.ConstructGroupBy(
+			input.root, groupingColIdx, groupingColOrder, aggInfos, reqOrdering, orderType,
+		)
+	}
+	if err != nil {
+		return execPlan{}, err
+	}
+	return ep, nil
+}
+
+func (b *Builder) buildGroupingSets(groupingSets *memo.GroupingSetsExpr) (execPlan, error) {
+	input, err := b.buildGroupByInput(groupingSets)
+	if err != nil {
+		return execPlan{}, err
+	}
+
+	var ep execPlan
+	private := &groupingSets.GroupingSetsPrivate
+	groupingColIdx := make([]exec.NodeColumnOrdinal, 0, private.GroupingCols.Len())
+	for i, ok := private.GroupingCols.Next(0); ok; i, ok = private.GroupingCols.Next(i + 1) {
+		ep.outputCols.Set(int(i), len(groupingColIdx))
+		ord, err := input.getNodeColumnOrdinal(i)
+		if err != nil {
+			return execPlan{}, err
+		}
+		groupingColIdx = append(groupingColIdx, ord)
+	}
+
+	sets := make([]exec.NodeColumnOrdinalSet, len(private.Sets))
+	for i := range private.Sets {
+		sets[i], err = input.getNodeColumnOrdinalSet(private.Sets[i])
+		if err != nil {
+			return execPlan{}, err
+		}
+	}
+
+	aggInfos, err := b.buildAggInfos(
+		input, groupingSets.Aggregations, &ep.outputCols, len(groupingColIdx),
+	)
+	if err != nil {
+		return execPlan{}, err
+	}
+	// The grouping set ordinal is the last output column.
+	ep.outputCols.Set(int(private.GroupingIDCol), len(groupingColIdx)+len(aggInfos))
+
+	ep.root, err = b.factory.ConstructGroupingSets(input.root, groupingColIdx, sets, aggInfos)
+	if err != nil {
+		return execPlan{}, err
+	}
+	return ep, nil
+}
+
+// buildAggInfos builds the exec.AggInfo for each aggregation, resolving
+// argument and filter columns against the input. The output column of the
+// i-th aggregation is recorded in outputCols at ordinal firstOutputOrd+i.
+func (b *Builder) buildAggInfos(
+	input execPlan, aggregations memo.AggregationsExpr, outputCols *opt.ColMap, firstOutputOrd int,
+) ([]exec.AggInfo, error) {
 	aggInfos := make([]exec.AggInfo, len(aggregations))
 	for i := range aggregations {
 		item := &aggregations[i]
@@ -1554,11 +1641,12 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 		if aggFilter, ok := agg.(*memo.AggFilterExpr); ok {
 			filter, ok := aggFilter.Filter.(*memo.VariableExpr)
 			if !ok {
-				return execPlan{}, errors.AssertionFailedf("only VariableOp args supported")
+				return nil, errors.AssertionFailedf("only VariableOp args supported")
 			}
+			var err error
 			filterOrd, err = input.getNodeColumnOrdinal(filter.Col)
 			if err != nil {
-				return execPlan{}, err
+				return nil, err
 			}
 			agg = aggFilter.Input
 		}
@@ -1569,67 +1657,52 @@ func (b *Builder) buildGroupBy(groupBy memo.RelExpr) (execPlan, error) {
 			agg = aggDistinct.Input
 		}
 
-		name, _ := memo.FindAggregateOverload(agg)
+		var name string
+		var userDefined *exec.UserDefinedAggInfo
+		var args opt.Expr = agg
+		if uda, ok := agg.(*memo.UserDefinedAggExpr); ok {
+			name = uda.Name
+			userDefined = b.buildUserDefinedAggInfo(uda)
+			args = &uda.Args
+		} else {
+			name, _ = memo.FindAggregateOverload(agg)
+		}
 
 		// Accumulate variable arguments in argCols and constant arguments in
 		// constArgs. Constant arguments must follow variable arguments.
 		var argCols []exec.NodeColumnOrdinal
 		var constArgs tree.Datums
-		for j, n := 0, agg.ChildCount(); j < n; j++ {
-			child := agg.Child(j)
+		for j, n := 0, args.ChildCount(); j < n; j++ {
+			child := args.Child(j)
 			if variable, ok := child.(*memo.VariableExpr); ok {
 				if len(constArgs) != 0 {
-					return execPlan{}, errors.Errorf("constant args must come after variable args")
+					return nil, errors.Errorf("constant args must come after variable args")
 				}
 				ord, err := input.getNodeColumnOrdinal(variable.Col)
 				if err != nil {
-					return execPlan{}, err
+					return nil, err
 				}
 				argCols = append(argCols, ord)
 			} else {
 				if len(argCols) == 0 {
-					return execPlan{}, errors.Errorf("a constant arg requires at least one variable arg")
+					return nil, errors.Errorf("a constant arg requires at least one variable arg")
 				}
 				constArgs = append(constArgs, memo.ExtractConstDatum(child))
 			}
 		}
 
 		aggInfos[i] = exec.AggInfo{
-			FuncName:   name,
-			Distinct:   distinct,
-			ResultType: item.Agg.DataType(),
-			ArgCols:    argCols,
-			ConstArgs:  constArgs,
-			Filter:     filterOrd,
-		}
-		ep.outputCols.Set(int(item.Col), len(groupingColIdx)+i)
-	}
-
-	if groupBy.Op() == opt.ScalarGroupByOp {
-		ep.root, err = b.factory.ConstructScalarGroupBy(input.root, aggInfos)
-	} else {
-		groupBy := groupBy.(*memo.GroupByExpr)
-		var groupingColOrder colinfo.ColumnOrdering
-		groupingColOrder, err = input.sqlOrdering(ordering.StreamingGroupingColOrdering(
-			&groupBy.GroupingPrivate, &groupBy.RequiredPhysical().Ordering,
-		))
-		if err != nil {
-			return execPlan{}, err
-		}
-		var reqOrdering exec.OutputOrdering
-		reqOrdering, err = ep.reqOrdering(groupBy)
-		if err != nil {
-			return execPlan{}, err
+			FuncName:    name,
+			Distinct:    distinct,
+			ResultType:  item.Agg.DataType(),
+			ArgCols:     argCols,
+			ConstArgs:   constArgs,
+			Filter:      filterOrd,
+			UserDefined: userDefined,
 		}
-		orderType := exec.GroupingOrderType(groupBy.GroupingOrderType(&groupBy.RequiredPhysical().Ordering))
-		ep.root, err = b.factory.ConstructGroupBy(
-			input.root, groupingColIdx, groupingColOrder, aggInfos, reqOrdering, orderType,
-		)
+		outputCols.Set(int(item.Col), firstOutputOrd+i)
 	}
-	if err != nil {
-		return execPlan{}, err
-	}
-	return ep, nil
+	return aggInfos, nil
 }
 
 func (b *Builder) buildDistinct(distinct memo.RelExpr) (execPlan, error) {
@@ -1697,8 +1770,15 @@ func (b *Builder) buildGroupByInput(groupBy memo.RelExpr) (execPlan, error) {
 	// We address just the GroupBy case for now because there is a particularly
 	// important case with COUNT(*) where we can remove all input columns, which
 	// leads to significant speedup.
-	private := groupBy.Private().(*memo.GroupingPrivate)
-	neededCols := private.GroupingCols.Copy()
+	var neededCols opt.ColSet
+	switch private := groupBy.Private().(type) {
+	case *memo.GroupingPrivate:
+		neededCols = private.GroupingCols.Copy()
+	case *memo.GroupingSetsPrivate:
+		neededCols = private.GroupingCols.Copy()
+	default:
+		return execPlan{}, errors.AssertionFailedf("unexpected private %T", private)
+	}
 	aggs := *groupBy.Child(1).(*memo.AggregationsExpr)
 	for i := range aggs {
 		neededCols.UnionWith(memo.ExtractAggInputColumns(aggs[i].Agg))
@@ -3243,20 +3323,43 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 	filterIdxs := make([]int, len(w.Windows))
 	exprs := make([]*tree.FuncExpr, len(w.Windows))
 	windowVals := make([]tree.WindowDef, len(w.Windows))
+	var userDefinedAggs []*exec.UserDefinedAggInfo
 
 	for i := range w.Windows {
 		item := &w.Windows[i]
 		fn := b.extractWindowFunction(item.Function)
-		name, overload := memo.FindWindowOverload(fn)
-		if !b.disableTelemetry {
-			telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+
+		var name string
+		var overload *tree.Overload
+		var props *tree.FunctionProperties
+		var fnArgs opt.Expr = fn
+		uda, isUserDefinedAgg := fn.(*memo.UserDefinedAggExpr)
+		if isUserDefinedAgg {
+			// User-defined aggregates are not builtins, so there are no
+			// properties to look up. The overload only carries the class and
+			// the return type, which is all that is needed for planning.
+			name = uda.Name
+			overload = &tree.Overload{
+				Class:      tree.AggregateClass,
+				ReturnType: tree.FixedReturnType(uda.Typ),
+			}
+			fnArgs = &uda.Args
+			if userDefinedAggs == nil {
+				userDefinedAggs = make([]*exec.UserDefinedAggInfo, len(w.Windows))
+			}
+			userDefinedAggs[i] = b.buildUserDefinedAggInfo(uda)
+		} else {
+			name, overload = memo.FindWindowOverload(fn)
+			if !b.disableTelemetry {
+				telemetry.Inc(sqltelemetry.WindowFunctionCounter(name))
+			}
+			props, _ = builtinsregistry.GetBuiltinProperties(name)
 		}
-		props, _ := builtinsregistry.GetBuiltinProperties(name)
 
-		args := make([]tree.TypedExpr, fn.ChildCount())
-		argIdxs[i] = make([]exec.NodeColumnOrdinal, fn.ChildCount())
-		for j, n := 0, fn.ChildCount(); j < n; j++ {
-			col := fn.Child(j).(*memo.VariableExpr).Col
+		args := make([]tree.TypedExpr, fnArgs.ChildCount())
+		argIdxs[i] = make([]exec.NodeColumnOrdinal, fnArgs.ChildCount())
+		for j, n := 0, fnArgs.ChildCount(); j < n; j++ {
+			col := fnArgs.Child(j).(*memo.VariableExpr).Col
 			indexedVar, err := b.indexedVar(&ctx, b.mem.Metadata(), col)
 			if err != nil {
 				return execPlan{}, err
@@ -3294,9 +3397,16 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 			OrderBy:    orderingExprs,
 			Frame:      frame,
 		}
-		wrappedFn, err := b.wrapFunction(name)
-		if err != nil {
-			return execPlan{}, err
+		var wrappedFn tree.ResolvableFunctionReference
+		if isUserDefinedAgg {
+			wrappedFn = tree.ResolvableFunctionReference{
+				FunctionReference: &tree.ResolvedFunctionDefinition{Name: name},
+			}
+		} else {
+			wrappedFn, err = b.wrapFunction(name)
+			if err != nil {
+				return execPlan{}, err
+			}
 		}
 		exprs[i] = tree.NewTypedFuncExpr(
 			wrappedFn,
@@ -3349,6 +3459,8 @@ func (b *Builder) buildWindow(w *memo.WindowExpr) (execPlan, error) {
 		FilterIdxs: filterIdxs,
 		Partition:  partitionIdxs,
 		Ordering:   sqlOrdering,
+
+		UserDefinedAggs: userDefinedAggs,
 	})
 	if err != nil {
 		return execPlan{}, err
@@ -939,8 +939,8 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 	// Create a tree.RoutinePlanFn that can plan the statements in the UDF body.
 	// TODO(mgartner): Add support for WITH expressions inside UDF bodies.
 	planGen := b.buildRoutinePlanGenerator(
-		udf.Params,
-		udf.Body,
+		udf.Def.Params,
+		udf.Def.Body,
 		false, /* allowOuterWithRefs */
 		nil,   /* wrapRootExpr */
 	)
@@ -950,7 +950,36 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 	// statements.
 	enableStepping := udf.Volatility == volatility.Volatile
 
-	return tree.NewTypedRoutineExpr(
+	// Build a routine for each exception handler. The handlers are invoked
+	// with the same arguments as the routine that defines the exception block.
+	var exceptionHandler *tree.RoutineExceptionHandler
+	if udf.Def.ExceptionBlock != nil {
+		block := udf.Def.ExceptionBlock
+		exceptionHandler = &tree.RoutineExceptionHandler{
+			Codes:   block.Codes,
+			Actions: make([]*tree.RoutineExpr, len(block.Actions)),
+		}
+		for i, action := range block.Actions {
+			actionPlanGen := b.buildRoutinePlanGenerator(
+				action.Params,
+				action.Body,
+				false, /* allowOuterWithRefs */
+				nil,   /* wrapRootExpr */
+			)
+			exceptionHandler.Actions[i] = tree.NewTypedRoutineExpr(
+				udf.Name,
+				nil, /* args */
+				actionPlanGen,
+				udf.Typ,
+				enableStepping,
+				true,  /* calledOnNullInput */
+				false, /* multiColOutput */
+				false, /* generator */
+			)
+		}
+	}
+
+	routine := tree.NewTypedRoutineExpr(
 		udf.Name,
 		args,
 		planGen,
@@ -959,7 +988,44 @@ func (b *Builder) buildUDF(ctx *buildScalarCtx, scalar opt.ScalarExpr) (tree.Typ
 		udf.CalledOnNullInput,
 		udf.MultiColDataSource,
 		udf.SetReturning,
-	), nil
+	)
+	routine.ExceptionHandler = exceptionHandler
+	return routine, nil
+}
+
+// buildUserDefinedAggInfo builds the routines that implement a user-defined
+// aggregate. The routines are built without arguments; the aggregate supplies
+// the argument datums directly when it invokes them.
+func (b *Builder) buildUserDefinedAggInfo(agg *memo.UserDefinedAggExpr) *exec.UserDefinedAggInfo {
+	info := &exec.UserDefinedAggInfo{
+		Transition: b.buildAggSupportRoutine(agg.Def.Transition),
+		InitCond:   agg.Def.InitCond,
+	}
+	if agg.Def.Final != nil {
+		info.Final = b.buildAggSupportRoutine(agg.Def.Final)
+	}
+	return info
+}
+
+// buildAggSupportRoutine builds a routine for one of the support functions of
+// a user-defined aggregate.
+func (b *Builder) buildAggSupportRoutine(udf *memo.UDFPrivate) *tree.RoutineExpr {
+	planGen := b.buildRoutinePlanGenerator(
+		udf.Def.Params,
+		udf.Def.Body,
+		false, /* allowOuterWithRefs */
+		nil,   /* wrapRootExpr */
+	)
+	return tree.NewTypedRoutineExpr(
+		udf.Name,
+		nil, /* args */
+		planGen,
+		udf.Typ,
+		udf.Volatility == volatility.Volatile, /* enableStepping */
+		udf.CalledOnNullInput,
+		false, /* multiColOutput */
+		false, /* generator */
+	)
 }
 
 type wrapRootExprFn func(f *norm.Factory, e memo.RelExpr) opt.Expr
@@ -1063,7 +1129,12 @@ func (b *Builder) buildRoutinePlanGenerator(
 					// We lazily add these With expressions to the metadata here
 					// because the call to Factory.CopyAndReplace below clears With
 					// expressions in the metadata.
-					if allowOuterWithRefs && !addedWithBindings {
+					//
+					// The With expressions must also be added when outer references
+					// are not allowed, because a mutation within the statement may
+					// have FK checks or cascades that refer to the mutation's own
+					// With binding.
+					if !addedWithBindings {
 						b.mem.Metadata().ForEachWithBinding(func(id opt.WithID, expr opt.Expr) {
 							f.Metadata().AddWithBinding(id, expr)
 						})
@@ -1108,12 +1179,8 @@ func (b *Builder) buildRoutinePlanGenerator(
 			if len(eb.subqueries) > 0 {
 				return expectedLazyRoutineError("subquery")
 			}
-			if len(eb.cascades) > 0 {
-				return expectedLazyRoutineError("cascade")
-			}
-			if len(eb.checks) > 0 {
-				return expectedLazyRoutineError("check")
-			}
+			// Cascades and checks of mutations within the routine are part of
+			// the plan and are executed after the main query of the plan.
 			isFinalPlan := i == len(stmts)-1
 			err = fn(plan, isFinalPlan)
 			if err != nil {
@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/util/treeprinter"
+	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 )
 
@@ -87,6 +88,24 @@ func (b *Builder) buildCreateFunction(cf *memo.CreateFunctionExpr) (execPlan, er
 	return execPlan{root: root}, err
 }
 
+func (b *Builder) buildCall(c *memo.CallExpr) (execPlan, error) {
+	scalarCtx := buildScalarCtx{}
+	proc, err := b.buildScalar(&scalarCtx, c.Proc)
+	if err != nil {
+		return execPlan{}, err
+	}
+	r, ok := proc.(*tree.RoutineExpr)
+	if !ok {
+		return execPlan{}, errors.AssertionFailedf("expected a RoutineExpr, found %T", proc)
+	}
+	root, err := b.factory.ConstructCall(r)
+	if err != nil {
+		return execPlan{}, err
+	}
+	// Call returns no columns.
+	return execPlan{root: root}, nil
+}
+
 func (b *Builder) buildExplainOpt(explain *memo.ExplainExpr) (execPlan, error) {
 	fmtFlags := memo.ExprFmtHideAll
 	switch {
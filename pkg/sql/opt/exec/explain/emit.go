@@ -286,6 +286,7 @@ var nodeNames = [...]string{
 	alterTableUnsplitOp:    "unsplit",
 	applyJoinOp:            "", // This node does not have a fixed name.
 	bufferOp:               "buffer",
+	callOp:                 "call",
 	cancelQueriesOp:        "cancel queries",
 	cancelSessionsOp:       "cancel sessions",
 	controlJobsOp:          "control jobs",
@@ -304,6 +305,7 @@ var nodeNames = [...]string{
 	exportOp:               "export",
 	filterOp:               "filter",
 	groupByOp:              "", // This node does not have a fixed name.
+	groupingSetsOp:         "group (grouping sets)",
 	hashJoinOp:             "", // This node does not have a fixed name.
 	indexJoinOp:            "index join",
 	insertFastPathOp:       "insert fast path",
@@ -660,6 +662,18 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 			a.Aggregations, nil /* groupCols */, nil /* groupColOrdering */, true, /* isScalar */
 		)
 
+	case groupingSetsOp:
+		a := n.args.(*groupingSetsArgs)
+		inputCols := a.Input.Columns()
+		e.emitGroupByAttributes(
+			inputCols, a.Aggregations, a.GroupCols, nil /* groupColOrdering */, false, /* isScalar */
+		)
+		sets := make([]string, len(a.GroupingSets))
+		for i, set := range a.GroupingSets {
+			sets[i] = fmt.Sprintf("(%s)", printColumnSet(inputCols, set))
+		}
+		ob.Attr("grouping sets", strings.Join(sets, ", "))
+
 	case distinctOp:
 		a := n.args.(*distinctArgs)
 		inputCols := a.Input.Columns()
@@ -951,6 +965,10 @@ func (e *emitter) emitNodeAttributes(n *Node) error {
 			ob.Expr("from", a.fromStoreID, nil /* columns */)
 		}
 
+	case callOp:
+		a := n.args.(*callArgs)
+		ob.Expr("procedure", a.Proc, nil /* columns */)
+
 	case simpleProjectOp,
 		serializingProjectOp,
 		ordinalityOp,
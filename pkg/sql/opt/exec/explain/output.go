@@ -298,6 +298,12 @@ func (ob *OutputBuilder) AddVectorized(value bool) {
 	ob.AddFlakyTopLevelField(DeflakeVectorized, "vectorized", fmt.Sprintf("%t", value))
 }
 
+// AddPlanType adds a top-level field for the type of query plan, either
+// "generic" or "custom". Cannot be called while inside a node.
+func (ob *OutputBuilder) AddPlanType(value string) {
+	ob.AddTopLevelField("plan type", value)
+}
+
 // AddPlanningTime adds a top-level planning time field. Cannot be called
 // while inside a node.
 func (ob *OutputBuilder) AddPlanningTime(delta time.Duration) {
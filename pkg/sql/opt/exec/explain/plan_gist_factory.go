@@ -575,6 +575,34 @@ func (u *unknownTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	panic(errors.AssertionFailedf("not implemented"))
 }
 
+func (u *unknownTable) TriggerCount() int {
+	return 0
+}
+
+func (u *unknownTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
+func (u *unknownTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+func (u *unknownTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+func (u *unknownTable) IsForeignTable() bool {
+	return false
+}
+
+func (u *unknownTable) PolicyCount() int {
+	return 0
+}
+
+func (u *unknownTable) Policy(i int) cat.Policy {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
 func (u *unknownTable) Zone() cat.Zone {
 	return cat.EmptyZone()
 }
@@ -648,6 +676,14 @@ func (u *unknownIndex) IsInverted() bool {
 	return false
 }
 
+func (u *unknownIndex) IsExclusion() bool {
+	return false
+}
+
+func (u *unknownIndex) ExclusionOperator(i int) tree.ExclusionOperator {
+	panic(errors.AssertionFailedf("not implemented"))
+}
+
 func (u *unknownIndex) GetInvisibility() float64 {
 	return 0.0
 }
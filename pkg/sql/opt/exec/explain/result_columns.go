@@ -102,6 +102,13 @@ func getResultColumns(
 		a := args.(*scalarGroupByArgs)
 		return groupByColumns(inputs[0], nil /* groupCols */, a.Aggregations), nil
 
+	case groupingSetsOp:
+		a := args.(*groupingSetsArgs)
+		return appendColumns(
+			groupByColumns(inputs[0], a.GroupCols, a.Aggregations),
+			colinfo.ResultColumn{Name: "grouping_set", Typ: types.Int},
+		), nil
+
 	case windowOp:
 		return args.(*windowArgs).Window.Cols, nil
 
@@ -193,7 +200,7 @@ func getResultColumns(
 
 	case createTableOp, createTableAsOp, createViewOp, controlJobsOp, controlSchedulesOp,
 		cancelQueriesOp, cancelSessionsOp, createStatisticsOp, errorIfRowsOp, deleteRangeOp,
-		createFunctionOp:
+		createFunctionOp, callOp:
 		// These operations produce no columns.
 		return nil, nil
 
@@ -154,6 +154,26 @@ type AggInfo struct {
 	// Filter is the index of the column, if any, which should be used as the
 	// FILTER condition for the aggregate. If there is no filter, Filter is -1.
 	Filter NodeColumnOrdinal
+
+	// UserDefined is set if the aggregate is a user-defined aggregate created
+	// with CREATE AGGREGATE. In that case FuncName is informational only.
+	UserDefined *UserDefinedAggInfo
+}
+
+// UserDefinedAggInfo describes a user-defined aggregate in terms of the
+// routines that implement it.
+type UserDefinedAggInfo struct {
+	// Transition is the state transition routine. It takes the current state
+	// followed by the aggregate arguments and returns the new state.
+	Transition *tree.RoutineExpr
+
+	// Final, if non-nil, is applied to the final state to produce the result
+	// of the aggregate.
+	Final *tree.RoutineExpr
+
+	// InitCond is the initial state of the aggregate. It is DNull if no
+	// initial condition was specified.
+	InitCond tree.Datum
 }
 
 // WindowInfo represents the information about a window function that must be
@@ -183,6 +203,10 @@ type WindowInfo struct {
 
 	// Ordering is the set of input columns to order on.
 	Ordering colinfo.ColumnOrdering
+
+	// UserDefinedAggs is the list of user-defined aggregate descriptions, in
+	// the same order as Exprs. Entries are nil for built-in functions.
+	UserDefinedAggs []*UserDefinedAggInfo
 }
 
 // ExplainEnvData represents the data that's going to be displayed in EXPLAIN (env).
@@ -273,6 +297,29 @@ type InsertFastPathFKCheck struct {
 // relevant row.
 type MkErrFn func(tree.Datums) error
 
+// DeferrableCheck contains information about a FK or uniqueness check for a
+// constraint that was declared DEFERRABLE (see ConstructErrorIfRows). If the
+// constraint is deferred when the check runs, the violations are recorded
+// instead of returned as an error, and they are checked again when the
+// transaction commits.
+type DeferrableCheck struct {
+	// ConstraintName is the name of the constraint.
+	ConstraintName string
+
+	// TableID is the ID of the table on which the constraint is defined. For
+	// foreign keys, this is the origin (referencing) table.
+	TableID cat.StableID
+
+	// InitiallyDeferred is true if the constraint was declared INITIALLY
+	// DEFERRED, i.e. it is deferred unless SET CONSTRAINTS says otherwise.
+	InitiallyDeferred bool
+
+	// KeyCols are the input columns that contain the constraint key of each
+	// violating row. For foreign keys, the key values correspond to the FK
+	// columns, in the order of the constraint.
+	KeyCols []NodeColumnOrdinal
+}
+
 // ExplainFactory is an extension of Factory used when constructing a plan that
 // can be explained. It allows annotation of nodes with extra information.
 type ExplainFactory interface {
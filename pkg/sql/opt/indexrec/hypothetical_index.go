@@ -115,6 +115,16 @@ func (hi *hypotheticalIndex) IsInverted() bool {
 	return hi.inverted
 }
 
+// IsExclusion is part of the cat.Index interface.
+func (hi *hypotheticalIndex) IsExclusion() bool {
+	return false
+}
+
+// ExclusionOperator is part of the cat.Index interface.
+func (hi *hypotheticalIndex) ExclusionOperator(i int) tree.ExclusionOperator {
+	panic(errors.AssertionFailedf("hypothetical indexes do not back exclusion constraints"))
+}
+
 // GetInvisibility is part of the cat.Index interface.
 func (hi *hypotheticalIndex) GetInvisibility() float64 {
 	// A hypotheticalIndex should not be invisible because there is no motivation
@@ -203,6 +203,21 @@ func (m *Memo) CheckExpr(e opt.Expr) {
 			}
 		}
 
+	case *GroupingSetsExpr:
+		if len(t.Sets) < 2 {
+			panic(errors.AssertionFailedf("grouping sets with fewer than two sets"))
+		}
+		for _, set := range t.Sets {
+			if !set.SubsetOf(t.GroupingCols) {
+				panic(errors.AssertionFailedf(
+					"grouping set %s is not a subset of grouping columns %s", set, t.GroupingCols,
+				))
+			}
+		}
+		if t.Input.Relational().OutputCols.Contains(t.GroupingIDCol) {
+			panic(errors.AssertionFailedf("grouping ID column is an input column"))
+		}
+
 	case *IndexJoinExpr:
 		if t.Cols.Empty() {
 			panic(errors.AssertionFailedf("index join with no columns"))
@@ -22,6 +22,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props/physical"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treewindow"
@@ -1228,8 +1229,10 @@ type FKCascades []FKCascade
 
 // FKCascade stores metadata necessary for building a cascading query.
 // Cascading queries are built as needed, after the original query is executed.
+// AFTER triggers are also executed as cascading queries.
 type FKCascade struct {
-	// FKName is the name of the FK constraint.
+	// FKName is the name of the FK constraint, or the name of the trigger if
+	// the cascade executes an AFTER trigger.
 	FKName string
 
 	// Builder is an object that can be used as the "optbuilder" for the cascading
@@ -1237,7 +1240,8 @@ type FKCascade struct {
 	Builder CascadeBuilder
 
 	// WithID identifies the buffer for the mutation input in the original
-	// expression tree. 0 if the cascade does not require input.
+	// expression tree. 0 if the cascade does not require input. A cascade that
+	// does not require input is executed even if no rows were modified.
 	WithID opt.WithID
 
 	// OldValues are column IDs from the mutation input that correspond to the
@@ -1328,6 +1332,10 @@ func (g *GroupingPrivate) GroupingOrderType(required *props.OrderingChoice) Grou
 	return PartialStreaming
 }
 
+// GroupingSetList is the list of grouping sets of a GroupingSets operator.
+// Each grouping set is a subset of the grouping columns.
+type GroupingSetList []opt.ColSet
+
 // IsConstantsAndPlaceholders returns true if all values in the list are
 // constant, placeholders or tuples containing constants, placeholders or other
 // such nested tuples.
@@ -1408,3 +1416,66 @@ func (e ScalarListExpr) isConstantsAndPlaceholdersAndVariables(insideTuple bool)
 	}
 	return true
 }
+
+// UDFDefinition stores the details of a user-defined function that may be
+// shared between multiple invocations of the function, including recursive
+// invocations from within its own body. Recursive functions are built by the
+// optbuilder for PL/pgSQL loops, in which case the definition is referenced
+// from its own body. For this reason, UDFDefinition is interned by pointer
+// rather than by value, and the body must only be traversed when necessary.
+type UDFDefinition struct {
+	// Body contains a relational expression for each statement in the function
+	// body. It is set once the body has been built, which may be after the
+	// definition is referenced by recursive invocations within the body.
+	Body RelListExpr
+
+	// Params is the list of columns representing parameters of the function.
+	// The i-th column in the list corresponds to the i-th parameter of the
+	// function. During execution of the UDF, these columns are replaced with
+	// the arguments of the function invocation.
+	Params opt.ColList
+
+	// IsRecursive is true if the function body contains an invocation of the
+	// function itself. Recursive functions cannot be inlined, and their bodies
+	// are only formatted once.
+	IsRecursive bool
+
+	// ExceptionBlock, if non-nil, contains the handlers for errors that are
+	// raised while the function body is executing. It is only set for
+	// functions built from PL/pgSQL blocks with an EXCEPTION section.
+	ExceptionBlock *ExceptionBlock
+}
+
+// ExceptionBlock contains the information needed to catch errors raised by the
+// body of a PL/pgSQL block, and to execute the matching handler instead.
+type ExceptionBlock struct {
+	// Codes is a list of the error codes that are handled by the block. The
+	// special code "OTHERS" matches all errors except for query cancellations
+	// and assertion failures.
+	Codes []pgcode.Code
+
+	// Actions contains a handler for each error code in Codes. Each handler is
+	// a function that has the same parameters as the function that defines the
+	// exception block, and is invoked with the same arguments.
+	Actions []*UDFDefinition
+}
+
+// UserDefinedAggDefinition stores the details of an aggregate function that
+// was created with CREATE AGGREGATE. The aggregate is computed by invoking the
+// transition function once for each input row with the current state and the
+// arguments of the row, and then invoking the final function, if any, with
+// the last state. Like UDFDefinition, it is interned by pointer.
+type UserDefinedAggDefinition struct {
+	// Transition is the state transition function of the aggregate. Its first
+	// parameter is the current state, and the remaining parameters are the
+	// arguments of the aggregate.
+	Transition *UDFPrivate
+
+	// Final is the function that computes the result of the aggregate from the
+	// final state. It is nil if the result of the aggregate is the final state.
+	Final *UDFPrivate
+
+	// InitCond is the initial state of the aggregate. It is DNull if the
+	// aggregate has no initial condition.
+	InitCond tree.Datum
+}
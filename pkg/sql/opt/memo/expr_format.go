@@ -160,6 +160,10 @@ type ExprFmtCtx struct {
 	// correspond to the tables that would be saved if the query were run
 	// with the session variable `save_tables_prefix` set to the same value.
 	nameGen *ExprNameGenerator
+
+	// seenUDFs is used to ensure that the body of a recursive UDF is only
+	// formatted once.
+	seenUDFs map[*UDFDefinition]struct{}
 }
 
 // makeExprFmtCtxForString creates an expression formatting context from a new
@@ -375,6 +379,27 @@ func (f *ExprFmtCtx) formatRelational(e RelExpr, tp treeprinter.Node) {
 			tp.Childf("error: \"%s\"", private.ErrorOnDup)
 		}
 
+	case *GroupingSetsExpr:
+		if !f.HasFlags(ExprFmtHideColumns) {
+			f.formatRelColList(e, tp, "grouping columns:", t.GroupingCols.ToList())
+			sets := tp.Child("grouping sets:")
+			for _, set := range t.Sets {
+				f.Buffer.Reset()
+				f.Buffer.WriteByte('(')
+				first := true
+				set.ForEach(func(col opt.ColumnID) {
+					if !first {
+						f.space()
+					}
+					first = false
+					f.formatCol("" /* label */, col, opt.ColSet{} /* notNullCols */)
+				})
+				f.Buffer.WriteByte(')')
+				sets.Child(f.Buffer.String())
+			}
+			f.formatRelColList(e, tp, "grouping id:", opt.ColList{t.GroupingIDCol})
+		}
+
 	case *TopKExpr:
 		if !f.HasFlags(ExprFmtHidePhysProps) && !t.Ordering.Any() {
 			tp.Childf("internal-ordering: %s", t.Ordering)
@@ -923,16 +948,37 @@ func (f *ExprFmtCtx) formatScalarWithLabel(
 		if !udf.CalledOnNullInput {
 			tp.Child("strict")
 		}
-		if len(udf.Params) > 0 {
-			f.formatColList(tp, "params:", udf.Params, opt.ColSet{} /* notNullCols */)
+		if len(udf.Def.Params) > 0 {
+			f.formatColList(tp, "params:", udf.Def.Params, opt.ColSet{} /* notNullCols */)
 			n = tp.Child("args")
 			for i := range udf.Args {
 				f.formatExpr(udf.Args[i], n)
 			}
 		}
+		if udf.Def.IsRecursive {
+			// Only format the body of a recursive function once, to avoid
+			// infinite recursion.
+			if _, ok := f.seenUDFs[udf.Def]; ok {
+				tp.Child("recursive-call")
+				return
+			}
+			if f.seenUDFs == nil {
+				f.seenUDFs = make(map[*UDFDefinition]struct{})
+			}
+			f.seenUDFs[udf.Def] = struct{}{}
+		}
 		n = tp.Child("body")
-		for i := range udf.Body {
-			f.formatExpr(udf.Body[i], n)
+		for i := range udf.Def.Body {
+			f.formatExpr(udf.Def.Body[i], n)
+		}
+		if udf.Def.ExceptionBlock != nil {
+			n = tp.Child("exception-handler")
+			for i, code := range udf.Def.ExceptionBlock.Codes {
+				body := n.Childf("SQLSTATE '%s'", code.String())
+				for _, stmt := range udf.Def.ExceptionBlock.Actions[i].Body {
+					f.formatExpr(stmt, body)
+				}
+			}
 		}
 	}
 
@@ -1190,14 +1236,28 @@ func (f *ExprFmtCtx) formatScalarPrivate(scalar opt.ScalarExpr) {
 
 	case *UniqueChecksItem:
 		tab := f.Memo.metadata.TableMeta(t.Table)
-		constraint := tab.Table.Unique(t.CheckOrdinal)
 		fmt.Fprintf(f.Buffer, ": %s(", tab.Alias.ObjectName)
-		for i := 0; i < constraint.ColumnCount(); i++ {
-			if i > 0 {
-				f.Buffer.WriteByte(',')
+		if t.Exclusion {
+			index := tab.Table.Index(t.CheckOrdinal)
+			for i := 0; i < index.ExplicitColumnCount(); i++ {
+				if i > 0 {
+					f.Buffer.WriteByte(',')
+				}
+				ord := index.Column(i).Ordinal()
+				if index.Column(i).Kind() == cat.Inverted {
+					ord = index.Column(i).InvertedSourceColumnOrdinal()
+				}
+				fmt.Fprintf(f.Buffer, "%s %s", tab.Table.Column(ord).ColName(), index.ExclusionOperator(i))
+			}
+		} else {
+			constraint := tab.Table.Unique(t.CheckOrdinal)
+			for i := 0; i < constraint.ColumnCount(); i++ {
+				if i > 0 {
+					f.Buffer.WriteByte(',')
+				}
+				col := tab.Table.Column(constraint.ColumnOrdinal(tab.Table, i))
+				f.Buffer.WriteString(string(col.ColName()))
 			}
-			col := tab.Table.Column(constraint.ColumnOrdinal(tab.Table, i))
-			f.Buffer.WriteString(string(col.ColName()))
 		}
 		f.Buffer.WriteByte(')')
 
@@ -1621,6 +1681,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 			fmt.Fprintf(f.Buffer, ",ordering=%s", t.Ordering)
 		}
 
+	case *GroupingSetsPrivate:
+		fmt.Fprintf(f.Buffer, " cols=%s,sets=%d", t.GroupingCols.String(), len(t.Sets))
+
 	case *SetPrivate:
 		if !t.Ordering.Any() {
 			fmt.Fprintf(f.Buffer, " ordering=%s", t.Ordering)
@@ -1653,6 +1716,9 @@ func FormatPrivate(f *ExprFmtCtx, private interface{}, physProps *physical.Requi
 	case *FunctionPrivate:
 		fmt.Fprintf(f.Buffer, " %s", t.Name)
 
+	case *UserDefinedAggPrivate:
+		fmt.Fprintf(f.Buffer, " %s", t.Name)
+
 	case *WindowsItemPrivate:
 		fmt.Fprintf(f.Buffer, " frame=%q", &t.Frame)
 
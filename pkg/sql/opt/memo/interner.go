@@ -459,6 +459,13 @@ func (h *hasher) HashColList(val opt.ColList) {
 	h.hash = hash
 }
 
+func (h *hasher) HashGroupingSetList(val GroupingSetList) {
+	for i := range val {
+		h.HashColSet(val[i])
+		h.HashUint64(uint64(i))
+	}
+}
+
 func (h *hasher) HashOptionalColList(val opt.OptionalColList) {
 	hash := h.hash
 	for _, id := range val {
@@ -921,6 +928,18 @@ func (h *hasher) IsColListEqual(l, r opt.ColList) bool {
 	return l.Equals(r)
 }
 
+func (h *hasher) IsGroupingSetListEqual(l, r GroupingSetList) bool {
+	if len(l) != len(r) {
+		return false
+	}
+	for i := range l {
+		if !l[i].Equals(r[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 func (h *hasher) IsOptionalColListEqual(l, r opt.OptionalColList) bool {
 	return l.Equals(r)
 }
@@ -342,6 +342,13 @@ func TestInterner(t *testing.T) {
 			{val1: opt.OptionalColList{1, 2}, val2: opt.OptionalColList{1, 2, 3}, equal: false},
 		}},
 
+		{hashFn: in.hasher.HashGroupingSetList, eqFn: in.hasher.IsGroupingSetListEqual, variations: []testVariation{
+			{val1: GroupingSetList{}, val2: GroupingSetList{}, equal: true},
+			{val1: GroupingSetList{opt.MakeColSet(1, 2), opt.MakeColSet()}, val2: GroupingSetList{opt.MakeColSet(2, 1), opt.MakeColSet()}, equal: true},
+			{val1: GroupingSetList{opt.MakeColSet(1, 2), opt.MakeColSet()}, val2: GroupingSetList{opt.MakeColSet(1), opt.MakeColSet(2)}, equal: false},
+			{val1: GroupingSetList{opt.MakeColSet(1), opt.MakeColSet()}, val2: GroupingSetList{opt.MakeColSet(), opt.MakeColSet(1)}, equal: false},
+		}},
+
 		{hashFn: in.hasher.HashOrdering, eqFn: in.hasher.IsOrderingEqual, variations: []testVariation{
 			{val1: opt.Ordering{}, val2: opt.Ordering{}, equal: true},
 			{val1: opt.Ordering{-1, 1}, val2: opt.Ordering{-1, 1}, equal: true},
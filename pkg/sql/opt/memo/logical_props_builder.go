@@ -677,6 +677,107 @@ func (b *logicalPropsBuilder) buildGroupingExprProps(groupExpr RelExpr, rel *pro
 	}
 }
 
+func (b *logicalPropsBuilder) buildGroupingSetsProps(
+	groupingSets *GroupingSetsExpr, rel *props.Relational,
+) {
+	BuildSharedProps(groupingSets, &rel.Shared, b.evalCtx)
+
+	inputProps := groupingSets.Input.Relational()
+	aggs := groupingSets.Aggregations
+	groupingCols := groupingSets.GroupingCols
+
+	numEmptySets := 0
+	for _, set := range groupingSets.Sets {
+		if set.Empty() {
+			numEmptySets++
+		}
+	}
+
+	// Output Columns
+	// --------------
+	// Output columns are the union of grouping columns with columns from the
+	// aggregate projection list and the grouping ID column.
+	rel.OutputCols = groupingCols.Copy()
+	for i := range aggs {
+		rel.OutputCols.Add(aggs[i].Col)
+	}
+	rel.OutputCols.Add(groupingSets.GroupingIDCol)
+
+	// Not Null Columns
+	// ----------------
+	// A grouping column is NULL in the rows of the grouping sets that do not
+	// contain it, so only columns that are in every grouping set can be not
+	// null.
+	rel.NotNullCols = inputProps.NotNullCols.Intersection(groupingCols)
+	for _, set := range groupingSets.Sets {
+		rel.NotNullCols.IntersectionWith(set)
+	}
+	rel.NotNullCols.Add(groupingSets.GroupingIDCol)
+
+	for i := range aggs {
+		item := &aggs[i]
+		agg := ExtractAggFunc(item.Agg)
+
+		// Some aggregates never return NULL, regardless of input.
+		if opt.AggregateIsNeverNull(agg.Op()) {
+			rel.NotNullCols.Add(item.Col)
+			continue
+		}
+
+		// An empty grouping set produces a row even if there are no input rows,
+		// in which case most aggregate functions return NULL. This is also
+		// possible with AggFilter.
+		if numEmptySets > 0 || item.Agg.Op() == opt.AggFilterOp {
+			continue
+		}
+
+		if opt.AggregateIsNeverNullOnNonNullInput(agg.Op()) {
+			inputCols := ExtractAggInputColumns(agg)
+			if inputCols.SubsetOf(inputProps.NotNullCols) {
+				rel.NotNullCols.Add(item.Col)
+			}
+		}
+	}
+
+	// Outer Columns
+	// -------------
+	// Outer columns were derived by BuildSharedProps; remove any that are bound
+	// by input columns.
+	rel.OuterCols.DifferenceWith(inputProps.OutputCols)
+
+	// Functional Dependencies
+	// -----------------------
+	// The functional dependencies of the input do not hold for the output,
+	// since grouping columns are replaced by NULL values in the rows of the
+	// grouping sets that do not contain them. Within each grouping set the
+	// grouping columns form a key, so together with the grouping ID column
+	// they form a key of the output.
+	keyCols := groupingCols.Copy()
+	keyCols.Add(groupingSets.GroupingIDCol)
+	rel.FuncDeps.AddStrictKey(keyCols, rel.OutputCols)
+
+	// Cardinality
+	// -----------
+	// Each grouping set returns at most as many rows as the input, and at least
+	// one row if the input has at least one row. Empty grouping sets always
+	// return exactly one row.
+	numSets := uint32(len(groupingSets.Sets))
+	minRows := numSets
+	if inputProps.Cardinality.CanBeZero() {
+		minRows = uint32(numEmptySets)
+	}
+	rel.Cardinality = inputProps.Cardinality.
+		Product(props.Cardinality{Min: numSets, Max: numSets}).
+		AsLowAs(minRows).
+		AtLeast(props.Cardinality{Min: minRows, Max: uint32(numEmptySets)})
+
+	// Statistics
+	// ----------
+	if !b.disableStats {
+		b.sb.buildGroupingSets(groupingSets, rel)
+	}
+}
+
 func (b *logicalPropsBuilder) buildUnionProps(union *UnionExpr, rel *props.Relational) {
 	b.buildSetProps(union, rel)
 }
@@ -1525,6 +1626,10 @@ func (b *logicalPropsBuilder) buildCreateFunctionProps(
 	BuildSharedProps(cf, &rel.Shared, b.evalCtx)
 }
 
+func (b *logicalPropsBuilder) buildCallProps(call *CallExpr, rel *props.Relational) {
+	b.buildBasicProps(call, call.Columns, rel)
+}
+
 func (b *logicalPropsBuilder) buildFiltersItemProps(item *FiltersItem, scalar *props.Scalar) {
 	BuildSharedProps(item.Condition, &scalar.Shared, b.evalCtx)
 
@@ -1665,6 +1770,20 @@ func BuildSharedProps(e opt.Expr, shared *props.Shared, evalCtx *eval.Context) {
 	case *UDFExpr:
 		shared.HasUDF = true
 		shared.VolatilitySet.Add(t.Volatility)
+		// The body of a recursive function may not be built yet.
+		for i := range t.Def.Body {
+			if t.Def.Body[i].Relational().CanMutate {
+				shared.CanMutate = true
+				break
+			}
+		}
+
+	case *UserDefinedAggExpr:
+		shared.HasUDF = true
+		shared.VolatilitySet.Add(t.Def.Transition.Volatility)
+		if t.Def.Final != nil {
+			shared.VolatilitySet.Add(t.Def.Final.Volatility)
+		}
 
 	default:
 		if opt.IsUnaryOp(e) {
@@ -366,6 +366,12 @@ func TestMemoIsStale(t *testing.T) {
 	evalCtx.SessionData().OptimizerUseImprovedComputedColumnFiltersDerivation = false
 	notStale()
 
+	// Stale allow_materialized_view_mutations.
+	evalCtx.SessionData().AllowMaterializedViewMutations = true
+	stale()
+	evalCtx.SessionData().AllowMaterializedViewMutations = false
+	notStale()
+
 	// User no longer has access to view.
 	catalog.View(tree.NewTableNameWithSchema("t", tree.PublicSchemaName, "abcview")).Revoked = true
 	_, err = o.Memo().IsStale(ctx, &evalCtx, catalog)
@@ -453,6 +453,9 @@ func (sb *statisticsBuilder) colStat(colSet opt.ColSet, e RelExpr) *props.Column
 		opt.UpsertDistinctOnOp, opt.EnsureUpsertDistinctOnOp:
 		return sb.colStatGroupBy(colSet, e)
 
+	case opt.GroupingSetsOp:
+		return sb.colStatGroupingSets(colSet, e.(*GroupingSetsExpr))
+
 	case opt.LimitOp:
 		return sb.colStatLimit(colSet, e.(*LimitExpr))
 
@@ -1979,6 +1982,71 @@ func (sb *statisticsBuilder) colStatGroupBy(
 	return colStat
 }
 
+// +---------------+
+// | Grouping Sets |
+// +---------------+
+
+func (sb *statisticsBuilder) buildGroupingSets(
+	groupingSets *GroupingSetsExpr, relProps *props.Relational,
+) {
+	s := relProps.Statistics()
+	if zeroCardinality := s.Init(relProps); zeroCardinality {
+		// Short cut if cardinality is 0.
+		return
+	}
+	s.Available = sb.availabilityFromInput(groupingSets)
+
+	// Each grouping set returns one row per distinct value of its columns in
+	// the input, and an empty grouping set returns a single row.
+	inputStats := sb.statsFromChild(groupingSets, 0 /* childIdx */)
+	s.RowCount = 0
+	for _, set := range groupingSets.Sets {
+		if set.Empty() {
+			s.RowCount++
+			continue
+		}
+		colStat := sb.colStatFromChild(set, groupingSets, 0 /* childIdx */)
+		s.RowCount += min(colStat.DistinctCount, inputStats.RowCount)
+	}
+
+	sb.finalizeFromCardinality(relProps)
+}
+
+func (sb *statisticsBuilder) colStatGroupingSets(
+	colSet opt.ColSet, groupingSets *GroupingSetsExpr,
+) *props.ColumnStatistic {
+	relProps := groupingSets.Relational()
+	s := relProps.Statistics()
+
+	colStat, _ := s.ColStats.Add(colSet)
+	if colSet.SubsetOf(groupingSets.GroupingCols) {
+		// Sum the distinct counts of the columns over the grouping sets. The
+		// columns that are not in a grouping set are NULL for its rows.
+		colStat.DistinctCount = 0
+		for _, set := range groupingSets.Sets {
+			inSet := colSet.Intersection(set)
+			if inSet.Empty() {
+				colStat.DistinctCount++
+				continue
+			}
+			inputColStat := sb.colStatFromChild(inSet, groupingSets, 0 /* childIdx */)
+			colStat.DistinctCount += inputColStat.DistinctCount
+		}
+	} else {
+		// Some of the requested columns are aggregates or the grouping ID
+		// column. Estimate the distinct count to be the same as the row count.
+		colStat.DistinctCount = s.RowCount
+	}
+
+	if colSet.Intersects(relProps.NotNullCols) {
+		colStat.NullCount = 0
+	} else {
+		colStat.NullCount = s.RowCount * UnknownNullCountRatio
+	}
+	sb.finalizeFromRowCountAndDistinctCounts(colStat, s)
+	return colStat
+}
+
 // +--------+
 // | Set Op |
 // +--------+
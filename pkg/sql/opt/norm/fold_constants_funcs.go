@@ -24,6 +24,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/cockroachdb/errors"
 	"github.com/cockroachdb/redact"
 	"github.com/lib/pq/oid"
@@ -646,6 +647,34 @@ func (c *CustomFuncs) FunctionReturnType(private *memo.FunctionPrivate) *types.T
 	return private.Typ
 }
 
+// textSearchConfigFuncs are the functions whose two-argument overloads take
+// the name of a text search configuration as their first argument.
+var textSearchConfigFuncs = map[string]struct{}{
+	"to_tsvector":      {},
+	"to_tsquery":       {},
+	"plainto_tsquery":  {},
+	"phraseto_tsquery": {},
+}
+
+// foldFunctionVolatility returns the volatility with which a function with
+// constant inputs is folded. Text search functions which take a configuration
+// are immutable, so that they can be used in index expressions, but their
+// result depends on the definition of the configuration when it is
+// user-defined. They are folded as stable functions in that case, so that the
+// result isn't cached in plans which outlive changes to the configuration.
+func foldFunctionVolatility(args memo.ScalarListExpr, private *memo.FunctionPrivate) volatility.V {
+	v := private.Overload.Volatility
+	if _, ok := textSearchConfigFuncs[private.Name]; !ok || len(args) != 2 || v >= volatility.Stable {
+		return v
+	}
+	if config, ok := memo.ExtractConstDatum(args[0]).(*tree.DString); ok {
+		if _, ok := tsearch.BuiltinConfig(string(*config)); !ok {
+			return volatility.Stable
+		}
+	}
+	return v
+}
+
 // FoldFunction evaluates a function expression with constant inputs. It returns
 // a constant expression as long as the evaluation causes no error. Otherwise, it
 // returns ok=false.
@@ -658,7 +687,7 @@ func (c *CustomFuncs) FoldFunction(
 		return nil, false
 	}
 
-	if !c.CanFoldOperator(private.Overload.Volatility) {
+	if !c.CanFoldOperator(foldFunctionVolatility(args, private)) {
 		return nil, false
 	}
 
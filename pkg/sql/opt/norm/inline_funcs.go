@@ -412,6 +412,7 @@ func (c *CustomFuncs) InlineConstVar(f memo.FiltersExpr) memo.FiltersExpr {
 //  3. It is not a set-returning function.
 //  4. Its arguments are only Variable or Const expressions.
 //  5. It is not a record-returning function.
+//  6. It is not recursive, and it does not have an exception block.
 //
 // UDFs with mutations (INSERT, UPDATE, UPSERT, DELETE) cannot be inlined, but
 // we do not need an explicit check for this because immutable UDFs cannot
@@ -441,7 +442,8 @@ func (c *CustomFuncs) InlineConstVar(f memo.FiltersExpr) memo.FiltersExpr {
 // single column can be inlined since subqueries can only return a single
 // column.
 func (c *CustomFuncs) IsInlinableUDF(args memo.ScalarListExpr, udfp *memo.UDFPrivate) bool {
-	if udfp.Volatility == volatility.Volatile || len(udfp.Body) != 1 || udfp.SetReturning || udfp.MultiColDataSource {
+	if udfp.Volatility == volatility.Volatile || len(udfp.Def.Body) != 1 || udfp.SetReturning ||
+		udfp.MultiColDataSource || udfp.Def.IsRecursive || udfp.Def.ExceptionBlock != nil {
 		return false
 	}
 	if !args.IsConstantsAndPlaceholdersAndVariables() {
@@ -459,8 +461,8 @@ func (c *CustomFuncs) ConvertUDFToSubquery(
 	// column, if the column is a parameter of the UDF. It returns ok=false if
 	// the column is not a UDF parameter.
 	argForParam := func(col opt.ColumnID) (e opt.Expr, ok bool) {
-		for i := range udfp.Params {
-			if udfp.Params[i] == col {
+		for i := range udfp.Def.Params {
+			if udfp.Def.Params[i] == col {
 				return args[i], true
 			}
 		}
@@ -489,7 +491,7 @@ func (c *CustomFuncs) ConvertUDFToSubquery(
 	//
 	// TODO(mgartner): The ordering may need to be preserved in the
 	// SubqueryPrivate for SETOF UDFs.
-	stmt := udfp.Body[0]
+	stmt := udfp.Def.Body[0]
 	returnColID := stmt.PhysProps.Presentation[0].ID
 	res := c.f.ConstructSubquery(
 		c.f.ConstructProject(
@@ -15,6 +15,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 )
@@ -242,6 +243,27 @@ func (c *CustomFuncs) NeededMutationFetchCols(
 		}
 	}
 
+	// Add all visible columns if there is an AFTER row-level trigger that is
+	// passed the old values of the rows.
+	event := tree.TriggerEventUpdate
+	if op == opt.DeleteOp {
+		event = tree.TriggerEventDelete
+	}
+	for i, n := 0, tabMeta.Table.TriggerCount(); i < n; i++ {
+		trig := tabMeta.Table.Trigger(i)
+		if trig.ActionTime() != tree.TriggerActionTimeAfter || !trig.ForEachRow() ||
+			!trig.HasEvent(event) {
+			continue
+		}
+		for ord, m := 0, tabMeta.Table.ColumnCount(); ord < m; ord++ {
+			col := tabMeta.Table.Column(ord)
+			if col.Kind() == cat.Ordinary && col.Visibility() == cat.Visible {
+				cols.Add(tabMeta.MetaID.ColumnID(ord))
+			}
+		}
+		break
+	}
+
 	return cols
 }
 
@@ -137,6 +137,11 @@ type Builder struct {
 	// within.
 	insideUDF bool
 
+	// triggerFuncStack contains the OIDs of the trigger functions that are
+	// currently being built. It is used to detect triggers that fire
+	// themselves recursively.
+	triggerFuncStack []oid.Oid
+
 	// insideDataSource is true when we are processing a data source.
 	insideDataSource bool
 
@@ -304,7 +309,7 @@ func (b *Builder) buildStmt(
 		case *tree.Delete, *tree.Insert, *tree.Update, *tree.CreateTable, *tree.CreateView,
 			*tree.Split, *tree.Unsplit, *tree.Relocate, *tree.RelocateRange,
 			*tree.ControlJobs, *tree.ControlSchedules, *tree.CancelQueries, *tree.CancelSessions,
-			*tree.CreateFunction:
+			*tree.CreateFunction, *tree.Call:
 			panic(pgerror.Newf(
 				pgcode.Syntax, "%s cannot be used inside a view definition", stmt.StatementTag(),
 			))
@@ -317,11 +322,8 @@ func (b *Builder) buildStmt(
 		case *tree.Select:
 		case tree.SelectStatement:
 		case *tree.Delete:
-			panic(unimplemented.NewWithIssuef(87289, "%s usage inside a function definition", stmt.StatementTag()))
 		case *tree.Insert:
-			panic(unimplemented.NewWithIssuef(87289, "%s usage inside a function definition", stmt.StatementTag()))
 		case *tree.Update:
-			panic(unimplemented.NewWithIssuef(87289, "%s usage inside a function definition", stmt.StatementTag()))
 		default:
 			panic(unimplemented.Newf("user-defined functions", "%s usage inside a function definition", stmt.StatementTag()))
 		}
@@ -358,6 +360,9 @@ func (b *Builder) buildStmt(
 	case *tree.CreateFunction:
 		return b.buildCreateFunction(stmt, inScope)
 
+	case *tree.Call:
+		return b.buildCall(stmt, inScope)
+
 	case *tree.Explain:
 		return b.buildExplain(stmt, inScope)
 
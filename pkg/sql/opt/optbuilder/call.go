@@ -0,0 +1,48 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+// buildCall builds a memo group for a CALL statement. The procedure is built
+// as a UDF expression whose result is discarded.
+func (b *Builder) buildCall(c *tree.Call, inScope *scope) (outScope *scope) {
+	// Type-check the procedure and its arguments.
+	texpr := inScope.resolveType(c.Proc, types.Any)
+	f, ok := texpr.(*tree.FuncExpr)
+	if !ok {
+		panic(errors.AssertionFailedf("expected a function expression, found %T", texpr))
+	}
+	def, err := f.Func.Resolve(b.ctx, b.semaCtx.SearchPath, b.semaCtx.FunctionResolver)
+	if err != nil {
+		panic(err)
+	}
+	o := f.ResolvedOverload()
+	if !o.IsProcedure {
+		panic(errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s is not a procedure", def.Name),
+			"To call a function, use SELECT.",
+		))
+	}
+
+	proc := b.buildUDF(f, def, inScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+
+	outScope = inScope.push()
+	outScope.expr = b.factory.ConstructCall(proc, &memo.CallPrivate{})
+	return outScope
+}
@@ -17,11 +17,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree/utils"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/intsets"
@@ -83,12 +85,23 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	if err := tree.ValidateFuncOptions(cf.Options); err != nil {
 		panic(err)
 	}
+	if cf.IsProcedure {
+		// Procedures do not have volatility, leakproof, or null input behavior
+		// attributes.
+		for _, option := range cf.Options {
+			switch option.(type) {
+			case tree.FunctionVolatility, tree.FunctionLeakproof, tree.FunctionNullInputBehavior:
+				panic(pgerror.Newf(pgcode.InvalidFunctionDefinition, "invalid attribute in procedure definition"))
+			}
+		}
+	}
 
 	// Look for function body string from function options.
 	// Note that function body can be an empty string.
 	funcBodyFound := false
 	languageFound := false
 	var funcBodyStr string
+	var language tree.FunctionLanguage
 	for _, option := range cf.Options {
 		switch opt := option.(type) {
 		case tree.FunctionBodyStr:
@@ -96,15 +109,16 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 			funcBodyStr = string(opt)
 		case tree.FunctionLanguage:
 			languageFound = true
+			language = opt
 			// Check the language here, before attempting to parse the function body.
 			if _, err := funcinfo.FunctionLangToProto(opt); err != nil {
 				panic(err)
 			}
 
 			if opt == tree.FunctionLangPLpgSQL {
+				// Collect telemetry for the statements in the function body. This
+				// returns an error if the body cannot be parsed.
 				if err := utils.ParseAndCollectTelemetryForPLpgSQLFunc(cf); err != nil {
-					// Until plpgsql is fully implemented DealWithPlpgSQlFunc will always
-					// return an error.
 					panic(err)
 				}
 			}
@@ -126,7 +140,12 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	// bodyScope is the base scope for each statement in the body. We add the
 	// named parameters to the scope so that references to them in the body can
 	// be resolved.
+	//
+	// Only input parameters are added to the scope. OUT parameters are
+	// collected separately, because they determine the return type of the
+	// function.
 	bodyScope := b.allocScope()
+	var outParams []tree.OutParam
 	for i := range cf.Params {
 		param := &cf.Params[i]
 		typ, err := tree.ResolveType(b.ctx, param.Type, b.semaCtx.TypeResolver)
@@ -134,10 +153,32 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 			panic(err)
 		}
 
-		// Add the parameter to the base scope of the body.
-		paramColName := funcParamColName(param.Name, i)
-		col := b.synthesizeColumn(bodyScope, paramColName, typ, nil /* expr */, nil /* scalar */)
-		col.setParamOrd(i)
+		if param.IsOutParam() {
+			if cf.IsProcedure {
+				panic(unimplemented.NewWithIssue(100405,
+					"procedures with OUT or INOUT parameters are not yet supported"))
+			}
+			if param.DefaultVal != nil && param.Class == tree.FunctionParamOut {
+				panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+					"only input parameters can have default values"))
+			}
+			inputOrdinal := -1
+			if param.IsInParam() {
+				inputOrdinal = len(bodyScope.cols)
+			}
+			outParams = append(outParams, tree.OutParam{
+				Name:         string(param.Name),
+				Typ:          typ,
+				InputOrdinal: inputOrdinal,
+			})
+		}
+		if param.IsInParam() {
+			// Add the parameter to the base scope of the body.
+			ord := len(bodyScope.cols)
+			paramColName := funcParamColName(param.Name, ord)
+			col := b.synthesizeColumn(bodyScope, paramColName, typ, nil /* expr */, nil /* scalar */)
+			col.setParamOrd(ord)
+		}
 
 		// Collect the user defined type dependencies.
 		typedesc.GetTypeDescriptorClosure(typ).ForEach(func(id descpb.ID) {
@@ -145,25 +186,107 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 		})
 	}
 
-	// Collect the user defined type dependency of the return type.
-	funcReturnType, err := tree.ResolveType(b.ctx, cf.ReturnType.Type, b.semaCtx.TypeResolver)
-	if err != nil {
-		panic(err)
+	// Determine the return type. The return type of a function with OUT
+	// parameters is determined by the parameters, and the RETURNS clause can
+	// be omitted. The resolved type is stored in the AST so that it is used
+	// when the function descriptor is created.
+	var funcReturnType *types.T
+	if len(outParams) > 0 {
+		funcReturnType = tree.OutParamsReturnType(outParams)
+		if cf.ReturnType.Type != nil {
+			declaredType, err := tree.ResolveType(b.ctx, cf.ReturnType.Type, b.semaCtx.TypeResolver)
+			if err != nil {
+				panic(err)
+			}
+			// A record is always allowed, because RETURNS TABLE is parsed as OUT
+			// parameters of a function returning SETOF RECORD.
+			if !types.IsRecordType(declaredType) &&
+				(len(outParams) > 1 || !declaredType.Equivalent(funcReturnType)) {
+				expected := funcReturnType.SQLStandardName()
+				if len(outParams) > 1 {
+					expected = "record"
+				}
+				panic(pgerror.Newf(pgcode.InvalidFunctionDefinition,
+					"function result type must be %s because of OUT parameters", expected))
+			}
+		}
+		cf.ReturnType.Type = funcReturnType
+	} else {
+		if cf.ReturnType.Type == nil {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition, "function result type must be specified"))
+		}
+		var err error
+		funcReturnType, err = tree.ResolveType(b.ctx, cf.ReturnType.Type, b.semaCtx.TypeResolver)
+		if err != nil {
+			panic(err)
+		}
 	}
+
+	// Collect the user defined type dependency of the return type.
 	typedesc.GetTypeDescriptorClosure(funcReturnType).ForEach(func(id descpb.ID) {
 		typeDeps.Add(int(id))
 	})
 
-	// Parse the function body.
-	stmts, err := parser.Parse(funcBodyStr)
-	if err != nil {
-		panic(err)
+	// A trigger function is called with the NEW and OLD rows of the table
+	// that the trigger is defined on, so its body can only be built when the
+	// trigger fires. As Postgres does for PL/pgSQL trigger functions, only the
+	// syntax of the body is validated here.
+	isTriggerFunc := funcReturnType.Family() == types.TriggerFamily
+	if isTriggerFunc {
+		if len(cf.Params) > 0 {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"trigger functions cannot have declared arguments"))
+		}
+		if cf.ReturnType.IsSet {
+			panic(pgerror.New(pgcode.InvalidFunctionDefinition,
+				"trigger functions cannot return a set"))
+		}
+		if language == tree.FunctionLangPLpgSQL {
+			panic(unimplemented.New("PL/pgSQL trigger function",
+				"PL/pgSQL trigger functions are not yet supported"))
+		}
+	}
+
+	// Parse the function body. A PL/pgSQL function body is built in its
+	// entirety to validate it and to collect its dependencies.
+	var stmts statements.Statements
+	if language == tree.FunctionLangPLpgSQL {
+		if cf.ReturnType.IsSet {
+			panic(unimplemented.New("SETOF PL/pgSQL function",
+				"set-returning PL/pgSQL functions are not yet supported"))
+		}
+		if funcReturnType.Family() == types.TupleFamily && len(outParams) == 0 {
+			panic(unimplemented.New("PL/pgSQL function returning a record",
+				"PL/pgSQL functions returning composite types are not yet supported"))
+		}
+		// TODO(drewk): check the volatility of the statements in the function
+		// body, like we do for SQL functions below.
+		b.factory.FoldingControl().TemporarilyDisallowStableFolds(func() {
+			b.buildPLpgSQLFunctionBody(
+				funcBodyStr, funcReturnType, outParams, volatility.Volatile, bodyScope,
+			)
+		})
+		deps = append(deps, b.schemaDeps...)
+		typeDeps.UnionWith(b.schemaTypeDeps)
+		b.schemaDeps = nil
+		b.schemaTypeDeps = intsets.Fast{}
+	} else {
+		var err error
+		stmts, err = parser.Parse(funcBodyStr)
+		if err != nil {
+			panic(err)
+		}
 	}
 
 	targetVolatility := tree.GetFuncVolatility(cf.Options)
 	// Validate each statement and collect the dependencies.
 	fmtCtx := tree.NewFmtCtx(tree.FmtSimple)
 	for i, stmt := range stmts {
+		if isTriggerFunc {
+			formatFuncBodyStmt(fmtCtx, stmt.AST, i > 0 /* newLine */)
+			cf.BodyStatements = append(cf.BodyStatements, stmt.AST)
+			continue
+		}
 		var stmtScope *scope
 		// We need to disable stable function folding because we want to catch the
 		// volatility of stable functions. If folded, we only get a scalar and lose
@@ -182,7 +305,7 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 			// TODO(mgartner): stmtScope.cols does not describe the result
 			// columns of the statement. We should use physical.Presentation
 			// instead.
-			err := validateReturnType(funcReturnType, stmtScope.cols)
+			err := validateReturnType(funcReturnType, len(outParams) > 1, stmtScope.cols)
 			if err != nil {
 				panic(err)
 			}
@@ -207,10 +330,13 @@ func (b *Builder) buildCreateFunction(cf *tree.CreateFunction, inScope *scope) (
 	}
 
 	// Override the function body so that references are fully qualified.
-	for i, option := range cf.Options {
-		if _, ok := option.(tree.FunctionBodyStr); ok {
-			cf.Options[i] = tree.FunctionBodyStr(fmtCtx.String())
-			break
+	// TODO(drewk): qualify the references in PL/pgSQL function bodies.
+	if language != tree.FunctionLangPLpgSQL {
+		for i, option := range cf.Options {
+			if _, ok := option.(tree.FunctionBodyStr); ok {
+				cf.Options[i] = tree.FunctionBodyStr(fmtCtx.String())
+				break
+			}
 		}
 	}
 
@@ -234,13 +360,18 @@ func formatFuncBodyStmt(fmtCtx *tree.FmtCtx, ast tree.Statement, newLine bool) {
 	fmtCtx.WriteString(";")
 }
 
-func validateReturnType(expected *types.T, cols []scopeColumn) error {
+// validateReturnType returns an error if the given columns produced by the
+// last statement of a function body do not match the expected return type.
+// isOutParamsRecord should be true if the expected type is a record that is
+// determined by multiple OUT parameters, in which case the columns must match
+// the parameters.
+func validateReturnType(expected *types.T, isOutParamsRecord bool, cols []scopeColumn) error {
 	// If return type is void, any column types are valid.
 	if expected.Equivalent(types.Void) {
 		return nil
 	}
 	// If return type is RECORD, any column types are valid.
-	if types.IsRecordType(expected) {
+	if types.IsRecordType(expected) && !isOutParamsRecord {
 		return nil
 	}
 
@@ -54,6 +54,7 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 
 	var mb mutationBuilder
 	mb.init(b, "delete", tab, alias)
+	mb.rowLevelSecurity = b.rowLevelSecurityApplies(tab)
 
 	// Build the input expression that selects the rows that will be deleted:
 	//
@@ -77,8 +78,12 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 // buildDelete constructs a Delete operator, possibly wrapped by a Project
 // operator that corresponds to the given RETURNING clause.
 func (mb *mutationBuilder) buildDelete(returning *tree.ReturningExprs) {
+	mb.buildBeforeRowTriggers(tree.TriggerEventDelete)
+
 	mb.buildFKChecksAndCascadesForDelete()
 
+	mb.buildAfterTriggers(tree.TriggerEventDelete)
+
 	// Project partial index DEL boolean columns.
 	mb.projectPartialIndexDelCols()
 
@@ -93,4 +98,6 @@ func (mb *mutationBuilder) buildDelete(returning *tree.ReturningExprs) {
 	)
 
 	mb.buildReturning(returning)
+
+	mb.buildBeforeStatementTriggers(tree.TriggerEventDelete)
 }
@@ -45,7 +45,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // groupby information stored in scopes.
@@ -100,8 +102,29 @@ type groupby struct {
 	// It is used to ensure that the builder does not throw a grouping error
 	// prematurely.
 	buildingGroupingCols bool
+
+	// groupingSets contains the grouping sets of a GROUP BY clause that uses
+	// GROUPING SETS, ROLLUP or CUBE. Each set is a subset of the ids of the
+	// grouping columns. It is nil if the GROUP BY clause has a single grouping
+	// set, which is always the case for GROUP BY clauses that use none of these.
+	groupingSets []opt.ColSet
+
+	// groupingIDCol is the column containing the ordinal of the grouping set of
+	// each output row. It is only set if groupingSets is not nil.
+	groupingIDCol opt.ColumnID
 }
 
+const (
+	// maxGroupingSets is the maximum number of grouping sets that a GROUP BY
+	// clause can produce. This is the same limit that Postgres uses.
+	maxGroupingSets = 4096
+
+	// maxCubeElements is the maximum number of elements of a CUBE, which produces
+	// 2^n grouping sets for n elements. This is the same limit that Postgres
+	// uses.
+	maxCubeElements = 12
+)
+
 // groupByStrSet is a set of stringified GROUP BY expressions that map to the
 // grouping column in an aggOutScope scope that projects that expression. It
 // is used to enforce scoping rules, since any non-aggregate, variable
@@ -250,6 +273,11 @@ func (a aggregateInfo) isOrderingSensitive() bool {
 	if a.isOrderedSetAggregate() {
 		return true
 	}
+	if isUserDefinedAggregate(&a.def) {
+		// The result of a user-defined aggregate may depend on the order in
+		// which the transition function is applied to the input rows.
+		return true
+	}
 	switch a.def.Name {
 	case "array_agg", "array_cat_agg", "concat_agg", "string_agg", "json_agg",
 		"jsonb_agg", "json_object_agg", "jsonb_object_agg", "st_makeline",
@@ -279,6 +307,42 @@ func (a *aggregateInfo) ResolvedType() *types.T {
 var _ tree.Expr = &aggregateInfo{}
 var _ tree.TypedExpr = &aggregateInfo{}
 
+// groupingFuncInfo stores information about a call to the GROUPING function.
+// The call is replaced by an expression over the grouping ID column once the
+// grouping sets of the query are known (see Builder.buildGroupingFunc).
+type groupingFuncInfo struct {
+	*tree.FuncExpr
+
+	// args are the typed arguments of the function. Each of them must be a
+	// grouping expression of the query.
+	args []tree.TypedExpr
+}
+
+// Walk is part of the tree.Expr interface.
+func (g *groupingFuncInfo) Walk(v tree.Visitor) tree.Expr {
+	return g
+}
+
+// TypeCheck is part of the tree.Expr interface.
+func (g *groupingFuncInfo) TypeCheck(
+	ctx context.Context, semaCtx *tree.SemaContext, desired *types.T,
+) (tree.TypedExpr, error) {
+	return g, nil
+}
+
+// Eval is part of the tree.TypedExpr interface.
+func (g *groupingFuncInfo) Eval(_ context.Context, _ tree.ExprEvaluator) (tree.Datum, error) {
+	panic(errors.AssertionFailedf("groupingFuncInfo must be replaced before evaluation"))
+}
+
+// ResolvedType is part of the tree.TypedExpr interface.
+func (g *groupingFuncInfo) ResolvedType() *types.T {
+	return types.Int
+}
+
+var _ tree.Expr = &groupingFuncInfo{}
+var _ tree.TypedExpr = &groupingFuncInfo{}
+
 func (b *Builder) needsAggregation(sel *tree.SelectClause, scope *scope) bool {
 	// We have an aggregation if:
 	//  - we have a GROUP BY, or
@@ -292,6 +356,23 @@ func (b *Builder) needsAggregation(sel *tree.SelectClause, scope *scope) bool {
 func (b *Builder) constructGroupBy(
 	input memo.RelExpr, groupingColSet opt.ColSet, aggCols []scopeColumn, ordering opt.Ordering,
 ) memo.RelExpr {
+	aggs := b.constructAggregations(aggCols)
+	private := memo.GroupingPrivate{GroupingCols: groupingColSet}
+
+	// The ordering of the GROUP BY is inherited from the input. This ordering is
+	// only useful for intra-group ordering (for order-sensitive aggregations like
+	// ARRAY_AGG). So we add the grouping columns as optional columns.
+	private.Ordering.FromOrderingWithOptCols(ordering, groupingColSet)
+
+	if groupingColSet.Empty() {
+		return b.factory.ConstructScalarGroupBy(input, aggs, &private)
+	}
+	return b.factory.ConstructGroupBy(input, aggs, &private)
+}
+
+// constructAggregations constructs the aggregations of a grouping operator from
+// the given aggregate result columns.
+func (b *Builder) constructAggregations(aggCols []scopeColumn) memo.AggregationsExpr {
 	aggs := make(memo.AggregationsExpr, 0, len(aggCols))
 
 	// Deduplicate the columns; we don't need to produce the same aggregation
@@ -308,18 +389,7 @@ func (b *Builder) constructGroupBy(
 			colSet.Add(id)
 		}
 	}
-
-	private := memo.GroupingPrivate{GroupingCols: groupingColSet}
-
-	// The ordering of the GROUP BY is inherited from the input. This ordering is
-	// only useful for intra-group ordering (for order-sensitive aggregations like
-	// ARRAY_AGG). So we add the grouping columns as optional columns.
-	private.Ordering.FromOrderingWithOptCols(ordering, groupingColSet)
-
-	if groupingColSet.Empty() {
-		return b.factory.ConstructScalarGroupBy(input, aggs, &private)
-	}
-	return b.factory.ConstructGroupBy(input, aggs, &private)
+	return aggs
 }
 
 // buildGroupingColumns builds the grouping columns and adds them to the
@@ -354,6 +424,10 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// If there are any aggregates that are ordering sensitive, build the
 	// aggregations as window functions over each group.
 	if g.hasNonCommutativeAggregates() {
+		if g.groupingSets != nil {
+			panic(unimplemented.New("grouping sets",
+				"ordering-sensitive aggregates with GROUPING SETS, ROLLUP or CUBE are not supported"))
+		}
 		return b.buildAggregationAsWindow(groupingColSet, having, fromScope)
 	}
 
@@ -385,7 +459,7 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 
 		// Construct the aggregate function from its name and arguments and store
 		// it in the corresponding scope column.
-		aggCols[i].scalar = b.constructAggregate(agg.def.Name, args)
+		aggCols[i].scalar = b.constructAggregate(&agg.def, args)
 
 		// Wrap the aggregate function with an AggDistinct operator if DISTINCT
 		// was specified in the query.
@@ -430,12 +504,24 @@ func (b *Builder) buildAggregation(having opt.ScalarExpr, fromScope *scope) (out
 	// aggregate arguments, as well as any additional order by columns.
 	b.constructProjectForScope(fromScope, g.aggInScope)
 
-	g.aggOutScope.expr = b.constructGroupBy(
-		g.aggInScope.expr,
-		groupingColSet,
-		aggCols,
-		g.aggInScope.ordering,
-	)
+	if g.groupingSets != nil {
+		g.aggOutScope.expr = b.factory.ConstructGroupingSets(
+			g.aggInScope.expr,
+			b.constructAggregations(aggCols),
+			&memo.GroupingSetsPrivate{
+				GroupingCols:  groupingColSet,
+				Sets:          memo.GroupingSetList(g.groupingSets),
+				GroupingIDCol: g.groupingIDCol,
+			},
+		)
+	} else {
+		g.aggOutScope.expr = b.constructGroupBy(
+			g.aggInScope.expr,
+			groupingColSet,
+			aggCols,
+			g.aggInScope.ordering,
+		)
+	}
 
 	// Wrap with having filter if it exists.
 	if having != nil {
@@ -509,15 +595,129 @@ func (b *Builder) buildGroupingList(
 	// used in an aggregate function`. The builder cannot know whether there is
 	// a grouping error until the grouping columns are fully built.
 	g.buildingGroupingCols = true
+
+	// Each GROUP BY item is either an expression, which is added to all the
+	// grouping sets, or a GROUPING SETS, ROLLUP or CUBE item, in which case the
+	// resulting grouping sets are the cross product of the current ones and the
+	// ones of the item. For example:
+	//   GROUP BY a, ROLLUP (b, c)
+	// results in the grouping sets (a, b, c), (a, b) and (a).
+	sets := []opt.ColSet{{}}
 	for _, e := range groupBy {
-		b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		if gs, ok := e.(*tree.GroupingSet); ok {
+			itemSets := b.buildGroupingSet(gs, selects, projectionsScope, fromScope)
+			if len(sets)*len(itemSets) > maxGroupingSets {
+				panic(errTooManyGroupingSets)
+			}
+			product := make([]opt.ColSet, 0, len(sets)*len(itemSets))
+			for i := range sets {
+				for j := range itemSets {
+					product = append(product, sets[i].Union(itemSets[j]))
+				}
+			}
+			sets = product
+			continue
+		}
+		cols := b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
+		for i := range sets {
+			sets[i] = sets[i].Union(cols)
+		}
 	}
 	g.buildingGroupingCols = false
+
+	if len(sets) > 1 {
+		g.groupingSets = sets
+		g.groupingIDCol = b.factory.Metadata().AddColumn("grouping_set", types.Int)
+	}
+}
+
+var errTooManyGroupingSets = pgerror.Newf(
+	pgcode.StatementTooComplex, "too many grouping sets present (maximum %d)", maxGroupingSets,
+)
+
+// buildGroupingSet builds the expressions of a GROUPING SETS, ROLLUP or CUBE
+// item of a GROUP BY clause, and returns the grouping sets that it produces.
+// See buildGroupingList for a description of the other arguments.
+func (b *Builder) buildGroupingSet(
+	gs *tree.GroupingSet, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) []opt.ColSet {
+	if gs.Kind == tree.SetsGroupingSet {
+		// The elements of GROUPING SETS can themselves be GROUPING SETS, ROLLUP
+		// or CUBE items, in which case their sets are included in the result.
+		var sets []opt.ColSet
+		for _, e := range gs.Exprs {
+			if nested, ok := e.(*tree.GroupingSet); ok {
+				sets = append(sets, b.buildGroupingSet(nested, selects, projectionsScope, fromScope)...)
+			} else {
+				sets = append(sets, b.buildGroupingElement(e, selects, projectionsScope, fromScope))
+			}
+			if len(sets) > maxGroupingSets {
+				panic(errTooManyGroupingSets)
+			}
+		}
+		return sets
+	}
+
+	if gs.Kind == tree.CubeGroupingSet && len(gs.Exprs) > maxCubeElements {
+		panic(pgerror.Newf(pgcode.StatementTooComplex,
+			"CUBE is limited to %d elements", maxCubeElements))
+	}
+	elems := make([]opt.ColSet, len(gs.Exprs))
+	for i, e := range gs.Exprs {
+		elems[i] = b.buildGroupingElement(e, selects, projectionsScope, fromScope)
+	}
+
+	switch gs.Kind {
+	case tree.RollupGroupingSet:
+		// ROLLUP (e1, e2, ..., en) produces the grouping sets (e1, e2, ..., en),
+		// (e1, e2, ..., en-1), ..., (e1) and ().
+		sets := make([]opt.ColSet, len(elems)+1)
+		for i := range elems {
+			sets[len(elems)-i-1] = sets[len(elems)-i].Union(elems[i])
+		}
+		return sets
+
+	case tree.CubeGroupingSet:
+		// CUBE (e1, e2, ..., en) produces all the subsets of its elements,
+		// starting with (e1, e2, ..., en) and ending with ().
+		sets := make([]opt.ColSet, 0, 1<<len(elems))
+		for mask := (1 << len(elems)) - 1; mask >= 0; mask-- {
+			var set opt.ColSet
+			for i := range elems {
+				if mask&(1<<(len(elems)-i-1)) != 0 {
+					set.UnionWith(elems[i])
+				}
+			}
+			sets = append(sets, set)
+		}
+		return sets
+
+	default:
+		panic(errors.AssertionFailedf("unexpected grouping set kind %d", gs.Kind))
+	}
+}
+
+// buildGroupingElement builds an element of a GROUPING SETS, ROLLUP or CUBE
+// item, which is either an expression or a parenthesized list of expressions,
+// and returns the ids of its grouping columns.
+func (b *Builder) buildGroupingElement(
+	e tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope *scope,
+) opt.ColSet {
+	g := fromScope.groupby
+	if t, ok := tree.StripParens(e).(*tree.Tuple); ok {
+		var cols opt.ColSet
+		for _, expr := range t.Exprs {
+			cols.UnionWith(b.buildGrouping(expr, selects, projectionsScope, fromScope, g.aggInScope))
+		}
+		return cols
+	}
+	return b.buildGrouping(e, selects, projectionsScope, fromScope, g.aggInScope)
 }
 
 // buildGrouping builds a set of memo groups that represent a GROUP BY
 // expression. The expression (or expressions, if we have a star) is added to
-// groupStrs and to the aggInScope.
+// groupStrs and to the aggInScope. Returns the ids of the grouping columns of
+// the expression.
 //
 // groupBy          The given GROUP BY expression.
 // selects          The select expressions are needed in case the GROUP BY
@@ -537,7 +737,7 @@ func (b *Builder) buildGroupingList(
 //	as the aggregate function arguments.
 func (b *Builder) buildGrouping(
 	groupBy tree.Expr, selects tree.SelectExprs, projectionsScope, fromScope, aggInScope *scope,
-) {
+) (cols opt.ColSet) {
 	// Unwrap parenthesized expressions like "((a))" to "a".
 	groupBy = tree.StripParens(groupBy)
 	alias := ""
@@ -635,7 +835,8 @@ func (b *Builder) buildGrouping(
 		// If a grouping column has already been added, don't add it again.
 		// GROUP BY a, a is semantically equivalent to GROUP BY a.
 		exprStr := symbolicExprStr(e)
-		if _, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+		if col, ok := fromScope.groupby.groupStrs[exprStr]; ok {
+			cols.Add(col.id)
 			continue
 		}
 
@@ -645,9 +846,64 @@ func (b *Builder) buildGrouping(
 		col := aggInScope.addColumn(scopeColName(tree.Name(alias)), e)
 		b.buildScalar(e, fromScope, aggInScope, col, nil)
 		fromScope.groupby.groupStrs[exprStr] = col
+		cols.Add(col.id)
+	}
+	return cols
+}
+
+// buildGroupingFunc builds a call to the GROUPING function, which returns a bit
+// mask with a bit for each of its arguments, the last argument corresponding
+// to the least significant bit. A bit is set if the argument is not part of the
+// grouping set of the current row. The call is built as a CASE expression over
+// the grouping ID column, for example:
+//
+//	SELECT grouping(a, b) FROM t GROUP BY ROLLUP (a, b)
+//
+// is built as:
+//
+//	CASE grouping_set WHEN 0 THEN 0 WHEN 1 THEN 1 WHEN 2 THEN 3 END
+func (b *Builder) buildGroupingFunc(f *groupingFuncInfo, inScope *scope) opt.ScalarExpr {
+	g := inScope.groupby
+	if g == nil {
+		panic(errGroupingFuncArgs)
+	}
+	argCols := make([]opt.ColumnID, len(f.args))
+	for i, arg := range f.args {
+		col, ok := g.groupStrs[symbolicExprStr(arg)]
+		if !ok {
+			panic(errGroupingFuncArgs)
+		}
+		argCols[i] = col.id
+	}
+
+	// Without grouping sets, all the arguments are part of the single grouping
+	// set of every row.
+	if g.groupingSets == nil {
+		return b.factory.ConstructConstVal(tree.NewDInt(0), types.Int)
+	}
+
+	whens := make(memo.ScalarListExpr, len(g.groupingSets))
+	for i, set := range g.groupingSets {
+		var mask tree.DInt
+		for _, col := range argCols {
+			mask <<= 1
+			if !set.Contains(col) {
+				mask |= 1
+			}
+		}
+		whens[i] = b.factory.ConstructWhen(
+			b.factory.ConstructConstVal(tree.NewDInt(tree.DInt(i)), types.Int),
+			b.factory.ConstructConstVal(tree.NewDInt(mask), types.Int),
+		)
 	}
+	return b.factory.ConstructCase(
+		b.factory.ConstructVariable(g.groupingIDCol), whens, b.factory.ConstructNull(types.Int),
+	)
 }
 
+var errGroupingFuncArgs = pgerror.New(pgcode.Grouping,
+	"arguments to GROUPING must be grouping expressions of the associated query level")
+
 // buildAggArg builds a scalar expression which is used as an input in some form
 // to an aggregate expression. The scopeColumn for the built expression will
 // be added to tempScope.
@@ -774,8 +1030,13 @@ func (b *Builder) buildAggregateFunction(
 	return &info
 }
 
-func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.ScalarExpr {
-	switch name {
+func (b *Builder) constructWindowFn(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	if isUserDefinedAggregate(def) {
+		return b.constructUserDefinedAgg(def, args)
+	}
+	switch def.Name {
 	case "rank":
 		return b.factory.ConstructRank()
 	case "row_number":
@@ -799,11 +1060,17 @@ func (b *Builder) constructWindowFn(name string, args []opt.ScalarExpr) opt.Scal
 	case "nth_value":
 		return b.factory.ConstructNthValue(args[0], args[1])
 	default:
-		return b.constructAggregate(name, args)
+		return b.constructAggregate(def, args)
 	}
 }
 
-func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.ScalarExpr {
+func (b *Builder) constructAggregate(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	if isUserDefinedAggregate(def) {
+		return b.constructUserDefinedAgg(def, args)
+	}
+	name := def.Name
 	switch name {
 	case "array_agg":
 		return b.factory.ConstructArrayAgg(args[0])
@@ -896,6 +1163,69 @@ func (b *Builder) constructAggregate(name string, args []opt.ScalarExpr) opt.Sca
 	panic(errors.AssertionFailedf("unhandled aggregate: %s", name))
 }
 
+// isUserDefinedAggregate returns true if the given function is an aggregate
+// function created with CREATE AGGREGATE.
+func isUserDefinedAggregate(def *memo.FunctionPrivate) bool {
+	return def.Overload != nil && def.Overload.UDFAggregate != nil
+}
+
+// constructUserDefinedAgg constructs a UserDefinedAgg expression for an
+// aggregate function created with CREATE AGGREGATE. The transition function
+// and the final function of the aggregate are built as UDFs, which are invoked
+// by the aggregation during execution.
+func (b *Builder) constructUserDefinedAgg(
+	def *memo.FunctionPrivate, args []opt.ScalarExpr,
+) opt.ScalarExpr {
+	agg := def.Overload.UDFAggregate
+	aggDef := &memo.UserDefinedAggDefinition{
+		Transition: b.buildUserDefinedAggSupportFunc(agg.TransitionFunc),
+		InitCond:   tree.DNull,
+	}
+	if agg.FinalFunc != 0 {
+		aggDef.Final = b.buildUserDefinedAggSupportFunc(agg.FinalFunc)
+	}
+	if agg.InitCond != nil {
+		d, _, err := tree.ParseAndRequireString(agg.StateType, *agg.InitCond, b.evalCtx)
+		if err != nil {
+			panic(err)
+		}
+		aggDef.InitCond = d
+	}
+	return b.factory.ConstructUserDefinedAgg(
+		args,
+		&memo.UserDefinedAggPrivate{
+			Name: def.Name,
+			Typ:  def.Overload.FixedReturnType(),
+			Def:  aggDef,
+		},
+	)
+}
+
+// buildUserDefinedAggSupportFunc builds the support function of a
+// user-defined aggregate with the given OID.
+func (b *Builder) buildUserDefinedAggSupportFunc(funcOid oid.Oid) *memo.UDFPrivate {
+	name, o, err := b.catalog.ResolveFunctionByOID(b.ctx, funcOid)
+	if err != nil {
+		panic(err)
+	}
+	// The support functions are referenced by OID rather than by name, so there
+	// is no name to track for resolution changes.
+	b.factory.Metadata().AddUserDefinedFunction(o, nil /* name */)
+
+	// The support functions are never used as data sources, even if the
+	// aggregate is called in the FROM clause.
+	defer func(prev bool) { b.insideDataSource = prev }(b.insideDataSource)
+	b.insideDataSource = false
+	udfDef, rtyp, _, _ := b.buildUDFDefinition(o, o.FixedReturnType())
+	return &memo.UDFPrivate{
+		Name:              name.Object(),
+		Typ:               rtyp,
+		Volatility:        o.Volatility,
+		CalledOnNullInput: o.CalledOnNullInput,
+		Def:               udfDef,
+	}
+}
+
 func isAggregate(def *tree.ResolvedFunctionDefinition) bool {
 	return isClass(def, tree.AggregateClass)
 }
@@ -930,7 +1260,12 @@ func newGroupingError(name tree.Name) error {
 // not specified in the query.
 // In the unique index or unique without index cases, all key columns must be
 // marked as NOT NULL to allow the implicit grouping.
+// Implicit grouping columns are never allowed with grouping sets, since the key
+// columns are not part of every grouping set.
 func (b *Builder) allowImplicitGroupingColumn(colID opt.ColumnID, g *groupby) bool {
+	if g.groupingSets != nil {
+		return false
+	}
 	md := b.factory.Metadata()
 	colMeta := md.ColumnMeta(colID)
 	if colMeta.Table == 0 {
@@ -222,6 +222,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 	} else {
 		mb.init(b, "insert", tab, alias)
 	}
+	mb.rowLevelSecurity = b.rowLevelSecurityApplies(tab)
 
 	// Compute target columns in two cases:
 	//
@@ -297,6 +298,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 		// Wrap the input in one ANTI JOIN per UNIQUE index, and filter out rows
 		// that have conflicts. See the buildInputForDoNothing comment for more
 		// details.
+		mb.checkNoOnConflictRowTriggers(true /* doNothing */)
 		mb.buildInputForDoNothing(inScope, ins.OnConflict)
 
 		// Since buildInputForDoNothing filters out rows with conflicts, always
@@ -355,6 +357,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 //     values specified for them.
 //  4. Each update value is the same as the corresponding insert value.
 //  5. There are no inbound foreign keys containing non-key columns.
+//  6. Row-level security policies are not enforced for the table.
 //
 // TODO(andyk): The fast path is currently only enabled when the UPSERT alias
 // is explicitly selected by the user. It's possible to fast path some queries
@@ -362,6 +365,12 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 // of edge cases (that caused real correctness bugs #13437 #13962). As a result,
 // this support was removed and needs to re-enabled. See #14482.
 func (mb *mutationBuilder) needExistingRows() bool {
+	// Row-level security policies may need to be checked against the existing
+	// rows.
+	if mb.rowLevelSecurity {
+		return true
+	}
+
 	if mb.tab.DeletableIndexCount() > 1 {
 		return true
 	}
@@ -670,10 +679,18 @@ func (mb *mutationBuilder) addSynthesizedColsForInsert() {
 // buildInsert constructs an Insert operator, possibly wrapped by a Project
 // operator that corresponds to the given RETURNING clause.
 func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs) {
+	// Enforce the constraints of any domain-typed columns.
+	mb.addDomainChecks(mb.insertColIDs)
+
+	mb.buildBeforeRowTriggers(tree.TriggerEventInsert)
+
 	// Disambiguate names so that references in any expressions, such as a
 	// check constraint, refer to the correct columns.
 	mb.disambiguateColumns()
 
+	// Enforce the row-level security policies of the table.
+	mb.addRowLevelSecurityChecksForInsert()
+
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(false /* isUpdate */)
 
@@ -681,15 +698,20 @@ func (mb *mutationBuilder) buildInsert(returning *tree.ReturningExprs) {
 	mb.projectPartialIndexPutCols()
 
 	mb.buildUniqueChecksForInsert()
+	mb.buildExclusionChecksForInsert()
 
 	mb.buildFKChecksForInsert()
 
+	mb.buildAfterTriggers(tree.TriggerEventInsert)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructInsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
 	)
 
 	mb.buildReturning(returning)
+
+	mb.buildBeforeStatementTriggers(tree.TriggerEventInsert)
 }
 
 // buildInputForDoNothing wraps the input expression in ANTI JOIN expressions,
@@ -875,6 +897,13 @@ func (mb *mutationBuilder) setUpsertCols(insertCols tree.NameList) {
 // buildUpsert constructs an Upsert operator, possibly wrapped by a Project
 // operator that corresponds to the given RETURNING clause.
 func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
+	mb.checkNoOnConflictRowTriggers(false /* doNothing */)
+
+	// Enforce the constraints of any domain-typed columns. As in Postgres, the
+	// values to be inserted are checked even if the row is updated instead.
+	mb.addDomainChecks(mb.insertColIDs)
+	mb.addDomainChecks(mb.updateColIDs)
+
 	// Merge input insert and update columns using CASE expressions.
 	mb.projectUpsertColumns()
 
@@ -882,6 +911,9 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 	// check constraint, refer to the correct columns.
 	mb.disambiguateColumns()
 
+	// Enforce the row-level security policies of the table.
+	mb.addRowLevelSecurityChecksForUpsert()
+
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(false /* isUpdate */)
 
@@ -904,15 +936,26 @@ func (mb *mutationBuilder) buildUpsert(returning *tree.ReturningExprs) {
 	}
 
 	mb.buildUniqueChecksForUpsert()
+	mb.buildExclusionChecksForUpsert()
 
 	mb.buildFKChecksForUpsert()
 
+	// As in Postgres, the AFTER UPDATE statement-level triggers fire before the
+	// AFTER INSERT ones.
+	mb.buildAfterTriggers(tree.TriggerEventUpdate)
+	mb.buildAfterTriggers(tree.TriggerEventInsert)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	mb.outScope.expr = mb.b.factory.ConstructUpsert(
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
 	)
 
 	mb.buildReturning(returning)
+
+	// The BEFORE INSERT statement-level triggers fire before the BEFORE UPDATE
+	// ones, since the outer With expression is executed first.
+	mb.buildBeforeStatementTriggers(tree.TriggerEventUpdate)
+	mb.buildBeforeStatementTriggers(tree.TriggerEventInsert)
 }
 
 // projectUpsertColumns projects a set of merged columns that will be either
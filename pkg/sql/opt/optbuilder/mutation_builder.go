@@ -138,6 +138,11 @@ type mutationBuilder struct {
 	// an insert; otherwise it's an update.
 	canaryColID opt.ColumnID
 
+	// rowLevelSecurity is true if the row-level security policies of the
+	// target table are enforced for the current user. It is never set for
+	// cascading mutations, which bypass row-level security as in Postgres.
+	rowLevelSecurity bool
+
 	// arbiters is the set of indexes and unique constraints that are used to
 	// detect conflicts for UPSERT and INSERT ON CONFLICT statements.
 	arbiters arbiterSet
@@ -299,6 +304,14 @@ func (mb *mutationBuilder) buildInputForUpdate(
 		false, /* disableNotVisibleIndex */
 	)
 
+	// Filter out the rows that are not visible to the user or that may not be
+	// updated by the user according to the row-level security policies.
+	if mb.rowLevelSecurity {
+		mb.b.addRowLevelSecurityFilter(
+			mb.tab, []tree.PolicyCommand{tree.PolicyCommandSelect, tree.PolicyCommandUpdate}, mb.fetchScope,
+		)
+	}
+
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
 
@@ -409,6 +422,14 @@ func (mb *mutationBuilder) buildInputForDelete(
 		false, /* disableNotVisibleIndex */
 	)
 
+	// Filter out the rows that are not visible to the user or that may not be
+	// deleted by the user according to the row-level security policies.
+	if mb.rowLevelSecurity {
+		mb.b.addRowLevelSecurityFilter(
+			mb.tab, []tree.PolicyCommand{tree.PolicyCommandSelect, tree.PolicyCommandDelete}, mb.fetchScope,
+		)
+	}
+
 	// Set list of columns that will be fetched by the input expression.
 	mb.setFetchColIDs(mb.fetchScope.cols)
 
@@ -1097,6 +1118,12 @@ func (mb *mutationBuilder) parseDefaultExpr(colID opt.ColumnID) tree.Expr {
 	col := mb.tab.Column(ord)
 	exprStr := col.DefaultExprStr()
 
+	// If the column has no default expression of its own, use the default of
+	// its domain, if any.
+	if d := col.DatumType().TypeMeta.DomainData; exprStr == "" && d != nil && d.DefaultExpr != nil {
+		exprStr = *d.DefaultExpr
+	}
+
 	// If no default expression, return NULL or a default value.
 	if exprStr == "" {
 		if col.IsMutation() && !col.IsNullable() {
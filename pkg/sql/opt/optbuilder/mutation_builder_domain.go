@@ -0,0 +1,102 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// hasDomainConstraints returns true if typ is a domain with a NOT NULL or
+// CHECK constraint.
+func hasDomainConstraints(typ *types.T) bool {
+	d := typ.TypeMeta.DomainData
+	return typ.DomainOID() != 0 && d != nil && (d.NotNull || len(d.Checks) > 0)
+}
+
+// makeDomainConstraintExpr wraps the given value, which is coerced to the
+// domain type typ, in calls to builtins that raise an error if the value
+// violates a NOT NULL or CHECK constraint of the domain. The value expression
+// is substituted for VALUE in each CHECK expression, so it appears in the
+// result once per constraint.
+func makeDomainConstraintExpr(value tree.Expr, typ *types.T) tree.Expr {
+	d := typ.TypeMeta.DomainData
+	domainName := tree.NewDString(typ.TypeMeta.Name.Basename())
+	expr := value
+	if d.NotNull {
+		expr = &tree.FuncExpr{
+			Func:  tree.WrapFunction("crdb_internal.domain_not_null"),
+			Exprs: tree.Exprs{expr, domainName},
+		}
+	}
+	for i := range d.Checks {
+		check, err := schemaexpr.ParseDomainCheckExpr(d.Checks[i].Expr, value)
+		if err != nil {
+			panic(err)
+		}
+		expr = &tree.FuncExpr{
+			Func:  tree.WrapFunction("crdb_internal.domain_check"),
+			Exprs: tree.Exprs{expr, check, domainName, tree.NewDString(d.Checks[i].Name)},
+		}
+	}
+	return expr
+}
+
+// addDomainChecks wraps each mutated column whose target table column has a
+// domain type in a projection that enforces the NOT NULL and CHECK
+// constraints of the domain. It must be called after assignment casts have
+// been added for the columns, so that the constraints apply to the final
+// values written to the table. The IDs in srcCols are updated to refer to the
+// new columns.
+func (mb *mutationBuilder) addDomainChecks(srcCols opt.OptionalColList) {
+	var projectionScope *scope
+	for ord, colID := range srcCols {
+		if colID == 0 {
+			// Column not mutated, so nothing to do.
+			continue
+		}
+
+		targetCol := mb.tab.Column(ord)
+		targetType := targetCol.DatumType()
+		if !hasDomainConstraints(targetType) {
+			continue
+		}
+
+		// Lazily create the new scope.
+		if projectionScope == nil {
+			projectionScope = mb.outScope.replace()
+			projectionScope.appendColumnsFromScope(mb.outScope)
+		}
+
+		inCol := mb.outScope.getColumnWithIDAndReferenceName(colID, targetCol.ColName())
+		expr := makeDomainConstraintExpr(inCol, targetType)
+		texpr := mb.outScope.resolveAndRequireType(expr, targetType)
+		scalar := mb.b.buildScalar(texpr, mb.outScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+
+		// Update the scope column to be checked. See addAssignmentCasts for why
+		// the column is looked up by both ID and name.
+		scopeCol := projectionScope.getColumnWithIDAndReferenceName(colID, targetCol.ColName())
+		scopeCol.name = scopeCol.name.WithMetadataName(fmt.Sprintf("%s_domain", targetCol.ColName()))
+		mb.b.populateSynthesizedColumn(scopeCol, scalar)
+
+		// Replace old source column with the new one.
+		srcCols[ord] = scopeCol.id
+	}
+
+	if projectionScope != nil {
+		projectionScope.expr = mb.b.constructProject(mb.outScope.expr, projectionScope.cols)
+		mb.outScope = projectionScope
+	}
+}
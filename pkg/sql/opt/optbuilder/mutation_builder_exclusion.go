@@ -0,0 +1,246 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
+	"github.com/cockroachdb/errors"
+)
+
+// buildExclusionChecksForInsert builds exclusion check queries for an insert.
+// These check queries are used to enforce EXCLUDE constraints.
+func (mb *mutationBuilder) buildExclusionChecksForInsert() {
+	mb.buildExclusionChecks(false /* onlyIfUpdated */)
+}
+
+// buildExclusionChecksForUpdate builds exclusion check queries for an update.
+// These check queries are used to enforce EXCLUDE constraints.
+func (mb *mutationBuilder) buildExclusionChecksForUpdate() {
+	mb.buildExclusionChecks(true /* onlyIfUpdated */)
+}
+
+// buildExclusionChecksForUpsert builds exclusion check queries for an upsert.
+// These check queries are used to enforce EXCLUDE constraints.
+func (mb *mutationBuilder) buildExclusionChecksForUpsert() {
+	// Rows inserted by an upsert may conflict on any column of the constraint,
+	// so the checks cannot be skipped based on the updated columns.
+	mb.buildExclusionChecks(false /* onlyIfUpdated */)
+}
+
+// buildExclusionChecks builds a check query for each index that backs an
+// exclusion constraint. Write-only indexes are included, since the constraint
+// must hold for all rows written while the index is being backfilled. If
+// onlyIfUpdated is true, constraints with no updated columns are skipped.
+func (mb *mutationBuilder) buildExclusionChecks(onlyIfUpdated bool) {
+	if !mb.hasExclusionConstraints() {
+		return
+	}
+
+	if onlyIfUpdated {
+		mb.ensureWithID()
+	}
+
+	for i, n := 0, mb.tab.WritableIndexCount(); i < n; i++ {
+		index := mb.tab.Index(i)
+		if !index.IsExclusion() {
+			continue
+		}
+		if onlyIfUpdated && !mb.exclusionColsUpdated(index) {
+			continue
+		}
+		var h exclusionCheckHelper
+		if h.init(mb, i) {
+			mb.uniqueChecks = append(mb.uniqueChecks, h.buildInsertionCheck())
+		}
+	}
+	telemetry.Inc(sqltelemetry.ExclusionChecksUseCounter)
+}
+
+// hasExclusionConstraints returns true if any writable index on the table
+// backs an exclusion constraint.
+func (mb *mutationBuilder) hasExclusionConstraints() bool {
+	for i, n := 0, mb.tab.WritableIndexCount(); i < n; i++ {
+		if mb.tab.Index(i).IsExclusion() {
+			return true
+		}
+	}
+	return false
+}
+
+// exclusionColsUpdated returns true if any of the columns of the exclusion
+// constraint backed by the given index are being updated (according to
+// updateColIDs).
+func (mb *mutationBuilder) exclusionColsUpdated(index cat.Index) bool {
+	for i, n := 0, index.ExplicitColumnCount(); i < n; i++ {
+		if ord := exclusionColumnOrdinal(index, i); mb.updateColIDs[ord] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// exclusionColumnOrdinal returns the table ordinal of the ith column of the
+// given exclusion constraint index. For an inverted column, this is the
+// ordinal of the column that the inverted column is derived from.
+func exclusionColumnOrdinal(index cat.Index, i int) int {
+	col := index.Column(i)
+	if col.Kind() == cat.Inverted {
+		return col.InvertedSourceColumnOrdinal()
+	}
+	return col.Ordinal()
+}
+
+// exclusionCheckHelper is a type associated with a single exclusion constraint
+// and is used to build the "leaves" of an exclusion check expression, namely
+// the WithScan of the mutation input and the Scan of the table.
+type exclusionCheckHelper struct {
+	mb *mutationBuilder
+
+	index        cat.Index
+	indexOrdinal cat.IndexOrdinal
+
+	// exclusionOrdinals are the table ordinals of the columns of the exclusion
+	// constraint, in the order they appear in the index. They correspond
+	// 1-to-1 to the operators of the constraint.
+	exclusionOrdinals []int
+
+	// primaryKeyOrdinals are the ordinals of the primary key columns.
+	primaryKeyOrdinals intsets.Fast
+
+	// The scope and column ordinals of the scan that will serve as the right
+	// side of the semi join for the exclusion checks.
+	scanScope    *scope
+	scanOrdinals []int
+}
+
+// init initializes the helper with the index that backs an exclusion
+// constraint.
+//
+// Returns false if the constraint should be ignored (e.g. because the new
+// values for one of the columns are known to be always NULL).
+func (h *exclusionCheckHelper) init(mb *mutationBuilder, indexOrdinal cat.IndexOrdinal) bool {
+	*h = exclusionCheckHelper{
+		mb:           mb,
+		index:        mb.tab.Index(indexOrdinal),
+		indexOrdinal: indexOrdinal,
+	}
+
+	h.exclusionOrdinals = make([]int, h.index.ExplicitColumnCount())
+	for i := range h.exclusionOrdinals {
+		tabOrd := exclusionColumnOrdinal(h.index, i)
+		// Both equality and overlap are never true for NULL values, so a row
+		// with a NULL value in one of the columns can never conflict with
+		// another row.
+		if memo.OutputColumnIsAlwaysNull(mb.outScope.expr, mb.mapToReturnColID(tabOrd)) {
+			return false
+		}
+		h.exclusionOrdinals[i] = tabOrd
+	}
+	h.primaryKeyOrdinals = getIndexLaxKeyOrdinals(mb.tab.Index(cat.PrimaryIndex))
+
+	h.scanScope, h.scanOrdinals = h.buildTableScan()
+	return true
+}
+
+// buildInsertionCheck creates an exclusion check for rows which are added to
+// a table. The input to the insertion check will be produced from the input
+// to the mutation operator.
+func (h *exclusionCheckHelper) buildInsertionCheck() memo.UniqueChecksItem {
+	f := h.mb.b.factory
+
+	// Build a self semi-join, with the new values on the left and the
+	// existing values on the right.
+	withScanScope, _ := h.mb.buildCheckInputScan(
+		checkInputScanNewVals, h.scanOrdinals, false, /* isFK */
+	)
+
+	// Build the join filters:
+	//   (new_a op_a existing_a) AND (new_b op_b existing_b) AND ...
+	semiJoinFilters := make(memo.FiltersExpr, 0, len(h.exclusionOrdinals)+1)
+	for i, ord := range h.exclusionOrdinals {
+		newVal := f.ConstructVariable(withScanScope.cols[ord].id)
+		existingVal := f.ConstructVariable(h.scanScope.cols[ord].id)
+		var cmp opt.ScalarExpr
+		switch op := h.index.ExclusionOperator(i); op {
+		case tree.ExcludeEquals:
+			cmp = f.ConstructEq(newVal, existingVal)
+		case tree.ExcludeOverlaps:
+			cmp = f.ConstructOverlaps(newVal, existingVal)
+		default:
+			panic(errors.AssertionFailedf("unexpected exclusion operator %v", op))
+		}
+		semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(cmp))
+	}
+
+	// We need to prevent rows from matching themselves in the semi join. We can
+	// do this by adding another filter that uses the primary keys to check if
+	// two rows are identical:
+	//    (new_pk1 != existing_pk1) OR (new_pk2 != existing_pk2) OR ...
+	var pkFilter opt.ScalarExpr
+	for i, ok := h.primaryKeyOrdinals.Next(0); ok; i, ok = h.primaryKeyOrdinals.Next(i + 1) {
+		pkFilterLocal := f.ConstructNe(
+			f.ConstructVariable(withScanScope.cols[i].id),
+			f.ConstructVariable(h.scanScope.cols[i].id),
+		)
+		if pkFilter == nil {
+			pkFilter = pkFilterLocal
+		} else {
+			pkFilter = f.ConstructOr(pkFilter, pkFilterLocal)
+		}
+	}
+	semiJoinFilters = append(semiJoinFilters, f.ConstructFiltersItem(pkFilter))
+
+	semiJoin := f.ConstructSemiJoin(withScanScope.expr, h.scanScope.expr, semiJoinFilters, memo.EmptyJoinPrivate)
+
+	// Collect the key columns that will be shown in the error message if there
+	// is a conflict resulting from this exclusion check.
+	keyCols := make(opt.ColList, len(h.exclusionOrdinals))
+	for i, ord := range h.exclusionOrdinals {
+		keyCols[i] = withScanScope.cols[ord].id
+	}
+
+	// Create a Project that passes-through only the key columns. This allows
+	// normalization rules to prune any unnecessary columns from the expression.
+	project := f.ConstructProject(semiJoin, nil /* projections */, keyCols.ToSet())
+
+	return f.ConstructUniqueChecksItem(project, &memo.UniqueChecksItemPrivate{
+		Table:        h.mb.tabID,
+		CheckOrdinal: h.indexOrdinal,
+		KeyCols:      keyCols,
+		OpName:       h.mb.opName,
+		Exclusion:    true,
+	})
+}
+
+// buildTableScan builds a Scan of the table. The ordinals of the columns
+// scanned are also returned.
+func (h *exclusionCheckHelper) buildTableScan() (outScope *scope, ordinals []int) {
+	tabMeta := h.mb.b.addTable(h.mb.tab, tree.NewUnqualifiedTableName(h.mb.tab.Name()))
+	ordinals = tableOrdinals(tabMeta.Table, columnKinds{
+		includeMutations: false,
+		includeSystem:    false,
+		includeInverted:  false,
+	})
+	return h.mb.b.buildScan(
+		tabMeta,
+		ordinals,
+		nil, /* indexFlags */
+		noRowLocking,
+		h.mb.b.allocScope(),
+		true, /* disableNotVisibleIndex */
+	), ordinals
+}
@@ -0,0 +1,1309 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	plpgsqlparser "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/cast"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treebin"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// plpgsqlBuilder builds a PL/pgSQL function body into memo expressions that
+// can be executed with the same routine machinery as SQL-language UDFs.
+//
+// PL/pgSQL control flow cannot be expressed directly as a relational
+// expression, because statements like LOOP and EXIT can transfer control to
+// an arbitrary point in the function. Instead, the builder uses
+// continuation-passing style: a sequence of statements is split at each
+// control-flow statement, and the statements that follow are built into a
+// separate routine, called a continuation. A continuation has a parameter for
+// each variable that is in scope, and it is invoked with the current values of
+// the variables as arguments. Every continuation returns the result of the
+// function, so the result of the root expression is the result of the
+// function. For example, the following function body:
+//
+//	DECLARE
+//	  i INT := 0;
+//	BEGIN
+//	  LOOP
+//	    IF i >= 10 THEN EXIT; END IF;
+//	    i := i + 1;
+//	  END LOOP;
+//	  RETURN i;
+//	END
+//
+// is built into expressions that are roughly equivalent to:
+//
+//	loop_exit(i)  => SELECT i;
+//	stmt_loop(i)  => SELECT CASE WHEN i >= 10 THEN loop_exit(i) ELSE stmt_if(i) END;
+//	stmt_if(i)    => SELECT stmt_loop(i + 1);
+//	root          => SELECT stmt_loop(0);
+//
+// Variable assignments are built as projections. SQL statements and RAISE
+// statements are built as non-final statements in the body of a continuation
+// so that they are executed for their side effects.
+type plpgsqlBuilder struct {
+	ob *Builder
+
+	// vars contains the variables that are in scope at the current point of
+	// the function body: the function parameters, followed by the variables
+	// declared in enclosing blocks and the hidden variables used to implement
+	// FOR loops. The columns of every scope that is built by the plpgsqlBuilder
+	// correspond one-to-one with the first len(scope.cols) variables.
+	vars []plpgsqlVar
+
+	// numParams is the number of function parameters at the start of vars.
+	numParams int
+
+	// returnType is the return type of the function, and of every
+	// continuation.
+	returnType *types.T
+
+	// outParams are the OUT and INOUT parameters of the function.
+	outParams []tree.OutParam
+
+	// outVars contains the index in vars of the variable for each of the
+	// outParams. The function returns the values of these variables.
+	outVars []int
+
+	// volatility is the volatility of the continuations.
+	volatility volatility.V
+
+	// continuations is a stack of the continuations that are invoked when
+	// control reaches the end of the statement list that is being built.
+	continuations []continuation
+
+	// exitTargets is a stack of the loops and blocks that enclose the statement
+	// that is being built. It is used to resolve EXIT and CONTINUE statements.
+	exitTargets []exitTarget
+
+	// continuationCount is used to give each continuation a unique name.
+	continuationCount int
+}
+
+// plpgsqlVar is a PL/pgSQL variable or function parameter.
+type plpgsqlVar struct {
+	// name is the name by which the variable is referenced. It is empty for
+	// hidden variables, which cannot be referenced by the function body.
+	name tree.Name
+
+	// metaName is the name of the columns that represent the variable in the
+	// metadata.
+	metaName string
+
+	typ *types.T
+
+	// constant is true if the variable was declared CONSTANT, in which case it
+	// cannot be assigned.
+	constant bool
+}
+
+// continuation is a routine that executes the rest of the function from some
+// point in the function body.
+type continuation struct {
+	def  *memo.UDFDefinition
+	name string
+
+	// s is the scope in which the body of the continuation is built. Its
+	// columns are the parameters of the continuation.
+	s *scope
+}
+
+// exitTarget is a loop or block that can be targeted by an EXIT or CONTINUE
+// statement.
+type exitTarget struct {
+	label string
+
+	// exit is the continuation that is invoked by an EXIT statement.
+	exit continuation
+
+	// cont is the continuation that is invoked by a CONTINUE statement. It is
+	// nil for blocks, which cannot be targeted by CONTINUE.
+	cont *continuation
+}
+
+// buildPLpgSQLFunctionBody builds the body of a PL/pgSQL function. The
+// columns of bodyScope must be the input parameters of the function. The
+// returned scope has a single column that produces the result of the function.
+func (b *Builder) buildPLpgSQLFunctionBody(
+	body string,
+	returnType *types.T,
+	outParams []tree.OutParam,
+	vol volatility.V,
+	bodyScope *scope,
+) *scope {
+	stmt, err := plpgsqlparser.Parse(body)
+	if err != nil {
+		panic(err)
+	}
+	return newPLpgSQLBuilder(b, bodyScope, returnType, outParams, vol).build(stmt.AST, bodyScope)
+}
+
+func newPLpgSQLBuilder(
+	ob *Builder, paramScope *scope, returnType *types.T, outParams []tree.OutParam, vol volatility.V,
+) *plpgsqlBuilder {
+	b := &plpgsqlBuilder{
+		ob:         ob,
+		numParams:  len(paramScope.cols),
+		returnType: returnType,
+		outParams:  outParams,
+		volatility: vol,
+	}
+	b.vars = make([]plpgsqlVar, len(paramScope.cols))
+	for i := range paramScope.cols {
+		col := &paramScope.cols[i]
+		b.vars[i] = plpgsqlVar{
+			name:     col.name.ReferenceName(),
+			metaName: col.name.MetadataName(),
+			typ:      col.typ,
+		}
+	}
+	return b
+}
+
+// build builds the given PL/pgSQL block, which is the body of a function. The
+// columns of paramScope must be the parameters of the function. The returned
+// scope has a single column that produces the result of the function.
+func (b *plpgsqlBuilder) build(block *plpgsqltree.PLpgSQLStmtBlock, paramScope *scope) *scope {
+	s := paramScope.push()
+	s.appendColumnsFromScope(paramScope)
+	s.expr = b.constructNoColsRow()
+	s = b.buildOutParamVars(s)
+
+	b.pushContinuation(b.buildEndOfFunctionContinuation())
+	defer b.popContinuation()
+	return b.buildBlock(block, s)
+}
+
+// buildOutParamVars adds a variable for each OUT parameter of the function,
+// initialized to NULL. An INOUT parameter is represented by the variable of
+// the corresponding input parameter.
+func (b *plpgsqlBuilder) buildOutParamVars(s *scope) *scope {
+	if len(b.outParams) == 0 {
+		return s
+	}
+	b.outVars = make([]int, len(b.outParams))
+	var idxs []int
+	var vals []opt.ScalarExpr
+	for i := range b.outParams {
+		param := &b.outParams[i]
+		if param.InputOrdinal >= 0 {
+			b.outVars[i] = param.InputOrdinal
+			continue
+		}
+		metaName := param.Name
+		if metaName == "" {
+			metaName = fmt.Sprintf("out_param_%d", i+1)
+		}
+		b.vars = append(b.vars, plpgsqlVar{
+			name:     tree.Name(param.Name),
+			metaName: metaName,
+			typ:      param.Typ,
+		})
+		b.outVars[i] = len(b.vars) - 1
+		idxs = append(idxs, len(b.vars)-1)
+		vals = append(vals, b.ob.factory.ConstructNull(param.Typ))
+	}
+	if len(idxs) == 0 {
+		return s
+	}
+	return b.assignVars(s, idxs, vals)
+}
+
+// makeOutParamsResult returns an expression that produces the result of a
+// function with OUT parameters from the current values of their variables.
+func (b *plpgsqlBuilder) makeOutParamsResult(s *scope) opt.ScalarExpr {
+	if len(b.outVars) == 1 {
+		return b.ob.factory.ConstructVariable(s.cols[b.outVars[0]].id)
+	}
+	elems := make(memo.ScalarListExpr, len(b.outVars))
+	for i, idx := range b.outVars {
+		elems[i] = b.ob.factory.ConstructVariable(s.cols[idx].id)
+	}
+	return b.ob.factory.ConstructTuple(elems, b.returnType)
+}
+
+// buildEndOfFunctionContinuation builds the continuation that is invoked when
+// control reaches the end of the function without a RETURN statement. For a
+// function that returns void, the continuation returns NULL, and for a
+// function with OUT parameters it returns their current values. Otherwise, it
+// raises an error, like Postgres.
+func (b *plpgsqlBuilder) buildEndOfFunctionContinuation() continuation {
+	con := b.makeContinuation("end_of_function")
+	if len(b.outParams) > 0 {
+		b.appendBodyStmt(con, b.projectResult(con.s, b.makeOutParamsResult(con.s)))
+		return con
+	}
+	if b.returnType.Family() != types.VoidFamily {
+		raise := makeRaiseFunc(
+			tree.NewDString("ERROR"),
+			tree.NewDString("control reached end of function without RETURN"),
+			tree.NewDString(""), /* detail */
+			tree.NewDString(""), /* hint */
+			pgcode.RoutineExceptionFunctionExecutedNoReturnStatement.String(),
+		)
+		b.appendBodyStmt(con, b.buildRaiseStmt(raise, con.s))
+	}
+	b.appendBodyStmt(con, b.projectResult(con.s, b.ob.factory.ConstructNull(b.returnType)))
+	return con
+}
+
+// buildBlock builds a PL/pgSQL block. When control reaches the end of the
+// block, the continuation at the top of the stack is invoked.
+func (b *plpgsqlBuilder) buildBlock(block *plpgsqltree.PLpgSQLStmtBlock, s *scope) *scope {
+	b.pushExitTarget(block.Label, b.fallThrough(), nil /* cont */)
+	defer b.popExitTarget()
+
+	// Variables that are declared in the block go out of scope at the end of
+	// the block.
+	numVars := len(b.vars)
+	defer func() { b.vars = b.vars[:numVars] }()
+	for _, decl := range block.Decls {
+		switch t := decl.(type) {
+		case *plpgsqltree.PLpgSQLDecl:
+			s = b.buildDecl(t, s)
+		case *plpgsqltree.PLpgSQLCursorDecl:
+			panic(unimplemented.New("DECLARE CURSOR",
+				"cursor declarations in PL/pgSQL blocks are not yet supported"))
+		default:
+			panic(errors.AssertionFailedf("unexpected declaration type %T", t))
+		}
+	}
+
+	if block.Exceptions == nil {
+		return b.buildPLpgSQLStatements(block.Body, s)
+	}
+
+	// The body of a block with an EXCEPTION section is built into a separate
+	// continuation so that errors raised while it executes can be caught. The
+	// declarations are evaluated outside of the continuation, because errors
+	// raised by their default values are not caught by the block's handlers.
+	//
+	// TODO(drewk): execution falls through from the end of the block to the
+	// rest of the function while the block's continuation is still executing,
+	// so the handlers can catch errors that are raised outside the block. This
+	// only affects the error raised when control reaches the end of a function
+	// without a RETURN statement, because only the top-level block of a
+	// function is currently allowed to have an EXCEPTION section.
+	blockCon := b.makeContinuation("exception_block")
+	b.appendBodyStmt(blockCon, b.buildPLpgSQLStatements(block.Body, blockCon.s))
+	blockCon.def.ExceptionBlock = b.buildExceptions(block.Exceptions)
+	return b.callContinuation(blockCon, s)
+}
+
+// buildExceptions builds a handler for each branch of the given EXCEPTION
+// section. Each handler is a continuation with the same parameters as the
+// continuation for the body of the block, because it is invoked with the same
+// arguments in place of the block's body.
+//
+// TODO(drewk): assignments made in the body of the block before the error was
+// raised should be visible in the handler.
+func (b *plpgsqlBuilder) buildExceptions(
+	exceptions *plpgsqltree.PLpgSQLExceptionBlock,
+) *memo.ExceptionBlock {
+	block := &memo.ExceptionBlock{}
+	for _, e := range exceptions.ExecList {
+		handlerCon := b.makeContinuation("exception_handler")
+		b.appendBodyStmt(handlerCon, b.buildPLpgSQLStatements(e.Action, handlerCon.s))
+		for _, cond := range e.Conditions {
+			block.Codes = append(block.Codes, getExceptionCode(cond))
+			block.Actions = append(block.Actions, handlerCon.def)
+		}
+	}
+	return block
+}
+
+// buildPLpgSQLStatements builds the given list of PL/pgSQL statements. The
+// returned scope has a single column that produces the result of the
+// function. If control reaches the end of the list, the continuation at the
+// top of the stack is invoked.
+func (b *plpgsqlBuilder) buildPLpgSQLStatements(
+	stmts []plpgsqltree.PLpgSQLStatement, s *scope,
+) *scope {
+	for i, stmt := range stmts {
+		rest := stmts[i+1:]
+		switch t := stmt.(type) {
+		case *plpgsqltree.PLpgSQLStmtAssign:
+			s = b.buildAssign(t, s)
+
+		case *plpgsqltree.PLpgSQLStmtBlock:
+			if t.Exceptions != nil {
+				panic(unimplemented.New("nested exception block",
+					"PL/pgSQL blocks with an EXCEPTION section are only supported at the top level of a function"))
+			}
+			// The statements after the block are built into a continuation that is
+			// invoked when control reaches the end of the block.
+			b.pushContinuation(b.buildContinuation("nested_block", rest))
+			blockScope := b.buildBlock(t, s)
+			b.popContinuation()
+			return blockScope
+
+		case *plpgsqltree.PLpgSQLStmtIf:
+			return b.buildIf(t, rest, s)
+
+		case *plpgsqltree.PLpgSQLStmtSimpleLoop:
+			return b.buildSimpleLoop(t, rest, s)
+
+		case *plpgsqltree.PLpgSQLStmtWhileLoop:
+			return b.buildWhileLoop(t, rest, s)
+
+		case *plpgsqltree.PLpgSQLStmtForIntLoop:
+			return b.buildForIntLoop(t, rest, s)
+
+		case *plpgsqltree.PLpgSQLStmtForQuerySelectLoop:
+			return b.buildForQueryLoop(t, rest, s)
+
+		case *plpgsqltree.PLpgSQLStmtExit:
+			return b.buildExit(t, rest, s)
+
+		case *plpgsqltree.PLpgSQLStmtReturn:
+			return b.buildReturn(t, s)
+
+		case *plpgsqltree.PLpgSQLStmtRaise:
+			return b.buildRaise(t, rest, s)
+
+		case *plpgsqltree.PLpgSQLStmtExecSql:
+			return b.buildExecSQL(t, rest, s)
+
+		case *plpgsqltree.PLpgSQLStmtPerform:
+			// PERFORM executes the query as if it was a SELECT, and discards the
+			// result.
+			return b.buildSQLStmt(b.parseSQLStmt("SELECT "+t.Query), rest, s)
+
+		default:
+			tag := "unknown"
+			if tagged, ok := stmt.(plpgsqltree.TaggedPLpgSQLStatement); ok {
+				tag = tagged.PlpgSQLStatementTag()
+			}
+			panic(unimplemented.New("plpgsql "+tag,
+				fmt.Sprintf("PL/pgSQL statement %s is not yet supported", tag)))
+		}
+	}
+	return b.callContinuation(b.fallThrough(), s)
+}
+
+// buildDecl builds a variable declaration. The variable is added to the end of
+// b.vars.
+func (b *plpgsqlBuilder) buildDecl(decl *plpgsqltree.PLpgSQLDecl, s *scope) *scope {
+	if decl.NotNull {
+		panic(unimplemented.New("not null variable",
+			"not-null PL/pgSQL variables are not yet supported"))
+	}
+	if decl.Collate != "" {
+		panic(unimplemented.New("variable collation",
+			"collation for PL/pgSQL variables is not yet supported"))
+	}
+	if b.findVar(decl.Var) >= 0 {
+		panic(unimplemented.New("variable shadowing",
+			"variables that shadow a parameter or another variable are not yet supported"))
+	}
+	typ, err := tree.ResolveType(b.ob.ctx, decl.Typ, b.ob.semaCtx.TypeResolver)
+	if err != nil {
+		panic(err)
+	}
+	if b.ob.trackSchemaDeps && typ.UserDefined() {
+		typedesc.GetTypeDescriptorClosure(typ).ForEach(func(id descpb.ID) {
+			b.ob.schemaTypeDeps.Add(int(id))
+		})
+	}
+
+	// The default value is built before the variable is added, because it
+	// cannot reference the variable itself.
+	var val opt.ScalarExpr
+	if decl.Expr != nil {
+		val = b.buildSQLExpr(decl.Expr, typ, s)
+	} else {
+		val = b.ob.factory.ConstructNull(typ)
+	}
+	b.vars = append(b.vars, plpgsqlVar{
+		name:     decl.Var,
+		metaName: string(decl.Var),
+		typ:      typ,
+		constant: decl.Constant,
+	})
+	return b.assignVars(s, []int{len(b.vars) - 1}, []opt.ScalarExpr{val})
+}
+
+// buildAssign builds an assignment to a variable.
+func (b *plpgsqlBuilder) buildAssign(assign *plpgsqltree.PLpgSQLStmtAssign, s *scope) *scope {
+	idx := b.resolveAssignableVar(assign.Var)
+	expr, err := parser.ParseExpr(assign.Value)
+	if err != nil {
+		panic(err)
+	}
+	val := b.buildSQLExpr(expr, b.vars[idx].typ, s)
+	return b.assignVars(s, []int{idx}, []opt.ScalarExpr{val})
+}
+
+// buildIf builds an IF statement. Each branch is built into a separate
+// continuation, and the branches all fall through to a continuation for the
+// statements that follow the IF statement.
+func (b *plpgsqlBuilder) buildIf(
+	stmt *plpgsqltree.PLpgSQLStmtIf, rest []plpgsqltree.PLpgSQLStatement, s *scope,
+) *scope {
+	b.pushContinuation(b.buildContinuation("stmt_if", rest))
+	defer b.popContinuation()
+
+	whens := make(memo.ScalarListExpr, 0, len(stmt.ElseIfList)+1)
+	addBranch := func(cond string, body []plpgsqltree.PLpgSQLStatement) {
+		condExpr, err := parser.ParseExpr(cond)
+		if err != nil {
+			panic(err)
+		}
+		whens = append(whens, b.ob.factory.ConstructWhen(
+			b.buildSQLExpr(condExpr, types.Bool, s),
+			b.makeContinuationCall(b.buildContinuation("stmt_if_then", body), s),
+		))
+	}
+	addBranch(stmt.Condition, stmt.ThenBody)
+	for _, elsIf := range stmt.ElseIfList {
+		addBranch(elsIf.Condition, elsIf.Stmts)
+	}
+	orElse := b.makeContinuationCall(b.buildContinuation("stmt_if_else", stmt.ElseBody), s)
+	return b.projectResult(s, b.ob.factory.ConstructCase(memo.TrueSingleton, whens, orElse))
+}
+
+// buildSimpleLoop builds an unconditional LOOP statement. The body of the loop
+// is built into a recursive continuation that invokes itself when control
+// reaches the end of the body.
+func (b *plpgsqlBuilder) buildSimpleLoop(
+	stmt *plpgsqltree.PLpgSQLStmtSimpleLoop, rest []plpgsqltree.PLpgSQLStatement, s *scope,
+) *scope {
+	exitCon := b.buildContinuation("loop_exit", rest)
+	loopCon := b.makeRecursiveContinuation("stmt_loop")
+	b.pushExitTarget(stmt.Label, exitCon, &loopCon)
+	b.pushContinuation(loopCon)
+	b.appendBodyStmt(loopCon, b.buildPLpgSQLStatements(stmt.Body, loopCon.s))
+	b.popContinuation()
+	b.popExitTarget()
+	return b.callContinuation(loopCon, s)
+}
+
+// buildWhileLoop builds a WHILE loop. The loop is a recursive continuation
+// that checks the condition, and then either invokes the continuation for the
+// body of the loop or exits the loop.
+func (b *plpgsqlBuilder) buildWhileLoop(
+	stmt *plpgsqltree.PLpgSQLStmtWhileLoop, rest []plpgsqltree.PLpgSQLStatement, s *scope,
+) *scope {
+	exitCon := b.buildContinuation("loop_exit", rest)
+	loopCon := b.makeRecursiveContinuation("stmt_while")
+	b.pushExitTarget(stmt.Label, exitCon, &loopCon)
+	b.pushContinuation(loopCon)
+	bodyCon := b.buildContinuation("while_body", stmt.Body)
+	b.popContinuation()
+	b.popExitTarget()
+
+	ls := loopCon.s
+	cond := b.buildSQLExpr(stmt.Condition, types.Bool, ls)
+	b.appendBodyStmt(loopCon, b.projectResult(ls, b.makeCase(
+		cond, b.makeContinuationCall(bodyCon, ls), b.makeContinuationCall(exitCon, ls),
+	)))
+	return b.callContinuation(loopCon, s)
+}
+
+// buildForIntLoop builds a FOR loop over a range of integers. The upper bound
+// and the step of the loop are evaluated once, before the first iteration, and
+// stored in hidden variables.
+//
+// The loop variable is a new variable, unless a variable with the same name
+// is already in scope. In the latter case, the existing variable is used as
+// the loop variable, so its value is modified by the loop. In Postgres, the
+// loop variable always shadows variables with the same name.
+func (b *plpgsqlBuilder) buildForIntLoop(
+	stmt *plpgsqltree.PLpgSQLStmtForIntLoop, rest []plpgsqltree.PLpgSQLStatement, s *scope,
+) *scope {
+	exitCon := b.buildContinuation("loop_exit", rest)
+	lower := b.buildSQLExpr(stmt.Lower, types.Int, s)
+	upper := b.buildSQLExpr(stmt.Upper, types.Int, s)
+	var step opt.ScalarExpr
+	if stmt.Step != nil {
+		step = b.buildSQLExpr(stmt.Step, types.Int, s)
+	} else {
+		step = b.ob.factory.ConstructConstVal(tree.NewDInt(1), types.Int)
+	}
+
+	numVars := len(b.vars)
+	defer func() { b.vars = b.vars[:numVars] }()
+	loopVar := b.findVar(stmt.Var)
+	if loopVar < 0 {
+		b.vars = append(b.vars, plpgsqlVar{name: stmt.Var, metaName: string(stmt.Var), typ: types.Int})
+		loopVar = len(b.vars) - 1
+	} else {
+		b.checkAssignable(loopVar)
+	}
+	upperVar := b.addHiddenVar("loop_upper", types.Int)
+	stepVar := b.addHiddenVar("loop_step", types.Int)
+	s = b.assignVars(s, []int{loopVar, upperVar, stepVar}, []opt.ScalarExpr{lower, upper, step})
+
+	// The step must be positive, even for a REVERSE loop.
+	stepCol := b.ob.factory.ConstructVariable(s.cols[stepVar].id)
+	raise := b.buildSQLExpr(makeRaiseFunc(
+		tree.NewDString("ERROR"),
+		tree.NewDString("BY value of FOR loop must be greater than zero"),
+		tree.NewDString(""), /* detail */
+		tree.NewDString(""), /* hint */
+		pgcode.InvalidParameterValue.String(),
+	), types.Int, s)
+	checkedStep := b.makeCase(
+		b.ob.factory.ConstructLe(stepCol, b.ob.factory.ConstructConstVal(tree.NewDInt(0), types.Int)),
+		raise,
+		stepCol,
+	)
+	s = b.assignVars(s, []int{stepVar}, []opt.ScalarExpr{checkedStep})
+
+	// CONTINUE statements and the end of the loop body invoke a continuation
+	// that increments the loop variable and then starts the next iteration.
+	loopCon := b.makeRecursiveContinuation("stmt_for")
+	incrCon := b.makeContinuation("loop_incr")
+	is := incrCon.s
+	loopVarCol := b.ob.factory.ConstructVariable(is.cols[loopVar].id)
+	stepVarCol := b.ob.factory.ConstructVariable(is.cols[stepVar].id)
+	var next opt.ScalarExpr
+	if stmt.Reverse {
+		next = b.ob.factory.ConstructMinus(loopVarCol, stepVarCol)
+	} else {
+		next = b.ob.factory.ConstructPlus(loopVarCol, stepVarCol)
+	}
+	is = b.assignVars(is, []int{loopVar}, []opt.ScalarExpr{next})
+	b.appendBodyStmt(incrCon, b.callContinuation(loopCon, is))
+
+	b.pushExitTarget(stmt.Label, exitCon, &incrCon)
+	b.pushContinuation(incrCon)
+	bodyCon := b.buildContinuation("for_body", stmt.Body)
+	b.popContinuation()
+	b.popExitTarget()
+
+	ls := loopCon.s
+	loopVarCol = b.ob.factory.ConstructVariable(ls.cols[loopVar].id)
+	upperVarCol := b.ob.factory.ConstructVariable(ls.cols[upperVar].id)
+	var done opt.ScalarExpr
+	if stmt.Reverse {
+		done = b.ob.factory.ConstructLt(loopVarCol, upperVarCol)
+	} else {
+		done = b.ob.factory.ConstructGt(loopVarCol, upperVarCol)
+	}
+	b.appendBodyStmt(loopCon, b.projectResult(ls, b.makeCase(
+		done, b.makeContinuationCall(exitCon, ls), b.makeContinuationCall(bodyCon, ls),
+	)))
+	return b.callContinuation(loopCon, s)
+}
+
+// buildForQueryLoop builds a FOR loop over the rows of a query. The query is
+// executed once, before the first iteration, and its rows are stored as an
+// array of tuples in a hidden variable. A second hidden variable tracks the
+// index of the current row.
+func (b *plpgsqlBuilder) buildForQueryLoop(
+	stmt *plpgsqltree.PLpgSQLStmtForQuerySelectLoop,
+	rest []plpgsqltree.PLpgSQLStatement,
+	s *scope,
+) *scope {
+	targets := make([]int, len(stmt.Target))
+	for i := range stmt.Target {
+		targets[i] = b.resolveAssignableVar(stmt.Target[i])
+	}
+	exitCon := b.buildContinuation("loop_exit", rest)
+	rows := b.buildQueryRows(stmt.Query, s)
+
+	numVars := len(b.vars)
+	defer func() { b.vars = b.vars[:numVars] }()
+	rowsVar := b.addHiddenVar("loop_rows", rows.DataType())
+	idxVar := b.addHiddenVar("loop_idx", types.Int)
+	s = b.assignVars(s, []int{rowsVar, idxVar}, []opt.ScalarExpr{
+		rows, b.ob.factory.ConstructConstVal(tree.NewDInt(1), types.Int),
+	})
+
+	// CONTINUE statements and the end of the loop body invoke a continuation
+	// that increments the row index and then starts the next iteration.
+	loopCon := b.makeRecursiveContinuation("stmt_for_query")
+	incrCon := b.makeContinuation("loop_incr")
+	is := incrCon.s
+	next := b.ob.factory.ConstructPlus(
+		b.ob.factory.ConstructVariable(is.cols[idxVar].id),
+		b.ob.factory.ConstructConstVal(tree.NewDInt(1), types.Int),
+	)
+	is = b.assignVars(is, []int{idxVar}, []opt.ScalarExpr{next})
+	b.appendBodyStmt(incrCon, b.callContinuation(loopCon, is))
+
+	// The body of the loop starts by assigning the columns of the current row
+	// to the target variables.
+	b.pushExitTarget(stmt.Label, exitCon, &incrCon)
+	b.pushContinuation(incrCon)
+	bodyCon := b.makeContinuation("for_body")
+	bs := bodyCon.s
+	row := b.makeCurrentRow(bs, rowsVar, idxVar)
+	vals := make([]opt.ScalarExpr, len(targets))
+	for i := range targets {
+		if i < len(row.DataType().TupleContents()) {
+			vals[i] = b.ob.factory.ConstructColumnAccess(row, memo.TupleOrdinal(i))
+		} else {
+			vals[i] = b.ob.factory.ConstructNull(b.vars[targets[i]].typ)
+		}
+	}
+	bs = b.assignVars(bs, targets, vals)
+	b.appendBodyStmt(bodyCon, b.buildPLpgSQLStatements(stmt.Body, bs))
+	b.popContinuation()
+	b.popExitTarget()
+
+	// The loop is done when the index is past the last row. A row is never
+	// NULL, even if all of its columns are NULL, so a NULL row indicates that
+	// the index is out of bounds.
+	ls := loopCon.s
+	currentRow := b.makeCurrentRow(ls, rowsVar, idxVar)
+	done := b.ob.factory.ConstructIs(currentRow, b.ob.factory.ConstructNull(currentRow.DataType()))
+	b.appendBodyStmt(loopCon, b.projectResult(ls, b.makeCase(
+		done, b.makeContinuationCall(exitCon, ls), b.makeContinuationCall(bodyCon, ls),
+	)))
+	return b.callContinuation(loopCon, s)
+}
+
+// buildQueryRows builds a scalar expression that produces the rows of the
+// given query as an array of tuples, in the order requested by the query.
+func (b *plpgsqlBuilder) buildQueryRows(query string, s *scope) opt.ScalarExpr {
+	stmt := b.parseSQLStmt(query)
+	sel, ok := stmt.(*tree.Select)
+	if !ok {
+		panic(unimplemented.New("FOR loop over non-SELECT query",
+			"FOR loops over statements other than SELECT are not yet supported"))
+	}
+	// We must push() here so that the columns in s are correctly identified as
+	// outer columns.
+	stmtScope := b.ob.buildStmt(sel, nil /* desiredTypes */, s.push())
+	stmtScope.removeHiddenCols()
+
+	elems := make(memo.ScalarListExpr, len(stmtScope.cols))
+	contents := make([]*types.T, len(stmtScope.cols))
+	for i := range stmtScope.cols {
+		elems[i] = b.ob.factory.ConstructVariable(stmtScope.cols[i].id)
+		contents[i] = stmtScope.cols[i].typ
+	}
+	rowType := types.MakeTuple(contents)
+	rowScope := stmtScope.push()
+	rowScope.copyOrdering(stmtScope)
+	rowCol := b.ob.synthesizeColumn(
+		rowScope, scopeColName(""), rowType, nil /* expr */, b.ob.factory.ConstructTuple(elems, rowType),
+	)
+	input := b.ob.constructProject(stmtScope.expr, append(rowScope.cols, rowScope.extraCols...))
+	return b.ob.factory.ConstructArrayFlatten(input, &memo.SubqueryPrivate{
+		OriginalExpr: &tree.Subquery{Select: &tree.ParenSelect{Select: sel}},
+		Ordering:     stmtScope.ordering,
+		RequestedCol: rowCol.id,
+		WithinUDF:    b.ob.insideUDF,
+	})
+}
+
+// makeCurrentRow returns an expression that produces the current row of a FOR
+// loop over the rows of a query.
+func (b *plpgsqlBuilder) makeCurrentRow(s *scope, rowsVar, idxVar int) opt.ScalarExpr {
+	return b.ob.factory.ConstructIndirection(
+		b.ob.factory.ConstructVariable(s.cols[rowsVar].id),
+		b.ob.factory.ConstructVariable(s.cols[idxVar].id),
+	)
+}
+
+// buildExit builds an EXIT or CONTINUE statement.
+func (b *plpgsqlBuilder) buildExit(
+	stmt *plpgsqltree.PLpgSQLStmtExit, rest []plpgsqltree.PLpgSQLStatement, s *scope,
+) *scope {
+	con := b.resolveExitTarget(stmt)
+	if stmt.Condition == nil {
+		return b.callContinuation(con, s)
+	}
+	restCon := b.buildContinuation("stmt_exit", rest)
+	cond := b.buildSQLExpr(stmt.Condition, types.Bool, s)
+	return b.projectResult(s, b.makeCase(
+		cond, b.makeContinuationCall(con, s), b.makeContinuationCall(restCon, s),
+	))
+}
+
+// resolveExitTarget returns the continuation that is invoked by the given
+// EXIT or CONTINUE statement.
+func (b *plpgsqlBuilder) resolveExitTarget(stmt *plpgsqltree.PLpgSQLStmtExit) continuation {
+	for i := len(b.exitTargets) - 1; i >= 0; i-- {
+		target := &b.exitTargets[i]
+		if stmt.Label == "" {
+			// An unlabeled EXIT or CONTINUE applies to the innermost loop.
+			if target.cont == nil {
+				continue
+			}
+		} else if target.label != stmt.Label {
+			continue
+		}
+		if stmt.IsExit {
+			return target.exit
+		}
+		if target.cont == nil {
+			panic(pgerror.Newf(pgcode.Syntax,
+				"block label \"%s\" cannot be used in CONTINUE", stmt.Label))
+		}
+		return *target.cont
+	}
+	if stmt.Label != "" {
+		panic(pgerror.Newf(pgcode.Syntax,
+			"there is no label \"%s\" attached to any block or loop enclosing this statement",
+			stmt.Label))
+	}
+	if stmt.IsExit {
+		panic(pgerror.New(pgcode.Syntax,
+			"EXIT cannot be used outside a loop, unless it has a label"))
+	}
+	panic(pgerror.New(pgcode.Syntax, "CONTINUE cannot be used outside a loop"))
+}
+
+// buildReturn builds a RETURN statement.
+func (b *plpgsqlBuilder) buildReturn(stmt *plpgsqltree.PLpgSQLStmtReturn, s *scope) *scope {
+	var val opt.ScalarExpr
+	switch {
+	case len(b.outParams) > 0:
+		if stmt.Expr != nil {
+			panic(pgerror.New(pgcode.DatatypeMismatch,
+				"RETURN cannot have a parameter in function with OUT parameters"))
+		}
+		val = b.makeOutParamsResult(s)
+	case b.returnType.Family() == types.VoidFamily:
+		if stmt.Expr != nil {
+			panic(pgerror.New(pgcode.DatatypeMismatch,
+				"RETURN cannot have a parameter in function returning void"))
+		}
+		val = b.ob.factory.ConstructNull(b.returnType)
+	case stmt.Expr == nil:
+		panic(pgerror.New(pgcode.Syntax, "missing expression at or near \"RETURN\""))
+	default:
+		val = b.buildSQLExpr(stmt.Expr, b.returnType, s)
+	}
+	return b.projectResult(s, val)
+}
+
+// buildRaise builds a RAISE statement. The statement is built as a call to the
+// crdb_internal.plpgsql_raise builtin, which is executed as the first
+// statement of a continuation for the rest of the statements.
+func (b *plpgsqlBuilder) buildRaise(
+	stmt *plpgsqltree.PLpgSQLStmtRaise, rest []plpgsqltree.PLpgSQLStatement, s *scope,
+) *scope {
+	raiseCon := b.makeContinuation("stmt_raise")
+	b.appendBodyStmt(raiseCon, b.buildRaiseStmt(makeRaiseCall(stmt), raiseCon.s))
+	b.appendBodyStmt(raiseCon, b.buildPLpgSQLStatements(rest, raiseCon.s))
+	return b.callContinuation(raiseCon, s)
+}
+
+// buildRaiseStmt builds a statement that evaluates the given call to the
+// crdb_internal.plpgsql_raise builtin.
+func (b *plpgsqlBuilder) buildRaiseStmt(raise tree.Expr, s *scope) *scope {
+	raiseScope := s.push()
+	b.ob.synthesizeColumn(
+		raiseScope, scopeColName(""), types.Int, nil /* expr */, b.buildSQLExpr(raise, types.Int, s),
+	)
+	raiseScope.expr = b.ob.constructProject(s.expr, raiseScope.cols)
+	return raiseScope
+}
+
+// makeRaiseCall returns a call to the crdb_internal.plpgsql_raise builtin that
+// implements the given RAISE statement.
+func makeRaiseCall(stmt *plpgsqltree.PLpgSQLStmtRaise) tree.Expr {
+	severity := stmt.LogLevel
+	switch severity {
+	case "", "EXCEPTION":
+		severity = "ERROR"
+	case "DEBUG":
+		severity = "DEBUG1"
+	}
+
+	var code, condition string
+	if stmt.Code != "" {
+		code = checkSQLState(stmt.Code)
+		condition = stmt.Code
+	} else if stmt.CodeName != "" {
+		code = getConditionCode(stmt.CodeName)
+		condition = stmt.CodeName
+	}
+	var message, detail, hint tree.Expr
+	if stmt.Message != "" {
+		message = makeRaiseMessage(stmt.Message, stmt.Params)
+	}
+	for i := range stmt.Options {
+		option := &stmt.Options[i]
+		var dest *tree.Expr
+		switch option.OptType {
+		case plpgsqltree.PLpgSQLRaiseOptionMessage:
+			dest = &message
+		case plpgsqltree.PLpgSQLRaiseOptionDetail:
+			dest = &detail
+		case plpgsqltree.PLpgSQLRaiseOptionHint:
+			dest = &hint
+		case plpgsqltree.PLpgSQLRaiseOptionErrCode:
+			if code != "" {
+				panic(raiseOptionAlreadySpecifiedError(option.OptType))
+			}
+			str, ok := option.Expr.(*tree.StrVal)
+			if !ok {
+				panic(unimplemented.New("RAISE ERRCODE",
+					"RAISE statement option ERRCODE with a non-constant value is not yet supported"))
+			}
+			condition = str.RawString()
+			if _, ok := pgcode.PLpgSQLConditionNameToCode[strings.ToLower(condition)]; ok {
+				code = getConditionCode(condition)
+			} else {
+				code = checkSQLState(condition)
+			}
+			continue
+		default:
+			panic(unimplemented.New("RAISE "+option.OptType.String(), fmt.Sprintf(
+				"RAISE statement option %s is not yet supported", option.OptType,
+			)))
+		}
+		if *dest != nil {
+			panic(raiseOptionAlreadySpecifiedError(option.OptType))
+		}
+		*dest = &tree.CastExpr{Expr: option.Expr, Type: types.String, SyntaxMode: tree.CastShort}
+	}
+
+	if message == nil {
+		if condition == "" && detail == nil && hint == nil {
+			panic(unimplemented.New("RAISE without arguments",
+				"RAISE statement without arguments is not yet supported"))
+		}
+		// Like Postgres, use the condition as the message if no message was
+		// given.
+		message = tree.NewDString(condition)
+	}
+	if detail == nil {
+		detail = tree.NewDString("")
+	}
+	if hint == nil {
+		hint = tree.NewDString("")
+	}
+	return makeRaiseFunc(tree.NewDString(severity), message, detail, hint, code)
+}
+
+func raiseOptionAlreadySpecifiedError(optType plpgsqltree.PLpgSQLRaiseOptionType) error {
+	return pgerror.Newf(pgcode.Syntax, "RAISE option already specified: %s", optType)
+}
+
+// makeRaiseMessage returns an expression that formats the message of a RAISE
+// statement. Each "%" in the format string is replaced by the next parameter,
+// and "%%" is replaced by a literal "%". NULL parameters are formatted as
+// "<NULL>", like in Postgres.
+func makeRaiseMessage(format string, params []tree.Expr) tree.Expr {
+	var result tree.Expr
+	add := func(expr tree.Expr) {
+		if result == nil {
+			result = expr
+			return
+		}
+		result = &tree.BinaryExpr{
+			Operator: treebin.MakeBinaryOperator(treebin.Concat),
+			Left:     result,
+			Right:    expr,
+		}
+	}
+	var buf strings.Builder
+	paramIdx := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			buf.WriteByte(format[i])
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			buf.WriteByte('%')
+			i++
+			continue
+		}
+		if paramIdx >= len(params) {
+			panic(pgerror.New(pgcode.Syntax, "too few parameters specified for RAISE"))
+		}
+		if buf.Len() > 0 {
+			add(tree.NewDString(buf.String()))
+			buf.Reset()
+		}
+		add(&tree.CoalesceExpr{
+			Name: "COALESCE",
+			Exprs: tree.Exprs{
+				&tree.CastExpr{Expr: params[paramIdx], Type: types.String, SyntaxMode: tree.CastShort},
+				tree.NewDString("<NULL>"),
+			},
+		})
+		paramIdx++
+	}
+	if paramIdx < len(params) {
+		panic(pgerror.New(pgcode.Syntax, "too many parameters specified for RAISE"))
+	}
+	if buf.Len() > 0 || result == nil {
+		add(tree.NewDString(buf.String()))
+	}
+	return result
+}
+
+// makeRaiseFunc returns a call to the crdb_internal.plpgsql_raise builtin.
+func makeRaiseFunc(severity, message, detail, hint tree.Expr, code string) tree.Expr {
+	return &tree.FuncExpr{
+		Func:  tree.WrapFunction("crdb_internal.plpgsql_raise"),
+		Exprs: tree.Exprs{severity, message, detail, hint, tree.NewDString(code)},
+	}
+}
+
+// buildExecSQL builds a SQL statement in a PL/pgSQL function body, optionally
+// with an INTO clause.
+func (b *plpgsqlBuilder) buildExecSQL(
+	stmt *plpgsqltree.PLpgSQLStmtExecSql, rest []plpgsqltree.PLpgSQLStatement, s *scope,
+) *scope {
+	sqlStmt := b.parseSQLStmt(stmt.SqlStmt)
+	if !stmt.Into {
+		if _, ok := sqlStmt.(*tree.Select); ok {
+			panic(errors.WithHint(
+				pgerror.New(pgcode.Syntax, "query has no destination for result data"),
+				"If you want to discard the results of a SELECT, use PERFORM instead.",
+			))
+		}
+		return b.buildSQLStmt(sqlStmt, rest, s)
+	}
+	if stmt.Strict {
+		panic(unimplemented.New("INTO STRICT",
+			"INTO STRICT statements are not yet supported"))
+	}
+	sel, ok := sqlStmt.(*tree.Select)
+	if !ok {
+		panic(unimplemented.New("INTO with non-SELECT statement",
+			"INTO clauses on statements other than SELECT are not yet supported"))
+	}
+	targets := make([]int, len(stmt.Target))
+	for i := range stmt.Target {
+		targets[i] = b.resolveAssignableVar(stmt.Target[i])
+	}
+
+	// The first row of the query is joined with the parameters of a new
+	// continuation, and its columns are assigned to the target variables. If
+	// the query returns no rows, the targets are assigned NULL, like in
+	// Postgres.
+	intoCon := b.makeContinuation("stmt_exec_into")
+	is := intoCon.s
+	stmtScope := b.ob.buildStmt(sel, nil /* desiredTypes */, is)
+	b.ob.buildLimit(&tree.Limit{Count: tree.NewDInt(1)}, b.ob.allocScope(), stmtScope)
+	stmtScope.removeHiddenCols()
+	joinScope := is.push()
+	joinScope.appendColumnsFromScope(is)
+	joinScope.expr = b.ob.factory.ConstructLeftJoin(
+		is.expr, stmtScope.expr, memo.TrueFilter, memo.EmptyJoinPrivate,
+	)
+	vals := make([]opt.ScalarExpr, len(targets))
+	for i := range targets {
+		if i < len(stmtScope.cols) {
+			vals[i] = b.ob.factory.ConstructVariable(stmtScope.cols[i].id)
+		} else {
+			vals[i] = b.ob.factory.ConstructNull(b.vars[targets[i]].typ)
+		}
+	}
+	intoScope := b.assignVars(joinScope, targets, vals)
+	b.appendBodyStmt(intoCon, b.buildPLpgSQLStatements(rest, intoScope))
+	return b.callContinuation(intoCon, s)
+}
+
+// buildSQLStmt builds a SQL statement whose result is discarded. The statement
+// is executed as the first statement of a continuation for the rest of the
+// statements.
+func (b *plpgsqlBuilder) buildSQLStmt(
+	stmt tree.Statement, rest []plpgsqltree.PLpgSQLStatement, s *scope,
+) *scope {
+	execCon := b.makeContinuation("stmt_exec")
+	b.appendBodyStmt(execCon, b.ob.buildStmt(stmt, nil /* desiredTypes */, execCon.s))
+	b.appendBodyStmt(execCon, b.buildPLpgSQLStatements(rest, execCon.s))
+	return b.callContinuation(execCon, s)
+}
+
+// parseSQLStmt parses a single SQL statement that is embedded in a PL/pgSQL
+// statement.
+func (b *plpgsqlBuilder) parseSQLStmt(sql string) tree.Statement {
+	stmt, err := parser.ParseOne(sql)
+	if err != nil {
+		panic(err)
+	}
+	return stmt.AST
+}
+
+// buildSQLExpr builds a SQL expression in a PL/pgSQL statement. The result is
+// cast to the given type with an assignment cast, if necessary.
+func (b *plpgsqlBuilder) buildSQLExpr(expr tree.Expr, typ *types.T, s *scope) opt.ScalarExpr {
+	// We need to save and restore the previous value of the field in semaCtx in
+	// case we are recursively called within a subquery context.
+	defer b.ob.semaCtx.Properties.Restore(b.ob.semaCtx.Properties)
+	b.ob.semaCtx.Properties.Require("PL/pgSQL expression", tree.RejectSpecial)
+	texpr := s.resolveType(expr, typ)
+	scalar := b.ob.buildScalar(texpr, s, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	return b.coerceType(scalar, typ)
+}
+
+// coerceType adds an assignment cast to the given expression if its type is
+// not identical to the given type.
+func (b *plpgsqlBuilder) coerceType(scalar opt.ScalarExpr, typ *types.T) opt.ScalarExpr {
+	resolved := scalar.DataType()
+	if resolved.Identical(typ) || typ.Family() == types.VoidFamily {
+		return scalar
+	}
+	if !cast.ValidCast(resolved, typ, cast.ContextAssignment) {
+		panic(sqlerrors.NewInvalidAssignmentCastError(resolved, typ, "" /* targetColName */))
+	}
+	return b.ob.factory.ConstructAssignmentCast(scalar, typ)
+}
+
+// assignVars returns a new scope in which each variable vars[idxs[i]] is
+// assigned vals[i]. The indexes may refer to variables that are not yet
+// columns of s, i.e. variables that were just added to b.vars.
+func (b *plpgsqlBuilder) assignVars(s *scope, idxs []int, vals []opt.ScalarExpr) *scope {
+	newScope := s.push()
+	inputCols := s.expr.Relational().OutputCols
+	for i := range b.vars {
+		var val opt.ScalarExpr
+		for j := range idxs {
+			if idxs[j] == i {
+				val = b.coerceType(vals[j], b.vars[i].typ)
+				break
+			}
+		}
+		if val == nil {
+			if i >= len(s.cols) {
+				panic(errors.AssertionFailedf("no column for variable %d", i))
+			}
+			if inputCols.Contains(s.cols[i].id) {
+				// Pass through the existing column.
+				newScope.cols = append(newScope.cols, s.cols[i])
+				newScope.cols[len(newScope.cols)-1].scalar = nil
+				continue
+			}
+			// The variable is a parameter of the enclosing routine, which must be
+			// projected into a new column.
+			val = b.ob.factory.ConstructVariable(s.cols[i].id)
+		}
+		v := &b.vars[i]
+		col := b.ob.synthesizeColumn(
+			newScope, scopeColName(v.name).WithMetadataName(v.metaName), v.typ, nil /* expr */, val,
+		)
+		if i < b.numParams {
+			col.setParamOrd(i)
+		}
+	}
+	newScope.expr = b.ob.constructProject(s.expr, newScope.cols)
+	return newScope
+}
+
+// projectResult returns a new scope with a single column that produces the
+// given result of the function.
+func (b *plpgsqlBuilder) projectResult(s *scope, result opt.ScalarExpr) *scope {
+	resultScope := s.push()
+	b.ob.synthesizeColumn(resultScope, scopeColName(""), b.returnType, nil /* expr */, result)
+	resultScope.expr = b.ob.constructProject(s.expr, resultScope.cols)
+	return resultScope
+}
+
+// makeCase returns a CASE expression that evaluates to then if cond is true,
+// and to orElse otherwise.
+func (b *plpgsqlBuilder) makeCase(cond, then, orElse opt.ScalarExpr) opt.ScalarExpr {
+	return b.ob.factory.ConstructCase(
+		memo.TrueSingleton,
+		memo.ScalarListExpr{b.ob.factory.ConstructWhen(cond, then)},
+		orElse,
+	)
+}
+
+// makeContinuation returns a new continuation with a parameter for each
+// variable that is currently in scope. The body of the continuation must be
+// built in con.s and added with appendBodyStmt.
+func (b *plpgsqlBuilder) makeContinuation(name string) continuation {
+	s := b.ob.allocScope()
+	params := make(opt.ColList, len(b.vars))
+	for i := range b.vars {
+		v := &b.vars[i]
+		col := b.ob.synthesizeColumn(
+			s, scopeColName(v.name).WithMetadataName(v.metaName), v.typ, nil /* expr */, nil, /* scalar */
+		)
+		if i < b.numParams {
+			col.setParamOrd(i)
+		}
+		params[i] = col.id
+	}
+	s.expr = b.constructNoColsRow()
+	b.continuationCount++
+	return continuation{
+		def:  &memo.UDFDefinition{Params: params},
+		name: fmt.Sprintf("%s_%d", name, b.continuationCount),
+		s:    s,
+	}
+}
+
+// makeRecursiveContinuation returns a new continuation that may be invoked
+// from within its own body.
+func (b *plpgsqlBuilder) makeRecursiveContinuation(name string) continuation {
+	con := b.makeContinuation(name)
+	con.def.IsRecursive = true
+	return con
+}
+
+// buildContinuation returns a continuation that executes the given statements.
+// If there are no statements, the current fall-through continuation is
+// returned instead of building a new one.
+func (b *plpgsqlBuilder) buildContinuation(
+	name string, stmts []plpgsqltree.PLpgSQLStatement,
+) continuation {
+	if len(stmts) == 0 {
+		return b.fallThrough()
+	}
+	con := b.makeContinuation(name)
+	b.appendBodyStmt(con, b.buildPLpgSQLStatements(stmts, con.s))
+	return con
+}
+
+// appendBodyStmt adds the expression of the given scope as the next statement
+// in the body of the continuation.
+func (b *plpgsqlBuilder) appendBodyStmt(con continuation, s *scope) {
+	con.def.Body = append(con.def.Body, memo.RelRequiredPropsExpr{
+		RelExpr:   s.expr,
+		PhysProps: s.makePhysicalProps(),
+	})
+}
+
+// makeContinuationCall returns an expression that invokes the given
+// continuation with the values of the variables in s.
+func (b *plpgsqlBuilder) makeContinuationCall(con continuation, s *scope) opt.ScalarExpr {
+	args := make(memo.ScalarListExpr, len(con.def.Params))
+	for i := range args {
+		args[i] = b.ob.factory.ConstructVariable(s.cols[i].id)
+	}
+	return b.ob.factory.ConstructUDF(
+		args,
+		&memo.UDFPrivate{
+			Name:              con.name,
+			Typ:               b.returnType,
+			Volatility:        b.volatility,
+			CalledOnNullInput: true,
+			Def:               con.def,
+		},
+	)
+}
+
+// callContinuation returns a new scope with a single column that invokes the
+// given continuation with the values of the variables in s.
+func (b *plpgsqlBuilder) callContinuation(con continuation, s *scope) *scope {
+	return b.projectResult(s, b.makeContinuationCall(con, s))
+}
+
+// constructNoColsRow returns a Values expression with a single row and no
+// columns.
+func (b *plpgsqlBuilder) constructNoColsRow() memo.RelExpr {
+	return b.ob.factory.ConstructValues(memo.ScalarListWithEmptyTuple, &memo.ValuesPrivate{
+		Cols: opt.ColList{},
+		ID:   b.ob.factory.Metadata().NextUniqueID(),
+	})
+}
+
+func (b *plpgsqlBuilder) fallThrough() continuation {
+	return b.continuations[len(b.continuations)-1]
+}
+
+func (b *plpgsqlBuilder) pushContinuation(con continuation) {
+	b.continuations = append(b.continuations, con)
+}
+
+func (b *plpgsqlBuilder) popContinuation() {
+	b.continuations = b.continuations[:len(b.continuations)-1]
+}
+
+func (b *plpgsqlBuilder) pushExitTarget(label string, exit continuation, cont *continuation) {
+	b.exitTargets = append(b.exitTargets, exitTarget{label: label, exit: exit, cont: cont})
+}
+
+func (b *plpgsqlBuilder) popExitTarget() {
+	b.exitTargets = b.exitTargets[:len(b.exitTargets)-1]
+}
+
+// addHiddenVar adds a variable that cannot be referenced by the function body
+// and returns its index in b.vars.
+func (b *plpgsqlBuilder) addHiddenVar(metaName string, typ *types.T) int {
+	b.vars = append(b.vars, plpgsqlVar{metaName: metaName, typ: typ})
+	return len(b.vars) - 1
+}
+
+// findVar returns the index of the innermost variable with the given name, or
+// -1 if there is no such variable.
+func (b *plpgsqlBuilder) findVar(name tree.Name) int {
+	for i := len(b.vars) - 1; i >= 0; i-- {
+		if b.vars[i].name != "" && b.vars[i].name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// resolveAssignableVar returns the index of the variable with the given name.
+// It panics if there is no such variable, or if the variable is a constant.
+func (b *plpgsqlBuilder) resolveAssignableVar(name tree.Name) int {
+	idx := b.findVar(name)
+	if idx < 0 {
+		panic(pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", name))
+	}
+	b.checkAssignable(idx)
+	return idx
+}
+
+func (b *plpgsqlBuilder) checkAssignable(idx int) {
+	if b.vars[idx].constant {
+		panic(pgerror.Newf(pgcode.Syntax, "variable \"%s\" is declared CONSTANT", b.vars[idx].name))
+	}
+}
+
+// getExceptionCode returns the error code that is matched by the given
+// condition of an exception handler.
+func getExceptionCode(cond plpgsqltree.PLpgSQLCondition) pgcode.Code {
+	if cond.SqlErrState != "" {
+		return pgcode.MakeCode(checkSQLState(cond.SqlErrState))
+	}
+	if strings.EqualFold(cond.Name, "others") {
+		return tree.ExceptionCodeOthers
+	}
+	return pgcode.MakeCode(getConditionCode(cond.Name))
+}
+
+// getConditionCode returns the error code for the condition with the given
+// name, e.g. "22012" for division_by_zero.
+func getConditionCode(name string) string {
+	code, ok := pgcode.PLpgSQLConditionNameToCode[strings.ToLower(name)]
+	if !ok {
+		panic(pgerror.Newf(pgcode.UndefinedObject, "unrecognized exception condition \"%s\"", name))
+	}
+	return code
+}
+
+// checkSQLState panics if the given string is not a valid SQLSTATE code, which
+// consists of five digits or upper-case letters.
+func checkSQLState(code string) string {
+	if len(code) != 5 {
+		panic(pgerror.Newf(pgcode.Syntax, "invalid SQLSTATE code \"%s\"", code))
+	}
+	for i := 0; i < len(code); i++ {
+		if !(code[i] >= '0' && code[i] <= '9') && !(code[i] >= 'A' && code[i] <= 'Z') {
+			panic(pgerror.Newf(pgcode.Syntax, "invalid SQLSTATE code \"%s\"", code))
+		}
+	}
+	return code
+}
@@ -0,0 +1,234 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// rowLevelSecurityApplies returns true if the row-level security policies of
+// the given table must be enforced for the current user. Policies are not
+// enforced if row-level security is disabled for the table, if the user has
+// the BYPASSRLS role option, or if the user owns the table and row-level
+// security is not forced for the table.
+func (b *Builder) rowLevelSecurityApplies(tab cat.Table) bool {
+	if !tab.IsRowLevelSecurityEnabled() || b.insideViewDef || b.insideFuncDef {
+		return false
+	}
+
+	// Whether or not the policies are enforced, and which policies are
+	// enforced, depends on the current user. The memo staleness check does not
+	// account for the user, so the memo cannot be reused.
+	b.DisableMemoReuse = true
+
+	bypass, err := b.catalog.HasRoleOption(b.ctx, roleoption.BYPASSRLS)
+	if err != nil {
+		panic(err)
+	}
+	if bypass {
+		return false
+	}
+	if !tab.IsRowLevelSecurityForced() {
+		isOwner, err := b.catalog.HasOwnership(b.ctx, tab)
+		if err != nil {
+			panic(err)
+		}
+		if isOwner {
+			return false
+		}
+	}
+	return true
+}
+
+// policyAppliesToUser returns true if the given policy applies to the current
+// user, either because it applies to the public role or because the user is a
+// member of one of the roles of the policy.
+func (b *Builder) policyAppliesToUser(policy cat.Policy) bool {
+	for i, n := 0, policy.RoleCount(); i < n; i++ {
+		role := policy.Role(i)
+		if role.IsPublicRole() {
+			return true
+		}
+		isMember, err := b.catalog.IsMemberOfRole(b.ctx, role)
+		if err != nil {
+			panic(err)
+		}
+		if isMember {
+			return true
+		}
+	}
+	return false
+}
+
+// buildRowLevelSecurityExpr returns a boolean expression that combines the
+// policies of the given table which apply to the current user and the given
+// command. If withCheck is true, the WITH CHECK expressions of the policies
+// are combined, falling back to the USING expression for policies without a
+// WITH CHECK expression. Otherwise, the USING expressions are combined.
+//
+// As in Postgres, the expressions of permissive policies are combined with OR,
+// and the result is combined with the expressions of restrictive policies
+// using AND. If there are no permissive policies, the expression is false, so
+// that no rows are visible or writable.
+func (b *Builder) buildRowLevelSecurityExpr(
+	tab cat.Table, cmd tree.PolicyCommand, withCheck bool,
+) tree.Expr {
+	var permissive, restrictive tree.Expr
+	for i, n := 0, tab.PolicyCount(); i < n; i++ {
+		policy := tab.Policy(i)
+		if policy.Command() != cmd && policy.Command() != tree.PolicyCommandAll {
+			continue
+		}
+		if !b.policyAppliesToUser(policy) {
+			continue
+		}
+		exprStr := policy.UsingExpr()
+		if withCheck && policy.WithCheckExpr() != "" {
+			exprStr = policy.WithCheckExpr()
+		}
+		var expr tree.Expr = tree.DBoolTrue
+		if exprStr != "" {
+			var err error
+			expr, err = parser.ParseExpr(exprStr)
+			if err != nil {
+				panic(err)
+			}
+		}
+		expr = &tree.ParenExpr{Expr: expr}
+		if policy.Type() == tree.PolicyTypeRestrictive {
+			if restrictive == nil {
+				restrictive = expr
+			} else {
+				restrictive = &tree.AndExpr{Left: restrictive, Right: expr}
+			}
+		} else {
+			if permissive == nil {
+				permissive = expr
+			} else {
+				permissive = &tree.OrExpr{Left: permissive, Right: expr}
+			}
+		}
+	}
+	if permissive == nil {
+		return tree.DBoolFalse
+	}
+	if restrictive == nil {
+		return permissive
+	}
+	return &tree.AndExpr{Left: &tree.ParenExpr{Expr: permissive}, Right: restrictive}
+}
+
+// addRowLevelSecurityFilter wraps the expression of the given scope, which
+// must be a scan of the given table, in a Select that filters out the rows
+// that are not visible to the current user according to the USING expressions
+// of the policies for each of the given commands. It should only be called if
+// rowLevelSecurityApplies returns true for the table.
+func (b *Builder) addRowLevelSecurityFilter(
+	tab cat.Table, cmds []tree.PolicyCommand, s *scope,
+) {
+	filters := make(memo.FiltersExpr, 0, len(cmds))
+	for _, cmd := range cmds {
+		expr := b.buildRowLevelSecurityExpr(tab, cmd, false /* withCheck */)
+		texpr := s.resolveAndRequireType(expr, types.Bool)
+		scalar := b.buildScalar(texpr, s, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+		filters = append(filters, b.factory.ConstructFiltersItem(scalar))
+	}
+	s.expr = b.factory.ConstructSelect(s.expr, filters)
+}
+
+// makeRowLevelSecurityCheckExpr wraps the given boolean expression in a call
+// to a builtin that raises an error unless the expression is true.
+func makeRowLevelSecurityCheckExpr(expr tree.Expr, tab cat.Table) tree.Expr {
+	return &tree.FuncExpr{
+		Func:  tree.WrapFunction("crdb_internal.row_level_security_check"),
+		Exprs: tree.Exprs{expr, tree.NewDString(string(tab.Name()))},
+	}
+}
+
+// addRowLevelSecurityCheck wraps the input expression of the mutation in a
+// Select that raises an error if a row violates the given check expression,
+// which is resolved against the given scope. The expression should be built
+// with makeRowLevelSecurityCheckExpr.
+func (mb *mutationBuilder) addRowLevelSecurityCheck(check tree.Expr, s *scope) {
+	texpr := s.resolveAndRequireType(check, types.Bool)
+	scalar := mb.b.buildScalar(texpr, s, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+	mb.outScope.expr = mb.b.factory.ConstructSelect(
+		mb.outScope.expr,
+		memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(scalar)},
+	)
+}
+
+// addRowLevelSecurityChecksForInsert adds a check that every inserted row
+// satisfies the INSERT policies of the target table. It must be called after
+// disambiguateColumns, so that the policy expressions refer to the inserted
+// values.
+func (mb *mutationBuilder) addRowLevelSecurityChecksForInsert() {
+	if !mb.rowLevelSecurity {
+		return
+	}
+	check := mb.b.buildRowLevelSecurityExpr(mb.tab, tree.PolicyCommandInsert, true /* withCheck */)
+	mb.addRowLevelSecurityCheck(makeRowLevelSecurityCheckExpr(check, mb.tab), mb.outScope)
+}
+
+// addRowLevelSecurityChecksForUpdate adds a check that every updated row
+// satisfies the UPDATE policies of the target table. It must be called after
+// disambiguateColumns, so that the policy expressions refer to the updated
+// values. The rows to update have already been filtered by the USING
+// expressions of the policies in buildInputForUpdate.
+func (mb *mutationBuilder) addRowLevelSecurityChecksForUpdate() {
+	if !mb.rowLevelSecurity {
+		return
+	}
+	check := mb.b.buildRowLevelSecurityExpr(mb.tab, tree.PolicyCommandUpdate, true /* withCheck */)
+	mb.addRowLevelSecurityCheck(makeRowLevelSecurityCheckExpr(check, mb.tab), mb.outScope)
+}
+
+// addRowLevelSecurityChecksForUpsert adds checks that every inserted row
+// satisfies the INSERT policies of the target table, and that every updated
+// row satisfies the UPDATE policies. Unlike UPDATE, an existing row that
+// conflicts with an inserted row is not silently skipped if it is not visible
+// to the user; instead an error is raised, as in Postgres. It must be called
+// after disambiguateColumns, and requires that existing rows are fetched.
+func (mb *mutationBuilder) addRowLevelSecurityChecksForUpsert() {
+	if !mb.rowLevelSecurity {
+		return
+	}
+	canaryCol := mb.fetchScope.getColumn(mb.canaryColID)
+
+	// The existing row must be visible to the user and satisfy the USING
+	// expressions of the UPDATE policies. These expressions refer to the fetch
+	// columns, so they are resolved against the fetch scope.
+	existing := &tree.OrExpr{
+		Left: &tree.IsNullExpr{Expr: canaryCol},
+		Right: &tree.AndExpr{
+			Left:  &tree.ParenExpr{Expr: mb.b.buildRowLevelSecurityExpr(mb.tab, tree.PolicyCommandSelect, false /* withCheck */)},
+			Right: &tree.ParenExpr{Expr: mb.b.buildRowLevelSecurityExpr(mb.tab, tree.PolicyCommandUpdate, false /* withCheck */)},
+		},
+	}
+	mb.addRowLevelSecurityCheck(makeRowLevelSecurityCheckExpr(existing, mb.tab), mb.fetchScope)
+
+	// The new row must satisfy the WITH CHECK expressions of either the INSERT
+	// or the UPDATE policies, depending on whether a row is inserted or
+	// updated.
+	newRow := &tree.CaseExpr{
+		Whens: []*tree.When{{
+			Cond: &tree.IsNullExpr{Expr: canaryCol},
+			Val:  mb.b.buildRowLevelSecurityExpr(mb.tab, tree.PolicyCommandInsert, true /* withCheck */),
+		}},
+		Else: mb.b.buildRowLevelSecurityExpr(mb.tab, tree.PolicyCommandUpdate, true /* withCheck */),
+	}
+	mb.addRowLevelSecurityCheck(makeRowLevelSecurityCheckExpr(newRow, mb.tab), mb.outScope)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
@@ -281,6 +282,16 @@ func (b *Builder) buildScalar(
 
 	case *tree.CastExpr:
 		texpr := t.Expr.(tree.TypedExpr)
+		if typ := t.ResolvedType(); hasDomainConstraints(typ) {
+			// Casts to a domain type must check the constraints of the domain. The
+			// value is first cast to the base type of the domain, and then wrapped
+			// in the constraint checks.
+			value := tree.NewTypedCastExpr(texpr, typ.DomainBaseType())
+			expr := inScope.resolveAndRequireType(makeDomainConstraintExpr(value, typ), typ)
+			arg := b.buildScalar(expr, inScope, nil, nil, colRefs)
+			out = b.factory.ConstructCast(arg, typ)
+			break
+		}
 		arg := b.buildScalar(texpr, inScope, nil, nil, colRefs)
 		out = b.factory.ConstructCast(arg, t.ResolvedType())
 
@@ -434,6 +445,9 @@ func (b *Builder) buildScalar(
 	case *sqlFnInfo:
 		out = b.buildSQLFn(t, inScope, outScope, outCol, colRefs)
 
+	case *groupingFuncInfo:
+		out = b.buildGroupingFunc(t, inScope)
+
 	case *srf:
 		if len(t.cols) == 1 {
 			if inGroupingContext {
@@ -549,6 +563,12 @@ func (b *Builder) buildFunction(
 	}
 
 	overload := f.ResolvedOverload()
+	if overload.IsProcedure {
+		panic(errors.WithHint(
+			pgerror.Newf(pgcode.WrongObjectType, "%s is a procedure", def.Name),
+			"To call a procedure, use CALL.",
+		))
+	}
 	if overload.HasSQLBody() {
 		return b.buildUDF(f, def, inScope, outScope, outCol, colRefs)
 	}
@@ -621,6 +641,11 @@ func (b *Builder) buildUDF(
 	colRefs *opt.ColSet,
 ) (out opt.ScalarExpr) {
 	o := f.ResolvedOverload()
+	if f.ResolvedType().Family() == types.TriggerFamily {
+		// Trigger functions are only built by buildTriggerFuncCall.
+		panic(pgerror.New(pgcode.FeatureNotSupported,
+			"trigger functions can only be called as triggers"))
+	}
 	b.factory.Metadata().AddUserDefinedFunction(o, f.Func.ReferenceByName)
 
 	// Validate that the return types match the original return types defined in
@@ -655,6 +680,47 @@ func (b *Builder) buildUDF(
 		}
 	}
 
+	// Build the parameters and the statements of the function body.
+	udfDef, rtyp, isSetReturning, isMultiColDataSource := b.buildUDFDefinition(o, rtyp)
+	if rtyp != f.ResolvedType() {
+		f.SetTypeAnnotation(rtyp)
+	}
+
+	out = b.factory.ConstructUDF(
+		args,
+		&memo.UDFPrivate{
+			Name:               def.Name,
+			Typ:                f.ResolvedType(),
+			SetReturning:       isSetReturning,
+			Volatility:         o.Volatility,
+			CalledOnNullInput:  o.CalledOnNullInput,
+			MultiColDataSource: isMultiColDataSource,
+			Def:                udfDef,
+		},
+	)
+
+	// Synthesize an output columns if necessary.
+	if outCol == nil {
+		if isMultiColDataSource {
+			// TODO(harding): Add the returns record property during create function.
+			f.ResolvedOverload().ReturnsRecordType = types.IsRecordType(rtyp) && len(o.OutParams) == 0
+			return b.finishBuildGeneratorFunction(f, f.ResolvedOverload(), out, inScope, outScope, outCol)
+		}
+		if outScope != nil {
+			outCol = b.synthesizeColumn(outScope, scopeColName(""), f.ResolvedType(), nil /* expr */, out)
+		}
+	}
+
+	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+}
+
+// buildUDFDefinition builds the parameters and the statements of the body of
+// the given user-defined function overload. rtyp is the return type of the
+// function. The returned type differs from rtyp if the function returns a
+// RECORD type that is determined by the last statement of the body.
+func (b *Builder) buildUDFDefinition(
+	o *tree.Overload, rtyp *types.T,
+) (_ *memo.UDFDefinition, _ *types.T, isSetReturning, isMultiColDataSource bool) {
 	// Create a new scope for building the statements in the function body. We
 	// start with an empty scope because a statement in the function body cannot
 	// refer to anything from the outer expression. If there are function
@@ -681,21 +747,51 @@ func (b *Builder) buildUDF(
 		}
 	}
 
-	// Parse the function body.
-	stmts, err := parser.Parse(o.Body)
-	if err != nil {
-		panic(err)
+	// Parse the function body. PL/pgSQL function bodies are parsed and built
+	// separately below.
+	var stmts statements.Statements
+	if o.Language != tree.FunctionLangPLpgSQL {
+		var err error
+		stmts, err = parser.Parse(o.Body)
+		if err != nil {
+			panic(err)
+		}
 	}
 
 	// Build an expression for each statement in the function body.
 	rels := make(memo.RelListExpr, len(stmts))
-	isSetReturning := o.Class == tree.GeneratorClass
+	isSetReturning = o.Class == tree.GeneratorClass
 	// TODO(mgartner): Once other UDFs can be referenced from within a UDF, a
 	// boolean will not be sufficient to track whether or not we are in a UDF.
 	// We'll need to track the depth of the UDFs we are building expressions
 	// within.
+	//
+	// The previous value is restored after the body is built, because a UDF
+	// body can invoke another UDF through a trigger on a table that it mutates.
+	insideUDF := b.insideUDF
 	b.insideUDF = true
-	isMultiColDataSource := false
+	if o.Language == tree.FunctionLangPLpgSQL {
+		// The body of a PL/pgSQL function is built into a single expression that
+		// produces the result of the function.
+		stmtScope := b.buildPLpgSQLFunctionBody(o.Body, rtyp, o.OutParams, o.Volatility, bodyScope)
+		if b.insideDataSource && rtyp.Family() == types.TupleFamily {
+			// When the function is used as a data source, the record produced by
+			// its OUT parameters is expanded into individual columns.
+			isMultiColDataSource = true
+			resultCol := stmtScope.cols[0].id
+			expandScope := bodyScope.push()
+			for i := range rtyp.TupleContents() {
+				e := b.factory.ConstructColumnAccess(b.factory.ConstructVariable(resultCol), memo.TupleOrdinal(i))
+				b.synthesizeColumn(expandScope, scopeColName(""), rtyp.TupleContents()[i], nil, e)
+			}
+			expandScope.expr = b.constructProject(stmtScope.expr, expandScope.cols)
+			stmtScope = expandScope
+		}
+		rels = memo.RelListExpr{{
+			RelExpr:   stmtScope.expr,
+			PhysProps: stmtScope.makePhysicalProps(),
+		}}
+	}
 	for i := range stmts {
 		stmtScope := b.buildStmt(stmts[i].AST, nil /* desiredTypes */, bodyScope)
 		expr := stmtScope.expr
@@ -706,8 +802,9 @@ func (b *Builder) buildUDF(
 			// Add a LIMIT 1 to the last statement if the UDF is not
 			// set-returning. This is valid because any other rows after the
 			// first can simply be ignored. The limit could be beneficial
-			// because it could allow additional optimization.
-			if !isSetReturning {
+			// because it could allow additional optimization. A limit is not
+			// added to a mutation because all of its rows must be mutated.
+			if !isSetReturning && !expr.Relational().CanMutate {
 				b.buildLimit(&tree.Limit{Count: tree.NewDInt(1)}, b.allocScope(), stmtScope)
 				expr = stmtScope.expr
 				// The limit expression will maintain the desired ordering, if any,
@@ -716,6 +813,18 @@ func (b *Builder) buildUDF(
 				physProps.Ordering = props.OrderingChoice{}
 			}
 
+			// A mutation without a RETURNING clause produces no columns. The
+			// result of the UDF is NULL in this case. Procedures do not return a
+			// value, so the result of the last statement is always discarded.
+			if len(physProps.Presentation) == 0 || o.IsProcedure {
+				stmtScope = bodyScope.push()
+				col := b.synthesizeColumn(
+					stmtScope, scopeColName(""), rtyp, nil /* expr */, b.factory.ConstructNull(rtyp),
+				)
+				expr = b.constructProject(expr, []scopeColumn{*col})
+				physProps = stmtScope.makePhysicalProps()
+			}
+
 			// If returning a RECORD type, the function return type needs to be
 			// modified because when we first parse the CREATE FUNCTION, the RECORD
 			// is represented as a tuple with any types and execution requires the
@@ -723,7 +832,9 @@ func (b *Builder) buildUDF(
 			// determine the types from the result columns or tuple of the last
 			// statement.
 			isSingleTupleResult := len(stmtScope.cols) == 1 && stmtScope.cols[0].typ.Family() == types.TupleFamily
-			if types.IsRecordType(rtyp) {
+			// The record type of a function with OUT parameters is determined by
+			// the parameters, so it is already concrete.
+			if types.IsRecordType(rtyp) && len(o.OutParams) == 0 {
 				if isSingleTupleResult {
 					// When the final statement returns a single tuple, we can use the
 					// tuple's types as the function return type.
@@ -738,7 +849,6 @@ func (b *Builder) buildUDF(
 					}
 					rtyp = types.MakeLabeledTuple(tc, tl)
 				}
-				f.SetTypeAnnotation(rtyp)
 			}
 
 			// Only a single column can be returned from a UDF, unless it is used as a
@@ -812,35 +922,8 @@ func (b *Builder) buildUDF(
 			PhysProps: physProps,
 		}
 	}
-	b.insideUDF = false
-
-	out = b.factory.ConstructUDF(
-		args,
-		&memo.UDFPrivate{
-			Name:               def.Name,
-			Params:             params,
-			Body:               rels,
-			Typ:                f.ResolvedType(),
-			SetReturning:       isSetReturning,
-			Volatility:         o.Volatility,
-			CalledOnNullInput:  o.CalledOnNullInput,
-			MultiColDataSource: isMultiColDataSource,
-		},
-	)
-
-	// Synthesize an output columns if necessary.
-	if outCol == nil {
-		if isMultiColDataSource {
-			// TODO(harding): Add the returns record property during create function.
-			f.ResolvedOverload().ReturnsRecordType = types.IsRecordType(rtyp)
-			return b.finishBuildGeneratorFunction(f, f.ResolvedOverload(), out, inScope, outScope, outCol)
-		}
-		if outScope != nil {
-			outCol = b.synthesizeColumn(outScope, scopeColName(""), f.ResolvedType(), nil /* expr */, out)
-		}
-	}
-
-	return b.finishBuildScalar(f, out, inScope, outScope, outCol)
+	b.insideUDF = insideUDF
+	return &memo.UDFDefinition{Params: params, Body: rels}, rtyp, isSetReturning, isMultiColDataSource
 }
 
 // buildRangeCond builds a RANGE clause as a simpler expression. Examples:
@@ -1017,6 +1100,8 @@ func (b *Builder) constructComparison(
 		return b.factory.ConstructOverlaps(left, right)
 	case treecmp.TSMatches:
 		return b.factory.ConstructTSMatches(left, right)
+	case treecmp.Adjacent:
+		return b.factory.ConstructAdjacent(left, right)
 	}
 	panic(errors.AssertionFailedf("unhandled comparison operator: %s", redact.Safe(cmp.Operator)))
 }
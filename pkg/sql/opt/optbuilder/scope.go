@@ -1083,6 +1083,11 @@ func (s *scope) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
 			break
 		}
 
+		if def.Name == "grouping" {
+			expr = s.replaceGroupingFunc(t)
+			break
+		}
+
 	case *tree.ArrayFlatten:
 		if sub, ok := t.Subquery.(*tree.Subquery); ok {
 			// Copy the ArrayFlatten expression so that the tree isn't mutated.
@@ -1422,6 +1427,30 @@ func (s *scope) replaceWindowFn(f *tree.FuncExpr, def *tree.ResolvedFunctionDefi
 	return &info
 }
 
+// replaceGroupingFunc replaces a call to the GROUPING function with a
+// groupingFuncInfo struct. The call is built once the grouping sets of the query
+// are known (see Builder.buildGroupingFunc).
+func (s *scope) replaceGroupingFunc(f *tree.FuncExpr) tree.Expr {
+	if s.builder.semaCtx.Properties.IsSet(tree.RejectAggregates) {
+		panic(pgerror.Newf(pgcode.Grouping,
+			"grouping operations are not allowed in %s", s.context))
+	}
+	if s.builder.semaCtx.Properties.IsSet(tree.RejectNestedAggregates) {
+		panic(pgerror.New(pgcode.Grouping,
+			"aggregate function calls cannot contain grouping operations"))
+	}
+	if len(f.Exprs) == 0 || len(f.Exprs) > 31 {
+		panic(pgerror.New(pgcode.TooManyArguments,
+			"GROUPING must have between 1 and 31 arguments"))
+	}
+
+	args := make([]tree.TypedExpr, len(f.Exprs))
+	for i, e := range f.Exprs {
+		args[i] = s.resolveType(e, types.Any)
+	}
+	return &groupingFuncInfo{FuncExpr: f, args: args}
+}
+
 // replaceSQLFn replaces a tree.SQLClass function with a sqlFnInfo struct. See
 // comments above tree.SQLClass and sqlFnInfo for details.
 func (s *scope) replaceSQLFn(f *tree.FuncExpr, def *tree.ResolvedFunctionDefinition) tree.Expr {
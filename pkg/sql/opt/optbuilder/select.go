@@ -143,7 +143,7 @@ func (b *Builder) buildDataSource(
 		switch t := ds.(type) {
 		case cat.Table:
 			tabMeta := b.addTable(t, &resName)
-			return b.buildScan(
+			outScope = b.buildScan(
 				tabMeta,
 				tableOrdinals(t, columnKinds{
 					includeMutations: false,
@@ -153,6 +153,10 @@ func (b *Builder) buildDataSource(
 				indexFlags, locking, inScope,
 				false, /* disableNotVisibleIndex */
 			)
+			if b.rowLevelSecurityApplies(t) {
+				b.addRowLevelSecurityFilter(t, []tree.PolicyCommand{tree.PolicyCommandSelect}, outScope)
+			}
+			return outScope
 
 		case cat.Sequence:
 			return b.buildSequenceSelect(t, &resName, inScope)
@@ -456,7 +460,15 @@ func (b *Builder) buildScanFromTableRef(
 	tn := tree.MakeUnqualifiedTableName(tab.Name())
 	tabMeta := b.addTable(tab, &tn)
 
-	return b.buildScan(tabMeta, ordinals, indexFlags, locking, inScope, false /* disableNotVisibleIndex */)
+	outScope = b.buildScan(tabMeta, ordinals, indexFlags, locking, inScope, false /* disableNotVisibleIndex */)
+	if b.rowLevelSecurityApplies(tab) {
+		if ref.Columns != nil {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"column references are not supported for table %q with row-level security", tab.Name()))
+		}
+		b.addRowLevelSecurityFilter(tab, []tree.PolicyCommand{tree.PolicyCommandSelect}, outScope)
+	}
+	return outScope
 }
 
 // addTable adds a table to the metadata and returns the TableMeta. The table
@@ -0,0 +1,561 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/typedesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/props"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// This file contains methods that build the triggers defined on the target
+// table of a mutation. A trigger calls a user-defined function which returns
+// TRIGGER either once for each row modified by the mutation (row-level), or
+// once for the statement (statement-level). A trigger function has no declared
+// parameters. Instead, it is built with the implicit parameters NEW and OLD of
+// the table's record type, which hold the new and old values of the row. NEW
+// is NULL for a delete, and OLD is NULL for an insert. Both are NULL for a
+// statement-level trigger. The function returns a value of the same type.
+//
+// -- BEFORE row-level triggers --
+//
+// The function is called for each row of the mutation input. The row it
+// returns replaces the new values of the row; if it returns NULL, the row is
+// skipped. For example:
+//
+//	insert t
+//	 ├── ...
+//	 └── project
+//	      ├── columns: a_trig:8 b_trig:9 ...
+//	      ├── select
+//	      │    ├── project
+//	      │    │    ├── columns: trig:7 column1:5 column2:6
+//	      │    │    ├── values
+//	      │    │    └── projections
+//	      │    │         └── trig_fn(CAST((column1:5, column2:6) AS t), CAST(NULL AS t))
+//	      │    └── filters
+//	      │         └── trig:7 IS DISTINCT FROM NULL
+//	      └── projections
+//	           ├── (trig:7).a
+//	           └── (trig:7).b
+//
+// Computed columns are recomputed from the values returned by the function.
+//
+// -- BEFORE statement-level triggers --
+//
+// The mutation is wrapped in a With expression that is always materialized,
+// and whose binding calls the function. The binding is executed before the
+// mutation.
+//
+// -- AFTER triggers --
+//
+// AFTER triggers are executed after the mutation in the same way as FK
+// cascades (see memo.FKCascade and afterTriggerBuilder). Row-level triggers are
+// fired before statement-level triggers.
+//
+// -- UPSERT and INSERT .. ON CONFLICT --
+//
+// As in Postgres, both the INSERT and the UPDATE statement-level triggers fire
+// for UPSERT and INSERT .. ON CONFLICT DO UPDATE. Row-level INSERT and UPDATE
+// triggers are not supported for these statements, since the action taken for
+// each row is only known during execution. INSERT .. ON CONFLICT DO NOTHING
+// fires the INSERT triggers for the rows that are inserted, except for BEFORE
+// row-level triggers, which are not supported because they would fire after
+// the conflicting rows are filtered out.
+
+// triggersEnabled returns true if the triggers of the target table should be
+// built. Triggers are not built when a function definition is validated,
+// since they are not part of the function body.
+func (mb *mutationBuilder) triggersEnabled() bool {
+	return !mb.b.insideFuncDef && mb.tab.TriggerCount() > 0
+}
+
+// triggerFires returns true if the given trigger fires at the given time, at
+// the given level, for the given event.
+func triggerFires(
+	trig cat.Trigger, actionTime tree.TriggerActionTime, forEachRow bool, event tree.TriggerEventType,
+) bool {
+	return trig.ActionTime() == actionTime && trig.ForEachRow() == forEachRow && trig.HasEvent(event)
+}
+
+// checkNoOnConflictRowTriggers raises an error if the target table of an
+// UPSERT or INSERT .. ON CONFLICT statement has row-level triggers that are not
+// supported for the statement. See the comment at the top of the file for
+// details.
+func (mb *mutationBuilder) checkNoOnConflictRowTriggers(doNothing bool) {
+	if !mb.triggersEnabled() {
+		return
+	}
+	stmt := "INSERT .. ON CONFLICT DO UPDATE"
+	switch {
+	case doNothing:
+		stmt = "INSERT .. ON CONFLICT DO NOTHING"
+	case mb.opName == "upsert":
+		stmt = "UPSERT"
+	}
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trig := mb.tab.Trigger(i)
+		if !trig.ForEachRow() {
+			continue
+		}
+		if doNothing {
+			if trig.ActionTime() == tree.TriggerActionTimeBefore && trig.HasEvent(tree.TriggerEventInsert) {
+				panic(unimplemented.Newf("on conflict row triggers",
+					"%s is not supported on table %s with BEFORE row-level INSERT triggers",
+					stmt, mb.tab.Name()))
+			}
+			continue
+		}
+		if trig.HasEvent(tree.TriggerEventInsert) || trig.HasEvent(tree.TriggerEventUpdate) {
+			panic(unimplemented.Newf("on conflict row triggers",
+				"%s is not supported on table %s with row-level INSERT or UPDATE triggers",
+				stmt, mb.tab.Name()))
+		}
+	}
+}
+
+// buildBeforeRowTriggers builds the BEFORE row-level triggers of the target
+// table that fire for the given event. See the comment at the top of the file
+// for details.
+func (mb *mutationBuilder) buildBeforeRowTriggers(event tree.TriggerEventType) {
+	if !mb.triggersEnabled() {
+		return
+	}
+
+	// newColIDs are the columns that hold the new values of each row. They are
+	// replaced with the values returned by the trigger functions.
+	var newColIDs opt.OptionalColList
+	switch event {
+	case tree.TriggerEventInsert:
+		newColIDs = mb.insertColIDs
+	case tree.TriggerEventUpdate:
+		newColIDs = mb.updateColIDs
+	}
+
+	fired := false
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trig := mb.tab.Trigger(i)
+		if !triggerFires(trig, tree.TriggerActionTimeBefore, true /* forEachRow */, event) {
+			continue
+		}
+		fired = true
+
+		var newRow, oldRow tree.Expr
+		switch event {
+		case tree.TriggerEventInsert:
+			newRow = mb.b.makeTriggerRow(mb.tab, mb.triggerRowCols(mb.insertColIDs))
+			oldRow = mb.b.makeTriggerRow(mb.tab, nil /* exprs */)
+		case tree.TriggerEventUpdate:
+			newRow = mb.b.makeTriggerRow(mb.tab, mb.triggerRowCols(mb.updateOrFetchColIDs()))
+			oldRow = mb.b.makeTriggerRow(mb.tab, mb.triggerRowCols(mb.fetchColIDs))
+		case tree.TriggerEventDelete:
+			newRow = mb.b.makeTriggerRow(mb.tab, nil /* exprs */)
+			oldRow = mb.b.makeTriggerRow(mb.tab, mb.triggerRowCols(mb.fetchColIDs))
+		}
+		trigCol := mb.b.buildTriggerFuncCall(mb.tab, trig, mb.outScope, newRow, oldRow)
+		mb.outScope = trigCol.scope
+
+		// Skip the rows for which the trigger function returns NULL.
+		trigVar := mb.b.factory.ConstructVariable(trigCol.col.id)
+		mb.outScope.expr = mb.b.factory.ConstructSelect(
+			mb.outScope.expr,
+			memo.FiltersExpr{mb.b.factory.ConstructFiltersItem(
+				mb.b.factory.ConstructIsNot(trigVar, mb.b.factory.ConstructNull(trigCol.col.typ)),
+			)},
+		)
+
+		if newColIDs == nil {
+			continue
+		}
+
+		// Replace the new values of each row with the fields of the row
+		// returned by the trigger function. The values of computed columns are
+		// ignored; they are recomputed below.
+		projectionsScope := mb.outScope.replace()
+		projectionsScope.appendColumnsFromScope(mb.outScope)
+		field := 0
+		for ord, n := 0, mb.tab.ColumnCount(); ord < n; ord++ {
+			tabCol := mb.tab.Column(ord)
+			if !isTriggerRowColumn(tabCol) {
+				continue
+			}
+			fieldTyp := trigCol.col.typ.TupleContents()[field]
+			access := mb.b.factory.ConstructColumnAccess(trigVar, memo.TupleOrdinal(field))
+			field++
+			if tabCol.IsComputed() {
+				continue
+			}
+			colName := scopeColName(tabCol.ColName()).WithMetadataName(
+				string(tabCol.ColName()) + "_" + string(trig.Name()),
+			)
+			col := mb.b.synthesizeColumn(projectionsScope, colName, fieldTyp, nil /* expr */, access)
+			newColIDs[ord] = col.id
+		}
+		mb.b.constructProjectForScope(mb.outScope, projectionsScope)
+		mb.outScope = projectionsScope
+
+		// Clear the names of the replaced columns so that references in any
+		// expressions refer to the new columns.
+		mb.disambiguateColumns()
+		mb.addAssignmentCasts(newColIDs)
+	}
+
+	if !fired || newColIDs == nil {
+		return
+	}
+
+	// Recompute the computed columns, since the trigger functions may have
+	// changed the columns they depend on.
+	for ord, n := 0, mb.tab.ColumnCount(); ord < n; ord++ {
+		if mb.tab.Column(ord).IsComputed() {
+			newColIDs[ord] = 0
+		}
+	}
+	mb.disambiguateColumns()
+	mb.addSynthesizedComputedCols(newColIDs, false /* restrict */)
+	mb.addAssignmentCasts(newColIDs)
+}
+
+// buildBeforeStatementTriggers builds the BEFORE statement-level triggers of
+// the target table that fire for the given event. It must be called after the
+// mutation expression, including the RETURNING clause, is built. See the
+// comment at the top of the file for details.
+func (mb *mutationBuilder) buildBeforeStatementTriggers(event tree.TriggerEventType) {
+	if !mb.triggersEnabled() {
+		return
+	}
+
+	var bindingScope *scope
+	for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+		trig := mb.tab.Trigger(i)
+		if !triggerFires(trig, tree.TriggerActionTimeBefore, false /* forEachRow */, event) {
+			continue
+		}
+		if mb.b.insideUDF {
+			// The With expression would be planned as a subquery, which is not
+			// supported within a routine.
+			panic(unimplemented.Newf("statement triggers in functions",
+				"%s with BEFORE statement-level trigger %s inside a function definition",
+				strings.ToUpper(mb.opName), trig.Name()))
+		}
+		if bindingScope == nil {
+			bindingScope = mb.b.allocScope()
+			bindingScope.expr = mb.b.factory.ConstructValues(
+				memo.ScalarListWithEmptyTuple, &memo.ValuesPrivate{
+					Cols: opt.ColList{},
+					ID:   mb.md.NextUniqueID(),
+				},
+			)
+		}
+		bindingScope = mb.b.buildTriggerFuncCall(
+			mb.tab, trig, bindingScope,
+			mb.b.makeTriggerRow(mb.tab, nil /* exprs */), mb.b.makeTriggerRow(mb.tab, nil /* exprs */),
+		).scope
+	}
+	if bindingScope == nil {
+		return
+	}
+
+	id := mb.b.factory.Memo().NextWithID()
+	mb.md.AddWithBinding(id, bindingScope.expr)
+	mb.outScope.expr = mb.b.factory.ConstructWith(
+		bindingScope.expr,
+		mb.outScope.expr,
+		&memo.WithPrivate{
+			ID:   id,
+			Name: "before-statement-triggers",
+			Mtr:  tree.CTEMaterializeAlways,
+		},
+	)
+}
+
+// buildAfterTriggers builds the AFTER triggers of the target table that fire
+// for the given event. They are added to mb.cascades, so they must be built
+// before the mutation private is made. See the comment at the top of the file
+// for details.
+func (mb *mutationBuilder) buildAfterTriggers(event tree.TriggerEventType) {
+	if !mb.triggersEnabled() {
+		return
+	}
+
+	// Row-level triggers fire before statement-level triggers.
+	for _, forEachRow := range []bool{true, false} {
+		for i, n := 0, mb.tab.TriggerCount(); i < n; i++ {
+			trig := mb.tab.Trigger(i)
+			if !triggerFires(trig, tree.TriggerActionTimeAfter, forEachRow, event) {
+				continue
+			}
+			cascade := memo.FKCascade{
+				FKName:  string(trig.Name()),
+				Builder: newAfterTriggerBuilder(mb.tab, i),
+			}
+			if forEachRow {
+				mb.ensureWithID()
+				cascade.WithID = mb.withID
+				switch event {
+				case tree.TriggerEventInsert:
+					cascade.NewValues = triggerRowColList(mb.tab, mb.insertColIDs)
+				case tree.TriggerEventUpdate:
+					cascade.OldValues = triggerRowColList(mb.tab, mb.fetchColIDs)
+					cascade.NewValues = triggerRowColList(mb.tab, mb.updateOrFetchColIDs())
+				case tree.TriggerEventDelete:
+					cascade.OldValues = triggerRowColList(mb.tab, mb.fetchColIDs)
+				}
+			}
+			mb.cascades = append(mb.cascades, cascade)
+		}
+	}
+}
+
+// updateOrFetchColIDs returns the columns that hold the new values of each row
+// of an update: the update column if the table column is updated, and the
+// fetch column otherwise.
+func (mb *mutationBuilder) updateOrFetchColIDs() opt.OptionalColList {
+	colIDs := make(opt.OptionalColList, len(mb.fetchColIDs))
+	for i := range colIDs {
+		colIDs[i] = mb.updateColIDs[i]
+		if colIDs[i] == 0 {
+			colIDs[i] = mb.fetchColIDs[i]
+		}
+	}
+	return colIDs
+}
+
+// triggerRowCols returns the scope columns of mb.outScope with the given IDs,
+// one for each field of the table's record type.
+func (mb *mutationBuilder) triggerRowCols(colIDs opt.OptionalColList) tree.Exprs {
+	var exprs tree.Exprs
+	for _, col := range triggerRowColList(mb.tab, colIDs) {
+		exprs = append(exprs, mb.outScope.getColumn(col))
+	}
+	return exprs
+}
+
+// triggerRowColList returns the columns in the given list, one for each field
+// of the table's record type.
+func triggerRowColList(tab cat.Table, colIDs opt.OptionalColList) opt.ColList {
+	var cols opt.ColList
+	for ord, n := 0, tab.ColumnCount(); ord < n; ord++ {
+		if isTriggerRowColumn(tab.Column(ord)) {
+			cols = append(cols, colIDs[ord])
+		}
+	}
+	return cols
+}
+
+// isTriggerRowColumn returns true if the column is a field of the table's
+// record type, which contains the visible columns of the table.
+func isTriggerRowColumn(col *cat.Column) bool {
+	return col.Kind() == cat.Ordinary && col.Visibility() == cat.Visible
+}
+
+// makeTriggerRow returns an expression that constructs a value of the table's
+// record type from the given expressions, one for each field. If exprs is nil,
+// the value is NULL.
+func (b *Builder) makeTriggerRow(tab cat.Table, exprs tree.Exprs) tree.Expr {
+	var row tree.Expr = tree.DNull
+	if exprs != nil {
+		row = &tree.Tuple{Exprs: exprs}
+	}
+	return &tree.CastExpr{
+		Expr:       row,
+		Type:       &tree.OIDTypeReference{OID: typedesc.TableIDToImplicitTypeOID(descpb.ID(tab.ID()))},
+		SyntaxMode: tree.CastShort,
+	}
+}
+
+// triggerCallResult is the result of buildTriggerFuncCall.
+type triggerCallResult struct {
+	// scope is the new scope, which contains the columns of the input scope
+	// and the result of the call.
+	scope *scope
+	// col is the column holding the result of the call.
+	col *scopeColumn
+}
+
+// buildTriggerFuncCall projects the result of calling the function of the given
+// trigger on top of inScope. The new and old rows are bound to the implicit NEW
+// and OLD parameters of the function.
+func (b *Builder) buildTriggerFuncCall(
+	tab cat.Table, trig cat.Trigger, inScope *scope, newRow, oldRow tree.Expr,
+) triggerCallResult {
+	// A BEFORE trigger function is built inline. Guard against a function that
+	// fires its own trigger, directly or through other triggers, which would
+	// otherwise be built infinitely.
+	for _, o := range b.triggerFuncStack {
+		if o == trig.FuncOID() {
+			panic(pgerror.Newf(pgcode.FeatureNotSupported,
+				"trigger %s on table %s fires itself recursively", trig.Name(), tab.Name()))
+		}
+	}
+	b.triggerFuncStack = append(b.triggerFuncStack, trig.FuncOID())
+	defer func() { b.triggerFuncStack = b.triggerFuncStack[:len(b.triggerFuncStack)-1] }()
+
+	name, o, err := b.catalog.ResolveFunctionByOID(b.ctx, trig.FuncOID())
+	if err != nil {
+		panic(err)
+	}
+	if o.FixedReturnType().Family() != types.TriggerFamily {
+		panic(errors.AssertionFailedf("function %s of trigger %s does not return trigger",
+			name.Object(), trig.Name()))
+	}
+	// The function is referenced by OID rather than by name, so there is no
+	// name to track for resolution changes.
+	b.factory.Metadata().AddUserDefinedFunction(o, nil /* name */)
+
+	typedNew := inScope.resolveType(newRow, types.Any)
+	typedOld := inScope.resolveType(oldRow, types.Any)
+	rowTyp := typedNew.ResolvedType()
+	args := memo.ScalarListExpr{
+		b.buildScalar(typedNew, inScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */),
+		b.buildScalar(typedOld, inScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */),
+	}
+
+	// Build the body of the function with the implicit parameters, and with the
+	// table's record type as the return type.
+	fn := *o
+	fn.Types = tree.ParamTypes{{Name: "new", Typ: rowTyp}, {Name: "old", Typ: rowTyp}}
+	defer func(prev bool) { b.insideDataSource = prev }(b.insideDataSource)
+	b.insideDataSource = false
+	udfDef, _, _, _ := b.buildUDFDefinition(&fn, rowTyp)
+	call := b.factory.ConstructUDF(args, &memo.UDFPrivate{
+		Name:       name.Object(),
+		Typ:        rowTyp,
+		Volatility: o.Volatility,
+		// As in Postgres, a trigger function is called even if it is declared
+		// STRICT and NEW or OLD is NULL.
+		CalledOnNullInput: true,
+		Def:               udfDef,
+	})
+
+	projectionsScope := inScope.replace()
+	projectionsScope.appendColumnsFromScope(inScope)
+	colName := scopeColName("").WithMetadataName(string(trig.Name()))
+	col := b.synthesizeColumn(projectionsScope, colName, rowTyp, nil /* expr */, call)
+	b.constructProjectForScope(inScope, projectionsScope)
+	return triggerCallResult{scope: projectionsScope, col: col}
+}
+
+// afterTriggerBuilder is a memo.CascadeBuilder implementation for AFTER
+// triggers.
+//
+// For a row-level trigger, it builds a query that calls the trigger function
+// for each row of the mutation input, binding NEW and OLD to the new and old
+// values of the row. For example:
+//
+//	project
+//	 ├── columns: trig:5
+//	 ├── with-scan &1
+//	 │    ├── columns: a:3 b:4
+//	 │    └── mapping:
+//	 │         ├──  column1:1 => a:3
+//	 │         └──  column2:2 => b:4
+//	 └── projections
+//	      └── trig_fn(CAST((a:3, b:4) AS t), CAST(NULL AS t))
+//
+// For a statement-level trigger, it builds a query that calls the trigger
+// function once, with NULL values for NEW and OLD.
+//
+// The results of the query are discarded.
+type afterTriggerBuilder struct {
+	mutatedTable cat.Table
+	// triggerOrdinal is the ordinal of the trigger on the mutated table (can be
+	// passed to mutatedTable.Trigger).
+	triggerOrdinal int
+}
+
+var _ memo.CascadeBuilder = &afterTriggerBuilder{}
+
+func newAfterTriggerBuilder(mutatedTable cat.Table, triggerOrdinal int) *afterTriggerBuilder {
+	return &afterTriggerBuilder{
+		mutatedTable:   mutatedTable,
+		triggerOrdinal: triggerOrdinal,
+	}
+}
+
+// Build is part of the memo.CascadeBuilder interface.
+func (tb *afterTriggerBuilder) Build(
+	ctx context.Context,
+	semaCtx *tree.SemaContext,
+	evalCtx *eval.Context,
+	catalog cat.Catalog,
+	factoryI interface{},
+	binding opt.WithID,
+	bindingProps *props.Relational,
+	oldValues, newValues opt.ColList,
+) (_ memo.RelExpr, err error) {
+	return buildCascadeHelper(ctx, semaCtx, evalCtx, catalog, factoryI, func(b *Builder) memo.RelExpr {
+		trig := tb.mutatedTable.Trigger(tb.triggerOrdinal)
+		md := b.factory.Metadata()
+
+		inScope := b.allocScope()
+		if binding == 0 {
+			// Statement-level trigger.
+			inScope.expr = b.factory.ConstructValues(
+				memo.ScalarListWithEmptyTuple, &memo.ValuesPrivate{
+					Cols: opt.ColList{},
+					ID:   md.NextUniqueID(),
+				},
+			)
+			return b.buildTriggerFuncCall(
+				tb.mutatedTable, trig, inScope,
+				b.makeTriggerRow(tb.mutatedTable, nil /* exprs */), b.makeTriggerRow(tb.mutatedTable, nil /* exprs */),
+			).scope.expr
+		}
+
+		// Construct a dummy operator as the binding.
+		md.AddWithBinding(binding, b.factory.ConstructFakeRel(&memo.FakeRelPrivate{
+			Props: bindingProps,
+		}))
+		inCols := make(opt.ColList, 0, len(oldValues)+len(newValues))
+		inCols = append(inCols, oldValues...)
+		inCols = append(inCols, newValues...)
+		outCols := make(opt.ColList, len(inCols))
+		for i := range inCols {
+			c := md.ColumnMeta(inCols[i])
+			col := b.synthesizeColumn(inScope, scopeColName(""), c.Type, nil /* expr */, nil /* scalar */)
+			outCols[i] = col.id
+		}
+		inScope.expr = b.factory.ConstructWithScan(&memo.WithScanPrivate{
+			With:    binding,
+			InCols:  inCols,
+			OutCols: outCols,
+			ID:      md.NextUniqueID(),
+		})
+
+		rowExprs := func(cols []scopeColumn) tree.Exprs {
+			if len(cols) == 0 {
+				return nil
+			}
+			exprs := make(tree.Exprs, len(cols))
+			for i := range cols {
+				exprs[i] = &cols[i]
+			}
+			return exprs
+		}
+		oldRow := b.makeTriggerRow(tb.mutatedTable, rowExprs(inScope.cols[:len(oldValues)]))
+		newRow := b.makeTriggerRow(tb.mutatedTable, rowExprs(inScope.cols[len(oldValues):]))
+		return b.buildTriggerFuncCall(tb.mutatedTable, trig, inScope, newRow, oldRow).scope.expr
+	})
+}
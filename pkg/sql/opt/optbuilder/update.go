@@ -90,6 +90,7 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 
 	var mb mutationBuilder
 	mb.init(b, "update", tab, alias)
+	mb.rowLevelSecurity = b.rowLevelSecurityApplies(tab)
 
 	// Build the input expression that selects the rows that will be updated:
 	//
@@ -327,10 +328,18 @@ func (mb *mutationBuilder) addSynthesizedColsForUpdate() {
 // buildUpdate constructs an Update operator, possibly wrapped by a Project
 // operator that corresponds to the given RETURNING clause.
 func (mb *mutationBuilder) buildUpdate(returning *tree.ReturningExprs) {
+	// Enforce the constraints of any domain-typed columns.
+	mb.addDomainChecks(mb.updateColIDs)
+
+	mb.buildBeforeRowTriggers(tree.TriggerEventUpdate)
+
 	// Disambiguate names so that references in any expressions, such as a
 	// check constraint, refer to the correct columns.
 	mb.disambiguateColumns()
 
+	// Enforce the row-level security policies of the table.
+	mb.addRowLevelSecurityChecksForUpdate()
+
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols(true /* isUpdate */)
 
@@ -343,9 +352,12 @@ func (mb *mutationBuilder) buildUpdate(returning *tree.ReturningExprs) {
 	mb.projectPartialIndexPutAndDelCols()
 
 	mb.buildUniqueChecksForUpdate()
+	mb.buildExclusionChecksForUpdate()
 
 	mb.buildFKChecksForUpdate()
 
+	mb.buildAfterTriggers(tree.TriggerEventUpdate)
+
 	private := mb.makeMutationPrivate(returning != nil)
 	for _, col := range mb.extraAccessibleCols {
 		if col.id != 0 {
@@ -356,4 +368,5 @@ func (mb *mutationBuilder) buildUpdate(returning *tree.ReturningExprs) {
 		mb.outScope.expr, mb.uniqueChecks, mb.fkChecks, private,
 	)
 	mb.buildReturning(returning)
+	mb.buildBeforeStatementTriggers(tree.TriggerEventUpdate)
 }
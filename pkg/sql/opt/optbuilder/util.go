@@ -586,11 +586,17 @@ func (b *Builder) resolveTableForMutation(
 		alias = *outerAlias
 	}
 
-	// We can't mutate materialized views.
-	if tab.IsMaterializedView() {
+	// We can't mutate materialized views, except when their rows are updated
+	// by an incremental refresh.
+	if tab.IsMaterializedView() && !b.evalCtx.SessionData().AllowMaterializedViewMutations {
 		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate materialized view %q", tab.Name()))
 	}
 
+	// We can't mutate foreign tables, whose rows are read from external storage.
+	if tab.IsForeignTable() {
+		panic(pgerror.Newf(pgcode.WrongObjectType, "cannot mutate foreign table %q", tab.Name()))
+	}
+
 	return tab, depName, alias, columns
 }
 
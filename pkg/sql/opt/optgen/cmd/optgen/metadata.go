@@ -198,6 +198,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"Ordering":             {fullName: "opt.Ordering", passByVal: true},
 		"OrderingChoice":       {fullName: "props.OrderingChoice", passByVal: true},
 		"GroupingOrder":        {fullName: "memo.GroupingOrder", passByVal: true},
+		"GroupingSetList":      {fullName: "memo.GroupingSetList", passByVal: true},
 		"TupleOrdinal":         {fullName: "memo.TupleOrdinal", passByVal: true},
 		"ScanLimit":            {fullName: "memo.ScanLimit", passByVal: true},
 		"ScanFlags":            {fullName: "memo.ScanFlags", passByVal: true},
@@ -249,6 +250,8 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"PreFiltererState":     {fullName: "invertedexpr.PreFiltererStateForInvertedFilterer", isPointer: true, usePointerIntern: true},
 		"Volatility":           {fullName: "volatility.V", passByVal: true},
 		"LiteralRows":          {fullName: "opt.LiteralRows", isExpr: true, isPointer: true},
+		"UDFDefinition":        {fullName: "memo.UDFDefinition", isPointer: true, usePointerIntern: true},
+		"UDAggDefinition":      {fullName: "memo.UserDefinedAggDefinition", isPointer: true, usePointerIntern: true},
 		"Distribution":         {fullName: "physical.Distribution", passByVal: true},
 	}
 
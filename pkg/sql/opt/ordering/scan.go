@@ -79,6 +79,11 @@ func ScanPrivateCanProvide(
 			direction = rev
 		}
 	}
+	if direction == either && md.Table(s.Table).IsForeignTable() {
+		// Foreign tables are read from a file front to back, so they cannot be
+		// scanned in reverse.
+		direction = fwd
+	}
 	index := md.Table(s.Table).Index(s.Index)
 	for left, right := 0, 0; right < len(required.Columns); {
 		if left >= index.KeyColumnCount() {
@@ -370,6 +370,16 @@ func (tc *Catalog) CreateTable(stmt *tree.CreateTable) *Table {
 		case *tree.IndexTableDef:
 			tab.addIndex(def, nonUniqueIndex)
 
+		case *tree.ExclusionConstraintTableDef:
+			idx := tab.addIndex(&tree.IndexTableDef{
+				Name:     def.Name,
+				Columns:  def.Elems.IndexElems(),
+				Inverted: def.Inverted,
+			}, nonUniqueIndex)
+			for i := range def.Elems {
+				idx.exclusionOps = append(idx.exclusionOps, def.Elems[i].Operator)
+			}
+
 		case *tree.FamilyTableDef:
 			tab.addFamily(def)
 
@@ -682,6 +692,7 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		matchMethod:              d.Match,
 		deleteAction:             d.Actions.Delete,
 		updateAction:             d.Actions.Update,
+		deferrability:            d.Deferrability,
 	}
 	tab.outboundFKs = append(tab.outboundFKs, fk)
 	targetTable.inboundFKs = append(targetTable.inboundFKs, fk)
@@ -17,6 +17,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/treeprinter"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
@@ -71,20 +72,39 @@ func (tc *Catalog) CreateFunction(c *tree.CreateFunction) {
 	}
 
 	// Resolve the parameter names and types.
-	paramTypes := make(tree.ParamTypes, len(c.Params))
+	paramTypes := make(tree.ParamTypes, 0, len(c.Params))
+	var outParams []tree.OutParam
 	for i := range c.Params {
 		param := &c.Params[i]
 		typ, err := tree.ResolveType(context.Background(), param.Type, tc)
 		if err != nil {
 			panic(err)
 		}
-		paramTypes.SetAt(i, string(param.Name), typ)
+		if param.IsOutParam() {
+			inputOrdinal := -1
+			if param.IsInParam() {
+				inputOrdinal = len(paramTypes)
+			}
+			outParams = append(outParams, tree.OutParam{
+				Name: string(param.Name), Typ: typ, InputOrdinal: inputOrdinal,
+			})
+		}
+		if param.IsInParam() {
+			paramTypes = append(paramTypes, tree.ParamType{Name: string(param.Name), Typ: typ})
+		}
 	}
 
-	// Resolve the return type.
-	retType, err := tree.ResolveType(context.Background(), c.ReturnType.Type, tc)
-	if err != nil {
-		panic(err)
+	// Resolve the return type. The return type of a function with OUT
+	// parameters is determined by the parameters.
+	var retType *types.T
+	if len(outParams) > 0 {
+		retType = tree.OutParamsReturnType(outParams)
+	} else {
+		var err error
+		retType, err = tree.ResolveType(context.Background(), c.ReturnType.Type, tc)
+		if err != nil {
+			panic(err)
+		}
 	}
 
 	// Retrieve the function body, volatility, and calledOnNullInput.
@@ -101,6 +121,7 @@ func (tc *Catalog) CreateFunction(c *tree.CreateFunction) {
 		Body:              body,
 		Volatility:        v,
 		CalledOnNullInput: calledOnNullInput,
+		OutParams:         outParams,
 	}
 	if c.ReturnType.IsSet {
 		overload.Class = tree.GeneratorClass
@@ -580,6 +580,9 @@ func (c *coster) ComputeCost(candidate memo.RelExpr, required *physical.Required
 		opt.UpsertDistinctOnOp, opt.EnsureUpsertDistinctOnOp:
 		cost = c.computeGroupingCost(candidate, required)
 
+	case opt.GroupingSetsOp:
+		cost = c.computeGroupingSetsCost(candidate.(*memo.GroupingSetsExpr))
+
 	case opt.LimitOp:
 		cost = c.computeLimitCost(candidate.(*memo.LimitExpr))
 
@@ -1481,6 +1484,28 @@ func (c *coster) computeGroupingCost(grouping memo.RelExpr, required *physical.R
 	return cost
 }
 
+func (c *coster) computeGroupingSetsCost(groupingSets *memo.GroupingSetsExpr) memo.Cost {
+	// Start with the same fixed overhead as the other grouping operators.
+	cost := memo.Cost(cpuCostFactor)
+
+	// Add the CPU cost of emitting the rows.
+	outputRowCount := groupingSets.Relational().Statistics().RowCount
+	cost += memo.Cost(outputRowCount) * cpuCostFactor
+
+	// Each input row is processed once for every grouping set, and is added to
+	// a hash table each time.
+	inputRowCount := groupingSets.Input.Relational().Statistics().RowCount
+	processedRowCount := inputRowCount * float64(len(groupingSets.Sets))
+	perRowCount := len(groupingSets.Aggregations) + groupingSets.GroupingCols.Len() + 1
+	cost += memo.Cost(processedRowCount) * memo.Cost(perRowCount) * cpuCostFactor
+
+	// Add a cost for buffering rows that takes into account increased memory
+	// pressure and the possibility of spilling to disk.
+	cost += c.rowBufferCost(outputRowCount)
+
+	return cost
+}
+
 func (c *coster) computeLimitCost(limit *memo.LimitExpr) memo.Cost {
 	// Add the CPU cost of emitting the rows.
 	cost := memo.Cost(limit.Relational().Statistics().RowCount) * cpuCostFactor
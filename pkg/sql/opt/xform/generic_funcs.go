@@ -0,0 +1,103 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package xform
+
+import (
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/norm"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+// GenericRulesEnabled returns true if rules for optimizing generic query plans
+// are enabled, based on the plan_cache_mode session setting.
+func (c *CustomFuncs) GenericRulesEnabled() bool {
+	return c.e.evalCtx.SessionData().PlanCacheMode != sessiondatapb.PlanCacheModeForceCustom
+}
+
+// HasPlaceholders returns true if the given relational expression's subtree
+// has at least one placeholder.
+func (c *CustomFuncs) HasPlaceholders(e memo.RelExpr) bool {
+	return e.Relational().HasPlaceholder
+}
+
+// GeneratePlaceholderValuesAndJoinFilters returns a single-row Values
+// expression containing placeholders in the given filters. It also returns a
+// new set of filters where the placeholders have been replaced with references
+// to the Values expression's columns. If the filters have no placeholders or
+// cannot be rewritten, ok=false is returned.
+//
+// For example, the filters:
+//
+//	a = $1 AND b > $2
+//
+// Would produce the Values expression:
+//
+//	VALUES ($1, $2)
+//
+// And the new filters, where "$1" and "$2" are columns produced by the
+// Values expression:
+//
+//	a = "$1" AND b > "$2"
+func (c *CustomFuncs) GeneratePlaceholderValuesAndJoinFilters(
+	filters memo.FiltersExpr,
+) (values memo.RelExpr, newFilters memo.FiltersExpr, ok bool) {
+	// Subqueries in the filters could reference the placeholders from an outer
+	// scope that the new Values expression does not provide, so don't attempt
+	// to rewrite them.
+	for i := range filters {
+		if filters[i].ScalarProps().HasSubquery {
+			return nil, nil, false
+		}
+	}
+
+	md := c.e.f.Metadata()
+	var cols opt.ColList
+	var colTypes []*types.T
+	var placeholders memo.ScalarListExpr
+	placeholderCols := make(map[tree.PlaceholderIdx]opt.ColumnID)
+
+	var replace norm.ReplaceFunc
+	replace = func(e opt.Expr) opt.Expr {
+		if p, ok := e.(*memo.PlaceholderExpr); ok {
+			idx := p.Value.(*tree.Placeholder).Idx
+			col, ok := placeholderCols[idx]
+			if !ok {
+				typ := p.DataType()
+				col = md.AddColumn(fmt.Sprintf("$%d", idx+1), typ)
+				placeholderCols[idx] = col
+				cols = append(cols, col)
+				colTypes = append(colTypes, typ)
+				placeholders = append(placeholders, p)
+			}
+			return c.e.f.ConstructVariable(col)
+		}
+		return c.e.f.Replace(e, replace)
+	}
+	newFilters = *replace(&filters).(*memo.FiltersExpr)
+
+	if len(cols) == 0 {
+		return nil, nil, false
+	}
+
+	values = c.e.f.ConstructValues(
+		memo.ScalarListExpr{c.e.f.ConstructTuple(placeholders, types.MakeTuple(colTypes))},
+		&memo.ValuesPrivate{
+			Cols: cols,
+			ID:   md.NextUniqueID(),
+		},
+	)
+	return values, newFilters, true
+}
@@ -210,6 +210,11 @@ func (it *scanIndexIter) ForEach(f enumerateIndexFunc) {
 // ForEachStartingAfter calls the given callback function for every index of the
 // Scan operator's table with an ordinal greater than ord.
 func (it *scanIndexIter) ForEachStartingAfter(ord int, f enumerateIndexFunc) {
+	// Foreign tables can only be read in full by the canonical scan, so no
+	// constrained scans or lookup joins are generated for them.
+	if it.tabMeta.Table.IsForeignTable() {
+		return
+	}
 	ord++
 	for ; ord < it.tabMeta.Table.IndexCount(); ord++ {
 		// Skip over the primary index if rejectPrimaryIndex is set.
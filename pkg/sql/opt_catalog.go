@@ -13,6 +13,7 @@ package sql
 import (
 	"context"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/config"
@@ -418,6 +419,29 @@ func (oc *optCatalog) HasRoleOption(
 	return oc.planner.HasRoleOption(ctx, roleOption)
 }
 
+// HasOwnership is part of the cat.Catalog interface.
+func (oc *optCatalog) HasOwnership(ctx context.Context, o cat.Object) (bool, error) {
+	desc, err := getDescFromCatalogObjectForPermissions(o)
+	if err != nil {
+		return false, err
+	}
+	return oc.planner.HasOwnership(ctx, desc)
+}
+
+// IsMemberOfRole is part of the cat.Catalog interface.
+func (oc *optCatalog) IsMemberOfRole(ctx context.Context, role username.SQLUsername) (bool, error) {
+	user := oc.planner.User()
+	if user == role {
+		return true, nil
+	}
+	memberOf, err := oc.planner.MemberOfWithAdminOption(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	_, ok := memberOf[role]
+	return ok, nil
+}
+
 // FullyQualifiedName is part of the cat.Catalog interface.
 func (oc *optCatalog) FullyQualifiedName(
 	ctx context.Context, ds cat.DataSource,
@@ -733,6 +757,11 @@ type optTable struct {
 	// constraints for user defined types.
 	checkConstraints []cat.CheckConstraint
 
+	// triggers is the set of triggers defined on this table, ordered by name.
+	triggers []optTrigger
+
+	policies []optPolicy
+
 	// colMap is a mapping from unique ColumnID to column ordinal within the
 	// table. This is a common lookup that needs to be fast.
 	colMap catalog.TableColMap
@@ -866,12 +895,13 @@ func newOptTable(
 	ot.uniqueConstraints = make([]optUniqueConstraint, len(ot.desc.EnforcedUniqueConstraintsWithoutIndex()))
 	for i, u := range ot.desc.EnforcedUniqueConstraintsWithoutIndex() {
 		ot.uniqueConstraints[i] = optUniqueConstraint{
-			name:         u.GetName(),
-			table:        ot.ID(),
-			columns:      u.CollectKeyColumnIDs().Ordered(),
-			predicate:    u.GetPredicate(),
-			withoutIndex: true,
-			validity:     u.GetConstraintValidity(),
+			name:          u.GetName(),
+			table:         ot.ID(),
+			columns:       u.CollectKeyColumnIDs().Ordered(),
+			predicate:     u.GetPredicate(),
+			withoutIndex:  true,
+			validity:      u.GetConstraintValidity(),
+			deferrability: tree.ConstraintDeferrability(u.Deferrability()),
 		}
 	}
 
@@ -976,6 +1006,7 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrability:     tree.ConstraintDeferrability(fk.Deferrability()),
 		})
 	}
 	for _, fk := range ot.desc.InboundForeignKeys() {
@@ -989,9 +1020,25 @@ func newOptTable(
 			match:             tree.CompositeKeyMatchMethodType[fk.Match()],
 			deleteAction:      tree.ForeignKeyReferenceActionType[fk.OnDelete()],
 			updateAction:      tree.ForeignKeyReferenceActionType[fk.OnUpdate()],
+			deferrability:     tree.ConstraintDeferrability(fk.Deferrability()),
 		})
 	}
 
+	ot.policies = make([]optPolicy, len(desc.GetPolicies()))
+	for i := range ot.policies {
+		ot.policies[i] = optPolicy{desc: &desc.GetPolicies()[i]}
+	}
+
+	ot.triggers = make([]optTrigger, len(desc.GetTriggers()))
+	for i := range ot.triggers {
+		ot.triggers[i] = optTrigger{desc: &desc.GetTriggers()[i]}
+	}
+	// Like Postgres, fire triggers of the same kind in alphabetical order by
+	// name.
+	sort.Slice(ot.triggers, func(i, j int) bool {
+		return ot.triggers[i].desc.Name < ot.triggers[j].desc.Name
+	})
+
 	ot.primaryFamily.init(ot, &desc.GetFamilies()[0])
 	ot.families = make([]optFamily, len(desc.GetFamilies())-1)
 	for i := range ot.families {
@@ -1259,6 +1306,41 @@ func (ot *optTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	return &ot.uniqueConstraints[i]
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optTable) TriggerCount() int {
+	return len(ot.triggers)
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optTable) Trigger(i int) cat.Trigger {
+	return &ot.triggers[i]
+}
+
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityEnabled() bool {
+	return ot.desc.IsRowLevelSecurityEnabled()
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optTable) IsRowLevelSecurityForced() bool {
+	return ot.desc.IsRowLevelSecurityForced()
+}
+
+// IsForeignTable is part of the cat.Table interface.
+func (ot *optTable) IsForeignTable() bool {
+	return ot.desc.IsForeignTable()
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optTable) PolicyCount() int {
+	return len(ot.policies)
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optTable) Policy(i int) cat.Policy {
+	return &ot.policies[i]
+}
+
 // Zone is part of the cat.Table interface.
 func (ot *optTable) Zone() cat.Zone {
 	return ot.zone
@@ -1550,6 +1632,16 @@ func (oi *optIndex) IsInverted() bool {
 	return oi.idx.GetType() == descpb.IndexDescriptor_INVERTED
 }
 
+// IsExclusion is part of the cat.Index interface.
+func (oi *optIndex) IsExclusion() bool {
+	return oi.idx.IsExclusion()
+}
+
+// ExclusionOperator is part of the cat.Index interface.
+func (oi *optIndex) ExclusionOperator(i int) tree.ExclusionOperator {
+	return tree.ExclusionOperator(oi.idx.GetExclusionOperator(i))
+}
+
 // GetInvisibility is part of the cat.Index interface.
 func (oi *optIndex) GetInvisibility() float64 {
 	return oi.idx.GetInvisibility()
@@ -1843,8 +1935,9 @@ type optUniqueConstraint struct {
 	columns   []descpb.ColumnID
 	predicate string
 
-	withoutIndex bool
-	validity     descpb.ConstraintValidity
+	withoutIndex  bool
+	validity      descpb.ConstraintValidity
+	deferrability tree.ConstraintDeferrability
 
 	uniquenessGuaranteedByAnotherIndex bool
 }
@@ -1902,6 +1995,118 @@ func (u *optUniqueConstraint) UniquenessGuaranteedByAnotherIndex() bool {
 	return u.uniquenessGuaranteedByAnotherIndex
 }
 
+// Deferrability is part of the cat.UniqueConstraint interface.
+func (u *optUniqueConstraint) Deferrability() tree.ConstraintDeferrability {
+	return u.deferrability
+}
+
+// optTrigger implements cat.Trigger and represents a trigger defined on a
+// table.
+type optTrigger struct {
+	desc *descpb.TriggerDescriptor
+}
+
+var _ cat.Trigger = &optTrigger{}
+
+// Name is part of the cat.Trigger interface.
+func (t *optTrigger) Name() tree.Name {
+	return tree.Name(t.desc.Name)
+}
+
+// ActionTime is part of the cat.Trigger interface.
+func (t *optTrigger) ActionTime() tree.TriggerActionTime {
+	if t.desc.ActionTime == descpb.TriggerDescriptor_AFTER {
+		return tree.TriggerActionTimeAfter
+	}
+	return tree.TriggerActionTimeBefore
+}
+
+// HasEvent is part of the cat.Trigger interface.
+func (t *optTrigger) HasEvent(event tree.TriggerEventType) bool {
+	var e descpb.TriggerDescriptor_Event
+	switch event {
+	case tree.TriggerEventInsert:
+		e = descpb.TriggerDescriptor_INSERT
+	case tree.TriggerEventUpdate:
+		e = descpb.TriggerDescriptor_UPDATE
+	case tree.TriggerEventDelete:
+		e = descpb.TriggerDescriptor_DELETE
+	}
+	for _, ev := range t.desc.Events {
+		if ev == e {
+			return true
+		}
+	}
+	return false
+}
+
+// ForEachRow is part of the cat.Trigger interface.
+func (t *optTrigger) ForEachRow() bool {
+	return t.desc.ForEachRow
+}
+
+// FuncOID is part of the cat.Trigger interface.
+func (t *optTrigger) FuncOID() oid.Oid {
+	return catid.FuncIDToOID(t.desc.FuncID)
+}
+
+// optPolicy implements cat.Policy and represents a row-level security policy
+// defined on a table.
+type optPolicy struct {
+	desc *descpb.PolicyDescriptor
+}
+
+var _ cat.Policy = &optPolicy{}
+
+// Name is part of the cat.Policy interface.
+func (p *optPolicy) Name() tree.Name {
+	return tree.Name(p.desc.Name)
+}
+
+// Type is part of the cat.Policy interface.
+func (p *optPolicy) Type() tree.PolicyType {
+	if p.desc.Type == descpb.PolicyDescriptor_RESTRICTIVE {
+		return tree.PolicyTypeRestrictive
+	}
+	return tree.PolicyTypePermissive
+}
+
+// Command is part of the cat.Policy interface.
+func (p *optPolicy) Command() tree.PolicyCommand {
+	switch p.desc.Command {
+	case descpb.PolicyDescriptor_SELECT:
+		return tree.PolicyCommandSelect
+	case descpb.PolicyDescriptor_INSERT:
+		return tree.PolicyCommandInsert
+	case descpb.PolicyDescriptor_UPDATE:
+		return tree.PolicyCommandUpdate
+	case descpb.PolicyDescriptor_DELETE:
+		return tree.PolicyCommandDelete
+	default:
+		return tree.PolicyCommandAll
+	}
+}
+
+// RoleCount is part of the cat.Policy interface.
+func (p *optPolicy) RoleCount() int {
+	return len(p.desc.RoleNames)
+}
+
+// Role is part of the cat.Policy interface.
+func (p *optPolicy) Role(i int) username.SQLUsername {
+	return username.MakeSQLUsernameFromPreNormalizedString(p.desc.RoleNames[i])
+}
+
+// UsingExpr is part of the cat.Policy interface.
+func (p *optPolicy) UsingExpr() string {
+	return p.desc.UsingExpr
+}
+
+// WithCheckExpr is part of the cat.Policy interface.
+func (p *optPolicy) WithCheckExpr() string {
+	return p.desc.WithCheckExpr
+}
+
 // optForeignKeyConstraint implements cat.ForeignKeyConstraint and represents a
 // foreign key relationship. Both the origin and the referenced table store the
 // same optForeignKeyConstraint (as an outbound and inbound reference,
@@ -1919,6 +2124,8 @@ type optForeignKeyConstraint struct {
 	match        tree.CompositeKeyMatchMethod
 	deleteAction tree.ReferenceAction
 	updateAction tree.ReferenceAction
+
+	deferrability tree.ConstraintDeferrability
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -1990,6 +2197,11 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return fk.updateAction
 }
 
+// Deferrability is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) Deferrability() tree.ConstraintDeferrability {
+	return fk.deferrability
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -2288,6 +2500,41 @@ func (ot *optVirtualTable) Unique(i cat.UniqueOrdinal) cat.UniqueConstraint {
 	panic(errors.AssertionFailedf("no unique constraints"))
 }
 
+// TriggerCount is part of the cat.Table interface.
+func (ot *optVirtualTable) TriggerCount() int {
+	return 0
+}
+
+// Trigger is part of the cat.Table interface.
+func (ot *optVirtualTable) Trigger(i int) cat.Trigger {
+	panic(errors.AssertionFailedf("no triggers"))
+}
+
+// IsRowLevelSecurityEnabled is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityEnabled() bool {
+	return false
+}
+
+// IsRowLevelSecurityForced is part of the cat.Table interface.
+func (ot *optVirtualTable) IsRowLevelSecurityForced() bool {
+	return false
+}
+
+// IsForeignTable is part of the cat.Table interface.
+func (ot *optVirtualTable) IsForeignTable() bool {
+	return false
+}
+
+// PolicyCount is part of the cat.Table interface.
+func (ot *optVirtualTable) PolicyCount() int {
+	return 0
+}
+
+// Policy is part of the cat.Table interface.
+func (ot *optVirtualTable) Policy(i int) cat.Policy {
+	panic(errors.AssertionFailedf("no policies"))
+}
+
 // Zone is part of the cat.Table interface.
 func (ot *optVirtualTable) Zone() cat.Zone {
 	panic(errors.AssertionFailedf("no zone"))
@@ -2385,6 +2632,16 @@ func (oi *optVirtualIndex) IsInverted() bool {
 	return false
 }
 
+// IsExclusion is part of the cat.Index interface.
+func (oi *optVirtualIndex) IsExclusion() bool {
+	return false
+}
+
+// ExclusionOperator is part of the cat.Index interface.
+func (oi *optVirtualIndex) ExclusionOperator(i int) tree.ExclusionOperator {
+	panic(errors.AssertionFailedf("virtual indexes do not back exclusion constraints"))
+}
+
 // GetInvisibility is part of the cat.Index interface.
 func (oi *optVirtualIndex) GetInvisibility() float64 {
 	return 0.0
@@ -2597,6 +2854,8 @@ func collectTypes(col catalog.Column) (descpb.IDs, error) {
 	}
 	if typ := col.GetType(); typ != nil && typ.UserDefined() {
 		visitor.OIDs[typ.Oid()] = struct{}{}
+	} else if typ != nil && typ.DomainOID() != 0 {
+		visitor.OIDs[typ.DomainOID()] = struct{}{}
 	}
 
 	ids := make(descpb.IDs, 0, len(visitor.OIDs))
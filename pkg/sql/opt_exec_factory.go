@@ -19,9 +19,11 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/featureflag"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -32,12 +34,15 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/constraint"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/exec/explain"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/row"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowcontainer"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treewindow"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/span"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
@@ -59,6 +64,40 @@ type execFactory struct {
 
 var _ exec.Factory = &execFactory{}
 
+// toScanLockingStrength converts a tree.LockingStrength to its corresponding
+// descpb.ScanLockingStrength. Until the cluster version supports Shared locks,
+// FOR SHARE and FOR KEY SHARE are performed as non-locking reads, as in
+// previous releases.
+func toScanLockingStrength(
+	ctx context.Context, st *cluster.Settings, s tree.LockingStrength,
+) descpb.ScanLockingStrength {
+	str := descpb.ToScanLockingStrength(s)
+	switch str {
+	case descpb.ScanLockingStrength_FOR_KEY_SHARE, descpb.ScanLockingStrength_FOR_SHARE:
+		if !st.Version.IsActive(ctx, clusterversion.V23_2_SharedLocks) {
+			return descpb.ScanLockingStrength_FOR_NONE
+		}
+	}
+	return str
+}
+
+// toScanLockingDurability returns the durability of the locks acquired by a
+// scan with the given locking strength. The locks are replicated if the
+// durable_locking session variable is set, once the cluster version supports
+// replicated locks.
+func toScanLockingDurability(
+	ctx context.Context,
+	st *cluster.Settings,
+	sd *sessiondata.SessionData,
+	str descpb.ScanLockingStrength,
+) descpb.ScanLockingDurability {
+	if str != descpb.ScanLockingStrength_FOR_NONE && sd.DurableLocking &&
+		st.Version.IsActive(ctx, clusterversion.V23_2_ReplicatedLocks) {
+		return descpb.ScanLockingDurability_GUARANTEED
+	}
+	return descpb.ScanLockingDurability_BEST_EFFORT
+}
+
 func newExecFactory(ctx context.Context, p *planner) *execFactory {
 	return &execFactory{
 		ctx:     ctx,
@@ -132,6 +171,9 @@ func (ef *execFactory) ConstructScan(
 	if table.IsVirtualTable() {
 		return ef.constructVirtualScan(table, index, params, reqOrdering)
 	}
+	if table.IsForeignTable() {
+		return ef.constructForeignScan(table, params, reqOrdering)
+	}
 
 	tabDesc := table.(*optTable).desc
 	idx := index.(*optIndex).idx
@@ -167,8 +209,11 @@ func (ef *execFactory) ConstructScan(
 	}
 	scan.reqOrdering = ReqOrdering(reqOrdering)
 	scan.estimatedRowCount = uint64(params.EstimatedRowCount)
-	scan.lockingStrength = descpb.ToScanLockingStrength(params.Locking.Strength)
+	scan.lockingStrength = toScanLockingStrength(ef.ctx, ef.planner.ExecCfg().Settings, params.Locking.Strength)
 	scan.lockingWaitPolicy = descpb.ToScanLockingWaitPolicy(params.Locking.WaitPolicy)
+	scan.lockingDurability = toScanLockingDurability(
+		ef.ctx, ef.planner.ExecCfg().Settings, ef.planner.SessionData(), scan.lockingStrength,
+	)
 	scan.localityOptimized = params.LocalityOptimized
 	if !ef.isExplain && !(ef.planner.isInternalPlanner || ef.planner.SessionData().Internal) {
 		idxUsageKey := roachpb.IndexUsageKey{
@@ -531,6 +576,46 @@ func (ef *execFactory) ConstructGroupBy(
 	return n, nil
 }
 
+// ConstructGroupingSets is part of the exec.Factory interface.
+func (ef *execFactory) ConstructGroupingSets(
+	input exec.Node,
+	groupCols []exec.NodeColumnOrdinal,
+	groupingSets []exec.NodeColumnOrdinalSet,
+	aggregations []exec.AggInfo,
+) (exec.Node, error) {
+	if !ef.planner.ExecCfg().Settings.Version.IsActive(ef.ctx, clusterversion.V23_2_GroupingSets) {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"GROUPING SETS, ROLLUP and CUBE are not supported until the cluster version is finalized")
+	}
+	inputPlan := input.(planNode)
+	inputCols := planColumns(inputPlan)
+	columns := getResultColumnsForGroupBy(inputCols, groupCols, aggregations)
+	columns = append(columns, colinfo.ResultColumn{Name: "grouping_set", Typ: types.Int})
+	n := &groupNode{
+		plan:         inputPlan,
+		funcs:        make([]*aggregateFuncHolder, 0, len(groupCols)+len(aggregations)),
+		columns:      columns,
+		groupCols:    convertNodeOrdinalsToInts(groupCols),
+		groupingSets: make([][]int, len(groupingSets)),
+	}
+	for i := range groupingSets {
+		n.groupingSets[i] = groupingSets[i].Ordered()
+	}
+	for _, col := range n.groupCols {
+		f := newAggregateFuncHolder(
+			builtins.AnyNotNull,
+			[]int{col},
+			nil,   /* arguments */
+			false, /* isDistinct */
+		)
+		n.funcs = append(n.funcs, f)
+	}
+	if err := ef.addAggregations(n, aggregations); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
 func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo) error {
 	for i := range aggregations {
 		agg := &aggregations[i]
@@ -543,6 +628,7 @@ func (ef *execFactory) addAggregations(n *groupNode, aggregations []exec.AggInfo
 			agg.Distinct,
 		)
 		f.filterRenderIdx = int(agg.Filter)
+		f.userDefined = agg.UserDefined
 
 		n.funcs = append(n.funcs, f)
 	}
@@ -663,8 +749,11 @@ func (ef *execFactory) ConstructIndexJoin(
 	idx := tabDesc.GetPrimaryIndex()
 	tableScan.index = idx
 	tableScan.disableBatchLimit()
-	tableScan.lockingStrength = descpb.ToScanLockingStrength(locking.Strength)
+	tableScan.lockingStrength = toScanLockingStrength(ef.ctx, ef.planner.ExecCfg().Settings, locking.Strength)
 	tableScan.lockingWaitPolicy = descpb.ToScanLockingWaitPolicy(locking.WaitPolicy)
+	tableScan.lockingDurability = toScanLockingDurability(
+		ef.ctx, ef.planner.ExecCfg().Settings, ef.planner.SessionData(), tableScan.lockingStrength,
+	)
 
 	if !ef.isExplain && !(ef.planner.isInternalPlanner || ef.planner.SessionData().Internal) {
 		idxUsageKey := roachpb.IndexUsageKey{
@@ -723,8 +812,11 @@ func (ef *execFactory) ConstructLookupJoin(
 	}
 
 	tableScan.index = idx
-	tableScan.lockingStrength = descpb.ToScanLockingStrength(locking.Strength)
+	tableScan.lockingStrength = toScanLockingStrength(ef.ctx, ef.planner.ExecCfg().Settings, locking.Strength)
 	tableScan.lockingWaitPolicy = descpb.ToScanLockingWaitPolicy(locking.WaitPolicy)
+	tableScan.lockingDurability = toScanLockingDurability(
+		ef.ctx, ef.planner.ExecCfg().Settings, ef.planner.SessionData(), tableScan.lockingStrength,
+	)
 
 	if !ef.isExplain && !(ef.planner.isInternalPlanner || ef.planner.SessionData().Internal) {
 		idxUsageKey := roachpb.IndexUsageKey{
@@ -863,8 +955,11 @@ func (ef *execFactory) ConstructInvertedJoin(
 		return nil, err
 	}
 	tableScan.index = idx
-	tableScan.lockingStrength = descpb.ToScanLockingStrength(locking.Strength)
+	tableScan.lockingStrength = toScanLockingStrength(ef.ctx, ef.planner.ExecCfg().Settings, locking.Strength)
 	tableScan.lockingWaitPolicy = descpb.ToScanLockingWaitPolicy(locking.WaitPolicy)
+	tableScan.lockingDurability = toScanLockingDurability(
+		ef.ctx, ef.planner.ExecCfg().Settings, ef.planner.SessionData(), tableScan.lockingStrength,
+	)
 
 	if !ef.isExplain && !(ef.planner.isInternalPlanner || ef.planner.SessionData().Internal) {
 		idxUsageKey := roachpb.IndexUsageKey{
@@ -943,8 +1038,11 @@ func (ef *execFactory) constructScanForZigzag(
 	}
 
 	scan.index = idxDesc
-	scan.lockingStrength = descpb.ToScanLockingStrength(locking.Strength)
+	scan.lockingStrength = toScanLockingStrength(ef.ctx, ef.planner.ExecCfg().Settings, locking.Strength)
 	scan.lockingWaitPolicy = descpb.ToScanLockingWaitPolicy(locking.WaitPolicy)
+	scan.lockingDurability = toScanLockingDurability(
+		ef.ctx, ef.planner.ExecCfg().Settings, ef.planner.SessionData(), scan.lockingStrength,
+	)
 
 	return scan, eqColOrdinals, nil
 }
@@ -1133,6 +1231,10 @@ func (ef *execFactory) ConstructWindow(root exec.Node, wi exec.WindowInfo) (exec
 
 	p.funcs = make([]*windowFuncHolder, len(wi.Exprs))
 	for i := range wi.Exprs {
+		var userDefined *exec.UserDefinedAggInfo
+		if wi.UserDefinedAggs != nil {
+			userDefined = wi.UserDefinedAggs[i]
+		}
 		argsIdxs := make([]uint32, len(wi.ArgIdxs[i]))
 		for j := range argsIdxs {
 			argsIdxs[j] = uint32(wi.ArgIdxs[i][j])
@@ -1147,6 +1249,7 @@ func (ef *execFactory) ConstructWindow(root exec.Node, wi exec.WindowInfo) (exec
 			partitionIdxs:  partitionIdxs,
 			columnOrdering: wi.Ordering,
 			frame:          wi.Exprs[i].WindowDef.Frame,
+			userDefined:    userDefined,
 		}
 		if len(wi.Ordering) == 0 {
 			frame := p.funcs[i].frame
@@ -1806,9 +1909,17 @@ func (ef *execFactory) ConstructCreateTable(
 	); err != nil {
 		return nil, err
 	}
+	var foreignTable *descpb.ForeignTableDescriptor
+	if ct.IsForeign() {
+		var err error
+		if foreignTable, err = ef.planner.makeForeignTableDescriptor(ef.ctx, ct); err != nil {
+			return nil, err
+		}
+	}
 	return &createTableNode{
-		n:      ct,
-		dbDesc: schema.(*optSchema).database,
+		n:            ct,
+		dbDesc:       schema.(*optSchema).database,
+		foreignTable: foreignTable,
 	}, nil
 }
 
@@ -1878,11 +1989,25 @@ func (ef *execFactory) ConstructCreateView(
 func (ef *execFactory) ConstructCreateFunction(
 	schema cat.Schema, cf *tree.CreateFunction, deps opt.SchemaDeps, typeDeps opt.SchemaTypeDeps,
 ) (exec.Node, error) {
-
+	stmtName := "CREATE FUNCTION"
+	if cf.IsProcedure {
+		stmtName = "CREATE PROCEDURE"
+		if !ef.planner.ExecCfg().Settings.Version.IsActive(ef.ctx, clusterversion.V23_2_StoredProcedures) {
+			return nil, pgerror.New(pgcode.FeatureNotSupported,
+				"CREATE PROCEDURE is not supported until the cluster version is finalized")
+		}
+	}
+	for i := range cf.Params {
+		if cf.Params[i].IsOutParam() &&
+			!ef.planner.ExecCfg().Settings.Version.IsActive(ef.ctx, clusterversion.V23_2_RoutineOutParams) {
+			return nil, pgerror.New(pgcode.FeatureNotSupported,
+				"OUT and INOUT parameters are not supported until the cluster version is finalized")
+		}
+	}
 	if err := checkSchemaChangeEnabled(
 		ef.ctx,
 		ef.planner.ExecCfg(),
-		"CREATE FUNCTION",
+		stmtName,
 	); err != nil {
 		return nil, err
 	}
@@ -1957,11 +2082,12 @@ func (ef *execFactory) ConstructSaveTable(
 
 // ConstructErrorIfRows is part of the exec.Factory interface.
 func (ef *execFactory) ConstructErrorIfRows(
-	input exec.Node, mkErr exec.MkErrFn,
+	input exec.Node, mkErr exec.MkErrFn, deferrable *exec.DeferrableCheck,
 ) (exec.Node, error) {
 	return &errorIfRowsNode{
-		plan:  input.(planNode),
-		mkErr: mkErr,
+		plan:       input.(planNode),
+		mkErr:      mkErr,
+		deferrable: deferrable,
 	}, nil
 }
 
@@ -2096,6 +2222,11 @@ func (ef *execFactory) ConstructControlSchedules(
 	}, nil
 }
 
+// ConstructCall is part of the exec.Factory interface.
+func (ef *execFactory) ConstructCall(proc *tree.RoutineExpr) (exec.Node, error) {
+	return &callNode{proc: proc}, nil
+}
+
 // ConstructShowCompletions is part of the exec.Factory interface.
 func (ef *execFactory) ConstructShowCompletions(command *tree.ShowCompletions) (exec.Node, error) {
 	return &completionsNode{
@@ -114,6 +114,8 @@ func TestContextualHelp(t *testing.T) {
 		{`ANALYSE ??`, `ANALYZE`},
 		{`ANALYSE blah ??`, `ANALYZE`},
 
+		{`CALL ??`, `CALL`},
+
 		{`CANCEL ??`, `CANCEL`},
 		{`CANCEL JOB ??`, `CANCEL JOBS`},
 		{`CANCEL JOBS ??`, `CANCEL JOBS`},
@@ -174,6 +176,8 @@ func TestContextualHelp(t *testing.T) {
 
 		{`CREATE TYPE blah AS ENUM ??`, `CREATE TYPE`},
 		{`DROP TYPE ??`, `DROP TYPE`},
+		{`CREATE DOMAIN ??`, `CREATE DOMAIN`},
+		{`DROP DOMAIN ??`, `DROP DOMAIN`},
 
 		{`CREATE SCHEMA IF ??`, `CREATE SCHEMA`},
 		{`CREATE SCHEMA IF NOT ??`, `CREATE SCHEMA`},
@@ -263,6 +267,10 @@ func TestContextualHelp(t *testing.T) {
 		{`MOVE ??`, `MOVE`},
 		{`MOVE 1 ??`, `MOVE`},
 
+		{`LISTEN ??`, `LISTEN`},
+		{`NOTIFY ??`, `NOTIFY`},
+		{`UNLISTEN ??`, `UNLISTEN`},
+
 		{`INSERT INTO ??`, `INSERT`},
 		{`INSERT INTO blah (??`, `<SELECTCLAUSE>`},
 		{`INSERT INTO blah VALUES (1) RETURNING ??`, `INSERT`},
@@ -471,6 +479,9 @@ func TestContextualHelp(t *testing.T) {
 		{`SET LOCAL TIME ??`, `SET LOCAL`},
 		{`SET LOCAL TIME ZONE 'UTC' ??`, `SET LOCAL`},
 
+		{`SET CONSTRAINTS ??`, `SET CONSTRAINTS`},
+		{`SET CONSTRAINTS ALL ??`, `SET CONSTRAINTS`},
+
 		{`SET TRANSACTION ??`, `SET TRANSACTION`},
 		{`SET TRANSACTION ISOLATION LEVEL SNAPSHOT ??`, `SET TRANSACTION`},
 		{`SET TIME ??`, `SET SESSION`},
@@ -530,8 +541,32 @@ func TestContextualHelp(t *testing.T) {
 		{`CREATE CHANGEFEED FOR foo INTO 'sink' ??`, `CREATE CHANGEFEED`},
 
 		{`CREATE FUNCTION ??`, `CREATE FUNCTION`},
+		{`CREATE PROCEDURE ??`, `CREATE PROCEDURE`},
+		{`CREATE AGGREGATE ??`, `CREATE AGGREGATE`},
+		{`CREATE TRIGGER ??`, `CREATE TRIGGER`},
+		{`CREATE POLICY ??`, `CREATE POLICY`},
+		{`CREATE FOREIGN TABLE ??`, `CREATE FOREIGN TABLE`},
+		{`CREATE FOREIGN TABLE t (a INT) ??`, `CREATE FOREIGN TABLE`},
+		{`CREATE PUBLICATION ??`, `CREATE PUBLICATION`},
+		{`CREATE PUBLICATION p FOR ??`, `CREATE PUBLICATION`},
+		{`CREATE TEXT SEARCH CONFIGURATION ??`, `CREATE TEXT SEARCH CONFIGURATION`},
+		{`CREATE TEXT SEARCH DICTIONARY ??`, `CREATE TEXT SEARCH DICTIONARY`},
 		{`ALTER FUNCTION ??`, `ALTER FUNCTION`},
+		{`ALTER AGGREGATE ??`, `ALTER AGGREGATE`},
+		{`ALTER POLICY ??`, `ALTER POLICY`},
+		{`ALTER PUBLICATION ??`, `ALTER PUBLICATION`},
+		{`ALTER TEXT SEARCH CONFIGURATION ??`, `ALTER TEXT SEARCH CONFIGURATION`},
+		{`ALTER TEXT SEARCH CONFIGURATION c ADD ??`, `ALTER TEXT SEARCH CONFIGURATION`},
+		{`ALTER TEXT SEARCH DICTIONARY ??`, `ALTER TEXT SEARCH DICTIONARY`},
 		{`DROP FUNCTION ??`, `DROP FUNCTION`},
+		{`DROP PROCEDURE ??`, `DROP PROCEDURE`},
+		{`DROP AGGREGATE ??`, `DROP AGGREGATE`},
+		{`DROP TRIGGER ??`, `DROP TRIGGER`},
+		{`DROP POLICY ??`, `DROP POLICY`},
+		{`DROP FOREIGN TABLE ??`, `DROP FOREIGN TABLE`},
+		{`DROP PUBLICATION ??`, `DROP PUBLICATION`},
+		{`DROP TEXT SEARCH CONFIGURATION ??`, `DROP TEXT SEARCH CONFIGURATION`},
+		{`DROP TEXT SEARCH DICTIONARY ??`, `DROP TEXT SEARCH DICTIONARY`},
 	}
 
 	// The following checks that the test definition above exercises all
@@ -629,9 +629,33 @@ func (u *sqlSymUnion) idxElem() tree.IndexElem {
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
+func (u *sqlSymUnion) excludeElem() tree.ExcludeElem {
+    return u.val.(tree.ExcludeElem)
+}
+func (u *sqlSymUnion) excludeElems() tree.ExcludeElemList {
+    return u.val.(tree.ExcludeElemList)
+}
+func (u *sqlSymUnion) exclusionOperator() tree.ExclusionOperator {
+    return u.val.(tree.ExclusionOperator)
+}
 func (u *sqlSymUnion) dropBehavior() tree.DropBehavior {
     return u.val.(tree.DropBehavior)
 }
+func (u *sqlSymUnion) triggerActionTime() tree.TriggerActionTime {
+    return u.val.(tree.TriggerActionTime)
+}
+func (u *sqlSymUnion) triggerEvents() tree.TriggerEvents {
+    return u.val.(tree.TriggerEvents)
+}
+func (u *sqlSymUnion) triggerEvent() tree.TriggerEventType {
+    return u.val.(tree.TriggerEventType)
+}
+func (u *sqlSymUnion) policyType() tree.PolicyType {
+    return u.val.(tree.PolicyType)
+}
+func (u *sqlSymUnion) policyCommand() tree.PolicyCommand {
+    return u.val.(tree.PolicyCommand)
+}
 func (u *sqlSymUnion) validationBehavior() tree.ValidationBehavior {
     return u.val.(tree.ValidationBehavior)
 }
@@ -719,6 +743,9 @@ func (u *sqlSymUnion) transactionModes() tree.TransactionModes {
 func (u *sqlSymUnion) compositeKeyMatchMethod() tree.CompositeKeyMatchMethod {
   return u.val.(tree.CompositeKeyMatchMethod)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+  return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) referenceAction() tree.ReferenceAction {
     return u.val.(tree.ReferenceAction)
 }
@@ -828,6 +855,12 @@ func (u *sqlSymUnion) functionOptions() tree.FunctionOptions {
 func (u *sqlSymUnion) functionOption() tree.FunctionOption {
     return u.val.(tree.FunctionOption)
 }
+func (u *sqlSymUnion) aggregateOptions() tree.AggregateOptions {
+    return u.val.(tree.AggregateOptions)
+}
+func (u *sqlSymUnion) aggregateOption() tree.AggregateOption {
+    return u.val.(tree.AggregateOption)
+}
 func (u *sqlSymUnion) functionParams() tree.FuncParams {
     return u.val.(tree.FuncParams)
 }
@@ -889,13 +922,13 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 // below; search this file for "Keyword category lists".
 
 // Ordinary key words in alphabetical order.
-%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADMIN AFTER AGGREGATE
+%token <str> ABORT ABSOLUTE ACCESS ACTION ADD ADJACENT ADMIN AFTER AGGREGATE
 %token <str> ALL ALTER ALWAYS ANALYSE ANALYZE AND AND_AND ANY ANNOTATE_TYPE ARRAY AS ASC AS_JSON AT_AT
 %token <str> ASENSITIVE ASYMMETRIC AT ATOMIC ATTRIBUTE AUTHORIZATION AUTOMATIC AVAILABILITY
 
 %token <str> BACKUP BACKUPS BACKWARD BEFORE BEGIN BETWEEN BIGINT BIGSERIAL BINARY BIT
 %token <str> BUCKET_COUNT
-%token <str> BOOLEAN BOTH BOX2D BUNDLE BY
+%token <str> BOOLEAN BOTH BOX2D BUNDLE BY BYPASSRLS
 
 %token <str> CACHE CALL CALLED CANCEL CANCELQUERY CAPABILITIES CAPABILITY CASCADE CASE CAST CBRT CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CHECK_FILES CLOSE
@@ -909,9 +942,9 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 
 %token <str> DATA DATABASE DATABASES DATE DAY DEBUG_IDS DEBUG_PAUSE_ON DEC DEBUG_DUMP_METADATA_SST DECIMAL DEFAULT DEFAULTS DEFINER
 %token <str> DEALLOCATE DECLARE DEFERRABLE DEFERRED DELETE DELIMITER DEPENDS DESC DESTINATION DETACHED DETAILS
-%token <str> DISCARD DISTINCT DO DOMAIN DOUBLE DROP
+%token <str> DICTIONARY DISABLE DISCARD DISTINCT DO DOMAIN DOUBLE DROP
 
-%token <str> ELSE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
+%token <str> EACH ELSE ENABLE ENCODING ENCRYPTED ENCRYPTION_INFO_DIR ENCRYPTION_PASSPHRASE END ENUM ENUMS ESCAPE EXCEPT EXCLUDE EXCLUDING
 %token <str> EXISTS EXECUTE EXECUTION EXPERIMENTAL
 %token <str> EXPERIMENTAL_FINGERPRINTS EXPERIMENTAL_REPLICA
 %token <str> EXPERIMENTAL_AUDIT EXPERIMENTAL_RELOCATE
@@ -945,25 +978,25 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %token <str> LABEL LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEAKPROOF LEFT LESS LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
-%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
+%token <str> LIST LISTEN LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
 
-%token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
+%token <str> MAPPING MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MODIFYSQLCLUSTERSETTING MONTH MOVE
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
 %token <str> MULTIPOINT MULTIPOINTM MULTIPOINTZ MULTIPOINTZM
 %token <str> MULTIPOLYGON MULTIPOLYGONM MULTIPOLYGONZ MULTIPOLYGONZM
 
-%token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEXT NO NOCANCELQUERY NOCONTROLCHANGEFEED
+%token <str> NAN NAME NAMES NATURAL NEVER NEW_DB_NAME NEW_KMS NEXT NO NOBYPASSRLS NOCANCELQUERY NOCONTROLCHANGEFEED
 %token <str> NOCONTROLJOB NOCREATEDB NOCREATELOGIN NOCREATEROLE NOLOGIN NOMODIFYCLUSTERSETTING
 %token <str> NOSQLLOGIN NO_INDEX_JOIN NO_ZIGZAG_JOIN NO_FULL_SCAN NONE NONVOTERS NORMAL NOT
 %token <str> NOTHING NOTHING_AFTER_RETURNING
-%token <str> NOTNULL
+%token <str> NOTIFY NOTNULL
 %token <str> NOVIEWACTIVITY NOVIEWACTIVITYREDACTED NOVIEWCLUSTERSETTING NOWAIT NULL NULLIF NULLS NUMERIC
 
 %token <str> OF OFF OFFSET OID OIDS OIDVECTOR OLD_KMS ON ONLY OPT OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
-%token <str> PARALLEL PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PHYSICAL PLACEMENT PLACING
-%token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLYGON POLYGONM POLYGONZ POLYGONZM
+%token <str> PARALLEL PARENT PARSER PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PERMISSIVE PHYSICAL PLACEMENT PLACING
+%token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLICY POLYGON POLYGONM POLYGONZ POLYGONZM
 %token <str> POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIOR PRIORITY PRIVILEGES
 %token <str> PROCEDURAL PROCEDURE PUBLIC PUBLICATION
 
@@ -972,7 +1005,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %token <str> RANGE RANGES READ REAL REASON REASSIGN RECURSIVE RECURRING REDACT REF REFERENCES REFRESH
 %token <str> REGCLASS REGION REGIONAL REGIONS REGNAMESPACE REGPROC REGPROCEDURE REGROLE REGTYPE REINDEX
 %token <str> RELATIVE RELOCATE REMOVE_PATH RENAME REPEATABLE REPLACE REPLICATION
-%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
+%token <str> RELEASE RESET RESTART RESTORE RESTRICT RESTRICTED RESTRICTIVE RESUME RETENTION RETURNING RETURN RETURNS RETRY REVISION_HISTORY
 %token <str> REVOKE RIGHT ROLE ROLES ROLLBACK ROLLUP ROUTINES ROW ROWS RSHIFT RULE RUNNING
 
 %token <str> SAVEPOINT SCANS SCATTER SCHEDULE SCHEDULES SCROLL SCHEMA SCHEMA_ONLY SCHEMAS SCRUB
@@ -981,7 +1014,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %token <str> SHARE SHARED SHOW SIMILAR SIMPLE SKIP SKIP_LOCALITIES_CHECK SKIP_MISSING_FOREIGN_KEYS
 %token <str> SKIP_MISSING_SEQUENCES SKIP_MISSING_SEQUENCE_OWNERS SKIP_MISSING_VIEWS SKIP_MISSING_UDFS SMALLINT SMALLSERIAL SNAPSHOT SOME SPLIT SQL
 %token <str> SQLLOGIN
-%token <str> STABLE START STATE STATISTICS STATUS STDIN STDOUT STOP STREAM STRICT STRING STORAGE STORE STORED STORING SUBSTRING SUPER
+%token <str> STABLE START STATE STATEMENT STATISTICS STATUS STDIN STDOUT STOP STREAM STRICT STRING STORAGE STORE STORED STORING SUBSTRING SUPER
 %token <str> SUPPORT SURVIVE SURVIVAL SYMMETRIC SYNTAX SYSTEM SQRT SUBSCRIPTION STATEMENTS
 
 %token <str> TABLE TABLES TABLESPACE TEMP TEMPLATE TEMPORARY TENANT TENANT_NAME TENANTS TESTING_RELOCATE TEXT THEN
@@ -1052,6 +1085,11 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <tree.Statement> alter_schema_stmt
 %type <tree.Statement> alter_unsupported_stmt
 %type <tree.Statement> alter_func_stmt
+%type <tree.Statement> alter_aggregate_stmt
+%type <tree.Statement> alter_policy_stmt
+%type <tree.Statement> alter_publication_stmt
+%type <tree.Statement> alter_text_search_config_stmt
+%type <tree.Statement> alter_text_search_dict_stmt
 
 // ALTER RANGE
 %type <tree.Statement> alter_zone_range_stmt
@@ -1179,6 +1217,15 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <tree.Statement> create_view_stmt
 %type <tree.Statement> create_sequence_stmt
 %type <tree.Statement> create_func_stmt
+%type <tree.Statement> create_aggregate_stmt
+%type <tree.Statement> create_proc_stmt
+%type <tree.Statement> create_trigger_stmt
+%type <tree.Statement> create_policy_stmt
+%type <tree.Statement> create_publication_stmt
+%type <tree.Statement> create_text_search_config_stmt
+%type <tree.Statement> create_text_search_dict_stmt
+%type <tree.Statement> create_foreign_table_stmt
+%type <tree.Statement> create_domain_stmt
 
 %type <*tree.LikeTenantSpec> opt_like_tenant
 
@@ -1200,11 +1247,28 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <tree.Statement> drop_schema_stmt
 %type <tree.Statement> drop_table_stmt
 %type <tree.Statement> drop_type_stmt
+%type <tree.Statement> drop_domain_stmt
 %type <tree.Statement> drop_view_stmt
 %type <tree.Statement> drop_sequence_stmt
 %type <tree.Statement> drop_func_stmt
+%type <tree.Statement> drop_aggregate_stmt
+%type <tree.Statement> drop_proc_stmt
+%type <tree.Statement> drop_trigger_stmt
+%type <tree.Statement> drop_policy_stmt
+%type <tree.Statement> drop_publication_stmt
+%type <tree.Statement> drop_text_search_config_stmt
+%type <tree.Statement> drop_text_search_dict_stmt
+%type <tree.Statement> drop_foreign_table_stmt
 %type <tree.Statement> drop_tenant_stmt
 %type <bool>           opt_immediate
+%type <tree.TriggerActionTime> trigger_action_time
+%type <tree.TriggerEvents> trigger_event_list
+%type <tree.TriggerEventType> trigger_event
+%type <bool> opt_trigger_for_each trigger_row_or_statement
+%type <tree.PolicyType> opt_policy_type
+%type <tree.PolicyCommand> opt_policy_command
+%type <tree.RoleSpecList> opt_policy_roles
+%type <tree.Expr> opt_policy_using opt_policy_with_check
 
 %type <tree.Statement> analyze_stmt
 %type <tree.Statement> explain_stmt
@@ -1242,6 +1306,8 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <tree.Statement> set_session_stmt
 %type <tree.Statement> set_csetting_stmt set_or_reset_csetting_stmt
 %type <tree.Statement> set_transaction_stmt
+%type <tree.Statement> set_constraints_stmt
+%type <bool> constraints_set_mode
 %type <tree.Statement> set_exprs_internal
 %type <tree.Statement> generic_set
 %type <tree.Statement> set_rest_more
@@ -1303,6 +1369,8 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 
 %type <tree.Statement> transaction_stmt legacy_transaction_stmt legacy_begin_stmt legacy_end_stmt
 %type <tree.Statement> truncate_stmt
+%type <tree.Statement> listen_stmt
+%type <tree.Statement> notify_stmt
 %type <tree.Statement> unlisten_stmt
 %type <tree.Statement> update_stmt
 %type <tree.Statement> upsert_stmt
@@ -1324,6 +1392,10 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <[]string> opt_incremental
 %type <tree.KVOption> kv_option
 %type <[]tree.KVOption> kv_option_list opt_with_options var_set_list opt_with_schedule_options
+%type <[]tree.KVOption> opt_with_publication_options
+%type <tree.KVOption> text_search_option
+%type <[]tree.KVOption> text_search_option_list
+%type <tree.Expr> text_search_option_value
 %type <*tree.BackupOptions> opt_with_backup_options backup_options backup_options_list
 %type <*tree.RestoreOptions> opt_with_restore_options restore_options restore_options_list
 %type <*tree.TenantReplicationOptions> opt_with_tenant_replication_options tenant_replication_options tenant_replication_options_list
@@ -1451,6 +1523,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <tree.GroupBy> group_clause
 %type <tree.Exprs> group_by_list
 %type <tree.Expr> group_by_item
+%type <tree.Expr> rollup_clause cube_clause grouping_sets_clause
 %type <*tree.Limit> select_limit opt_select_limit
 %type <tree.TableNames> relation_expr_list
 %type <tree.ReturningClause> returning_clause
@@ -1522,6 +1595,9 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <bool> opt_ordinality opt_compact
 %type <*tree.Order> sortby
 %type <tree.IndexElem> index_elem index_elem_options create_as_param
+%type <tree.ExcludeElemList> exclude_elem_list
+%type <tree.ExcludeElem> exclude_elem
+%type <tree.ExclusionOperator> exclude_op
 %type <tree.TableExpr> table_ref numeric_table_ref func_table
 %type <tree.Exprs> rowsfrom_list
 %type <tree.Expr> rowsfrom_item
@@ -1587,6 +1663,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <tree.ColumnQualification> col_qualification_elem create_as_col_qualification_elem
 %type <tree.CompositeKeyMatchMethod> key_match
 %type <tree.ReferenceActions> reference_actions
+%type <tree.ConstraintDeferrability> opt_deferrable
 %type <tree.ReferenceAction> reference_action reference_on_delete reference_on_update
 
 %type <tree.Expr> func_application func_expr_common_subexpr special_function
@@ -1648,13 +1725,16 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %type <privilege.TargetObjectType> target_object_type
 
 // User defined function relevant components.
-%type <bool> opt_or_replace opt_return_table opt_return_set opt_no
+%type <bool> opt_or_replace opt_return_set opt_no
 %type <str> param_name func_as
 %type <tree.FuncParams> opt_func_param_with_default_list func_param_with_default_list func_params func_params_list
-%type <tree.FuncParam> func_param_with_default func_param
+%type <tree.FuncParams> return_table_column_list
+%type <tree.FuncParam> func_param_with_default func_param return_table_column
 %type <tree.ResolvableTypeReference> func_return_type func_param_type
 %type <tree.FunctionOptions> opt_create_func_opt_list create_func_opt_list alter_func_opt_list
 %type <tree.FunctionOption> create_func_opt_item common_func_opt_item
+%type <tree.AggregateOptions> aggregate_option_list
+%type <tree.AggregateOption> aggregate_option
 %type <tree.FuncParamClass> func_param_class
 %type <*tree.UnresolvedObjectName> func_create_name
 %type <tree.Statement> routine_return_stmt routine_body_stmt
@@ -1712,7 +1792,7 @@ func (u *sqlSymUnion) showCreateFormatOption() tree.ShowCreateFormatOption {
 %left      '|'
 %left      '#'
 %left      '&'
-%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND SQRT CBRT
+%left      LSHIFT RSHIFT INET_CONTAINS_OR_EQUALS INET_CONTAINED_BY_OR_EQUALS AND_AND ADJACENT SQRT CBRT
 %left      OPERATOR // if changing the last token before OPERATOR, change all instances of %prec <last token>
 %left      '+' '-'
 %left      '*' '/' FLOORDIV '%'
@@ -1756,7 +1836,7 @@ stmt:
 stmt_without_legacy_transaction:
   preparable_stmt            // help texts in sub-rule
 | analyze_stmt               // EXTEND WITH HELP: ANALYZE
-| call_stmt
+| call_stmt                  // EXTEND WITH HELP: CALL
 | copy_stmt
 | comment_stmt
 | execute_stmt               // EXTEND WITH HELP: EXECUTE
@@ -1777,7 +1857,9 @@ stmt_without_legacy_transaction:
 | fetch_cursor_stmt          // EXTEND WITH HELP: FETCH
 | move_cursor_stmt           // EXTEND WITH HELP: MOVE
 | reindex_stmt
-| unlisten_stmt
+| listen_stmt                // EXTEND WITH HELP: LISTEN
+| notify_stmt                // EXTEND WITH HELP: NOTIFY
+| unlisten_stmt              // EXTEND WITH HELP: UNLISTEN
 | show_commit_timestamp_stmt // EXTEND WITH HELP: SHOW COMMIT TIMESTAMP
 
 // %Help: ALTER
@@ -1804,6 +1886,11 @@ alter_ddl_stmt:
 | alter_changefeed_stmt         // EXTEND WITH HELP: ALTER CHANGEFEED
 | alter_backup_stmt             // EXTEND WITH HELP: ALTER BACKUP
 | alter_func_stmt               // EXTEND WITH HELP: ALTER FUNCTION
+| alter_aggregate_stmt          // EXTEND WITH HELP: ALTER AGGREGATE
+| alter_policy_stmt             // EXTEND WITH HELP: ALTER POLICY
+| alter_publication_stmt        // EXTEND WITH HELP: ALTER PUBLICATION
+| alter_text_search_config_stmt // EXTEND WITH HELP: ALTER TEXT SEARCH CONFIGURATION
+| alter_text_search_dict_stmt   // EXTEND WITH HELP: ALTER TEXT SEARCH DICTIONARY
 | alter_backup_schedule  // EXTEND WITH HELP: ALTER BACKUP SCHEDULE
 
 // %Help: ALTER TABLE - change the definition of a table
@@ -1838,6 +1925,7 @@ alter_ddl_stmt:
 //   ALTER TABLE ... CONFIGURE ZONE <zoneconfig>
 //   ALTER TABLE ... SET SCHEMA <newschemaname>
 //   ALTER TABLE ... SET LOCALITY [REGIONAL BY [TABLE IN <region> | ROW] | GLOBAL]
+//   ALTER TABLE ... {ENABLE | DISABLE | FORCE | NO FORCE} ROW LEVEL SECURITY
 //
 // Column qualifiers:
 //   [CONSTRAINT <constraintname>] {NULL | NOT NULL | UNIQUE | PRIMARY KEY | CHECK (<expr>) | DEFAULT <expr>}
@@ -1998,6 +2086,41 @@ alter_func_stmt:
 | alter_func_dep_extension_stmt
 | ALTER FUNCTION error // SHOW HELP: ALTER FUNCTION
 
+// %Help: ALTER AGGREGATE - change the definition of an aggregate function
+// %Category: DDL
+// %Text:
+// ALTER AGGREGATE name ( [ argname ] argtype [, ...] ) RENAME TO new_name
+// ALTER AGGREGATE name ( [ argname ] argtype [, ...] )
+//    OWNER TO { new_owner | CURRENT_USER | SESSION_USER }
+// ALTER AGGREGATE name ( [ argname ] argtype [, ...] ) SET SCHEMA new_schema
+// %SeeAlso: CREATE AGGREGATE, DROP AGGREGATE
+alter_aggregate_stmt:
+  ALTER AGGREGATE function_with_paramtypes RENAME TO name
+  {
+    $$.val = &tree.AlterFunctionRename{
+      IsAggregate: true,
+      Function: $3.functionObj(),
+      NewName: tree.Name($6),
+    }
+  }
+| ALTER AGGREGATE function_with_paramtypes OWNER TO role_spec
+  {
+    $$.val = &tree.AlterFunctionSetOwner{
+      IsAggregate: true,
+      Function: $3.functionObj(),
+      NewOwner: $6.roleSpec(),
+    }
+  }
+| ALTER AGGREGATE function_with_paramtypes SET SCHEMA schema_name
+  {
+    $$.val = &tree.AlterFunctionSetSchema{
+      IsAggregate: true,
+      Function: $3.functionObj(),
+      NewSchemaName: tree.Name($6),
+    }
+  }
+| ALTER AGGREGATE error // SHOW HELP: ALTER AGGREGATE
+
 // ALTER DATABASE has its error help token here because the ALTER DATABASE
 // prefix is spread over multiple non-terminals.
 | ALTER DATABASE error // SHOW HELP: ALTER DATABASE
@@ -2839,6 +2962,26 @@ alter_table_cmd:
       Params: $3.storageParamKeys(),
     }
   }
+  // ALTER TABLE <name> ENABLE ROW LEVEL SECURITY
+| ENABLE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableRowLevelSecurity{Mode: tree.RowLevelSecurityEnable}
+  }
+  // ALTER TABLE <name> DISABLE ROW LEVEL SECURITY
+| DISABLE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableRowLevelSecurity{Mode: tree.RowLevelSecurityDisable}
+  }
+  // ALTER TABLE <name> FORCE ROW LEVEL SECURITY
+| FORCE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableRowLevelSecurity{Mode: tree.RowLevelSecurityForce}
+  }
+  // ALTER TABLE <name> NO FORCE ROW LEVEL SECURITY
+| NO FORCE ROW LEVEL SECURITY
+  {
+    $$.val = &tree.AlterTableRowLevelSecurity{Mode: tree.RowLevelSecurityNoForce}
+  }
 
 audit_mode:
   READ WRITE { $$.val = tree.AuditModeReadWrite }
@@ -3097,7 +3240,7 @@ alter_attribute_action:
 // %Help: REFRESH - recalculate a materialized view
 // %Category: Misc
 // %Text:
-// REFRESH MATERIALIZED VIEW [CONCURRENTLY] view_name [WITH [NO] DATA]
+// REFRESH MATERIALIZED VIEW [CONCURRENTLY] view_name [WITH [NO | INCREMENTAL] DATA]
 refresh_stmt:
   REFRESH MATERIALIZED VIEW opt_concurrently view_name opt_clear_data
   {
@@ -3118,6 +3261,10 @@ opt_clear_data:
   {
     $$.val = tree.RefreshDataClear
   }
+| WITH INCREMENTAL DATA
+  {
+    $$.val = tree.RefreshDataIncremental
+  }
 | /* EMPTY */
   {
     $$.val = tree.RefreshDataDefault
@@ -3808,10 +3955,6 @@ alter_unsupported_stmt:
   {
     return unimplemented(sqllex, "alter domain")
   }
-| ALTER AGGREGATE error
-  {
-    return unimplementedWithIssueDetail(sqllex, 74775, "alter aggregate")
-  }
 
 // %Help: IMPORT - load data from file in a distributed manner
 // %Category: CCL
@@ -3961,9 +4104,16 @@ opt_with_options:
     $$.val = nil
   }
 
-// CALL invokes a stored procedure. It is not currently supported in CRDB.
+// %Help: CALL - invoke a procedure
+// %Category: Misc
+// %Text: CALL <name> ( [ <expr> [, ...] ] )
+// %SeeAlso: CREATE PROCEDURE
 call_stmt:
-  CALL error { return unimplementedWithIssueDetail(sqllex, 17511, "call procedure") }
+  CALL func_application
+  {
+    $$.val = &tree.Call{Proc: $2.expr().(*tree.FuncExpr)}
+  }
+| CALL error // SHOW HELP: CALL
 
 // The COPY grammar in postgres has 3 different versions, all of which are supported by postgres:
 // 1) The "really old" syntax from v7.2 and prior
@@ -4517,7 +4667,8 @@ create_extension_stmt:
 // %Text:
 // CREATE [ OR REPLACE ] FUNCTION
 //    name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
-//    [ RETURNS rettype ]
+//    [ RETURNS rettype
+//      | RETURNS TABLE ( column_name column_type [, ...] ) ]
 //  { LANGUAGE lang_name
 //    | { IMMUTABLE | STABLE | VOLATILE }
 //    | [ NOT ] LEAKPROOF
@@ -4527,7 +4678,7 @@ create_extension_stmt:
 // %SeeAlso: WEBDOCS/create-function.html
 create_func_stmt:
   CREATE opt_or_replace FUNCTION func_create_name '(' opt_func_param_with_default_list ')'
-  RETURNS opt_return_table opt_return_set func_return_type
+  RETURNS opt_return_set func_return_type
   opt_create_func_opt_list opt_routine_body
   {
     name := $4.unresolvedObjectName().ToFunctionName()
@@ -4537,23 +4688,145 @@ create_func_stmt:
       FuncName: name,
       Params: $6.functionParams(),
       ReturnType: tree.FuncReturnType{
-        Type: $11.typeReference(),
-        IsSet: $10.bool(),
+        Type: $10.typeReference(),
+        IsSet: $9.bool(),
+      },
+      Options: $11.functionOptions(),
+      RoutineBody: $12.routineBody(),
+    }
+  }
+| CREATE opt_or_replace FUNCTION func_create_name '(' opt_func_param_with_default_list ')'
+  RETURNS TABLE '(' return_table_column_list ')'
+  opt_create_func_opt_list opt_routine_body
+  {
+    name := $4.unresolvedObjectName().ToFunctionName()
+    params := $6.functionParams()
+    for i := range params {
+      if params[i].IsOutParam() {
+        return setErr(sqllex, pgerror.New(pgcode.InvalidFunctionDefinition,
+          "OUT and INOUT arguments aren't allowed in TABLE functions"))
+      }
+    }
+    // RETURNS TABLE is equivalent to declaring the columns as OUT parameters
+    // of a function that returns SETOF RECORD.
+    $$.val = &tree.CreateFunction{
+      IsProcedure: false,
+      Replace: $2.bool(),
+      FuncName: name,
+      Params: append(params, $11.functionParams()...),
+      ReturnType: tree.FuncReturnType{
+        Type: types.AnyTuple,
+        IsSet: true,
       },
-      Options: $12.functionOptions(),
-      RoutineBody: $13.routineBody(),
+      Options: $13.functionOptions(),
+      RoutineBody: $14.routineBody(),
+    }
+  }
+| CREATE opt_or_replace FUNCTION func_create_name '(' opt_func_param_with_default_list ')'
+  opt_create_func_opt_list opt_routine_body
+  {
+    // The RETURNS clause may only be omitted when the function has OUT
+    // parameters, in which case the return type is determined by them.
+    name := $4.unresolvedObjectName().ToFunctionName()
+    $$.val = &tree.CreateFunction{
+      IsProcedure: false,
+      Replace: $2.bool(),
+      FuncName: name,
+      Params: $6.functionParams(),
+      Options: $8.functionOptions(),
+      RoutineBody: $9.routineBody(),
     }
   }
 | CREATE opt_or_replace FUNCTION error // SHOW HELP: CREATE FUNCTION
 
+// %Help: CREATE PROCEDURE - define a new procedure
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] PROCEDURE
+//    name ( [ [ argmode ] [ argname ] argtype [, ...] ] )
+//  { LANGUAGE lang_name
+//    | AS 'definition'
+//  } ...
+// %SeeAlso: CALL, DROP PROCEDURE, CREATE FUNCTION
+create_proc_stmt:
+  CREATE opt_or_replace PROCEDURE func_create_name '(' opt_func_param_with_default_list ')'
+  opt_create_func_opt_list opt_routine_body
+  {
+    name := $4.unresolvedObjectName().ToFunctionName()
+    $$.val = &tree.CreateFunction{
+      IsProcedure: true,
+      Replace: $2.bool(),
+      FuncName: name,
+      Params: $6.functionParams(),
+      ReturnType: tree.FuncReturnType{
+        Type: types.Void,
+      },
+      Options: $8.functionOptions(),
+      RoutineBody: $9.routineBody(),
+    }
+  }
+| CREATE opt_or_replace PROCEDURE error // SHOW HELP: CREATE PROCEDURE
+
+// %Help: CREATE AGGREGATE - define a new aggregate function
+// %Category: DDL
+// %Text:
+// CREATE [ OR REPLACE ] AGGREGATE name ( [ argname ] argtype [, ...] ) (
+//    SFUNC = sfunc,
+//    STYPE = state_data_type
+//    [ , FINALFUNC = ffunc ]
+//    [ , COMBINEFUNC = combinefunc ]
+//    [ , INITCOND = initial_condition ]
+// )
+// %SeeAlso: DROP AGGREGATE, CREATE FUNCTION
+create_aggregate_stmt:
+  CREATE opt_or_replace AGGREGATE func_create_name '(' opt_func_param_with_default_list ')'
+  '(' aggregate_option_list ')'
+  {
+    name := $4.unresolvedObjectName().ToFunctionName()
+    $$.val = &tree.CreateAggregate{
+      Replace: $2.bool(),
+      FuncName: name,
+      Params: $6.functionParams(),
+      Options: $9.aggregateOptions(),
+    }
+  }
+| CREATE opt_or_replace AGGREGATE error // SHOW HELP: CREATE AGGREGATE
+
+aggregate_option_list:
+  aggregate_option
+  {
+    $$.val = tree.AggregateOptions{$1.aggregateOption()}
+  }
+| aggregate_option_list ',' aggregate_option
+  {
+    $$.val = append($1.aggregateOptions(), $3.aggregateOption())
+  }
+
+// The value of an aggregate option is either a type name, which is also used
+// to name the support functions of the aggregate, a constant, or an ARRAY
+// constructor.
+aggregate_option:
+  name '=' typename
+  {
+    $$.val = tree.AggregateOption{Name: tree.Name($1), TypeVal: $3.typeReference()}
+  }
+| name '=' SCONST
+  {
+    $$.val = tree.AggregateOption{Name: tree.Name($1), StrVal: tree.NewStrVal($3)}
+  }
+| name '=' numeric_only
+  {
+    $$.val = tree.AggregateOption{Name: tree.Name($1), StrVal: tree.NewStrVal($3.numVal().String())}
+  }
+| name '=' ARRAY array_expr
+  {
+    $$.val = tree.AggregateOption{Name: tree.Name($1), ExprVal: $4.expr()}
+  }
+
 opt_or_replace:
   OR REPLACE { $$.val = true }
 | /* EMPTY */ { $$.val = false }
 
-opt_return_table:
-  TABLE { return unimplementedWithIssueDetail(sqllex, 100226, "UDF returning TABLE") }
-| /* EMPTY */ { $$.val = false }
-
 opt_return_set:
   SETOF { $$.val = true}
 | /* EMPTY */ { $$.val = false }
@@ -4629,14 +4902,31 @@ func_param:
 
 func_param_class:
   IN { $$.val = tree.FunctionParamIn }
-| OUT { return unimplementedWithIssueDetail(sqllex, 100405, "create function with 'OUT' argument class") }
-| INOUT { return unimplementedWithIssueDetail(sqllex, 100405, "create function with 'INOUT' argument class") }
-| IN OUT { return unimplementedWithIssueDetail(sqllex, 100405, "create function with 'IN OUT' argument class") }
+| OUT { $$.val = tree.FunctionParamOut }
+| INOUT { $$.val = tree.FunctionParamInOut }
+| IN OUT { $$.val = tree.FunctionParamInOut }
 | VARIADIC { return unimplementedWithIssueDetail(sqllex, 88947, "variadic user-defined functions") }
 
 func_param_type:
   typename
 
+return_table_column_list:
+  return_table_column { $$.val = tree.FuncParams{$1.functionParam()} }
+| return_table_column_list ',' return_table_column
+  {
+    $$.val = append($1.functionParams(), $3.functionParam())
+  }
+
+return_table_column:
+  param_name func_param_type
+  {
+    $$.val = tree.FuncParam{
+      Name: tree.Name($1),
+      Type: $2.typeReference(),
+      Class: tree.FunctionParamOut,
+    }
+  }
+
 func_return_type:
   func_param_type
 
@@ -4704,115 +4994,748 @@ common_func_opt_item:
   {
     return unimplemented(sqllex, "create function...security")
   }
-| SECURITY DEFINER
+| SECURITY DEFINER
+  {
+    return unimplemented(sqllex, "create function...security")
+  }
+| SECURITY INVOKER
+  {
+    return unimplemented(sqllex, "create function...security")
+  }
+| LEAKPROOF
+  {
+    $$.val = tree.FunctionLeakproof(true)
+  }
+| NOT LEAKPROOF
+  {
+    $$.val = tree.FunctionLeakproof(false)
+  }
+| COST numeric_only
+  {
+    return unimplemented(sqllex, "create function...cost")
+  }
+| ROWS numeric_only
+  {
+    return unimplemented(sqllex, "create function...rows")
+  }
+| SUPPORT name
+  {
+    return unimplemented(sqllex, "create function...support")
+  }
+
+// In theory we should parse the a whole set/reset statement here. But it's fine
+// to just return fast on SET/RESET keyword for now since it's not supported
+// yet.
+| SET { return unimplemented(sqllex, "create function...set") }
+| PARALLEL { return unimplemented(sqllex, "create function...parallel") }
+
+func_as:
+  SCONST
+
+routine_return_stmt:
+  RETURN a_expr
+{
+  $$.val = &tree.RoutineReturn{
+    ReturnVal: $2.expr(),
+  }
+}
+
+routine_body_stmt:
+  stmt_without_legacy_transaction
+| routine_return_stmt
+
+routine_body_stmt_list:
+  routine_body_stmt_list routine_body_stmt ';'
+  {
+    $$.val = append($1.stmts(), $2.stmt())
+  }
+| /* Empty */
+  {
+    $$.val = tree.Statements{}
+  }
+
+opt_routine_body:
+  routine_return_stmt
+  {
+    $$.val = &tree.RoutineBody{
+      Stmts: tree.Statements{$1.stmt()},
+    }
+  }
+| BEGIN ATOMIC routine_body_stmt_list END
+  {
+    $$.val = &tree.RoutineBody{
+        Stmts: $3.stmts(),
+    }
+  }
+| /* Empty */
+  {
+    $$.val = (*tree.RoutineBody)(nil)
+  }
+
+opt_link_sym:
+  ',' SCONST
+  {
+  }
+| /* Empty */
+  {
+  }
+
+// %Help: DROP FUNCTION - remove a function
+// %Category: DDL
+// %Text:
+// DROP FUNCTION [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: WEBDOCS/drop-function.html
+drop_func_stmt:
+  DROP FUNCTION function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      Functions: $3.functionObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP FUNCTION IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IfExists: true,
+      Functions: $5.functionObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP FUNCTION error // SHOW HELP: DROP FUNCTION
+
+// %Help: DROP PROCEDURE - remove a procedure
+// %Category: DDL
+// %Text:
+// DROP PROCEDURE [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE PROCEDURE
+drop_proc_stmt:
+  DROP PROCEDURE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsProcedure: true,
+      Functions: $3.functionObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP PROCEDURE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsProcedure: true,
+      IfExists: true,
+      Functions: $5.functionObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP PROCEDURE error // SHOW HELP: DROP PROCEDURE
+
+// %Help: DROP AGGREGATE - remove an aggregate function
+// %Category: DDL
+// %Text:
+// DROP AGGREGATE [ IF EXISTS ] name ( [ argname ] argtype [, ...] ) [, ...]
+//    [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE AGGREGATE
+drop_aggregate_stmt:
+  DROP AGGREGATE function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsAggregate: true,
+      Functions: $3.functionObjs(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE IF EXISTS function_with_paramtypes_list opt_drop_behavior
+  {
+    $$.val = &tree.DropFunction{
+      IsAggregate: true,
+      IfExists: true,
+      Functions: $5.functionObjs(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP AGGREGATE error // SHOW HELP: DROP AGGREGATE
+
+// %Help: CREATE TRIGGER - define a new trigger
+// %Category: DDL
+// %Text:
+// CREATE TRIGGER name { BEFORE | AFTER } { INSERT | UPDATE | DELETE } [ OR ... ]
+//    ON table_name
+//    [ FOR [ EACH ] { ROW | STATEMENT } ]
+//    EXECUTE { FUNCTION | PROCEDURE } function_name ( )
+// %SeeAlso: DROP TRIGGER, CREATE FUNCTION
+create_trigger_stmt:
+  CREATE TRIGGER name trigger_action_time trigger_event_list ON table_name
+  opt_trigger_for_each EXECUTE function_or_procedure func_name '(' ')'
+  {
+    $$.val = &tree.CreateTrigger{
+      Name: tree.Name($3),
+      ActionTime: $4.triggerActionTime(),
+      Events: $5.triggerEvents(),
+      Table: $7.unresolvedObjectName().ToTableName(),
+      ForEachRow: $8.bool(),
+      FuncName: $11.unresolvedName(),
+    }
+  }
+| CREATE TRIGGER error // SHOW HELP: CREATE TRIGGER
+
+trigger_action_time:
+  BEFORE
+  {
+    $$.val = tree.TriggerActionTimeBefore
+  }
+| AFTER
+  {
+    $$.val = tree.TriggerActionTimeAfter
+  }
+
+trigger_event_list:
+  trigger_event
+  {
+    $$.val = tree.TriggerEvents{$1.triggerEvent()}
+  }
+| trigger_event_list OR trigger_event
+  {
+    $$.val = append($1.triggerEvents(), $3.triggerEvent())
+  }
+
+trigger_event:
+  INSERT
+  {
+    $$.val = tree.TriggerEventInsert
+  }
+| UPDATE
+  {
+    $$.val = tree.TriggerEventUpdate
+  }
+| DELETE
+  {
+    $$.val = tree.TriggerEventDelete
+  }
+
+opt_trigger_for_each:
+  FOR EACH trigger_row_or_statement
+  {
+    $$.val = $3.bool()
+  }
+| FOR trigger_row_or_statement
+  {
+    $$.val = $2.bool()
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
+trigger_row_or_statement:
+  ROW
+  {
+    $$.val = true
+  }
+| STATEMENT
+  {
+    $$.val = false
+  }
+
+function_or_procedure:
+  FUNCTION {}
+| PROCEDURE {}
+
+// %Help: DROP TRIGGER - remove a trigger
+// %Category: DDL
+// %Text: DROP TRIGGER [ IF EXISTS ] name ON table_name [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE TRIGGER
+drop_trigger_stmt:
+  DROP TRIGGER name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName().ToTableName(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TRIGGER IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropTrigger{
+      Name: tree.Name($5),
+      Table: $7.unresolvedObjectName().ToTableName(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP TRIGGER error // SHOW HELP: DROP TRIGGER
+
+// %Help: CREATE POLICY - define a new row-level security policy for a table
+// %Category: DDL
+// %Text:
+// CREATE POLICY name ON table_name
+//    [ AS { PERMISSIVE | RESTRICTIVE } ]
+//    [ FOR { ALL | SELECT | INSERT | UPDATE | DELETE } ]
+//    [ TO role_name [, ...] ]
+//    [ USING ( using_expression ) ]
+//    [ WITH CHECK ( check_expression ) ]
+// %SeeAlso: ALTER POLICY, DROP POLICY, ALTER TABLE
+create_policy_stmt:
+  CREATE POLICY name ON table_name opt_policy_type opt_policy_command
+  opt_policy_roles opt_policy_using opt_policy_with_check
+  {
+    $$.val = &tree.CreatePolicy{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName().ToTableName(),
+      Type: $6.policyType(),
+      Command: $7.policyCommand(),
+      Roles: $8.roleSpecList(),
+      Using: $9.expr(),
+      WithCheck: $10.expr(),
+    }
+  }
+| CREATE POLICY error // SHOW HELP: CREATE POLICY
+
+opt_policy_type:
+  AS PERMISSIVE
+  {
+    $$.val = tree.PolicyTypePermissive
+  }
+| AS RESTRICTIVE
+  {
+    $$.val = tree.PolicyTypeRestrictive
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.PolicyTypePermissive
+  }
+
+opt_policy_command:
+  FOR ALL
+  {
+    $$.val = tree.PolicyCommandAll
+  }
+| FOR SELECT
+  {
+    $$.val = tree.PolicyCommandSelect
+  }
+| FOR INSERT
+  {
+    $$.val = tree.PolicyCommandInsert
+  }
+| FOR UPDATE
+  {
+    $$.val = tree.PolicyCommandUpdate
+  }
+| FOR DELETE
+  {
+    $$.val = tree.PolicyCommandDelete
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.PolicyCommandAll
+  }
+
+opt_policy_roles:
+  TO role_spec_list
+  {
+    $$.val = $2.roleSpecList()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.RoleSpecList(nil)
+  }
+
+opt_policy_using:
+  USING '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+opt_policy_with_check:
+  WITH CHECK '(' a_expr ')'
+  {
+    $$.val = $4.expr()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.Expr(nil)
+  }
+
+// %Help: ALTER POLICY - change the definition of a row-level security policy
+// %Category: DDL
+// %Text:
+// ALTER POLICY name ON table_name RENAME TO new_name
+// ALTER POLICY name ON table_name
+//    [ TO role_name [, ...] ]
+//    [ USING ( using_expression ) ]
+//    [ WITH CHECK ( check_expression ) ]
+// %SeeAlso: CREATE POLICY, DROP POLICY
+alter_policy_stmt:
+  ALTER POLICY name ON table_name RENAME TO name
+  {
+    $$.val = &tree.AlterPolicy{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName().ToTableName(),
+      NewName: tree.Name($8),
+    }
+  }
+| ALTER POLICY name ON table_name opt_policy_roles opt_policy_using opt_policy_with_check
+  {
+    $$.val = &tree.AlterPolicy{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName().ToTableName(),
+      Roles: $6.roleSpecList(),
+      Using: $7.expr(),
+      WithCheck: $8.expr(),
+    }
+  }
+| ALTER POLICY error // SHOW HELP: ALTER POLICY
+
+// %Help: DROP POLICY - remove a row-level security policy
+// %Category: DDL
+// %Text: DROP POLICY [ IF EXISTS ] name ON table_name [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE POLICY, ALTER POLICY
+drop_policy_stmt:
+  DROP POLICY name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropPolicy{
+      Name: tree.Name($3),
+      Table: $5.unresolvedObjectName().ToTableName(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP POLICY IF EXISTS name ON table_name opt_drop_behavior
+  {
+    $$.val = &tree.DropPolicy{
+      Name: tree.Name($5),
+      Table: $7.unresolvedObjectName().ToTableName(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
+  }
+| DROP POLICY error // SHOW HELP: DROP POLICY
+
+// %Help: CREATE PUBLICATION - define a new publication for logical replication
+// %Category: DDL
+// %Text:
+// CREATE PUBLICATION <name>
+//    [ FOR TABLE <tablename> [, ...] | FOR ALL TABLES ]
+//    [ WITH ( publish = '<operation> [, ...]' ) ]
+// %SeeAlso: ALTER PUBLICATION, DROP PUBLICATION
+create_publication_stmt:
+  CREATE PUBLICATION name opt_with_publication_options
+  {
+    $$.val = &tree.CreatePublication{
+      Name: tree.Name($3),
+      Options: $4.kvOptions(),
+    }
+  }
+| CREATE PUBLICATION name FOR TABLE table_name_list opt_with_publication_options
+  {
+    $$.val = &tree.CreatePublication{
+      Name: tree.Name($3),
+      Tables: $6.tableNames(),
+      Options: $7.kvOptions(),
+    }
+  }
+| CREATE PUBLICATION name FOR ALL TABLES opt_with_publication_options
+  {
+    $$.val = &tree.CreatePublication{
+      Name: tree.Name($3),
+      AllTables: true,
+      Options: $7.kvOptions(),
+    }
+  }
+| CREATE PUBLICATION error // SHOW HELP: CREATE PUBLICATION
+
+opt_with_publication_options:
+  WITH '(' kv_option_list ')'
+  {
+    $$.val = $3.kvOptions()
+  }
+| /* EMPTY */
+  {
+    $$.val = nil
+  }
+
+// %Help: ALTER PUBLICATION - change the definition of a publication
+// %Category: DDL
+// %Text:
+// ALTER PUBLICATION <name> { ADD | SET | DROP } TABLE <tablename> [, ...]
+// ALTER PUBLICATION <name> SET ( publish = '<operation> [, ...]' )
+// ALTER PUBLICATION <name> RENAME TO <newname>
+// %SeeAlso: CREATE PUBLICATION, DROP PUBLICATION
+alter_publication_stmt:
+  ALTER PUBLICATION name ADD TABLE table_name_list
+  {
+    $$.val = &tree.AlterPublication{
+      Name: tree.Name($3),
+      Cmd: tree.AlterPublicationAddTables,
+      Tables: $6.tableNames(),
+    }
+  }
+| ALTER PUBLICATION name DROP TABLE table_name_list
+  {
+    $$.val = &tree.AlterPublication{
+      Name: tree.Name($3),
+      Cmd: tree.AlterPublicationDropTables,
+      Tables: $6.tableNames(),
+    }
+  }
+| ALTER PUBLICATION name SET TABLE table_name_list
+  {
+    $$.val = &tree.AlterPublication{
+      Name: tree.Name($3),
+      Cmd: tree.AlterPublicationSetTables,
+      Tables: $6.tableNames(),
+    }
+  }
+| ALTER PUBLICATION name SET '(' kv_option_list ')'
+  {
+    $$.val = &tree.AlterPublication{
+      Name: tree.Name($3),
+      Cmd: tree.AlterPublicationSetOptions,
+      Options: $6.kvOptions(),
+    }
+  }
+| ALTER PUBLICATION name RENAME TO name
+  {
+    $$.val = &tree.AlterPublication{
+      Name: tree.Name($3),
+      Cmd: tree.AlterPublicationRename,
+      NewName: tree.Name($6),
+    }
+  }
+| ALTER PUBLICATION error // SHOW HELP: ALTER PUBLICATION
+
+// %Help: DROP PUBLICATION - remove a publication
+// %Category: DDL
+// %Text: DROP PUBLICATION [ IF EXISTS ] <name> [, ...] [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE PUBLICATION, ALTER PUBLICATION
+drop_publication_stmt:
+  DROP PUBLICATION name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropPublication{
+      Names: $3.nameList(),
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP PUBLICATION IF EXISTS name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropPublication{
+      Names: $5.nameList(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP PUBLICATION error // SHOW HELP: DROP PUBLICATION
+
+// %Help: CREATE TEXT SEARCH CONFIGURATION - define a new text search configuration
+// %Category: DDL
+// %Text:
+// CREATE TEXT SEARCH CONFIGURATION <name> ( PARSER = <parser_name> )
+// CREATE TEXT SEARCH CONFIGURATION <name> ( COPY = <source_config> )
+// %SeeAlso: ALTER TEXT SEARCH CONFIGURATION, DROP TEXT SEARCH CONFIGURATION,
+// CREATE TEXT SEARCH DICTIONARY
+create_text_search_config_stmt:
+  CREATE TEXT SEARCH CONFIGURATION db_object_name '(' text_search_option_list ')'
+  {
+    $$.val = &tree.CreateTextSearchConfig{
+      Name: $5.unresolvedObjectName(),
+      Options: $7.kvOptions(),
+    }
+  }
+| CREATE TEXT SEARCH CONFIGURATION error // SHOW HELP: CREATE TEXT SEARCH CONFIGURATION
+
+// %Help: CREATE TEXT SEARCH DICTIONARY - define a new text search dictionary
+// %Category: DDL
+// %Text:
+// CREATE TEXT SEARCH DICTIONARY <name> (
+//    TEMPLATE = <template>
+//    [, <option> = <value> [, ...]]
+// )
+// %SeeAlso: ALTER TEXT SEARCH DICTIONARY, DROP TEXT SEARCH DICTIONARY,
+// CREATE TEXT SEARCH CONFIGURATION
+create_text_search_dict_stmt:
+  CREATE TEXT SEARCH DICTIONARY db_object_name '(' text_search_option_list ')'
+  {
+    $$.val = &tree.CreateTextSearchDictionary{
+      Name: $5.unresolvedObjectName(),
+      Options: $7.kvOptions(),
+    }
+  }
+| CREATE TEXT SEARCH DICTIONARY error // SHOW HELP: CREATE TEXT SEARCH DICTIONARY
+
+text_search_option_list:
+  text_search_option
+  {
+    $$.val = []tree.KVOption{$1.kvOption()}
+  }
+| text_search_option_list ',' text_search_option
+  {
+    $$.val = append($1.kvOptions(), $3.kvOption())
+  }
+
+text_search_option:
+  name '=' text_search_option_value
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: $3.expr()}
   }
-| SECURITY INVOKER
+
+// The value of a text search option is a name, such as the name of a parser,
+// template or dictionary, or a constant. All values are kept as strings.
+text_search_option_value:
+  db_object_name
   {
-    return unimplemented(sqllex, "create function...security")
+    $$.val = tree.NewStrVal($1.unresolvedObjectName().String())
   }
-| LEAKPROOF
+| SCONST
   {
-    $$.val = tree.FunctionLeakproof(true)
+    $$.val = tree.NewStrVal($1)
   }
-| NOT LEAKPROOF
+| numeric_only
   {
-    $$.val = tree.FunctionLeakproof(false)
+    $$.val = tree.NewStrVal($1.numVal().String())
   }
-| COST numeric_only
+| TRUE
   {
-    return unimplemented(sqllex, "create function...cost")
+    $$.val = tree.NewStrVal("true")
   }
-| ROWS numeric_only
+| FALSE
   {
-    return unimplemented(sqllex, "create function...rows")
+    $$.val = tree.NewStrVal("false")
   }
-| SUPPORT name
+| DEFAULT
   {
-    return unimplemented(sqllex, "create function...support")
+    $$.val = tree.NewStrVal("default")
   }
 
-// In theory we should parse the a whole set/reset statement here. But it's fine
-// to just return fast on SET/RESET keyword for now since it's not supported
-// yet.
-| SET { return unimplemented(sqllex, "create function...set") }
-| PARALLEL { return unimplemented(sqllex, "create function...parallel") }
-
-func_as:
-  SCONST
-
-routine_return_stmt:
-  RETURN a_expr
-{
-  $$.val = &tree.RoutineReturn{
-    ReturnVal: $2.expr(),
+// %Help: ALTER TEXT SEARCH CONFIGURATION - change the definition of a text search configuration
+// %Category: DDL
+// %Text:
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//    ADD MAPPING FOR <token_type> [, ...] WITH <dictionary> [, ...]
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//    ALTER MAPPING FOR <token_type> [, ...] WITH <dictionary> [, ...]
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//    ALTER MAPPING [ FOR <token_type> [, ...] ] REPLACE <old_dictionary> WITH <new_dictionary>
+// ALTER TEXT SEARCH CONFIGURATION <name>
+//    DROP MAPPING [ IF EXISTS ] FOR <token_type> [, ...]
+// %SeeAlso: CREATE TEXT SEARCH CONFIGURATION, DROP TEXT SEARCH CONFIGURATION
+alter_text_search_config_stmt:
+  ALTER TEXT SEARCH CONFIGURATION db_object_name ADD MAPPING FOR name_list WITH db_object_name_list
+  {
+    $$.val = &tree.AlterTextSearchConfig{
+      Name: $5.unresolvedObjectName(),
+      Cmd: tree.AlterTextSearchConfigAddMapping,
+      TokenTypes: $9.nameList(),
+      Dictionaries: $11.tableNames(),
+    }
   }
-}
-
-routine_body_stmt:
-  stmt_without_legacy_transaction
-| routine_return_stmt
-
-routine_body_stmt_list:
-  routine_body_stmt_list routine_body_stmt ';'
+| ALTER TEXT SEARCH CONFIGURATION db_object_name ALTER MAPPING FOR name_list WITH db_object_name_list
   {
-    $$.val = append($1.stmts(), $2.stmt())
+    $$.val = &tree.AlterTextSearchConfig{
+      Name: $5.unresolvedObjectName(),
+      Cmd: tree.AlterTextSearchConfigAlterMapping,
+      TokenTypes: $9.nameList(),
+      Dictionaries: $11.tableNames(),
+    }
   }
-| /* Empty */
+| ALTER TEXT SEARCH CONFIGURATION db_object_name ALTER MAPPING REPLACE db_object_name WITH db_object_name
   {
-    $$.val = tree.Statements{}
+    $$.val = &tree.AlterTextSearchConfig{
+      Name: $5.unresolvedObjectName(),
+      Cmd: tree.AlterTextSearchConfigReplaceDictionary,
+      OldDictionary: $9.unresolvedObjectName(),
+      NewDictionary: $11.unresolvedObjectName(),
+    }
   }
-
-opt_routine_body:
-  routine_return_stmt
+| ALTER TEXT SEARCH CONFIGURATION db_object_name ALTER MAPPING FOR name_list REPLACE db_object_name WITH db_object_name
   {
-    $$.val = &tree.RoutineBody{
-      Stmts: tree.Statements{$1.stmt()},
+    $$.val = &tree.AlterTextSearchConfig{
+      Name: $5.unresolvedObjectName(),
+      Cmd: tree.AlterTextSearchConfigReplaceDictionary,
+      TokenTypes: $9.nameList(),
+      OldDictionary: $11.unresolvedObjectName(),
+      NewDictionary: $13.unresolvedObjectName(),
     }
   }
-| BEGIN ATOMIC routine_body_stmt_list END
+| ALTER TEXT SEARCH CONFIGURATION db_object_name DROP MAPPING FOR name_list
   {
-    $$.val = &tree.RoutineBody{
-        Stmts: $3.stmts(),
+    $$.val = &tree.AlterTextSearchConfig{
+      Name: $5.unresolvedObjectName(),
+      Cmd: tree.AlterTextSearchConfigDropMapping,
+      TokenTypes: $9.nameList(),
     }
   }
-| /* Empty */
+| ALTER TEXT SEARCH CONFIGURATION db_object_name DROP MAPPING IF EXISTS FOR name_list
   {
-    $$.val = (*tree.RoutineBody)(nil)
+    $$.val = &tree.AlterTextSearchConfig{
+      Name: $5.unresolvedObjectName(),
+      Cmd: tree.AlterTextSearchConfigDropMapping,
+      TokenTypes: $11.nameList(),
+      IfExists: true,
+    }
   }
+| ALTER TEXT SEARCH CONFIGURATION error // SHOW HELP: ALTER TEXT SEARCH CONFIGURATION
 
-opt_link_sym:
-  ',' SCONST
+// %Help: ALTER TEXT SEARCH DICTIONARY - change the options of a text search dictionary
+// %Category: DDL
+// %Text: ALTER TEXT SEARCH DICTIONARY <name> ( <option> = <value> [, ...] )
+// %SeeAlso: CREATE TEXT SEARCH DICTIONARY, DROP TEXT SEARCH DICTIONARY
+alter_text_search_dict_stmt:
+  ALTER TEXT SEARCH DICTIONARY db_object_name '(' text_search_option_list ')'
   {
+    $$.val = &tree.AlterTextSearchDictionary{
+      Name: $5.unresolvedObjectName(),
+      Options: $7.kvOptions(),
+    }
   }
-| /* Empty */
+| ALTER TEXT SEARCH DICTIONARY error // SHOW HELP: ALTER TEXT SEARCH DICTIONARY
+
+// %Help: DROP TEXT SEARCH CONFIGURATION - remove a text search configuration
+// %Category: DDL
+// %Text: DROP TEXT SEARCH CONFIGURATION [ IF EXISTS ] <name> [, ...] [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE TEXT SEARCH CONFIGURATION, ALTER TEXT SEARCH CONFIGURATION
+drop_text_search_config_stmt:
+  DROP TEXT SEARCH CONFIGURATION db_object_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTextSearchConfig{
+      Names: $5.tableNames(),
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP TEXT SEARCH CONFIGURATION IF EXISTS db_object_name_list opt_drop_behavior
   {
+    $$.val = &tree.DropTextSearchConfig{
+      Names: $7.tableNames(),
+      IfExists: true,
+      DropBehavior: $8.dropBehavior(),
+    }
   }
+| DROP TEXT SEARCH CONFIGURATION error // SHOW HELP: DROP TEXT SEARCH CONFIGURATION
 
-// %Help: DROP FUNCTION - remove a function
+// %Help: DROP TEXT SEARCH DICTIONARY - remove a text search dictionary
 // %Category: DDL
-// %Text:
-// DROP FUNCTION [ IF EXISTS ] name [ ( [ [ argmode ] [ argname ] argtype [, ...] ] ) ] [, ...]
-//    [ CASCADE | RESTRICT ]
-// %SeeAlso: WEBDOCS/drop-function.html
-drop_func_stmt:
-  DROP FUNCTION function_with_paramtypes_list opt_drop_behavior
+// %Text: DROP TEXT SEARCH DICTIONARY [ IF EXISTS ] <name> [, ...] [ CASCADE | RESTRICT ]
+// %SeeAlso: CREATE TEXT SEARCH DICTIONARY, ALTER TEXT SEARCH DICTIONARY
+drop_text_search_dict_stmt:
+  DROP TEXT SEARCH DICTIONARY db_object_name_list opt_drop_behavior
   {
-    $$.val = &tree.DropFunction{
-      Functions: $3.functionObjs(),
-      DropBehavior: $4.dropBehavior(),
+    $$.val = &tree.DropTextSearchDictionary{
+      Names: $5.tableNames(),
+      DropBehavior: $6.dropBehavior(),
     }
   }
-| DROP FUNCTION IF EXISTS function_with_paramtypes_list opt_drop_behavior
+| DROP TEXT SEARCH DICTIONARY IF EXISTS db_object_name_list opt_drop_behavior
   {
-    $$.val = &tree.DropFunction{
+    $$.val = &tree.DropTextSearchDictionary{
+      Names: $7.tableNames(),
       IfExists: true,
-      Functions: $5.functionObjs(),
-      DropBehavior: $6.dropBehavior(),
+      DropBehavior: $8.dropBehavior(),
     }
   }
-| DROP FUNCTION error // SHOW HELP: DROP FUNCTION
+| DROP TEXT SEARCH DICTIONARY error // SHOW HELP: DROP TEXT SEARCH DICTIONARY
 
 function_with_paramtypes_list:
   function_with_paramtypes
@@ -4931,23 +5854,17 @@ opt_no:
 
 create_unsupported:
   CREATE ACCESS METHOD error { return unimplemented(sqllex, "create access method") }
-| CREATE AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "create aggregate") }
 | CREATE CAST error { return unimplemented(sqllex, "create cast") }
 | CREATE CONSTRAINT TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create constraint") }
 | CREATE CONVERSION error { return unimplemented(sqllex, "create conversion") }
 | CREATE DEFAULT CONVERSION error { return unimplemented(sqllex, "create def conv") }
-| CREATE FOREIGN TABLE error { return unimplemented(sqllex, "create foreign table") }
 | CREATE FOREIGN DATA error { return unimplemented(sqllex, "create fdw") }
 | CREATE opt_or_replace opt_trusted opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "create language " + $6) }
 | CREATE OPERATOR error { return unimplementedWithIssue(sqllex, 65017) }
-| CREATE opt_or_replace PROCEDURE error { return unimplementedWithIssueDetail(sqllex, 17511, "create procedure") }
-| CREATE PUBLICATION error { return unimplemented(sqllex, "create publication") }
 | CREATE opt_or_replace RULE error { return unimplemented(sqllex, "create rule") }
 | CREATE SERVER error { return unimplemented(sqllex, "create server") }
 | CREATE SUBSCRIPTION error { return unimplemented(sqllex, "create subscription") }
 | CREATE TABLESPACE error { return unimplementedWithIssueDetail(sqllex, 54113, "create tablespace") }
-| CREATE TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "create text") }
-| CREATE TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "create trigger") }
 
 opt_trusted:
   TRUSTED {}
@@ -4959,23 +5876,17 @@ opt_procedural:
 
 drop_unsupported:
   DROP ACCESS METHOD error { return unimplemented(sqllex, "drop access method") }
-| DROP AGGREGATE error { return unimplementedWithIssueDetail(sqllex, 74775, "drop aggregate") }
 | DROP CAST error { return unimplemented(sqllex, "drop cast") }
 | DROP COLLATION error { return unimplemented(sqllex, "drop collation") }
 | DROP CONVERSION error { return unimplemented(sqllex, "drop conversion") }
-| DROP DOMAIN error { return unimplementedWithIssueDetail(sqllex, 27796, "drop") }
 | DROP EXTENSION IF EXISTS name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension if exists") }
 | DROP EXTENSION name error { return unimplementedWithIssueDetail(sqllex, 74777, "drop extension") }
-| DROP FOREIGN TABLE error { return unimplemented(sqllex, "drop foreign table") }
 | DROP FOREIGN DATA error { return unimplemented(sqllex, "drop fdw") }
 | DROP opt_procedural LANGUAGE name error { return unimplementedWithIssueDetail(sqllex, 17511, "drop language " + $4) }
 | DROP OPERATOR error { return unimplemented(sqllex, "drop operator") }
-| DROP PUBLICATION error { return unimplemented(sqllex, "drop publication") }
 | DROP RULE error { return unimplemented(sqllex, "drop rule") }
 | DROP SERVER error { return unimplemented(sqllex, "drop server") }
 | DROP SUBSCRIPTION error { return unimplemented(sqllex, "drop subscription") }
-| DROP TEXT error { return unimplementedWithIssueDetail(sqllex, 7821, "drop text") }
-| DROP TRIGGER error { return unimplementedWithIssueDetail(sqllex, 28296, "drop") }
 
 create_ddl_stmt:
   create_database_stmt // EXTEND WITH HELP: CREATE DATABASE
@@ -4986,9 +5897,18 @@ create_ddl_stmt:
 // Error case for both CREATE TABLE and CREATE TABLE ... AS in one
 | CREATE opt_persistence_temp_table TABLE error   // SHOW HELP: CREATE TABLE
 | create_type_stmt     // EXTEND WITH HELP: CREATE TYPE
+| create_domain_stmt   // EXTEND WITH HELP: CREATE DOMAIN
 | create_view_stmt     // EXTEND WITH HELP: CREATE VIEW
 | create_sequence_stmt // EXTEND WITH HELP: CREATE SEQUENCE
 | create_func_stmt     // EXTEND WITH HELP: CREATE FUNCTION
+| create_proc_stmt     // EXTEND WITH HELP: CREATE PROCEDURE
+| create_aggregate_stmt // EXTEND WITH HELP: CREATE AGGREGATE
+| create_trigger_stmt  // EXTEND WITH HELP: CREATE TRIGGER
+| create_policy_stmt   // EXTEND WITH HELP: CREATE POLICY
+| create_publication_stmt // EXTEND WITH HELP: CREATE PUBLICATION
+| create_text_search_config_stmt // EXTEND WITH HELP: CREATE TEXT SEARCH CONFIGURATION
+| create_text_search_dict_stmt // EXTEND WITH HELP: CREATE TEXT SEARCH DICTIONARY
+| create_foreign_table_stmt // EXTEND WITH HELP: CREATE FOREIGN TABLE
 
 // %Help: CREATE STATISTICS - create a new table statistic
 // %Category: Misc
@@ -5338,7 +6258,16 @@ drop_ddl_stmt:
 | drop_sequence_stmt // EXTEND WITH HELP: DROP SEQUENCE
 | drop_schema_stmt   // EXTEND WITH HELP: DROP SCHEMA
 | drop_type_stmt     // EXTEND WITH HELP: DROP TYPE
+| drop_domain_stmt   // EXTEND WITH HELP: DROP DOMAIN
 | drop_func_stmt     // EXTEND WITH HELP: DROP FUNCTION
+| drop_proc_stmt     // EXTEND WITH HELP: DROP PROCEDURE
+| drop_aggregate_stmt // EXTEND WITH HELP: DROP AGGREGATE
+| drop_trigger_stmt  // EXTEND WITH HELP: DROP TRIGGER
+| drop_policy_stmt   // EXTEND WITH HELP: DROP POLICY
+| drop_publication_stmt // EXTEND WITH HELP: DROP PUBLICATION
+| drop_text_search_config_stmt // EXTEND WITH HELP: DROP TEXT SEARCH CONFIGURATION
+| drop_text_search_dict_stmt // EXTEND WITH HELP: DROP TEXT SEARCH DICTIONARY
+| drop_foreign_table_stmt // EXTEND WITH HELP: DROP FOREIGN TABLE
 
 // %Help: DROP VIEW - remove a view
 // %Category: DDL
@@ -5403,6 +6332,21 @@ drop_table_stmt:
   }
 | DROP TABLE error // SHOW HELP: DROP TABLE
 
+// %Help: DROP FOREIGN TABLE - remove a foreign table
+// %Category: DDL
+// %Text: DROP FOREIGN TABLE [IF EXISTS] <tablename> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE FOREIGN TABLE, DROP TABLE
+drop_foreign_table_stmt:
+  DROP FOREIGN TABLE table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTable{Names: $4.tableNames(), IfExists: false, DropBehavior: $5.dropBehavior(), IsForeign: true}
+  }
+| DROP FOREIGN TABLE IF EXISTS table_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropTable{Names: $6.tableNames(), IfExists: true, DropBehavior: $7.dropBehavior(), IsForeign: true}
+  }
+| DROP FOREIGN TABLE error // SHOW HELP: DROP FOREIGN TABLE
+
 // %Help: DROP INDEX - remove an index
 // %Category: DDL
 // %Text: DROP INDEX [CONCURRENTLY] [IF EXISTS] <idxname> [, ...] [CASCADE | RESTRICT]
@@ -5473,6 +6417,29 @@ drop_type_stmt:
   }
 | DROP TYPE error // SHOW HELP: DROP TYPE
 
+// %Help: DROP DOMAIN - remove a domain
+// %Category: DDL
+// %Text: DROP DOMAIN [IF EXISTS] <domain_name> [, ...] [CASCADE | RESTRICT]
+// %SeeAlso: CREATE DOMAIN, DROP TYPE
+drop_domain_stmt:
+  DROP DOMAIN type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $3.unresolvedObjectNames(),
+      IfExists: false,
+      DropBehavior: $4.dropBehavior(),
+    }
+  }
+| DROP DOMAIN IF EXISTS type_name_list opt_drop_behavior
+  {
+    $$.val = &tree.DropDomain{
+      Names: $5.unresolvedObjectNames(),
+      IfExists: true,
+      DropBehavior: $6.dropBehavior(),
+    }
+  }
+| DROP DOMAIN error // SHOW HELP: DROP DOMAIN
+
 // %Help: DROP TENANT - remove a tenant
 // %Category: Experimental
 // %Text: DROP TENANT [IF EXISTS] <tenant_spec> [IMMEDIATE]
@@ -6344,7 +7311,7 @@ use_stmt:
 nonpreparable_set_stmt:
   set_transaction_stmt // EXTEND WITH HELP: SET TRANSACTION
 | set_exprs_internal   { /* SKIP DOC */ }
-| SET CONSTRAINTS error { return unimplemented(sqllex, "set constraints") }
+| set_constraints_stmt // EXTEND WITH HELP: SET CONSTRAINTS
 
 // SET SESSION / SET LOCAL / SET CLUSTER SETTING
 preparable_set_stmt:
@@ -6762,6 +7729,35 @@ set_transaction_stmt:
   }
 | SET SESSION TRANSACTION error // SHOW HELP: SET TRANSACTION
 
+// %Help: SET CONSTRAINTS - set the checking mode of deferrable constraints
+// %Category: Txn
+// %Text:
+// SET CONSTRAINTS { ALL | <name> [, ...] } { DEFERRED | IMMEDIATE }
+//
+// The checking mode applies to the current transaction only.
+//
+// %SeeAlso: SET TRANSACTION
+set_constraints_stmt:
+  SET CONSTRAINTS ALL constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{All: true, Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS name_list constraints_set_mode
+  {
+    $$.val = &tree.SetConstraints{Names: $3.nameList(), Deferred: $4.bool()}
+  }
+| SET CONSTRAINTS error // SHOW HELP: SET CONSTRAINTS
+
+constraints_set_mode:
+  DEFERRED
+  {
+    $$.val = true
+  }
+| IMMEDIATE
+  {
+    $$.val = false
+  }
+
 generic_set:
   var_name to_or_eq var_list
   {
@@ -6886,19 +7882,19 @@ var_list:
 iso_level:
   READ UNCOMMITTED
   {
-    $$.val = tree.SerializableIsolation
+    $$.val = tree.ReadUncommittedIsolation
   }
 | READ COMMITTED
   {
-    $$.val = tree.SerializableIsolation
+    $$.val = tree.ReadCommittedIsolation
   }
 | SNAPSHOT
   {
-    $$.val = tree.SerializableIsolation
+    $$.val = tree.SnapshotIsolation
   }
 | REPEATABLE READ
   {
-    $$.val = tree.SerializableIsolation
+    $$.val = tree.RepeatableReadIsolation
   }
 | SERIALIZABLE
   {
@@ -8302,6 +9298,16 @@ show_create_stmt:
       },
     }
   }
+| SHOW CREATE PROCEDURE db_object_name
+  {
+    /* SKIP DOC */
+    $$.val = &tree.ShowCreateFunction{
+      IsProcedure: true,
+      Name: tree.ResolvableFunctionReference{
+        FunctionReference: $4.unresolvedObjectName().ToUnresolvedName(),
+      },
+    }
+  }
 | SHOW CREATE ALL SCHEMAS
   {
     $$.val = &tree.ShowCreateAllSchemas{}
@@ -8846,6 +9852,10 @@ grant_targets:
   {
     $$.val = tree.GrantTargetList{Functions: $2.functionObjs()}
   }
+| PROCEDURE function_with_paramtypes_list
+  {
+    $$.val = tree.GrantTargetList{Procedures: $2.functionObjs()}
+  }
 
 // backup_targets is similar to grant_targets but used by backup and restore, and thus
 // supports tenants, but does not support sequences, types, or other SQL nouns
@@ -9186,6 +10196,47 @@ create_table_stmt:
     }
   }
 
+// %Help: CREATE FOREIGN TABLE - create a table over a file in external storage
+// %Category: DDL
+// %Text:
+// CREATE FOREIGN TABLE [IF NOT EXISTS] <tablename> ( <colname> <type> [<qualifiers...>] [, ...] )
+//   OPTIONS ( <option> = <value> [, ...] )
+//
+// Column qualifiers:
+//   [CONSTRAINT <constraintname>] {NULL | NOT NULL | NOT VISIBLE | DEFAULT <expr>}
+//   COLLATE <collationname>
+//
+// Options:
+//    location = '<uri>'                     (required)
+//    format = {'csv' | 'avro' | 'parquet'}  (default: 'csv')
+//    decompress = {'auto' | 'none' | 'gzip' | 'bzip' | 'snappy'}
+//    delimiter, comment, nullif, skip       (CSV only)
+//    strict_validation                      (Avro only)
+//
+// %SeeAlso: CREATE TABLE, DROP FOREIGN TABLE, IMPORT, CREATE EXTERNAL CONNECTION
+create_foreign_table_stmt:
+  CREATE FOREIGN TABLE table_name '(' opt_table_elem_list ')' OPTIONS '(' kv_option_list ')'
+  {
+    name := $4.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CreateTable{
+      Table: name,
+      IfNotExists: false,
+      Defs: $6.tblDefs(),
+      ForeignOptions: $10.kvOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE IF NOT EXISTS table_name '(' opt_table_elem_list ')' OPTIONS '(' kv_option_list ')'
+  {
+    name := $7.unresolvedObjectName().ToTableName()
+    $$.val = &tree.CreateTable{
+      Table: name,
+      IfNotExists: true,
+      Defs: $9.tblDefs(),
+      ForeignOptions: $13.kvOptions(),
+    }
+  }
+| CREATE FOREIGN TABLE error // SHOW HELP: CREATE FOREIGN TABLE
+
 opt_locality:
   locality
   {
@@ -9650,7 +10701,7 @@ col_qualification_elem:
   {
     $$.val = &tree.ColumnOnUpdate{Expr: $3.expr()}
   }
-| REFERENCES table_name opt_name_parens key_match reference_actions
+| REFERENCES table_name opt_name_parens key_match reference_actions opt_deferrable
   {
     name := $2.unresolvedObjectName().ToTableName()
     $$.val = &tree.ColumnFKConstraint{
@@ -9658,6 +10709,7 @@ col_qualification_elem:
       Col: tree.Name($3),
       Actions: $5.referenceActions(),
       Match: $4.compositeKeyMatchMethod(),
+      Deferrability: $6.constraintDeferrability(),
     }
   }
 | generated_as '(' a_expr ')' STORED
@@ -9813,6 +10865,9 @@ table_constraint:
 constraint_elem:
   CHECK '(' a_expr ')' opt_deferrable
   {
+    if $5.constraintDeferrability() != tree.ConstraintNotDeferrable {
+      return unimplementedWithIssueDetail(sqllex, 31632, "deferrable")
+    }
     $$.val = &tree.CheckConstraintTableDef{
       Expr: $3.expr(),
     }
@@ -9828,6 +10883,7 @@ constraint_elem:
         PartitionByIndex: $7.partitionByIndex(),
         Predicate: $9.expr(),
       },
+      Deferrability: $8.constraintDeferrability(),
     }
   }
 | PRIMARY KEY '(' index_params ')' opt_hash_sharded opt_with_storage_parameter_list
@@ -9851,11 +10907,44 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrability: $11.constraintDeferrability(),
+    }
+  }
+| EXCLUDE opt_index_access_method '(' exclude_elem_list ')' opt_where_clause
+  {
+    if $6.expr() != nil {
+      return unimplementedWithIssueDetail(sqllex, 46657, "exclude where")
+    }
+    $$.val = &tree.ExclusionConstraintTableDef{
+      Inverted: $2.bool(),
+      Elems: $4.excludeElems(),
     }
   }
-| EXCLUDE USING error
+
+exclude_elem_list:
+  exclude_elem
+  {
+    $$.val = tree.ExcludeElemList{$1.excludeElem()}
+  }
+| exclude_elem_list ',' exclude_elem
+  {
+    $$.val = append($1.excludeElems(), $3.excludeElem())
+  }
+
+exclude_elem:
+  name WITH exclude_op
+  {
+    $$.val = tree.ExcludeElem{Column: tree.Name($1), Operator: $3.exclusionOperator()}
+  }
+
+exclude_op:
+  '='
+  {
+    $$.val = tree.ExcludeEquals
+  }
+| AND_AND
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    $$.val = tree.ExcludeOverlaps
   }
 
 
@@ -9964,12 +11053,30 @@ create_as_col_qualification_elem:
   }
 
 opt_deferrable:
-  /* EMPTY */ { /* no error */ }
-| DEFERRABLE { return unimplementedWithIssueDetail(sqllex, 31632, "deferrable") }
-| DEFERRABLE INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "def initially deferred") }
-| DEFERRABLE INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "def initially immediate") }
-| INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
-| INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
+  DEFERRABLE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintInitiallyImmediate
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintInitiallyDeferred
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.ConstraintNotDeferrable
+  }
 
 storing:
   COVERING
@@ -10523,6 +11630,14 @@ role_option:
   {
     $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
   }
+| BYPASSRLS
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
+  }
+| NOBYPASSRLS
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: nil}
+  }
 | password_clause
 | valid_until_clause
 
@@ -10608,8 +11723,32 @@ create_type_stmt:
 | CREATE TYPE type_name '(' error         { return unimplementedWithIssueDetail(sqllex, 27793, "base") }
   // Shell types, gateway to define base types using the previous syntax.
 | CREATE TYPE type_name                   { return unimplementedWithIssueDetail(sqllex, 27793, "shell") }
-  // Domain types.
-| CREATE DOMAIN type_name error           { return unimplementedWithIssueDetail(sqllex, 27796, "create") }
+
+// %Help: CREATE DOMAIN - create a domain
+// %Category: DDL
+// %Text:
+// CREATE DOMAIN <domain_name> [AS] <base_type>
+//   [DEFAULT <expr>]
+//   [ [CONSTRAINT <constraint_name>] { NOT NULL | NULL | CHECK (<expr>) } ... ]
+// %SeeAlso: DROP DOMAIN, CREATE TYPE
+create_domain_stmt:
+  CREATE DOMAIN type_name AS typename col_qual_list
+  {
+    n, err := tree.NewCreateDomain($3.unresolvedObjectName(), $5.typeReference(), $6.colQuals())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = n
+  }
+| CREATE DOMAIN type_name typename col_qual_list
+  {
+    n, err := tree.NewCreateDomain($3.unresolvedObjectName(), $4.typeReference(), $5.colQuals())
+    if err != nil {
+      return setErr(sqllex, err)
+    }
+    $$.val = n
+  }
+| CREATE DOMAIN error // SHOW HELP: CREATE DOMAIN
 
 opt_enum_val_list:
   enum_val_list
@@ -12719,9 +13858,27 @@ group_by_list:
 // rather than reducing the conflicting unreserved_keyword rule.
 group_by_item:
   a_expr { $$.val = $1.expr() }
-| ROLLUP '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "rollup") }
-| CUBE '(' error { return unimplementedWithIssueDetail(sqllex, 46280, "cube") }
-| GROUPING SETS error { return unimplementedWithIssueDetail(sqllex, 46280, "grouping sets") }
+| rollup_clause { $$.val = $1.expr() }
+| cube_clause { $$.val = $1.expr() }
+| grouping_sets_clause { $$.val = $1.expr() }
+
+rollup_clause:
+  ROLLUP '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Kind: tree.RollupGroupingSet, Exprs: $3.exprs()}
+  }
+
+cube_clause:
+  CUBE '(' expr_list ')'
+  {
+    $$.val = &tree.GroupingSet{Kind: tree.CubeGroupingSet, Exprs: $3.exprs()}
+  }
+
+grouping_sets_clause:
+  GROUPING SETS '(' group_by_list ')'
+  {
+    $$.val = &tree.GroupingSet{Kind: tree.SetsGroupingSet, Exprs: $4.exprs()}
+  }
 
 having_clause:
   HAVING a_expr
@@ -13296,7 +14453,36 @@ relation_expr_list:
     $$.val = append($1.tableNames(), name)
   }
 
-// UNLISTEN
+// %Help: LISTEN - listen for notifications on a channel
+// %Category: Misc
+// %Text: LISTEN <channel>
+// %SeeAlso: NOTIFY, UNLISTEN
+listen_stmt:
+  LISTEN type_name
+  {
+    $$.val = &tree.Listen{ChannelName: $2.unresolvedObjectName()}
+  }
+| LISTEN error // SHOW HELP: LISTEN
+
+// %Help: NOTIFY - send a notification on a channel
+// %Category: Misc
+// %Text: NOTIFY <channel> [ , <payload> ]
+// %SeeAlso: LISTEN, UNLISTEN
+notify_stmt:
+  NOTIFY type_name
+  {
+    $$.val = &tree.Notify{ChannelName: $2.unresolvedObjectName()}
+  }
+| NOTIFY type_name ',' SCONST
+  {
+    $$.val = &tree.Notify{ChannelName: $2.unresolvedObjectName(), Payload: $4}
+  }
+| NOTIFY error // SHOW HELP: NOTIFY
+
+// %Help: UNLISTEN - stop listening for notifications on a channel
+// %Category: Misc
+// %Text: UNLISTEN { <channel> | * }
+// %SeeAlso: LISTEN, NOTIFY
 unlisten_stmt:
    UNLISTEN type_name
     {
@@ -13306,6 +14492,7 @@ unlisten_stmt:
       {
           $$.val = &tree.Unlisten{ ChannelName:nil, Star: true}
       }
+| UNLISTEN error // SHOW HELP: UNLISTEN
 
 
 // Given "UPDATE foo set set ...", we have to decide without looking any
@@ -14189,6 +15376,10 @@ a_expr:
   {
     $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.TSMatches), Left: $1.expr(), Right: $3.expr()}
   }
+| a_expr ADJACENT a_expr
+  {
+    $$.val = &tree.ComparisonExpr{Operator: treecmp.MakeComparisonOperator(treecmp.Adjacent), Left: $1.expr(), Right: $3.expr()}
+  }
 | a_expr INET_CONTAINS_OR_EQUALS a_expr
   {
     $$.val = &tree.FuncExpr{Func: tree.WrapFunction("inet_contains_or_equals"), Exprs: tree.Exprs{$1.expr(), $3.expr()}}
@@ -14743,7 +15934,11 @@ d_expr:
   {
     $$.val = $2.expr()
   }
-| GROUPING '(' expr_list ')' { return unimplemented(sqllex, "d_expr grouping") }
+| GROUPING '(' expr_list ')'
+  {
+    $$.val = &tree.FuncExpr{Func: tree.WrapFunction($1), Exprs: $3.exprs()}
+  }
+| GROUPING '(' error { return helpWithFunctionByName(sqllex, $1) }
 
 func_application:
   func_application_name '(' ')'
@@ -15401,6 +16596,7 @@ all_op:
 | NOT_REGIMATCH { $$.val = treecmp.MakeComparisonOperator(treecmp.NotRegIMatch) }
 | AND_AND { $$.val = treecmp.MakeComparisonOperator(treecmp.Overlaps) }
 | AT_AT { $$.val = treecmp.MakeComparisonOperator(treecmp.TSMatches) }
+| ADJACENT { $$.val = treecmp.MakeComparisonOperator(treecmp.Adjacent) }
 | '~' { $$.val = tree.MakeUnaryOperator(tree.UnaryComplement) }
 | SQRT { $$.val = tree.MakeUnaryOperator(tree.UnarySqrt) }
 | CBRT { $$.val = tree.MakeUnaryOperator(tree.UnaryCbrt) }
@@ -16352,6 +17548,7 @@ unreserved_keyword:
 | BUCKET_COUNT
 | BUNDLE
 | BY
+| BYPASSRLS
 | CACHE
 | CALL
 | CALLED
@@ -16412,10 +17609,14 @@ unreserved_keyword:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DICTIONARY
+| DISABLE
 | DISCARD
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
+| ENABLE
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_PASSPHRASE
@@ -16515,12 +17716,14 @@ unreserved_keyword:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCKED
 | LOGIN
 | LOCALITY
 | LOOKUP
 | LOW
+| MAPPING
 | MATCH
 | MATERIALIZED
 | MAXVALUE
@@ -16558,6 +17761,7 @@ unreserved_keyword:
 | NO_FULL_SCAN
 | NOCREATEDB
 | NOCREATELOGIN
+| NOBYPASSRLS
 | NOCANCELQUERY
 | NOCREATEROLE
 | NOCONTROLCHANGEFEED
@@ -16566,6 +17770,7 @@ unreserved_keyword:
 | NOMODIFYCLUSTERSETTING
 | NONVOTERS
 | NOSQLLOGIN
+| NOTIFY
 | NOVIEWACTIVITY
 | NOVIEWACTIVITYREDACTED
 | NOVIEWCLUSTERSETTING
@@ -16588,12 +17793,14 @@ unreserved_keyword:
 | OWNER
 | PARALLEL
 | PARENT
+| PARSER
 | PARTIAL
 | PARTITION
 | PARTITIONS
 | PASSWORD
 | PAUSE
 | PAUSED
+| PERMISSIVE
 | PHYSICAL
 | PLACEMENT
 | PLAN
@@ -16601,6 +17808,7 @@ unreserved_keyword:
 | POINTM
 | POINTZ
 | POINTZM
+| POLICY
 | POLYGONM
 | POLYGONZ
 | POLYGONZM
@@ -16642,6 +17850,7 @@ unreserved_keyword:
 | RESTORE
 | RESTRICT
 | RESTRICTED
+| RESTRICTIVE
 | RESUME
 | RETENTION
 | RETRY
@@ -16701,6 +17910,7 @@ unreserved_keyword:
 | STABLE
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STDIN
@@ -16822,6 +18032,7 @@ bare_label_keywords:
 | BUCKET_COUNT
 | BUNDLE
 | BY
+| BYPASSRLS
 | CACHE
 | CALL
 | CALLED
@@ -16903,13 +18114,17 @@ bare_label_keywords:
 | DESTINATION
 | DETACHED
 | DETAILS
+| DICTIONARY
+| DISABLE
 | DISCARD
 | DISTINCT
 | DO
 | DOMAIN
 | DOUBLE
 | DROP
+| EACH
 | ELSE
+| ENABLE
 | ENCODING
 | ENCRYPTED
 | ENCRYPTION_INFO_DIR
@@ -17044,6 +18259,7 @@ bare_label_keywords:
 | LINESTRINGZ
 | LINESTRINGZM
 | LIST
+| LISTEN
 | LOCAL
 | LOCALITY
 | LOCALTIME
@@ -17052,6 +18268,7 @@ bare_label_keywords:
 | LOGIN
 | LOOKUP
 | LOW
+| MAPPING
 | MATCH
 | MATERIALIZED
 | MAXVALUE
@@ -17081,6 +18298,7 @@ bare_label_keywords:
 | NEW_KMS
 | NEXT
 | NO
+| NOBYPASSRLS
 | NOCANCELQUERY
 | NOCONTROLCHANGEFEED
 | NOCONTROLJOB
@@ -17096,6 +18314,7 @@ bare_label_keywords:
 | NOT
 | NOTHING
 | NOTHING_AFTER_RETURNING
+| NOTIFY
 | NOVIEWACTIVITY
 | NOVIEWACTIVITYREDACTED
 | NOVIEWCLUSTERSETTING
@@ -17126,12 +18345,14 @@ bare_label_keywords:
 | OWNER
 | PARALLEL
 | PARENT
+| PARSER
 | PARTIAL
 | PARTITION
 | PARTITIONS
 | PASSWORD
 | PAUSE
 | PAUSED
+| PERMISSIVE
 | PHYSICAL
 | PLACEMENT
 | PLACING
@@ -17141,6 +18362,7 @@ bare_label_keywords:
 | POINTM
 | POINTZ
 | POINTZM
+| POLICY
 | POLYGON
 | POLYGONM
 | POLYGONZ
@@ -17187,6 +18409,7 @@ bare_label_keywords:
 | RESTORE
 | RESTRICT
 | RESTRICTED
+| RESTRICTIVE
 | RESUME
 | RETENTION
 | RETRY
@@ -17252,6 +18475,7 @@ bare_label_keywords:
 | STABLE
 | START
 | STATE
+| STATEMENT
 | STATEMENTS
 | STATISTICS
 | STATUS
@@ -58,6 +58,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/lsn"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
@@ -573,6 +574,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-authid.html`,
 			if err != nil {
 				return err
 			}
+			bypassRLS, err := options.bypassRLS()
+			if err != nil {
+				return err
+			}
 
 			isSuper, err := userIsSuper(ctx, p, userName)
 			if err != nil {
@@ -588,7 +593,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-authid.html`,
 				tree.MakeDBool(isRoot || createDB),   // rolcreatedb
 				tree.MakeDBool(roleCanLogin),         // rolcanlogin.
 				tree.DBoolFalse,                      // rolreplication
-				tree.DBoolFalse,                      // rolbypassrls
+				tree.MakeDBool(bypassRLS),            // rolbypassrls
 				negOneVal,                            // rolconnlimit
 				passwdStarString,                     // rolpassword
 				rolValidUntil,                        // rolvaliduntil
@@ -870,6 +875,18 @@ var (
 	}
 )
 
+// constraintDeferrability returns the deferrability of the given constraint.
+// Only foreign keys and unique constraints without an index can be deferred.
+func constraintDeferrability(c catalog.Constraint) semenumpb.Deferrability {
+	if fk := c.AsForeignKey(); fk != nil {
+		return fk.Deferrability()
+	}
+	if uwoi := c.AsUniqueWithoutIndex(); uwoi != nil {
+		return uwoi.Deferrability()
+	}
+	return semenumpb.Deferrability_NOT_DEFERRABLE
+}
+
 func populateTableConstraints(
 	ctx context.Context,
 	p *planner,
@@ -895,6 +912,9 @@ func populateTableConstraints(
 		consrc := tree.DNull
 		conbin := tree.DNull
 		condef := tree.DNull
+		deferrability := constraintDeferrability(c)
+		condeferrable := tree.MakeDBool(deferrability != semenumpb.Deferrability_NOT_DEFERRABLE)
+		condeferred := tree.MakeDBool(deferrability == semenumpb.Deferrability_INITIALLY_DEFERRED)
 
 		// Determine constraint kind-specific fields.
 		var err error
@@ -995,6 +1015,7 @@ func populateTableConstraints(
 			}
 			f.WriteString(strings.Join(colNames, ", "))
 			f.WriteByte(')')
+			formatConstraintDeferrability(&f.Buffer, uwoi.Deferrability())
 			if !uwoi.IsConstraintValidated() {
 				f.WriteString(" NOT VALID")
 			}
@@ -1030,8 +1051,8 @@ func populateTableConstraints(
 			dNameOrNull(c.GetName()), // conname
 			namespaceOid,             // connamespace
 			contype,                  // contype
-			tree.DBoolFalse,          // condeferrable
-			tree.DBoolFalse,          // condeferred
+			condeferrable,            // condeferrable
+			condeferred,              // condeferred
 			tree.MakeDBool(tree.DBool(!c.IsConstraintUnvalidated())), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -2571,16 +2592,33 @@ func addPgProcUDFRow(
 ) error {
 	isStrict := fnDesc.GetNullInputBehavior() != catpb.Function_CALLED_ON_NULL_INPUT
 	argTypes := tree.NewDArray(types.Oid)
+	allArgTypes := tree.NewDArray(types.Oid)
 	argModes := tree.NewDArray(types.String)
 	var argNames tree.Datum
 	argNamesArray := tree.NewDArray(types.String)
 	foundAnyArgNames := false
+	foundAnyOutArgs := false
 	for _, param := range fnDesc.GetParams() {
-		if err := argTypes.Append(tree.NewDOid(param.Type.Oid())); err != nil {
+		if funcdesc.IsInParam(param) {
+			if err := argTypes.Append(tree.NewDOid(param.Type.Oid())); err != nil {
+				return err
+			}
+		}
+		if err := allArgTypes.Append(tree.NewDOid(param.Type.Oid())); err != nil {
 			return err
 		}
-		// We only support IN arguments at the moment.
-		if err := argModes.Append(tree.NewDString("i")); err != nil {
+		argMode := "i"
+		switch param.Class {
+		case catpb.Function_Param_OUT:
+			argMode = "o"
+			foundAnyOutArgs = true
+		case catpb.Function_Param_IN_OUT:
+			argMode = "b"
+			foundAnyOutArgs = true
+		case catpb.Function_Param_VARIADIC:
+			argMode = "v"
+		}
+		if err := argModes.Append(tree.NewDString(argMode)); err != nil {
 			return err
 		}
 		if len(param.Name) > 0 {
@@ -2594,6 +2632,20 @@ func addPgProcUDFRow(
 	if foundAnyArgNames {
 		argNames = argNamesArray
 	}
+	// Like Postgres, proallargtypes is only set when the function has OUT or
+	// INOUT parameters.
+	var allArgTypesDatum tree.Datum = tree.DNull
+	if foundAnyOutArgs {
+		allArgTypesDatum = allArgTypes
+	}
+	kind := tree.NewDString("f")
+	if fnDesc.GetIsProcedure() {
+		kind = tree.NewDString("p")
+	}
+	isAggregate := fnDesc.GetAggregate() != nil
+	if isAggregate {
+		kind = tree.NewDString("a")
+	}
 
 	return addRow(
 		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())), // oid
@@ -2602,33 +2654,33 @@ func addPgProcUDFRow(
 		h.UserOid(fnDesc.GetPrivileges().Owner()),       // proowner
 		// In postgres oid of sql language is 14, need to add a mapping if
 		// we are going to support more languages.
-		tree.NewDOid(14), // prolang
-		tree.DNull,       // procost
-		tree.DNull,       // prorows
-		oidZero,          // provariadic
-		tree.DNull,       // protransform
-		tree.DBoolFalse,  // proisagg
-		tree.DBoolFalse,  // proiswindow
-		tree.DBoolFalse,  // prosecdef
+		tree.NewDOid(14),                        // prolang
+		tree.DNull,                              // procost
+		tree.DNull,                              // prorows
+		oidZero,                                 // provariadic
+		tree.DNull,                              // protransform
+		tree.MakeDBool(tree.DBool(isAggregate)), // proisagg
+		tree.DBoolFalse,                         // proiswindow
+		tree.DBoolFalse,                         // prosecdef
 		tree.MakeDBool(tree.DBool(fnDesc.GetLeakProof())),            // proleakproof
 		tree.MakeDBool(tree.DBool(isStrict)),                         // proisstrict
 		tree.MakeDBool(tree.DBool(fnDesc.GetReturnType().ReturnSet)), // proretset
 		tree.NewDString(funcVolatility(fnDesc.GetVolatility())),      // provolatile
-		tree.DNull, // proparallel
-		tree.NewDInt(tree.DInt(len(fnDesc.GetParams()))), // pronargs
-		tree.NewDInt(tree.DInt(0)),                       // pronargdefaults
-		tree.NewDOid(fnDesc.GetReturnType().Type.Oid()),  // prorettype
-		tree.NewDOidVectorFromDArray(argTypes),           // proargtypes
-		tree.DNull,                                       // proallargtypes
-		argModes,                                         // proargmodes
-		argNames,                                         // proargnames
-		tree.DNull,                                       // proargdefaults
-		tree.DNull,                                       // protrftypes
-		tree.NewDString(fnDesc.GetFunctionBody()),        // prosrc
-		tree.DNull,                                       // probin
-		tree.DNull,                                       // proconfig
-		tree.DNull,                                       // proacl
-		tree.NewDString("f"),                             // prokind
+		tree.DNull,                                      // proparallel
+		tree.NewDInt(tree.DInt(argTypes.Len())),         // pronargs
+		tree.NewDInt(tree.DInt(0)),                      // pronargdefaults
+		tree.NewDOid(fnDesc.GetReturnType().Type.Oid()), // prorettype
+		tree.NewDOidVectorFromDArray(argTypes),          // proargtypes
+		allArgTypesDatum,                                // proallargtypes
+		argModes,                                        // proargmodes
+		argNames,                                        // proargnames
+		tree.DNull,                                      // proargdefaults
+		tree.DNull,                                      // protrftypes
+		tree.NewDString(fnDesc.GetFunctionBody()),       // prosrc
+		tree.DNull,                                      // probin
+		tree.DNull,                                      // proconfig
+		tree.DNull,                                      // proacl
+		kind,                                            // prokind
 		// These columns were automatically created by pg_catalog_test's missing column generator.
 		tree.DNull, // prosupport
 	)
@@ -2736,16 +2788,24 @@ https://www.postgresql.org/docs/9.5/catalog-pg-proc.html`,
 }
 
 var pgCatalogRangeTable = virtualSchemaTable{
-	comment: `range types (empty - feature does not exist)
+	comment: `range types
 https://www.postgresql.org/docs/9.5/catalog-pg-range.html`,
 	schema: vtable.PGCatalogRange,
 	populate: func(_ context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// We currently do not support any range types, so this table is empty.
-		// This table should be populated when any range types are added to
-		// oidToDatum (and therefore pg_type).
+		for _, typ := range types.RangeTypes {
+			if err := addRow(
+				tree.NewDOid(typ.Oid()),                 // rngtypid
+				tree.NewDOid(typ.RangeContents().Oid()), // rngsubtype
+				oidZero,                                 // rngcollation
+				oidZero,                                 // rngsubopc
+				oidZero,                                 // rngcanonical
+				oidZero,                                 // rngsubdiff
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogRewriteTable = virtualSchemaTable{
@@ -2817,6 +2877,10 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 				if err != nil {
 					return err
 				}
+				bypassRLS, err := options.bypassRLS()
+				if err != nil {
+					return err
+				}
 				isSuper, err := userIsSuper(ctx, p, userName)
 				if err != nil {
 					return err
@@ -2835,7 +2899,7 @@ https://www.postgresql.org/docs/9.5/view-pg-roles.html`,
 					negOneVal,                             // rolconnlimit
 					passwdStarString,                      // rolpassword
 					rolValidUntil,                         // rolvaliduntil
-					tree.DBoolFalse,                       // rolbypassrls
+					tree.MakeDBool(bypassRLS),             // rolbypassrls
 					settings,                              // rolconfig
 				)
 			})
@@ -3138,17 +3202,16 @@ https://www.postgresql.org/docs/9.5/catalog-pg-trigger.html`,
 }
 
 var (
-	typTypeBase      = tree.NewDString("b")
-	typTypeComposite = tree.NewDString("c")
-	typTypeDomain    = tree.NewDString("d")
-	typTypeEnum      = tree.NewDString("e")
-	typTypePseudo    = tree.NewDString("p")
-	typTypeRange     = tree.NewDString("r")
+	typTypeBase       = tree.NewDString("b")
+	typTypeComposite  = tree.NewDString("c")
+	typTypeDomain     = tree.NewDString("d")
+	typTypeEnum       = tree.NewDString("e")
+	typTypePseudo     = tree.NewDString("p")
+	typTypeRange      = tree.NewDString("r")
+	typTypeMultirange = tree.NewDString("m")
 
 	// Avoid unused warning for constants.
-	_ = typTypeDomain
 	_ = typTypePseudo
-	_ = typTypeRange
 
 	// See https://www.postgresql.org/docs/9.6/static/catalog-pg-type.html#CATALOG-TYPCATEGORY-TABLE.
 	typCategoryArray       = tree.NewDString("A")
@@ -3170,7 +3233,6 @@ var (
 	// Avoid unused warning for constants.
 	_ = typCategoryEnum
 	_ = typCategoryGeometric
-	_ = typCategoryRange
 	_ = typCategoryBitString
 
 	commaTypDelim = tree.NewDString(",")
@@ -3234,6 +3296,9 @@ func addPGTypeRowForTable(
 func addPGTypeRow(
 	h oidHasher, nspOid tree.Datum, owner tree.Datum, typ *types.T, addRow func(...tree.Datum) error,
 ) error {
+	if typ.DomainOID() != 0 {
+		return addPGTypeRowForDomain(h, nspOid, owner, typ, addRow)
+	}
 	cat := typCategory(typ)
 	typType := typTypeBase
 	typElem := oidZero
@@ -3266,8 +3331,14 @@ func addPGTypeRow(
 		builtinPrefix = "record_"
 		typType = typTypeComposite
 		typArray = tree.NewDOid(types.CalcArrayOid(typ))
-	case types.VoidFamily:
-		// void does not have an array type.
+	case types.RangeFamily:
+		typType = typTypeRange
+		typArray = tree.NewDOid(types.CalcArrayOid(typ))
+	case types.MultirangeFamily:
+		typType = typTypeMultirange
+		typArray = tree.NewDOid(types.CalcArrayOid(typ))
+	case types.VoidFamily, types.TriggerFamily:
+		// void and trigger do not have an array type.
 	default:
 		typArray = tree.NewDOid(types.CalcArrayOid(typ))
 	}
@@ -3314,6 +3385,59 @@ func addPGTypeRow(
 	)
 }
 
+func addPGTypeRowForDomain(
+	h oidHasher, nspOid tree.Datum, owner tree.Datum, typ *types.T, addRow func(...tree.Datum) error,
+) error {
+	base := typ.DomainBaseType()
+	builtinPrefix := builtins.PGIOBuiltinPrefix(base)
+	typNotNull := tree.DBoolFalse
+	typDefault := tree.DNull
+	if d := typ.TypeMeta.DomainData; d != nil {
+		typNotNull = tree.MakeDBool(tree.DBool(d.NotNull))
+		if d.DefaultExpr != nil {
+			typDefault = tree.NewDString(*d.DefaultExpr)
+		}
+	}
+	typname := typ.TypeMeta.Name.Basename()
+	return addRow(
+		tree.NewDOid(typ.DomainOID()),     // oid
+		tree.NewDName(typname),            // typname
+		nspOid,                            // typnamespace
+		owner,                             // typowner
+		typLen(base),                      // typlen
+		typByVal(base),                    // typbyval (is it fixedlen or not)
+		typTypeDomain,                     // typtype
+		typCategory(base),                 // typcategory
+		tree.DBoolFalse,                   // typispreferred
+		tree.DBoolTrue,                    // typisdefined
+		tree.NewDString(base.Delimiter()), // typdelim
+		oidZero,                           // typrelid
+		oidZero,                           // typelem
+		// Domains do not have an array type.
+		oidZero, // typarray
+
+		// regproc references
+		h.RegProc(builtinPrefix+"in"),   // typinput
+		h.RegProc(builtinPrefix+"out"),  // typoutput
+		h.RegProc(builtinPrefix+"recv"), // typreceive
+		h.RegProc(builtinPrefix+"send"), // typsend
+		oidZero,                         // typmodin
+		oidZero,                         // typmodout
+		oidZero,                         // typanalyze
+
+		tree.DNull,               // typalign
+		tree.DNull,               // typstorage
+		typNotNull,               // typnotnull
+		tree.NewDOid(base.Oid()), // typbasetype
+		negOneVal,                // typtypmod
+		zeroVal,                  // typndims
+		typColl(base, h),         // typcollation
+		tree.DNull,               // typdefaultbin
+		typDefault,               // typdefault
+		tree.DNull,               // typacl
+	)
+}
+
 func getSchemaAndTypeByTypeID(
 	ctx context.Context, p *planner, id descpb.ID,
 ) (catalog.SchemaDescriptor, catalog.TypeDescriptor, error) {
@@ -3972,12 +4096,32 @@ var pgCatalogStatUserFunctionsTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTable = virtualSchemaTable{
-	comment: "pg_publication was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublication,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `publications for logical replication
+https://www.postgresql.org/docs/16/catalog-pg-publication.html`,
+	schema: vtable.PgCatalogPublication,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				for i := range db.GetPublications() {
+					publication := &db.GetPublications()[i]
+					if err := addRow(
+						h.PublicationOid(db.GetID(), publication.ID),          // oid
+						tree.NewDName(publication.Name),                       // pubname
+						h.UserOid(publication.OwnerProto.Decode()),            // pubowner
+						tree.MakeDBool(tree.DBool(publication.AllTables)),     // puballtables
+						tree.MakeDBool(tree.DBool(publication.PublishInsert)), // pubinsert
+						tree.MakeDBool(tree.DBool(publication.PublishUpdate)), // pubupdate
+						tree.MakeDBool(tree.DBool(publication.PublishDelete)), // pubdelete
+						tree.DBoolFalse, // pubtruncate
+						tree.DBoolFalse, // pubviaroot
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogAmprocTable = virtualSchemaTable{
@@ -4027,12 +4171,12 @@ https://www.postgresql.org/docs/14/view-pg-cursors.html`,
 				return err
 			}
 			if err := addRow(
-				tree.NewDString(string(name)), /* name */
-				tree.NewDString(c.statement),  /* statement */
-				tree.DBoolFalse,               /* is_holdable */
-				tree.DBoolFalse,               /* is_binary */
-				tree.DBoolFalse,               /* is_scrollable */
-				tz,                            /* creation_date */
+				tree.NewDString(string(name)),          /* name */
+				tree.NewDString(c.statement),           /* statement */
+				tree.MakeDBool(tree.DBool(c.withHold)), /* is_holdable */
+				tree.DBoolFalse,                        /* is_binary */
+				tree.DBoolFalse,                        /* is_scrollable */
+				tz,                                     /* creation_date */
 			); err != nil {
 				return err
 			}
@@ -4132,12 +4276,32 @@ var pgCatalogStatXactUserTablesTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationTablesTable = virtualSchemaTable{
-	comment: "pg_publication_tables was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationTables,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables published by publications, including FOR ALL TABLES publications
+https://www.postgresql.org/docs/16/view-pg-publication-tables.html`,
+	schema: vtable.PgCatalogPublicationTables,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual,
+			func(db catalog.DatabaseDescriptor, sc catalog.SchemaDescriptor, table catalog.TableDescriptor) error {
+				for i := range db.GetPublications() {
+					publication := &db.GetPublications()[i]
+					published := publicationHasTable(publication, table.GetID())
+					if publication.AllTables {
+						published = table.IsTable() && !table.IsTemporary()
+					}
+					if !published {
+						continue
+					}
+					if err := addRow(
+						tree.NewDName(publication.Name), // pubname
+						tree.NewDName(sc.GetName()),     // schemaname
+						tree.NewDName(table.GetName()),  // tablename
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogStatProgressClusterTable = virtualSchemaTable{
@@ -4168,12 +4332,56 @@ var pgCatalogLargeobjectMetadataTable = virtualSchemaTable{
 }
 
 var pgCatalogReplicationSlotsTable = virtualSchemaTable{
-	comment: "pg_replication_slots was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogReplicationSlots,
+	comment: `logical replication slots
+https://www.postgresql.org/docs/16/view-pg-replication-slots.html`,
+	schema: vtable.PgCatalogReplicationSlots,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		dbNames := make(map[descpb.ID]string)
+		if err := forEachDatabaseDesc(ctx, p, nil /* all databases */, false, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				dbNames[db.GetID()] = db.GetName()
+				return nil
+			}); err != nil {
+			return err
+		}
+		rows, err := p.InternalSQLTxn().QueryBufferedEx(
+			ctx,
+			"select-replication-slots",
+			p.Txn(),
+			sessiondata.NodeUserSessionDataOverride,
+			`SELECT slot_name, plugin, database_id, confirmed_flush_lsn FROM system.replication_slots`,
+		)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			dbID := descpb.ID(tree.MustBeDInt(row[2]))
+			dbName := tree.DNull
+			if name, ok := dbNames[dbID]; ok {
+				dbName = tree.NewDName(name)
+			}
+			confirmed := tree.NewDString(lsn.LSN(tree.MustBeDInt(row[3])).String())
+			if err := addRow(
+				tree.NewDName(string(tree.MustBeDString(row[0]))), // slot_name
+				tree.NewDName(string(tree.MustBeDString(row[1]))), // plugin
+				tree.NewDString("logical"),                        // slot_type
+				dbOid(dbID),                                       // datoid
+				dbName,                                            // database
+				tree.DBoolFalse,                                   // temporary
+				tree.DBoolFalse,                                   // active
+				tree.DNull,                                        // active_pid
+				tree.DNull,                                        // xmin
+				tree.DNull,                                        // catalog_xmin
+				confirmed,                                         // restart_lsn
+				confirmed,                                         // confirmed_flush_lsn
+				tree.NewDString("reserved"),                       // wal_status
+				tree.DNull,                                        // safe_wal_size
+			); err != nil {
+				return err
+			}
+		}
 		return nil
 	},
-	unimplemented: true,
 }
 
 var pgCatalogSubscriptionRelTable = virtualSchemaTable{
@@ -4444,12 +4652,29 @@ var pgCatalogStatReplicationTable = virtualSchemaTable{
 }
 
 var pgCatalogPublicationRelTable = virtualSchemaTable{
-	comment: "pg_publication_rel was created for compatibility and is currently unimplemented",
-	schema:  vtable.PgCatalogPublicationRel,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+	comment: `tables explicitly added to publications
+https://www.postgresql.org/docs/16/catalog-pg-publication-rel.html`,
+	schema: vtable.PgCatalogPublicationRel,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		h := makeOidHasher()
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				for i := range db.GetPublications() {
+					publication := &db.GetPublications()[i]
+					pubOid := h.PublicationOid(db.GetID(), publication.ID)
+					for _, tableID := range publication.TableIDs {
+						if err := addRow(
+							h.PublicationRelOid(db.GetID(), publication.ID, tableID), // oid
+							pubOid,            // prpubid
+							tableOid(tableID), // prrelid
+						); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var pgCatalogAvailableExtensionVersionsTable = virtualSchemaTable{
@@ -4465,6 +4690,9 @@ var pgCatalogAvailableExtensionVersionsTable = virtualSchemaTable{
 // object identifiers for types are not arbitrary, but instead need to be kept in
 // sync with Postgres.
 func typOid(typ *types.T) tree.Datum {
+	if typ.DomainOID() != 0 {
+		return tree.NewDOid(typ.DomainOID())
+	}
 	return tree.NewDOid(typ.Oid())
 }
 
@@ -4520,12 +4748,15 @@ var datumToTypeCategory = map[types.Family]*tree.DString{
 	types.TSQueryFamily:     typCategoryUserDefined,
 	types.TSVectorFamily:    typCategoryUserDefined,
 	types.ArrayFamily:       typCategoryArray,
+	types.RangeFamily:       typCategoryRange,
+	types.MultirangeFamily:  typCategoryRange,
 	types.TupleFamily:       typCategoryPseudo,
 	types.OidFamily:         typCategoryNumeric,
 	types.UuidFamily:        typCategoryUserDefined,
 	types.INetFamily:        typCategoryNetworkAddr,
 	types.UnknownFamily:     typCategoryUnknown,
 	types.VoidFamily:        typCategoryPseudo,
+	types.TriggerFamily:     typCategoryPseudo,
 }
 
 func typCategory(typ *types.T) tree.Datum {
@@ -4646,11 +4877,84 @@ https://www.postgresql.org/docs/9.6/catalog-pg-aggregate.html`,
 						}
 					}
 				}
-				return nil
+				return forEachSchema(ctx, p, db, true /* requiresPrivileges */, func(scDesc catalog.SchemaDescriptor) error {
+					return scDesc.ForEachFunctionSignature(func(sig descpb.SchemaDescriptor_FunctionSignature) error {
+						if !sig.IsAggregate {
+							return nil
+						}
+						fnDesc, err := p.Descriptors().ByID(p.Txn()).WithoutNonPublic().Get().Function(ctx, sig.ID)
+						if err != nil {
+							return err
+						}
+						return addPgAggregateUDFRow(ctx, p, fnDesc, addRow)
+					})
+				})
 			})
 	},
 }
 
+// addPgAggregateUDFRow adds the pg_aggregate row of a user-defined aggregate.
+func addPgAggregateUDFRow(
+	ctx context.Context,
+	p *planner,
+	fnDesc catalog.FunctionDescriptor,
+	addRow func(...tree.Datum) error,
+) error {
+	agg := fnDesc.GetAggregate()
+	regprocForZeroOid := tree.NewDOidWithName(0, types.RegProc, "-")
+	regproc := func(id descpb.ID) (tree.Datum, error) {
+		if id == descpb.InvalidID {
+			return regprocForZeroOid, nil
+		}
+		supportFn, err := p.Descriptors().ByID(p.Txn()).WithoutNonPublic().Get().Function(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		return tree.NewDOid(catid.FuncIDToOID(id)).AsRegProc(supportFn.GetName()), nil
+	}
+	transFn, err := regproc(agg.TransitionFunctionID)
+	if err != nil {
+		return err
+	}
+	finalFn, err := regproc(agg.FinalFunctionID)
+	if err != nil {
+		return err
+	}
+	combineFn, err := regproc(agg.CombineFunctionID)
+	if err != nil {
+		return err
+	}
+	var initVal tree.Datum = tree.DNull
+	if agg.InitCond != nil {
+		initVal = tree.NewDString(*agg.InitCond)
+	}
+	return addRow(
+		tree.NewDOid(catid.FuncIDToOID(fnDesc.GetID())).AsRegProc(fnDesc.GetName()), // aggfnoid
+		tree.NewDString("n"),              // aggkind
+		zeroVal,                           // aggnumdirectargs
+		transFn,                           // aggtransfn
+		finalFn,                           // aggfinalfn
+		combineFn,                         // aggcombinefn
+		regprocForZeroOid,                 // aggserialfn
+		regprocForZeroOid,                 // aggdeserialfn
+		regprocForZeroOid,                 // aggmtransfn
+		regprocForZeroOid,                 // aggminvtransfn
+		regprocForZeroOid,                 // aggmfinalfn
+		tree.DBoolFalse,                   // aggfinalextra
+		tree.DBoolFalse,                   // aggmfinalextra
+		oidZero,                           // aggsortop
+		tree.NewDOid(agg.StateType.Oid()), // aggtranstype
+		tree.DNull,                        // aggtransspace
+		tree.DNull,                        // aggmtranstype
+		tree.DNull,                        // aggmtransspace
+		initVal,                           // agginitval
+		tree.DNull,                        // aggminitval
+		// These columns were automatically created by pg_catalog_test's missing column generator.
+		tree.DNull, // aggfinalmodify
+		tree.DNull, // aggmfinalmodify
+	)
+}
+
 // oidHasher provides a consistent hashing mechanism for object identifiers in
 // pg_catalog tables, allowing for reliable joins across tables.
 //
@@ -4739,6 +5043,8 @@ const (
 	rewriteTypeTag
 	dbSchemaRoleTypeTag
 	castTypeTag
+	publicationTypeTag
+	publicationRelTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -4938,6 +5244,23 @@ func (h oidHasher) CastOid(srcID oid.Oid, tgtID oid.Oid) *tree.DOid {
 	return h.getOid()
 }
 
+func (h oidHasher) PublicationOid(dbID descpb.ID, publicationID descpb.PublicationID) *tree.DOid {
+	h.writeTypeTag(publicationTypeTag)
+	h.writeDB(dbID)
+	h.writeUInt32(uint32(publicationID))
+	return h.getOid()
+}
+
+func (h oidHasher) PublicationRelOid(
+	dbID descpb.ID, publicationID descpb.PublicationID, tableID descpb.ID,
+) *tree.DOid {
+	h.writeTypeTag(publicationRelTypeTag)
+	h.writeDB(dbID)
+	h.writeUInt32(uint32(publicationID))
+	h.writeTable(tableID)
+	return h.getOid()
+}
+
 func funcVolatility(v catpb.Function_Volatility) string {
 	switch v {
 	case catpb.Function_IMMUTABLE:
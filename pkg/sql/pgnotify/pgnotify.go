@@ -0,0 +1,419 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pgnotify implements the delivery of the notifications published
+// with NOTIFY to the sessions that LISTEN on their channel.
+//
+// A notification is written to system.notifications in the transaction that
+// executes NOTIFY. Every node that has at least one listening session runs a
+// rangefeed over the table, and since rangefeeds only emit committed values,
+// a notification is delivered if and only if its transaction commits. The rows
+// are deleted by a single node once they are older than notificationTTL.
+//
+// Unlike Postgres, notifications published by different transactions are not
+// guaranteed to be delivered in commit order, and a notification can, in rare
+// cases (e.g. when the rangefeed is restarted), be delivered more than once.
+package pgnotify
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/rangefeed"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/systemschema"
+	"github.com/cockroachdb/cockroach/pkg/sql/isql"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sqlinstance"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+	"github.com/cockroachdb/errors"
+)
+
+const (
+	// MaxPayloadLength is the maximum length of a notification payload. It
+	// matches the limit imposed by Postgres.
+	MaxPayloadLength = 8000
+
+	// notificationTTL is how long a notification is kept in
+	// system.notifications. The rangefeeds deliver notifications as soon as
+	// they are committed, so the rows only need to outlive the rangefeed catch
+	// up scans. Expired notifications are deleted every notificationTTL.
+	notificationTTL = time.Minute
+
+	// maxPendingNotifications is the maximum number of notifications that are
+	// queued for a Listener that hasn't consumed them. Older notifications are
+	// dropped once the limit is reached.
+	maxPendingNotifications = 1 << 14
+)
+
+// Notification is a payload published on a channel with NOTIFY.
+type Notification struct {
+	Channel string
+	Payload string
+	// PID is the backend process ID of the session that published the
+	// notification.
+	PID int32
+}
+
+// Registry delivers the notifications published in the cluster to the
+// Listeners registered on this node.
+type Registry struct {
+	ambientCtx log.AmbientContext
+	codec      keys.SQLCodec
+	clock      *hlc.Clock
+	stopper    *stop.Stopper
+	db         isql.DB
+	resolver   catalog.SystemTableIDResolver
+	factory    *rangefeed.Factory
+	decoder    valueside.Decoder
+
+	// isMeta1Leaseholder, instanceID and instances are used to pick the single
+	// node that deletes the expired notifications.
+	isMeta1Leaseholder func(context.Context, hlc.ClockTimestamp) (bool, error)
+	instanceID         *base.SQLIDContainer
+	instances          sqlinstance.AddressResolver
+
+	// startMu serializes the lazy start of the rangefeed.
+	startMu struct {
+		syncutil.Mutex
+		// feed is the rangefeed over system.notifications, or nil if it hasn't
+		// been started yet. It is closed when the stopper quiesces.
+		feed *rangefeed.RangeFeed
+	}
+
+	mu struct {
+		syncutil.Mutex
+		// listeners contains the Listeners that listen on at least one channel.
+		listeners map[*Listener]struct{}
+	}
+}
+
+// NewRegistry constructs a new Registry. The rangefeed over
+// system.notifications is only started once a session starts listening.
+func NewRegistry(
+	ambientCtx log.AmbientContext,
+	codec keys.SQLCodec,
+	clock *hlc.Clock,
+	stopper *stop.Stopper,
+	db isql.DB,
+	resolver catalog.SystemTableIDResolver,
+	factory *rangefeed.Factory,
+	isMeta1Leaseholder func(context.Context, hlc.ClockTimestamp) (bool, error),
+	instanceID *base.SQLIDContainer,
+	instances sqlinstance.AddressResolver,
+) *Registry {
+	r := &Registry{
+		ambientCtx:         ambientCtx,
+		codec:              codec,
+		clock:              clock,
+		stopper:            stopper,
+		db:                 db,
+		resolver:           resolver,
+		factory:            factory,
+		decoder:            valueside.MakeDecoder(systemschema.NotificationsTable.PublicColumns()),
+		isMeta1Leaseholder: isMeta1Leaseholder,
+		instanceID:         instanceID,
+		instances:          instances,
+	}
+	r.mu.listeners = make(map[*Listener]struct{})
+	return r
+}
+
+// Notify publishes a notification in the given transaction. The notification
+// is delivered to the listening sessions once the transaction commits.
+func (r *Registry) Notify(ctx context.Context, txn isql.Txn, n Notification) error {
+	if len(n.Payload) >= MaxPayloadLength {
+		return pgerror.New(pgcode.InvalidParameterValue, "payload string too long")
+	}
+	_, err := txn.ExecEx(
+		ctx, "notify", txn.KV(), sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.notifications (channel, payload, pid) VALUES ($1, $2, $3)`,
+		n.Channel, n.Payload, n.PID,
+	)
+	return err
+}
+
+// NewListener returns a new Listener that doesn't listen on any channel.
+// onNotify is called, from the rangefeed goroutine, whenever a notification is
+// queued for a Listener that doesn't have any pending notifications; the
+// pending notifications are consumed with TakePending.
+func (r *Registry) NewListener(onNotify func(ctx context.Context)) *Listener {
+	l := &Listener{registry: r, onNotify: onNotify}
+	l.mu.channels = make(map[string]struct{})
+	return l
+}
+
+func (r *Registry) register(l *Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.mu.listeners[l] = struct{}{}
+}
+
+func (r *Registry) unregister(l *Listener) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.mu.listeners, l)
+}
+
+// maybeStartRangefeed starts the rangefeed over system.notifications if it
+// isn't running yet.
+func (r *Registry) maybeStartRangefeed(ctx context.Context) error {
+	r.startMu.Lock()
+	defer r.startMu.Unlock()
+	if r.startMu.feed != nil {
+		return nil
+	}
+	tableID, err := r.resolver.LookupSystemTableID(ctx, systemschema.NotificationsTable.GetName())
+	if err != nil {
+		return err
+	}
+	prefix := r.codec.TablePrefix(uint32(tableID))
+	span := roachpb.Span{Key: prefix, EndKey: prefix.PrefixEnd()}
+	// The rangefeed outlives the session that started it, so it doesn't use the
+	// session's context. There is no initial scan: the notifications committed
+	// before the first session started listening are not delivered.
+	rfCtx := r.ambientCtx.AnnotateCtx(context.Background())
+	feed, err := r.factory.RangeFeed(
+		rfCtx, "notifications", []roachpb.Span{span}, r.clock.Now(), r.onValue,
+	)
+	if err != nil {
+		return err
+	}
+	if err := r.stopper.RunAsyncTask(rfCtx, "notifications-rangefeed-closer", func(context.Context) {
+		<-r.stopper.ShouldQuiesce()
+		feed.Close()
+	}); err != nil {
+		feed.Close()
+		return err
+	}
+	r.startMu.feed = feed
+	return nil
+}
+
+// Start starts the loop that deletes the expired notifications. The loop runs
+// on every node, but only one node deletes the notifications at a time; see
+// shouldRunGC.
+func (r *Registry) Start(ctx context.Context) error {
+	return r.stopper.RunAsyncTask(ctx, "notifications-gc", r.runGC)
+}
+
+func (r *Registry) runGC(ctx context.Context) {
+	ctx, cancel := r.stopper.WithCancelOnQuiesce(ctx)
+	defer cancel()
+	var timer timeutil.Timer
+	defer timer.Stop()
+	for {
+		timer.Reset(notificationTTL)
+		select {
+		case <-timer.C:
+			timer.Read = true
+		case <-ctx.Done():
+			return
+		}
+		if err := r.deleteExpired(ctx); err != nil {
+			log.Warningf(ctx, "failed to delete expired notifications: %v", err)
+		}
+	}
+}
+
+// deleteExpired deletes the expired notifications if this node is the one
+// responsible for it.
+func (r *Registry) deleteExpired(ctx context.Context) error {
+	ok, err := r.shouldRunGC(ctx)
+	if err != nil || !ok {
+		return err
+	}
+	cutoff := r.clock.PhysicalTime().Add(-notificationTTL)
+	_, err = r.db.Executor().ExecEx(
+		ctx, "delete-expired-notifications", nil /* txn */, sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.notifications WHERE created < $1`, cutoff,
+	)
+	return err
+}
+
+// shouldRunGC returns whether this node deletes the expired notifications. In
+// the system tenant, this is the node that holds the meta1 lease, like for the
+// cleanup of temporary objects. Secondary tenants don't have access to it, so
+// the live SQL instance with the lowest ID is used instead.
+func (r *Registry) shouldRunGC(ctx context.Context) (bool, error) {
+	if r.codec.ForSystemTenant() {
+		return r.isMeta1Leaseholder(ctx, r.clock.NowAsClockTimestamp())
+	}
+	instances, err := r.instances.GetAllInstances(ctx)
+	if err != nil {
+		return false, err
+	}
+	self := r.instanceID.SQLInstanceID()
+	for _, instance := range instances {
+		if instance.InstanceID < self {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// onValue decodes a notification and delivers it to the Listeners that listen
+// on its channel.
+func (r *Registry) onValue(ctx context.Context, value *kvpb.RangeFeedValue) {
+	if !value.Value.IsPresent() {
+		// The notification was deleted.
+		return
+	}
+	n, err := r.decode(value.Value)
+	if err != nil {
+		log.Warningf(ctx, "failed to decode notification %v: %v", value.Key, err)
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for l := range r.mu.listeners {
+		l.deliver(ctx, n)
+	}
+}
+
+func (r *Registry) decode(value roachpb.Value) (Notification, error) {
+	bytes, err := value.GetTuple()
+	if err != nil {
+		return Notification{}, err
+	}
+	datums, err := r.decoder.Decode(&tree.DatumAlloc{}, bytes)
+	if err != nil {
+		return Notification{}, err
+	}
+	channel, ok := datums[1].(*tree.DString)
+	if !ok {
+		return Notification{}, errors.AssertionFailedf("unexpected channel %v", datums[1])
+	}
+	payload, ok := datums[2].(*tree.DString)
+	if !ok {
+		return Notification{}, errors.AssertionFailedf("unexpected payload %v", datums[2])
+	}
+	pid, ok := datums[3].(*tree.DInt)
+	if !ok {
+		return Notification{}, errors.AssertionFailedf("unexpected pid %v", datums[3])
+	}
+	return Notification{
+		Channel: string(*channel),
+		Payload: string(*payload),
+		PID:     int32(*pid),
+	}, nil
+}
+
+// Listener tracks the channels a session listens on and queues the
+// notifications published on them until they are consumed.
+type Listener struct {
+	registry *Registry
+	onNotify func(ctx context.Context)
+
+	mu struct {
+		syncutil.Mutex
+		channels map[string]struct{}
+		pending  []Notification
+	}
+}
+
+// Listen starts listening on the given channel.
+//
+// Listen, Unlisten and UnlistenAll must not be called concurrently with each
+// other. The registry's mutex is acquired without holding the Listener's
+// mutex, since the registry acquires them in the opposite order when
+// delivering notifications.
+func (l *Listener) Listen(ctx context.Context, channel string) error {
+	if err := l.registry.maybeStartRangefeed(ctx); err != nil {
+		return err
+	}
+	if l.addChannel(channel) {
+		l.registry.register(l)
+	}
+	return nil
+}
+
+// Unlisten stops listening on the given channel.
+func (l *Listener) Unlisten(channel string) {
+	if l.removeChannels(channel) {
+		l.registry.unregister(l)
+	}
+}
+
+// UnlistenAll stops listening on all channels.
+func (l *Listener) UnlistenAll() {
+	if l.removeChannels("" /* channel */) {
+		l.registry.unregister(l)
+	}
+}
+
+// addChannel adds the channel to the set of channels the Listener listens on,
+// and returns whether the set was empty.
+func (l *Listener) addChannel(channel string) (wasEmpty bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	wasEmpty = len(l.mu.channels) == 0
+	l.mu.channels[channel] = struct{}{}
+	return wasEmpty
+}
+
+// removeChannels removes the channel, or all channels if channel is empty,
+// from the set of channels the Listener listens on, and returns whether the
+// set became empty.
+func (l *Listener) removeChannels(channel string) (becameEmpty bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.mu.channels) == 0 {
+		return false
+	}
+	if channel == "" {
+		l.mu.channels = make(map[string]struct{})
+	} else {
+		delete(l.mu.channels, channel)
+	}
+	return len(l.mu.channels) == 0
+}
+
+// TakePending returns the pending notifications and clears the queue.
+func (l *Listener) TakePending() []Notification {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	pending := l.mu.pending
+	l.mu.pending = nil
+	return pending
+}
+
+// deliver queues the notification if the Listener listens on its channel. It
+// is called with the registry's mutex held.
+func (l *Listener) deliver(ctx context.Context, n Notification) {
+	notify := func() bool {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if _, ok := l.mu.channels[n.Channel]; !ok {
+			return false
+		}
+		if len(l.mu.pending) >= maxPendingNotifications {
+			log.Warningf(ctx, "dropping notification on channel %q: too many pending notifications", n.Channel)
+			l.mu.pending = l.mu.pending[1:]
+		}
+		l.mu.pending = append(l.mu.pending, n)
+		return len(l.mu.pending) == 1
+	}()
+	if notify {
+		l.onNotify(ctx)
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgnotify
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/rowenc/valueside"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRegistry() *Registry {
+	return NewRegistry(
+		log.AmbientContext{}, keys.SystemSQLCodec, nil /* clock */, nil, /* stopper */
+		nil /* db */, nil /* resolver */, nil, /* factory */
+		nil /* isMeta1Leaseholder */, nil /* instanceID */, nil, /* instances */
+	)
+}
+
+// testListener is a Listener that counts how many times it was notified.
+type testListener struct {
+	*Listener
+	notified int
+}
+
+func newTestListener(r *Registry, channels ...string) *testListener {
+	tl := &testListener{}
+	tl.Listener = r.NewListener(func(context.Context) { tl.notified++ })
+	for _, channel := range channels {
+		// Listen would start the rangefeed, which isn't needed to deliver the
+		// notifications that are passed to onValue directly.
+		if tl.addChannel(channel) {
+			r.register(tl.Listener)
+		}
+	}
+	return tl
+}
+
+// encodeNotification encodes a notification the way it is stored in
+// system.notifications.
+func encodeNotification(t *testing.T, n Notification) *kvpb.RangeFeedValue {
+	var buf []byte
+	var lastColID descpb.ColumnID
+	for _, col := range []struct {
+		id    descpb.ColumnID
+		datum tree.Datum
+	}{
+		{id: 2, datum: tree.NewDString(n.Channel)},
+		{id: 3, datum: tree.NewDString(n.Payload)},
+		{id: 4, datum: tree.NewDInt(tree.DInt(n.PID))},
+	} {
+		var err error
+		buf, err = valueside.Encode(buf, valueside.MakeColumnIDDelta(lastColID, col.id), col.datum, nil /* scratch */)
+		require.NoError(t, err)
+		lastColID = col.id
+	}
+	value := &kvpb.RangeFeedValue{}
+	value.Value.SetTuple(buf)
+	return value
+}
+
+func TestDelivery(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	r := newTestRegistry()
+	l := newTestListener(r, "a")
+
+	n1 := Notification{Channel: "a", Payload: "p1", PID: 1}
+	n2 := Notification{Channel: "a", Payload: "p2", PID: 2}
+	r.onValue(ctx, encodeNotification(t, n1))
+	r.onValue(ctx, encodeNotification(t, n2))
+	// The Listener is only notified when its queue becomes non-empty.
+	require.Equal(t, 1, l.notified)
+	require.Equal(t, []Notification{n1, n2}, l.TakePending())
+	require.Empty(t, l.TakePending())
+
+	r.onValue(ctx, encodeNotification(t, n1))
+	require.Equal(t, 2, l.notified)
+	require.Equal(t, []Notification{n1}, l.TakePending())
+
+	// Deleted notifications are ignored.
+	r.onValue(ctx, &kvpb.RangeFeedValue{})
+	require.Equal(t, 2, l.notified)
+	require.Empty(t, l.TakePending())
+}
+
+func TestDeliveryFiltersChannels(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	r := newTestRegistry()
+	la := newTestListener(r, "a")
+	lab := newTestListener(r, "a", "b")
+	lc := newTestListener(r, "c")
+
+	na := Notification{Channel: "a", Payload: "x", PID: 1}
+	nb := Notification{Channel: "b", Payload: "y", PID: 1}
+	r.onValue(ctx, encodeNotification(t, na))
+	r.onValue(ctx, encodeNotification(t, nb))
+	require.Equal(t, []Notification{na}, la.TakePending())
+	require.Equal(t, []Notification{na, nb}, lab.TakePending())
+	require.Empty(t, lc.TakePending())
+	require.Zero(t, lc.notified)
+
+	// A Listener that stops listening on a channel no longer receives its
+	// notifications, but still receives those of its other channels.
+	lab.Unlisten("a")
+	r.onValue(ctx, encodeNotification(t, na))
+	r.onValue(ctx, encodeNotification(t, nb))
+	require.Equal(t, []Notification{na}, la.TakePending())
+	require.Equal(t, []Notification{nb}, lab.TakePending())
+
+	// A Listener that doesn't listen on any channel anymore is unregistered.
+	lab.UnlistenAll()
+	la.Unlisten("a")
+	r.onValue(ctx, encodeNotification(t, na))
+	r.onValue(ctx, encodeNotification(t, nb))
+	require.Empty(t, la.TakePending())
+	require.Empty(t, lab.TakePending())
+	require.Len(t, r.mu.listeners, 1)
+}
+
+func TestNotifyPayloadLength(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	r := newTestRegistry()
+	for _, length := range []int{MaxPayloadLength, MaxPayloadLength + 1} {
+		n := Notification{Channel: "a", Payload: strings.Repeat("x", length)}
+		// The payload is checked before the notification is written, so no
+		// transaction is needed.
+		err := r.Notify(ctx, nil /* txn */, n)
+		require.Error(t, err)
+		require.Equal(t, pgcode.InvalidParameterValue, pgerror.GetPGCode(err))
+		require.Contains(t, err.Error(), "payload string too long")
+	}
+}
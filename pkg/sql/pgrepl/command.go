@@ -0,0 +1,347 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package pgrepl implements the parts of the Postgres streaming replication
+// protocol that are independent of the SQL layer: the parser for the
+// replication commands, the encoding of the pgoutput logical decoding plugin
+// messages, and the mapping between MVCC timestamps and LSNs.
+package pgrepl
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/lsn"
+)
+
+// replicationCommands are the first keywords of the commands of the streaming
+// replication protocol, mapped to whether the command is supported.
+var replicationCommands = map[string]bool{
+	"IDENTIFY_SYSTEM":         true,
+	"CREATE_REPLICATION_SLOT": true,
+	"DROP_REPLICATION_SLOT":   true,
+	"START_REPLICATION":       true,
+	"ALTER_REPLICATION_SLOT":  false,
+	"READ_REPLICATION_SLOT":   false,
+	"TIMELINE_HISTORY":        false,
+	"BASE_BACKUP":             false,
+	"UPLOAD_MANIFEST":         false,
+}
+
+// IsReplicationCommand returns whether the query is a command of the
+// streaming replication protocol, rather than a SQL statement.
+func IsReplicationCommand(query string) bool {
+	_, ok := replicationCommands[firstWord(query)]
+	return ok
+}
+
+func firstWord(query string) string {
+	query = strings.TrimLeftFunc(query, unicode.IsSpace)
+	end := strings.IndexFunc(query, func(r rune) bool {
+		return !(r == '_' || unicode.IsLetter(r))
+	})
+	if end >= 0 {
+		query = query[:end]
+	}
+	return strings.ToUpper(query)
+}
+
+// Parse parses a replication command. The query must be a replication command
+// according to IsReplicationCommand.
+func Parse(query string) (tree.Statement, error) {
+	p := parser{query: query}
+	if err := p.tokenize(); err != nil {
+		return nil, err
+	}
+	cmd := p.nextKeyword()
+	if supported, ok := replicationCommands[cmd]; !ok {
+		return nil, pgerror.Newf(pgcode.Syntax, "%q is not a replication command", query)
+	} else if !supported {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported, "%s is not supported", cmd)
+	}
+	var stmt tree.Statement
+	var err error
+	switch cmd {
+	case "IDENTIFY_SYSTEM":
+		stmt = &tree.IdentifySystem{}
+	case "CREATE_REPLICATION_SLOT":
+		stmt, err = p.parseCreateReplicationSlot()
+	case "DROP_REPLICATION_SLOT":
+		stmt, err = p.parseDropReplicationSlot()
+	case "START_REPLICATION":
+		stmt, err = p.parseStartReplication()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, p.syntaxError()
+	}
+	return stmt, nil
+}
+
+func (p *parser) parseCreateReplicationSlot() (tree.Statement, error) {
+	n := &tree.CreateReplicationSlot{}
+	var err error
+	if n.Slot, err = p.nextIdent(); err != nil {
+		return nil, err
+	}
+	kw := p.nextKeyword()
+	if kw == "TEMPORARY" {
+		n.Temporary = true
+		kw = p.nextKeyword()
+	}
+	switch kw {
+	case "LOGICAL":
+	case "PHYSICAL":
+		return nil, errPhysicalReplication
+	default:
+		return nil, p.syntaxError()
+	}
+	if n.Plugin, err = p.nextIdent(); err != nil {
+		return nil, err
+	}
+	if p.peek().typ == tokLParen {
+		n.Options, err = p.parseOptions()
+		return n, err
+	}
+	// The options can also be given as keywords, as they were before Postgres
+	// 15.
+	for !p.done() {
+		switch kw := p.nextKeyword(); kw {
+		case "EXPORT_SNAPSHOT":
+			n.Options = append(n.Options, tree.ReplicationOption{Key: "snapshot", Value: "export"})
+		case "NOEXPORT_SNAPSHOT":
+			n.Options = append(n.Options, tree.ReplicationOption{Key: "snapshot", Value: "nothing"})
+		case "USE_SNAPSHOT":
+			n.Options = append(n.Options, tree.ReplicationOption{Key: "snapshot", Value: "use"})
+		case "TWO_PHASE":
+			n.Options = append(n.Options, tree.ReplicationOption{Key: "two_phase"})
+		default:
+			return nil, p.syntaxError()
+		}
+	}
+	return n, nil
+}
+
+func (p *parser) parseDropReplicationSlot() (tree.Statement, error) {
+	n := &tree.DropReplicationSlot{}
+	var err error
+	if n.Slot, err = p.nextIdent(); err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		if p.nextKeyword() != "WAIT" {
+			return nil, p.syntaxError()
+		}
+		n.Wait = true
+	}
+	return n, nil
+}
+
+func (p *parser) parseStartReplication() (tree.Statement, error) {
+	// Physical replication can be started without a slot, and without the
+	// PHYSICAL keyword.
+	if p.nextKeyword() != "SLOT" {
+		return nil, errPhysicalReplication
+	}
+	n := &tree.StartReplication{}
+	var err error
+	if n.Slot, err = p.nextIdent(); err != nil {
+		return nil, err
+	}
+	if p.nextKeyword() != "LOGICAL" {
+		return nil, errPhysicalReplication
+	}
+	t := p.next()
+	if t.typ != tokLSN {
+		return nil, p.syntaxError()
+	}
+	if n.LSN, err = lsn.ParseLSN(t.val); err != nil {
+		return nil, pgerror.Wrap(err, pgcode.Syntax, "invalid LSN")
+	}
+	if p.peek().typ == tokLParen {
+		n.Options, err = p.parseOptions()
+	}
+	return n, err
+}
+
+// parseOptions parses a parenthesized list of options, each of which is a
+// name optionally followed by a value.
+func (p *parser) parseOptions() (tree.ReplicationOptions, error) {
+	p.next() // (
+	var opts tree.ReplicationOptions
+	for {
+		key, err := p.nextIdent()
+		if err != nil {
+			return nil, err
+		}
+		opt := tree.ReplicationOption{Key: key}
+		switch t := p.peek(); t.typ {
+		case tokIdent, tokString, tokNumber:
+			opt.Value = p.next().val
+		}
+		opts = append(opts, opt)
+		switch p.next().typ {
+		case tokComma:
+		case tokRParen:
+			return opts, nil
+		default:
+			return nil, p.syntaxError()
+		}
+	}
+}
+
+var errPhysicalReplication = pgerror.New(pgcode.FeatureNotSupported,
+	"physical replication is not supported")
+
+type tokenType int
+
+const (
+	tokEOF tokenType = iota
+	tokIdent
+	tokString
+	tokNumber
+	tokLSN
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type token struct {
+	typ tokenType
+	val string
+	// quoted is set for double-quoted identifiers, which are not folded to
+	// lower case and can't be keywords.
+	quoted bool
+}
+
+type parser struct {
+	query  string
+	tokens []token
+	pos    int
+}
+
+func (p *parser) syntaxError() error {
+	return pgerror.Newf(pgcode.Syntax, "syntax error in replication command: %q", p.query)
+}
+
+func (p *parser) done() bool {
+	return p.peek().typ == tokEOF
+}
+
+func (p *parser) peek() token {
+	if p.pos >= len(p.tokens) {
+		return token{typ: tokEOF}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	if t.typ != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// nextKeyword consumes the next token and returns it in upper case if it is
+// an unquoted identifier, and the empty string otherwise.
+func (p *parser) nextKeyword() string {
+	t := p.next()
+	if t.typ != tokIdent || t.quoted {
+		return ""
+	}
+	return strings.ToUpper(t.val)
+}
+
+func (p *parser) nextIdent() (tree.Name, error) {
+	t := p.next()
+	if t.typ != tokIdent {
+		return "", p.syntaxError()
+	}
+	return tree.Name(t.val), nil
+}
+
+// tokenize splits the query into tokens. Unquoted identifiers are folded to
+// lower case, and a trailing semicolon is ignored.
+func (p *parser) tokenize() error {
+	s := strings.TrimRightFunc(p.query, unicode.IsSpace)
+	s = strings.TrimSuffix(s, ";")
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '(':
+			p.tokens = append(p.tokens, token{typ: tokLParen})
+			i++
+		case c == ')':
+			p.tokens = append(p.tokens, token{typ: tokRParen})
+			i++
+		case c == ',':
+			p.tokens = append(p.tokens, token{typ: tokComma})
+			i++
+		case c == '\'' || c == '"':
+			val, n, ok := scanQuoted(s[i:], c)
+			if !ok {
+				return p.syntaxError()
+			}
+			if c == '"' {
+				p.tokens = append(p.tokens, token{typ: tokIdent, val: val, quoted: true})
+			} else {
+				p.tokens = append(p.tokens, token{typ: tokString, val: val})
+			}
+			i += n
+		case c == '_' || c == '-' || c == '.' || lexbase.IsIdentStart(int(c)) || lexbase.IsDigit(int(c)):
+			j := i + 1
+			for j < len(s) && (s[j] == '/' || s[j] == '.' || lexbase.IsIdentMiddle(int(s[j]))) {
+				j++
+			}
+			word := s[i:j]
+			i = j
+			switch {
+			case strings.Contains(word, "/"):
+				p.tokens = append(p.tokens, token{typ: tokLSN, val: word})
+			case c == '-' || c == '.' || lexbase.IsDigit(int(c)):
+				p.tokens = append(p.tokens, token{typ: tokNumber, val: word})
+			default:
+				p.tokens = append(p.tokens, token{typ: tokIdent, val: strings.ToLower(word)})
+			}
+		default:
+			return p.syntaxError()
+		}
+	}
+	return nil
+}
+
+// scanQuoted scans a string quoted by the given quote character, in which the
+// quote is escaped by doubling it. It returns the unquoted string and the
+// number of bytes consumed.
+func scanQuoted(s string, quote byte) (string, int, bool) {
+	var b strings.Builder
+	for i := 1; i < len(s); i++ {
+		if s[i] != quote {
+			b.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == quote {
+			b.WriteByte(quote)
+			i++
+			continue
+		}
+		return b.String(), i + 1, true
+	}
+	return "", 0, false
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgrepl
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsReplicationCommand(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, tc := range []struct {
+		query    string
+		expected bool
+	}{
+		{`IDENTIFY_SYSTEM`, true},
+		{`  identify_system;`, true},
+		{`START_REPLICATION SLOT s LOGICAL 0/0`, true},
+		{`BASE_BACKUP`, true},
+		{`SELECT 1`, false},
+		{`SHOW wal_level`, false},
+		{`CREATE PUBLICATION p`, false},
+		{``, false},
+	} {
+		require.Equal(t, tc.expected, IsReplicationCommand(tc.query), tc.query)
+	}
+}
+
+func TestParse(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	for _, tc := range []struct {
+		query    string
+		expected string
+		code     pgcode.Code
+	}{
+		{
+			query:    `IDENTIFY_SYSTEM`,
+			expected: `IDENTIFY_SYSTEM`,
+		},
+		{
+			query:    `CREATE_REPLICATION_SLOT s1 LOGICAL pgoutput`,
+			expected: `CREATE_REPLICATION_SLOT s1 LOGICAL pgoutput`,
+		},
+		{
+			query:    `create_replication_slot "S1" temporary logical pgoutput (snapshot 'nothing');`,
+			expected: `CREATE_REPLICATION_SLOT "S1" TEMPORARY LOGICAL pgoutput (snapshot 'nothing')`,
+		},
+		{
+			query:    `CREATE_REPLICATION_SLOT s1 LOGICAL pgoutput NOEXPORT_SNAPSHOT`,
+			expected: `CREATE_REPLICATION_SLOT s1 LOGICAL pgoutput (snapshot 'nothing')`,
+		},
+		{
+			query: `CREATE_REPLICATION_SLOT s1 PHYSICAL RESERVE_WAL`,
+			code:  pgcode.FeatureNotSupported,
+		},
+		{
+			query:    `DROP_REPLICATION_SLOT s1 WAIT`,
+			expected: `DROP_REPLICATION_SLOT s1 WAIT`,
+		},
+		{
+			query:    `START_REPLICATION SLOT s1 LOGICAL 0/16B3748`,
+			expected: `START_REPLICATION SLOT s1 LOGICAL 0/16B3748`,
+		},
+		{
+			query:    `START_REPLICATION SLOT "s1" LOGICAL 1/0 ("proto_version" '1', "publication_names" 'p1,"P2"', messages)`,
+			expected: `START_REPLICATION SLOT s1 LOGICAL 1/0 (proto_version '1', publication_names 'p1,"P2"', messages)`,
+		},
+		{
+			query: `START_REPLICATION 0/0`,
+			code:  pgcode.FeatureNotSupported,
+		},
+		{
+			query: `START_REPLICATION SLOT s1 PHYSICAL 0/0 TIMELINE 1`,
+			code:  pgcode.FeatureNotSupported,
+		},
+		{
+			query: `START_REPLICATION SLOT s1 LOGICAL 0/0 (proto_version '1'`,
+			code:  pgcode.Syntax,
+		},
+		{
+			query: `START_REPLICATION SLOT s1 LOGICAL foo`,
+			code:  pgcode.Syntax,
+		},
+		{
+			query: `DROP_REPLICATION_SLOT s1 NOW`,
+			code:  pgcode.Syntax,
+		},
+		{
+			query: `DROP_REPLICATION_SLOT 's1'`,
+			code:  pgcode.Syntax,
+		},
+		{
+			query: `BASE_BACKUP (LABEL 'b')`,
+			code:  pgcode.FeatureNotSupported,
+		},
+		{
+			query: `IDENTIFY_SYSTEM 'x`,
+			code:  pgcode.Syntax,
+		},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			stmt, err := Parse(tc.query)
+			if tc.code != (pgcode.Code{}) {
+				require.Error(t, err)
+				require.Equal(t, tc.code, pgerror.GetPGCode(err))
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, tree.AsString(stmt))
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgrepl
+
+import (
+	"math"
+
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/lsn"
+)
+
+// The LSNs of a replication stream are derived from the wall time of the MVCC
+// timestamps of the changes it carries. All the changes committed with the
+// same wall time are sent as a single transaction, which commits at the LSN
+// equal to that wall time. This makes LSNs increase with commit timestamps,
+// and lets a stream resume after the changes of any LSN a client confirmed.
+
+// CommitLSN returns the LSN of the transaction that carries the changes
+// committed at ts.
+func CommitLSN(ts hlc.Timestamp) lsn.LSN {
+	return lsn.LSN(ts.WallTime)
+}
+
+// ResolvedLSN returns the highest LSN whose transaction is complete once no
+// further changes can be committed at or below the resolved timestamp ts.
+// Changes can still be committed at a later logical time with the same wall
+// time as ts, so only the LSNs below that of ts are complete.
+func ResolvedLSN(ts hlc.Timestamp) lsn.LSN {
+	if ts.WallTime == 0 {
+		return 0
+	}
+	return lsn.LSN(ts.WallTime - 1)
+}
+
+// ResumeTimestamp returns the timestamp after which a stream that has
+// delivered the transactions up to and including l must resume.
+func ResumeTimestamp(l lsn.LSN) hlc.Timestamp {
+	return hlc.Timestamp{WallTime: int64(l), Logical: math.MaxInt32}
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgrepl
+
+import (
+	"encoding/binary"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/cockroach/pkg/util/lsn"
+	"github.com/lib/pq/oid"
+)
+
+// PluginPgoutput is the name of the only supported logical decoding output
+// plugin. See https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+// for the format of its messages.
+const PluginPgoutput = "pgoutput"
+
+// ProtoVersion is the version of the pgoutput protocol that is implemented.
+const ProtoVersion = 1
+
+// Relation describes a table in a Relation message.
+type Relation struct {
+	ID        oid.Oid
+	Namespace string
+	Name      string
+	Columns   []RelationColumn
+}
+
+// RelationColumn describes a column of a Relation.
+type RelationColumn struct {
+	Name    string
+	TypeOID oid.Oid
+	TypeMod int32
+	// Key is set if the column is part of the primary key.
+	Key bool
+}
+
+// Tuple holds the text-encoded values of the columns of a row, in the order of
+// the columns of its Relation. A nil value is a NULL.
+type Tuple [][]byte
+
+// replicaIdentityFull is the replica identity sent for every relation. The old
+// values of all columns are included in updates and deletes, which is what
+// Postgres does for tables with REPLICA IDENTITY FULL.
+const replicaIdentityFull = 'f'
+
+// AppendBegin appends a Begin message for a transaction which commits at
+// finalLSN.
+func AppendBegin(buf []byte, finalLSN lsn.LSN, commitTime time.Time, xid uint32) []byte {
+	buf = append(buf, 'B')
+	buf = binary.BigEndian.AppendUint64(buf, uint64(finalLSN))
+	buf = appendTime(buf, commitTime)
+	return binary.BigEndian.AppendUint32(buf, xid)
+}
+
+// AppendCommit appends a Commit message for a transaction which commits at
+// commitLSN.
+func AppendCommit(buf []byte, commitLSN lsn.LSN, commitTime time.Time) []byte {
+	buf = append(buf, 'C', 0 /* flags */)
+	buf = binary.BigEndian.AppendUint64(buf, uint64(commitLSN))
+	// The end LSN of the transaction. We don't have a notion of the size of a
+	// transaction, so it ends where it commits.
+	buf = binary.BigEndian.AppendUint64(buf, uint64(commitLSN))
+	return appendTime(buf, commitTime)
+}
+
+// AppendRelation appends a Relation message, which describes the table that
+// the changes sent after it refer to.
+func AppendRelation(buf []byte, rel *Relation) []byte {
+	buf = append(buf, 'R')
+	buf = binary.BigEndian.AppendUint32(buf, uint32(rel.ID))
+	buf = appendString(buf, rel.Namespace)
+	buf = appendString(buf, rel.Name)
+	buf = append(buf, replicaIdentityFull)
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(rel.Columns)))
+	for i := range rel.Columns {
+		col := &rel.Columns[i]
+		var flags byte
+		if col.Key {
+			flags = 1
+		}
+		buf = append(buf, flags)
+		buf = appendString(buf, col.Name)
+		buf = binary.BigEndian.AppendUint32(buf, uint32(col.TypeOID))
+		buf = binary.BigEndian.AppendUint32(buf, uint32(col.TypeMod))
+	}
+	return buf
+}
+
+// AppendInsert appends an Insert message for a row of the relation.
+func AppendInsert(buf []byte, relID oid.Oid, newTuple Tuple) []byte {
+	buf = append(buf, 'I')
+	buf = binary.BigEndian.AppendUint32(buf, uint32(relID))
+	buf = append(buf, 'N')
+	return appendTuple(buf, newTuple)
+}
+
+// AppendUpdate appends an Update message for a row of the relation. The old
+// tuple can be nil if the previous values of the row are not known.
+func AppendUpdate(buf []byte, relID oid.Oid, oldTuple, newTuple Tuple) []byte {
+	buf = append(buf, 'U')
+	buf = binary.BigEndian.AppendUint32(buf, uint32(relID))
+	if oldTuple != nil {
+		buf = append(buf, 'O')
+		buf = appendTuple(buf, oldTuple)
+	}
+	buf = append(buf, 'N')
+	return appendTuple(buf, newTuple)
+}
+
+// AppendDelete appends a Delete message for a row of the relation.
+func AppendDelete(buf []byte, relID oid.Oid, oldTuple Tuple) []byte {
+	buf = append(buf, 'D')
+	buf = binary.BigEndian.AppendUint32(buf, uint32(relID))
+	buf = append(buf, 'O')
+	return appendTuple(buf, oldTuple)
+}
+
+func appendTuple(buf []byte, tuple Tuple) []byte {
+	buf = binary.BigEndian.AppendUint16(buf, uint16(len(tuple)))
+	for _, val := range tuple {
+		if val == nil {
+			buf = append(buf, 'n')
+			continue
+		}
+		buf = append(buf, 't')
+		buf = binary.BigEndian.AppendUint32(buf, uint32(len(val)))
+		buf = append(buf, val...)
+	}
+	return buf
+}
+
+func appendString(buf []byte, s string) []byte {
+	buf = append(buf, s...)
+	return append(buf, 0)
+}
+
+// appendTime appends a timestamp as the number of microseconds since the
+// Postgres epoch.
+func appendTime(buf []byte, t time.Time) []byte {
+	return binary.BigEndian.AppendUint64(buf, uint64(duration.DiffMicros(t, pgwirebase.PGEpochJDate)))
+}
+
+// The messages below are exchanged in the CopyData messages of the streaming
+// replication protocol, and wrap the pgoutput messages above. See
+// https://www.postgresql.org/docs/current/protocol-replication.html.
+
+// AppendXLogData appends an XLogData message carrying the given pgoutput
+// message, which starts at walStart.
+func AppendXLogData(buf []byte, walStart, walEnd lsn.LSN, now time.Time, msg []byte) []byte {
+	buf = append(buf, 'w')
+	buf = binary.BigEndian.AppendUint64(buf, uint64(walStart))
+	buf = binary.BigEndian.AppendUint64(buf, uint64(walEnd))
+	buf = appendTime(buf, now)
+	return append(buf, msg...)
+}
+
+// AppendKeepalive appends a primary keepalive message, which informs the
+// client of the end of the stream so far. If replyRequested is set, the client
+// should reply with a standby status update immediately.
+func AppendKeepalive(buf []byte, walEnd lsn.LSN, now time.Time, replyRequested bool) []byte {
+	buf = append(buf, 'k')
+	buf = binary.BigEndian.AppendUint64(buf, uint64(walEnd))
+	buf = appendTime(buf, now)
+	if replyRequested {
+		return append(buf, 1)
+	}
+	return append(buf, 0)
+}
+
+// StandbyStatus is a standby status update sent by the client, which reports
+// the positions up to which it has received and persisted the stream.
+type StandbyStatus struct {
+	Written lsn.LSN
+	Flushed lsn.LSN
+	Applied lsn.LSN
+	// ReplyRequested is set if the client wants a keepalive immediately.
+	ReplyRequested bool
+}
+
+// ParseStandbyMessage parses a message sent by the client during streaming.
+// It returns false if the message is valid but not a standby status update,
+// such as hot standby feedback.
+func ParseStandbyMessage(msg []byte) (StandbyStatus, bool, error) {
+	if len(msg) == 0 {
+		return StandbyStatus{}, false, pgerror.New(pgcode.ProtocolViolation,
+			"empty replication message")
+	}
+	switch msg[0] {
+	case 'r':
+		const statusLen = 1 + 8 + 8 + 8 + 8 + 1
+		if len(msg) != statusLen {
+			return StandbyStatus{}, false, pgerror.Newf(pgcode.ProtocolViolation,
+				"invalid standby status update length %d", len(msg))
+		}
+		return StandbyStatus{
+			Written:        lsn.LSN(binary.BigEndian.Uint64(msg[1:])),
+			Flushed:        lsn.LSN(binary.BigEndian.Uint64(msg[9:])),
+			Applied:        lsn.LSN(binary.BigEndian.Uint64(msg[17:])),
+			ReplyRequested: msg[33] != 0,
+		}, true, nil
+	case 'h':
+		// Hot standby feedback is only meaningful for physical replication.
+		return StandbyStatus{}, false, nil
+	default:
+		return StandbyStatus{}, false, pgerror.Newf(pgcode.ProtocolViolation,
+			"unexpected replication message type %q", msg[0])
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package pgrepl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/lsn"
+	"github.com/lib/pq/oid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPgoutputMessages(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	// One second after the Postgres epoch.
+	commitTime := time.Date(2000, 1, 1, 0, 0, 1, 0, time.UTC)
+	micros := []byte{0, 0, 0, 0, 0, 0x0f, 0x42, 0x40}
+	cat := func(parts ...[]byte) []byte {
+		var b []byte
+		for _, p := range parts {
+			b = append(b, p...)
+		}
+		return b
+	}
+
+	require.Equal(t,
+		cat([]byte{'B', 0, 0, 0, 1, 0, 0, 0, 2}, micros, []byte{0, 0, 0, 7}),
+		AppendBegin(nil, lsn.LSN(1<<32|2), commitTime, 7),
+	)
+	require.Equal(t,
+		cat([]byte{'C', 0, 0, 0, 0, 0, 0, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0, 5}, micros),
+		AppendCommit(nil, lsn.LSN(5), commitTime),
+	)
+
+	rel := &Relation{
+		ID:        oid.Oid(104),
+		Namespace: "public",
+		Name:      "t",
+		Columns: []RelationColumn{
+			{Name: "k", TypeOID: oid.T_int8, TypeMod: -1, Key: true},
+			{Name: "v", TypeOID: oid.T_text, TypeMod: -1},
+		},
+	}
+	require.Equal(t,
+		cat(
+			[]byte{'R', 0, 0, 0, 104}, []byte("public\x00t\x00"), []byte{'f', 0, 2},
+			[]byte{1}, []byte("k\x00"), []byte{0, 0, 0, 20, 0xff, 0xff, 0xff, 0xff},
+			[]byte{0}, []byte("v\x00"), []byte{0, 0, 0, 25, 0xff, 0xff, 0xff, 0xff},
+		),
+		AppendRelation(nil, rel),
+	)
+
+	newTuple := Tuple{[]byte("1"), nil}
+	oldTuple := Tuple{[]byte("1"), []byte("")}
+	tupleBytes := func(prefix byte) []byte {
+		return cat([]byte{prefix, 0, 2, 't', 0, 0, 0, 1, '1', 'n'})
+	}
+	require.Equal(t,
+		cat([]byte{'I', 0, 0, 0, 104}, tupleBytes('N')),
+		AppendInsert(nil, rel.ID, newTuple),
+	)
+	require.Equal(t,
+		cat([]byte{'U', 0, 0, 0, 104}, []byte{'O', 0, 2, 't', 0, 0, 0, 1, '1', 't', 0, 0, 0, 0}, tupleBytes('N')),
+		AppendUpdate(nil, rel.ID, oldTuple, newTuple),
+	)
+	require.Equal(t,
+		cat([]byte{'U', 0, 0, 0, 104}, tupleBytes('N')),
+		AppendUpdate(nil, rel.ID, nil, newTuple),
+	)
+	require.Equal(t,
+		cat([]byte{'D', 0, 0, 0, 104}, tupleBytes('O')),
+		AppendDelete(nil, rel.ID, newTuple),
+	)
+
+	require.Equal(t,
+		cat([]byte{'w', 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, 0, 0, 0, 0, 4}, micros, []byte("msg")),
+		AppendXLogData(nil, lsn.LSN(3), lsn.LSN(4), commitTime, []byte("msg")),
+	)
+	require.Equal(t,
+		cat([]byte{'k', 0, 0, 0, 0, 0, 0, 0, 4}, micros, []byte{1}),
+		AppendKeepalive(nil, lsn.LSN(4), commitTime, true /* replyRequested */),
+	)
+}
+
+func TestParseStandbyMessage(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	msg := []byte{'r',
+		0, 0, 0, 0, 0, 0, 0, 3, // written
+		0, 0, 0, 0, 0, 0, 0, 2, // flushed
+		0, 0, 0, 0, 0, 0, 0, 1, // applied
+		0, 0, 0, 0, 0, 0, 0, 0, // client time
+		1, // reply requested
+	}
+	status, ok, err := ParseStandbyMessage(msg)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, StandbyStatus{Written: 3, Flushed: 2, Applied: 1, ReplyRequested: true}, status)
+
+	_, ok, err = ParseStandbyMessage([]byte{'h', 0})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, _, err = ParseStandbyMessage(msg[:10])
+	require.Error(t, err)
+	_, _, err = ParseStandbyMessage([]byte{'x'})
+	require.Error(t, err)
+}
+
+func TestLSNTimestamps(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	ts := hlc.Timestamp{WallTime: 1690000000123456789, Logical: 3}
+	require.Equal(t, lsn.LSN(1690000000123456789), CommitLSN(ts))
+
+	// A stream that resumes after the LSN resolved at ts sees the changes
+	// committed at ts again, while one that resumes after the commit LSN of ts
+	// doesn't.
+	require.True(t, ResumeTimestamp(ResolvedLSN(ts)).Less(ts))
+	require.True(t, ts.Less(ResumeTimestamp(CommitLSN(ts))))
+
+	require.Equal(t, lsn.LSN(0), ResolvedLSN(hlc.Timestamp{}))
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgwirebase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -59,6 +60,7 @@ type commandResult struct {
 	// buffer contains items that are sent before the connection is closed.
 	buffer struct {
 		notices            []pgnotice.Notice
+		notifications      []pgnotify.Notification
 		paramStatusUpdates []paramStatusUpdate
 	}
 
@@ -148,6 +150,10 @@ func (r *commandResult) Close(ctx context.Context, t sql.TransactionStatusIndica
 		}
 	}
 
+	for _, n := range r.buffer.notifications {
+		r.conn.bufferNotification(n)
+	}
+
 	// Send a completion message, specific to the type of result.
 	switch r.typ {
 	case commandComplete:
@@ -288,6 +294,11 @@ func (r *commandResult) BufferNotice(notice pgnotice.Notice) {
 	r.buffer.notices = append(r.buffer.notices, notice)
 }
 
+// BufferNotification is part of the sql.NotificationBuffer interface.
+func (r *commandResult) BufferNotification(n pgnotify.Notification) {
+	r.buffer.notifications = append(r.buffer.notifications, n)
+}
+
 // SetColumns is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SetColumns(ctx context.Context, cols colinfo.ResultColumns) {
 	r.assertNotReleased()
@@ -361,6 +372,31 @@ func (r *commandResult) SendCopyDone(ctx context.Context) error {
 	return r.conn.bufferCopyDone()
 }
 
+// SendCopyBothResponse is part of the sql.StartReplicationResult interface.
+func (r *commandResult) SendCopyBothResponse(ctx context.Context) error {
+	r.assertNotReleased()
+	r.conn.writerState.fi.registerCmd(r.pos)
+	if err := r.conn.bufferCopyBothResponse(); err != nil {
+		return err
+	}
+	return r.conn.Flush(r.pos)
+}
+
+// SendReplicationData is part of the sql.StartReplicationResult interface.
+func (r *commandResult) SendReplicationData(ctx context.Context, data []byte) error {
+	r.assertNotReleased()
+	r.conn.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDataCommand)
+	if _, err := r.conn.msgBuilder.Write(data); err != nil {
+		return err
+	}
+	if err := r.conn.msgBuilder.finishMsg(&r.conn.writerState.buf); err != nil {
+		return err
+	}
+	// The client acknowledges the changes it receives, so they must not linger
+	// in the buffer.
+	return r.conn.Flush(r.pos)
+}
+
 // SetRowsAffected is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) SetRowsAffected(ctx context.Context, n int) {
 	r.assertNotReleased()
@@ -373,6 +409,28 @@ func (r *commandResult) RowsAffected() int {
 	return r.rowsAffected
 }
 
+// BufferedResultsLen is part of the sql.RestrictedCommandResult interface.
+func (r *commandResult) BufferedResultsLen() int {
+	r.assertNotReleased()
+	return r.conn.writerState.buf.Len()
+}
+
+// TruncateBufferedResults is part of the sql.RestrictedCommandResult interface.
+func (r *commandResult) TruncateBufferedResults(idx int) bool {
+	r.assertNotReleased()
+	if r.conn.writerState.fi.lastFlushed >= r.pos {
+		// Some of the results of this command have already been flushed to the
+		// client.
+		return false
+	}
+	if idx < 0 || idx > r.conn.writerState.buf.Len() {
+		return false
+	}
+	r.conn.writerState.buf.Truncate(idx)
+	r.rowsAffected = 0
+	return true
+}
+
 // ResetStmtType is part of the sql.RestrictedCommandResult interface.
 func (r *commandResult) ResetStmtType(stmt tree.Statement) {
 	r.assertNotReleased()
@@ -590,6 +648,10 @@ func (r *limitedCommandResult) moreResultsNeeded(ctx context.Context) error {
 			if err := r.conn.Flush(r.pos); err != nil {
 				return err
 			}
+		case sql.DeliverNotifications:
+			// The portal runs in a transaction, so the notifications are only
+			// delivered once the next Sync is processed outside of it.
+			r.conn.stmtBuf.AdvanceOne()
 		default:
 			// If the portal is immediately followed by a COMMIT, we can proceed and
 			// let the portal be destroyed at the end of the transaction.
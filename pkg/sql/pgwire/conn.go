@@ -29,6 +29,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgnotify"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
@@ -116,6 +118,21 @@ type conn struct {
 
 	// afterReadMsgTestingKnob is called after reading every message.
 	afterReadMsgTestingKnob func(context.Context) error
+
+	// replicationStream is set while a START_REPLICATION command streams
+	// changes to the client, and receives the messages that the client sends
+	// during streaming. It is only accessed by the network goroutine.
+	replicationStream *replicationStream
+}
+
+// replicationStream connects the network goroutine to the execution of a
+// START_REPLICATION command.
+type replicationStream struct {
+	// msgs receives the payload of the CopyData messages sent by the client. It
+	// is closed when the client ends the stream.
+	msgs chan []byte
+	// done is closed once the execution of the command finishes.
+	done chan struct{}
 }
 
 // serveConn creates a conn that will serve the netConn. It returns once the
@@ -562,6 +579,9 @@ func (c *conn) serveImpl(
 				return false, isSimpleQuery, c.handleFlush(ctx)
 
 			case pgwirebase.ClientMsgCopyData, pgwirebase.ClientMsgCopyDone, pgwirebase.ClientMsgCopyFail:
+				if c.replicationStream != nil {
+					return false, isSimpleQuery, c.handleReplicationMessage(ctx, typ)
+				}
 				// We're supposed to ignore these messages, per the protocol spec. This
 				// state will happen when an error occurs on the server-side during a copy
 				// operation: the server will send an error and a ready message back to
@@ -781,6 +801,16 @@ func (c *conn) bufferNotice(ctx context.Context, noticeErr pgnotice.Notice) erro
 	return c.writeErrFields(ctx, noticeErr, &c.writerState.buf)
 }
 
+func (c *conn) bufferNotification(n pgnotify.Notification) {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgNotificationResponse)
+	c.msgBuilder.putInt32(n.PID)
+	c.msgBuilder.writeTerminatedString(n.Channel)
+	c.msgBuilder.writeTerminatedString(n.Payload)
+	if err := c.msgBuilder.finishMsg(&c.writerState.buf); err != nil {
+		panic(errors.NewAssertionErrorWithWrappedErrf(err, "unexpected err from buffer"))
+	}
+}
+
 func (c *conn) sendInitialConnData(
 	ctx context.Context, sqlServer *sql.Server, onDefaultIntSizeChange func(newSize int32),
 ) (sql.ConnectionHandler, error) {
@@ -859,6 +889,10 @@ func (c *conn) handleSimpleQuery(
 		return c.stmtBuf.Push(ctx, sql.SendError{Err: err})
 	}
 
+	if c.sessionArgs.LogicalReplication && pgrepl.IsReplicationCommand(query) {
+		return c.handleReplicationCommand(ctx, query, timeReceived)
+	}
+
 	startParse := timeutil.Now()
 	stmts, err := c.parser.ParseWithInt(query, unqualifiedIntSize)
 	if err != nil {
@@ -969,6 +1003,87 @@ func (c *conn) handleSimpleQuery(
 	return nil
 }
 
+// handleReplicationCommand handles a command of the streaming replication
+// protocol, which can only be sent in the simple protocol of a replication
+// connection.
+//
+// An error is returned iff the statement buffer has been closed. In that case,
+// the connection should be considered toast.
+func (c *conn) handleReplicationCommand(
+	ctx context.Context, query string, timeReceived time.Time,
+) error {
+	startParse := timeutil.Now()
+	stmt, err := pgrepl.Parse(query)
+	if err != nil {
+		return c.stmtBuf.Push(ctx, sql.SendError{Err: err})
+	}
+	endParse := timeutil.Now()
+	parsed := statements.Statement[tree.Statement]{
+		AST: stmt,
+		SQL: query,
+	}
+
+	// START_REPLICATION is special: its execution streams changes to the client
+	// while the client sends status updates, which this goroutine keeps reading
+	// and forwards to the stream.
+	if sr, ok := stmt.(*tree.StartReplication); ok {
+		stream := &replicationStream{
+			msgs: make(chan []byte, replicationStreamBufferSize),
+			done: make(chan struct{}),
+		}
+		c.replicationStream = stream
+		return c.stmtBuf.Push(
+			ctx,
+			sql.StartReplication{
+				ParsedStmt:     parsed,
+				Stmt:           sr,
+				ClientMessages: stream.msgs,
+				StreamDone:     stream.done,
+				TimeReceived:   timeReceived,
+				ParseStart:     startParse,
+				ParseEnd:       endParse,
+			})
+	}
+	return c.stmtBuf.Push(
+		ctx,
+		sql.ExecStmt{
+			Statement:    parsed,
+			TimeReceived: timeReceived,
+			ParseStart:   startParse,
+			ParseEnd:     endParse,
+			LastInBatch:  true,
+		})
+}
+
+// replicationStreamBufferSize is the number of messages sent by the client
+// during streaming that can be buffered before the network goroutine blocks.
+const replicationStreamBufferSize = 16
+
+// handleReplicationMessage forwards a message sent by the client during
+// streaming to the replication stream. Once the stream is over, either because
+// the client ended it or because its execution finished, further messages are
+// ignored like those of a failed COPY.
+func (c *conn) handleReplicationMessage(
+	ctx context.Context, typ pgwirebase.ClientMessageType,
+) error {
+	stream := c.replicationStream
+	if typ == pgwirebase.ClientMsgCopyData {
+		msg := make([]byte, len(c.readBuf.Msg))
+		copy(msg, c.readBuf.Msg)
+		select {
+		case stream.msgs <- msg:
+			return nil
+		case <-stream.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	} else {
+		close(stream.msgs)
+	}
+	c.replicationStream = nil
+	return nil
+}
+
 // An error is returned iff the statement buffer has been closed. In that case,
 // the connection should be considered toast.
 func (c *conn) handleParse(
@@ -1671,6 +1786,15 @@ func (c *conn) bufferCopyData(copyData []byte, res *commandResult) error {
 	return nil
 }
 
+func (c *conn) bufferCopyBothResponse() error {
+	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyBothResponse)
+	// The stream doesn't have columns, so the format is irrelevant. Postgres
+	// sends the text format.
+	c.msgBuilder.writeByte(byte(pgwirebase.FormatText))
+	c.msgBuilder.putInt16(0)
+	return c.msgBuilder.finishMsg(&c.writerState.buf)
+}
+
 func (c *conn) bufferCopyDone() error {
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgCopyDoneCommand)
 	return c.msgBuilder.finishMsg(&c.writerState.buf)
@@ -1844,6 +1968,11 @@ func (c *conn) CreateDrainResult(pos sql.CmdPos) sql.DrainResult {
 	return c.newMiscResult(pos, noCompletionMsg)
 }
 
+// CreateDeliverNotificationsResult is part of the sql.ClientComm interface.
+func (c *conn) CreateDeliverNotificationsResult(pos sql.CmdPos) sql.DeliverNotificationsResult {
+	return c.newMiscResult(pos, flush)
+}
+
 // CreateBindResult is part of the sql.ClientComm interface.
 func (c *conn) CreateBindResult(pos sql.CmdPos) sql.BindResult {
 	return c.newMiscResult(pos, bindComplete)
@@ -1892,6 +2021,16 @@ func (c *conn) CreateCopyOutResult(cmd sql.CopyOut, pos sql.CmdPos) sql.CopyOutR
 	return res
 }
 
+// CreateStartReplicationResult is part of the sql.ClientComm interface.
+func (c *conn) CreateStartReplicationResult(
+	cmd sql.StartReplication, pos sql.CmdPos,
+) sql.StartReplicationResult {
+	res := c.newMiscResult(pos, commandComplete)
+	res.stmtType = cmd.Stmt.StatementReturnType()
+	res.cmdCompleteTag = cmd.Stmt.StatementTag()
+	return res
+}
+
 // pgwireReader is an io.Reader that wraps a conn, maintaining its metrics as
 // it is consumed.
 type pgwireReader struct {
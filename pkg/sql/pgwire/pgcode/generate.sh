@@ -15,3 +15,9 @@ sed -E 's|^([A-Z0-9]{5})    .    ERRCODE_([A-Z_]+).*$|\2 = MakeCode("\1")|' |
 sed -E 's|// Section: Class 58 - System Error \(errors external to PostgreSQL itself\)|// Section: Class 58 - System Error|' |
 awk '{$1=tolower($1); print $0}' |
 perl -pe 's/(^|_)./uc($&)/ge;s/_//g' > errcodes.generated
+
+# This script will also generate a map from the condition names that can be
+# used in PL/pgSQL exception handlers to their error codes. Only conditions of
+# the error category are included. If a condition name is used by multiple
+# error codes, only the first is included.
+awk '$2 == "E" && NF >= 4 && !seen[$4]++ { printf "\t\"%s\": \"%s\",\n", $4, $1 }' errcodes.txt > plpgsql_codenames.generated
@@ -461,6 +461,14 @@ func DecodeDatum(
 			}
 			return &tree.DTSVector{TSVector: ret}, nil
 		}
+		switch typ.Family() {
+		case types.RangeFamily:
+			d, _, err := tree.ParseDRangeFromString(evalCtx, string(b), typ)
+			return d, err
+		case types.MultirangeFamily:
+			d, _, err := tree.ParseDMultirangeFromString(evalCtx, string(b), typ)
+			return d, err
+		}
 		if typ.Family() == types.ArrayFamily {
 			// Arrays come in in their string form, so we parse them as such and later
 			// convert them to their actual datum form.
@@ -772,6 +780,12 @@ func DecodeDatum(
 			if typ.Family() == types.TupleFamily {
 				return decodeBinaryTuple(ctx, evalCtx, b)
 			}
+			if typ.Family() == types.RangeFamily {
+				return decodeBinaryRange(ctx, evalCtx, typ, b)
+			}
+			if typ.Family() == types.MultirangeFamily {
+				return decodeBinaryMultirange(ctx, evalCtx, typ, b)
+			}
 			if typ.Family() == types.OidFamily {
 				if len(b) < 4 {
 					return nil, pgerror.Newf(pgcode.ProtocolViolation, "oid requires 4 bytes for binary format")
@@ -990,6 +1004,78 @@ func decodeBinaryArray(
 	return arr, nil
 }
 
+// decodeBinaryRange decodes the Postgres binary representation of a range,
+// which is a flags byte followed by the length-prefixed finite bounds.
+func decodeBinaryRange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte,
+) (*tree.DRange, error) {
+	if len(b) < 1 {
+		return nil, pgerror.Newf(pgcode.ProtocolViolation, "range requires a flags byte")
+	}
+	flags := tree.RangeFlags(b[0])
+	if flags&tree.RangeEmpty != 0 {
+		return tree.NewDEmptyRange(t), nil
+	}
+	r := bytes.NewBuffer(b[1:])
+	bounds := [2]tree.Datum{tree.DNull, tree.DNull}
+	infFlags := [2]tree.RangeFlags{tree.RangeLowerInf, tree.RangeUpperInf}
+	for i := range bounds {
+		if flags&infFlags[i] != 0 {
+			continue
+		}
+		var vlen int32
+		if err := binary.Read(r, binary.BigEndian, &vlen); err != nil {
+			return nil, err
+		}
+		if vlen < 0 || int(vlen) > r.Len() {
+			return nil, pgerror.Newf(pgcode.ProtocolViolation, "invalid range bound length %d", vlen)
+		}
+		bound, err := DecodeDatum(ctx, evalCtx, t.RangeContents(), FormatBinary, r.Next(int(vlen)))
+		if err != nil {
+			return nil, err
+		}
+		bounds[i] = bound
+	}
+	if r.Len() != 0 {
+		return nil, pgerror.Newf(pgcode.ProtocolViolation, "unexpected trailing bytes in range")
+	}
+	return tree.MakeDRange(
+		t, bounds[0], bounds[1], flags&tree.RangeLowerInc != 0, flags&tree.RangeUpperInc != 0,
+	)
+}
+
+// decodeBinaryMultirange decodes the Postgres binary representation of a
+// multirange, which is the number of ranges followed by the length-prefixed
+// ranges.
+func decodeBinaryMultirange(
+	ctx context.Context, evalCtx *eval.Context, t *types.T, b []byte,
+) (*tree.DMultirange, error) {
+	r := bytes.NewBuffer(b)
+	var n int32
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return nil, err
+	}
+	if n < 0 {
+		return nil, pgerror.Newf(pgcode.ProtocolViolation, "invalid multirange length %d", n)
+	}
+	var ranges []*tree.DRange
+	for i := int32(0); i < n; i++ {
+		var vlen int32
+		if err := binary.Read(r, binary.BigEndian, &vlen); err != nil {
+			return nil, err
+		}
+		if vlen < 0 || int(vlen) > r.Len() {
+			return nil, pgerror.Newf(pgcode.ProtocolViolation, "invalid range length %d", vlen)
+		}
+		rng, err := decodeBinaryRange(ctx, evalCtx, t.MultirangeContents(), r.Next(int(vlen)))
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, rng)
+	}
+	return tree.NewDMultirange(t, ranges), nil
+}
+
 const tupleHeaderSize, oidSize, elementSize = 4, 4, 4
 
 func decodeBinaryTuple(ctx context.Context, evalCtx *eval.Context, b []byte) (tree.Datum, error) {
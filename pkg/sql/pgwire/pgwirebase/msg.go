@@ -43,6 +43,7 @@ const (
 	ServerMsgBindComplete         ServerMessageType = '2'
 	ServerMsgCommandComplete      ServerMessageType = 'C'
 	ServerMsgCloseComplete        ServerMessageType = '3'
+	ServerMsgCopyBothResponse     ServerMessageType = 'W'
 	ServerMsgCopyInResponse       ServerMessageType = 'G'
 	ServerMsgCopyOutResponse      ServerMessageType = 'H'
 	ServerMsgCopyDataCommand      ServerMessageType = 'd'
@@ -52,6 +53,7 @@ const (
 	ServerMsgErrorResponse        ServerMessageType = 'E'
 	ServerMsgNoticeResponse       ServerMessageType = 'N'
 	ServerMsgNoData               ServerMessageType = 'n'
+	ServerMsgNotificationResponse ServerMessageType = 'A'
 	ServerMsgParameterDescription ServerMessageType = 't'
 	ServerMsgParameterStatus      ServerMessageType = 'S'
 	ServerMsgParseComplete        ServerMessageType = '1'
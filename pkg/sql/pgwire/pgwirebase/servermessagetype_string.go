@@ -13,6 +13,7 @@ func _() {
 	_ = x[ServerMsgBindComplete-50]
 	_ = x[ServerMsgCommandComplete-67]
 	_ = x[ServerMsgCloseComplete-51]
+	_ = x[ServerMsgCopyBothResponse-87]
 	_ = x[ServerMsgCopyInResponse-71]
 	_ = x[ServerMsgCopyOutResponse-72]
 	_ = x[ServerMsgCopyDataCommand-100]
@@ -22,6 +23,7 @@ func _() {
 	_ = x[ServerMsgErrorResponse-69]
 	_ = x[ServerMsgNoticeResponse-78]
 	_ = x[ServerMsgNoData-110]
+	_ = x[ServerMsgNotificationResponse-65]
 	_ = x[ServerMsgParameterDescription-116]
 	_ = x[ServerMsgParameterStatus-83]
 	_ = x[ServerMsgParseComplete-49]
@@ -42,6 +44,8 @@ func (i ServerMessageType) String() string {
 		return "ServerMsgCommandComplete"
 	case ServerMsgCloseComplete:
 		return "ServerMsgCloseComplete"
+	case ServerMsgCopyBothResponse:
+		return "ServerMsgCopyBothResponse"
 	case ServerMsgCopyInResponse:
 		return "ServerMsgCopyInResponse"
 	case ServerMsgCopyOutResponse:
@@ -60,6 +64,8 @@ func (i ServerMessageType) String() string {
 		return "ServerMsgNoticeResponse"
 	case ServerMsgNoData:
 		return "ServerMsgNoData"
+	case ServerMsgNotificationResponse:
+		return "ServerMsgNotificationResponse"
 	case ServerMsgParameterDescription:
 		return "ServerMsgParameterDescription"
 	case ServerMsgParameterStatus:
@@ -115,6 +115,21 @@ func parseClientProvidedSessionParameters(
 			}
 			args.foundBufferSize = true
 
+		case "replication":
+			// Only logical replication, which is requested with the "database"
+			// value, is supported.
+			switch strings.ToLower(value) {
+			case "database":
+				args.LogicalReplication = true
+			case "false", "off", "no", "0":
+			case "true", "on", "yes", "1":
+				return args, pgerror.New(pgcode.FeatureNotSupported,
+					"physical replication is not supported")
+			default:
+				return args, pgerror.Newf(pgcode.ProtocolViolation,
+					"invalid value for parameter \"replication\": %q", value)
+			}
+
 		case "crdb:remote_addr":
 			if !trustClientProvidedRemoteAddr {
 				return args, pgerror.Newf(pgcode.ProtocolViolation,
@@ -253,6 +253,10 @@ func writeTextDatumNotNull(
 		b.textFormatter.FormatNode(v)
 		b.writeFromFmtCtx(b.textFormatter)
 
+	case *tree.DRange, *tree.DMultirange:
+		b.textFormatter.FormatNode(v)
+		b.writeFromFmtCtx(b.textFormatter)
+
 	case *tree.DArray:
 		// Arrays have custom formatting depending on their OID.
 		b.textFormatter.FormatNode(d)
@@ -558,6 +562,23 @@ func writeBinaryJSON(b *writeBuffer, v json.JSON, t *types.T) {
 	b.writeString(s)
 }
 
+// writeBinaryRange writes the Postgres binary representation of a range, which
+// is its flags byte followed by its finite bounds, without a length prefix.
+func writeBinaryRange(
+	ctx context.Context, b *writeBuffer, r *tree.DRange, sessionLoc *time.Location,
+) {
+	b.writeByte(byte(r.Flags()))
+	if r.Empty {
+		return
+	}
+	subtype := r.ResolvedType().RangeContents()
+	for _, bound := range [2]tree.Datum{r.Lower, r.Upper} {
+		if bound != tree.DNull {
+			b.writeBinaryDatum(ctx, bound, sessionLoc, subtype)
+		}
+	}
+}
+
 // writeBinaryDatum writes d to the buffer. Type t must be specified for types
 // that have various width encodings (floats, ints, chars). It is ignored
 // (and can be nil) for types with a 1:1 datum:type mapping.
@@ -760,6 +781,29 @@ func writeBinaryDatumNotNull(
 		lengthToWrite := b.Len() - (initialLen + 4)
 		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
 
+	case *tree.DRange:
+		initialLen := b.Len()
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+		writeBinaryRange(ctx, b, v, sessionLoc)
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
+	case *tree.DMultirange:
+		initialLen := b.Len()
+		// Reserve bytes for writing length later.
+		b.putInt32(int32(0))
+		// Put the number of ranges, followed by each length-prefixed range.
+		b.putInt32(int32(len(v.Ranges)))
+		for _, r := range v.Ranges {
+			rangeLen := b.Len()
+			b.putInt32(int32(0))
+			writeBinaryRange(ctx, b, r, sessionLoc)
+			b.putInt32AtIndex(rangeLen /* index to write at */, int32(b.Len()-(rangeLen+4)))
+		}
+		lengthToWrite := b.Len() - (initialLen + 4)
+		b.putInt32AtIndex(initialLen /* index to write at */, int32(lengthToWrite))
+
 	case *tree.DArray:
 		if v.ParamTyp.Family() == types.ArrayFamily {
 			b.setError(unimplemented.NewWithIssueDetail(32552,
@@ -62,6 +62,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 		return n.columns
 	case *valuesNode:
 		return n.columns
+	case *foreignScanNode:
+		return n.resultColumns
 	case *virtualTableNode:
 		return n.columns
 	case *windowNode:
@@ -130,6 +132,10 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 		return n.getColumns(mut, colinfo.ExportColumns)
 	case *completionsNode:
 		return n.getColumns(mut, colinfo.ShowCompletionsColumns)
+	case *identifySystemNode:
+		return n.getColumns(mut, colinfo.IdentifySystemColumns)
+	case *createReplicationSlotNode:
+		return n.getColumns(mut, colinfo.CreateReplicationSlotColumns)
 
 	// The columns in the hookFnNode are returned by the hook function; we don't
 	// know if they can be modified in place or not.
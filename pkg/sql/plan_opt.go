@@ -75,7 +75,7 @@ func (p *planner) prepareUsingOptimizer(ctx context.Context) (planFlags, error)
 		*tree.ReleaseSavepoint, *tree.RenameColumn, *tree.RenameDatabase,
 		*tree.RenameIndex, *tree.RenameTable, *tree.Revoke, *tree.RevokeRole,
 		*tree.RollbackToSavepoint, *tree.RollbackTransaction,
-		*tree.Savepoint, *tree.SetTransaction, *tree.SetTracing, *tree.SetSessionAuthorizationDefault,
+		*tree.Savepoint, *tree.SetConstraints, *tree.SetTransaction, *tree.SetTracing, *tree.SetSessionAuthorizationDefault,
 		*tree.SetSessionCharacteristics:
 		// These statements do not have result columns and do not support placeholders
 		// so there is no need to do anything during prepare.
@@ -147,6 +147,7 @@ func (p *planner) prepareUsingOptimizer(ctx context.Context) (planFlags, error)
 					stmt.Prepared.Columns = pm.Columns
 					stmt.Prepared.Types = pm.Types
 					stmt.Prepared.Memo = cachedData.Memo
+					stmt.Prepared.GenericMemo = cachedData.GenericMemo
 					return opc.flags, nil
 				}
 				opc.log(ctx, "query cache hit but memo is stale (prepare)")
@@ -508,6 +509,151 @@ func (opc *optPlanningCtx) reuseMemo(
 	return f.Memo(), nil
 }
 
+// customPlanOverheadPerTable is an estimate, in units of the optimizer's cost
+// model, of the overhead of optimizing a custom plan for each table referenced
+// by a query. It is added to the average cost of custom plans when comparing
+// them to the cost of a generic plan, so that the optimization time saved by
+// reusing a generic plan is taken into account. This mirrors Postgres, which
+// adds 1000 * cpu_operator_cost per relation to the cost of custom plans.
+const customPlanOverheadPerTable memo.Cost = 10
+
+// choosePreparedMemo returns a fully optimized memo for executing a prepared
+// statement when the plan_cache_mode session setting permits generic query
+// plans. The returned memo is either the generic memo of the prepared
+// statement, which is built if necessary, or a custom memo optimized with the
+// current placeholder values:
+//
+//   - If plan_cache_mode is force_generic_plan, the generic memo is always
+//     used.
+//   - If plan_cache_mode is auto, custom memos are used for the first
+//     customPlanThreshold executions. After that, the generic memo is used if
+//     its cost is no greater than the average cost of the recent custom memos,
+//     including the estimated overhead of optimizing them.
+//
+// The returned memo is only safe to use in one thread, during execution of the
+// current statement. It must not be modified.
+func (opc *optPlanningCtx) choosePreparedMemo(
+	ctx context.Context, prepared *PreparedStatement, mode sessiondatapb.PlanCacheMode,
+) (*memo.Memo, error) {
+	if mode == sessiondatapb.PlanCacheModeForceGeneric ||
+		prepared.Costs.NumCustom() >= customPlanThreshold {
+		if err := opc.ensureGenericMemo(ctx, prepared); err != nil {
+			return nil, err
+		}
+		useGeneric := mode == sessiondatapb.PlanCacheModeForceGeneric
+		if !useGeneric {
+			numTables := len(prepared.Memo.Metadata().AllTables())
+			customCost := prepared.Costs.AvgCustom() +
+				customPlanOverheadPerTable*memo.Cost(numTables+1)
+			useGeneric = !customCost.Less(prepared.Costs.Generic())
+		}
+		if useGeneric {
+			opc.log(ctx, "using generic plan")
+			opc.flags.Set(planFlagGeneric)
+			return prepared.GenericMemo, nil
+		}
+	}
+	opc.log(ctx, "optimizing custom plan")
+	m, err := opc.reuseMemo(ctx, prepared.Memo)
+	if err != nil {
+		return nil, err
+	}
+	opc.flags.Set(planFlagCustom)
+	prepared.Costs.AddCustom(m.RootExpr().(memo.RelExpr).Cost())
+	return m, nil
+}
+
+// ensureGenericMemo sets the generic memo of the prepared statement, building
+// it if it does not exist or is stale. If the query cache is in use, a generic
+// memo that was built for the same prepared memo, possibly by another session,
+// is reused; a newly built generic memo is added to the cache.
+func (opc *optPlanningCtx) ensureGenericMemo(
+	ctx context.Context, prepared *PreparedStatement,
+) error {
+	p := opc.p
+	if prepared.GenericMemo != nil {
+		isStale, err := prepared.GenericMemo.IsStale(ctx, p.EvalContext(), opc.catalog)
+		if err != nil {
+			return err
+		}
+		if !isStale {
+			return nil
+		}
+		opc.log(ctx, "generic memo is stale")
+		prepared.GenericMemo = nil
+	}
+
+	var cachedData querycache.CachedData
+	var inCache bool
+	if opc.useCache {
+		cachedData, inCache = p.execCfg.QueryCache.Find(&p.queryCacheSession, prepared.SQL)
+		// The cached generic memo can only be used if it was built from the same
+		// prepared memo.
+		inCache = inCache && cachedData.Memo == prepared.Memo
+		if inCache && cachedData.GenericMemo != nil {
+			isStale, err := cachedData.GenericMemo.IsStale(ctx, p.EvalContext(), opc.catalog)
+			if err != nil {
+				return err
+			}
+			if !isStale {
+				opc.log(ctx, "query cache hit (generic memo)")
+				prepared.GenericMemo = cachedData.GenericMemo
+				prepared.Costs.SetGeneric(prepared.GenericMemo.RootExpr().(memo.RelExpr).Cost())
+				return nil
+			}
+		}
+	}
+
+	opc.log(ctx, "optimizing generic plan")
+	genericMemo, err := opc.buildGenericMemo(ctx, prepared.Memo)
+	if err != nil {
+		return err
+	}
+	prepared.GenericMemo = genericMemo
+	prepared.Costs.SetGeneric(genericMemo.RootExpr().(memo.RelExpr).Cost())
+
+	if inCache {
+		opc.log(ctx, "query cache add (generic memo)")
+		cachedData.GenericMemo = genericMemo
+		p.execCfg.QueryCache.Add(&p.queryCacheSession, &cachedData)
+	}
+	return nil
+}
+
+// buildGenericMemo returns a fully optimized memo built from the given prepared
+// memo without assigning values to placeholders. Stable operators are not
+// constant-folded, because the generic memo is reused for later executions.
+// The returned memo is fully detached from the planner and can be used
+// independently and concurrently by multiple threads.
+func (opc *optPlanningCtx) buildGenericMemo(
+	ctx context.Context, preparedMemo *memo.Memo,
+) (_ *memo.Memo, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			// This code allows us to propagate internal errors without having to add
+			// error checks everywhere throughout the code. This is only possible
+			// because the code does not update shared state and does not manipulate
+			// locks.
+			if ok, e := errorutil.ShouldCatch(r); ok {
+				err = e
+			} else {
+				panic(r)
+			}
+		}
+	}()
+
+	f := opc.optimizer.Factory()
+	f.CopyAndReplace(
+		preparedMemo.RootExpr().(memo.RelExpr),
+		preparedMemo.RootProps(),
+		f.CopyWithoutAssigningPlaceholders,
+	)
+	if _, err := opc.optimizer.Optimize(); err != nil {
+		return nil, err
+	}
+	return opc.optimizer.DetachMemo(ctx), nil
+}
+
 // buildExecMemo creates a fully optimized memo, possibly reusing a previously
 // cached memo as a starting point.
 //
@@ -530,6 +676,14 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 			if err != nil {
 				return nil, err
 			}
+			// The generic memo, if any, was built from the stale memo, and the
+			// costs of previous plans may no longer be accurate.
+			prepared.GenericMemo = nil
+			prepared.Costs.Reset()
+		}
+		if mode := p.SessionData().PlanCacheMode; mode != sessiondatapb.PlanCacheModeForceCustom &&
+			!prepared.Memo.IsOptimized() {
+			return opc.choosePreparedMemo(ctx, prepared, mode)
 		}
 		opc.log(ctx, "reusing cached memo")
 		memo, err := opc.reuseMemo(ctx, prepared.Memo)
@@ -549,8 +703,9 @@ func (opc *optPlanningCtx) buildExecMemo(ctx context.Context) (_ *memo.Memo, _ e
 					return nil, err
 				}
 				// Update the plan in the cache. If the cache entry had PrepareMetadata
-				// populated, it may no longer be valid.
+				// or a generic memo populated, they may no longer be valid.
 				cachedData.PrepareMetadata = nil
+				cachedData.GenericMemo = nil
 				p.execCfg.QueryCache.Add(&p.queryCacheSession, &cachedData)
 				opc.flags.Set(planFlagOptCacheMiss)
 			} else {
@@ -702,6 +857,11 @@ func (opc *optPlanningCtx) runExecBuilder(
 	if bld.ContainsNonDefaultKeyLocking {
 		planTop.flags.Set(planFlagContainsNonDefaultLocking)
 	}
+	if opc.flags.IsSet(planFlagGeneric) {
+		planTop.instrumentation.planType = "generic"
+	} else if opc.flags.IsSet(planFlagCustom) {
+		planTop.instrumentation.planType = "custom"
+	}
 	if planTop.instrumentation.ShouldSaveMemo() {
 		planTop.mem = mem
 		planTop.catalog = opc.catalog
@@ -70,6 +70,8 @@ func planReqOrdering(plan planNode) ReqOrdering {
 
 	case *scanNode:
 		return n.reqOrdering
+	case *foreignScanNode:
+		return n.reqOrdering
 	case *ordinalityNode:
 		return n.reqOrdering
 	case *renderNode:
@@ -224,6 +224,10 @@ type planner struct {
 
 	sqlCursors sqlCursors
 
+	listenChannels listenChannels
+
+	deferredConstraints deferredConstraints
+
 	createdSequences createdSequences
 
 	// autoCommit indicates whether the plan is allowed (but not required) to
@@ -454,6 +458,8 @@ func newInternalPlanner(
 	p.queryCacheSession.Init()
 	p.optPlanningCtx.init(p)
 	p.createdSequences = emptyCreatedSequences{}
+	p.listenChannels = emptyListenChannels{}
+	p.deferredConstraints = emptyDeferredConstraints{}
 
 	p.schemaResolver.descCollection = p.Descriptors()
 	p.schemaResolver.sessionDataStack = sds
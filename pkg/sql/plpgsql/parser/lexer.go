@@ -118,58 +118,275 @@ func (l *lexer) Lex(lval *plpgsqlSymType) int {
 	return int(lval.id)
 }
 
-// MakeExecSqlStmt makes a PLpgSQLStmtExecSql from current token position.
-// TODO(chengxiong): we need to fill in variables as well.
-func (l *lexer) MakeExecSqlStmt(startTokenID int) *plpgsqltree.PLpgSQLStmtExecSql {
-	sqlToks := make([]string, 0)
+// MakeExecSqlStmt makes a PLpgSQLStmtExecSql from current token position. The
+// statement is read up to and including the terminating semicolon. If the
+// statement has an INTO clause, it is removed from the SQL string and the
+// target variables are recorded in the returned PLpgSQLStmtExecSql.
+func (l *lexer) MakeExecSqlStmt(startTokenID int) (*plpgsqltree.PLpgSQLStmtExecSql, error) {
 	if startTokenID == 0 || startTokenID == ';' {
-		l.setErr(errors.AssertionFailedf("plpgsql_execsql: invalid start token"))
+		return nil, errors.AssertionFailedf("plpgsql_execsql: invalid start token")
 	}
-	if int(l.lastToken().id) != startTokenID {
-		l.setErr(errors.AssertionFailedf("plpgsql_execsql: given start token does not match current pos of lexer"))
+	startTok := l.lastToken()
+	if int(startTok.id) != startTokenID {
+		return nil, errors.AssertionFailedf("plpgsql_execsql: given start token does not match current pos of lexer")
 	}
 
 	var hasInto bool
 	var hasStrict bool
+	var target []plpgsqltree.PLpgSQLVariable
+	var intoStartPos, intoEndPos int32
 	var preTok plpgsqlSymType
-	tok := l.lastToken()
+	tok := startTok
 	for {
-		if !hasInto {
-			sqlToks = append(sqlToks, tok.Str())
-		}
 		preTok = tok
 		l.Lex(&tok)
 		if tok.id == ';' {
 			break
 		}
 		if tok.id == 0 {
-			l.setErr(errors.AssertionFailedf("unexpected end of function definition"))
+			return nil, pgerror.New(pgcode.Syntax, "unexpected end of function definition")
 		}
-		if hasInto && tok.id == STRICT {
-			hasStrict = true
+		if tok.id != INTO {
 			continue
 		}
-		if tok.id == INTO {
-			if preTok.id == INSERT {
-				continue
-			}
-			if preTok.id == MERGE {
-				continue
-			}
-			if startTokenID == IMPORT {
-				continue
+		if preTok.id == INSERT || preTok.id == MERGE || startTokenID == IMPORT {
+			continue
+		}
+		if hasInto {
+			return nil, pgerror.New(pgcode.Syntax, "INTO specified more than once")
+		}
+		hasInto = true
+		intoStartPos = tok.pos
+		if l.Peek().id == STRICT {
+			l.Lex(&tok)
+			hasStrict = true
+		}
+		// Read the comma-separated list of target variables.
+		for {
+			l.Lex(&tok)
+			if tok.id != IDENT {
+				return nil, pgerror.Newf(pgcode.Syntax, "\"%s\" is not a known variable", tok.str)
 			}
-			if hasInto {
-				l.setErr(errors.AssertionFailedf("plpgsql_execsql: INTO specified more than once"))
+			target = append(target, plpgsqltree.PLpgSQLVariable(tok.str))
+			if l.Peek().id != ',' {
+				break
 			}
-			hasInto = true
+			l.Lex(&tok)
 		}
+		intoEndPos = l.Peek().pos
+	}
+	sqlStr := l.in[startTok.pos:tok.pos]
+	if hasInto {
+		sqlStr = l.in[startTok.pos:intoStartPos] + " " + l.in[intoEndPos:tok.pos]
 	}
 	return &plpgsqltree.PLpgSQLStmtExecSql{
-		SqlStmt: strings.Join(sqlToks, " "),
+		SqlStmt: strings.TrimSpace(sqlStr),
 		Into:    hasInto,
 		Strict:  hasStrict,
+		Target:  target,
+	}, nil
+}
+
+// MakeAssignOrExecSqlStmt makes either a PLpgSQLStmtAssign or a
+// PLpgSQLStmtExecSql from the current token position, which must be an IDENT
+// at the start of a statement. The statement is read up to and including the
+// terminating semicolon.
+func (l *lexer) MakeAssignOrExecSqlStmt() (plpgsqltree.PLpgSQLStatement, error) {
+	varTok := l.lastToken()
+	if varTok.id != IDENT {
+		return nil, errors.AssertionFailedf("expected IDENT at the start of the statement")
+	}
+	if next := l.Peek().id; next != COLON_EQUALS && next != '=' {
+		return l.MakeExecSqlStmt(IDENT)
+	}
+	// Skip the assignment operator.
+	l.lastPos++
+	value, _ := l.ReadSqlConstruct(';')
+	if l.lastError != nil {
+		return nil, l.lastError
+	}
+	// Skip the semicolon.
+	l.lastPos++
+	return &plpgsqltree.PLpgSQLStmtAssign{
+		Var:   plpgsqltree.PLpgSQLVariable(varTok.str),
+		Value: value,
+	}, nil
+}
+
+// MakeForLoopControl reads the part of a FOR loop between IN and LOOP, and
+// returns either an integer FOR loop or a FOR loop over the results of a query,
+// without a label or body. target is the list of loop variables.
+func (l *lexer) MakeForLoopControl(
+	target []plpgsqltree.PLpgSQLVariable,
+) (plpgsqltree.PLpgSQLStatement, error) {
+	var reverse bool
+	if l.Peek().id == REVERSE {
+		reverse = true
+		l.lastPos++
+	}
+	// An integer FOR loop is identified by the ".." that separates the bounds.
+	lowerStr, terminator := l.ReadSqlConstruct(DOT_DOT, LOOP)
+	if l.lastError != nil {
+		return nil, l.lastError
+	}
+	if terminator != DOT_DOT {
+		if reverse {
+			return nil, pgerror.New(pgcode.Syntax, "cannot specify REVERSE in query FOR loop")
+		}
+		return &plpgsqltree.PLpgSQLStmtForQuerySelectLoop{
+			PLpgSQLStmtForQueryLoop: plpgsqltree.PLpgSQLStmtForQueryLoop{Target: target},
+			Query:                   lowerStr,
+		}, nil
+	}
+	if len(target) != 1 {
+		return nil, pgerror.New(pgcode.Syntax, "integer FOR loop must have only one target variable")
+	}
+	// Skip the "..".
+	l.lastPos++
+	upperStr, terminator := l.ReadSqlConstruct(BY, LOOP)
+	var stepStr string
+	if terminator == BY {
+		// Skip the BY.
+		l.lastPos++
+		stepStr, _ = l.ReadSqlConstruct(LOOP)
+	}
+	if l.lastError != nil {
+		return nil, l.lastError
+	}
+	loop := &plpgsqltree.PLpgSQLStmtForIntLoop{
+		Var:     target[0],
+		Reverse: reverse,
+	}
+	var err error
+	if loop.Lower, err = l.ParseExpr(lowerStr); err != nil {
+		return nil, err
+	}
+	if loop.Upper, err = l.ParseExpr(upperStr); err != nil {
+		return nil, err
+	}
+	if stepStr != "" {
+		if loop.Step, err = l.ParseExpr(stepStr); err != nil {
+			return nil, err
+		}
+	}
+	return loop, nil
+}
+
+// MakeRaiseStmt makes a PLpgSQLStmtRaise from the current token position,
+// which must be the RAISE keyword. The statement is read up to and including
+// the terminating semicolon.
+func (l *lexer) MakeRaiseStmt() (*plpgsqltree.PLpgSQLStmtRaise, error) {
+	raise := &plpgsqltree.PLpgSQLStmtRaise{}
+	// A RAISE with no parameters re-throws the error currently being handled.
+	if l.Peek().id == ';' {
+		l.lastPos++
+		return raise, nil
+	}
+
+	// Read the optional log level.
+	switch l.Peek().id {
+	case EXCEPTION, WARNING, NOTICE, INFO, LOG, DEBUG:
+		l.lastPos++
+		raise.LogLevel = strings.ToUpper(l.lastToken().str)
+	}
+
+	// Read the message format string, condition name, or SQLSTATE. These are
+	// all optional if a USING clause is present.
+	var tok plpgsqlSymType
+	switch l.Peek().id {
+	case SCONST:
+		l.Lex(&tok)
+		raise.Message = tok.str
+		for l.Peek().id == ',' {
+			// Skip the comma.
+			l.lastPos++
+			param, err := l.ParseExpr(l.ReadSqlExpressionStr3(',', ';', USING))
+			if err != nil {
+				return nil, err
+			}
+			raise.Params = append(raise.Params, param)
+		}
+		if err := checkRaiseParams(raise.Message, len(raise.Params)); err != nil {
+			return nil, err
+		}
+	case SQLSTATE:
+		l.lastPos++
+		l.Lex(&tok)
+		if tok.id != SCONST {
+			return nil, pgerror.New(pgcode.Syntax, "syntax error, expected string constant after SQLSTATE")
+		}
+		if err := checkSQLState(tok.str); err != nil {
+			return nil, err
+		}
+		raise.Code = tok.str
+	case IDENT:
+		l.Lex(&tok)
+		raise.CodeName = tok.str
+	case USING:
+	default:
+		l.Lex(&tok)
+		return nil, pgerror.New(pgcode.Syntax, "syntax error")
+	}
+
+	// Read the optional USING clause.
+	if l.Peek().id == USING {
+		l.lastPos++
+		for {
+			l.Lex(&tok)
+			var optType plpgsqltree.PLpgSQLRaiseOptionType
+			switch tok.id {
+			case MESSAGE:
+				optType = plpgsqltree.PLpgSQLRaiseOptionMessage
+			case DETAIL:
+				optType = plpgsqltree.PLpgSQLRaiseOptionDetail
+			case HINT:
+				optType = plpgsqltree.PLpgSQLRaiseOptionHint
+			case ERRCODE:
+				optType = plpgsqltree.PLpgSQLRaiseOptionErrCode
+			case COLUMN:
+				optType = plpgsqltree.PLpgSQLRaiseOptionColumn
+			case CONSTRAINT:
+				optType = plpgsqltree.PLpgSQLRaiseOptionConstraint
+			case DATATYPE:
+				optType = plpgsqltree.PLpgSQLRaiseOptionDataType
+			case TABLE:
+				optType = plpgsqltree.PLpgSQLRaiseOptionTable
+			case SCHEMA:
+				optType = plpgsqltree.PLpgSQLRaiseOptionSchema
+			default:
+				return nil, pgerror.New(pgcode.Syntax, "unrecognized RAISE statement option")
+			}
+			for i := range raise.Options {
+				if raise.Options[i].OptType == optType {
+					return nil, pgerror.Newf(pgcode.Syntax, "RAISE option already specified: %s", optType)
+				}
+			}
+			l.Lex(&tok)
+			if tok.id != '=' && tok.id != COLON_EQUALS {
+				return nil, pgerror.New(pgcode.Syntax, "syntax error, expected \"=\"")
+			}
+			optStr, terminator := l.ReadSqlExpressionStr2(',', ';')
+			expr, err := l.ParseExpr(optStr)
+			if err != nil {
+				return nil, err
+			}
+			raise.Options = append(raise.Options, plpgsqltree.PLpgSQLStmtRaiseOption{
+				OptType: optType,
+				Expr:    expr,
+			})
+			if terminator != ',' {
+				break
+			}
+			// Skip the comma.
+			l.lastPos++
+		}
+	}
+
+	l.Lex(&tok)
+	if tok.id != ';' {
+		return nil, pgerror.New(pgcode.Syntax, "syntax error, expected \";\"")
 	}
+	return raise, nil
 }
 
 func (l *lexer) MakeDynamicExecuteStmt() *plpgsqltree.PLpgSQLStmtDynamicExecute {
@@ -274,53 +491,110 @@ func (l *lexer) ProcessForOpenCursor(nullCursorExplicitExpr bool) *plpgsqltree.P
 // TODO(plpgsql-team): pass the output to the sql parser
 // (i.e. sqlParserImpl.Parse()).
 func (l *lexer) ReadSqlExpressionStr(terminator int) (sqlStr string) {
-	sqlStr, _ = l.ReadSqlConstruct(terminator, 0, 0)
+	sqlStr, _ = l.ReadSqlConstruct(terminator)
 	return sqlStr
 }
 
 func (l *lexer) ReadSqlExpressionStr2(
 	terminator1 int, terminator2 int,
 ) (sqlStr string, terminatorMet int) {
-	return l.ReadSqlConstruct(terminator1, terminator2, 0)
+	return l.ReadSqlConstruct(terminator1, terminator2)
 }
 
-func (l *lexer) ReadSqlConstruct(
-	terminator1 int, terminator2 int, terminator3 int,
-) (sqlStr string, terminatorMet int) {
-	exprTokenStrs := make([]string, 0)
+// ReadSqlExpressionStr3 is like ReadSqlExpressionStr, but stops at the first
+// of three terminators.
+func (l *lexer) ReadSqlExpressionStr3(terminator1, terminator2, terminator3 int) (sqlStr string) {
+	sqlStr, _ = l.ReadSqlConstruct(terminator1, terminator2, terminator3)
+	return sqlStr
+}
+
+// ReadSqlConstruct reads tokens up to, but not including, the first of the
+// given terminators that is not nested within parentheses or brackets. It
+// returns the original text that spans the tokens that were read, and the
+// terminator that ended the construct.
+func (l *lexer) ReadSqlConstruct(terminators ...int) (sqlStr string, terminatorMet int) {
+	startPos := l.lastPos + 1
 	parenLevel := 0
 	for l.lastPos < len(l.tokens) {
 		tok := l.Peek()
-		if int(tok.id) == terminator1 && parenLevel == 0 {
-			terminatorMet = terminator1
-			break
-		} else if int(tok.id) == terminator2 && parenLevel == 0 {
-			terminatorMet = terminator2
-			break
-		} else if int(tok.id) == terminator3 && parenLevel == 0 {
-			terminatorMet = terminator3
+		if parenLevel == 0 {
+			found := false
+			for _, terminator := range terminators {
+				if int(tok.id) == terminator {
+					terminatorMet = terminator
+					found = true
+					break
+				}
+			}
+			if found {
+				break
+			}
+		}
+		if tok.id == 0 {
 			break
 		} else if tok.id == '(' || tok.id == '[' {
 			parenLevel++
-
 		} else if tok.id == ')' || tok.id == ']' {
 			parenLevel--
 			if parenLevel < 0 {
-				panic(errors.AssertionFailedf("wrongly nested parentheses"))
+				l.setErr(pgerror.New(pgcode.Syntax, "mismatched parentheses"))
+				return "", 0
 			}
 		}
-		exprTokenStrs = append(exprTokenStrs, tok.Str())
 		l.lastPos++
 	}
 	if parenLevel != 0 {
-		panic(errors.AssertionFailedf("parentheses is badly nested"))
+		l.setErr(pgerror.New(pgcode.Syntax, "mismatched parentheses"))
+		return "", 0
+	}
+	if l.lastPos < startPos {
+		l.setErr(pgerror.New(pgcode.Syntax, "missing expression"))
+		return "", terminatorMet
+	}
+	return l.textUntilNextToken(startPos), terminatorMet
+}
+
+// textUntilNextToken returns the original text spanning from the token at the
+// given position through the last token returned by Lex, with surrounding
+// whitespace trimmed.
+func (l *lexer) textUntilNextToken(startPos int) string {
+	endPos := int32(len(l.in))
+	if l.lastPos+1 < len(l.tokens) {
+		endPos = l.tokens[l.lastPos+1].pos
 	}
-	if len(exprTokenStrs) == 0 {
-		//TODO(jane): show the terminator in the panic message.
-		l.setErr(errors.New("there should be at least one token for sql expression"))
+	return strings.TrimSpace(l.in[l.tokens[startPos].pos:endPos])
+}
+
+// ReadDatatype reads a data type that starts with the last token returned by
+// Lex, up to the first token that can follow a data type in a declaration.
+func (l *lexer) ReadDatatype() (tree.ResolvableTypeReference, error) {
+	startPos := l.lastPos
+	if startPos < 0 || startPos >= len(l.tokens) {
+		return nil, errors.AssertionFailedf("invalid position for data type")
+	}
+	parenLevel := 0
+	for l.lastPos+1 < len(l.tokens) {
+		tok := l.Peek()
+		if parenLevel == 0 {
+			switch tok.id {
+			case ';', ',', ')', '=', COLON_EQUALS, DEFAULT, COLLATE, NOT:
+				return parser.GetTypeFromValidSQLSyntax(l.textUntilNextToken(startPos))
+			}
+		}
+		switch tok.id {
+		case '(', '[':
+			parenLevel++
+		case ')', ']':
+			parenLevel--
+		}
+		l.lastPos++
 	}
+	return nil, pgerror.New(pgcode.Syntax, "unexpected end of function definition")
+}
 
-	return strings.Join(exprTokenStrs, " "), terminatorMet
+// ParseExpr parses the given string as a SQL scalar expression.
+func (l *lexer) ParseExpr(sqlStr string) (plpgsqltree.PLpgSQLExpr, error) {
+	return parser.ParseExprWithInt(sqlStr, l.nakedIntType)
 }
 
 func (l *lexer) ProcessQueryForCursorWithoutExplicitExpr(openStmt *plpgsqltree.PLpgSQLStmtOpen) {
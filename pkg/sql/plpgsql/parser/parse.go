@@ -96,7 +96,10 @@ func (p *Parser) parse(
 ) (statements.PLpgStatement, error) {
 	p.lexer.init(sql, tokens, nakedIntType)
 	defer p.lexer.cleanup()
-	if p.parserImpl.Parse(&p.lexer) != 0 {
+	// The lexer may register an error while reading a SQL construct on behalf
+	// of the grammar without causing the parse itself to fail, so the error
+	// must be checked even when Parse succeeds.
+	if p.parserImpl.Parse(&p.lexer) != 0 || p.lexer.lastError != nil {
 		if p.lexer.lastError == nil {
 			// This should never happen -- there should be an error object
 			// every time Parse() returns nonzero. We're just playing safe
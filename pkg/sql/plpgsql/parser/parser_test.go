@@ -39,6 +39,12 @@ func TestParseDataDriver(t *testing.T) {
 				}
 				// TODO(chengxiong): add pretty print round trip test.
 				return fn.String()
+			case "error":
+				_, err := parser.Parse(d.Input)
+				if err == nil {
+					d.Fatalf(t, "expected parse error")
+				}
+				return err.Error()
 			case "feature-count":
 				fn, err := utils.CountPLpgSQLStmt(d.Input)
 				if err != nil {
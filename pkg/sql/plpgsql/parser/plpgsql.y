@@ -2,8 +2,6 @@
 package parser
 
 import (
-  "fmt"
-
   "github.com/cockroachdb/cockroach/pkg/sql/plpgsql/parser/lexbase"
   "github.com/cockroachdb/cockroach/pkg/sql/scanner"
   "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -125,6 +123,56 @@ func (u *plpgsqlSymUnion) pLpgSQLStmtOpen() *plpgsqltree.PLpgSQLStmtOpen {
     return u.val.(*plpgsqltree.PLpgSQLStmtOpen)
 }
 
+func (u *plpgsqlSymUnion) plpgsqlExpr() plpgsqltree.PLpgSQLExpr {
+    if u.val == nil {
+        return nil
+    }
+    return u.val.(plpgsqltree.PLpgSQLExpr)
+}
+
+func (u *plpgsqlSymUnion) plpgsqlDecl() *plpgsqltree.PLpgSQLDecl {
+    return u.val.(*plpgsqltree.PLpgSQLDecl)
+}
+
+func (u *plpgsqlSymUnion) plpgsqlDecls() []*plpgsqltree.PLpgSQLDecl {
+    return u.val.([]*plpgsqltree.PLpgSQLDecl)
+}
+
+func (u *plpgsqlSymUnion) typ() tree.ResolvableTypeReference {
+    return u.val.(tree.ResolvableTypeReference)
+}
+
+func (u *plpgsqlSymUnion) loopBody() *loopBody {
+    return u.val.(*loopBody)
+}
+
+func (u *plpgsqlSymUnion) plpgsqlVariables() []plpgsqltree.PLpgSQLVariable {
+    return u.val.([]plpgsqltree.PLpgSQLVariable)
+}
+
+func (u *plpgsqlSymUnion) plpgsqlExceptionBlock() *plpgsqltree.PLpgSQLExceptionBlock {
+    if u.val == nil {
+        return nil
+    }
+    return u.val.(*plpgsqltree.PLpgSQLExceptionBlock)
+}
+
+func (u *plpgsqlSymUnion) plpgsqlException() *plpgsqltree.PLpgSQLException {
+    return u.val.(*plpgsqltree.PLpgSQLException)
+}
+
+func (u *plpgsqlSymUnion) plpgsqlExceptions() []*plpgsqltree.PLpgSQLException {
+    return u.val.([]*plpgsqltree.PLpgSQLException)
+}
+
+func (u *plpgsqlSymUnion) plpgsqlCondition() *plpgsqltree.PLpgSQLCondition {
+    return u.val.(*plpgsqltree.PLpgSQLCondition)
+}
+
+func (u *plpgsqlSymUnion) plpgsqlConditions() []plpgsqltree.PLpgSQLCondition {
+    return u.val.([]plpgsqltree.PLpgSQLCondition)
+}
+
 %}
 /*
  * Basic non-keyword token types.  These are hard-wired into the core lexer.
@@ -269,22 +317,23 @@ func (u *plpgsqlSymUnion) pLpgSQLStmtOpen() *plpgsqltree.PLpgSQLStmtOpen {
 }
 
 %type <*declareHeader> decl_sect
-%type <varName> decl_varname
-%type <boolean>	decl_const decl_notnull exit_type
-%type <plpgsqltree.PLpgSQLExpr>	decl_defval decl_cursor_query
-%type <*types.T>	decl_datatype
-%type <oid.OID>		decl_collate
-%type <plpgsqltree.PLpgSQLDatum>	decl_cursor_args
+%type <str> decl_varname decl_collate decl_cursor_query
+%type <bool>	decl_const decl_notnull exit_type
+%type <plpgsqltree.PLpgSQLExpr>	decl_defval decl_defkey
+%type <tree.ResolvableTypeReference>	decl_datatype
+%type <[]*plpgsqltree.PLpgSQLDecl>	decl_cursor_args decl_cursor_arglist
+%type <plpgsqltree.PLpgSQLStatement> decl_stmt decl_statement
+%type <[]plpgsqltree.PLpgSQLStatement> decl_stmts
 
 %type <*plpgsqltree.PLpgSQLStmtOpen> open_stmt_processor
 %type <str>	expr_until_semi expr_until_paren
-%type <str>	expr_until_then expr_until_loop opt_expr_until_when
-%type <plpgsqltree.PLpgSQLExpr>	opt_exitcond
+%type <str>	expr_until_then opt_expr_until_when
+%type <plpgsqltree.PLpgSQLExpr>	expr_until_loop opt_exitcond
 
 %type <plpgsqltree.PLpgSQLScalarVar>		cursor_variable
-%type <plpgsqltree.PLpgSQLDatum>	decl_cursor_arg
-%type <forvariable>	for_variable
-%type <returnvariable>	return_variable
+%type <*plpgsqltree.PLpgSQLDecl>	decl_cursor_arg
+%type <[]plpgsqltree.PLpgSQLVariable>	for_variable
+%type <plpgsqltree.PLpgSQLExpr>	return_variable
 %type <*tree.NumVal>	foreach_slice
 %type <plpgsqltree.PLpgSQLStatement>	for_control
 
@@ -293,10 +342,10 @@ func (u *plpgsqlSymUnion) pLpgSQLStmtOpen() *plpgsqltree.PLpgSQLStmtOpen {
 %type <[]plpgsqltree.PLpgSQLStatement> proc_sect
 %type <[]*plpgsqltree.PLpgSQLStmtIfElseIfArm> stmt_elsifs
 %type <[]plpgsqltree.PLpgSQLStatement> stmt_else // TODO is this a list of statement?
-%type <loopBody> loop_body
+%type <*loopBody> loop_body
 %type <plpgsqltree.PLpgSQLStatement>  pl_block
 %type <plpgsqltree.PLpgSQLStatement>	proc_stmt
-%type <plpgsqltree.PLpgSQLStatement>	stmt_assign stmt_if stmt_loop stmt_while stmt_exit
+%type <plpgsqltree.PLpgSQLStatement>	stmt_if stmt_loop stmt_while stmt_exit
 %type <plpgsqltree.PLpgSQLStatement>	stmt_return stmt_raise stmt_assert stmt_execsql
 %type <plpgsqltree.PLpgSQLStatement>	stmt_dynexecute stmt_for stmt_perform stmt_call stmt_getdiag
 %type <plpgsqltree.PLpgSQLStatement>	stmt_open stmt_fetch stmt_move stmt_close stmt_null
@@ -304,8 +353,10 @@ func (u *plpgsqlSymUnion) pLpgSQLStmtOpen() *plpgsqltree.PLpgSQLStmtOpen {
 %type <plpgsqltree.PLpgSQLStatement>	stmt_case stmt_foreach_a
 
 %type <*plpgsqltree.PLpgSQLExceptionBlock> exception_sect
+%type <[]*plpgsqltree.PLpgSQLException>	proc_exceptions
 %type <*plpgsqltree.PLpgSQLException>	proc_exception
-%type <*plpgsqltree.PLpgSQLCondition>	proc_conditions proc_condition
+%type <[]plpgsqltree.PLpgSQLCondition>	proc_conditions
+%type <*plpgsqltree.PLpgSQLCondition>	proc_condition
 
 %type <*plpgsqltree.PLpgSQLStmtCaseWhenArm>	case_when
 %type <[]*plpgsqltree.PLpgSQLStmtCaseWhenArm>	case_when_list
@@ -337,17 +388,17 @@ opt_semi:
 
 pl_block: decl_sect BEGIN proc_sect exception_sect END opt_label
   {
-  // TODO(chengxiong): log declare section ($1) here.
-  header := $1.plpgsqlDeclareheader()
-  stmtBlock := &plpgsqltree.PLpgSQLStmtBlock{
-    Label: header.label,
-    Body: $3.plpgsqlStatements(),
-  }
-  if header.initVars != nil {
-    stmtBlock.InitVars = header.initVars
+    header := $1.plpgsqlDeclareheader()
+    if err := checkBlockLabel(header.label, $6); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.PLpgSQLStmtBlock{
+      Label: header.label,
+      Decls: header.decls,
+      Body: $3.plpgsqlStatements(),
+      Exceptions: $4.plpgsqlExceptionBlock(),
+    }
   }
-  $$.val = stmtBlock
-}
 ;
 
 decl_sect: opt_block_label
@@ -358,21 +409,15 @@ decl_sect: opt_block_label
   {
     $$.val = &declareHeader{
       label: $1,
-      initVars: make([]plpgsqltree.PLpgSQLVariable, 0),
+      decls: []plpgsqltree.PLpgSQLStatement{},
     }
   }
 | opt_block_label decl_start decl_stmts
   {
-    h := &declareHeader{
+    $$.val = &declareHeader{
       label: $1,
-      // TODO(chengxiong): right now we only need to know there is
-      // non-empty "DECLARE" block. It's sufficient to just match the
-      // dclare statements and do nothing, but make it a length 1 slice
-      // with only a nil element.
-      initVars: make([]plpgsqltree.PLpgSQLVariable, 1),
+      decls: $3.plpgsqlStatements(),
     }
-    h.initVars = append(h.initVars, nil)
-    $$.val = h
   }
 ;
 
@@ -382,13 +427,31 @@ decl_start: DECLARE
 ;
 
 decl_stmts: decl_stmts decl_stmt
+  {
+    decls := $1.plpgsqlStatements()
+    if $2.val != nil {
+      decls = append(decls, $2.plpgsqlStatement())
+    }
+    $$.val = decls
+  }
 | decl_stmt
+  {
+    decls := []plpgsqltree.PLpgSQLStatement{}
+    if $1.val != nil {
+      decls = append(decls, $1.plpgsqlStatement())
+    }
+    $$.val = decls
+  }
 ;
 
 decl_stmt	: decl_statement
+  {
+    $$.val = $1.plpgsqlStatement()
+  }
 | DECLARE
   {
-  // This is to allow useless extra "DECLARE" keywords in the declare section.
+    // This is to allow useless extra "DECLARE" keywords in the declare section.
+    $$.val = nil
   }
 // TODO(chengxiong): turn this block on and throw useful error if user
 // tries to put the block label just before BEGIN instead of before
@@ -400,53 +463,76 @@ decl_stmt	: decl_statement
 
 decl_statement: decl_varname decl_const decl_datatype decl_collate decl_notnull decl_defval
   {
+    $$.val = &plpgsqltree.PLpgSQLDecl{
+      Var: plpgsqltree.PLpgSQLVariable($1),
+      Constant: $2.bool(),
+      Typ: $3.typ(),
+      Collate: $4,
+      NotNull: $5.bool(),
+      Expr: $6.plpgsqlExpr(),
+    }
   }
 | decl_varname ALIAS FOR decl_aliasitem ';'
   {
+    return unimplemented(plpgsqllex, "alias for")
   }
 | decl_varname opt_scrollable CURSOR decl_cursor_args decl_is_for decl_cursor_query ';'
   {
+    $$.val = &plpgsqltree.PLpgSQLCursorDecl{
+      Name: plpgsqltree.PLpgSQLVariable($1),
+      CursorOptions: $2.uint32(),
+      Args: $4.plpgsqlDecls(),
+      Query: $6,
+    }
   }
 ;
 
 opt_scrollable:
   {
-  fmt.Println("plpgsqllex.(*lexer).pos in opt_scrollable empty", plpgsqllex.(*lexer).lastPos)
+    $$.val = uint32(0)
   }
 | NO_SCROLL SCROLL
   {
-  fmt.Println("plpgsqllex.(*lexer).pos in opt_scrollable no_scroll", plpgsqllex.(*lexer).lastPos)
+    $$.val = plpgsqltree.PLpgSQLCursorOptNoScroll.Mask()
   }
 | SCROLL
   {
-  fmt.Println("plpgsqllex.(*lexer).pos in opt_scrollable scroll", plpgsqllex.(*lexer).lastPos)
+    $$.val = plpgsqltree.PLpgSQLCursorOptScroll.Mask()
   }
 ;
 
 decl_cursor_query:
   {
-    plpgsqllex.(*lexer).ReadSqlExpressionStr(';')
+    $$ = plpgsqllex.(*lexer).ReadSqlExpressionStr(';')
   }
 ;
 
 decl_cursor_args:
   {
+    $$.val = []*plpgsqltree.PLpgSQLDecl(nil)
   }
 | '(' decl_cursor_arglist ')'
   {
+    $$.val = $2.plpgsqlDecls()
   }
 ;
 
 decl_cursor_arglist: decl_cursor_arg
   {
+    $$.val = []*plpgsqltree.PLpgSQLDecl{$1.plpgsqlDecl()}
   }
 | decl_cursor_arglist ',' decl_cursor_arg
   {
+    $$.val = append($1.plpgsqlDecls(), $3.plpgsqlDecl())
   }
 ;
 
 decl_cursor_arg: decl_varname decl_datatype
   {
+    $$.val = &plpgsqltree.PLpgSQLDecl{
+      Var: plpgsqltree.PLpgSQLVariable($1),
+      Typ: $2.typ(),
+    }
   }
 ;
 
@@ -471,46 +557,76 @@ decl_varname: IDENT
 ;
 
 decl_const:
-  { }
+  {
+    $$.val = false
+  }
 | CONSTANT
-  { }
+  {
+    $$.val = true
+  }
 ;
 
-// TODO(chengxiong): better handling for type names.
 decl_datatype: IDENT
   {
+    // The data type may consist of any number of tokens, so read the rest of
+    // them manually, starting with the IDENT that was just consumed.
+    typ, err := plpgsqllex.(*lexer).ReadDatatype()
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = typ
   }
 ;
 
 decl_collate:
-  { }
+  {
+    $$ = ""
+  }
 | COLLATE IDENT
   {
+    $$ = $2
   }
 | COLLATE unreserved_keyword
   {
+    $$ = $2
   }
 ;
 
 decl_notnull:
-  { }
+  {
+    $$.val = false
+  }
 | NOT NULL
-  { }
+  {
+    $$.val = true
+  }
 ;
 
 decl_defval: ';'
-  {}
+  {
+    $$.val = nil
+  }
 | decl_defkey ';'
-  {}
+  {
+    $$.val = $1.plpgsqlExpr()
+  }
 ;
 
 decl_defkey: assign_operator
   {
-    plpgsqllex.(*lexer).ReadSqlExpressionStr(';')
+    expr, err := plpgsqllex.(*lexer).ParseExpr(plpgsqllex.(*lexer).ReadSqlExpressionStr(';'))
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = expr
   }
 | DEFAULT
   {
-    plpgsqllex.(*lexer).ReadSqlExpressionStr(';')
+    expr, err := plpgsqllex.(*lexer).ParseExpr(plpgsqllex.(*lexer).ReadSqlExpressionStr(';'))
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = expr
   }
 ;
 
@@ -539,8 +655,6 @@ proc_stmt:pl_block ';'
   {
     $$.val = $1.plpgsqlStmtBlock()
   }
-| stmt_assign
-  { }
 | stmt_if
   { }
 | stmt_case
@@ -603,7 +717,7 @@ proc_stmt:pl_block ';'
 
 stmt_perform: PERFORM expr_until_semi ';'
   {
-    return unimplemented(plpgsqllex, "perform")
+    $$.val = &plpgsqltree.PLpgSQLStmtPerform{Query: $2}
   }
 ;
 
@@ -622,15 +736,6 @@ call_cmd:
   }
 ;
 
-stmt_assign: IDENT assign_operator expr_until_semi ';'
-  {
-    $$.val = &plpgsqltree.PLpgSQLStmtAssign{
-      Var: $1,
-      Value: $3,
-    }
-  }
-;
-
 stmt_getdiag: GET getdiag_area_opt DIAGNOSTICS getdiag_list ';'
   {
   $$.val = &plpgsqltree.PLpgSQLStmtGetDiag{
@@ -811,26 +916,60 @@ opt_case_else:
 
 stmt_loop: opt_loop_label LOOP loop_body
   {
-    return unimplemented(plpgsqllex, "simple loop")
+    body := $3.loopBody()
+    if err := checkLoopLabel($1, body.label); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.PLpgSQLStmtSimpleLoop{
+      Label: $1,
+      Body: body.stmts,
+    }
   }
 ;
 
-stmt_while: opt_loop_label WHILE expr_until_loop loop_body
+stmt_while: opt_loop_label WHILE expr_until_loop LOOP loop_body
   {
-    return unimplemented(plpgsqllex, "while loop")
+    body := $5.loopBody()
+    if err := checkLoopLabel($1, body.label); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.PLpgSQLStmtWhileLoop{
+      Label: $1,
+      Condition: $3.plpgsqlExpr(),
+      Body: body.stmts,
+    }
   }
 ;
 
-stmt_for: opt_loop_label FOR for_control loop_body
+stmt_for: opt_loop_label FOR for_control LOOP loop_body
   {
-    return unimplemented(plpgsqllex, "for loop")
+    body := $5.loopBody()
+    if err := checkLoopLabel($1, body.label); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    loop := $3.plpgsqlStatement()
+    switch t := loop.(type) {
+    case *plpgsqltree.PLpgSQLStmtForIntLoop:
+      t.Label, t.Body = $1, body.stmts
+    case *plpgsqltree.PLpgSQLStmtForQuerySelectLoop:
+      t.Label, t.Body = $1, body.stmts
+    default:
+      return setErr(plpgsqllex, errors.AssertionFailedf("unexpected for loop %T", loop))
+    }
+    $$.val = loop
   }
 ;
 
 for_control: for_variable IN
-  // TODO need to parse the sql expression here.
   {
-    return unimplemented(plpgsqllex, "for loop")
+    if plpgsqllex.(*lexer).Peek().id == EXECUTE {
+      return unimplemented(plpgsqllex, "for loop over dynamic query")
+    }
+    loop, err := plpgsqllex.(*lexer).MakeForLoopControl($1.plpgsqlVariables())
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = loop
   }
 ;
 
@@ -854,11 +993,15 @@ for_control: for_variable IN
  */
 for_variable: any_identifier
   {
-    return unimplemented(plpgsqllex, "for loop")
+    $$.val = []plpgsqltree.PLpgSQLVariable{plpgsqltree.PLpgSQLVariable($1)}
+  }
+| for_variable ',' any_identifier
+  {
+    $$.val = append($1.plpgsqlVariables(), plpgsqltree.PLpgSQLVariable($3))
   }
 ;
 
-stmt_foreach_a: opt_loop_label FOREACH for_variable foreach_slice IN ARRAY expr_until_loop loop_body
+stmt_foreach_a: opt_loop_label FOREACH for_variable foreach_slice IN ARRAY expr_until_loop LOOP loop_body
   {
     return unimplemented(plpgsqllex, "for each loop")
   }
@@ -874,27 +1017,29 @@ foreach_slice:
 
 stmt_exit: exit_type opt_label opt_exitcond
   {
-    $$.val = &plpgsqltree.PLpgSQLStmtExit{}
+    $$.val = &plpgsqltree.PLpgSQLStmtExit{
+      IsExit: $1.bool(),
+      Label: $2,
+      Condition: $3.plpgsqlExpr(),
+    }
   }
 ;
 
 exit_type: EXIT
   {
+    $$.val = true
   }
 | CONTINUE
   {
+    $$.val = false
   }
 ;
 
-  // TODO handle variable names
-  // 1. verify if the first token is a variable (this means that we need to track variable scope during parsing)
-  // 2. if yes, check next token is ';'
-  // 3. if no, expecting a sql expression "read_sql_expression"
-  //    we can just read until a ';', then do the sql expression validation during compile time.
-
 stmt_return: RETURN return_variable ';'
   {
-    return unimplemented(plpgsqllex, "return")
+    $$.val = &plpgsqltree.PLpgSQLStmtReturn{
+      Expr: $2.plpgsqlExpr(),
+    }
   }
 | RETURN_NEXT NEXT return_variable ';'
   {
@@ -918,73 +1063,34 @@ query_options:
 ;
 
 
-return_variable: IDENT
-  {
-    return unimplemented(plpgsqllex, "return identifier")
-  }
-| expr_until_semi
-  {
-    return unimplemented(plpgsqllex, "return expression")
-  }
-| '(' expr_until_paren
-  {
-    return unimplemented(plpgsqllex, "return composite type")
-  }
-;
-
-stmt_raise: RAISE error_level ';'
+return_variable:
   {
+    // A bare RETURN is allowed in functions that return VOID or have OUT
+    // parameters.
+    if plpgsqllex.(*lexer).Peek().id == ';' {
+      $$.val = nil
+    } else {
+      expr, err := plpgsqllex.(*lexer).ParseExpr(plpgsqllex.(*lexer).ReadSqlExpressionStr(';'))
+      if err != nil {
+        return setErr(plpgsqllex, err)
+      }
+      $$.val = expr
+    }
   }
 ;
 
-error_level:
-  {
-    return unimplemented(plpgsqllex, "raise")
-  }
-| EXCEPTION raise_cond option_expr
-  {
-    return unimplemented(plpgsqllex, "raise exception")
-  }
-| DEBUG raise_cond option_expr
+stmt_raise: RAISE
   {
-    return unimplemented(plpgsqllex, "raise debug")
-  }
-| LOG raise_cond option_expr
-  {
-    return unimplemented(plpgsqllex, "raise log")
-  }
-| INFO raise_cond option_expr
-  {
-    return unimplemented(plpgsqllex, "raise info")
-  }
-| WARNING raise_cond option_expr
-  {
-    return unimplemented(plpgsqllex, "raise warning")
+    // The RAISE statement has many optional parts, so it is read manually by
+    // the lexer, up to and including the terminating semicolon.
+    raise, err := plpgsqllex.(*lexer).MakeRaiseStmt()
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = raise
   }
 ;
 
-raise_cond:
-  {}
-| SCONST
-  {}
-| SQLSTATE
-  {}
-| IDENT
-  {}
-;
-
-
-option_expr:
-  {}
-| USING MESSAGE assign_operator expr_until_semi
-  {}
-| USING DETAIL assign_operator expr_until_semi
-  {}
-| USING HINT assign_operator expr_until_semi
-  {}
-| USING ERRCODE assign_operator expr_until_semi
-  {}
-
 stmt_assert: ASSERT assert_cond ';'
   {
     $$.val = &plpgsqltree.PLpgSQLStmtAssert{}
@@ -1001,25 +1107,48 @@ assert_cond:
 
 loop_body: proc_sect END LOOP opt_label ';'
   {
+    $$.val = &loopBody{
+      stmts: $1.plpgsqlStatements(),
+      label: $4,
+    }
   }
 ;
 
 // MakeExecSqlStmt read until a ';'
 stmt_execsql: IMPORT
   {
-    $$.val = plpgsqllex.(*lexer).MakeExecSqlStmt(IMPORT)
+    stmt, err := plpgsqllex.(*lexer).MakeExecSqlStmt(IMPORT)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = stmt
   }
 | INSERT
   {
-    $$.val = plpgsqllex.(*lexer).MakeExecSqlStmt(INSERT)
+    stmt, err := plpgsqllex.(*lexer).MakeExecSqlStmt(INSERT)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = stmt
   }
 | MERGE
   {
-    $$.val = plpgsqllex.(*lexer).MakeExecSqlStmt(MERGE)
+    stmt, err := plpgsqllex.(*lexer).MakeExecSqlStmt(MERGE)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = stmt
   }
 | IDENT
   {
-    $$.val = plpgsqllex.(*lexer).MakeExecSqlStmt(IDENT)
+    // A statement that starts with an IDENT is either an assignment to a
+    // variable, or a SQL statement such as SELECT. The lexer looks ahead to
+    // tell them apart.
+    stmt, err := plpgsqllex.(*lexer).MakeAssignOrExecSqlStmt()
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = stmt
   }
 ;
 
@@ -1089,48 +1218,60 @@ AND CHAIN
 
 cursor_variable: IDENT
   {
-    unimplemented(plpgsqllex, "cursor variable")
   }
 ;
 
 exception_sect:
-  { }
-| EXCEPTION
   {
-    return unimplemented(plpgsqllex, "exception")
+    $$.val = nil
   }
-  proc_exceptions
+| EXCEPTION proc_exceptions
   {
-    unimplemented(plpgsqllex, "exception")
+    $$.val = &plpgsqltree.PLpgSQLExceptionBlock{
+      ExecList: $2.plpgsqlExceptions(),
+    }
   }
 ;
 
 proc_exceptions: proc_exceptions proc_exception
   {
+    $$.val = append($1.plpgsqlExceptions(), $2.plpgsqlException())
   }
 | proc_exception
   {
+    $$.val = []*plpgsqltree.PLpgSQLException{$1.plpgsqlException()}
   }
 ;
 
 proc_exception: WHEN proc_conditions THEN proc_sect
   {
+    $$.val = &plpgsqltree.PLpgSQLException{
+      Conditions: $2.plpgsqlConditions(),
+      Action: $4.plpgsqlStatements(),
+    }
   }
 ;
 
 proc_conditions: proc_conditions OR proc_condition
   {
+    $$.val = append($1.plpgsqlConditions(), *$3.plpgsqlCondition())
   }
 | proc_condition
   {
+    $$.val = []plpgsqltree.PLpgSQLCondition{*$1.plpgsqlCondition()}
   }
 ;
 
 proc_condition: any_identifier
   {
+    $$.val = &plpgsqltree.PLpgSQLCondition{Name: $1}
   }
 | SQLSTATE SCONST
   {
+    if err := checkSQLState($2); err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = &plpgsqltree.PLpgSQLCondition{SqlErrState: $2}
   }
 ;
 
@@ -1153,7 +1294,11 @@ expr_until_then:
 
 expr_until_loop:
   {
-    return unimplemented(plpgsqllex, "loop expr")
+    expr, err := plpgsqllex.(*lexer).ParseExpr(plpgsqllex.(*lexer).ReadSqlExpressionStr(LOOP))
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = expr
   }
 ;
 
@@ -1175,15 +1320,17 @@ opt_block_label	:
 
 opt_loop_label:
   {
+    $$ = ""
   }
 | LESS_LESS any_identifier GREATER_GREATER
   {
-    return unimplemented(plpgsqllex, "loop label")
+    $$ = $2
   }
 ;
 
 opt_label:
   {
+    $$ = ""
   }
 | any_identifier
   {
@@ -1191,9 +1338,17 @@ opt_label:
 ;
 
 opt_exitcond: ';'
-  { }
+  {
+    $$.val = nil
+  }
 | WHEN expr_until_semi ';'
-  { }
+  {
+    expr, err := plpgsqllex.(*lexer).ParseExpr($2)
+    if err != nil {
+      return setErr(plpgsqllex, err)
+    }
+    $$.val = expr
+  }
 ;
 
 /*
@@ -10,9 +10,85 @@
 
 package parser
 
-import "github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/plpgsqltree"
+)
 
+// declareHeader holds the label and declarations of a block, which are parsed
+// before the block body.
 type declareHeader struct {
-	label    string
-	initVars []plpgsqltree.PLpgSQLVariable
+	label string
+	decls []plpgsqltree.PLpgSQLStatement
+}
+
+// loopBody holds the statements of a loop along with the (optional) label
+// that follows END LOOP.
+type loopBody struct {
+	stmts []plpgsqltree.PLpgSQLStatement
+	label string
+}
+
+// checkBlockLabel verifies that the label following the END of a block, if
+// any, matches the label at the start of the block.
+func checkBlockLabel(startLabel, endLabel string) error {
+	if endLabel == "" {
+		return nil
+	}
+	if startLabel == "" {
+		return pgerror.Newf(pgcode.Syntax,
+			"end label \"%s\" specified for unlabeled block", endLabel,
+		)
+	}
+	if startLabel != endLabel {
+		return pgerror.Newf(pgcode.Syntax,
+			"end label \"%s\" differs from block's label \"%s\"", endLabel, startLabel,
+		)
+	}
+	return nil
+}
+
+// checkLoopLabel verifies that the label following END LOOP, if any, matches
+// the label at the start of the loop.
+func checkLoopLabel(startLabel, endLabel string) error {
+	return checkBlockLabel(startLabel, endLabel)
+}
+
+// checkSQLState verifies that the given string is a valid five-character
+// SQLSTATE code.
+func checkSQLState(code string) error {
+	if len(code) != 5 {
+		return pgerror.New(pgcode.Syntax, "invalid SQLSTATE code")
+	}
+	for i := 0; i < len(code); i++ {
+		if (code[i] < '0' || code[i] > '9') && (code[i] < 'A' || code[i] > 'Z') {
+			return pgerror.New(pgcode.Syntax, "invalid SQLSTATE code")
+		}
+	}
+	return nil
+}
+
+// checkRaiseParams verifies that the number of parameters supplied to a RAISE
+// statement matches the number of placeholders in its format string.
+func checkRaiseParams(format string, numParams int) error {
+	numPlaceholders := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		if i+1 < len(format) && format[i+1] == '%' {
+			// "%%" is an escaped percent sign.
+			i++
+			continue
+		}
+		numPlaceholders++
+	}
+	if numPlaceholders > numParams {
+		return pgerror.New(pgcode.Syntax, "too few parameters specified for RAISE")
+	}
+	if numPlaceholders < numParams {
+		return pgerror.New(pgcode.Syntax, "too many parameters specified for RAISE")
+	}
+	return nil
 }
@@ -60,6 +60,17 @@ type PreparedStatement struct {
 	// if it is used by the optimizer as a starting point.
 	Memo *memo.Memo
 
+	// GenericMemo, if set, is a fully optimized memo in which placeholders have
+	// not been assigned values. It is built from Memo when the plan_cache_mode
+	// session setting permits generic query plans, and can be reused as-is for
+	// any placeholder values.
+	GenericMemo *memo.Memo
+
+	// Costs tracks the costs of the custom and generic plans that have been
+	// built for this prepared statement. It is used to choose between custom
+	// and generic plans when plan_cache_mode is auto.
+	Costs planCosts
+
 	// refCount keeps track of the number of references to this PreparedStatement.
 	// New references are registered through incRef().
 	// Once refCount hits 0 (through calls to decRef()), the following memAcc is
@@ -83,13 +94,73 @@ func (p *PreparedStatement) MemoryEstimate() int64 {
 	// Account for the memory used by this prepared statement:
 	//   1. Size of the prepare metadata.
 	//   2. Size of the prepared memo, if using the cost-based optimizer.
+	//   3. Size of the generic memo, if one has been built.
 	size := p.PrepareMetadata.MemoryEstimate()
 	if p.Memo != nil {
 		size += p.Memo.MemoryEstimate()
 	}
+	if p.GenericMemo != nil {
+		size += p.GenericMemo.MemoryEstimate()
+	}
 	return size
 }
 
+// customPlanThreshold is the number of custom plans that are built for a
+// prepared statement, when plan_cache_mode is auto, before a generic plan is
+// considered. This matches Postgres.
+const customPlanThreshold = 5
+
+// planCosts tracks the costs of the generic plan and of the most recent custom
+// plans built for a prepared statement.
+type planCosts struct {
+	generic memo.Cost
+	custom  struct {
+		nextIdx int
+		length  int
+		costs   [customPlanThreshold]memo.Cost
+	}
+}
+
+// Generic returns the cost of the generic plan.
+func (c *planCosts) Generic() memo.Cost {
+	return c.generic
+}
+
+// SetGeneric sets the cost of the generic plan.
+func (c *planCosts) SetGeneric(cost memo.Cost) {
+	c.generic = cost
+}
+
+// AddCustom adds the cost of a custom plan. Only the costs of the most recent
+// customPlanThreshold custom plans are retained.
+func (c *planCosts) AddCustom(cost memo.Cost) {
+	c.custom.costs[c.custom.nextIdx] = cost
+	c.custom.nextIdx = (c.custom.nextIdx + 1) % customPlanThreshold
+	if c.custom.length < customPlanThreshold {
+		c.custom.length++
+	}
+}
+
+// NumCustom returns the number of custom plan costs that are being tracked.
+func (c *planCosts) NumCustom() int {
+	return c.custom.length
+}
+
+// AvgCustom returns the average cost of the tracked custom plans. It must not
+// be called if NumCustom is zero.
+func (c *planCosts) AvgCustom() memo.Cost {
+	var sum memo.Cost
+	for i := 0; i < c.custom.length; i++ {
+		sum += c.custom.costs[i]
+	}
+	return sum / memo.Cost(c.custom.length)
+}
+
+// Reset clears all tracked costs.
+func (c *planCosts) Reset() {
+	*c = planCosts{}
+}
+
 func (p *PreparedStatement) decRef(ctx context.Context) {
 	if p.refCount <= 0 {
 		log.Fatal(ctx, "corrupt PreparedStatement refcount")
@@ -56,6 +56,10 @@ type CachedData struct {
 	// PrepareMetadata is set for prepare queries. In this case the memo contains
 	// unassigned placeholders. For non-prepared queries, it is nil.
 	PrepareMetadata *PrepareMetadata
+	// GenericMemo, if set, is a fully optimized memo for a prepared query in
+	// which placeholders have not been assigned values. It can be reused, as-is,
+	// for any placeholder values. It is only set when PrepareMetadata is set.
+	GenericMemo *memo.Memo
 	// IsCorrelated memoizes whether the query contained correlated
 	// subqueries during planning (prior to de-correlation).
 	IsCorrelated bool
@@ -66,6 +70,9 @@ func (cd *CachedData) memoryEstimate() int64 {
 	if cd.PrepareMetadata != nil {
 		res += cd.PrepareMetadata.MemoryEstimate()
 	}
+	if cd.GenericMemo != nil {
+		res += cd.GenericMemo.MemoryEstimate()
+	}
 	return res
 }
 
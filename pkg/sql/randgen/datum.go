@@ -284,11 +284,46 @@ func RandDatumWithNullChance(
 		return tree.NewDTSVector(tsearch.RandomTSVector(rng))
 	case types.TSQueryFamily:
 		return tree.NewDTSQuery(tsearch.RandomTSQuery(rng))
+	case types.RangeFamily:
+		return randRange(rng, typ)
+	case types.MultirangeFamily:
+		ranges := make([]*tree.DRange, rng.Intn(4))
+		for i := range ranges {
+			ranges[i] = randRange(rng, typ.MultirangeContents())
+		}
+		return tree.NewDMultirange(typ, ranges)
 	default:
 		panic(errors.AssertionFailedf("invalid type %v", typ.DebugString()))
 	}
 }
 
+// randRange generates a random DRange of the given range type. Each bound has
+// a 1 in 10 chance of being unbounded, and the range itself has a 1 in 10
+// chance of being empty.
+func randRange(rng *rand.Rand, typ *types.T) *tree.DRange {
+	if rng.Intn(10) == 0 {
+		return tree.NewDEmptyRange(typ)
+	}
+	var bounds [2]tree.Datum
+	for i := range bounds {
+		bounds[i] = tree.DNull
+		if rng.Intn(10) != 0 {
+			bounds[i] = RandDatum(rng, typ.RangeContents(), false /* nullOk */)
+		}
+	}
+	lowerInc, upperInc := rng.Intn(2) == 0, rng.Intn(2) == 0
+	r, err := tree.MakeDRange(typ, bounds[0], bounds[1], lowerInc, upperInc)
+	if err != nil {
+		// The bounds were generated in the wrong order (or could not be
+		// canonicalized), so try again with them swapped.
+		r, err = tree.MakeDRange(typ, bounds[1], bounds[0], lowerInc, upperInc)
+		if err != nil {
+			return tree.NewDEmptyRange(typ)
+		}
+	}
+	return r
+}
+
 // RandArray generates a random DArray where the contents have nullChance
 // of being null.
 func RandArray(rng *rand.Rand, typ *types.T, nullChance int) tree.Datum {
@@ -0,0 +1,135 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql_test
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvclient/kvcoord"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReadCommittedStmtRetry verifies that a statement in a READ COMMITTED
+// transaction which encounters a retryable error that allows for a partial
+// retry is retried on its own, up to max_retries_for_read_committed times.
+func TestReadCommittedStmtRetry(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	var tablePrefix atomic.Value
+	tablePrefix.Store(roachpb.Key(nil))
+	// errorsToInject is the number of reads of the table to fail with a
+	// ReadWithinUncertaintyIntervalError, or -1 to fail all of them.
+	var errorsToInject, injected int64
+	var s serverutils.TestServerInterface
+	filter := func(_ context.Context, ba *kvpb.BatchRequest, _ *kvpb.BatchResponse) *kvpb.Error {
+		prefix := tablePrefix.Load().(roachpb.Key)
+		if prefix == nil || ba.Txn == nil || ba.Txn.IsoLevel != isolation.ReadCommitted {
+			return nil
+		}
+		for _, ru := range ba.Requests {
+			if !bytes.HasPrefix(ru.GetInner().Header().Key, prefix) {
+				continue
+			}
+			if n := atomic.LoadInt64(&errorsToInject); n >= 0 && atomic.LoadInt64(&injected) >= n {
+				return nil
+			}
+			atomic.AddInt64(&injected, 1)
+			txn := ba.Txn.Clone()
+			txn.ResetObservedTimestamps()
+			now := s.Clock().NowAsClockTimestamp()
+			txn.UpdateObservedTimestamp(s.NodeID(), now)
+			return kvpb.NewErrorWithTxn(kvpb.NewReadWithinUncertaintyIntervalError(
+				now.ToTimestamp(), now, txn, now.ToTimestamp(), now), txn)
+		}
+		return nil
+	}
+
+	ctx := context.Background()
+	s, db, _ := serverutils.StartServer(t, base.TestServerArgs{
+		// The response filter matches the keys of the system tenant.
+		DefaultTestTenant: base.TestTenantDisabled,
+		Knobs: base.TestingKnobs{
+			Store: &kvserver.StoreTestingKnobs{
+				// A response filter avoids server-side refreshes of the errors it
+				// returns.
+				TestingResponseFilter: filter,
+			},
+			// Disable client-side refreshes, so that the errors are returned to
+			// the connExecutor.
+			KVClient: &kvcoord.ClientTestingKnobs{MaxTxnRefreshAttempts: -1},
+		},
+	})
+	defer s.Stopper().Stop(ctx)
+
+	r := sqlutils.MakeSQLRunner(db)
+	r.Exec(t, `SET CLUSTER SETTING sql.txn.read_committed_isolation.enabled = true`)
+	r.Exec(t, `CREATE TABLE t (k INT PRIMARY KEY, v INT)`)
+	r.Exec(t, `INSERT INTO t VALUES (1, 1)`)
+	tableID := sqlutils.QueryTableID(t, db, "defaultdb", "public", "t")
+	tablePrefix.Store(keys.SystemSQLCodec.TablePrefix(tableID))
+
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.ExecContext(ctx, `SET max_retries_for_read_committed = 3`)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name           string
+		errorsToInject int64
+		expInjected    int64
+		expErr         bool
+	}{
+		{name: "retry succeeds", errorsToInject: 2, expInjected: 2},
+		{name: "retry limit exceeded", errorsToInject: -1, expInjected: 4, expErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := conn.ExecContext(ctx, `BEGIN TRANSACTION ISOLATION LEVEL READ COMMITTED`)
+			require.NoError(t, err)
+			atomic.StoreInt64(&injected, 0)
+			atomic.StoreInt64(&errorsToInject, tc.errorsToInject)
+			var v int
+			err = conn.QueryRowContext(ctx, `SELECT v FROM t WHERE k = 1`).Scan(&v)
+			atomic.StoreInt64(&errorsToInject, 0)
+			require.Equal(t, tc.expInjected, atomic.LoadInt64(&injected))
+			if tc.expErr {
+				var pqErr *pq.Error
+				require.True(t, errors.As(err, &pqErr), "%+v", err)
+				require.Equal(t, pgcode.SerializationFailure.String(), string(pqErr.Code))
+				require.Contains(t, pqErr.Message, "read committed retry limit exceeded")
+				_, err = conn.ExecContext(ctx, `ROLLBACK`)
+				require.NoError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, 1, v)
+			_, err = conn.ExecContext(ctx, `COMMIT`)
+			require.NoError(t, err)
+		})
+	}
+}
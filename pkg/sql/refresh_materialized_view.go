@@ -91,6 +91,15 @@ func (n *refreshMaterializedViewNode) startExec(params runParams) error {
 		)
 	}
 
+	// An incremental refresh applies the changes made to the tables of the view
+	// since its last refresh, and falls back to a full refresh if the view or
+	// the changes do not allow it.
+	if n.n.RefreshDataOption == tree.RefreshDataIncremental {
+		if done, err := n.refreshIncrementally(params); err != nil || done {
+			return err
+		}
+	}
+
 	// Prepare the new set of indexes by cloning all existing indexes on the view.
 	newPrimaryIndex := n.desc.GetPrimaryIndex().IndexDescDeepCopy()
 	newIndexes := make([]descpb.IndexDescriptor, len(n.desc.PublicNonPrimaryIndexes()))
@@ -0,0 +1,952 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/security/username"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/errors"
+)
+
+var incrementalRefreshMaxChangedRows = settings.RegisterIntSetting(
+	settings.TenantWritable,
+	"sql.materialized_view.incremental_refresh.max_changed_rows",
+	"the maximum number of changed rows of the tables of a materialized view that "+
+		"an incremental refresh applies, above which the view is fully refreshed",
+	10000,
+	settings.NonNegativeInt,
+)
+
+// incrementalRefreshBatchSize is the number of rows or groups of a
+// materialized view that are modified by each statement of an incremental
+// refresh.
+const incrementalRefreshBatchSize = 100
+
+// incrementalViewQuery is the analysis of the query of a materialized view
+// which can be refreshed incrementally.
+type incrementalViewQuery struct {
+	query string
+	// tables are the tables in the FROM clause of the query.
+	tables []incrementalViewTable
+	// aggregate is true if the query computes aggregates, in which case the
+	// rows of the view are recomputed by group.
+	aggregate bool
+	// exprs is the select list of the query, and groupBy its GROUP BY clause.
+	exprs   tree.SelectExprs
+	groupBy tree.GroupBy
+	// groupCols are the ordinals of the columns of the view which hold the
+	// GROUP BY expressions of the query, and groupExprs are those expressions.
+	// They are set by resolveGroupCols.
+	groupCols  []int
+	groupExprs tree.Exprs
+}
+
+// incrementalViewTable is a table in the FROM clause of the query of a
+// materialized view.
+type incrementalViewTable struct {
+	name  tree.TableName
+	alias tree.Name
+	// prefix is the prefix of the references to the columns of the table in
+	// the query: its alias, or its name.
+	prefix string
+
+	desc catalog.TableDescriptor
+	// cols are the names of the columns of the table which the query can
+	// reference.
+	cols []string
+	// pkCols are the names of the primary key columns of the table, pkTypes
+	// their types, and pkOrdinals their ordinals in the ReplicationColumns of
+	// the table.
+	pkCols     []string
+	pkTypes    []*types.T
+	pkOrdinals []int
+}
+
+// incrementalViewColumn identifies a column of a table of the query of a
+// materialized view.
+type incrementalViewColumn struct {
+	table int
+	name  string
+}
+
+// incrementalAggregates are the aggregate functions whose results can be
+// maintained incrementally.
+var incrementalAggregates = map[string]struct{}{
+	"count": {}, "sum": {}, "min": {}, "max": {},
+}
+
+// analyzeIncrementalViewQuery analyzes the query of a materialized view. If
+// the view cannot be refreshed incrementally, the reason is returned instead.
+func analyzeIncrementalViewQuery(query string) (_ *incrementalViewQuery, reason string, _ error) {
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return nil, "", err
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		return nil, "its query is not a SELECT", nil
+	}
+	if sel.With != nil || sel.OrderBy != nil || sel.Limit != nil || sel.Locking != nil {
+		return nil, "its query has a WITH, ORDER BY, LIMIT or locking clause", nil
+	}
+	sc, ok := sel.Select.(*tree.SelectClause)
+	if !ok || sc.TableSelect {
+		return nil, "its query is not a simple SELECT", nil
+	}
+	if sc.Distinct || sc.DistinctOn != nil {
+		return nil, "its query uses DISTINCT", nil
+	}
+	if sc.Having != nil || len(sc.Window) > 0 {
+		return nil, "its query has a HAVING or WINDOW clause", nil
+	}
+	if sc.From.AsOf.Expr != nil {
+		return nil, "its query uses AS OF SYSTEM TIME", nil
+	}
+
+	q := &incrementalViewQuery{query: query}
+	var exprs tree.Exprs
+	var addTables func(t tree.TableExpr) string
+	addTables = func(t tree.TableExpr) string {
+		switch t := t.(type) {
+		case *tree.AliasedTableExpr:
+			tn, ok := t.Expr.(*tree.TableName)
+			if !ok {
+				return "its query reads from a source other than a table"
+			}
+			if t.Ordinality || len(t.As.Cols) > 0 {
+				return "its query uses WITH ORDINALITY or column aliases in FROM"
+			}
+			prefix := tn.FQString()
+			if t.As.Alias != "" {
+				prefix = tree.NameString(string(t.As.Alias))
+			}
+			q.tables = append(q.tables, incrementalViewTable{name: *tn, alias: t.As.Alias, prefix: prefix})
+		case *tree.ParenTableExpr:
+			return addTables(t.Expr)
+		case *tree.JoinTableExpr:
+			if t.JoinType != "" && t.JoinType != tree.AstInner && t.JoinType != tree.AstCross {
+				return fmt.Sprintf("its query uses a %s JOIN", t.JoinType)
+			}
+			switch cond := t.Cond.(type) {
+			case *tree.OnJoinCond:
+				exprs = append(exprs, cond.Expr)
+			case *tree.UsingJoinCond, *tree.NaturalJoinCond:
+				// The columns merged by USING and NATURAL joins do not belong to a
+				// single table, so references to them cannot be resolved.
+				return "its query uses a USING or NATURAL join"
+			}
+			if reason := addTables(t.Left); reason != "" {
+				return reason
+			}
+			return addTables(t.Right)
+		default:
+			return "its query reads from a source other than a table"
+		}
+		return ""
+	}
+	for _, t := range sc.From.Tables {
+		if reason := addTables(t); reason != "" {
+			return nil, reason, nil
+		}
+	}
+	if len(q.tables) == 0 {
+		return nil, "its query does not read from a table", nil
+	}
+	if sc.Where != nil {
+		exprs = append(exprs, sc.Where.Expr)
+	}
+	for _, e := range exprs {
+		if reason := checkIncrementalViewExpr(e, false /* allowAggregates */); reason != "" {
+			return nil, reason, nil
+		}
+	}
+
+	for _, e := range sc.Exprs {
+		if reason := checkIncrementalViewExpr(e.Expr, true /* allowAggregates */); reason != "" {
+			return nil, reason, nil
+		}
+		if hasIncrementalAggregate(e.Expr) {
+			q.aggregate = true
+		}
+	}
+	if len(sc.GroupBy) == 0 {
+		return q, "", nil
+	}
+	q.aggregate = true
+	for _, g := range sc.GroupBy {
+		if reason := checkIncrementalViewExpr(g, false /* allowAggregates */); reason != "" {
+			return nil, reason, nil
+		}
+	}
+	q.exprs, q.groupBy = sc.Exprs, sc.GroupBy
+	return q, "", nil
+}
+
+// resolveGroupCols matches the GROUP BY expressions of the query with the
+// columns of the view, so that the rows of the view can be matched with their
+// groups. A GROUP BY expression matches a column of the view if it is its
+// ordinal or its alias, or if both refer to the same column of a table. It
+// returns the reason why the view cannot be refreshed incrementally if an
+// expression matches no column. The columns of the tables must be set.
+func (q *incrementalViewQuery) resolveGroupCols() (reason string) {
+	for _, g := range q.groupBy {
+		col := -1
+		if num, ok := g.(*tree.NumVal); ok {
+			if i, err := num.AsInt64(); err == nil && i >= 1 && int(i) <= len(q.exprs) {
+				col = int(i) - 1
+			}
+		} else if ref, ok := q.resolveColumn(g); ok {
+			for i, e := range q.exprs {
+				if r, ok := q.resolveColumn(e.Expr); ok && r == ref {
+					col = i
+					break
+				}
+			}
+		} else if n, ok := g.(*tree.UnresolvedName); ok && n.NumParts == 1 {
+			for i, e := range q.exprs {
+				if e.As != "" && string(e.As) == n.Parts[0] {
+					col = i
+					break
+				}
+			}
+		}
+		if col < 0 {
+			return fmt.Sprintf("its GROUP BY expression %s is not a column of the view", tree.AsString(g))
+		}
+		q.groupCols = append(q.groupCols, col)
+		q.groupExprs = append(q.groupExprs, q.exprs[col].Expr)
+	}
+	return ""
+}
+
+// resolveColumn resolves a reference to a column of one of the tables of the
+// query. It returns false if the expression is not a column reference, or if
+// the reference is ambiguous.
+func (q *incrementalViewQuery) resolveColumn(expr tree.Expr) (incrementalViewColumn, bool) {
+	if p, ok := expr.(*tree.ParenExpr); ok {
+		return q.resolveColumn(p.Expr)
+	}
+	n, ok := expr.(*tree.UnresolvedName)
+	if !ok || n.Star {
+		return incrementalViewColumn{}, false
+	}
+	v, err := n.NormalizeVarName()
+	if err != nil {
+		return incrementalViewColumn{}, false
+	}
+	c, ok := v.(*tree.ColumnItem)
+	if !ok {
+		return incrementalViewColumn{}, false
+	}
+	res := incrementalViewColumn{table: -1, name: string(c.ColumnName)}
+	for i := range q.tables {
+		t := &q.tables[i]
+		if (c.TableName != nil && !t.matches(c.TableName)) || !t.hasColumn(res.name) {
+			continue
+		}
+		if res.table >= 0 {
+			return incrementalViewColumn{}, false
+		}
+		res.table = i
+	}
+	return res, res.table >= 0
+}
+
+// matches returns true if the table name which qualifies a column reference
+// refers to the table.
+func (t *incrementalViewTable) matches(tn *tree.UnresolvedObjectName) bool {
+	if t.alias != "" {
+		return tn.NumParts == 1 && tn.Parts[0] == string(t.alias)
+	}
+	// The parts of the name are stored in reverse order.
+	parts := [...]string{t.name.Table(), t.name.Schema(), t.name.Catalog()}
+	for i := 0; i < tn.NumParts; i++ {
+		if i >= len(parts) || tn.Parts[i] != parts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// hasColumn returns true if the query can reference a column of the table
+// with the given name.
+func (t *incrementalViewTable) hasColumn(name string) bool {
+	for _, c := range t.cols {
+		if c == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkIncrementalViewExpr checks that an expression of the query of a
+// materialized view only depends on the rows it reads, and returns the reason
+// why it cannot be maintained incrementally otherwise.
+func checkIncrementalViewExpr(expr tree.Expr, allowAggregates bool) string {
+	v := incrementalViewExprVisitor{allowAggregates: allowAggregates}
+	tree.WalkExprConst(&v, expr)
+	return v.reason
+}
+
+type incrementalViewExprVisitor struct {
+	allowAggregates bool
+	inAggregate     bool
+	reason          string
+}
+
+var _ tree.Visitor = &incrementalViewExprVisitor{}
+
+// VisitPre implements the tree.Visitor interface.
+func (v *incrementalViewExprVisitor) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if v.reason != "" {
+		return false, expr
+	}
+	switch t := expr.(type) {
+	case *tree.Subquery:
+		v.reason = "its query contains a subquery"
+		return false, expr
+	case *tree.FuncExpr:
+		name := incrementalFuncName(t)
+		if _, ok := incrementalAggregates[name]; !ok || !v.allowAggregates || v.inAggregate {
+			v.reason = fmt.Sprintf("its query calls %s()", name)
+			return false, expr
+		}
+		if t.Type == tree.DistinctFuncType || t.Filter != nil || t.WindowDef != nil || len(t.OrderBy) > 0 {
+			v.reason = fmt.Sprintf("its query calls %s() with DISTINCT, FILTER, OVER or ORDER BY", name)
+			return false, expr
+		}
+		for _, arg := range t.Exprs {
+			if _, ok := arg.(tree.UnqualifiedStar); ok {
+				continue
+			}
+			v.inAggregate = true
+			tree.WalkExprConst(v, arg)
+			v.inAggregate = false
+		}
+		return false, expr
+	}
+	return true, expr
+}
+
+// VisitPost implements the tree.Visitor interface.
+func (v *incrementalViewExprVisitor) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// incrementalFuncName returns the unqualified name of the function called by
+// a FuncExpr.
+func incrementalFuncName(f *tree.FuncExpr) string {
+	name := strings.ToLower(tree.AsString(&f.Func))
+	return strings.TrimPrefix(name, "pg_catalog.")
+}
+
+// hasIncrementalAggregate returns true if the expression calls an aggregate
+// function.
+func hasIncrementalAggregate(expr tree.Expr) bool {
+	found := false
+	tree.WalkExprConst(aggregateFinder{found: &found}, expr)
+	return found
+}
+
+type aggregateFinder struct {
+	found *bool
+}
+
+// VisitPre implements the tree.Visitor interface.
+func (a aggregateFinder) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if f, ok := expr.(*tree.FuncExpr); ok {
+		if _, ok := incrementalAggregates[incrementalFuncName(f)]; ok {
+			*a.found = true
+			return false, expr
+		}
+	}
+	return !*a.found, expr
+}
+
+// VisitPost implements the tree.Visitor interface.
+func (aggregateFinder) VisitPost(expr tree.Expr) tree.Expr { return expr }
+
+// selectSQL returns the query of the view, with an additional filter and run
+// as of the given timestamp. If exprs is set, it replaces the select list,
+// and the rows are not grouped.
+func (q *incrementalViewQuery) selectSQL(
+	filter string, exprs tree.Exprs, asOf hlc.Timestamp,
+) (string, error) {
+	stmt, err := parser.ParseOne(q.query)
+	if err != nil {
+		return "", err
+	}
+	sc := stmt.AST.(*tree.Select).Select.(*tree.SelectClause)
+	if filter != "" {
+		f, err := parser.ParseExpr(filter)
+		if err != nil {
+			return "", err
+		}
+		if sc.Where == nil {
+			sc.Where = tree.NewWhere(tree.AstWhere, f)
+		} else {
+			sc.Where.Expr = &tree.AndExpr{
+				Left:  &tree.ParenExpr{Expr: sc.Where.Expr},
+				Right: &tree.ParenExpr{Expr: f},
+			}
+		}
+	}
+	if exprs != nil {
+		sc.Exprs = make(tree.SelectExprs, len(exprs))
+		for i, e := range exprs {
+			sc.Exprs[i] = tree.SelectExpr{Expr: e}
+		}
+		sc.GroupBy = nil
+	}
+	sc.From.AsOf = tree.AsOfClause{Expr: tree.NewStrVal(asOf.AsOfSystemTime())}
+	return tree.AsStringWithFlags(stmt.AST, tree.FmtParsable), nil
+}
+
+// incrementalQueryArgs are the arguments of a statement of an incremental
+// refresh. The values of the rows of the tables and the view are passed as
+// arguments, rather than formatted into the statement.
+type incrementalQueryArgs []interface{}
+
+// add adds an argument, and returns the placeholder which refers to it.
+func (a *incrementalQueryArgs) add(d tree.Datum) string {
+	*a = append(*a, d)
+	return fmt.Sprintf("$%d", len(*a))
+}
+
+// changedRowsFilter returns a filter of the query of the view which selects
+// the rows derived from at least one changed row of its tables. The primary
+// keys of the changed rows are added to args, as an array per column.
+func (q *incrementalViewQuery) changedRowsFilter(
+	changed map[descpb.ID][]tree.Datums, args *incrementalQueryArgs,
+) (string, error) {
+	var disjuncts []string
+	for _, t := range q.tables {
+		keys := changed[t.desc.GetID()]
+		if len(keys) == 0 {
+			continue
+		}
+		cols := make([]string, len(t.pkCols))
+		arrays := make([]string, len(t.pkCols))
+		for i, c := range t.pkCols {
+			cols[i] = t.prefix + "." + c
+			arr := tree.NewDArray(t.pkTypes[i])
+			for _, k := range keys {
+				if err := arr.Append(k[i]); err != nil {
+					return "", err
+				}
+			}
+			arrays[i] = args.add(arr)
+		}
+		if len(cols) == 1 {
+			disjuncts = append(disjuncts, fmt.Sprintf("%s = ANY (%s)", cols[0], arrays[0]))
+		} else {
+			disjuncts = append(disjuncts, fmt.Sprintf("(%s) IN (SELECT * FROM unnest(%s))",
+				strings.Join(cols, ", "), strings.Join(arrays, ", ")))
+		}
+	}
+	return strings.Join(disjuncts, " OR "), nil
+}
+
+// datumsKey returns a string which identifies the given datums, to
+// deduplicate and count rows.
+func datumsKey(datums tree.Datums) string {
+	var b strings.Builder
+	for i, d := range datums {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(tree.AsStringWithFlags(d, tree.FmtParsable))
+	}
+	return b.String()
+}
+
+// matchSQL returns a predicate which matches the given values, including
+// NULLs, against the given expressions. The non-NULL values are added to args.
+func matchSQL(exprs []string, datums tree.Datums, args *incrementalQueryArgs) string {
+	conjuncts := make([]string, len(exprs))
+	for i, e := range exprs {
+		if datums[i] == tree.DNull {
+			conjuncts[i] = fmt.Sprintf("(%s) IS NULL", e)
+		} else {
+			conjuncts[i] = fmt.Sprintf("(%s) = %s", e, args.add(datums[i]))
+		}
+	}
+	return "(" + strings.Join(conjuncts, " AND ") + ")"
+}
+
+var (
+	errIncrementalRefreshFeedDone       = errors.New("incremental refresh feed is resolved")
+	errIncrementalRefreshTooManyChanges = errors.New("too many changed rows")
+)
+
+// incrementalRefreshSink collects the primary keys of the rows of the tables
+// of a materialized view which changed until a given timestamp.
+type incrementalRefreshSink struct {
+	end        hlc.Timestamp
+	maxChanges int
+	pkOrdinals map[descpb.ID][]int
+
+	seen       map[string]struct{}
+	changed    map[descpb.ID][]tree.Datums
+	numChanged int
+}
+
+var _ ReplicationFeedSink = &incrementalRefreshSink{}
+
+// AddChange implements the ReplicationFeedSink interface.
+func (s *incrementalRefreshSink) AddChange(_ context.Context, change ReplicationChange) error {
+	if s.end.Less(change.Timestamp) {
+		return nil
+	}
+	id := change.Table.GetID()
+	// The primary key of a row only changes through a deletion and an
+	// insertion, but both are recorded to be safe.
+	for _, row := range []tree.Datums{change.Row, change.PrevRow} {
+		if row == nil {
+			continue
+		}
+		key := make(tree.Datums, len(s.pkOrdinals[id]))
+		for i, ord := range s.pkOrdinals[id] {
+			key[i] = row[ord]
+		}
+		k := fmt.Sprintf("%d/%s", id, datumsKey(key))
+		if _, ok := s.seen[k]; ok {
+			continue
+		}
+		s.seen[k] = struct{}{}
+		s.changed[id] = append(s.changed[id], key)
+		s.numChanged++
+	}
+	if s.numChanged > s.maxChanges {
+		return errIncrementalRefreshTooManyChanges
+	}
+	return nil
+}
+
+// Resolved implements the ReplicationFeedSink interface.
+func (s *incrementalRefreshSink) Resolved(_ context.Context, ts hlc.Timestamp) error {
+	if s.end.LessEq(ts) {
+		return errIncrementalRefreshFeedDone
+	}
+	return nil
+}
+
+// refreshIncrementally refreshes the view by applying the changes made to the
+// tables of its query since its last refresh. It returns false, after sending
+// a notice which explains why, if the view must be fully refreshed instead.
+func (n *refreshMaterializedViewNode) refreshIncrementally(params runParams) (bool, error) {
+	ctx, p := params.ctx, params.p
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_IncrementalMaterializedViewRefresh) {
+		return false, pgerror.New(pgcode.FeatureNotSupported,
+			"incremental refresh of materialized views is not supported until the cluster version is finalized")
+	}
+	fallback := func(reason string) (bool, error) {
+		p.BufferClientNotice(ctx, pgnotice.Newf(
+			"cannot refresh materialized view %q incrementally because %s; performing a full refresh",
+			n.desc.GetName(), reason,
+		))
+		return false, nil
+	}
+
+	since := n.desc.GetLastRefreshTime()
+	if n.desc.IsRefreshViewRequired() || since.IsEmpty() {
+		return fallback("the time of its last refresh is unknown")
+	}
+	q, reason, err := analyzeIncrementalViewQuery(n.desc.GetViewQuery())
+	if err != nil {
+		return false, err
+	}
+	if reason != "" {
+		return fallback(reason)
+	}
+	if ReplicationFeedHook == nil {
+		return fallback("it requires a CCL binary")
+	}
+
+	// Resolve the tables of the query, and check that their changes can be
+	// tracked by primary key.
+	var descs []catalog.TableDescriptor
+	pkOrdinals := make(map[descpb.ID][]int)
+	for i := range q.tables {
+		t := &q.tables[i]
+		_, desc, err := resolver.ResolveExistingTableObject(ctx, p, &t.name, tree.ObjectLookupFlags{
+			Required:               true,
+			DesiredObjectKind:      tree.TableObject,
+			AllowWithoutPrimaryKey: true,
+		})
+		if err != nil {
+			return false, err
+		}
+		t.desc = desc
+		if !desc.IsPhysicalTable() || desc.IsSequence() || (desc.IsView() && !desc.MaterializedView()) {
+			return fallback(fmt.Sprintf("%q is not a table", desc.GetName()))
+		}
+		if len(desc.GetFamilies()) > 1 {
+			return fallback(fmt.Sprintf("table %q has multiple column families", desc.GetName()))
+		}
+		for _, col := range desc.AccessibleColumns() {
+			t.cols = append(t.cols, col.GetName())
+		}
+		cols := ReplicationColumns(desc)
+		pk := desc.GetPrimaryIndex()
+		for j := 0; j < pk.NumKeyColumns(); j++ {
+			ord := -1
+			for k, col := range cols {
+				if col.GetID() == pk.GetKeyColumnID(j) {
+					ord = k
+				}
+			}
+			if ord < 0 {
+				return fallback(fmt.Sprintf("the primary key of table %q has hidden or virtual columns", desc.GetName()))
+			}
+			typ := cols[ord].GetType()
+			if ok, _ := types.IsValidArrayElementType(typ); !ok || typ.Family() == types.ArrayFamily {
+				return fallback(fmt.Sprintf("the primary key of table %q has a column of type %s",
+					desc.GetName(), typ.SQLString()))
+			}
+			t.pkCols = append(t.pkCols, tree.NameString(cols[ord].GetName()))
+			t.pkTypes = append(t.pkTypes, typ)
+			t.pkOrdinals = append(t.pkOrdinals, ord)
+		}
+		if _, ok := pkOrdinals[desc.GetID()]; ok {
+			continue
+		}
+		if changed, err := rowLayoutChangedSince(ctx, p.ExecCfg(), desc, since); err != nil {
+			return fallback(err.Error())
+		} else if changed {
+			return fallback(fmt.Sprintf("the columns of table %q changed since its last refresh", desc.GetName()))
+		}
+		pkOrdinals[desc.GetID()] = t.pkOrdinals
+		descs = append(descs, desc)
+	}
+	if reason := q.resolveGroupCols(); reason != "" {
+		return fallback(reason)
+	}
+
+	// Collect the primary keys of the rows which changed since the last
+	// refresh, until a resolved timestamp.
+	end := p.ExecCfg().Clock.Now()
+	maxChanges := int(incrementalRefreshMaxChangedRows.Get(&p.ExecCfg().Settings.SV))
+	sink := &incrementalRefreshSink{
+		end:        end,
+		maxChanges: maxChanges,
+		pkOrdinals: pkOrdinals,
+		seen:       make(map[string]struct{}),
+		changed:    make(map[descpb.ID][]tree.Datums),
+	}
+	if err := ReplicationFeedHook(ctx, p.ExecCfg(), descs, since, sink); !errors.Is(err, errIncrementalRefreshFeedDone) {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		if errors.Is(err, errIncrementalRefreshTooManyChanges) {
+			return fallback(fmt.Sprintf("the number of changed rows of its tables exceeds %s = %d",
+				incrementalRefreshMaxChangedRows.Key(), maxChanges))
+		}
+		if err == nil {
+			err = errors.New("the feed of changes ended")
+		}
+		return fallback(err.Error())
+	}
+
+	if sink.numChanged > 0 {
+		var args incrementalQueryArgs
+		var filter string
+		if filter, err = q.changedRowsFilter(sink.changed, &args); err != nil {
+			return false, err
+		}
+		if q.aggregate {
+			err = n.applyGroupChanges(params, q, filter, args, since, end)
+		} else {
+			err = n.applyRowChanges(params, q, filter, args, since, end)
+		}
+		if err != nil {
+			// The data as of the last refresh may have been garbage collected.
+			if errors.HasType(err, (*kvpb.BatchTimestampBeforeGCError)(nil)) {
+				return fallback(err.Error())
+			}
+			return false, err
+		}
+	}
+
+	n.desc.LastRefreshTime = end
+	return true, p.writeSchemaChange(
+		ctx, n.desc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+// rowLayoutChangedSince returns true if the columns of a table, or its
+// primary key, changed since the given timestamp.
+func rowLayoutChangedSince(
+	ctx context.Context, execCfg *ExecutorConfig, desc catalog.TableDescriptor, since hlc.Timestamp,
+) (bool, error) {
+	if len(desc.AllMutations()) > 0 {
+		return true, nil
+	}
+	if !since.Less(desc.GetModificationTime()) {
+		return false, nil
+	}
+	leased, err := execCfg.LeaseManager.Acquire(ctx, since, desc.GetID())
+	if err != nil {
+		return false, err
+	}
+	defer leased.Release(ctx)
+	prev, ok := leased.Underlying().(catalog.TableDescriptor)
+	if !ok {
+		return true, nil
+	}
+	before, after := ReplicationColumns(prev), ReplicationColumns(desc)
+	if len(before) != len(after) ||
+		prev.GetPrimaryIndex().NumKeyColumns() != desc.GetPrimaryIndex().NumKeyColumns() {
+		return true, nil
+	}
+	for i := range before {
+		if before[i].GetID() != after[i].GetID() || before[i].GetName() != after[i].GetName() ||
+			!before[i].GetType().Identical(after[i].GetType()) {
+			return true, nil
+		}
+	}
+	for i := 0; i < desc.GetPrimaryIndex().NumKeyColumns(); i++ {
+		if prev.GetPrimaryIndex().GetKeyColumnID(i) != desc.GetPrimaryIndex().GetKeyColumnID(i) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// viewMutationOverride is used to modify the rows of a materialized view.
+var viewMutationOverride = sessiondata.InternalExecutorOverride{
+	User:                           username.RootUserName(),
+	AllowMaterializedViewMutations: true,
+}
+
+// applyRowChanges applies the changes to the rows of a view which does not
+// aggregate. The rows derived from the changed rows, selected by the filter
+// and its arguments, are computed as of the last refresh and as of now, and
+// the difference is applied to the view.
+func (n *refreshMaterializedViewNode) applyRowChanges(
+	params runParams,
+	q *incrementalViewQuery,
+	filter string,
+	filterArgs incrementalQueryArgs,
+	since, end hlc.Timestamp,
+) error {
+	ctx, p := params.ctx, params.p
+	ie := p.ExecCfg().InternalDB.Executor()
+	counts := make(map[string]int)
+	rows := make(map[string]tree.Datums)
+	for _, snapshot := range []struct {
+		asOf hlc.Timestamp
+		sign int
+	}{{since, 1}, {end, -1}} {
+		query, err := q.selectSQL(filter, nil /* exprs */, snapshot.asOf)
+		if err != nil {
+			return err
+		}
+		res, err := ie.QueryBufferedEx(
+			ctx, "refresh-materialized-view-changes", nil /* txn */, sessiondata.RootUserSessionDataOverride,
+			query, filterArgs...,
+		)
+		if err != nil {
+			return err
+		}
+		for _, row := range res {
+			k := datumsKey(row)
+			counts[k] += snapshot.sign
+			rows[k] = row
+		}
+	}
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	view, cols, err := n.viewColumns(params)
+	if err != nil {
+		return err
+	}
+	var inserts []tree.Datums
+	for _, k := range keys {
+		switch c := counts[k]; {
+		case c > 0:
+			// Rows of the view are not distinguishable, so any c of the rows with
+			// the old values are deleted.
+			var args incrementalQueryArgs
+			if _, err := p.InternalSQLTxn().ExecEx(
+				ctx, "refresh-materialized-view-delete", p.Txn(), viewMutationOverride,
+				fmt.Sprintf("DELETE FROM %s WHERE %s LIMIT %d", view, matchSQL(cols, rows[k], &args), c),
+				args...,
+			); err != nil {
+				return err
+			}
+		case c < 0:
+			for ; c < 0; c++ {
+				inserts = append(inserts, rows[k])
+			}
+		}
+	}
+	return n.insertViewRows(params, view, cols, inserts)
+}
+
+// applyGroupChanges applies the changes to the rows of a view which
+// aggregates. The groups of the changed rows, selected by the filter and its
+// arguments, as of the last refresh and as of now are recomputed, and replace
+// their rows in the view.
+func (n *refreshMaterializedViewNode) applyGroupChanges(
+	params runParams,
+	q *incrementalViewQuery,
+	filter string,
+	filterArgs incrementalQueryArgs,
+	since, end hlc.Timestamp,
+) error {
+	ctx, p := params.ctx, params.p
+	ie := p.ExecCfg().InternalDB.Executor()
+	view, cols, err := n.viewColumns(params)
+	if err != nil {
+		return err
+	}
+
+	// Without GROUP BY, the view has a single row which is always recomputed.
+	groups := []tree.Datums{nil}
+	if len(q.groupCols) > 0 {
+		groups = groups[:0]
+		seen := make(map[string]struct{})
+		for _, asOf := range []hlc.Timestamp{since, end} {
+			query, err := q.selectSQL(filter, q.groupExprs, asOf)
+			if err != nil {
+				return err
+			}
+			res, err := ie.QueryBufferedEx(
+				ctx, "refresh-materialized-view-groups", nil /* txn */, sessiondata.RootUserSessionDataOverride,
+				query, filterArgs...,
+			)
+			if err != nil {
+				return err
+			}
+			for _, row := range res {
+				k := datumsKey(row)
+				if _, ok := seen[k]; !ok {
+					seen[k] = struct{}{}
+					groups = append(groups, row)
+				}
+			}
+		}
+	}
+	groupExprs := make([]string, len(q.groupExprs))
+	for i, e := range q.groupExprs {
+		groupExprs[i] = tree.AsStringWithFlags(e, tree.FmtParsable)
+	}
+	groupCols := make([]string, len(q.groupCols))
+	for i, c := range q.groupCols {
+		groupCols[i] = cols[c]
+	}
+
+	for len(groups) > 0 {
+		batch := groups
+		if len(batch) > incrementalRefreshBatchSize {
+			batch = batch[:incrementalRefreshBatchSize]
+		}
+		groups = groups[len(batch):]
+
+		var queryFilter, viewFilter []string
+		var queryArgs, viewArgs incrementalQueryArgs
+		for _, g := range batch {
+			if g != nil {
+				queryFilter = append(queryFilter, matchSQL(groupExprs, g, &queryArgs))
+				viewFilter = append(viewFilter, matchSQL(groupCols, g, &viewArgs))
+			}
+		}
+		query, err := q.selectSQL(strings.Join(queryFilter, " OR "), nil /* exprs */, end)
+		if err != nil {
+			return err
+		}
+		res, err := ie.QueryBufferedEx(
+			ctx, "refresh-materialized-view-changes", nil /* txn */, sessiondata.RootUserSessionDataOverride,
+			query, queryArgs...,
+		)
+		if err != nil {
+			return err
+		}
+		del := "DELETE FROM " + view
+		if len(viewFilter) > 0 {
+			del += " WHERE " + strings.Join(viewFilter, " OR ")
+		}
+		if _, err := p.InternalSQLTxn().ExecEx(
+			ctx, "refresh-materialized-view-delete", p.Txn(), viewMutationOverride, del, viewArgs...,
+		); err != nil {
+			return err
+		}
+		if err := n.insertViewRows(params, view, cols, res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// viewColumns returns the qualified name of the view, and the names of its
+// columns, in the order of its query.
+func (n *refreshMaterializedViewNode) viewColumns(params runParams) (string, []string, error) {
+	tn, err := params.p.getQualifiedTableName(params.ctx, n.desc)
+	if err != nil {
+		return "", nil, err
+	}
+	var cols []string
+	for _, col := range n.desc.VisibleColumns() {
+		cols = append(cols, tree.NameString(col.GetName()))
+	}
+	return tn.FQString(), cols, nil
+}
+
+// insertViewRows inserts rows into the view, in batches.
+func (n *refreshMaterializedViewNode) insertViewRows(
+	params runParams, view string, cols []string, rows []tree.Datums,
+) error {
+	for len(rows) > 0 {
+		batch := rows
+		if len(batch) > incrementalRefreshBatchSize {
+			batch = batch[:incrementalRefreshBatchSize]
+		}
+		rows = rows[len(batch):]
+		var args incrementalQueryArgs
+		values := make([]string, len(batch))
+		placeholders := make([]string, len(cols))
+		for i, row := range batch {
+			for j, d := range row {
+				placeholders[j] = args.add(d)
+			}
+			values[i] = "(" + strings.Join(placeholders, ", ") + ")"
+		}
+		if _, err := params.p.InternalSQLTxn().ExecEx(
+			params.ctx, "refresh-materialized-view-insert", params.p.Txn(), viewMutationOverride,
+			fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", view, strings.Join(cols, ", "), strings.Join(values, ", ")),
+			args...,
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzeIncrementalViewQuery(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	testCases := []struct {
+		query     string
+		tables    []string
+		aggregate bool
+		groupCols []int
+		reason    string
+	}{
+		{
+			query:  "SELECT a, b FROM db.public.t WHERE a > 1",
+			tables: []string{"db.public.t"},
+		},
+		{
+			query:  "SELECT x.a, y.c FROM db.public.t AS x JOIN db.public.u AS y ON x.a = y.a, db.public.v",
+			tables: []string{"x", "y", "db.public.v"},
+		},
+		{
+			query:     "SELECT b, count(*), sum(a) + 1 FROM db.public.t GROUP BY b",
+			tables:    []string{"db.public.t"},
+			aggregate: true,
+			groupCols: []int{0},
+		},
+		{
+			query:     "SELECT t.b, count(*) FROM db.public.t GROUP BY b",
+			tables:    []string{"db.public.t"},
+			aggregate: true,
+			groupCols: []int{0},
+		},
+		{
+			query:     "SELECT x.b, count(*) FROM db.public.t AS x GROUP BY x.b",
+			tables:    []string{"x"},
+			aggregate: true,
+			groupCols: []int{0},
+		},
+		{
+			query:     "SELECT max(a), b AS k FROM db.public.t GROUP BY k, 2",
+			tables:    []string{"db.public.t"},
+			aggregate: true,
+			groupCols: []int{1, 1},
+		},
+		{
+			query:     "SELECT count(*) FROM db.public.t",
+			tables:    []string{"db.public.t"},
+			aggregate: true,
+		},
+		{
+			query:  "SELECT a FROM db.public.t LEFT JOIN db.public.u USING (a)",
+			reason: "its query uses a LEFT JOIN",
+		},
+		{
+			query:  "SELECT a FROM db.public.t JOIN db.public.u USING (a)",
+			reason: "its query uses a USING or NATURAL join",
+		},
+		{
+			query:  "SELECT a FROM db.public.t NATURAL JOIN db.public.u",
+			reason: "its query uses a USING or NATURAL join",
+		},
+		{
+			query:  "SELECT DISTINCT a FROM db.public.t",
+			reason: "its query uses DISTINCT",
+		},
+		{
+			query:  "SELECT a FROM db.public.t ORDER BY a LIMIT 1",
+			reason: "its query has a WITH, ORDER BY, LIMIT or locking clause",
+		},
+		{
+			query:  "SELECT a FROM db.public.t WHERE a < now()",
+			reason: "its query calls now()",
+		},
+		{
+			query:  "SELECT avg(a) FROM db.public.t",
+			reason: "its query calls avg()",
+		},
+		{
+			query:  "SELECT count(DISTINCT a) FROM db.public.t",
+			reason: "its query calls count() with DISTINCT, FILTER, OVER or ORDER BY",
+		},
+		{
+			query:  "SELECT sum(a) FROM db.public.t GROUP BY b",
+			reason: "its GROUP BY expression b is not a column of the view",
+		},
+		{
+			query:  "SELECT b + 1, count(*) FROM db.public.t GROUP BY b + 1",
+			reason: "its GROUP BY expression b + 1 is not a column of the view",
+		},
+		{
+			query:  "SELECT u.b, count(*) FROM db.public.t, db.public.u GROUP BY t.b",
+			reason: "its GROUP BY expression t.b is not a column of the view",
+		},
+		{
+			query:  "SELECT a FROM (SELECT a FROM db.public.t) AS s",
+			reason: "its query reads from a source other than a table",
+		},
+		{
+			query:  "SELECT a FROM db.public.t WHERE a IN (SELECT a FROM db.public.u)",
+			reason: "its query contains a subquery",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.query, func(t *testing.T) {
+			q, reason, err := analyzeIncrementalViewQuery(tc.query)
+			require.NoError(t, err)
+			if reason == "" {
+				// Every table has the columns a and b.
+				for i := range q.tables {
+					q.tables[i].cols = []string{"a", "b"}
+				}
+				reason = q.resolveGroupCols()
+			}
+			require.Equal(t, tc.reason, reason)
+			if tc.reason != "" {
+				return
+			}
+			var tables []string
+			for _, tab := range q.tables {
+				tables = append(tables, tab.prefix)
+			}
+			require.Equal(t, tc.tables, tables)
+			require.Equal(t, tc.aggregate, q.aggregate)
+			require.Equal(t, tc.groupCols, q.groupCols)
+		})
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgrepl"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/syntheticprivilege"
+	"github.com/cockroachdb/errors"
+)
+
+// maxReplicationSlotNameLength is the maximum length of the name of a
+// replication slot, which is the maximum length of identifiers in Postgres.
+const maxReplicationSlotNameLength = 63
+
+// checkReplicationPrivilege checks that the user can use the replication
+// commands.
+func (p *planner) checkReplicationPrivilege(ctx context.Context) error {
+	if isAdmin, err := p.HasAdminRole(ctx); err != nil {
+		return err
+	} else if isAdmin {
+		return nil
+	}
+	if ok, err := p.HasPrivilege(
+		ctx, syntheticprivilege.GlobalPrivilegeObject, privilege.REPLICATION, p.User(),
+	); err != nil {
+		return err
+	} else if !ok {
+		return pgerror.New(pgcode.InsufficientPrivilege,
+			"must have REPLICATION privilege to use replication slots")
+	}
+	return nil
+}
+
+// checkReplicationCommand checks that a replication command can run in the
+// current session.
+func (p *planner) checkReplicationCommand(ctx context.Context) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.V23_2_LogicalReplication) {
+		return pgerror.New(pgcode.FeatureNotSupported,
+			"logical replication is not supported until the cluster version is finalized")
+	}
+	if p.CurrentDatabase() == "" {
+		return errNoDatabase
+	}
+	return p.checkReplicationPrivilege(ctx)
+}
+
+// validateReplicationSlotName checks that the name of a replication slot
+// only contains the characters allowed by Postgres.
+func validateReplicationSlotName(name string) error {
+	if name == "" || len(name) > maxReplicationSlotNameLength {
+		return pgerror.Newf(pgcode.InvalidName,
+			"replication slot name %q must contain between 1 and %d characters",
+			name, maxReplicationSlotNameLength)
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '_' {
+			return errors.WithHint(
+				pgerror.Newf(pgcode.InvalidName,
+					"replication slot name %q contains invalid character", name),
+				"Replication slot names may only contain lower case letters, numbers, and the underscore character.")
+		}
+	}
+	return nil
+}
+
+type identifySystemNode struct {
+	optColumnsSlot
+
+	row  tree.Datums
+	done bool
+}
+
+// IdentifySystem returns the identity of the cluster to a replication client.
+// The current position of the replication log is the latest position which
+// is guaranteed to be complete.
+// Privileges: REPLICATION.
+func (p *planner) IdentifySystem(ctx context.Context, n *tree.IdentifySystem) (planNode, error) {
+	if err := p.checkReplicationCommand(ctx); err != nil {
+		return nil, err
+	}
+	xlogPos := pgrepl.ResolvedLSN(p.ExecCfg().Clock.Now())
+	return &identifySystemNode{
+		row: tree.Datums{
+			tree.NewDString(p.ExecCfg().NodeInfo.LogicalClusterID().String()),
+			tree.NewDInt(1),
+			tree.NewDString(xlogPos.String()),
+			tree.NewDString(p.CurrentDatabase()),
+		},
+	}, nil
+}
+
+func (n *identifySystemNode) startExec(params runParams) error { return nil }
+
+func (n *identifySystemNode) Next(params runParams) (bool, error) {
+	if n.done {
+		return false, nil
+	}
+	n.done = true
+	return true, nil
+}
+
+func (n *identifySystemNode) Values() tree.Datums       { return n.row }
+func (n *identifySystemNode) Close(ctx context.Context) {}
+
+type createReplicationSlotNode struct {
+	optColumnsSlot
+
+	n    *tree.CreateReplicationSlot
+	row  tree.Datums
+	done bool
+}
+
+// CreateReplicationSlot creates a logical replication slot in the current
+// database. Streaming from the slot starts with the changes committed after
+// the slot is created.
+// Privileges: REPLICATION.
+func (p *planner) CreateReplicationSlot(
+	ctx context.Context, n *tree.CreateReplicationSlot,
+) (planNode, error) {
+	if err := p.checkReplicationCommand(ctx); err != nil {
+		return nil, err
+	}
+	if err := validateReplicationSlotName(string(n.Slot)); err != nil {
+		return nil, err
+	}
+	if n.Temporary {
+		return nil, pgerror.New(pgcode.FeatureNotSupported,
+			"temporary replication slots are not supported")
+	}
+	if n.Plugin != pgrepl.PluginPgoutput {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"output plugin %q is not supported", n.Plugin)
+	}
+	for _, opt := range n.Options {
+		switch opt.Key {
+		case "snapshot":
+			// Snapshots are not exported, since clients can read the tables as of
+			// any timestamp with AS OF SYSTEM TIME.
+		default:
+			return nil, pgerror.Newf(pgcode.Syntax,
+				"unrecognized replication slot option %q", opt.Key)
+		}
+	}
+	return &createReplicationSlotNode{n: n}, nil
+}
+
+func (n *createReplicationSlotNode) startExec(params runParams) error {
+	dbDesc, err := params.p.Descriptors().ByNameWithLeased(params.p.txn).Get().Database(
+		params.ctx, params.p.CurrentDatabase(),
+	)
+	if err != nil {
+		return err
+	}
+	// The slot starts after the changes committed up to the read timestamp of
+	// the transaction, which are all visible to a snapshot read at that time.
+	consistentPoint := pgrepl.ResolvedLSN(params.p.txn.ReadTimestamp())
+	row, err := params.p.InternalSQLTxn().QueryRowEx(
+		params.ctx,
+		"create-replication-slot",
+		params.p.txn,
+		sessiondata.NodeUserSessionDataOverride,
+		`INSERT INTO system.replication_slots (slot_name, database_id, plugin, confirmed_flush_lsn)
+VALUES ($1, $2, $3, $4) ON CONFLICT (slot_name) DO NOTHING RETURNING slot_name`,
+		string(n.n.Slot), int64(dbDesc.GetID()), string(n.n.Plugin), int64(consistentPoint),
+	)
+	if err != nil {
+		return err
+	}
+	if row == nil {
+		return pgerror.Newf(pgcode.DuplicateObject,
+			"replication slot %q already exists", n.n.Slot)
+	}
+	n.row = tree.Datums{
+		tree.NewDString(string(n.n.Slot)),
+		tree.NewDString(consistentPoint.String()),
+		tree.DNull,
+		tree.NewDString(string(n.n.Plugin)),
+	}
+	return nil
+}
+
+func (n *createReplicationSlotNode) Next(params runParams) (bool, error) {
+	if n.done {
+		return false, nil
+	}
+	n.done = true
+	return true, nil
+}
+
+func (n *createReplicationSlotNode) Values() tree.Datums       { return n.row }
+func (n *createReplicationSlotNode) Close(ctx context.Context) {}
+
+type dropReplicationSlotNode struct {
+	n *tree.DropReplicationSlot
+}
+
+// DropReplicationSlot drops a replication slot of the current database.
+// Privileges: REPLICATION.
+func (p *planner) DropReplicationSlot(
+	ctx context.Context, n *tree.DropReplicationSlot,
+) (planNode, error) {
+	if err := p.checkReplicationCommand(ctx); err != nil {
+		return nil, err
+	}
+	return &dropReplicationSlotNode{n: n}, nil
+}
+
+func (n *dropReplicationSlotNode) startExec(params runParams) error {
+	dbDesc, err := params.p.Descriptors().ByNameWithLeased(params.p.txn).Get().Database(
+		params.ctx, params.p.CurrentDatabase(),
+	)
+	if err != nil {
+		return err
+	}
+	deleted, err := params.p.InternalSQLTxn().ExecEx(
+		params.ctx,
+		"drop-replication-slot",
+		params.p.txn,
+		sessiondata.NodeUserSessionDataOverride,
+		`DELETE FROM system.replication_slots WHERE slot_name = $1 AND database_id = $2`,
+		string(n.n.Slot), int64(dbDesc.GetID()),
+	)
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return pgerror.Newf(pgcode.UndefinedObject,
+			"replication slot %q does not exist", n.n.Slot)
+	}
+	return nil
+}
+
+func (n *dropReplicationSlotNode) Next(params runParams) (bool, error) { return false, nil }
+func (n *dropReplicationSlotNode) Values() tree.Datums                 { return tree.Datums{} }
+func (n *dropReplicationSlotNode) Close(ctx context.Context)           {}
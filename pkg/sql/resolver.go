@@ -345,17 +345,24 @@ func (p *planner) getDescriptorsFromTargetListForPrivilegeChange(
 		return descs, nil
 	}
 
-	if targets.Functions != nil {
-		if len(targets.Functions) == 0 {
+	if targets.Functions != nil || targets.Procedures != nil {
+		funcObjs, isProcedure := targets.Functions, false
+		if targets.Procedures != nil {
+			funcObjs, isProcedure = targets.Procedures, true
+		}
+		if len(funcObjs) == 0 {
 			return nil, errNoFunction
 		}
-		descs := make([]DescriptorWithObjectType, 0, len(targets.Functions))
+		descs := make([]DescriptorWithObjectType, 0, len(funcObjs))
 		fnResolved := catalog.DescriptorIDSet{}
-		for _, f := range targets.Functions {
+		for _, f := range funcObjs {
 			overload, err := p.matchUDF(ctx, &f, true /* required */)
 			if err != nil {
 				return nil, err
 			}
+			if err := checkRoutineKind(overload, &f, isProcedure); err != nil {
+				return nil, err
+			}
 			fnID := funcdesc.UserDefinedFunctionOIDToID(overload.Oid)
 			if fnResolved.Contains(fnID) {
 				continue
@@ -34,6 +34,8 @@ func _() {
 	_ = x[NOSQLLOGIN-24]
 	_ = x[VIEWCLUSTERSETTING-25]
 	_ = x[NOVIEWCLUSTERSETTING-26]
+	_ = x[BYPASSRLS-27]
+	_ = x[NOBYPASSRLS-28]
 }
 
 func (i Option) String() string {
@@ -90,6 +92,10 @@ func (i Option) String() string {
 		return "VIEWCLUSTERSETTING"
 	case NOVIEWCLUSTERSETTING:
 		return "NOVIEWCLUSTERSETTING"
+	case BYPASSRLS:
+		return "BYPASSRLS"
+	case NOBYPASSRLS:
+		return "NOBYPASSRLS"
 	default:
 		return "Option(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -15,6 +15,7 @@ import (
 	"strconv"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
@@ -151,13 +152,42 @@ func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
 		}()
 	}
 
-	// Execute each statement in the routine sequentially.
+	// If the routine has an exception handler, create a savepoint so that the
+	// effects of the routine can be rolled back when an error is handled.
+	var sp kv.SavepointToken
+	if g.expr.ExceptionHandler != nil {
+		if sp, err = txn.CreateSavepoint(ctx); err != nil {
+			return err
+		}
+	}
+
+	err = g.runPlans(ctx, txn, g.expr)
+	if g.expr.ExceptionHandler != nil {
+		if err != nil {
+			err = g.handleException(ctx, txn, sp, retTypes, err)
+		} else {
+			err = txn.ReleaseSavepoint(ctx, sp)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	g.rci = newRowContainerIterator(ctx, g.rch)
+	return nil
+}
+
+// runPlans executes each statement in the given routine sequentially. The
+// result of the last statement is added to the generator's row container.
+func (g *routineGenerator) runPlans(
+	ctx context.Context, txn *kv.Txn, expr *tree.RoutineExpr,
+) error {
 	stmtIdx := 0
 	ef := newExecFactory(ctx, g.p)
 	rrw := NewRowResultWriter(&g.rch)
-	err = g.expr.ForEachPlan(ctx, ef, g.args, func(plan tree.RoutinePlan, isFinalPlan bool) error {
+	return expr.ForEachPlan(ctx, ef, g.args, func(plan tree.RoutinePlan, isFinalPlan bool) error {
 		stmtIdx++
-		opName := "udf-stmt-" + g.expr.Name + "-" + strconv.Itoa(stmtIdx)
+		opName := "udf-stmt-" + expr.Name + "-" + strconv.Itoa(stmtIdx)
 		ctx, sp := tracing.ChildSpan(ctx, opName)
 		defer sp.Finish()
 
@@ -172,26 +202,40 @@ func (g *routineGenerator) Start(ctx context.Context, txn *kv.Txn) (err error) {
 
 		// Place a sequence point before each statement in the routine for
 		// volatile functions.
-		if g.expr.EnableStepping {
-			if err := txn.Step(ctx); err != nil {
+		if expr.EnableStepping {
+			if err := txn.Step(ctx, false /* allowReadTimestampStep */); err != nil {
 				return err
 			}
 		}
 
 		// Run the plan.
-		err = runPlanInsidePlan(ctx, g.p.RunParams(ctx), plan.(*planComponents), w)
-		if err != nil {
-			return err
-		}
-
-		return nil
+		return runPlanInsidePlan(ctx, g.p.RunParams(ctx), plan.(*planComponents), w)
 	})
-	if err != nil {
-		return err
-	}
+}
 
-	g.rci = newRowContainerIterator(ctx, g.rch)
-	return nil
+// handleException is called when the execution of a routine with an exception
+// handler returns an error. If the error matches one of the branches of the
+// handler, the effects of the routine are rolled back to the given savepoint
+// and the branch's action is executed in its place. Otherwise, the original
+// error is returned.
+func (g *routineGenerator) handleException(
+	ctx context.Context,
+	txn *kv.Txn,
+	sp kv.SavepointToken,
+	retTypes []*types.T,
+	caughtErr error,
+) error {
+	action := g.expr.ExceptionHandler.Match(pgerror.GetPGCode(caughtErr))
+	if action == nil {
+		return caughtErr
+	}
+	if err := txn.RollbackToSavepoint(ctx, sp); err != nil {
+		return errors.WithSecondaryError(caughtErr, err)
+	}
+	// Discard any rows produced by the routine before the error occurred.
+	g.rch.Close(ctx)
+	g.rch.Init(ctx, retTypes, g.p.ExtendedEvalContext(), "routine" /* opName */)
+	return g.runPlans(ctx, txn, action)
 }
 
 // Next is part of the ValueGenerator interface.
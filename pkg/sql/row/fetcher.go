@@ -288,6 +288,9 @@ type FetcherInitArgs struct {
 	// LockWaitPolicy represents the policy to be used for handling conflicting
 	// locks held by other active transactions.
 	LockWaitPolicy descpb.ScanLockingWaitPolicy
+	// LockDurability represents the durability of the locks acquired when
+	// fetching rows.
+	LockDurability descpb.ScanLockingDurability
 	// LockTimeout specifies the maximum amount of time that the fetcher will
 	// wait while attempting to acquire a lock on a key or while blocking on an
 	// existing lock in order to perform a non-locking read on a key.
@@ -428,6 +431,7 @@ func (rf *Fetcher) Init(ctx context.Context, args FetcherInitArgs) error {
 			reverse:                    args.Reverse,
 			lockStrength:               args.LockStrength,
 			lockWaitPolicy:             args.LockWaitPolicy,
+			lockDurability:             args.LockDurability,
 			lockTimeout:                args.LockTimeout,
 			acc:                        rf.kvFetcherMemAcc,
 			forceProductionKVBatchSize: args.ForceProductionKVBatchSize,
@@ -17,7 +17,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
-	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/rowinfra"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
@@ -27,8 +26,9 @@ import (
 // txnKVStreamer handles retrieval of key/values.
 type txnKVStreamer struct {
 	kvBatchFetcherHelper
-	streamer   *kvstreamer.Streamer
-	keyLocking lock.Strength
+	streamer             *kvstreamer.Streamer
+	keyLocking           lock.Strength
+	keyLockingDurability lock.Durability
 
 	spans       roachpb.Spans
 	spanIDs     []int
@@ -52,14 +52,16 @@ var _ KVBatchFetcher = &txnKVStreamer{}
 // newTxnKVStreamer creates a new txnKVStreamer.
 func newTxnKVStreamer(
 	streamer *kvstreamer.Streamer,
-	lockStrength descpb.ScanLockingStrength,
+	keyLocking lock.Strength,
+	keyLockingDurability lock.Durability,
 	acc *mon.BoundAccount,
 	batchRequestsIssued *int64,
 ) KVBatchFetcher {
 	f := &txnKVStreamer{
-		streamer:   streamer,
-		keyLocking: GetKeyLockingStrength(lockStrength),
-		acc:        acc,
+		streamer:             streamer,
+		keyLocking:           keyLocking,
+		keyLockingDurability: keyLockingDurability,
+		acc:                  acc,
 	}
 	f.kvBatchFetcherHelper.init(f.nextBatch, batchRequestsIssued)
 	return f
@@ -98,7 +100,9 @@ func (f *txnKVStreamer) SetupNextFetch(
 		reqsScratch[i] = kvpb.RequestUnion{}
 	}
 	// TODO(yuzefovich): consider supporting COL_BATCH_RESPONSE scan format.
-	reqs := spansToRequests(spans, kvpb.BATCH_RESPONSE, false /* reverse */, f.keyLocking, reqsScratch)
+	reqs := spansToRequests(
+		spans, kvpb.BATCH_RESPONSE, false /* reverse */, f.keyLocking, f.keyLockingDurability, reqsScratch,
+	)
 	if err := f.streamer.Enqueue(ctx, reqs); err != nil {
 		return err
 	}
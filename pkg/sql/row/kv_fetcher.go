@@ -56,6 +56,7 @@ func newTxnKVFetcher(
 	reverse bool,
 	lockStrength descpb.ScanLockingStrength,
 	lockWaitPolicy descpb.ScanLockingWaitPolicy,
+	lockDurability descpb.ScanLockingDurability,
 	lockTimeout time.Duration,
 	acc *mon.BoundAccount,
 	forceProductionKVBatchSize bool,
@@ -93,6 +94,7 @@ func newTxnKVFetcher(
 		reverse:                    reverse,
 		lockStrength:               lockStrength,
 		lockWaitPolicy:             lockWaitPolicy,
+		lockDurability:             lockDurability,
 		lockTimeout:                lockTimeout,
 		acc:                        acc,
 		forceProductionKVBatchSize: forceProductionKVBatchSize,
@@ -123,12 +125,13 @@ func NewDirectKVBatchFetcher(
 	reverse bool,
 	lockStrength descpb.ScanLockingStrength,
 	lockWaitPolicy descpb.ScanLockingWaitPolicy,
+	lockDurability descpb.ScanLockingDurability,
 	lockTimeout time.Duration,
 	acc *mon.BoundAccount,
 	forceProductionKVBatchSize bool,
 ) KVBatchFetcher {
 	f := newTxnKVFetcher(
-		txn, bsHeader, reverse, lockStrength, lockWaitPolicy,
+		txn, bsHeader, reverse, lockStrength, lockWaitPolicy, lockDurability,
 		lockTimeout, acc, forceProductionKVBatchSize,
 	)
 	f.scanFormat = kvpb.COL_BATCH_RESPONSE
@@ -148,12 +151,13 @@ func NewKVFetcher(
 	reverse bool,
 	lockStrength descpb.ScanLockingStrength,
 	lockWaitPolicy descpb.ScanLockingWaitPolicy,
+	lockDurability descpb.ScanLockingDurability,
 	lockTimeout time.Duration,
 	acc *mon.BoundAccount,
 	forceProductionKVBatchSize bool,
 ) *KVFetcher {
 	return newKVFetcher(newTxnKVFetcher(
-		txn, bsHeader, reverse, lockStrength, lockWaitPolicy,
+		txn, bsHeader, reverse, lockStrength, lockWaitPolicy, lockDurability,
 		lockTimeout, acc, forceProductionKVBatchSize,
 	))
 }
@@ -169,6 +173,7 @@ func NewStreamingKVFetcher(
 	st *cluster.Settings,
 	lockWaitPolicy descpb.ScanLockingWaitPolicy,
 	lockStrength descpb.ScanLockingStrength,
+	lockDurability descpb.ScanLockingDurability,
 	streamerBudgetLimit int64,
 	streamerBudgetAcc *mon.BoundAccount,
 	maintainOrdering bool,
@@ -178,6 +183,7 @@ func NewStreamingKVFetcher(
 	kvFetcherMemAcc *mon.BoundAccount,
 ) *KVFetcher {
 	var batchRequestsIssued int64
+	keyLocking, keyLockingDurability := getKeyLocking(lockStrength, lockDurability)
 	streamer := kvstreamer.NewStreamer(
 		distSender,
 		stopper,
@@ -187,7 +193,7 @@ func NewStreamingKVFetcher(
 		streamerBudgetLimit,
 		streamerBudgetAcc,
 		&batchRequestsIssued,
-		GetKeyLockingStrength(lockStrength),
+		keyLocking,
 	)
 	mode := kvstreamer.OutOfOrder
 	if maintainOrdering {
@@ -202,7 +208,9 @@ func NewStreamingKVFetcher(
 		maxKeysPerRow,
 		diskBuffer,
 	)
-	return newKVFetcher(newTxnKVStreamer(streamer, lockStrength, kvFetcherMemAcc, &batchRequestsIssued))
+	return newKVFetcher(newTxnKVStreamer(
+		streamer, keyLocking, keyLockingDurability, kvFetcherMemAcc, &batchRequestsIssued,
+	))
 }
 
 func newKVFetcher(batchFetcher KVBatchFetcher) *KVFetcher {
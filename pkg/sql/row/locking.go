@@ -27,16 +27,15 @@ func GetKeyLockingStrength(lockStrength descpb.ScanLockingStrength) lock.Strengt
 		// Promote to FOR_SHARE.
 		fallthrough
 	case descpb.ScanLockingStrength_FOR_SHARE:
-		// We currently perform no per-key locking when FOR_SHARE is used
-		// because Shared locks have not yet been implemented.
-		return lock.None
+		return lock.Shared
 
 	case descpb.ScanLockingStrength_FOR_NO_KEY_UPDATE:
 		// Promote to FOR_UPDATE.
 		fallthrough
 	case descpb.ScanLockingStrength_FOR_UPDATE:
-		// We currently perform exclusive per-key locking when FOR_UPDATE is
-		// used because Update locks have not yet been implemented.
+		// We perform exclusive per-key locking when FOR_NO_KEY_UPDATE is used
+		// because, unlike FOR NO KEY UPDATE locks, Update locks are compatible
+		// with the Shared locks acquired by FOR SHARE.
 		return lock.Exclusive
 
 	default:
@@ -44,6 +43,33 @@ func GetKeyLockingStrength(lockStrength descpb.ScanLockingStrength) lock.Strengt
 	}
 }
 
+// getKeyLockingDurability returns the configured per-key locking durability to
+// use for key-value scans.
+func getKeyLockingDurability(lockDurability descpb.ScanLockingDurability) lock.Durability {
+	switch lockDurability {
+	case descpb.ScanLockingDurability_BEST_EFFORT:
+		return lock.Unreplicated
+
+	case descpb.ScanLockingDurability_GUARANTEED:
+		return lock.Replicated
+
+	default:
+		panic(errors.AssertionFailedf("unknown locking durability %s", lockDurability))
+	}
+}
+
+// getKeyLocking returns the configured per-key locking strength and durability
+// to use for key-value scans.
+func getKeyLocking(
+	lockStrength descpb.ScanLockingStrength, lockDurability descpb.ScanLockingDurability,
+) (lock.Strength, lock.Durability) {
+	str, dur := GetKeyLockingStrength(lockStrength), getKeyLockingDurability(lockDurability)
+	if str == lock.None {
+		return lock.None, lock.Unreplicated
+	}
+	return str, dur
+}
+
 // getWaitPolicy returns the configured lock wait policy to use for key-value
 // scans.
 func getWaitPolicy(lockWaitPolicy descpb.ScanLockingWaitPolicy) lock.WaitPolicy {
@@ -231,6 +231,11 @@ type DatumRowConverter struct {
 	CompletedRowFn func() int64
 	FractionFn     func() float32
 
+	// RowFn, if set, is called with each converted row, ordered according to
+	// the public columns of the table, instead of encoding the row into
+	// KVBatches. It is used to read rows without ingesting them.
+	RowFn func(ctx context.Context, row tree.Datums) error
+
 	db *kv.DB
 }
 
@@ -526,6 +531,15 @@ func (c *DatumRowConverter) Row(ctx context.Context, sourceID int32, rowIndex in
 		return errors.Wrap(err, "generate insert row")
 	}
 
+	if c.RowFn != nil {
+		publicCols := c.tableDesc.PublicColumns()
+		row := make(tree.Datums, len(publicCols))
+		for i, col := range publicCols {
+			row[i] = insertRow[c.ri.InsertColIDtoRowIndex.GetDefault(col.GetID())]
+		}
+		return c.RowFn(ctx, row)
+	}
+
 	// Initialize the PartialIndexUpdateHelper with evaluated predicates for
 	// partial indexes.
 	var pm PartialIndexUpdateHelper
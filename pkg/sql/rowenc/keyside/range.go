@@ -0,0 +1,219 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package keyside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// The following bytes are used in the ascending encoding of ranges. They are
+// chosen such that the encoding sorts in the same order as the ranges.
+const (
+	rangeEmptyTag    byte = 0x00
+	rangeNonEmptyTag byte = 0x01
+
+	// A lower bound is encoded as rangeLowerInf, or as rangeBoundFinite
+	// followed by the encoded value and by rangeLowerInc or rangeLowerExc.
+	rangeLowerInf byte = 0x00
+	rangeLowerInc byte = 0x00
+	rangeLowerExc byte = 0x01
+
+	// An upper bound is encoded as rangeUpperInf, or as rangeBoundFinite
+	// followed by the encoded value and by rangeUpperExc or rangeUpperInc.
+	rangeBoundFinite byte = 0x01
+	rangeUpperExc    byte = 0x00
+	rangeUpperInc    byte = 0x01
+	rangeUpperInf    byte = 0x02
+
+	// The ranges of a multirange are each preceded by multirangeRangeMarker,
+	// and the last one is followed by multirangeTerminator.
+	multirangeTerminator  byte = 0x00
+	multirangeRangeMarker byte = 0x01
+)
+
+// encodeRangeKey generates an ordered key encoding of a range. The range is
+// first encoded in ascending order into a byte string, which is then encoded
+// as bytes in the given direction.
+//
+// The byte string of an empty range is [rangeEmptyTag], which sorts before all
+// other ranges. The byte string of a non-empty range is [rangeNonEmptyTag,
+// lower bound, upper bound], where an infinite lower bound sorts before all
+// finite lower bounds, an infinite upper bound sorts after all finite upper
+// bounds, and the inclusivity of a finite bound orders bounds with the same
+// value.
+func encodeRangeKey(b []byte, r *tree.DRange, dir encoding.Direction) ([]byte, error) {
+	data, err := appendRangeKeyAscending(nil /* b */, r)
+	if err != nil {
+		return nil, err
+	}
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, data), nil
+	}
+	return encoding.EncodeBytesDescending(b, data), nil
+}
+
+// encodeMultirangeKey generates an ordered key encoding of a multirange. The
+// multirange is first encoded in ascending order into a byte string, which is
+// then encoded as bytes in the given direction. The byte string contains the
+// ranges of the multirange, each preceded by multirangeRangeMarker, followed
+// by multirangeTerminator, so that a multirange sorts before all other
+// multiranges that it is a prefix of.
+func encodeMultirangeKey(b []byte, mr *tree.DMultirange, dir encoding.Direction) ([]byte, error) {
+	var data []byte
+	for _, r := range mr.Ranges {
+		data = append(data, multirangeRangeMarker)
+		var err error
+		if data, err = appendRangeKeyAscending(data, r); err != nil {
+			return nil, err
+		}
+	}
+	data = append(data, multirangeTerminator)
+	if dir == encoding.Ascending {
+		return encoding.EncodeBytesAscending(b, data), nil
+	}
+	return encoding.EncodeBytesDescending(b, data), nil
+}
+
+func appendRangeKeyAscending(b []byte, r *tree.DRange) ([]byte, error) {
+	if r.Empty {
+		return append(b, rangeEmptyTag), nil
+	}
+	b = append(b, rangeNonEmptyTag)
+	var err error
+	if r.Lower == tree.DNull {
+		b = append(b, rangeLowerInf)
+	} else {
+		b = append(b, rangeBoundFinite)
+		if b, err = Encode(b, r.Lower, encoding.Ascending); err != nil {
+			return nil, err
+		}
+		if r.LowerInc {
+			b = append(b, rangeLowerInc)
+		} else {
+			b = append(b, rangeLowerExc)
+		}
+	}
+	if r.Upper == tree.DNull {
+		b = append(b, rangeUpperInf)
+	} else {
+		b = append(b, rangeBoundFinite)
+		if b, err = Encode(b, r.Upper, encoding.Ascending); err != nil {
+			return nil, err
+		}
+		if r.UpperInc {
+			b = append(b, rangeUpperInc)
+		} else {
+			b = append(b, rangeUpperExc)
+		}
+	}
+	return b, nil
+}
+
+// decodeRangeKey decodes a range key generated by encodeRangeKey.
+func decodeRangeKey(
+	a *tree.DatumAlloc, t *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	data, rkey, err := decodeRangeKeyBytes(key, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, data, err := decodeRangeKeyAscending(a, t, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(data) != 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (%d trailing bytes)", len(data))
+	}
+	return r, rkey, nil
+}
+
+// decodeMultirangeKey decodes a multirange key generated by
+// encodeMultirangeKey.
+func decodeMultirangeKey(
+	a *tree.DatumAlloc, t *types.T, key []byte, dir encoding.Direction,
+) (tree.Datum, []byte, error) {
+	data, rkey, err := decodeRangeKeyBytes(key, dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	var ranges []*tree.DRange
+	for {
+		if len(data) == 0 {
+			return nil, nil, errors.AssertionFailedf("invalid multirange encoding (unterminated)")
+		}
+		marker := data[0]
+		data = data[1:]
+		if marker == multirangeTerminator {
+			break
+		}
+		var r *tree.DRange
+		if r, data, err = decodeRangeKeyAscending(a, t.MultirangeContents(), data); err != nil {
+			return nil, nil, err
+		}
+		ranges = append(ranges, r)
+	}
+	return tree.NewDMultirange(t, ranges), rkey, nil
+}
+
+func decodeRangeKeyBytes(key []byte, dir encoding.Direction) (data, rkey []byte, err error) {
+	if dir == encoding.Ascending {
+		rkey, data, err = encoding.DecodeBytesAscending(key, nil)
+	} else {
+		rkey, data, err = encoding.DecodeBytesDescending(key, nil)
+	}
+	return data, rkey, err
+}
+
+func decodeRangeKeyAscending(
+	a *tree.DatumAlloc, t *types.T, data []byte,
+) (*tree.DRange, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (empty)")
+	}
+	tag := data[0]
+	data = data[1:]
+	if tag == rangeEmptyTag {
+		return tree.NewDEmptyRange(t), data, nil
+	}
+	var flags tree.RangeFlags
+	bounds := [2]tree.Datum{tree.DNull, tree.DNull}
+	for i := range bounds {
+		if len(data) == 0 {
+			return nil, nil, errors.AssertionFailedf("invalid range encoding (missing bound)")
+		}
+		if data[0] != rangeBoundFinite {
+			if i == 0 {
+				flags |= tree.RangeLowerInf
+			} else {
+				flags |= tree.RangeUpperInf
+			}
+			data = data[1:]
+			continue
+		}
+		var err error
+		if bounds[i], data, err = Decode(a, t.RangeContents(), data[1:], encoding.Ascending); err != nil {
+			return nil, nil, err
+		}
+		if len(data) == 0 {
+			return nil, nil, errors.AssertionFailedf("invalid range encoding (missing inclusivity)")
+		}
+		if i == 0 && data[0] == rangeLowerInc {
+			flags |= tree.RangeLowerInc
+		} else if i == 1 && data[0] == rangeUpperInc {
+			flags |= tree.RangeUpperInc
+		}
+		data = data[1:]
+	}
+	return tree.NewDRangeFromFlags(t, bounds[0], bounds[1], flags), data, nil
+}
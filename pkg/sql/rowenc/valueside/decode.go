@@ -226,6 +226,20 @@ func DecodeUntaggedDatum(
 		return decodeArray(a, t, b)
 	case types.TupleFamily:
 		return decodeTuple(a, t, buf)
+	case types.RangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		r, _, err := decodeRange(a, t, data)
+		return r, b, err
+	case types.MultirangeFamily:
+		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
+		if err != nil {
+			return nil, b, err
+		}
+		mr, _, err := decodeMultirange(a, t, data)
+		return mr, b, err
 	case types.EnumFamily:
 		b, data, err := encoding.DecodeUntaggedBytesValue(buf)
 		if err != nil {
@@ -101,6 +101,18 @@ func Encode(appendTo []byte, colID ColumnIDDelta, val tree.Datum, scratch []byte
 		return encoding.EncodeArrayValue(appendTo, uint32(colID), a), nil
 	case *tree.DTuple:
 		return encodeTuple(t, appendTo, uint32(colID), scratch)
+	case *tree.DRange:
+		data, err := encodeRange(scratch[:0], t)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), data), nil
+	case *tree.DMultirange:
+		data, err := encodeMultirange(scratch[:0], t)
+		if err != nil {
+			return nil, err
+		}
+		return encoding.EncodeBytesValue(appendTo, uint32(colID), data), nil
 	case *tree.DCollatedString:
 		return encoding.EncodeBytesValue(appendTo, uint32(colID), []byte(t.Contents)), nil
 	case *tree.DOid:
@@ -0,0 +1,93 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package valueside
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/errors"
+)
+
+// encodeRange produces the value encoding for a range, which consists of the
+// range's flags byte followed by the value encodings of its finite bounds.
+// The result is appended to appendTo.
+func encodeRange(appendTo []byte, r *tree.DRange) ([]byte, error) {
+	appendTo = append(appendTo, byte(r.Flags()))
+	if r.Empty {
+		return appendTo, nil
+	}
+	var err error
+	for _, bound := range [2]tree.Datum{r.Lower, r.Upper} {
+		if bound == tree.DNull {
+			continue
+		}
+		if appendTo, err = Encode(appendTo, NoColumnID, bound, nil /* scratch */); err != nil {
+			return nil, err
+		}
+	}
+	return appendTo, nil
+}
+
+// encodeMultirange produces the value encoding for a multirange, which
+// consists of the number of ranges followed by the encoding of each range.
+// The result is appended to appendTo.
+func encodeMultirange(appendTo []byte, mr *tree.DMultirange) ([]byte, error) {
+	appendTo = encoding.EncodeNonsortingUvarint(appendTo, uint64(len(mr.Ranges)))
+	var err error
+	for _, r := range mr.Ranges {
+		if appendTo, err = encodeRange(appendTo, r); err != nil {
+			return nil, err
+		}
+	}
+	return appendTo, nil
+}
+
+// decodeRange decodes a range from its value encoding. It is the counterpart
+// of encodeRange().
+func decodeRange(a *tree.DatumAlloc, t *types.T, b []byte) (*tree.DRange, []byte, error) {
+	if len(b) == 0 {
+		return nil, nil, errors.AssertionFailedf("invalid range encoding (empty)")
+	}
+	flags := tree.RangeFlags(b[0])
+	b = b[1:]
+	if flags&tree.RangeEmpty != 0 {
+		return tree.NewDEmptyRange(t), b, nil
+	}
+	bounds := [2]tree.Datum{tree.DNull, tree.DNull}
+	infFlags := [2]tree.RangeFlags{tree.RangeLowerInf, tree.RangeUpperInf}
+	for i := range bounds {
+		if flags&infFlags[i] != 0 {
+			continue
+		}
+		var err error
+		if bounds[i], b, err = Decode(a, t.RangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+	}
+	return tree.NewDRangeFromFlags(t, bounds[0], bounds[1], flags), b, nil
+}
+
+// decodeMultirange decodes a multirange from its value encoding. It is the
+// counterpart of encodeMultirange().
+func decodeMultirange(a *tree.DatumAlloc, t *types.T, b []byte) (*tree.DMultirange, []byte, error) {
+	b, _, n, err := encoding.DecodeNonsortingUvarint(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranges := make([]*tree.DRange, n)
+	for i := range ranges {
+		if ranges[i], b, err = decodeRange(a, t.MultirangeContents(), b); err != nil {
+			return nil, nil, err
+		}
+	}
+	return tree.NewDMultirange(t, ranges), b, nil
+}
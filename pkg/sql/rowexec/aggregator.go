@@ -26,6 +26,8 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/cancelchecker"
+	"github.com/cockroachdb/cockroach/pkg/util/encoding"
+	"github.com/cockroachdb/cockroach/pkg/util/intsets"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/optional"
@@ -74,6 +76,9 @@ type aggregatorBase struct {
 	groupCols        []uint32
 	orderedGroupCols []uint32
 	aggregations     []execinfrapb.AggregatorSpec_Aggregation
+	// groupingSets is set if the aggregations are computed for each of several
+	// grouping sets. Only the hashAggregator supports grouping sets.
+	groupingSets []aggregatorGroupingSet
 
 	lastOrdGroupCols rowenc.EncDatumRow
 	arena            stringarena.Arena
@@ -83,6 +88,17 @@ type aggregatorBase struct {
 	cancelChecker cancelchecker.CancelChecker
 }
 
+// aggregatorGroupingSet describes one of the grouping sets of an aggregator
+// that computes GROUPING SETS, ROLLUP or CUBE.
+type aggregatorGroupingSet struct {
+	// cols are the grouping columns of the set.
+	cols []uint32
+	// skipAggs contains the indices of the ANY_NOT_NULL aggregations over the
+	// group columns that are not part of the set. These aggregations are never
+	// fed any rows, so they produce NULL for all groups of the set.
+	skipAggs intsets.Fast
+}
+
 // init initializes the aggregatorBase.
 //
 // trailingMetaCallback is passed as part of ProcStateOpts; the inputs to drain
@@ -108,8 +124,8 @@ func (ag *aggregatorBase) init(
 	ag.orderedGroupCols = spec.OrderedGroupCols
 	ag.aggregations = spec.Aggregations
 	ag.funcs = make([]*aggregateFuncHolder, len(spec.Aggregations))
-	ag.outputTypes = make([]*types.T, len(spec.Aggregations))
-	ag.row = make(rowenc.EncDatumRow, len(spec.Aggregations))
+	ag.outputTypes = make([]*types.T, len(spec.Aggregations), len(spec.Aggregations)+1)
+	ag.row = make(rowenc.EncDatumRow, len(spec.Aggregations), len(spec.Aggregations)+1)
 	ag.bucketsAcc = memMonitor.MakeBoundAccount()
 	ag.arena = stringarena.Make(&ag.bucketsAcc)
 	ag.aggFuncsAcc = memMonitor.MakeBoundAccount()
@@ -146,6 +162,12 @@ func (ag *aggregatorBase) init(
 		}
 		ag.outputTypes[i] = outputType
 	}
+	if len(spec.GroupingSets) > 0 {
+		ag.initGroupingSets(spec)
+		// The ordinal of the grouping set is output after the aggregations.
+		ag.outputTypes = append(ag.outputTypes, types.Int)
+		ag.row = append(ag.row, rowenc.EncDatum{})
+	}
 
 	return ag.ProcessorBase.Init(
 		ctx, self, post, ag.outputTypes, flowCtx, processorID, memMonitor,
@@ -156,6 +178,32 @@ func (ag *aggregatorBase) init(
 	)
 }
 
+// initGroupingSets sets up the grouping sets of the aggregator.
+func (ag *aggregatorBase) initGroupingSets(spec *execinfrapb.AggregatorSpec) {
+	var groupCols intsets.Fast
+	for _, c := range spec.GroupCols {
+		groupCols.Add(int(c))
+	}
+	ag.groupingSets = make([]aggregatorGroupingSet, len(spec.GroupingSets))
+	for i := range spec.GroupingSets {
+		set := &ag.groupingSets[i]
+		set.cols = spec.GroupingSets[i].Cols
+		var setCols intsets.Fast
+		for _, c := range set.cols {
+			setCols.Add(int(c))
+		}
+		for j := range spec.Aggregations {
+			agg := &spec.Aggregations[j]
+			if agg.Func != execinfrapb.AnyNotNull || len(agg.ColIdx) != 1 {
+				continue
+			}
+			if c := int(agg.ColIdx[0]); groupCols.Contains(c) && !setCols.Contains(c) {
+				set.skipAggs.Add(j)
+			}
+		}
+	}
+}
+
 // execStatsForTrace implements ProcessorBase.ExecStatsForTrace.
 func (ag *aggregatorBase) execStatsForTrace() *execinfrapb.ComponentStats {
 	is, ok := getInputStats(ag.input)
@@ -261,6 +309,11 @@ func newAggregator(
 	if spec.IsRowCount() {
 		return newCountAggregator(ctx, flowCtx, processorID, input, post)
 	}
+	if len(spec.GroupingSets) > 0 {
+		// Only the hash aggregator supports grouping sets since the groups of
+		// different sets are interleaved in the input.
+		return newHashAggregator(ctx, flowCtx, processorID, spec, input, post)
+	}
 	if len(spec.OrderedGroupCols) == len(spec.GroupCols) {
 		return newOrderedAggregator(ctx, flowCtx, processorID, spec, input, post)
 	}
@@ -464,6 +517,23 @@ func (ag *hashAggregator) accumulateRows() (
 		}
 		ag.buckets[""] = bucket
 	}
+	// Similarly, empty grouping sets produce a row even if nothing was
+	// aggregated.
+	for i := range ag.groupingSets {
+		if len(ag.groupingSets[i].cols) != 0 {
+			continue
+		}
+		key := string(encoding.EncodeUvarintAscending(nil /* appendTo */, uint64(i)))
+		if _, ok := ag.buckets[key]; ok {
+			continue
+		}
+		bucket, err := ag.createAggregateFuncs()
+		if err != nil {
+			ag.MoveToDraining(err)
+			return aggStateUnknown, nil, nil
+		}
+		ag.buckets[key] = bucket
+	}
 
 	// Note that, for simplicity, we're ignoring the overhead of the slice of
 	// strings.
@@ -624,6 +694,19 @@ func (ag *hashAggregator) emitRow() (
 	bucket := ag.bucketsIter[0]
 	ag.bucketsIter = ag.bucketsIter[1:]
 
+	if ag.groupingSets != nil {
+		// The bucket key of a grouping set group starts with the ordinal of
+		// the set.
+		_, setOrd, err := encoding.DecodeUvarintAscending([]byte(bucket))
+		if err != nil {
+			ag.MoveToDraining(err)
+			return aggStateUnknown, nil, nil
+		}
+		ag.row[len(ag.funcs)] = rowenc.DatumToEncDatum(
+			types.Int, ag.datumAlloc.NewDInt(tree.DInt(setOrd)),
+		)
+	}
+
 	// Once we get the results from the bucket, we can delete it from the map.
 	// This will allow us to return the memory to the system before the hash
 	// aggregator is fully done (which matters when we have many buckets).
@@ -748,12 +831,17 @@ func (ag *orderedAggregator) ConsumerClosed() {
 	ag.close()
 }
 
+// accumulateRowIntoBucket feeds the row into the aggregate functions of the
+// bucket, skipping the aggregations in skipAggs.
 func (ag *aggregatorBase) accumulateRowIntoBucket(
-	row rowenc.EncDatumRow, groupKey []byte, bucket aggregateFuncs,
+	row rowenc.EncDatumRow, groupKey []byte, bucket aggregateFuncs, skipAggs intsets.Fast,
 ) error {
 	var err error
 	// Feed the func holders for this bucket the non-grouping datums.
 	for i, a := range ag.aggregations {
+		if skipAggs.Contains(i) {
+			continue
+		}
 		if a.FilterColIdx != nil {
 			col := *a.FilterColIdx
 			if err := row[col].EnsureDecoded(ag.inputTypes[col], &ag.datumAlloc); err != nil {
@@ -813,9 +901,9 @@ func (ag *aggregatorBase) accumulateRowIntoBucket(
 // encode returns the encoding for the grouping columns, this is then used as
 // our group key to determine which bucket to add to.
 func (ag *hashAggregator) encode(
-	appendTo []byte, row rowenc.EncDatumRow,
+	appendTo []byte, row rowenc.EncDatumRow, groupCols []uint32,
 ) (encoding []byte, err error) {
-	for _, colIdx := range ag.groupCols {
+	for _, colIdx := range groupCols {
 		// We might allocate tree.Datums when hashing the row, so we'll ask the
 		// fingerprint to account for them. Note that if the datums are later
 		// used by the aggregate functions (and accounted for accordingly),
@@ -837,14 +925,40 @@ func (ag *hashAggregator) accumulateRow(row rowenc.EncDatumRow) error {
 		return err
 	}
 
+	if ag.groupingSets != nil {
+		// The row is accumulated into one bucket for each grouping set. The
+		// ordinal of the set is a prefix of the bucket key so that the groups
+		// of different sets are kept apart.
+		for i := range ag.groupingSets {
+			set := &ag.groupingSets[i]
+			encoded := encoding.EncodeUvarintAscending(ag.scratch, uint64(i))
+			encoded, err := ag.encode(encoded, row, set.cols)
+			if err != nil {
+				return err
+			}
+			ag.scratch = encoded[:0]
+			if err := ag.accumulateRowForKey(row, encoded, set.skipAggs); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
 	// The encoding computed here determines which bucket the non-grouping
 	// datums are accumulated to.
-	encoded, err := ag.encode(ag.scratch, row)
+	encoded, err := ag.encode(ag.scratch, row, ag.groupCols)
 	if err != nil {
 		return err
 	}
 	ag.scratch = encoded[:0]
+	return ag.accumulateRowForKey(row, encoded, intsets.Fast{} /* skipAggs */)
+}
 
+// accumulateRowForKey accumulates a single row into the bucket with the given
+// key, creating the bucket if necessary.
+func (ag *hashAggregator) accumulateRowForKey(
+	row rowenc.EncDatumRow, encoded []byte, skipAggs intsets.Fast,
+) error {
 	bucket, ok := ag.buckets[string(encoded)]
 	if !ok {
 		s, err := ag.arena.AllocBytes(ag.Ctx(), encoded)
@@ -866,7 +980,7 @@ func (ag *hashAggregator) accumulateRow(row rowenc.EncDatumRow) error {
 		}
 	}
 
-	return ag.accumulateRowIntoBucket(row, encoded, bucket)
+	return ag.accumulateRowIntoBucket(row, encoded, bucket, skipAggs)
 }
 
 // accumulateRow accumulates a single row, returning an error if accumulation
@@ -884,7 +998,9 @@ func (ag *orderedAggregator) accumulateRow(row rowenc.EncDatumRow) error {
 		}
 	}
 
-	return ag.accumulateRowIntoBucket(row, nil /* groupKey */, ag.bucket)
+	return ag.accumulateRowIntoBucket(
+		row, nil /* groupKey */, ag.bucket, intsets.Fast{}, /* skipAggs */
+	)
 }
 
 type aggregateFuncHolder struct {
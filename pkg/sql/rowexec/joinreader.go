@@ -536,6 +536,7 @@ func newJoinReader(
 			flowCtx.EvalCtx.Settings,
 			spec.LockingWaitPolicy,
 			spec.LockingStrength,
+			spec.LockingDurability,
 			streamerBudgetLimit,
 			&jr.streamerInfo.budgetAcc,
 			jr.streamerInfo.maintainOrdering,
@@ -562,6 +563,7 @@ func newJoinReader(
 			Txn:                        jr.txn,
 			LockStrength:               spec.LockingStrength,
 			LockWaitPolicy:             spec.LockingWaitPolicy,
+			LockDurability:             spec.LockingDurability,
 			LockTimeout:                flowCtx.EvalCtx.SessionData().LockTimeout,
 			Alloc:                      &jr.alloc,
 			MemMonitor:                 flowCtx.Mon,
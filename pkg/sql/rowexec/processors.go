@@ -243,6 +243,15 @@ func NewProcessor(
 		}
 		return NewReadImportDataProcessor(ctx, flowCtx, processorID, *core.ReadImport, post)
 	}
+	if core.ForeignScan != nil {
+		if err := checkNumIn(inputs, 0); err != nil {
+			return nil, err
+		}
+		if NewForeignScanProcessor == nil {
+			return nil, errors.New("ForeignScan processor unimplemented")
+		}
+		return NewForeignScanProcessor(ctx, flowCtx, processorID, *core.ForeignScan, post)
+	}
 	if core.CloudStorageTest != nil {
 		if err := checkNumIn(inputs, 0); err != nil {
 			return nil, err
@@ -410,6 +419,10 @@ func NewProcessor(
 // NewReadImportDataProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
 var NewReadImportDataProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.ReadImportDataSpec, *execinfrapb.PostProcessSpec) (execinfra.Processor, error)
 
+// NewForeignScanProcessor is implemented in the importer package and then
+// injected here via runtime initialization.
+var NewForeignScanProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.ForeignScanSpec, *execinfrapb.PostProcessSpec) (execinfra.Processor, error)
+
 // NewCloudStorageTestProcessor is implemented in the non-free (CCL) codebase and then injected here via runtime initialization.
 var NewCloudStorageTestProcessor func(context.Context, *execinfra.FlowCtx, int32, execinfrapb.CloudStorageTestSpec, *execinfrapb.PostProcessSpec) (execinfra.Processor, error)
 
@@ -419,6 +419,13 @@ func (s *SQLScanner) Scan(lval ScanSymType) {
 
 	case '-':
 		switch s.peek() {
+		case '|': // -|-
+			if s.peekN(1) == '-' {
+				s.pos += 2
+				lval.SetID(lexbase.ADJACENT)
+				return
+			}
+			return
 		case '>': // ->
 			if s.peekN(1) == '>' {
 				// ->>
@@ -451,6 +451,13 @@ func (sc *SchemaChanger) maybeMakeAddTablePublic(
 			return nil
 		}
 		mut.State = descpb.DescriptorState_PUBLIC
+		// A materialized view created with data contains the results of its
+		// query as of its creation time, which is where the changes applied by
+		// its next incremental refresh start from.
+		if mut.MaterializedView() && !mut.IsRefreshViewRequired() &&
+			sc.settings.Version.IsActive(ctx, clusterversion.V23_2_IncrementalMaterializedViewRefresh) {
+			mut.LastRefreshTime = mut.GetCreateAsOfTime()
+		}
 		return txn.Descriptors().WriteDesc(ctx, true /* kvTrace */, mut, txn.KV())
 	})
 }
@@ -1458,6 +1465,14 @@ func (sc *SchemaChanger) done(ctx context.Context) error {
 				// If we are mutation is in the ADD state, then start GC jobs for the
 				// existing indexes on the table.
 				if m.Adding() {
+					// Record the time as of which the view was recomputed, so that the
+					// next incremental refresh applies the changes made since then.
+					if refresh.ShouldBackfill() &&
+						sc.settings.Version.IsActive(ctx, clusterversion.V23_2_IncrementalMaterializedViewRefresh) {
+						scTable.LastRefreshTime = refresh.AsOf()
+					} else {
+						scTable.LastRefreshTime = hlc.Timestamp{}
+					}
 					desc := fmt.Sprintf("REFRESH MATERIALIZED VIEW %q cleanup", scTable.Name)
 					for _, idx := range scTable.ActiveIndexes() {
 						if err := sc.createIndexGCJob(ctx, idx.GetID(), txn, desc); err != nil {
@@ -829,6 +829,9 @@ func (b *builderState) ResolveUserDefinedTypeType(
 	case descpb.TypeDescriptor_COMPOSITE:
 		b.ensureDescriptor(typ.GetID())
 		b.mustOwn(typ.GetID())
+	case descpb.TypeDescriptor_DOMAIN:
+		// Domains are only handled by the legacy schema changer.
+		panic(scerrors.NotImplementedErrorf(nil /* n */, "domain type %q", typ.GetName()))
 	case descpb.TypeDescriptor_TABLE_IMPLICIT_RECORD_TYPE:
 		// Implicit record types are not directly modifiable.
 		panic(pgerror.Newf(pgcode.DependentObjectsStillExist,
@@ -1213,6 +1216,7 @@ func (b *builderState) ensureDescriptor(id catid.DescID) {
 	}
 
 	c := b.newCachedDesc(id)
+	fallBackIfTriggers(c.desc)
 	// Collect privileges
 	if !c.hasOwnership {
 		var err error
@@ -1258,6 +1262,27 @@ func (b *builderState) ensureDescriptor(id catid.DescID) {
 	}
 }
 
+// fallBackIfTriggers panics with an unimplemented error if the descriptor is
+// a table with triggers, or a function called by a trigger. Triggers are not
+// represented by elements, so schema changes which involve them are handled by
+// the legacy schema changer.
+func fallBackIfTriggers(desc catalog.Descriptor) {
+	switch d := desc.(type) {
+	case catalog.TableDescriptor:
+		if len(d.GetTriggers()) > 0 {
+			panic(scerrors.NotImplementedErrorf(nil, /* n */
+				"table %q has triggers", d.GetName()))
+		}
+	case catalog.FunctionDescriptor:
+		for _, ref := range d.GetDependedOnBy() {
+			if len(ref.TriggerIDs) > 0 {
+				panic(scerrors.NotImplementedErrorf(nil, /* n */
+					"function %q is called by a trigger", d.GetName()))
+			}
+		}
+	}
+}
+
 func (b *builderState) readDescriptor(id catid.DescID) catalog.Descriptor {
 	if id == catid.InvalidDescID {
 		panic(errors.AssertionFailedf("invalid descriptor ID %d", id))
@@ -1350,8 +1375,12 @@ func (b *builderState) WrapFunctionBody(
 	lang catpb.Function_Language,
 	refProvider scbuildstmt.ReferenceProvider,
 ) *scpb.FunctionBody {
-	bodyStr = b.replaceSeqNamesWithIDs(bodyStr)
-	bodyStr = b.serializeUserDefinedTypes(bodyStr)
+	// TODO(drewk): Replace sequence names and serialize user-defined types in
+	// the SQL statements of PL/pgSQL function bodies.
+	if lang == catpb.Function_SQL {
+		bodyStr = b.replaceSeqNamesWithIDs(bodyStr)
+		bodyStr = b.serializeUserDefinedTypes(bodyStr)
+	}
 	fnBody := &scpb.FunctionBody{
 		FunctionID: fnID,
 		Body:       bodyStr,
@@ -125,6 +125,21 @@ func alterTableAddConstraintChecks(
 		return isV222Active(t, mode, activeVersion)
 	}
 
+	// Deferrable constraints and exclusion constraints are only supported by
+	// the legacy schema changer.
+	switch d := t.ConstraintDef.(type) {
+	case *tree.ExclusionConstraintTableDef:
+		return false
+	case *tree.UniqueConstraintTableDef:
+		if d.Deferrability != tree.ConstraintNotDeferrable {
+			return false
+		}
+	case *tree.ForeignKeyConstraintTableDef:
+		if d.Deferrability != tree.ConstraintNotDeferrable {
+			return false
+		}
+	}
+
 	// Start supporting all other ADD CONSTRAINTs from V23_1, including
 	// - ADD PRIMARY KEY NOT VALID
 	// - ADD UNIQUE [NOT VALID]
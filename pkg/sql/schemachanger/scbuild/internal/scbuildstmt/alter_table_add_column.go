@@ -150,6 +150,9 @@ func alterTableAddColumn(
 
 	_, _, tableNamespace := scpb.FindNamespace(b.QueryByID(tbl.TableID))
 	spec.colType.TypeT = b.ResolveTypeRef(d.Type)
+	if spec.colType.TypeT.Type.DomainOID() != 0 {
+		panic(scerrors.NotImplementedErrorf(d, "contains domain type"))
+	}
 	if spec.colType.TypeT.Type.UserDefined() {
 		typeID := typedesc.UserDefinedTypeOIDToID(spec.colType.TypeT.Type.Oid())
 		maybeFailOnCrossDBTypeReference(b, typeID, tableNamespace.DatabaseID)
@@ -566,13 +569,14 @@ func addSecondaryIndexTargetsForAddColumn(
 ) {
 	var partitioning *catpb.PartitioningDescriptor
 	index := scpb.Index{
-		TableID:       tbl.TableID,
-		IndexID:       desc.ID,
-		IsUnique:      desc.Unique,
-		IsInverted:    desc.Type == descpb.IndexDescriptor_INVERTED,
-		SourceIndexID: newPrimaryIdx.IndexID,
-		IsNotVisible:  desc.NotVisible,
-		Invisibility:  desc.Invisibility,
+		TableID:            tbl.TableID,
+		IndexID:            desc.ID,
+		IsUnique:           desc.Unique,
+		IsInverted:         desc.Type == descpb.IndexDescriptor_INVERTED,
+		SourceIndexID:      newPrimaryIdx.IndexID,
+		IsNotVisible:       desc.NotVisible,
+		Invisibility:       desc.Invisibility,
+		ExclusionOperators: desc.ExclusionOperators,
 	}
 	tempIndexID := index.IndexID + 1 // this is enforced below
 	index.TemporaryIndexID = tempIndexID
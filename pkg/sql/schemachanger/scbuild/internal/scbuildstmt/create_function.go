@@ -28,6 +28,16 @@ func CreateFunction(b BuildCtx, n *tree.CreateFunction) {
 	if n.Replace {
 		panic(scerrors.NotImplementedError(n))
 	}
+	// The return type of a function with OUT parameters is derived from the
+	// parameters by the legacy schema changer.
+	for i := range n.Params {
+		if n.Params[i].IsOutParam() {
+			panic(scerrors.NotImplementedErrorf(n, "OUT parameters"))
+		}
+	}
+	if n.ReturnType.Type == nil {
+		panic(pgerror.New(pgcode.InvalidFunctionDefinition, "function result type must be specified"))
+	}
 
 	dbElts, scElts := b.ResolvePrefix(n.FuncName.ObjectNamePrefix, privilege.CREATE)
 	_, _, sc := scpb.FindSchema(scElts)
@@ -60,9 +70,10 @@ func CreateFunction(b BuildCtx, n *tree.CreateFunction) {
 
 	fnID := b.GenerateUniqueDescID()
 	fn := scpb.Function{
-		FunctionID: fnID,
-		ReturnSet:  n.ReturnType.IsSet,
-		ReturnType: b.ResolveTypeRef(n.ReturnType.Type),
+		FunctionID:  fnID,
+		ReturnSet:   n.ReturnType.IsSet,
+		ReturnType:  b.ResolveTypeRef(n.ReturnType.Type),
+		IsProcedure: n.IsProcedure,
 	}
 	fn.Params = make([]scpb.Function_Parameter, len(n.Params))
 	for i, param := range n.Params {
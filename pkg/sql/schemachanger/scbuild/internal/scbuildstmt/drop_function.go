@@ -26,6 +26,9 @@ func DropFunction(b BuildCtx, n *tree.DropFunction) {
 		// TODO(chengxiong): remove this when we allow UDF usage.
 		panic(scerrors.NotImplementedErrorf(n, "cascade dropping functions"))
 	}
+	if n.IsAggregate {
+		panic(scerrors.NotImplementedErrorf(n, "dropping aggregate functions"))
+	}
 
 	var toCheckBackRefs []catid.DescID
 	var toCheckBackRefsNames []*scpb.FunctionName
@@ -37,6 +40,9 @@ func DropFunction(b BuildCtx, n *tree.DropFunction) {
 		if fn == nil {
 			continue
 		}
+		if fn.IsProcedure != n.IsProcedure {
+			panic(routineKindMismatchError(&f, n.IsProcedure))
+		}
 		f.FuncName.ObjectNamePrefix = b.NamePrefix(fn)
 		if dropRestrictDescriptor(b, fn.FunctionID) {
 			toCheckBackRefs = append(toCheckBackRefs, fn.FunctionID)
@@ -60,3 +66,14 @@ func DropFunction(b BuildCtx, n *tree.DropFunction) {
 		}
 	}
 }
+
+// routineKindMismatchError returns an error for a DROP FUNCTION statement
+// that targets a procedure, or a DROP PROCEDURE statement that targets a
+// function.
+func routineKindMismatchError(f *tree.FuncObj, wantProcedure bool) error {
+	kind := "function"
+	if wantProcedure {
+		kind = "procedure"
+	}
+	return pgerror.Newf(pgcode.WrongObjectType, "%s is not a %s", f.FuncName.Object(), kind)
+}
@@ -24,6 +24,9 @@ import (
 
 // DropTable implements DROP TABLE.
 func DropTable(b BuildCtx, n *tree.DropTable) {
+	if n.IsForeign {
+		panic(scerrors.NotImplementedErrorf(n, "DROP FOREIGN TABLE"))
+	}
 	var toCheckBackrefs []catid.DescID
 	droppedOwnedSequences := make(map[catid.DescID]catalog.DescriptorIDSet)
 	for idx := range n.Names {
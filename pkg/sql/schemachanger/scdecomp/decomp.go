@@ -213,6 +213,10 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 				Name:            comp.GetElementLabel(i),
 			})
 		}
+	} else if typ.AsDomainTypeDescriptor() != nil {
+		// Domains are only handled by the legacy schema changer.
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"domain type %q (%d)", typ.GetName(), typ.GetID()))
 	} else {
 		panic(errors.AssertionFailedf("unsupported type kind %q", typ.GetKind()))
 	}
@@ -226,6 +230,14 @@ func (w *walkCtx) walkType(typ catalog.TypeDescriptor) {
 }
 
 func (w *walkCtx) walkRelation(tbl catalog.TableDescriptor) {
+	if len(tbl.GetPolicies()) > 0 || tbl.IsRowLevelSecurityEnabled() || tbl.IsRowLevelSecurityForced() {
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"table %q has row-level security", tbl.GetName()))
+	}
+	if tbl.IsForeignTable() {
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"table %q is a foreign table", tbl.GetName()))
+	}
 	switch {
 	case tbl.IsSequence():
 		w.ev(descriptorStatus(tbl), &scpb.Sequence{
@@ -530,6 +542,7 @@ func (w *walkCtx) walkIndex(tbl catalog.TableDescriptor, idx catalog.Index) {
 			ConstraintID:        idx.GetConstraintID(),
 			IsNotVisible:        idx.GetInvisibility() != 0.0,
 			Invisibility:        idx.GetInvisibility(),
+			ExclusionOperators:  cpy.ExclusionOperators,
 		}
 		if geoConfig := idx.GetGeoConfig(); !geoConfig.IsEmpty() {
 			index.GeoConfig = protoutil.Clone(&geoConfig).(*geoindex.Config)
@@ -748,12 +761,23 @@ func (w *walkCtx) walkForeignKeyConstraint(
 }
 
 func (w *walkCtx) walkFunction(fnDesc catalog.FunctionDescriptor) {
+	if fnDesc.GetAggregate() != nil {
+		panic(scerrors.NotImplementedErrorf(nil, /* n */
+			"function %q is a user-defined aggregate", fnDesc.GetName()))
+	}
+	for _, ref := range fnDesc.GetDependedOnBy() {
+		if w.lookupFn(ref.ID).DescriptorType() == catalog.Function {
+			panic(scerrors.NotImplementedErrorf(nil, /* n */
+				"function %q is referenced by a user-defined aggregate", fnDesc.GetName()))
+		}
+	}
 	typeT := newTypeT(fnDesc.GetReturnType().Type)
 	fn := &scpb.Function{
-		FunctionID: fnDesc.GetID(),
-		ReturnSet:  fnDesc.GetReturnType().ReturnSet,
-		ReturnType: *typeT,
-		Params:     make([]scpb.Function_Parameter, len(fnDesc.GetParams())),
+		FunctionID:  fnDesc.GetID(),
+		ReturnSet:   fnDesc.GetReturnType().ReturnSet,
+		ReturnType:  *typeT,
+		Params:      make([]scpb.Function_Parameter, len(fnDesc.GetParams())),
+		IsProcedure: fnDesc.GetIsProcedure(),
 	}
 	for i, param := range fnDesc.GetParams() {
 		typeT := newTypeT(param.Type)
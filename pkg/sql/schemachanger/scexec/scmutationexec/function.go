@@ -42,6 +42,7 @@ func (i *immediateVisitor) CreateFunctionDescriptor(
 		op.Function.ReturnSet,
 		&catpb.PrivilegeDescriptor{Version: catpb.Version21_2},
 	)
+	mut.SetIsProcedure(op.Function.IsProcedure)
 	mut.State = descpb.DescriptorState_ADD
 	i.CreateDescriptor(&mut)
 	return nil
@@ -18,7 +18,6 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/funcdesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/schemachanger/scop"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catid"
-	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/errors"
 )
@@ -533,13 +532,12 @@ func (i *immediateVisitor) SetObjectParentID(ctx context.Context, op scop.SetObj
 		t.ParentSchemaID = sc.GetID()
 
 		ol := descpb.SchemaDescriptor_FunctionSignature{
-			ID:         obj.GetID(),
-			ArgTypes:   make([]*types.T, len(t.GetParams())),
-			ReturnType: t.GetReturnType().Type,
-			ReturnSet:  t.GetReturnType().ReturnSet,
-		}
-		for i := range t.Params {
-			ol.ArgTypes[i] = t.Params[i].Type
+			ID:          obj.GetID(),
+			ArgTypes:    funcdesc.InParamTypes(t.Params),
+			ReturnType:  t.GetReturnType().Type,
+			ReturnSet:   t.GetReturnType().ReturnSet,
+			IsProcedure: t.GetIsProcedure(),
+			IsAggregate: t.GetAggregate() != nil,
 		}
 		sc.AddFunction(obj.GetName(), ol)
 	}
@@ -263,14 +263,24 @@ var regularBuiltins = map[string]builtinDefinition{
 	// TODO(pmattis): What string functions should also support types.Bytes?
 
 	"lower": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
-		stringOverload1(
-			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
-				return tree.NewDString(strings.ToLower(s)), nil
+		append([]tree.Overload{
+			stringOverload1(
+				func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
+					return tree.NewDString(strings.ToLower(s)), nil
+				},
+				types.String,
+				"Converts all characters in `val` to their lower-case equivalents.",
+				volatility.Immutable,
+			),
+		}, makeRangeBoundOverloads(
+			func(r *tree.DRange) tree.Datum {
+				if r.Empty {
+					return tree.DNull
+				}
+				return r.Lower
 			},
-			types.String,
-			"Converts all characters in `val` to their lower-case equivalents.",
-			volatility.Immutable,
-		),
+			"Returns the lower bound of the range, or NULL if the range is empty or has no lower bound.",
+		)...)...,
 	),
 
 	"unaccent": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -294,14 +304,24 @@ var regularBuiltins = map[string]builtinDefinition{
 	),
 
 	"upper": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
-		stringOverload1(
-			func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
-				return tree.NewDString(strings.ToUpper(s)), nil
+		append([]tree.Overload{
+			stringOverload1(
+				func(_ context.Context, _ *eval.Context, s string) (tree.Datum, error) {
+					return tree.NewDString(strings.ToUpper(s)), nil
+				},
+				types.String,
+				"Converts all characters in `val` to their to their upper-case equivalents.",
+				volatility.Immutable,
+			),
+		}, makeRangeBoundOverloads(
+			func(r *tree.DRange) tree.Datum {
+				if r.Empty {
+					return tree.DNull
+				}
+				return r.Upper
 			},
-			types.String,
-			"Converts all characters in `val` to their to their upper-case equivalents.",
-			volatility.Immutable,
-		),
+			"Returns the upper bound of the range, or NULL if the range is empty or has no upper bound.",
+		)...)...,
 	),
 
 	"prettify_statement": makeBuiltin(tree.FunctionProperties{Category: builtinconstants.CategoryString},
@@ -3765,7 +3785,6 @@ value if you rely on the HLC for accuracy.`,
 	"tsvector_concat":                makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"ts_debug":                       makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"ts_headline":                    makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
-	"ts_lexize":                      makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"websearch_to_tsquery":           makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"array_to_tsvector":              makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
 	"get_current_ts_config":          makeBuiltin(tree.FunctionProperties{UnsupportedWithIssue: 7821, Category: builtinconstants.CategoryFullTextSearch}),
@@ -5192,6 +5211,42 @@ DO NOT USE -- USE 'CREATE TENANT' INSTEAD`,
 		},
 	),
 
+	"crdb_internal.plpgsql_raise": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     builtinconstants.CategoryCompatibility,
+			Undocumented: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "severity", Typ: types.String},
+				{Name: "message", Typ: types.String},
+				{Name: "detail", Typ: types.String},
+				{Name: "hint", Typ: types.String},
+				{Name: "code", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				argStrings := make([]string, len(args))
+				for i := range args {
+					if args[i] == tree.DNull {
+						continue
+					}
+					s, ok := tree.AsDString(args[i])
+					if !ok {
+						return nil, errors.Newf("expected string value, got %T", args[i])
+					}
+					argStrings[i] = string(s)
+				}
+				return crdbInternalPLpgSQLRaise(
+					ctx, evalCtx, argStrings[0], argStrings[1], argStrings[2], argStrings[3], argStrings[4],
+				)
+			},
+			Info:              "This function is used internally to implement the PL/pgSQL RAISE statement.",
+			Volatility:        volatility.Volatile,
+			CalledOnNullInput: true,
+		},
+	),
+
 	"crdb_internal.force_assertion_error": makeBuiltin(
 		tree.FunctionProperties{
 			Category: builtinconstants.CategorySystemInfo,
@@ -6014,6 +6069,89 @@ DO NOT USE -- USE 'CREATE TENANT' INSTEAD`,
 		},
 	),
 
+	"crdb_internal.domain_not_null": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     builtinconstants.CategorySystemInfo,
+			Undocumented: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "val", Typ: types.Any},
+				{Name: "domain", Typ: types.String},
+			},
+			ReturnType: tree.IdentityReturnType(0),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[0] == tree.DNull {
+					return nil, pgerror.Newf(pgcode.NotNullViolation,
+						"domain %s does not allow null values", tree.MustBeDString(args[1]))
+				}
+				return args[0], nil
+			},
+			Info:       "This function is used internally to enforce NOT NULL constraints of domains.",
+			Volatility: volatility.Immutable,
+			// The function must be able to reject NULL values.
+			CalledOnNullInput: true,
+		},
+	),
+
+	"crdb_internal.domain_check": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     builtinconstants.CategorySystemInfo,
+			Undocumented: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "val", Typ: types.Any},
+				{Name: "ok", Typ: types.Bool},
+				{Name: "domain", Typ: types.String},
+				{Name: "constraint", Typ: types.String},
+			},
+			ReturnType: tree.IdentityReturnType(0),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				// Like table CHECK constraints, a NULL result satisfies the
+				// constraint.
+				if args[1] == tree.DBoolFalse {
+					return nil, pgerror.Newf(pgcode.CheckViolation,
+						"value for domain %s violates check constraint %q",
+						tree.MustBeDString(args[2]), tree.MustBeDString(args[3]))
+				}
+				return args[0], nil
+			},
+			Info:       "This function is used internally to enforce CHECK constraints of domains.",
+			Volatility: volatility.Immutable,
+			// The checked value may be NULL.
+			CalledOnNullInput: true,
+		},
+	),
+
+	"crdb_internal.row_level_security_check": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     builtinconstants.CategorySystemInfo,
+			Undocumented: true,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "ok", Typ: types.Bool},
+				{Name: "table", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				// Unlike CHECK constraints, a NULL result does not satisfy the
+				// WITH CHECK expression of a policy.
+				if args[0] != tree.DBoolTrue {
+					return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+						"new row violates row-level security policy for table %q",
+						tree.MustBeDString(args[1]))
+				}
+				return tree.DBoolTrue, nil
+			},
+			Info:       "This function is used internally to enforce row-level security policies.",
+			Volatility: volatility.Volatile,
+			// The checked expression may be NULL.
+			CalledOnNullInput: true,
+		},
+	),
+
 	"crdb_internal.round_decimal_values": makeBuiltin(
 		tree.FunctionProperties{
 			Category: builtinconstants.CategorySystemInfo,
@@ -6605,6 +6743,28 @@ Parameters:` + randgencfg.ConfigDoc,
 		},
 	),
 
+	"grouping": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types: tree.VariadicType{
+				VarType: types.Any,
+			},
+			ReturnType: tree.FixedReturnType(types.Int),
+			Fn: func(_ context.Context, _ *eval.Context, _ tree.Datums) (tree.Datum, error) {
+				// The optimizer replaces calls to grouping with an expression
+				// over the grouping set of each output row.
+				return nil, pgerror.New(pgcode.Grouping,
+					"grouping() can only be used in the target list, HAVING or ORDER BY of a query with GROUP BY")
+			},
+			Info: "Returns an integer bit mask indicating which of the arguments are not " +
+				"included in the grouping set of the current row. The last argument " +
+				"corresponds to the least significant bit. The arguments must be grouping " +
+				"expressions of the query.",
+			Volatility:        volatility.Immutable,
+			CalledOnNullInput: true,
+		},
+	),
+
 	builtinconstants.GatewayRegionBuiltinName: makeBuiltin(
 		tree.FunctionProperties{
 			Category: builtinconstants.CategoryMultiRegion,
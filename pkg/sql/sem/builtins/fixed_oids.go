@@ -2382,6 +2382,161 @@ var builtinOidsArray = []string{
 	2409: `st_bdpolyfromtext(str: string, srid: int) -> geometry`,
 	2410: `crdb_internal.pretty_value(raw_value: bytes) -> string`,
 	2411: `to_char(date: date, format: string) -> string`,
+	2412: `crdb_internal.plpgsql_raise(severity: string, message: string, detail: string, hint: string, code: string) -> int`,
+	2413: `grouping(anyelement...) -> int`,
+	2414: `int4rangesend(int4range: int4range) -> bytes`,
+	2415: `int4rangeout(int4range: int4range) -> bytes`,
+	2416: `int4rangerecv(input: anyelement) -> int4range`,
+	2417: `int4rangein(input: anyelement) -> int4range`,
+	2418: `int4multirangesend(int4multirange: int4multirange) -> bytes`,
+	2419: `int4multirangeout(int4multirange: int4multirange) -> bytes`,
+	2420: `int4multirangerecv(input: anyelement) -> int4multirange`,
+	2421: `int4multirangein(input: anyelement) -> int4multirange`,
+	2422: `int8rangesend(int8range: int8range) -> bytes`,
+	2423: `int8rangeout(int8range: int8range) -> bytes`,
+	2424: `int8rangerecv(input: anyelement) -> int8range`,
+	2425: `int8rangein(input: anyelement) -> int8range`,
+	2426: `int8multirangesend(int8multirange: int8multirange) -> bytes`,
+	2427: `int8multirangeout(int8multirange: int8multirange) -> bytes`,
+	2428: `int8multirangerecv(input: anyelement) -> int8multirange`,
+	2429: `int8multirangein(input: anyelement) -> int8multirange`,
+	2430: `numrangesend(numrange: numrange) -> bytes`,
+	2431: `numrangeout(numrange: numrange) -> bytes`,
+	2432: `numrangerecv(input: anyelement) -> numrange`,
+	2433: `numrangein(input: anyelement) -> numrange`,
+	2434: `nummultirangesend(nummultirange: nummultirange) -> bytes`,
+	2435: `nummultirangeout(nummultirange: nummultirange) -> bytes`,
+	2436: `nummultirangerecv(input: anyelement) -> nummultirange`,
+	2437: `nummultirangein(input: anyelement) -> nummultirange`,
+	2438: `tsrangesend(tsrange: tsrange) -> bytes`,
+	2439: `tsrangeout(tsrange: tsrange) -> bytes`,
+	2440: `tsrangerecv(input: anyelement) -> tsrange`,
+	2441: `tsrangein(input: anyelement) -> tsrange`,
+	2442: `tsmultirangesend(tsmultirange: tsmultirange) -> bytes`,
+	2443: `tsmultirangeout(tsmultirange: tsmultirange) -> bytes`,
+	2444: `tsmultirangerecv(input: anyelement) -> tsmultirange`,
+	2445: `tsmultirangein(input: anyelement) -> tsmultirange`,
+	2446: `tstzrangesend(tstzrange: tstzrange) -> bytes`,
+	2447: `tstzrangeout(tstzrange: tstzrange) -> bytes`,
+	2448: `tstzrangerecv(input: anyelement) -> tstzrange`,
+	2449: `tstzrangein(input: anyelement) -> tstzrange`,
+	2450: `tstzmultirangesend(tstzmultirange: tstzmultirange) -> bytes`,
+	2451: `tstzmultirangeout(tstzmultirange: tstzmultirange) -> bytes`,
+	2452: `tstzmultirangerecv(input: anyelement) -> tstzmultirange`,
+	2453: `tstzmultirangein(input: anyelement) -> tstzmultirange`,
+	2454: `daterangesend(daterange: daterange) -> bytes`,
+	2455: `daterangeout(daterange: daterange) -> bytes`,
+	2456: `daterangerecv(input: anyelement) -> daterange`,
+	2457: `daterangein(input: anyelement) -> daterange`,
+	2458: `datemultirangesend(datemultirange: datemultirange) -> bytes`,
+	2459: `datemultirangeout(datemultirange: datemultirange) -> bytes`,
+	2460: `datemultirangerecv(input: anyelement) -> datemultirange`,
+	2461: `datemultirangein(input: anyelement) -> datemultirange`,
+	2462: `lower(val: int8range) -> int`,
+	2463: `lower(val: int8multirange) -> int`,
+	2464: `lower(val: numrange) -> decimal`,
+	2465: `lower(val: nummultirange) -> decimal`,
+	2466: `lower(val: tsrange) -> timestamp`,
+	2467: `lower(val: tsmultirange) -> timestamp`,
+	2468: `lower(val: tstzrange) -> timestamptz`,
+	2469: `lower(val: tstzmultirange) -> timestamptz`,
+	2470: `lower(val: daterange) -> date`,
+	2471: `lower(val: datemultirange) -> date`,
+	2472: `upper(val: int8range) -> int`,
+	2473: `upper(val: int8multirange) -> int`,
+	2474: `upper(val: numrange) -> decimal`,
+	2475: `upper(val: nummultirange) -> decimal`,
+	2476: `upper(val: tsrange) -> timestamp`,
+	2477: `upper(val: tsmultirange) -> timestamp`,
+	2478: `upper(val: tstzrange) -> timestamptz`,
+	2479: `upper(val: tstzmultirange) -> timestamptz`,
+	2480: `upper(val: daterange) -> date`,
+	2481: `upper(val: datemultirange) -> date`,
+	2482: `isempty(val: int8range) -> bool`,
+	2483: `isempty(val: int8multirange) -> bool`,
+	2484: `isempty(val: numrange) -> bool`,
+	2485: `isempty(val: nummultirange) -> bool`,
+	2486: `isempty(val: tsrange) -> bool`,
+	2487: `isempty(val: tsmultirange) -> bool`,
+	2488: `isempty(val: tstzrange) -> bool`,
+	2489: `isempty(val: tstzmultirange) -> bool`,
+	2490: `isempty(val: daterange) -> bool`,
+	2491: `isempty(val: datemultirange) -> bool`,
+	2492: `lower_inc(val: int8range) -> bool`,
+	2493: `lower_inc(val: int8multirange) -> bool`,
+	2494: `lower_inc(val: numrange) -> bool`,
+	2495: `lower_inc(val: nummultirange) -> bool`,
+	2496: `lower_inc(val: tsrange) -> bool`,
+	2497: `lower_inc(val: tsmultirange) -> bool`,
+	2498: `lower_inc(val: tstzrange) -> bool`,
+	2499: `lower_inc(val: tstzmultirange) -> bool`,
+	2500: `lower_inc(val: daterange) -> bool`,
+	2501: `lower_inc(val: datemultirange) -> bool`,
+	2502: `upper_inc(val: int8range) -> bool`,
+	2503: `upper_inc(val: int8multirange) -> bool`,
+	2504: `upper_inc(val: numrange) -> bool`,
+	2505: `upper_inc(val: nummultirange) -> bool`,
+	2506: `upper_inc(val: tsrange) -> bool`,
+	2507: `upper_inc(val: tsmultirange) -> bool`,
+	2508: `upper_inc(val: tstzrange) -> bool`,
+	2509: `upper_inc(val: tstzmultirange) -> bool`,
+	2510: `upper_inc(val: daterange) -> bool`,
+	2511: `upper_inc(val: datemultirange) -> bool`,
+	2512: `lower_inf(val: int8range) -> bool`,
+	2513: `lower_inf(val: int8multirange) -> bool`,
+	2514: `lower_inf(val: numrange) -> bool`,
+	2515: `lower_inf(val: nummultirange) -> bool`,
+	2516: `lower_inf(val: tsrange) -> bool`,
+	2517: `lower_inf(val: tsmultirange) -> bool`,
+	2518: `lower_inf(val: tstzrange) -> bool`,
+	2519: `lower_inf(val: tstzmultirange) -> bool`,
+	2520: `lower_inf(val: daterange) -> bool`,
+	2521: `lower_inf(val: datemultirange) -> bool`,
+	2522: `upper_inf(val: int8range) -> bool`,
+	2523: `upper_inf(val: int8multirange) -> bool`,
+	2524: `upper_inf(val: numrange) -> bool`,
+	2525: `upper_inf(val: nummultirange) -> bool`,
+	2526: `upper_inf(val: tsrange) -> bool`,
+	2527: `upper_inf(val: tsmultirange) -> bool`,
+	2528: `upper_inf(val: tstzrange) -> bool`,
+	2529: `upper_inf(val: tstzmultirange) -> bool`,
+	2530: `upper_inf(val: daterange) -> bool`,
+	2531: `upper_inf(val: datemultirange) -> bool`,
+	2532: `range_merge(left: int8range, right: int8range) -> int8range`,
+	2533: `range_merge(left: numrange, right: numrange) -> numrange`,
+	2534: `range_merge(left: tsrange, right: tsrange) -> tsrange`,
+	2535: `range_merge(left: tstzrange, right: tstzrange) -> tstzrange`,
+	2536: `range_merge(left: daterange, right: daterange) -> daterange`,
+	2537: `range_merge(val: int8multirange) -> int8range`,
+	2538: `range_merge(val: nummultirange) -> numrange`,
+	2539: `range_merge(val: tsmultirange) -> tsrange`,
+	2540: `range_merge(val: tstzmultirange) -> tstzrange`,
+	2541: `range_merge(val: datemultirange) -> daterange`,
+	2542: `int4range(lower: int, upper: int) -> int4range`,
+	2543: `int4range(lower: int, upper: int, bounds: string) -> int4range`,
+	2544: `int4multirange(int4range...) -> int4multirange`,
+	2545: `int8range(lower: int, upper: int) -> int8range`,
+	2546: `int8range(lower: int, upper: int, bounds: string) -> int8range`,
+	2547: `int8multirange(int8range...) -> int8multirange`,
+	2548: `numrange(lower: decimal, upper: decimal) -> numrange`,
+	2549: `numrange(lower: decimal, upper: decimal, bounds: string) -> numrange`,
+	2550: `nummultirange(numrange...) -> nummultirange`,
+	2551: `tsrange(lower: timestamp, upper: timestamp) -> tsrange`,
+	2552: `tsrange(lower: timestamp, upper: timestamp, bounds: string) -> tsrange`,
+	2553: `tsmultirange(tsrange...) -> tsmultirange`,
+	2554: `tstzrange(lower: timestamptz, upper: timestamptz) -> tstzrange`,
+	2555: `tstzrange(lower: timestamptz, upper: timestamptz, bounds: string) -> tstzrange`,
+	2556: `tstzmultirange(tstzrange...) -> tstzmultirange`,
+	2557: `daterange(lower: date, upper: date) -> daterange`,
+	2558: `daterange(lower: date, upper: date, bounds: string) -> daterange`,
+	2559: `datemultirange(daterange...) -> datemultirange`,
+	2560: `crdb_internal.domain_not_null(val: anyelement, domain: string) -> anyelement`,
+	2561: `crdb_internal.domain_check(val: anyelement, ok: bool, domain: string, constraint: string) -> anyelement`,
+	2562: `crdb_internal.row_level_security_check(ok: bool, table: string) -> bool`,
+	2563: `trigger_send(trigger: trigger) -> bytes`,
+	2564: `trigger_recv(input: anyelement) -> trigger`,
+	2565: `trigger_out(trigger: trigger) -> bytes`,
+	2566: `trigger_in(input: anyelement) -> trigger`,
 }
 
 var builtinOidsBySignature map[string]oid.Oid
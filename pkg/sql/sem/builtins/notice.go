@@ -14,6 +14,8 @@ import (
 	"context"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -35,3 +37,37 @@ func crdbInternalSendNotice(
 	)
 	return tree.NewDInt(0), nil
 }
+
+// crdbInternalPLpgSQLRaise implements the PL/pgSQL RAISE statement. An error
+// is returned if the severity is ERROR. Otherwise, a notice with the given
+// severity is sent to the client.
+func crdbInternalPLpgSQLRaise(
+	ctx context.Context, evalCtx *eval.Context, severity, message, detail, hint, code string,
+) (tree.Datum, error) {
+	severity = strings.ToUpper(severity)
+	if severity == "ERROR" {
+		if code == "" {
+			code = pgcode.RaiseException.String()
+		}
+		err := pgerror.Newf(pgcode.MakeCode(code), "%s", message)
+		if detail != "" {
+			err = errors.WithDetail(err, detail)
+		}
+		if hint != "" {
+			err = errors.WithHint(err, hint)
+		}
+		return nil, err
+	}
+	if evalCtx.ClientNoticeSender == nil {
+		return nil, errors.AssertionFailedf("notice sender not set")
+	}
+	var notice error = pgnotice.NewWithSeverityf(severity, "%s", message)
+	if detail != "" {
+		notice = errors.WithDetail(notice, detail)
+	}
+	if hint != "" {
+		notice = errors.WithHint(notice, hint)
+	}
+	evalCtx.ClientNoticeSender.BufferClientNotice(ctx, pgnotice.Notice(notice))
+	return tree.NewDInt(0), nil
+}
@@ -87,6 +87,7 @@ var typeBuiltinsHaveUnderscore = map[oid.Oid]struct{}{
 	types.Timestamp.Oid():   {},
 	types.TimestampTZ.Oid(): {},
 	types.AnyTuple.Oid():    {},
+	types.Trigger.Oid():     {},
 }
 
 // PGIOBuiltinPrefix returns the string prefix to a type's IO functions. This
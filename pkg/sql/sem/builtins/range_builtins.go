@@ -0,0 +1,282 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package builtins
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+func init() {
+	for k, v := range rangeBuiltins {
+		v.props.Category = builtinconstants.CategoryRange
+		v.props.AvailableOnPublicSchema = true
+		registerBuiltin(k, v)
+	}
+	for i, rng := range types.RangeTypes {
+		registerBuiltin(rng.Name(), makeRangeConstructorBuiltin(rng))
+		registerBuiltin(types.MultirangeTypes[i].Name(), makeMultirangeConstructorBuiltin(types.MultirangeTypes[i]))
+	}
+}
+
+// rangeOverloadTypes contains the range types for which the builtins that take
+// range arguments have overloads. INT4RANGE is omitted since it is equivalent
+// to INT8RANGE for the purposes of overload resolution.
+var rangeOverloadTypes = []*types.T{
+	types.Int8Range, types.NumRange, types.TSRange, types.TSTZRange, types.DateRange,
+}
+
+var rangeBuiltins = map[string]builtinDefinition{
+	"isempty": makeRangeBuiltin(
+		func(rng *types.T) *types.T { return types.Bool },
+		func(r *tree.DRange) tree.Datum { return tree.MakeDBool(tree.DBool(r.Empty)) },
+		func(mr *tree.DMultirange) tree.Datum { return tree.MakeDBool(tree.DBool(len(mr.Ranges) == 0)) },
+		"Returns true if the range is empty.",
+	),
+	"lower_inc": makeRangeBuiltin(
+		func(rng *types.T) *types.T { return types.Bool },
+		func(r *tree.DRange) tree.Datum { return tree.MakeDBool(tree.DBool(r.LowerInc)) },
+		func(mr *tree.DMultirange) tree.Datum { return tree.MakeDBool(tree.DBool(mr.Span().LowerInc)) },
+		"Returns true if the lower bound of the range is inclusive.",
+	),
+	"upper_inc": makeRangeBuiltin(
+		func(rng *types.T) *types.T { return types.Bool },
+		func(r *tree.DRange) tree.Datum { return tree.MakeDBool(tree.DBool(r.UpperInc)) },
+		func(mr *tree.DMultirange) tree.Datum { return tree.MakeDBool(tree.DBool(mr.Span().UpperInc)) },
+		"Returns true if the upper bound of the range is inclusive.",
+	),
+	"lower_inf": makeRangeBuiltin(
+		func(rng *types.T) *types.T { return types.Bool },
+		func(r *tree.DRange) tree.Datum { return tree.MakeDBool(tree.DBool(rangeLowerInf(r))) },
+		func(mr *tree.DMultirange) tree.Datum { return tree.MakeDBool(tree.DBool(rangeLowerInf(mr.Span()))) },
+		"Returns true if the range has no lower bound.",
+	),
+	"upper_inf": makeRangeBuiltin(
+		func(rng *types.T) *types.T { return types.Bool },
+		func(r *tree.DRange) tree.Datum { return tree.MakeDBool(tree.DBool(rangeUpperInf(r))) },
+		func(mr *tree.DMultirange) tree.Datum { return tree.MakeDBool(tree.DBool(rangeUpperInf(mr.Span()))) },
+		"Returns true if the range has no upper bound.",
+	),
+	"range_merge": makeBuiltin(tree.FunctionProperties{},
+		append(
+			makeRangeMergeOverloads(),
+			makeRangeOverloads(
+				func(rng *types.T) *types.T { return rng },
+				nil, /* rangeFn */
+				func(mr *tree.DMultirange) tree.Datum { return mr.Span() },
+				"Returns the smallest range that includes all the ranges of the multirange.",
+			)...,
+		)...,
+	),
+}
+
+// makeRangeBoundOverloads returns the overloads of lower or upper for range
+// and multirange arguments. bound returns the bound of a range, which is
+// DNull if the range is empty or the bound is infinite.
+func makeRangeBoundOverloads(bound func(r *tree.DRange) tree.Datum, info string) []tree.Overload {
+	return makeRangeOverloads(
+		func(rng *types.T) *types.T { return rng.RangeContents() },
+		bound,
+		func(mr *tree.DMultirange) tree.Datum { return bound(mr.Span()) },
+		info,
+	)
+}
+
+// makeRangeBuiltin returns a builtin with one overload for each range type and
+// one overload for each multirange type.
+func makeRangeBuiltin(
+	retType func(rng *types.T) *types.T,
+	rangeFn func(r *tree.DRange) tree.Datum,
+	multirangeFn func(mr *tree.DMultirange) tree.Datum,
+	info string,
+) builtinDefinition {
+	return makeBuiltin(tree.FunctionProperties{}, makeRangeOverloads(retType, rangeFn, multirangeFn, info)...)
+}
+
+// makeRangeOverloads returns an overload that calls rangeFn for each range
+// type and an overload that calls multirangeFn for each multirange type. The
+// return type of the overloads is determined by retType, which is called with
+// the range type. rangeFn may be nil, in which case only the multirange
+// overloads are returned.
+func makeRangeOverloads(
+	retType func(rng *types.T) *types.T,
+	rangeFn func(r *tree.DRange) tree.Datum,
+	multirangeFn func(mr *tree.DMultirange) tree.Datum,
+	info string,
+) []tree.Overload {
+	var overloads []tree.Overload
+	for _, rng := range rangeOverloadTypes {
+		if rangeFn != nil {
+			overloads = append(overloads, tree.Overload{
+				Types:      tree.ParamTypes{{Name: "val", Typ: rng}},
+				ReturnType: tree.FixedReturnType(retType(rng)),
+				Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+					return rangeFn(tree.MustBeDRange(args[0])), nil
+				},
+				Info:       info,
+				Volatility: volatility.Immutable,
+			})
+		}
+		overloads = append(overloads, tree.Overload{
+			Types:      tree.ParamTypes{{Name: "val", Typ: types.MakeMultirange(rng)}},
+			ReturnType: tree.FixedReturnType(retType(rng)),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return multirangeFn(tree.MustBeDMultirange(args[0])), nil
+			},
+			Info:       info,
+			Volatility: volatility.Immutable,
+		})
+	}
+	return overloads
+}
+
+// makeRangeMergeOverloads returns the overloads of range_merge that merge two
+// ranges.
+func makeRangeMergeOverloads() []tree.Overload {
+	overloads := make([]tree.Overload, len(rangeOverloadTypes))
+	for i, rng := range rangeOverloadTypes {
+		overloads[i] = tree.Overload{
+			Types:      tree.ParamTypes{{Name: "left", Typ: rng}, {Name: "right", Typ: rng}},
+			ReturnType: tree.FixedReturnType(rng),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return tree.MustBeDRange(args[0]).Merge(tree.MustBeDRange(args[1])), nil
+			},
+			Info:       "Returns the smallest range that includes both of the given ranges.",
+			Volatility: volatility.Immutable,
+		}
+	}
+	return overloads
+}
+
+// rangeLowerInf returns true if the range has no lower bound. The empty range
+// has no bounds, but they are not infinite.
+func rangeLowerInf(r *tree.DRange) bool {
+	return !r.Empty && r.Lower == tree.DNull
+}
+
+// rangeUpperInf returns true if the range has no upper bound. The empty range
+// has no bounds, but they are not infinite.
+func rangeUpperInf(r *tree.DRange) bool {
+	return !r.Empty && r.Upper == tree.DNull
+}
+
+// makeRangeConstructorBuiltin returns the constructor function of the given
+// range type, which is named after the type. The constructor takes the lower
+// and upper bounds, either of which may be NULL to indicate an infinite bound,
+// and optionally a string that specifies the inclusivity of the bounds.
+func makeRangeConstructorBuiltin(rng *types.T) builtinDefinition {
+	// The constructors of INT4RANGE and INT8RANGE both take INT arguments.
+	subtype := rng.RangeContents()
+	if subtype.Family() == types.IntFamily {
+		subtype = types.Int
+	}
+	construct := func(args tree.Datums, bounds string) (tree.Datum, error) {
+		var lowerInc, upperInc bool
+		switch bounds {
+		case "[)":
+			lowerInc = true
+		case "[]":
+			lowerInc, upperInc = true, true
+		case "(]":
+			upperInc = true
+		case "()":
+		default:
+			return nil, errors.WithHint(
+				pgerror.New(pgcode.Syntax, "invalid range bound flags"),
+				`Valid values are "[]", "[)", "(]", and "()".`,
+			)
+		}
+		lower, upper := args[0], args[1]
+		for _, d := range []*tree.Datum{&lower, &upper} {
+			if *d == tree.DNull {
+				continue
+			}
+			var err error
+			if *d, err = tree.AdjustValueToType(rng.RangeContents(), *d); err != nil {
+				return nil, err
+			}
+		}
+		return tree.MakeDRange(rng, lower, upper, lowerInc, upperInc)
+	}
+	return makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryRange},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "lower", Typ: subtype}, {Name: "upper", Typ: subtype}},
+			ReturnType: tree.FixedReturnType(rng),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				return construct(args, "[)")
+			},
+			Info: fmt.Sprintf(
+				"Returns the %s with the given bounds, which includes the lower bound "+
+					"and excludes the upper bound. A NULL bound is infinite.", rng.SQLString(),
+			),
+			CalledOnNullInput: true,
+			Volatility:        volatility.Immutable,
+		},
+		tree.Overload{
+			Types: tree.ParamTypes{
+				{Name: "lower", Typ: subtype}, {Name: "upper", Typ: subtype}, {Name: "bounds", Typ: types.String},
+			},
+			ReturnType: tree.FixedReturnType(rng),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				if args[2] == tree.DNull {
+					return nil, pgerror.New(pgcode.NullValueNotAllowed, "range constructor flags argument must not be null")
+				}
+				return construct(args, string(tree.MustBeDString(args[2])))
+			},
+			Info: fmt.Sprintf(
+				"Returns the %s with the given bounds, whose inclusivity is specified by `bounds`, "+
+					"which is one of `[]`, `[)`, `(]`, or `()`. A NULL bound is infinite.", rng.SQLString(),
+			),
+			CalledOnNullInput: true,
+			Volatility:        volatility.Immutable,
+		},
+	)
+}
+
+// makeMultirangeConstructorBuiltin returns the constructor function of the
+// given multirange type, which is named after the type. The constructor takes
+// any number of ranges and returns their union.
+func makeMultirangeConstructorBuiltin(mr *types.T) builtinDefinition {
+	return makeBuiltin(
+		tree.FunctionProperties{Category: builtinconstants.CategoryRange},
+		tree.Overload{
+			Types:      tree.VariadicType{VarType: mr.MultirangeContents()},
+			ReturnType: tree.FixedReturnType(mr),
+			Fn: func(_ context.Context, _ *eval.Context, args tree.Datums) (tree.Datum, error) {
+				ranges := make([]*tree.DRange, 0, len(args))
+				for _, arg := range args {
+					if arg == tree.DNull {
+						return nil, pgerror.New(pgcode.NullValueNotAllowed, "multirange values cannot contain null members")
+					}
+					r, err := tree.AdjustValueToType(mr.MultirangeContents(), arg)
+					if err != nil {
+						return nil, err
+					}
+					ranges = append(ranges, tree.MustBeDRange(r))
+				}
+				return tree.NewDMultirange(mr, ranges), nil
+			},
+			Info:              fmt.Sprintf("Returns the %s that contains the union of the given ranges.", mr.SQLString()),
+			CalledOnNullInput: true,
+			Volatility:        volatility.Immutable,
+		},
+	)
+}
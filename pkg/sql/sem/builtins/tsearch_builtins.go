@@ -14,9 +14,11 @@ import (
 	"context"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins/builtinconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/eval"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/volatility"
@@ -93,9 +95,12 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSVector),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
 				// Parse, stem, and stopword the input.
-				config := string(tree.MustBeDString(args[0]))
+				config, err := getTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				document := string(tree.MustBeDString(args[1]))
 				vector, err := tsearch.DocumentToTSVector(config, document)
 				if err != nil {
@@ -110,8 +115,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSVector),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig)
+				if err != nil {
+					return nil, err
+				}
 				document := string(tree.MustBeDString(args[0]))
 				vector, err := tsearch.DocumentToTSVector(config, document)
 				if err != nil {
@@ -129,8 +137,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.ToTSQuery(config, input)
 				if err != nil {
@@ -146,8 +157,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig)
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.ToTSQuery(config, input)
 				if err != nil {
@@ -166,8 +180,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.PlainToTSQuery(config, input)
 				if err != nil {
@@ -182,8 +199,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig)
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.PlainToTSQuery(config, input)
 				if err != nil {
@@ -201,8 +221,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "config", Typ: types.String}, {Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := string(tree.MustBeDString(args[0]))
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[1]))
 				query, err := tsearch.PhraseToTSQuery(config, input)
 				if err != nil {
@@ -217,8 +240,11 @@ var tsearchBuiltins = map[string]builtinDefinition{
 		tree.Overload{
 			Types:      tree.ParamTypes{{Name: "text", Typ: types.String}},
 			ReturnType: tree.FixedReturnType(types.TSQuery),
-			Fn: func(_ context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
-				config := tsearch.GetConfigKey(evalCtx.SessionData().DefaultTextSearchConfig)
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				config, err := getTextSearchConfig(ctx, evalCtx, evalCtx.SessionData().DefaultTextSearchConfig)
+				if err != nil {
+					return nil, err
+				}
 				input := string(tree.MustBeDString(args[0]))
 				query, err := tsearch.PhraseToTSQuery(config, input)
 				if err != nil {
@@ -231,6 +257,33 @@ var tsearchBuiltins = map[string]builtinDefinition{
 			Volatility: volatility.Stable,
 		},
 	),
+	"ts_lexize": makeBuiltin(
+		tree.FunctionProperties{},
+		tree.Overload{
+			Types:      tree.ParamTypes{{Name: "dict", Typ: types.String}, {Name: "token", Typ: types.String}},
+			ReturnType: tree.FixedReturnType(types.StringArray),
+			Fn: func(ctx context.Context, evalCtx *eval.Context, args tree.Datums) (tree.Datum, error) {
+				dict, err := getTextSearchDictionary(ctx, evalCtx, string(tree.MustBeDString(args[0])))
+				if err != nil {
+					return nil, err
+				}
+				lexemes, ok := dict.Lexize(string(tree.MustBeDString(args[1])))
+				if !ok {
+					return tree.DNull, nil
+				}
+				arr := tree.NewDArray(types.String)
+				for _, lexeme := range lexemes {
+					if err := arr.Append(tree.NewDString(lexeme)); err != nil {
+						return nil, err
+					}
+				}
+				return arr, nil
+			},
+			Info: "Returns the lexemes the dictionary normalizes the token into, an empty array if " +
+				"the token is a stop word, or NULL if the dictionary does not recognize the token.",
+			Volatility: volatility.Stable,
+		},
+	),
 	"ts_rank": makeBuiltin(
 		tree.FunctionProperties{},
 		tree.Overload{
@@ -332,6 +385,59 @@ var tsearchBuiltins = map[string]builtinDefinition{
 	),
 }
 
+// getTextSearchConfig returns the text search configuration with the given
+// name, which is either a built-in configuration, or a user-defined
+// configuration qualified with its schema and, optionally, its database.
+func getTextSearchConfig(
+	ctx context.Context, evalCtx *eval.Context, name string,
+) (*tsearch.Config, error) {
+	if config, ok := tsearch.BuiltinConfig(name); ok {
+		return config, nil
+	}
+	if err := tsearch.ValidConfig(name); err != nil {
+		return nil, err
+	}
+	tn, err := parseTextSearchObjectName(evalCtx, name)
+	if err != nil {
+		return nil, err
+	}
+	return evalCtx.CatalogBuiltins.ResolveTextSearchConfig(ctx, tn)
+}
+
+// getTextSearchDictionary returns the text search dictionary with the given
+// name, which is either a built-in dictionary, or a user-defined dictionary
+// qualified with its schema and, optionally, its database.
+func getTextSearchDictionary(
+	ctx context.Context, evalCtx *eval.Context, name string,
+) (*tsearch.Dictionary, error) {
+	if dict, ok := tsearch.BuiltinDictionary(name); ok {
+		return dict, nil
+	}
+	tn, err := parseTextSearchObjectName(evalCtx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !tn.ExplicitSchema || tn.Schema() == catconstants.PgCatalogName {
+		return nil, tsearch.UndefinedDictionaryError(name)
+	}
+	return evalCtx.CatalogBuiltins.ResolveTextSearchDictionary(ctx, tn)
+}
+
+// parseTextSearchObjectName parses the name of a user-defined text search
+// object, and qualifies it with the current database if it has no explicit
+// database.
+func parseTextSearchObjectName(evalCtx *eval.Context, name string) (*tree.TableName, error) {
+	tn, err := parser.ParseQualifiedTableName(name)
+	if err != nil {
+		return nil, err
+	}
+	if !tn.ExplicitCatalog {
+		tn.CatalogName = tree.Name(evalCtx.SessionData().Database)
+		tn.ExplicitCatalog = true
+	}
+	return tn, nil
+}
+
 func getWeights(arr *tree.DArray) ([]float32, error) {
 	ret := make([]float32, 4)
 	if arr.Len() < len(ret) {
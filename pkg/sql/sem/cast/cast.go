@@ -282,6 +282,35 @@ func LookupCast(src, tgt *types.T) (Cast, bool) {
 		}, true
 	}
 
+	// Casts from range and multirange types to string types are stable and
+	// allowed in assignment contexts, and casts from string types to range and
+	// multirange types are stable and allowed in explicit contexts.
+	isRangeFamily := func(f types.Family) bool {
+		return f == types.RangeFamily || f == types.MultirangeFamily
+	}
+	if isRangeFamily(srcFamily) && tgtFamily == types.StringFamily {
+		return Cast{
+			MaxContext: ContextAssignment,
+			Volatility: volatility.Stable,
+		}, true
+	}
+	if srcFamily == types.StringFamily && isRangeFamily(tgtFamily) {
+		return Cast{
+			MaxContext: ContextExplicit,
+			Volatility: volatility.Stable,
+		}, true
+	}
+
+	// Casts from a range type to its multirange type are immutable and allowed
+	// in explicit contexts.
+	if srcFamily == types.RangeFamily && tgtFamily == types.MultirangeFamily &&
+		src.Oid() == tgt.MultirangeContents().Oid() {
+		return Cast{
+			MaxContext: ContextExplicit,
+			Volatility: volatility.Immutable,
+		}, true
+	}
+
 	// Casts from int types to bit and varbit types are allowed only if the the
 	// length of the bit or varbit is defined
 	if srcFamily == types.IntFamily &&
@@ -450,7 +450,7 @@ func performCastWithoutPrecisionTruncation(
 				tree.FmtDataConversionConfig(evalCtx.SessionData().DataConversionConfig),
 				tree.FmtLocation(evalCtx.GetLocation()),
 			)
-		case *tree.DArray:
+		case *tree.DArray, *tree.DRange, *tree.DMultirange:
 			s = tree.AsStringWithFlags(
 				d,
 				tree.FmtPgwireText,
@@ -953,6 +953,38 @@ func performCastWithoutPrecisionTruncation(
 			res, _, err := tree.ParseDTupleFromString(evalCtx, string(*v), t)
 			return res, err
 		}
+	case types.RangeFamily:
+		if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V23_2_RangeTypes) {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use range types",
+				clusterversion.ByKey(clusterversion.V23_2_RangeTypes))
+		}
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDRangeFromString(evalCtx, string(*v), t)
+			return res, err
+		case *tree.DRange:
+			return tree.AdjustValueToType(t, v)
+		}
+	case types.MultirangeFamily:
+		if !evalCtx.Settings.Version.IsActive(ctx, clusterversion.V23_2_RangeTypes) {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use range types",
+				clusterversion.ByKey(clusterversion.V23_2_RangeTypes))
+		}
+		switch v := d.(type) {
+		case *tree.DString:
+			res, _, err := tree.ParseDMultirangeFromString(evalCtx, string(*v), t)
+			return res, err
+		case *tree.DRange:
+			r, err := tree.AdjustValueToType(t.MultirangeContents(), v)
+			if err != nil {
+				return nil, err
+			}
+			return tree.NewDMultirange(t, []*tree.DRange{tree.MustBeDRange(r)}), nil
+		case *tree.DMultirange:
+			return tree.AdjustValueToType(t, v)
+		}
 	case types.VoidFamily:
 		switch d.(type) {
 		case *tree.DString:
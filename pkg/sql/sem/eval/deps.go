@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/rangedesc"
+	"github.com/cockroachdb/cockroach/pkg/util/tsearch"
 	"github.com/lib/pq/oid"
 )
 
@@ -127,6 +128,14 @@ type CatalogBuiltins interface {
 	DescriptorWithPostDeserializationChanges(
 		ctx context.Context, encodedDescriptor []byte,
 	) ([]byte, error)
+
+	// ResolveTextSearchConfig returns the user-defined text search
+	// configuration with the given fully-qualified name.
+	ResolveTextSearchConfig(ctx context.Context, name *tree.TableName) (*tsearch.Config, error)
+
+	// ResolveTextSearchDictionary returns the user-defined text search
+	// dictionary with the given fully-qualified name.
+	ResolveTextSearchDictionary(ctx context.Context, name *tree.TableName) (*tsearch.Dictionary, error)
 }
 
 // HasPrivilegeSpecifier specifies an object to lookup privilege for.
@@ -12,10 +12,57 @@ package plpgsqltree
 
 import "github.com/cockroachdb/errors"
 
-// PLpgSQLRaiseOptionType represents the severity of the error in
-// a raise statement.
+// PLpgSQLRaiseOptionType represents the kind of an option specified in the
+// USING clause of a raise statement.
 type PLpgSQLRaiseOptionType int
 
+const (
+	// PLpgSQLRaiseOptionMessage sets the error message text.
+	PLpgSQLRaiseOptionMessage PLpgSQLRaiseOptionType = iota
+	// PLpgSQLRaiseOptionDetail supplies an error detail message.
+	PLpgSQLRaiseOptionDetail
+	// PLpgSQLRaiseOptionHint supplies a hint message.
+	PLpgSQLRaiseOptionHint
+	// PLpgSQLRaiseOptionErrCode specifies the error code (SQLSTATE) to report,
+	// either by condition name or directly as a five-character code.
+	PLpgSQLRaiseOptionErrCode
+	// PLpgSQLRaiseOptionColumn supplies the name of a related column.
+	PLpgSQLRaiseOptionColumn
+	// PLpgSQLRaiseOptionConstraint supplies the name of a related constraint.
+	PLpgSQLRaiseOptionConstraint
+	// PLpgSQLRaiseOptionDataType supplies the name of a related data type.
+	PLpgSQLRaiseOptionDataType
+	// PLpgSQLRaiseOptionTable supplies the name of a related table.
+	PLpgSQLRaiseOptionTable
+	// PLpgSQLRaiseOptionSchema supplies the name of a related schema.
+	PLpgSQLRaiseOptionSchema
+)
+
+// String implements the fmt.Stringer interface.
+func (t PLpgSQLRaiseOptionType) String() string {
+	switch t {
+	case PLpgSQLRaiseOptionMessage:
+		return "MESSAGE"
+	case PLpgSQLRaiseOptionDetail:
+		return "DETAIL"
+	case PLpgSQLRaiseOptionHint:
+		return "HINT"
+	case PLpgSQLRaiseOptionErrCode:
+		return "ERRCODE"
+	case PLpgSQLRaiseOptionColumn:
+		return "COLUMN"
+	case PLpgSQLRaiseOptionConstraint:
+		return "CONSTRAINT"
+	case PLpgSQLRaiseOptionDataType:
+		return "DATATYPE"
+	case PLpgSQLRaiseOptionTable:
+		return "TABLE"
+	case PLpgSQLRaiseOptionSchema:
+		return "SCHEMA"
+	}
+	panic(errors.AssertionFailedf("unknown raise option type %d", t))
+}
+
 // PLpgSQLGetDiagKind represents the type of error diagnostic
 // item in stmt_getdiag.
 type PLpgSQLGetDiagKind int
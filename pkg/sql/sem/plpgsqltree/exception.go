@@ -10,20 +10,54 @@
 
 package plpgsqltree
 
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
 type PLpgSQLExceptionBlock struct {
 	SqlStateVarNo int
 	SqlErrmNo     int
 	ExecList      []*PLpgSQLException
 }
 
+func (s *PLpgSQLExceptionBlock) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("EXCEPTION\n")
+	for _, e := range s.ExecList {
+		e.Format(ctx)
+	}
+}
+
 type PLpgSQLException struct {
-	LineNo    int
-	Condition *PLpgSQLCondition
-	Action    []PLpgSQLStatement
+	LineNo int
+	// Conditions is the list of conditions that are handled by Action. The
+	// exception handler matches an error if any of the conditions match.
+	Conditions []PLpgSQLCondition
+	Action     []PLpgSQLStatement
+}
+
+func (s *PLpgSQLException) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("WHEN ")
+	for i, cond := range s.Conditions {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		if cond.SqlErrState != "" {
+			ctx.WriteString("SQLSTATE ")
+			ctx.WriteString(lexbase.EscapeSQLString(cond.SqlErrState))
+		} else {
+			ctx.WriteString(cond.Name)
+		}
+	}
+	ctx.WriteString(" THEN\n")
+	for _, stmt := range s.Action {
+		stmt.Format(ctx)
+	}
 }
 
+// PLpgSQLCondition is a condition in an exception handler. Either the SQLSTATE
+// code or the name of the condition (e.g. division_by_zero) is set.
 type PLpgSQLCondition struct {
-	SqlErrState int
-	Name        string // TODO postgres says it's helpful for debugging.
-	Next        *PLpgSQLCondition
+	SqlErrState string
+	Name        string
 }
@@ -13,6 +13,7 @@ package plpgsqltree
 import (
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 )
 
@@ -56,22 +57,34 @@ func (s *PLpgSQLStatementImpl) plpgsqlStmt() {}
 // pl_block
 type PLpgSQLStmtBlock struct {
 	PLpgSQLStatementImpl
-	Label      string
+	Label string
+	// Decls contains the declarations from the DECLARE section of the block.
+	// It is non-nil if the block has a DECLARE section, even if the section is
+	// empty.
+	Decls      []PLpgSQLStatement
 	Body       []PLpgSQLStatement
-	InitVars   []PLpgSQLVariable
 	Exceptions *PLpgSQLExceptionBlock
 	Scope      VariableScope
 }
 
 func (s *PLpgSQLStmtBlock) Format(ctx *tree.FmtCtx) {
-	if s.InitVars != nil {
+	if s.Label != "" {
+		ctx.Printf("<<%s>>\n", s.Label)
+	}
+	if s.Decls != nil {
 		ctx.WriteString("DECLARE\n")
+		for _, decl := range s.Decls {
+			decl.Format(ctx)
+		}
 	}
 	// TODO: Make sure the child statement is pretty printed correctly
 	ctx.WriteString("BEGIN\n")
 	for _, childStmt := range s.Body {
 		childStmt.Format(ctx)
 	}
+	if s.Exceptions != nil {
+		s.Exceptions.Format(ctx)
+	}
 	ctx.WriteString("END\n")
 	ctx.WriteString("<NOT DONE YET>")
 }
@@ -85,13 +98,104 @@ func (s *PLpgSQLStmtBlock) WalkStmt(visitor PLpgSQLStmtVisitor) {
 	for _, stmt := range s.Body {
 		stmt.WalkStmt(visitor)
 	}
+	if s.Exceptions != nil {
+		for _, e := range s.Exceptions.ExecList {
+			for _, stmt := range e.Action {
+				stmt.WalkStmt(visitor)
+			}
+		}
+	}
+}
+
+// decl_stmt
+type PLpgSQLDecl struct {
+	PLpgSQLStatementImpl
+	Var      PLpgSQLVariable
+	Constant bool
+	Typ      tree.ResolvableTypeReference
+	Collate  string
+	NotNull  bool
+	// Expr is the default value of the variable. It is nil if no default was
+	// given, in which case the variable is initialized to NULL.
+	Expr PLpgSQLExpr
+}
+
+func (s *PLpgSQLDecl) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(&s.Var)
+	if s.Constant {
+		ctx.WriteString(" CONSTANT")
+	}
+	ctx.WriteByte(' ')
+	ctx.FormatTypeReference(s.Typ)
+	if s.Collate != "" {
+		ctx.WriteString(" COLLATE ")
+		ctx.FormatNameP(&s.Collate)
+	}
+	if s.NotNull {
+		ctx.WriteString(" NOT NULL")
+	}
+	if s.Expr != nil {
+		ctx.WriteString(" := ")
+		ctx.FormatNode(s.Expr)
+	}
+	ctx.WriteString("\n")
+}
+
+func (s *PLpgSQLDecl) PlpgSQLStatementTag() string {
+	return "decl_stmt"
+}
+
+func (s *PLpgSQLDecl) WalkStmt(visitor PLpgSQLStmtVisitor) {
+	visitor.Visit(s)
+}
+
+// decl_cursor
+type PLpgSQLCursorDecl struct {
+	PLpgSQLStatementImpl
+	Name          PLpgSQLVariable
+	CursorOptions uint32
+	Args          []*PLpgSQLDecl
+	// TODO(plpgsql-team): Query should be a PLpgSQLExpr.
+	Query string
+}
+
+func (s *PLpgSQLCursorDecl) Format(ctx *tree.FmtCtx) {
+	ctx.FormatNode(&s.Name)
+	for _, opt := range OptListFromBitField(s.CursorOptions) {
+		if opt.String() != "" {
+			ctx.Printf(" %s", opt.String())
+		}
+	}
+	ctx.WriteString(" CURSOR ")
+	if len(s.Args) > 0 {
+		ctx.WriteByte('(')
+		for i, arg := range s.Args {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatNode(&arg.Var)
+			ctx.WriteByte(' ')
+			ctx.FormatTypeReference(arg.Typ)
+		}
+		ctx.WriteString(") ")
+	}
+	ctx.WriteString("FOR ")
+	ctx.WriteString(s.Query)
+	ctx.WriteString("\n")
+}
+
+func (s *PLpgSQLCursorDecl) PlpgSQLStatementTag() string {
+	return "decl_cursor_stmt"
+}
+
+func (s *PLpgSQLCursorDecl) WalkStmt(visitor PLpgSQLStmtVisitor) {
+	visitor.Visit(s)
 }
 
 // stmt_assign
 type PLpgSQLStmtAssign struct {
-	PLpgSQLStatement
-	// TODO(jane): It should be PLpgSQLVariable.
-	Var string
+	PLpgSQLStatementImpl
+	Var PLpgSQLVariable
 	// TODO(jane): It should be PLpgSQLExpr.
 	Value string
 }
@@ -101,7 +205,9 @@ func (s *PLpgSQLStmtAssign) PlpgSQLStatementTag() string {
 }
 
 func (s *PLpgSQLStmtAssign) Format(ctx *tree.FmtCtx) {
-	ctx.WriteString(fmt.Sprintf("ASSIGN %s := %s\n", s.Var, s.Value))
+	ctx.WriteString("ASSIGN ")
+	ctx.FormatNode(&s.Var)
+	ctx.WriteString(fmt.Sprintf(" := %s\n", s.Value))
 }
 
 func (s *PLpgSQLStmtAssign) WalkStmt(visitor PLpgSQLStmtVisitor) {
@@ -205,7 +311,6 @@ func (s *PLpgSQLStmtCase) Format(ctx *tree.FmtCtx) {
 		ctx.WriteString(fmt.Sprintf(" %s", s.TestExpr))
 	}
 	ctx.WriteString("\n")
-	// TODO: Strip spaces before each comma of the when list
 	for _, when := range s.CaseWhenList {
 		when.Format(ctx)
 	}
@@ -281,6 +386,26 @@ func (s *PLpgSQLStmtSimpleLoop) PlpgSQLStatementTag() string {
 }
 
 func (s *PLpgSQLStmtSimpleLoop) Format(ctx *tree.FmtCtx) {
+	formatLoopLabel(ctx, s.Label)
+	ctx.WriteString("LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
+}
+
+func formatLoopLabel(ctx *tree.FmtCtx, label string) {
+	if label != "" {
+		ctx.Printf("<<%s>>\n", label)
+	}
+}
+
+func formatLoopBody(ctx *tree.FmtCtx, label string, body []PLpgSQLStatement) {
+	for _, stmt := range body {
+		stmt.Format(ctx)
+	}
+	ctx.WriteString("END LOOP")
+	if label != "" {
+		ctx.Printf(" %s", label)
+	}
+	ctx.WriteString("\n")
 }
 
 func (s *PLpgSQLStmtSimpleLoop) WalkStmt(visitor PLpgSQLStmtVisitor) {
@@ -299,6 +424,11 @@ type PLpgSQLStmtWhileLoop struct {
 }
 
 func (s *PLpgSQLStmtWhileLoop) Format(ctx *tree.FmtCtx) {
+	formatLoopLabel(ctx, s.Label)
+	ctx.WriteString("WHILE ")
+	ctx.FormatNode(s.Condition)
+	ctx.WriteString(" LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *PLpgSQLStmtWhileLoop) PlpgSQLStatementTag() string {
@@ -320,11 +450,27 @@ type PLpgSQLStmtForIntLoop struct {
 	Lower   PLpgSQLExpr
 	Upper   PLpgSQLExpr
 	Step    PLpgSQLExpr
-	Reverse int
+	Reverse bool
 	Body    []PLpgSQLStatement
 }
 
 func (s *PLpgSQLStmtForIntLoop) Format(ctx *tree.FmtCtx) {
+	formatLoopLabel(ctx, s.Label)
+	ctx.WriteString("FOR ")
+	ctx.FormatNode(&s.Var)
+	ctx.WriteString(" IN ")
+	if s.Reverse {
+		ctx.WriteString("REVERSE ")
+	}
+	ctx.FormatNode(s.Lower)
+	ctx.WriteString("..")
+	ctx.FormatNode(s.Upper)
+	if s.Step != nil {
+		ctx.WriteString(" BY ")
+		ctx.FormatNode(s.Step)
+	}
+	ctx.WriteString(" LOOP\n")
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *PLpgSQLStmtForIntLoop) PlpgSQLStatementTag() string {
@@ -341,8 +487,10 @@ func (s *PLpgSQLStmtForIntLoop) WalkStmt(visitor PLpgSQLStmtVisitor) {
 type PLpgSQLStmtForQueryLoop struct {
 	PLpgSQLStatementImpl
 	Label string
-	Var   PLpgSQLVariable
-	Body  []PLpgSQLStatement
+	// Target is the list of variables that are assigned the columns of each
+	// row of the query.
+	Target []PLpgSQLVariable
+	Body   []PLpgSQLStatement
 }
 
 func (s *PLpgSQLStmtForQueryLoop) Format(ctx *tree.FmtCtx) {
@@ -361,10 +509,21 @@ func (s *PLpgSQLStmtForQueryLoop) WalkStmt(visitor PLpgSQLStmtVisitor) {
 
 type PLpgSQLStmtForQuerySelectLoop struct {
 	PLpgSQLStmtForQueryLoop
-	Query PLpgSQLExpr
+	// TODO(plpgsql-team): Query should be a PLpgSQLExpr.
+	Query string
 }
 
 func (s *PLpgSQLStmtForQuerySelectLoop) Format(ctx *tree.FmtCtx) {
+	formatLoopLabel(ctx, s.Label)
+	ctx.WriteString("FOR ")
+	for i := range s.Target {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&s.Target[i])
+	}
+	ctx.Printf(" IN %s LOOP\n", s.Query)
+	formatLoopBody(ctx, s.Label, s.Body)
 }
 
 func (s *PLpgSQLStmtForQuerySelectLoop) PlpgSQLStatementTag() string {
@@ -446,10 +605,19 @@ type PLpgSQLStmtExit struct {
 }
 
 func (s *PLpgSQLStmtExit) Format(ctx *tree.FmtCtx) {
-	// TODO: Pretty print the exit label
-	ctx.WriteString("EXIT\n")
-	ctx.WriteString("<NOT DONE YET>")
-
+	if s.IsExit {
+		ctx.WriteString("EXIT")
+	} else {
+		ctx.WriteString("CONTINUE")
+	}
+	if s.Label != "" {
+		ctx.Printf(" %s", s.Label)
+	}
+	if s.Condition != nil {
+		ctx.WriteString(" WHEN ")
+		ctx.FormatNode(s.Condition)
+	}
+	ctx.WriteString("\n")
 }
 
 func (s *PLpgSQLStmtExit) PlpgSQLStatementTag() string {
@@ -468,6 +636,12 @@ type PLpgSQLStmtReturn struct {
 }
 
 func (s *PLpgSQLStmtReturn) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RETURN")
+	if s.Expr != nil {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(s.Expr)
+	}
+	ctx.WriteString("\n")
 }
 
 func (s *PLpgSQLStmtReturn) PlpgSQLStatementTag() string {
@@ -516,14 +690,45 @@ func (s *PLpgSQLStmtReturnQuery) WalkStmt(visitor PLpgSQLStmtVisitor) {
 // stmt_raise
 type PLpgSQLStmtRaise struct {
 	PLpgSQLStatementImpl
-	LogLevel int
+	// LogLevel is the severity of the raised message, e.g. "NOTICE". It is
+	// empty if no level was specified, in which case EXCEPTION is implied.
+	LogLevel string
+	// CodeName is the name of the condition to raise, e.g. "division_by_zero".
 	CodeName string
-	Message  string
-	Params   []PLpgSQLExpr
-	Options  []PLpgSQLStmtRaiseOption
+	// Code is the SQLSTATE code of the condition to raise.
+	Code string
+	// Message is the format string for the error message, in which each "%"
+	// is replaced by the next parameter.
+	Message string
+	Params  []PLpgSQLExpr
+	Options []PLpgSQLStmtRaiseOption
 }
 
 func (s *PLpgSQLStmtRaise) Format(ctx *tree.FmtCtx) {
+	ctx.WriteString("RAISE")
+	if s.LogLevel != "" {
+		ctx.Printf(" %s", s.LogLevel)
+	}
+	if s.Code != "" {
+		ctx.Printf(" SQLSTATE %s", lexbase.EscapeSQLString(s.Code))
+	} else if s.CodeName != "" {
+		ctx.Printf(" %s", s.CodeName)
+	} else if s.Message != "" {
+		ctx.Printf(" %s", lexbase.EscapeSQLString(s.Message))
+		for _, param := range s.Params {
+			ctx.WriteString(", ")
+			ctx.FormatNode(param)
+		}
+	}
+	for i := range s.Options {
+		if i == 0 {
+			ctx.WriteString("\nUSING ")
+		} else {
+			ctx.WriteString(",\n")
+		}
+		s.Options[i].Format(ctx)
+	}
+	ctx.WriteString("\n")
 }
 
 type PLpgSQLStmtRaiseOption struct {
@@ -532,6 +737,8 @@ type PLpgSQLStmtRaiseOption struct {
 }
 
 func (s *PLpgSQLStmtRaiseOption) Format(ctx *tree.FmtCtx) {
+	ctx.Printf("%s = ", s.OptType)
+	ctx.FormatNode(s.Expr)
 }
 
 func (s *PLpgSQLStmtRaise) PlpgSQLStatementTag() string {
@@ -566,9 +773,13 @@ func (s *PLpgSQLStmtAssert) WalkStmt(visitor PLpgSQLStmtVisitor) {
 // stmt_execsql
 type PLpgSQLStmtExecSql struct {
 	PLpgSQLStatementImpl
+	// SqlStmt is the SQL statement, with the INTO clause (if any) removed.
 	SqlStmt string
 	Into    bool // INTO provided?
 	Strict  bool // INTO STRICT flag
+	// Target is the list of variables that are assigned the columns of the
+	// first row returned by the statement if INTO was provided.
+	Target []PLpgSQLVariable
 }
 
 func (s *PLpgSQLStmtExecSql) Format(ctx *tree.FmtCtx) {
@@ -630,10 +841,14 @@ func (s *PLpgSQLStmtDynamicExecute) WalkStmt(visitor PLpgSQLStmtVisitor) {
 // stmt_perform
 type PLpgSQLStmtPerform struct {
 	PLpgSQLStatementImpl
-	Expr PLpgSQLExpr
+	// Query is the text of the query that follows the PERFORM keyword. It is
+	// executed as if it was preceded by SELECT, and the result is discarded.
+	// TODO(plpgsql-team): Query should be a PLpgSQLExpr.
+	Query string
 }
 
 func (s *PLpgSQLStmtPerform) Format(ctx *tree.FmtCtx) {
+	ctx.Printf("PERFORM %s\n", s.Query)
 }
 
 func (s *PLpgSQLStmtPerform) PlpgSQLStatementTag() string {
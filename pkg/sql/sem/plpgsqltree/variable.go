@@ -10,14 +10,15 @@
 
 package plpgsqltree
 
+import "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+
 type PLpgSQLDatum interface {
 	plpgsqldatum()
 }
 
-type PLpgSQLVariable interface {
-	PLpgSQLDatum
-	pgpgsqlvariable()
-}
+// PLpgSQLVariable is the name of a variable declared in a PL/pgSQL block, or
+// of a parameter of the function.
+type PLpgSQLVariable = tree.Name
 
 // Scope contains all the variables defined in the DECLARE section of current statement block.
 type VariableScope struct {
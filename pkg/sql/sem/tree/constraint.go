@@ -14,6 +14,7 @@ import (
 	"strconv"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/semenumpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree/treecmp"
 )
 
 // ReferenceAction is the method used to maintain referential integrity through
@@ -127,3 +128,52 @@ func (x CompositeKeyMatchMethod) String() string {
 		return strconv.Itoa(int(x))
 	}
 }
+
+// ConstraintDeferrability describes whether the checking of a constraint can
+// be deferred until the end of the transaction.
+type ConstraintDeferrability semenumpb.Deferrability
+
+// The values for ConstraintDeferrability. It has a one-to-one mapping to
+// semenumpb.Deferrability.
+const (
+	ConstraintNotDeferrable ConstraintDeferrability = iota
+	ConstraintInitiallyImmediate
+	ConstraintInitiallyDeferred
+)
+
+// Format implements the NodeFormatter interface.
+func (node *ConstraintDeferrability) Format(ctx *FmtCtx) {
+	switch *node {
+	case ConstraintInitiallyImmediate:
+		ctx.WriteString(" DEFERRABLE")
+	case ConstraintInitiallyDeferred:
+		ctx.WriteString(" DEFERRABLE INITIALLY DEFERRED")
+	}
+}
+
+// ExclusionOperator is the operator used by an exclusion constraint to compare
+// the values of one of its columns in two different rows.
+type ExclusionOperator semenumpb.ExclusionOperator
+
+// The values for ExclusionOperator. It has a one-to-one mapping to
+// semenumpb.ExclusionOperator.
+const (
+	ExcludeEquals ExclusionOperator = iota
+	ExcludeOverlaps
+)
+
+// ComparisonOperator returns the comparison operator that is used to compare
+// two values of a column in an exclusion constraint.
+func (x ExclusionOperator) ComparisonOperator() treecmp.ComparisonOperator {
+	switch x {
+	case ExcludeOverlaps:
+		return treecmp.MakeComparisonOperator(treecmp.Overlaps)
+	default:
+		return treecmp.MakeComparisonOperator(treecmp.EQ)
+	}
+}
+
+// String implements the fmt.Stringer interface.
+func (x ExclusionOperator) String() string {
+	return x.ComparisonOperator().String()
+}
@@ -370,14 +370,82 @@ type CreateType struct {
 	// CompositeTypeList is set when this repesnets a CREATE TYPE ... AS ( )
 	// statement.
 	CompositeTypeList []CompositeTypeElem
+	// DomainType, DomainDefault, DomainNotNull and DomainChecks are set when
+	// this represents a CREATE DOMAIN statement.
+	DomainType    ResolvableTypeReference
+	DomainDefault Expr
+	DomainNotNull bool
+	DomainChecks  []DomainCheck
 	// IfNotExists is true if IF NOT EXISTS was requested.
 	IfNotExists bool
 }
 
+// DomainCheck is a CHECK constraint in a CREATE DOMAIN statement. The VALUE
+// keyword in Expr refers to the value being checked.
+type DomainCheck struct {
+	Name Name
+	Expr Expr
+}
+
 var _ Statement = &CreateType{}
 
+// NewCreateDomain constructs a CREATE DOMAIN statement for a domain over the
+// given base type. Postgres parses the domain constraints with the same
+// grammar as column qualifications, so an error is returned for any
+// qualification that does not make sense for a domain.
+func NewCreateDomain(
+	name *UnresolvedObjectName,
+	typRef ResolvableTypeReference,
+	qualifications []NamedColumnQualification,
+) (*CreateType, error) {
+	n := &CreateType{
+		TypeName:   name,
+		Variety:    Domain,
+		DomainType: typRef,
+	}
+	var explicitNull bool
+	for _, c := range qualifications {
+		switch t := c.Qualification.(type) {
+		case *ColumnDefault:
+			if n.DomainDefault != nil {
+				return nil, pgerror.New(pgcode.Syntax, "multiple default expressions")
+			}
+			n.DomainDefault = t.Expr
+		case NotNullConstraint:
+			if explicitNull {
+				return nil, pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+			}
+			n.DomainNotNull = true
+		case NullConstraint:
+			if n.DomainNotNull {
+				return nil, pgerror.New(pgcode.Syntax, "conflicting NULL/NOT NULL constraints")
+			}
+			explicitNull = true
+		case *ColumnCheckConstraint:
+			n.DomainChecks = append(n.DomainChecks, DomainCheck{Name: c.Name, Expr: t.Expr})
+		case PrimaryKeyConstraint, ShardedPrimaryKeyConstraint:
+			return nil, pgerror.New(pgcode.Syntax, "primary key constraints not possible for domains")
+		case UniqueConstraint:
+			return nil, pgerror.New(pgcode.Syntax, "unique constraints not possible for domains")
+		case *ColumnFKConstraint:
+			return nil, pgerror.New(pgcode.Syntax, "foreign key constraints not possible for domains")
+		case *ColumnComputedDef, *GeneratedAlwaysAsIdentity, *GeneratedByDefAsIdentity:
+			return nil, pgerror.New(pgcode.Syntax, "generated columns not possible for domains")
+		case ColumnCollation:
+			return nil, pgerror.New(pgcode.FeatureNotSupported, "collations are not supported for domains")
+		default:
+			return nil, pgerror.Newf(pgcode.Syntax, "unexpected domain constraint: %T", t)
+		}
+	}
+	return n, nil
+}
+
 // Format implements the NodeFormatter interface.
 func (node *CreateType) Format(ctx *FmtCtx) {
+	if node.Variety == Domain {
+		node.formatDomain(ctx)
+		return
+	}
 	ctx.WriteString("CREATE TYPE ")
 	if node.IfNotExists {
 		ctx.WriteString("IF NOT EXISTS ")
@@ -404,6 +472,30 @@ func (node *CreateType) Format(ctx *FmtCtx) {
 	}
 }
 
+func (node *CreateType) formatDomain(ctx *FmtCtx) {
+	ctx.WriteString("CREATE DOMAIN ")
+	ctx.FormatNode(node.TypeName)
+	ctx.WriteString(" AS ")
+	ctx.FormatTypeReference(node.DomainType)
+	if node.DomainDefault != nil {
+		ctx.WriteString(" DEFAULT ")
+		ctx.FormatNode(node.DomainDefault)
+	}
+	if node.DomainNotNull {
+		ctx.WriteString(" NOT NULL")
+	}
+	for i := range node.DomainChecks {
+		check := &node.DomainChecks[i]
+		if check.Name != "" {
+			ctx.WriteString(" CONSTRAINT ")
+			ctx.FormatNode(&check.Name)
+		}
+		ctx.WriteString(" CHECK (")
+		ctx.FormatNode(check.Expr)
+		ctx.WriteByte(')')
+	}
+}
+
 func (node *CreateType) String() string {
 	return AsString(node)
 }
@@ -422,6 +514,7 @@ func (*IndexTableDef) tableDef()                {}
 func (*FamilyTableDef) tableDef()               {}
 func (*ForeignKeyConstraintTableDef) tableDef() {}
 func (*CheckConstraintTableDef) tableDef()      {}
+func (*ExclusionConstraintTableDef) tableDef()  {}
 func (*LikeTableDef) tableDef()                 {}
 
 // TableDefs represents a list of table definitions.
@@ -511,6 +604,7 @@ type ColumnTableDef struct {
 		ConstraintName Name
 		Actions        ReferenceActions
 		Match          CompositeKeyMatchMethod
+		Deferrability  ConstraintDeferrability
 	}
 	Computed struct {
 		Computed bool
@@ -694,6 +788,7 @@ func NewColumnTableDef(
 			d.References.ConstraintName = c.Name
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
+			d.References.Deferrability = t.Deferrability
 		case *ColumnComputedDef:
 			if d.GeneratedIdentity.IsGeneratedAsIdentity {
 				return nil, pgerror.Newf(pgcode.Syntax,
@@ -864,6 +959,7 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.WriteString(node.References.Match.String())
 		}
 		ctx.FormatNode(&node.References.Actions)
+		ctx.FormatNode(&node.References.Deferrability)
 	}
 	if node.IsComputed() {
 		ctx.WriteString(" AS (")
@@ -1002,10 +1098,11 @@ type ColumnCheckConstraint struct {
 
 // ColumnFKConstraint represents a FK-constaint on a column.
 type ColumnFKConstraint struct {
-	Table   TableName
-	Col     Name // empty-string means use PK
-	Actions ReferenceActions
-	Match   CompositeKeyMatchMethod
+	Table         TableName
+	Col           Name // empty-string means use PK
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	Deferrability ConstraintDeferrability
 }
 
 // ColumnComputedDef represents the description of a computed column.
@@ -1097,14 +1194,16 @@ type ConstraintTableDef interface {
 func (*UniqueConstraintTableDef) constraintTableDef()     {}
 func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
 func (*CheckConstraintTableDef) constraintTableDef()      {}
+func (*ExclusionConstraintTableDef) constraintTableDef()  {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
 type UniqueConstraintTableDef struct {
 	IndexTableDef
-	PrimaryKey   bool
-	WithoutIndex bool
-	IfNotExists  bool
+	PrimaryKey    bool
+	WithoutIndex  bool
+	IfNotExists   bool
+	Deferrability ConstraintDeferrability
 }
 
 // SetName implements the TableDef interface.
@@ -1149,6 +1248,7 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 	if node.PartitionByIndex != nil {
 		ctx.FormatNode(node.PartitionByIndex)
 	}
+	ctx.FormatNode(&node.Deferrability)
 	if node.Predicate != nil {
 		ctx.WriteString(" WHERE ")
 		ctx.FormatNode(node.Predicate)
@@ -1165,13 +1265,14 @@ func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
 
 // ForeignKeyConstraintTableDef represents a FOREIGN KEY constraint in the AST.
 type ForeignKeyConstraintTableDef struct {
-	Name        Name
-	Table       TableName
-	FromCols    NameList
-	ToCols      NameList
-	Actions     ReferenceActions
-	Match       CompositeKeyMatchMethod
-	IfNotExists bool
+	Name          Name
+	Table         TableName
+	FromCols      NameList
+	ToCols        NameList
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	Deferrability ConstraintDeferrability
+	IfNotExists   bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -1202,6 +1303,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(&node.Deferrability)
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1214,6 +1316,81 @@ func (node *ForeignKeyConstraintTableDef) SetIfNotExists() {
 	node.IfNotExists = true
 }
 
+// ExcludeElem represents a single column of an EXCLUDE constraint and the
+// operator used to compare its values.
+type ExcludeElem struct {
+	Column   Name
+	Operator ExclusionOperator
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExcludeElem) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.Column)
+	ctx.WriteString(" WITH ")
+	ctx.WriteString(node.Operator.String())
+}
+
+// ExcludeElemList is a list of ExcludeElem.
+type ExcludeElemList []ExcludeElem
+
+// Format implements the NodeFormatter interface.
+func (l *ExcludeElemList) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*l)[i])
+	}
+}
+
+// IndexElems returns the columns of the list as index elements.
+func (l ExcludeElemList) IndexElems() IndexElemList {
+	res := make(IndexElemList, len(l))
+	for i := range l {
+		res[i].Column = l[i].Column
+	}
+	return res
+}
+
+// ExclusionConstraintTableDef represents an EXCLUDE constraint within a
+// CREATE TABLE statement. The constraint is backed by an index, which is
+// inverted if Inverted is true.
+type ExclusionConstraintTableDef struct {
+	Name        Name
+	Inverted    bool
+	Elems       ExcludeElemList
+	IfNotExists bool
+}
+
+// SetName implements the ConstraintTableDef interface.
+func (node *ExclusionConstraintTableDef) SetName(name Name) {
+	node.Name = name
+}
+
+// SetIfNotExists implements the ConstraintTableDef interface.
+func (node *ExclusionConstraintTableDef) SetIfNotExists() {
+	node.IfNotExists = true
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExclusionConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		if node.IfNotExists {
+			ctx.WriteString("IF NOT EXISTS ")
+		}
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("EXCLUDE ")
+	if node.Inverted {
+		ctx.WriteString("USING gist ")
+	}
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Elems)
+	ctx.WriteByte(')')
+}
+
 // CheckConstraintTableDef represents a check constraint within a CREATE
 // TABLE statement.
 type CheckConstraintTableDef struct {
@@ -1494,6 +1671,15 @@ type CreateTable struct {
 	Defs     TableDefs
 	AsSource *Select
 	Locality *Locality
+	// ForeignOptions is non-nil for a CREATE FOREIGN TABLE statement, and
+	// describes the file in external storage backing the table.
+	ForeignOptions KVOptions
+}
+
+// IsForeign returns true if this table represents a CREATE FOREIGN TABLE
+// statement, false otherwise.
+func (node *CreateTable) IsForeign() bool {
+	return node.ForeignOptions != nil
 }
 
 // As returns true if this table represents a CREATE TABLE ... AS statement,
@@ -1526,6 +1712,9 @@ func (node *CreateTable) Format(ctx *FmtCtx) {
 	case PersistenceUnlogged:
 		ctx.WriteString("UNLOGGED ")
 	}
+	if node.IsForeign() {
+		ctx.WriteString("FOREIGN ")
+	}
 	ctx.WriteString("TABLE ")
 	if node.IfNotExists {
 		ctx.WriteString("IF NOT EXISTS ")
@@ -1566,6 +1755,11 @@ func (node *CreateTable) FormatBody(ctx *FmtCtx) {
 			ctx.WriteString(" ")
 			ctx.FormatNode(node.Locality)
 		}
+		if node.IsForeign() {
+			ctx.WriteString(" OPTIONS (")
+			ctx.FormatNode(&node.ForeignOptions)
+			ctx.WriteByte(')')
+		}
 	}
 }
 
@@ -1614,12 +1808,13 @@ func (node *CreateTable) HoistConstraints() {
 					targetCol = append(targetCol, col.References.Col)
 				}
 				node.Defs = append(node.Defs, &ForeignKeyConstraintTableDef{
-					Table:    *col.References.Table,
-					FromCols: NameList{col.Name},
-					ToCols:   targetCol,
-					Name:     col.References.ConstraintName,
-					Actions:  col.References.Actions,
-					Match:    col.References.Match,
+					Table:         *col.References.Table,
+					FromCols:      NameList{col.Name},
+					ToCols:        targetCol,
+					Name:          col.References.ConstraintName,
+					Actions:       col.References.Actions,
+					Match:         col.References.Match,
+					Deferrability: col.References.Deferrability,
 				})
 				col.References.Table = nil
 			}
@@ -2005,6 +2200,9 @@ const (
 	// RefreshDataClear refers to the WITH NO DATA option provided to the REFRESH
 	// MATERIALIZED VIEW statement.
 	RefreshDataClear
+	// RefreshDataIncremental refers to the WITH INCREMENTAL DATA option provided
+	// to the REFRESH MATERIALIZED VIEW statement.
+	RefreshDataIncremental
 )
 
 // Format implements the NodeFormatter interface.
@@ -2019,6 +2217,8 @@ func (node *RefreshMaterializedView) Format(ctx *FmtCtx) {
 		ctx.WriteString(" WITH DATA")
 	case RefreshDataClear:
 		ctx.WriteString(" WITH NO DATA")
+	case RefreshDataIncremental:
+		ctx.WriteString(" WITH INCREMENTAL DATA")
 	}
 }
 
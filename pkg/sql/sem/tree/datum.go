@@ -3835,7 +3835,7 @@ func AsJSON(
 		// This is RFC3339Nano, but without the TZ fields.
 		return json.FromString(formatTime(t.UTC(), "2006-01-02T15:04:05.999999999")), nil
 	case *DDate, *DUuid, *DOid, *DInterval, *DBytes, *DIPAddr, *DTime, *DTimeTZ, *DBitArray, *DBox2D,
-		*DTSVector, *DTSQuery:
+		*DTSVector, *DTSQuery, *DRange, *DMultirange:
 		return json.FromString(
 			AsStringWithFlags(t, FmtBareStrings, FmtDataConversionConfig(dcc), FmtLocation(loc)),
 		), nil
@@ -4210,6 +4210,420 @@ func ParseDTSVector(s string) (Datum, error) {
 	return NewDTSVector(v), nil
 }
 
+// RangeFlags describe the bounds of a DRange. The values of the flags match
+// the flags byte of the Postgres binary encoding of ranges.
+type RangeFlags uint8
+
+const (
+	// RangeEmpty is set if the range contains no values.
+	RangeEmpty RangeFlags = 1 << iota
+	// RangeLowerInc is set if the lower bound is included in the range.
+	RangeLowerInc
+	// RangeUpperInc is set if the upper bound is included in the range.
+	RangeUpperInc
+	// RangeLowerInf is set if the range has no lower bound.
+	RangeLowerInf
+	// RangeUpperInf is set if the range has no upper bound.
+	RangeUpperInf
+)
+
+// DRange is the range Datum. Ranges are always kept in canonical form: empty
+// ranges have no bounds, infinite bounds are exclusive, and ranges of discrete
+// values (integers and dates) have an inclusive lower bound and an exclusive
+// upper bound.
+type DRange struct {
+	typ *types.T
+	// Lower and Upper are the bounds of the range. They are DNull if the range
+	// is unbounded on that side, or if the range is empty.
+	Lower, Upper Datum
+	// LowerInc and UpperInc are true if the corresponding bound is included in
+	// the range.
+	LowerInc, UpperInc bool
+	// Empty is true if the range contains no values.
+	Empty bool
+}
+
+// NewDEmptyRange returns an empty range of the given range type.
+func NewDEmptyRange(typ *types.T) *DRange {
+	return &DRange{typ: typ, Lower: DNull, Upper: DNull, Empty: true}
+}
+
+// MakeDRange returns a range of the given range type with the given bounds,
+// converted to its canonical form. A DNull bound means that the range is
+// unbounded on that side. An error is returned if the lower bound is greater
+// than the upper bound.
+func MakeDRange(typ *types.T, lower, upper Datum, lowerInc, upperInc bool) (*DRange, error) {
+	lowerInf, upperInf := lower == DNull, upper == DNull
+	if !lowerInf && !upperInf && compareRangeValues(lower, upper) > 0 {
+		return nil, pgerror.New(pgcode.DataException,
+			"range lower bound must be less than or equal to range upper bound")
+	}
+	if lowerInf {
+		lowerInc = false
+	}
+	if upperInf {
+		upperInc = false
+	}
+	subtype := typ.RangeContents()
+	if !lowerInf && !lowerInc {
+		next, ok, err := nextDiscreteRangeValue(subtype, lower)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			lower, lowerInc = next, true
+		}
+	}
+	if !upperInf && upperInc {
+		next, ok, err := nextDiscreteRangeValue(subtype, upper)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			upper, upperInc = next, false
+		}
+	}
+	if !lowerInf && !upperInf {
+		if cmp := compareRangeValues(lower, upper); cmp > 0 || (cmp == 0 && !(lowerInc && upperInc)) {
+			return NewDEmptyRange(typ), nil
+		}
+	}
+	return &DRange{typ: typ, Lower: lower, Upper: upper, LowerInc: lowerInc, UpperInc: upperInc}, nil
+}
+
+// NewDRangeFromFlags returns a range of the given type with the given bounds
+// and flags. The bounds must already be in canonical form, as they are when
+// decoding a range that was previously encoded. Bounds that the flags mark as
+// infinite are ignored.
+func NewDRangeFromFlags(typ *types.T, lower, upper Datum, flags RangeFlags) *DRange {
+	if flags&RangeEmpty != 0 {
+		return NewDEmptyRange(typ)
+	}
+	d := &DRange{
+		typ:      typ,
+		Lower:    lower,
+		Upper:    upper,
+		LowerInc: flags&RangeLowerInc != 0,
+		UpperInc: flags&RangeUpperInc != 0,
+	}
+	if flags&RangeLowerInf != 0 {
+		d.Lower, d.LowerInc = DNull, false
+	}
+	if flags&RangeUpperInf != 0 {
+		d.Upper, d.UpperInc = DNull, false
+	}
+	return d
+}
+
+// Flags returns the flags that describe the bounds of the range.
+func (d *DRange) Flags() RangeFlags {
+	if d.Empty {
+		return RangeEmpty
+	}
+	var flags RangeFlags
+	if d.LowerInc {
+		flags |= RangeLowerInc
+	}
+	if d.UpperInc {
+		flags |= RangeUpperInc
+	}
+	if d.Lower == DNull {
+		flags |= RangeLowerInf
+	}
+	if d.Upper == DNull {
+		flags |= RangeUpperInf
+	}
+	return flags
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DRange) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	d.formatBody(ctx, !bareStrings /* escapeQuotes */)
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// formatBody writes the Postgres text representation of the range, such as
+// [1,5), to ctx.
+func (d *DRange) formatBody(ctx *FmtCtx, escapeQuotes bool) {
+	if d.Empty {
+		ctx.WriteString("empty")
+		return
+	}
+	if d.LowerInc {
+		ctx.WriteByte('[')
+	} else {
+		ctx.WriteByte('(')
+	}
+	formatRangeBound(ctx, d.Lower, escapeQuotes)
+	ctx.WriteByte(',')
+	formatRangeBound(ctx, d.Upper, escapeQuotes)
+	if d.UpperInc {
+		ctx.WriteByte(']')
+	} else {
+		ctx.WriteByte(')')
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DRange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DRange) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DRange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DRange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DRange)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	return compareRanges(d, v), nil
+}
+
+// Prev implements the Datum interface.
+func (d *DRange) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DRange) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DRange) IsMin(_ CompareContext) bool {
+	return d.Empty
+}
+
+// IsMax implements the Datum interface.
+func (d *DRange) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DRange) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DRange) Min(_ CompareContext) (Datum, bool) {
+	return NewDEmptyRange(d.typ), true
+}
+
+// Size implements the Datum interface.
+func (d *DRange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	if d.Lower != DNull {
+		sz += d.Lower.Size()
+	}
+	if d.Upper != DNull {
+		sz += d.Upper.Size()
+	}
+	return sz
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DRange) IsComposite() bool {
+	for _, bound := range [2]Datum{d.Lower, d.Upper} {
+		if cdatum, ok := bound.(CompositeDatum); ok && cdatum.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// AsDRange attempts to retrieve a DRange from an Expr, returning a DRange and
+// a flag signifying whether the assertion was successful. The function should
+// be used instead of direct type assertions wherever a *DRange wrapped by a
+// *DOidWrapper is possible.
+func AsDRange(e Expr) (*DRange, bool) {
+	switch t := e.(type) {
+	case *DRange:
+		return t, true
+	case *DOidWrapper:
+		return AsDRange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDRange attempts to retrieve a DRange from an Expr, panicking if the
+// assertion fails.
+func MustBeDRange(e Expr) *DRange {
+	v, ok := AsDRange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DRange, found %T", e))
+	}
+	return v
+}
+
+// DMultirange is the multirange Datum. The ranges of a multirange are always
+// kept sorted, non-empty and non-overlapping, and adjacent ranges are merged.
+type DMultirange struct {
+	typ    *types.T
+	Ranges []*DRange
+}
+
+// NewDMultirange returns a multirange of the given multirange type that
+// contains the union of the given ranges.
+func NewDMultirange(typ *types.T, ranges []*DRange) *DMultirange {
+	return &DMultirange{typ: typ, Ranges: normalizeRanges(ranges)}
+}
+
+// Format implements the NodeFormatter interface.
+func (d *DMultirange) Format(ctx *FmtCtx) {
+	bareStrings := ctx.HasFlags(FmtFlags(lexbase.EncBareStrings))
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+	ctx.WriteByte('{')
+	for i, r := range d.Ranges {
+		if i > 0 {
+			ctx.WriteByte(',')
+		}
+		r.formatBody(ctx, !bareStrings /* escapeQuotes */)
+	}
+	ctx.WriteByte('}')
+	if !bareStrings {
+		ctx.WriteByte('\'')
+	}
+}
+
+// ResolvedType implements the TypedExpr interface.
+func (d *DMultirange) ResolvedType() *types.T {
+	return d.typ
+}
+
+// AmbiguousFormat implements the Datum interface.
+func (*DMultirange) AmbiguousFormat() bool { return true }
+
+// Compare implements the Datum interface.
+func (d *DMultirange) Compare(ctx CompareContext, other Datum) int {
+	res, err := d.CompareError(ctx, other)
+	if err != nil {
+		panic(err)
+	}
+	return res
+}
+
+// CompareError implements the Datum interface.
+func (d *DMultirange) CompareError(ctx CompareContext, other Datum) (int, error) {
+	if other == DNull {
+		// NULL is less than any non-NULL value.
+		return 1, nil
+	}
+	v, ok := ctx.UnwrapDatum(other).(*DMultirange)
+	if !ok {
+		return 0, makeUnsupportedComparisonMessage(d, other)
+	}
+	// Multiranges are compared range by range. If all the ranges of the
+	// shorter multirange are equal to those of the longer one, the shorter
+	// multirange sorts first.
+	for i := 0; i < len(d.Ranges) && i < len(v.Ranges); i++ {
+		if cmp := compareRanges(d.Ranges[i], v.Ranges[i]); cmp != 0 {
+			return cmp, nil
+		}
+	}
+	if len(d.Ranges) < len(v.Ranges) {
+		return -1, nil
+	} else if len(d.Ranges) > len(v.Ranges) {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Prev implements the Datum interface.
+func (d *DMultirange) Prev(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Next implements the Datum interface.
+func (d *DMultirange) Next(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// IsMin implements the Datum interface.
+func (d *DMultirange) IsMin(_ CompareContext) bool {
+	return len(d.Ranges) == 0
+}
+
+// IsMax implements the Datum interface.
+func (d *DMultirange) IsMax(_ CompareContext) bool {
+	return false
+}
+
+// Max implements the Datum interface.
+func (d *DMultirange) Max(_ CompareContext) (Datum, bool) {
+	return nil, false
+}
+
+// Min implements the Datum interface.
+func (d *DMultirange) Min(_ CompareContext) (Datum, bool) {
+	return &DMultirange{typ: d.typ}, true
+}
+
+// Size implements the Datum interface.
+func (d *DMultirange) Size() uintptr {
+	sz := unsafe.Sizeof(*d)
+	for _, r := range d.Ranges {
+		sz += r.Size()
+	}
+	return sz
+}
+
+// IsComposite implements the CompositeDatum interface.
+func (d *DMultirange) IsComposite() bool {
+	for _, r := range d.Ranges {
+		if r.IsComposite() {
+			return true
+		}
+	}
+	return false
+}
+
+// AsDMultirange attempts to retrieve a DMultirange from an Expr, returning a
+// DMultirange and a flag signifying whether the assertion was successful. The
+// function should be used instead of direct type assertions wherever a
+// *DMultirange wrapped by a *DOidWrapper is possible.
+func AsDMultirange(e Expr) (*DMultirange, bool) {
+	switch t := e.(type) {
+	case *DMultirange:
+		return t, true
+	case *DOidWrapper:
+		return AsDMultirange(t.Wrapped)
+	}
+	return nil, false
+}
+
+// MustBeDMultirange attempts to retrieve a DMultirange from an Expr, panicking
+// if the assertion fails.
+func MustBeDMultirange(e Expr) *DMultirange {
+	v, ok := AsDMultirange(e)
+	if !ok {
+		panic(errors.AssertionFailedf("expected *DMultirange, found %T", e))
+	}
+	return v
+}
+
 // DTuple is the tuple Datum.
 type DTuple struct {
 	D Datums
@@ -5919,6 +6333,8 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.TimestampTZFamily:    {unsafe.Sizeof(DTimestampTZ{}), fixedSize},
 	types.TSQueryFamily:        {unsafe.Sizeof(DTSQuery{}), variableSize},
 	types.TSVectorFamily:       {unsafe.Sizeof(DTSVector{}), variableSize},
+	types.RangeFamily:          {unsafe.Sizeof(DRange{}), variableSize},
+	types.MultirangeFamily:     {unsafe.Sizeof(DMultirange{}), variableSize},
 	types.IntervalFamily:       {unsafe.Sizeof(DInterval{}), fixedSize},
 	types.JsonFamily:           {unsafe.Sizeof(DJSON{}), variableSize},
 	types.UuidFamily:           {unsafe.Sizeof(DUuid{}), fixedSize},
@@ -5927,6 +6343,8 @@ var baseDatumTypeSizes = map[types.Family]struct {
 	types.EnumFamily:           {unsafe.Sizeof(DEnum{}), variableSize},
 
 	types.VoidFamily: {sz: unsafe.Sizeof(DVoid{}), variable: fixedSize},
+	// There are no values of the trigger pseudo-type.
+	types.TriggerFamily: {sz: 0, variable: fixedSize},
 	// TODO(jordan,justin): This seems suspicious.
 	types.ArrayFamily: {unsafe.Sizeof(DString("")), variableSize},
 
@@ -6168,6 +6586,22 @@ func AdjustValueToType(typ *types.T, inVal Datum) (outVal Datum, err error) {
 			}
 			return &outDec, nil
 		}
+	case types.RangeFamily:
+		if in, ok := inVal.(*DRange); ok {
+			return adjustRangeToType(typ, in)
+		}
+	case types.MultirangeFamily:
+		if in, ok := inVal.(*DMultirange); ok && in.typ.Oid() != typ.Oid() {
+			out := &DMultirange{typ: typ, Ranges: make([]*DRange, len(in.Ranges))}
+			for i, r := range in.Ranges {
+				outRange, err := adjustRangeToType(typ.MultirangeContents(), r)
+				if err != nil {
+					return nil, err
+				}
+				out.Ranges[i] = outRange
+			}
+			return out, nil
+		}
 	case types.ArrayFamily:
 		if inArr, ok := inVal.(*DArray); ok {
 			var outArr *DArray
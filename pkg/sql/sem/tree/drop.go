@@ -88,11 +88,17 @@ type DropTable struct {
 	Names        TableNames
 	IfExists     bool
 	DropBehavior DropBehavior
+	// IsForeign is true for a DROP FOREIGN TABLE statement.
+	IsForeign bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *DropTable) Format(ctx *FmtCtx) {
-	ctx.WriteString("DROP TABLE ")
+	ctx.WriteString("DROP ")
+	if node.IsForeign {
+		ctx.WriteString("FOREIGN ")
+	}
+	ctx.WriteString("TABLE ")
 	if node.IfExists {
 		ctx.WriteString("IF EXISTS ")
 	}
@@ -196,6 +202,33 @@ func (node *DropType) Format(ctx *FmtCtx) {
 	}
 }
 
+// DropDomain represents a DROP DOMAIN command.
+type DropDomain struct {
+	Names        []*UnresolvedObjectName
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+var _ Statement = &DropDomain{}
+
+// Format implements the NodeFormatter interface.
+func (node *DropDomain) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP DOMAIN ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	for i := range node.Names {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(node.Names[i])
+	}
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
 // DropSchema represents a DROP SCHEMA command.
 type DropSchema struct {
 	Names        ObjectNamePrefixList
@@ -1385,16 +1385,17 @@ func cmpOpFixups(
 		panic(errors.AssertionFailedf("could not find cmp op %s(%s,%s)", op, t, t))
 	}
 
+	appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
+		s, ok := cmpOps[sym]
+		if !ok {
+			s = new(CmpOpOverloads)
+			cmpOps[sym] = s
+		}
+		s.overloads = append(s.overloads, cmpOp)
+	}
+
 	// Array equality comparisons.
 	for _, t := range append(types.Scalar, types.AnyEnum) {
-		appendCmpOp := func(sym treecmp.ComparisonOperatorSymbol, cmpOp *CmpOp) {
-			s, ok := cmpOps[sym]
-			if !ok {
-				s = new(CmpOpOverloads)
-				cmpOps[sym] = s
-			}
-			s.overloads = append(s.overloads, cmpOp)
-		}
 		appendCmpOp(treecmp.EQ, &CmpOp{
 			LeftType:   types.MakeArray(t),
 			RightType:  types.MakeArray(t),
@@ -1422,6 +1423,16 @@ func cmpOpFixups(
 		})
 	}
 
+	// Range and multirange comparisons.
+	for _, rng := range types.RangeTypes {
+		// INT4RANGE is equivalent to INT8RANGE for the purposes of overload
+		// resolution, so it shares the INT8RANGE overloads.
+		if rng == types.Int4Range {
+			continue
+		}
+		appendRangeCmpOps(appendCmpOp, rng)
+	}
+
 	for _, overloads := range cmpOps {
 		_ = overloads.ForEachCmpOp(func(op *CmpOp) error {
 			op.types = ParamTypes{{"left", op.LeftType}, {"right", op.RightType}}
@@ -1972,6 +1983,84 @@ func makeBox2DComparisonOperators(op func(lhs, rhs *geo.CartesianBoundingBox) bo
 	}
 }
 
+// appendRangeCmpOps calls appendCmpOp with the comparison operators of the
+// given range type, its multirange type, and its subtype.
+func appendRangeCmpOps(
+	appendCmpOp func(treecmp.ComparisonOperatorSymbol, *CmpOp), rng *types.T,
+) {
+	mr, elem := types.MakeMultirange(rng), rng.RangeContents()
+	makeOp := func(left, right *types.T, op func(left, right Datum) bool) *CmpOp {
+		return &CmpOp{
+			LeftType:   left,
+			RightType:  right,
+			EvalOp:     &CompareRangeOp{Op: op},
+			Volatility: volatility.Immutable,
+		}
+	}
+
+	for _, t := range []*types.T{rng, mr} {
+		appendCmpOp(treecmp.EQ, makeEqFn(t, t, volatility.Immutable))
+		appendCmpOp(treecmp.LT, makeLtFn(t, t, volatility.Immutable))
+		appendCmpOp(treecmp.LE, makeLeFn(t, t, volatility.Immutable))
+		appendCmpOp(treecmp.IsNotDistinctFrom, makeIsFn(t, t, volatility.Immutable))
+	}
+
+	// Overlaps (&&).
+	appendCmpOp(treecmp.Overlaps, makeOp(rng, rng, func(left, right Datum) bool {
+		return MustBeDRange(left).Overlaps(MustBeDRange(right))
+	}))
+	appendCmpOp(treecmp.Overlaps, makeOp(mr, mr, func(left, right Datum) bool {
+		return MustBeDMultirange(left).Overlaps(MustBeDMultirange(right))
+	}))
+	appendCmpOp(treecmp.Overlaps, makeOp(mr, rng, func(left, right Datum) bool {
+		return MustBeDMultirange(left).OverlapsRange(MustBeDRange(right))
+	}))
+	appendCmpOp(treecmp.Overlaps, makeOp(rng, mr, func(left, right Datum) bool {
+		return MustBeDMultirange(right).OverlapsRange(MustBeDRange(left))
+	}))
+
+	// Contains (@>) and ContainedBy (<@), which is Contains with the operands
+	// swapped.
+	appendContains := func(container, containee *types.T, contains func(container, containee Datum) bool) {
+		appendCmpOp(treecmp.Contains, makeOp(container, containee, contains))
+		appendCmpOp(treecmp.ContainedBy, makeOp(containee, container, func(left, right Datum) bool {
+			return contains(right, left)
+		}))
+	}
+	appendContains(rng, rng, func(container, containee Datum) bool {
+		return MustBeDRange(container).ContainsRange(MustBeDRange(containee))
+	})
+	appendContains(rng, elem, func(container, containee Datum) bool {
+		return MustBeDRange(container).ContainsValue(containee)
+	})
+	appendContains(rng, mr, func(container, containee Datum) bool {
+		return NewDMultirange(mr, []*DRange{MustBeDRange(container)}).Contains(MustBeDMultirange(containee))
+	})
+	appendContains(mr, mr, func(container, containee Datum) bool {
+		return MustBeDMultirange(container).Contains(MustBeDMultirange(containee))
+	})
+	appendContains(mr, rng, func(container, containee Datum) bool {
+		return MustBeDMultirange(container).ContainsRange(MustBeDRange(containee))
+	})
+	appendContains(mr, elem, func(container, containee Datum) bool {
+		return MustBeDMultirange(container).ContainsValue(containee)
+	})
+
+	// Adjacent (-|-).
+	appendCmpOp(treecmp.Adjacent, makeOp(rng, rng, func(left, right Datum) bool {
+		return MustBeDRange(left).Adjacent(MustBeDRange(right))
+	}))
+	appendCmpOp(treecmp.Adjacent, makeOp(mr, mr, func(left, right Datum) bool {
+		return MustBeDMultirange(left).Adjacent(MustBeDMultirange(right))
+	}))
+	appendCmpOp(treecmp.Adjacent, makeOp(mr, rng, func(left, right Datum) bool {
+		return MustBeDMultirange(left).AdjacentRange(MustBeDRange(right))
+	}))
+	appendCmpOp(treecmp.Adjacent, makeOp(rng, mr, func(left, right Datum) bool {
+		return MustBeDMultirange(right).AdjacentRange(MustBeDRange(left))
+	}))
+}
+
 // This map contains the inverses for operators in the CmpOps map that have
 // inverses.
 var cmpOpsInverse map[treecmp.ComparisonOperatorSymbol]treecmp.ComparisonOperatorSymbol
@@ -32,6 +32,11 @@ type CompareBox2DOp struct {
 	Op func(left, right Datum) bool
 }
 
+// CompareRangeOp is a BinaryEvalOp.
+type CompareRangeOp struct {
+	Op func(left, right Datum) bool
+}
+
 // InTupleOp is a BinaryEvalOp.
 type InTupleOp struct{}
 
@@ -37,6 +37,8 @@ type GrantTargetList struct {
 	Tables    TableAttrs
 	Types     []*UnresolvedObjectName
 	Functions FuncObjs
+	// Procedures is the list of procedures targeted, if any.
+	Procedures FuncObjs
 	// If the target is for all sequences in a set of schemas.
 	AllSequencesInSchema bool
 	// If the target is for all tables in a set of schemas.
@@ -86,6 +88,9 @@ func (tl *GrantTargetList) Format(ctx *FmtCtx) {
 	} else if tl.Functions != nil {
 		ctx.WriteString("FUNCTION ")
 		ctx.FormatNode(tl.Functions)
+	} else if tl.Procedures != nil {
+		ctx.WriteString("PROCEDURE ")
+		ctx.FormatNode(tl.Procedures)
 	} else {
 		if tl.Tables.SequenceOnly {
 			ctx.WriteString("SEQUENCE ")
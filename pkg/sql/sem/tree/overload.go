@@ -211,9 +211,15 @@ type Overload struct {
 	// IsUDF is set to true when this is a user-defined function overload built
 	// using CREATE FUNCTION. Note: Body can be empty even if IsUDF is true.
 	IsUDF bool
+	// IsProcedure is set to true when this is a stored procedure overload built
+	// using CREATE PROCEDURE. IsUDF is also set for procedures. Procedures can
+	// only be invoked with CALL.
+	IsProcedure bool
 	// Body is the SQL string body of a function. It can be set even if IsUDF is
 	// false if a builtin function is defined using a SQL string.
 	Body string
+	// Language is the language of the function body. It is only set for UDFs.
+	Language FunctionLanguage
 	// UDFContainsOnlySignature is only set to true for Overload signatures cached
 	// in a Schema descriptor, which means that the full UDF descriptor need to be
 	// fetched to get more info, e.g. function Body.
@@ -221,11 +227,56 @@ type Overload struct {
 	// ReturnSet is set to true when a user-defined function is defined to return
 	// a set of values.
 	ReturnSet bool
+	// OutParams are the OUT and INOUT parameters of a user-defined function, in
+	// the order they were declared. When set, they determine the return type of
+	// the function. Types only contains the input parameters of the function.
+	OutParams []OutParam
+	// UDFAggregate is set for user-defined aggregate functions built using
+	// CREATE AGGREGATE. It identifies the functions that implement the
+	// aggregate. IsUDF is also set for user-defined aggregates.
+	UDFAggregate *UDFAggregate
 	// Version is the descriptor version of the descriptor used to construct
 	// this version of the function overload. Only used for UDFs.
 	Version uint64
 }
 
+// OutParam is an OUT or INOUT parameter of a user-defined function.
+type OutParam struct {
+	Name string
+	Typ  *types.T
+	// InputOrdinal is the ordinal of the parameter among the input parameters
+	// of the function if it is an INOUT parameter, and -1 if it is an OUT
+	// parameter.
+	InputOrdinal int
+}
+
+// OutParamsReturnType returns the return type of a user-defined function with
+// the given OUT parameters. A function with a single OUT parameter returns the
+// type of that parameter, and a function with multiple OUT parameters returns
+// a record with a field for each parameter. Unnamed parameters are given the
+// names column1, column2, etc., like in Postgres.
+func OutParamsReturnType(outParams []OutParam) *types.T {
+	if len(outParams) == 1 {
+		return outParams[0].Typ
+	}
+	contents := make([]*types.T, len(outParams))
+	labels := make([]string, len(outParams))
+	for i := range outParams {
+		contents[i] = outParams[i].Typ
+		labels[i] = OutParamLabel(outParams, i)
+	}
+	return types.MakeLabeledTuple(contents, labels)
+}
+
+// OutParamLabel returns the name of the result column produced by the i-th
+// OUT parameter.
+func OutParamLabel(outParams []OutParam, i int) string {
+	if outParams[i].Name != "" {
+		return outParams[i].Name
+	}
+	return fmt.Sprintf("column%d", i+1)
+}
+
 // params implements the overloadImpl interface.
 func (b Overload) params() TypeList { return b.Types }
 
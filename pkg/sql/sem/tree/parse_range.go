@@ -0,0 +1,229 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+)
+
+func makeMalformedRangeError(s string) error {
+	return pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed range literal: %q", s)
+}
+
+func makeMalformedMultirangeError(s string) error {
+	return pgerror.Newf(pgcode.InvalidTextRepresentation, "malformed multirange literal: %q", s)
+}
+
+// rangeParseState is the state of the parser of a range literal.
+type rangeParseState struct {
+	s   string
+	pos int
+}
+
+func (p *rangeParseState) eatWhitespace() {
+	for p.pos < len(p.s) && asciiSpace[p.s[p.pos]] != 0 {
+		p.pos++
+	}
+}
+
+func (p *rangeParseState) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+// consumeEmpty consumes the "empty" keyword if it is next in the input.
+func (p *rangeParseState) consumeEmpty() bool {
+	const empty = "empty"
+	if len(p.s)-p.pos >= len(empty) && strings.EqualFold(p.s[p.pos:p.pos+len(empty)], empty) {
+		p.pos += len(empty)
+		return true
+	}
+	return false
+}
+
+// parseBound parses a range bound that is terminated by one of the characters
+// in terminators. Parts of the bound can be enclosed in double quotes, in
+// which a doubled double quote stands for a double quote. Outside of double
+// quotes, a backslash escapes the following character. infinite is true if
+// the bound is missing.
+func (p *rangeParseState) parseBound(terminators string) (bound string, infinite bool, ok bool) {
+	var sb strings.Builder
+	inQuote, sawAny := false, false
+	for p.pos < len(p.s) {
+		ch := p.s[p.pos]
+		if !inQuote && strings.IndexByte(terminators, ch) >= 0 {
+			return sb.String(), !sawAny, true
+		}
+		sawAny = true
+		p.pos++
+		switch {
+		case ch == '\\':
+			if p.pos >= len(p.s) {
+				return "", false, false
+			}
+			sb.WriteByte(p.s[p.pos])
+			p.pos++
+		case ch == '"' && inQuote && p.pos < len(p.s) && p.s[p.pos] == '"':
+			sb.WriteByte('"')
+			p.pos++
+		case ch == '"':
+			inQuote = !inQuote
+		case !inQuote && strings.IndexByte("()[]", ch) >= 0:
+			return "", false, false
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return "", false, false
+}
+
+// parseRange parses a range literal, such as [1,5) or empty, starting at the
+// current position.
+func (p *rangeParseState) parseRange() (lower, upper string, flags RangeFlags, ok bool) {
+	if p.consumeEmpty() {
+		return "", "", RangeEmpty, true
+	}
+	if p.eof() {
+		return "", "", 0, false
+	}
+	switch p.s[p.pos] {
+	case '[':
+		flags |= RangeLowerInc
+	case '(':
+	default:
+		return "", "", 0, false
+	}
+	p.pos++
+	lower, lowerInf, ok := p.parseBound(",")
+	if !ok {
+		return "", "", 0, false
+	}
+	if lowerInf {
+		flags |= RangeLowerInf
+	}
+	// Skip the comma.
+	p.pos++
+	upper, upperInf, ok := p.parseBound(")]")
+	if !ok {
+		return "", "", 0, false
+	}
+	if upperInf {
+		flags |= RangeUpperInf
+	}
+	if p.s[p.pos] == ']' {
+		flags |= RangeUpperInc
+	}
+	p.pos++
+	return lower, upper, flags, true
+}
+
+// makeRangeFromStrings parses the bounds of a range of type t and returns the
+// range.
+func makeRangeFromStrings(
+	ctx ParseContext, t *types.T, lower, upper string, flags RangeFlags,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	if flags&RangeEmpty != 0 {
+		return NewDEmptyRange(t), false, nil
+	}
+	subtype := t.RangeContents()
+	bounds := [2]Datum{DNull, DNull}
+	infFlags := [2]RangeFlags{RangeLowerInf, RangeUpperInf}
+	for i, s := range [2]string{lower, upper} {
+		if flags&infFlags[i] != 0 {
+			continue
+		}
+		d, boundDependsOnContext, err := ParseAndRequireString(subtype, s, ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		bounds[i] = d
+		dependsOnContext = dependsOnContext || boundDependsOnContext
+	}
+	r, err := MakeDRange(t, bounds[0], bounds[1], flags&RangeLowerInc != 0, flags&RangeUpperInc != 0)
+	return r, dependsOnContext, err
+}
+
+// ParseDRangeFromString parses the string-form of a range, such as `[1,5)` or
+// `empty`, into a range of type t.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDRangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DRange, dependsOnContext bool, _ error) {
+	p := rangeParseState{s: s}
+	p.eatWhitespace()
+	lower, upper, flags, ok := p.parseRange()
+	if !ok {
+		return nil, false, makeMalformedRangeError(s)
+	}
+	p.eatWhitespace()
+	if !p.eof() {
+		return nil, false, makeMalformedRangeError(s)
+	}
+	return makeRangeFromStrings(ctx, t, lower, upper, flags)
+}
+
+// ParseDMultirangeFromString parses the string-form of a multirange, such as
+// `{[1,3),[5,7)}`, into a multirange of type t.
+//
+// The dependsOnContext return value indicates if we had to consult the
+// ParseContext (either for the time or the local timezone).
+func ParseDMultirangeFromString(
+	ctx ParseContext, s string, t *types.T,
+) (_ *DMultirange, dependsOnContext bool, _ error) {
+	p := rangeParseState{s: s}
+	p.eatWhitespace()
+	if p.eof() || p.s[p.pos] != '{' {
+		return nil, false, makeMalformedMultirangeError(s)
+	}
+	p.pos++
+	p.eatWhitespace()
+	rangeTyp := t.MultirangeContents()
+	var ranges []*DRange
+	if !p.eof() && p.s[p.pos] == '}' {
+		p.pos++
+	} else {
+		for {
+			lower, upper, flags, ok := p.parseRange()
+			if !ok {
+				return nil, false, makeMalformedMultirangeError(s)
+			}
+			r, rangeDependsOnContext, err := makeRangeFromStrings(ctx, rangeTyp, lower, upper, flags)
+			if err != nil {
+				return nil, false, err
+			}
+			ranges = append(ranges, r)
+			dependsOnContext = dependsOnContext || rangeDependsOnContext
+			p.eatWhitespace()
+			if p.eof() {
+				return nil, false, makeMalformedMultirangeError(s)
+			}
+			ch := p.s[p.pos]
+			p.pos++
+			if ch == '}' {
+				break
+			}
+			if ch != ',' {
+				return nil, false, makeMalformedMultirangeError(s)
+			}
+			p.eatWhitespace()
+		}
+	}
+	p.eatWhitespace()
+	if !p.eof() {
+		return nil, false, makeMalformedMultirangeError(s)
+	}
+	return NewDMultirange(t, ranges), dependsOnContext, nil
+}
@@ -106,6 +106,10 @@ func ParseAndRequireString(
 		d, err = ParseDTSQuery(s)
 	case types.TSVectorFamily:
 		d, err = ParseDTSVector(s)
+	case types.RangeFamily:
+		d, dependsOnContext, err = ParseDRangeFromString(ctx, s, t)
+	case types.MultirangeFamily:
+		d, dependsOnContext, err = ParseDMultirangeFromString(ctx, s, t)
 	case types.TupleFamily:
 		d, dependsOnContext, err = ParseDTupleFromString(ctx, s, t)
 	case types.VoidFamily:
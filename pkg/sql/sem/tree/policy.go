@@ -0,0 +1,186 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// PolicyType specifies how a row-level security policy is combined with the
+// other policies that apply to the same command.
+type PolicyType uint8
+
+const (
+	// PolicyTypePermissive indicates a PERMISSIVE policy. Permissive policies
+	// are combined with OR.
+	PolicyTypePermissive PolicyType = iota
+	// PolicyTypeRestrictive indicates a RESTRICTIVE policy. Restrictive
+	// policies are combined with AND.
+	PolicyTypeRestrictive
+)
+
+var policyTypeName = [...]string{
+	PolicyTypePermissive:  "PERMISSIVE",
+	PolicyTypeRestrictive: "RESTRICTIVE",
+}
+
+// String implements the fmt.Stringer interface.
+func (t PolicyType) String() string {
+	return policyTypeName[t]
+}
+
+// PolicyCommand is the kind of statement to which a row-level security policy
+// applies.
+type PolicyCommand uint8
+
+const (
+	// PolicyCommandAll indicates a policy which applies to all statements.
+	PolicyCommandAll PolicyCommand = iota
+	// PolicyCommandSelect indicates a policy which applies to SELECT.
+	PolicyCommandSelect
+	// PolicyCommandInsert indicates a policy which applies to INSERT.
+	PolicyCommandInsert
+	// PolicyCommandUpdate indicates a policy which applies to UPDATE.
+	PolicyCommandUpdate
+	// PolicyCommandDelete indicates a policy which applies to DELETE.
+	PolicyCommandDelete
+)
+
+var policyCommandName = [...]string{
+	PolicyCommandAll:    "ALL",
+	PolicyCommandSelect: "SELECT",
+	PolicyCommandInsert: "INSERT",
+	PolicyCommandUpdate: "UPDATE",
+	PolicyCommandDelete: "DELETE",
+}
+
+// String implements the fmt.Stringer interface.
+func (c PolicyCommand) String() string {
+	return policyCommandName[c]
+}
+
+// CreatePolicy represents a CREATE POLICY statement.
+type CreatePolicy struct {
+	Name    Name
+	Table   TableName
+	Type    PolicyType
+	Command PolicyCommand
+	// Roles is the list of roles to which the policy applies. It is empty if
+	// the TO clause was omitted, in which case the policy applies to all roles.
+	Roles     RoleSpecList
+	Using     Expr
+	WithCheck Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreatePolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE POLICY ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(&node.Table)
+	ctx.WriteString(" AS ")
+	ctx.WriteString(node.Type.String())
+	ctx.WriteString(" FOR ")
+	ctx.WriteString(node.Command.String())
+	if len(node.Roles) > 0 {
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(&node.Roles)
+	}
+	formatPolicyExprs(ctx, node.Using, node.WithCheck)
+}
+
+// AlterPolicy represents an ALTER POLICY statement. Either NewName is set, or
+// any combination of Roles, Using and WithCheck is set.
+type AlterPolicy struct {
+	Name      Name
+	Table     TableName
+	NewName   Name
+	Roles     RoleSpecList
+	Using     Expr
+	WithCheck Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterPolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER POLICY ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(&node.Table)
+	if node.NewName != "" {
+		ctx.WriteString(" RENAME TO ")
+		ctx.FormatNode(&node.NewName)
+		return
+	}
+	if len(node.Roles) > 0 {
+		ctx.WriteString(" TO ")
+		ctx.FormatNode(&node.Roles)
+	}
+	formatPolicyExprs(ctx, node.Using, node.WithCheck)
+}
+
+func formatPolicyExprs(ctx *FmtCtx, using, withCheck Expr) {
+	if using != nil {
+		ctx.WriteString(" USING (")
+		ctx.FormatNode(using)
+		ctx.WriteByte(')')
+	}
+	if withCheck != nil {
+		ctx.WriteString(" WITH CHECK (")
+		ctx.FormatNode(withCheck)
+		ctx.WriteByte(')')
+	}
+}
+
+// DropPolicy represents a DROP POLICY statement.
+type DropPolicy struct {
+	Name         Name
+	Table        TableName
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropPolicy) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP POLICY ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(&node.Table)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// RowLevelSecurityMode is a change to the row-level security settings of a
+// table.
+type RowLevelSecurityMode uint8
+
+const (
+	// RowLevelSecurityEnable enables row-level security for a table.
+	RowLevelSecurityEnable RowLevelSecurityMode = iota
+	// RowLevelSecurityDisable disables row-level security for a table.
+	RowLevelSecurityDisable
+	// RowLevelSecurityForce applies row-level security to the table owner.
+	RowLevelSecurityForce
+	// RowLevelSecurityNoForce exempts the table owner from row-level security.
+	RowLevelSecurityNoForce
+)
+
+var rowLevelSecurityModeName = [...]string{
+	RowLevelSecurityEnable:  "ENABLE",
+	RowLevelSecurityDisable: "DISABLE",
+	RowLevelSecurityForce:   "FORCE",
+	RowLevelSecurityNoForce: "NO FORCE",
+}
+
+// String implements the fmt.Stringer interface.
+func (m RowLevelSecurityMode) String() string {
+	return rowLevelSecurityModeName[m]
+}
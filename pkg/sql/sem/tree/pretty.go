@@ -1117,6 +1117,10 @@ func (node *Array) doc(p *PrettyCfg) pretty.Doc {
 	return p.bracketKeyword("ARRAY", "[", p.Doc(&node.Exprs), "]", "")
 }
 
+func (node *GroupingSet) doc(p *PrettyCfg) pretty.Doc {
+	return p.bracketKeyword(groupingSetKindName[node.Kind], " (", p.Doc(&node.Exprs), ")", "")
+}
+
 func (node *Tuple) doc(p *PrettyCfg) pretty.Doc {
 	exprDoc := p.Doc(&node.Exprs)
 	if len(node.Exprs) == 1 {
@@ -1244,10 +1248,11 @@ func (node *UpdateExpr) doc(p *PrettyCfg) pretty.Doc {
 func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 	// Final layout:
 	//
-	// CREATE [TEMP | UNLOGGED] TABLE [IF NOT EXISTS] name ( .... ) [AS]
+	// CREATE [TEMP | UNLOGGED] [FOREIGN] TABLE [IF NOT EXISTS] name ( .... ) [AS]
 	//     [SELECT ...] - for CREATE TABLE AS
 	//     [INTERLEAVE ...]
 	//     [PARTITION BY ...]
+	//     [OPTIONS (...)] - for CREATE FOREIGN TABLE
 	//
 	title := pretty.Keyword("CREATE")
 	switch node.Persistence {
@@ -1256,6 +1261,9 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 	case PersistenceUnlogged:
 		title = pretty.ConcatSpace(title, pretty.Keyword("UNLOGGED"))
 	}
+	if node.IsForeign() {
+		title = pretty.ConcatSpace(title, pretty.Keyword("FOREIGN"))
+	}
 	title = pretty.ConcatSpace(title, pretty.Keyword("TABLE"))
 	if node.IfNotExists {
 		title = pretty.ConcatSpace(title, pretty.Keyword("IF NOT EXISTS"))
@@ -1297,6 +1305,15 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 	if node.Locality != nil {
 		clauses = append(clauses, p.Doc(node.Locality))
 	}
+	if node.IsForeign() {
+		clauses = append(
+			clauses,
+			pretty.ConcatSpace(
+				pretty.Keyword(`OPTIONS`),
+				p.bracket(`(`, p.Doc(&node.ForeignOptions), `)`),
+			),
+		)
+	}
 	if len(clauses) == 0 {
 		return title
 	}
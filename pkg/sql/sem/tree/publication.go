@@ -0,0 +1,109 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// CreatePublication represents a CREATE PUBLICATION statement.
+type CreatePublication struct {
+	Name Name
+	// AllTables is set for FOR ALL TABLES, in which case Tables is empty.
+	AllTables bool
+	Tables    TableNames
+	Options   KVOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreatePublication) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE PUBLICATION ")
+	ctx.FormatNode(&node.Name)
+	if node.AllTables {
+		ctx.WriteString(" FOR ALL TABLES")
+	} else if len(node.Tables) > 0 {
+		ctx.WriteString(" FOR TABLE ")
+		ctx.FormatNode(&node.Tables)
+	}
+	if len(node.Options) > 0 {
+		ctx.WriteString(" WITH (")
+		ctx.FormatNode(&node.Options)
+		ctx.WriteByte(')')
+	}
+}
+
+// AlterPublicationCmd is the kind of change made by an ALTER PUBLICATION
+// statement.
+type AlterPublicationCmd uint8
+
+const (
+	// AlterPublicationAddTables adds tables to a publication.
+	AlterPublicationAddTables AlterPublicationCmd = iota
+	// AlterPublicationDropTables removes tables from a publication.
+	AlterPublicationDropTables
+	// AlterPublicationSetTables replaces the tables of a publication.
+	AlterPublicationSetTables
+	// AlterPublicationSetOptions changes the options of a publication.
+	AlterPublicationSetOptions
+	// AlterPublicationRename renames a publication.
+	AlterPublicationRename
+)
+
+// AlterPublication represents an ALTER PUBLICATION statement. Tables is set
+// for the commands that change the tables of the publication, Options for
+// AlterPublicationSetOptions and NewName for AlterPublicationRename.
+type AlterPublication struct {
+	Name    Name
+	Cmd     AlterPublicationCmd
+	Tables  TableNames
+	Options KVOptions
+	NewName Name
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterPublication) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER PUBLICATION ")
+	ctx.FormatNode(&node.Name)
+	switch node.Cmd {
+	case AlterPublicationAddTables:
+		ctx.WriteString(" ADD TABLE ")
+		ctx.FormatNode(&node.Tables)
+	case AlterPublicationDropTables:
+		ctx.WriteString(" DROP TABLE ")
+		ctx.FormatNode(&node.Tables)
+	case AlterPublicationSetTables:
+		ctx.WriteString(" SET TABLE ")
+		ctx.FormatNode(&node.Tables)
+	case AlterPublicationSetOptions:
+		ctx.WriteString(" SET (")
+		ctx.FormatNode(&node.Options)
+		ctx.WriteByte(')')
+	case AlterPublicationRename:
+		ctx.WriteString(" RENAME TO ")
+		ctx.FormatNode(&node.NewName)
+	}
+}
+
+// DropPublication represents a DROP PUBLICATION statement.
+type DropPublication struct {
+	Names        NameList
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropPublication) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP PUBLICATION ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
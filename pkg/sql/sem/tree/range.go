@@ -0,0 +1,414 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"math"
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/errors"
+)
+
+var rangeQuoteSet asciiSet
+
+func init() {
+	var ok bool
+	rangeQuoteSet, ok = makeASCIISet(" \t\v\f\r\n()[],\"\\")
+	if !ok {
+		panic("range asciiset")
+	}
+}
+
+// compareRangeValues compares two finite range bounds, which must have the
+// same subtype.
+func compareRangeValues(a, b Datum) int {
+	switch t := a.(type) {
+	case *DInt:
+		v := *b.(*DInt)
+		if *t < v {
+			return -1
+		} else if *t > v {
+			return 1
+		}
+		return 0
+	case *DDecimal:
+		return CompareDecimals(&t.Decimal, &b.(*DDecimal).Decimal)
+	case *DTimestamp:
+		return compareRangeTimes(t.Time.Before(b.(*DTimestamp).Time), t.Time.After(b.(*DTimestamp).Time))
+	case *DTimestampTZ:
+		return compareRangeTimes(t.Time.Before(b.(*DTimestampTZ).Time), t.Time.After(b.(*DTimestampTZ).Time))
+	case *DDate:
+		return t.Date.Compare(b.(*DDate).Date)
+	}
+	panic(errors.AssertionFailedf("unexpected range bound %T", a))
+}
+
+func compareRangeTimes(before, after bool) int {
+	if before {
+		return -1
+	} else if after {
+		return 1
+	}
+	return 0
+}
+
+// nextDiscreteRangeValue returns the value that follows v if the given range
+// subtype is discrete. ok is false if the subtype is not discrete, or if v has
+// no successor that needs to be considered, such as the infinite dates.
+func nextDiscreteRangeValue(subtype *types.T, v Datum) (_ Datum, ok bool, _ error) {
+	switch t := v.(type) {
+	case *DInt:
+		if *t == math.MaxInt64 {
+			return nil, false, ErrIntOutOfRange
+		}
+		next, err := AdjustValueToType(subtype, NewDInt(*t+1))
+		if err != nil {
+			return nil, false, err
+		}
+		return next, true, nil
+	case *DDate:
+		if !t.IsFinite() {
+			return nil, false, nil
+		}
+		next, err := t.AddDays(1)
+		if err != nil {
+			return nil, false, err
+		}
+		return NewDDate(next), true, nil
+	}
+	return nil, false, nil
+}
+
+// adjustRangeToType returns the given range converted to the range type typ,
+// which must have a subtype of the same family. The bounds are adjusted to the
+// subtype of typ, as by AdjustValueToType.
+func adjustRangeToType(typ *types.T, in *DRange) (*DRange, error) {
+	if in.typ.Oid() == typ.Oid() {
+		return in, nil
+	}
+	bounds := [2]Datum{in.Lower, in.Upper}
+	for i := range bounds {
+		if bounds[i] == DNull {
+			continue
+		}
+		var err error
+		if bounds[i], err = AdjustValueToType(typ.RangeContents(), bounds[i]); err != nil {
+			return nil, err
+		}
+	}
+	return NewDRangeFromFlags(typ, bounds[0], bounds[1], in.Flags()), nil
+}
+
+// compareRangeBounds compares two range bounds. A DNull bound is infinite.
+// isLower indicates whether each bound is the lower bound of its range.
+func compareRangeBounds(a Datum, aInc, aIsLower bool, b Datum, bInc, bIsLower bool) int {
+	aInf, bInf := a == DNull, b == DNull
+	switch {
+	case aInf && bInf:
+		if aIsLower == bIsLower {
+			return 0
+		}
+		if aIsLower {
+			return -1
+		}
+		return 1
+	case aInf:
+		if aIsLower {
+			return -1
+		}
+		return 1
+	case bInf:
+		if bIsLower {
+			return 1
+		}
+		return -1
+	}
+	cmp := compareRangeValues(a, b)
+	if cmp != 0 {
+		return cmp
+	}
+	// The values are equal, so the result depends on which bounds include the
+	// value. An exclusive lower bound is after the value, and an exclusive
+	// upper bound is before it.
+	switch {
+	case !aInc && !bInc:
+		if aIsLower == bIsLower {
+			return 0
+		}
+		if aIsLower {
+			return 1
+		}
+		return -1
+	case !aInc:
+		if aIsLower {
+			return 1
+		}
+		return -1
+	case !bInc:
+		if bIsLower {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+// compareRanges compares two ranges. Empty ranges sort before all other
+// ranges, and non-empty ranges are ordered by their lower bound and then by
+// their upper bound.
+func compareRanges(a, b *DRange) int {
+	if a.Empty || b.Empty {
+		switch {
+		case a.Empty && b.Empty:
+			return 0
+		case a.Empty:
+			return -1
+		}
+		return 1
+	}
+	if cmp := compareRangeBounds(a.Lower, a.LowerInc, true, b.Lower, b.LowerInc, true); cmp != 0 {
+		return cmp
+	}
+	return compareRangeBounds(a.Upper, a.UpperInc, false, b.Upper, b.UpperInc, false)
+}
+
+// rangeBoundsAdjacent returns true if the given upper bound and lower bound
+// are adjacent, which is the case when there are no values between them and
+// exactly one of them includes the value at the bound. Since ranges of
+// discrete values are kept in canonical form, this only needs to consider
+// bounds that have the same value.
+func rangeBoundsAdjacent(upper Datum, upperInc bool, lower Datum, lowerInc bool) bool {
+	if upper == DNull || lower == DNull {
+		return false
+	}
+	return compareRangeValues(upper, lower) == 0 && upperInc != lowerInc
+}
+
+// formatRangeBound writes the text representation of a range bound to ctx.
+// Bounds are quoted if they contain any of the characters with a special
+// meaning in range literals, and double quotes and backslashes are doubled.
+func formatRangeBound(ctx *FmtCtx, bound Datum, escapeQuotes bool) {
+	if bound == DNull {
+		return
+	}
+	s := AsStringWithFlags(
+		bound,
+		FmtBareStrings|(ctx.flags&fmtPgwireFormat),
+		FmtDataConversionConfig(ctx.dataConversionConfig),
+		FmtLocation(ctx.location),
+	)
+	quote := s == "" || rangeQuoteSet.in(s)
+	if quote {
+		ctx.WriteByte('"')
+	}
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			ctx.WriteRune(r)
+		case r == '\'' && escapeQuotes:
+			ctx.WriteRune(r)
+		}
+		ctx.WriteRune(r)
+	}
+	if quote {
+		ctx.WriteByte('"')
+	}
+}
+
+// normalizeRanges returns the given ranges sorted, with empty ranges removed
+// and overlapping or adjacent ranges merged.
+func normalizeRanges(ranges []*DRange) []*DRange {
+	res := make([]*DRange, 0, len(ranges))
+	for _, r := range ranges {
+		if !r.Empty {
+			res = append(res, r)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return compareRanges(res[i], res[j]) < 0
+	})
+	n := 0
+	for _, r := range res {
+		if n > 0 {
+			last := res[n-1]
+			if compareRangeBounds(r.Lower, r.LowerInc, true, last.Upper, last.UpperInc, false) <= 0 ||
+				rangeBoundsAdjacent(last.Upper, last.UpperInc, r.Lower, r.LowerInc) {
+				res[n-1] = last.Merge(r)
+				continue
+			}
+		}
+		res[n] = r
+		n++
+	}
+	return res[:n]
+}
+
+// Overlaps returns true if the ranges have any values in common.
+func (d *DRange) Overlaps(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	if compareRangeBounds(d.Lower, d.LowerInc, true, other.Lower, other.LowerInc, true) >= 0 &&
+		compareRangeBounds(d.Lower, d.LowerInc, true, other.Upper, other.UpperInc, false) <= 0 {
+		return true
+	}
+	return compareRangeBounds(other.Lower, other.LowerInc, true, d.Lower, d.LowerInc, true) >= 0 &&
+		compareRangeBounds(other.Lower, other.LowerInc, true, d.Upper, d.UpperInc, false) <= 0
+}
+
+// ContainsRange returns true if every value of the other range is in the
+// range. The empty range is contained by every range.
+func (d *DRange) ContainsRange(other *DRange) bool {
+	if other.Empty {
+		return true
+	}
+	if d.Empty {
+		return false
+	}
+	return compareRangeBounds(d.Lower, d.LowerInc, true, other.Lower, other.LowerInc, true) <= 0 &&
+		compareRangeBounds(d.Upper, d.UpperInc, false, other.Upper, other.UpperInc, false) >= 0
+}
+
+// ContainsValue returns true if the given value, which must have the subtype
+// of the range, is in the range.
+func (d *DRange) ContainsValue(v Datum) bool {
+	if d.Empty {
+		return false
+	}
+	if d.Lower != DNull {
+		if cmp := compareRangeValues(d.Lower, v); cmp > 0 || (cmp == 0 && !d.LowerInc) {
+			return false
+		}
+	}
+	if d.Upper != DNull {
+		if cmp := compareRangeValues(d.Upper, v); cmp < 0 || (cmp == 0 && !d.UpperInc) {
+			return false
+		}
+	}
+	return true
+}
+
+// Adjacent returns true if the ranges do not overlap, but there are no values
+// between them.
+func (d *DRange) Adjacent(other *DRange) bool {
+	if d.Empty || other.Empty {
+		return false
+	}
+	return rangeBoundsAdjacent(d.Upper, d.UpperInc, other.Lower, other.LowerInc) ||
+		rangeBoundsAdjacent(other.Upper, other.UpperInc, d.Lower, d.LowerInc)
+}
+
+// Merge returns the smallest range that contains both ranges.
+func (d *DRange) Merge(other *DRange) *DRange {
+	if d.Empty {
+		return other
+	}
+	if other.Empty {
+		return d
+	}
+	res := *d
+	if compareRangeBounds(other.Lower, other.LowerInc, true, d.Lower, d.LowerInc, true) < 0 {
+		res.Lower, res.LowerInc = other.Lower, other.LowerInc
+	}
+	if compareRangeBounds(other.Upper, other.UpperInc, false, d.Upper, d.UpperInc, false) > 0 {
+		res.Upper, res.UpperInc = other.Upper, other.UpperInc
+	}
+	return &res
+}
+
+// Span returns the smallest range that contains all the ranges of the
+// multirange. It is empty if the multirange is empty.
+func (d *DMultirange) Span() *DRange {
+	if len(d.Ranges) == 0 {
+		return NewDEmptyRange(d.typ.MultirangeContents())
+	}
+	return d.Ranges[0].Merge(d.Ranges[len(d.Ranges)-1])
+}
+
+// OverlapsRange returns true if any range of the multirange overlaps the given
+// range.
+func (d *DMultirange) OverlapsRange(r *DRange) bool {
+	for _, dr := range d.Ranges {
+		if dr.Overlaps(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Overlaps returns true if the multiranges have any values in common.
+func (d *DMultirange) Overlaps(other *DMultirange) bool {
+	for _, r := range other.Ranges {
+		if d.OverlapsRange(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsRange returns true if every value of the given range is in the
+// multirange.
+func (d *DMultirange) ContainsRange(r *DRange) bool {
+	if r.Empty {
+		return true
+	}
+	// Since the ranges of a multirange are never adjacent, a non-empty range
+	// can only be contained by a single range of the multirange.
+	for _, dr := range d.Ranges {
+		if dr.ContainsRange(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains returns true if every value of the other multirange is in the
+// multirange.
+func (d *DMultirange) Contains(other *DMultirange) bool {
+	for _, r := range other.Ranges {
+		if !d.ContainsRange(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsValue returns true if the given value, which must have the subtype
+// of the multirange, is in the multirange.
+func (d *DMultirange) ContainsValue(v Datum) bool {
+	for _, dr := range d.Ranges {
+		if dr.ContainsValue(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// AdjacentRange returns true if the given range does not overlap the
+// multirange, but there are no values between them.
+func (d *DMultirange) AdjacentRange(r *DRange) bool {
+	if len(d.Ranges) == 0 || r.Empty {
+		return false
+	}
+	first, last := d.Ranges[0], d.Ranges[len(d.Ranges)-1]
+	return rangeBoundsAdjacent(r.Upper, r.UpperInc, first.Lower, first.LowerInc) ||
+		rangeBoundsAdjacent(last.Upper, last.UpperInc, r.Lower, r.LowerInc)
+}
+
+// Adjacent returns true if the multiranges do not overlap, but there are no
+// values between them.
+func (d *DMultirange) Adjacent(other *DMultirange) bool {
+	if len(d.Ranges) == 0 {
+		return false
+	}
+	return other.AdjacentRange(d.Span())
+}
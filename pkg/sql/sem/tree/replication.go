@@ -0,0 +1,115 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/lexbase"
+	"github.com/cockroachdb/cockroach/pkg/util/lsn"
+)
+
+// The statements in this file are the commands of the Postgres streaming
+// replication protocol. They are not part of the SQL grammar, and are only
+// accepted on connections opened with the replication=database startup
+// parameter. See pkg/sql/pgrepl for the parser.
+
+// ReplicationOption is an option of a replication command. Value is empty for
+// options that don't take a value.
+type ReplicationOption struct {
+	Key   Name
+	Value string
+}
+
+// ReplicationOptions is a list of ReplicationOption.
+type ReplicationOptions []ReplicationOption
+
+// Format implements the NodeFormatter interface.
+func (o *ReplicationOptions) Format(ctx *FmtCtx) {
+	ctx.WriteByte('(')
+	for i := range *o {
+		opt := &(*o)[i]
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&opt.Key)
+		if opt.Value != "" {
+			ctx.WriteByte(' ')
+			lexbase.EncodeSQLStringWithFlags(&ctx.Buffer, opt.Value, ctx.flags.EncodeFlags())
+		}
+	}
+	ctx.WriteByte(')')
+}
+
+// IdentifySystem represents an IDENTIFY_SYSTEM replication command.
+type IdentifySystem struct{}
+
+// Format implements the NodeFormatter interface.
+func (node *IdentifySystem) Format(ctx *FmtCtx) {
+	ctx.WriteString("IDENTIFY_SYSTEM")
+}
+
+// CreateReplicationSlot represents a CREATE_REPLICATION_SLOT replication
+// command. Only logical replication slots can be expressed.
+type CreateReplicationSlot struct {
+	Slot      Name
+	Temporary bool
+	Plugin    Name
+	Options   ReplicationOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateReplicationSlot) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE_REPLICATION_SLOT ")
+	ctx.FormatNode(&node.Slot)
+	if node.Temporary {
+		ctx.WriteString(" TEMPORARY")
+	}
+	ctx.WriteString(" LOGICAL ")
+	ctx.FormatNode(&node.Plugin)
+	if len(node.Options) > 0 {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.Options)
+	}
+}
+
+// DropReplicationSlot represents a DROP_REPLICATION_SLOT replication command.
+type DropReplicationSlot struct {
+	Slot Name
+	Wait bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropReplicationSlot) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP_REPLICATION_SLOT ")
+	ctx.FormatNode(&node.Slot)
+	if node.Wait {
+		ctx.WriteString(" WAIT")
+	}
+}
+
+// StartReplication represents a START_REPLICATION replication command for a
+// logical replication slot.
+type StartReplication struct {
+	Slot    Name
+	LSN     lsn.LSN
+	Options ReplicationOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *StartReplication) Format(ctx *FmtCtx) {
+	ctx.WriteString("START_REPLICATION SLOT ")
+	ctx.FormatNode(&node.Slot)
+	ctx.WriteString(" LOGICAL ")
+	ctx.WriteString(node.LSN.String())
+	if len(node.Options) > 0 {
+		ctx.WriteByte(' ')
+		ctx.FormatNode(&node.Options)
+	}
+}
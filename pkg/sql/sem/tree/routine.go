@@ -12,7 +12,9 @@ package tree
 
 import (
 	"context"
+	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 )
 
@@ -111,6 +113,49 @@ type RoutineExpr struct {
 
 	// Generator is true if the function may output a set of rows.
 	Generator bool
+
+	// ExceptionHandler, if non-nil, handles errors that occur while executing
+	// the routine. It is used to implement the EXCEPTION section of a PL/pgSQL
+	// block.
+	ExceptionHandler *RoutineExceptionHandler
+}
+
+// RoutineExceptionHandler encapsulates the information needed to match and
+// handle errors raised during the execution of a routine.
+type RoutineExceptionHandler struct {
+	// Codes is a list of error codes, one for each branch of the handler. The
+	// special code "OTHERS" matches all errors except for query cancellation
+	// and assertion failures.
+	Codes []pgcode.Code
+
+	// Actions contains a routine for each branch of the handler. When an error
+	// matches Codes[i], Actions[i] is executed in place of the failed routine,
+	// with the same arguments.
+	Actions []*RoutineExpr
+}
+
+// ExceptionCodeOthers is the code that represents the OTHERS condition of an
+// exception handler.
+var ExceptionCodeOthers = pgcode.MakeCode("OTHERS")
+
+// Match returns the action of the first branch of the handler that matches the
+// given error code, or nil if there is no such branch. A code that represents
+// an error class (e.g. "22000") matches every code in that class.
+func (h *RoutineExceptionHandler) Match(code pgcode.Code) *RoutineExpr {
+	for i := range h.Codes {
+		if h.Codes[i] == code {
+			return h.Actions[i]
+		}
+		if class := h.Codes[i].String(); strings.HasSuffix(class, "000") &&
+			strings.HasPrefix(code.String(), class[:2]) {
+			return h.Actions[i]
+		}
+		if h.Codes[i] == ExceptionCodeOthers &&
+			code != pgcode.QueryCanceled && code != pgcode.AssertFailure {
+			return h.Actions[i]
+		}
+	}
+	return nil
 }
 
 // NewTypedRoutineExpr returns a new RoutineExpr that is well-typed.
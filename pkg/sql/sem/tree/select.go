@@ -758,6 +758,42 @@ func (node *GroupBy) Format(ctx *FmtCtx) {
 	}
 }
 
+// GroupingSetKind indicates the kind of a GroupingSet.
+type GroupingSetKind int
+
+const (
+	// RollupGroupingSet represents ROLLUP (e1, e2, ...).
+	RollupGroupingSet GroupingSetKind = iota
+	// CubeGroupingSet represents CUBE (e1, e2, ...).
+	CubeGroupingSet
+	// SetsGroupingSet represents GROUPING SETS (...).
+	SetsGroupingSet
+)
+
+var groupingSetKindName = [...]string{
+	RollupGroupingSet: "ROLLUP",
+	CubeGroupingSet:   "CUBE",
+	SetsGroupingSet:   "GROUPING SETS",
+}
+
+// GroupingSet represents a ROLLUP, CUBE or GROUPING SETS item in a GROUP BY
+// clause. Each element of Exprs is either an expression or a parenthesized
+// list of expressions, represented as a Tuple. The elements of GROUPING SETS
+// can additionally be nested GroupingSets, and the empty Tuple represents the
+// empty grouping set.
+type GroupingSet struct {
+	Kind  GroupingSetKind
+	Exprs Exprs
+}
+
+// Format implements the NodeFormatter interface.
+func (node *GroupingSet) Format(ctx *FmtCtx) {
+	ctx.WriteString(groupingSetKindName[node.Kind])
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Exprs)
+	ctx.WriteByte(')')
+}
+
 // DistinctOn represents a DISTINCT ON clause.
 type DistinctOn []Expr
 
@@ -177,6 +177,12 @@ func CanWriteData(stmt Statement) bool {
 	// Replication operations.
 	case *CreateTenantFromReplication, *AlterTenantReplication:
 		return true
+	// Notifications are written to system.notifications.
+	case *Notify:
+		return true
+	// Replication slots are stored in system.replication_slots.
+	case *CreateReplicationSlot, *DropReplicationSlot:
+		return true
 	}
 	return false
 }
@@ -417,6 +423,24 @@ func (*AlterIndexVisible) StatementTag() string { return "ALTER INDEX" }
 
 func (*AlterIndexVisible) hiddenFromShowQueries() {}
 
+// StatementReturnType implements the Statement interface.
+func (*AlterPolicy) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterPolicy) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterPolicy) StatementTag() string { return "ALTER POLICY" }
+
+// StatementReturnType implements the Statement interface.
+func (*AlterPublication) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterPublication) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterPublication) StatementTag() string { return "ALTER PUBLICATION" }
+
 // StatementReturnType implements the Statement interface.
 func (*AlterTable) StatementReturnType() StatementReturnType { return DDL }
 
@@ -519,6 +543,24 @@ func (*AlterTenantService) StatementType() StatementType { return TypeDCL }
 // StatementTag returns a short string identifying the type of statement.
 func (*AlterTenantService) StatementTag() string { return "ALTER TENANT SERVICE" }
 
+// StatementReturnType implements the Statement interface.
+func (*AlterTextSearchConfig) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterTextSearchConfig) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterTextSearchConfig) StatementTag() string { return "ALTER TEXT SEARCH CONFIGURATION" }
+
+// StatementReturnType implements the Statement interface.
+func (*AlterTextSearchDictionary) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*AlterTextSearchDictionary) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*AlterTextSearchDictionary) StatementTag() string { return "ALTER TEXT SEARCH DICTIONARY" }
+
 // StatementReturnType implements the Statement interface.
 func (*AlterType) StatementReturnType() StatementReturnType { return DDL }
 
@@ -680,6 +722,15 @@ func (*CancelSessions) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*CancelSessions) StatementTag() string { return "CANCEL SESSIONS" }
 
+// StatementReturnType implements the Statement interface.
+func (*Call) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*Call) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Call) StatementTag() string { return "CALL" }
+
 // StatementReturnType implements the Statement interface.
 func (*CannedOptPlan) StatementReturnType() StatementReturnType { return Rows }
 
@@ -898,6 +949,9 @@ func (n *CreateTable) StatementTag() string {
 	if n.As() {
 		return "CREATE TABLE AS"
 	}
+	if n.IsForeign() {
+		return "CREATE FOREIGN TABLE"
+	}
 	return "CREATE TABLE"
 }
 
@@ -911,10 +965,84 @@ func (*CreateType) StatementReturnType() StatementReturnType { return DDL }
 func (*CreateType) StatementType() StatementType { return TypeDDL }
 
 // StatementTag implements the Statement interface.
-func (*CreateType) StatementTag() string { return "CREATE TYPE" }
+func (n *CreateType) StatementTag() string {
+	if n.Variety == Domain {
+		return "CREATE DOMAIN"
+	}
+	return "CREATE TYPE"
+}
 
 func (*CreateType) modifiesSchema() bool { return true }
 
+// StatementReturnType implements the Statement interface.
+func (*CreatePolicy) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreatePolicy) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (*CreatePolicy) StatementTag() string { return "CREATE POLICY" }
+
+// modifiesSchema implements the canModifySchema interface.
+func (*CreatePolicy) modifiesSchema() bool { return true }
+
+// StatementReturnType implements the Statement interface.
+func (*CreatePublication) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreatePublication) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (*CreatePublication) StatementTag() string { return "CREATE PUBLICATION" }
+
+// modifiesSchema implements the canModifySchema interface.
+func (*CreatePublication) modifiesSchema() bool { return true }
+
+// StatementReturnType implements the Statement interface.
+func (*CreateReplicationSlot) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*CreateReplicationSlot) StatementType() StatementType { return TypeDML }
+
+// StatementTag implements the Statement interface.
+func (*CreateReplicationSlot) StatementTag() string { return "CREATE_REPLICATION_SLOT" }
+
+// StatementReturnType implements the Statement interface.
+func (*CreateTextSearchConfig) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreateTextSearchConfig) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateTextSearchConfig) StatementTag() string { return "CREATE TEXT SEARCH CONFIGURATION" }
+
+// modifiesSchema implements the canModifySchema interface.
+func (*CreateTextSearchConfig) modifiesSchema() bool { return true }
+
+// StatementReturnType implements the Statement interface.
+func (*CreateTextSearchDictionary) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreateTextSearchDictionary) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateTextSearchDictionary) StatementTag() string { return "CREATE TEXT SEARCH DICTIONARY" }
+
+// modifiesSchema implements the canModifySchema interface.
+func (*CreateTextSearchDictionary) modifiesSchema() bool { return true }
+
+// StatementReturnType implements the Statement interface.
+func (*CreateTrigger) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreateTrigger) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (*CreateTrigger) StatementTag() string { return "CREATE TRIGGER" }
+
+// modifiesSchema implements the canModifySchema interface.
+func (*CreateTrigger) modifiesSchema() bool { return true }
+
 // StatementReturnType implements the Statement interface.
 func (*CreateRole) StatementReturnType() StatementReturnType { return Ack }
 
@@ -1010,6 +1138,15 @@ func (*DropDatabase) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*DropDatabase) StatementTag() string { return "DROP DATABASE" }
 
+// StatementReturnType implements the Statement interface.
+func (*DropDomain) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropDomain) StatementType() StatementType { return TypeDDL }
+
+// StatementTag implements the Statement interface.
+func (*DropDomain) StatementTag() string { return "DROP DOMAIN" }
+
 // StatementReturnType implements the Statement interface.
 func (*DropIndex) StatementReturnType() StatementReturnType { return DDL }
 
@@ -1026,7 +1163,12 @@ func (*DropTable) StatementReturnType() StatementReturnType { return DDL }
 func (*DropTable) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*DropTable) StatementTag() string { return "DROP TABLE" }
+func (n *DropTable) StatementTag() string {
+	if n.IsForeign {
+		return "DROP FOREIGN TABLE"
+	}
+	return "DROP TABLE"
+}
 
 // StatementReturnType implements the Statement interface.
 func (*DropView) StatementReturnType() StatementReturnType { return DDL }
@@ -1066,6 +1208,60 @@ func (*DropType) StatementType() StatementType { return TypeDDL }
 // StatementTag returns a short string identifying the type of statement.
 func (*DropType) StatementTag() string { return "DROP TYPE" }
 
+// StatementReturnType implements the Statement interface.
+func (*DropPolicy) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropPolicy) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropPolicy) StatementTag() string { return "DROP POLICY" }
+
+// StatementReturnType implements the Statement interface.
+func (*DropPublication) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropPublication) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropPublication) StatementTag() string { return "DROP PUBLICATION" }
+
+// StatementReturnType implements the Statement interface.
+func (*DropReplicationSlot) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*DropReplicationSlot) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropReplicationSlot) StatementTag() string { return "DROP_REPLICATION_SLOT" }
+
+// StatementReturnType implements the Statement interface.
+func (*DropTextSearchConfig) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropTextSearchConfig) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropTextSearchConfig) StatementTag() string { return "DROP TEXT SEARCH CONFIGURATION" }
+
+// StatementReturnType implements the Statement interface.
+func (*DropTextSearchDictionary) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropTextSearchDictionary) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropTextSearchDictionary) StatementTag() string { return "DROP TEXT SEARCH DICTIONARY" }
+
+// StatementReturnType implements the Statement interface.
+func (*DropTrigger) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*DropTrigger) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*DropTrigger) StatementTag() string { return "DROP TRIGGER" }
+
 // StatementReturnType implements the Statement interface.
 func (*DropSchema) StatementReturnType() StatementReturnType { return DDL }
 
@@ -1187,6 +1383,42 @@ func (*LiteralValuesClause) StatementType() StatementType { return TypeDML }
 // StatementTag returns a short string identifying the type of statement.
 func (*LiteralValuesClause) StatementTag() string { return "VALUES" }
 
+// StatementReturnType implements the Statement interface.
+func (*Listen) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*Listen) StatementType() StatementType { return TypeTCL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Listen) StatementTag() string { return "LISTEN" }
+
+// StatementReturnType implements the Statement interface.
+func (*Notify) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*Notify) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*Notify) StatementTag() string { return "NOTIFY" }
+
+// StatementReturnType implements the Statement interface.
+func (*IdentifySystem) StatementReturnType() StatementReturnType { return Rows }
+
+// StatementType implements the Statement interface.
+func (*IdentifySystem) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*IdentifySystem) StatementTag() string { return "IDENTIFY_SYSTEM" }
+
+// StatementReturnType implements the Statement interface.
+func (*StartReplication) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*StartReplication) StatementType() StatementType { return TypeDML }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*StartReplication) StatementTag() string { return "START_REPLICATION" }
+
 // StatementReturnType implements the Statement interface.
 func (*ParenSelect) StatementReturnType() StatementReturnType { return Rows }
 
@@ -1436,6 +1668,15 @@ func (*SetClusterSetting) StatementType() StatementType { return TypeDCL }
 // StatementTag returns a short string identifying the type of statement.
 func (*SetClusterSetting) StatementTag() string { return "SET CLUSTER SETTING" }
 
+// StatementReturnType implements the Statement interface.
+func (*SetConstraints) StatementReturnType() StatementReturnType { return Ack }
+
+// StatementType implements the Statement interface.
+func (*SetConstraints) StatementType() StatementType { return TypeTCL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*SetConstraints) StatementTag() string { return "SET CONSTRAINTS" }
+
 // StatementReturnType implements the Statement interface.
 func (*SetTransaction) StatementReturnType() StatementReturnType { return Ack }
 
@@ -1967,7 +2208,12 @@ func (*ShowCreateFunction) StatementReturnType() StatementReturnType { return Ro
 func (*ShowCreateFunction) StatementType() StatementType { return TypeDML }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*ShowCreateFunction) StatementTag() string { return "SHOW CREATE FUNCTION" }
+func (n *ShowCreateFunction) StatementTag() string {
+	if n.IsProcedure {
+		return "SHOW CREATE PROCEDURE"
+	}
+	return "SHOW CREATE FUNCTION"
+}
 
 // StatementReturnType implements the Statement interface.
 func (*ShowCreateExternalConnections) StatementReturnType() StatementReturnType { return Rows }
@@ -2064,7 +2310,21 @@ func (*CreateFunction) StatementReturnType() StatementReturnType { return DDL }
 func (*CreateFunction) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*CreateFunction) StatementTag() string { return "CREATE FUNCTION" }
+func (n *CreateFunction) StatementTag() string {
+	if n.IsProcedure {
+		return "CREATE PROCEDURE"
+	}
+	return "CREATE FUNCTION"
+}
+
+// StatementReturnType implements the Statement interface.
+func (*CreateAggregate) StatementReturnType() StatementReturnType { return DDL }
+
+// StatementType implements the Statement interface.
+func (*CreateAggregate) StatementType() StatementType { return TypeDDL }
+
+// StatementTag returns a short string identifying the type of statement.
+func (*CreateAggregate) StatementTag() string { return "CREATE AGGREGATE" }
 
 // StatementReturnType implements the Statement interface.
 func (*RoutineReturn) StatementReturnType() StatementReturnType { return Rows }
@@ -2082,7 +2342,15 @@ func (*DropFunction) StatementReturnType() StatementReturnType { return DDL }
 func (*DropFunction) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*DropFunction) StatementTag() string { return "DROP FUNCTION" }
+func (n *DropFunction) StatementTag() string {
+	if n.IsProcedure {
+		return "DROP PROCEDURE"
+	}
+	if n.IsAggregate {
+		return "DROP AGGREGATE"
+	}
+	return "DROP FUNCTION"
+}
 
 // StatementReturnType implements the Statement interface.
 func (*AlterFunctionOptions) StatementReturnType() StatementReturnType { return DDL }
@@ -2100,7 +2368,12 @@ func (*AlterFunctionRename) StatementReturnType() StatementReturnType { return D
 func (*AlterFunctionRename) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*AlterFunctionRename) StatementTag() string { return "ALTER FUNCTION" }
+func (n *AlterFunctionRename) StatementTag() string {
+	if n.IsAggregate {
+		return "ALTER AGGREGATE"
+	}
+	return "ALTER FUNCTION"
+}
 
 // StatementReturnType implements the Statement interface.
 func (*AlterFunctionSetSchema) StatementReturnType() StatementReturnType { return DDL }
@@ -2109,7 +2382,12 @@ func (*AlterFunctionSetSchema) StatementReturnType() StatementReturnType { retur
 func (*AlterFunctionSetSchema) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*AlterFunctionSetSchema) StatementTag() string { return "ALTER FUNCTION" }
+func (n *AlterFunctionSetSchema) StatementTag() string {
+	if n.IsAggregate {
+		return "ALTER AGGREGATE"
+	}
+	return "ALTER FUNCTION"
+}
 
 // StatementReturnType implements the Statement interface.
 func (*AlterFunctionSetOwner) StatementReturnType() StatementReturnType { return DDL }
@@ -2118,7 +2396,12 @@ func (*AlterFunctionSetOwner) StatementReturnType() StatementReturnType { return
 func (*AlterFunctionSetOwner) StatementType() StatementType { return TypeDDL }
 
 // StatementTag returns a short string identifying the type of statement.
-func (*AlterFunctionSetOwner) StatementTag() string { return "ALTER FUNCTION" }
+func (n *AlterFunctionSetOwner) StatementTag() string {
+	if n.IsAggregate {
+		return "ALTER AGGREGATE"
+	}
+	return "ALTER FUNCTION"
+}
 
 // StatementReturnType implements the Statement interface.
 func (*AlterFunctionDepExtension) StatementReturnType() StatementReturnType { return DDL }
@@ -2154,6 +2437,8 @@ func (n *AlterFunctionRename) String() string                 { return AsString(
 func (n *AlterFunctionSetSchema) String() string              { return AsString(n) }
 func (n *AlterFunctionSetOwner) String() string               { return AsString(n) }
 func (n *AlterFunctionDepExtension) String() string           { return AsString(n) }
+func (n *AlterPolicy) String() string                         { return AsString(n) }
+func (n *AlterPublication) String() string                    { return AsString(n) }
 func (n *AlterSchema) String() string                         { return AsString(n) }
 func (n *AlterTable) String() string                          { return AsString(n) }
 func (n *AlterTableCmds) String() string                      { return AsString(n) }
@@ -2169,12 +2454,15 @@ func (n *AlterTableSetDefault) String() string                { return AsString(
 func (n *AlterTableSetVisible) String() string                { return AsString(n) }
 func (n *AlterTableSetNotNull) String() string                { return AsString(n) }
 func (n *AlterTableOwner) String() string                     { return AsString(n) }
+func (n *AlterTableRowLevelSecurity) String() string          { return AsString(n) }
 func (n *AlterTableSetSchema) String() string                 { return AsString(n) }
 func (n *AlterTenantCapability) String() string               { return AsString(n) }
 func (n *AlterTenantSetClusterSetting) String() string        { return AsString(n) }
 func (n *AlterTenantRename) String() string                   { return AsString(n) }
 func (n *AlterTenantReplication) String() string              { return AsString(n) }
 func (n *AlterTenantService) String() string                  { return AsString(n) }
+func (n *AlterTextSearchConfig) String() string               { return AsString(n) }
+func (n *AlterTextSearchDictionary) String() string           { return AsString(n) }
 func (n *AlterType) String() string                           { return AsString(n) }
 func (n *AlterRole) String() string                           { return AsString(n) }
 func (n *AlterRoleSet) String() string                        { return AsString(n) }
@@ -2182,6 +2470,7 @@ func (n *AlterSequence) String() string                       { return AsString(
 func (n *Analyze) String() string                             { return AsString(n) }
 func (n *Backup) String() string                              { return AsString(n) }
 func (n *BeginTransaction) String() string                    { return AsString(n) }
+func (n *Call) String() string                                { return AsString(n) }
 func (n *ControlJobs) String() string                         { return AsString(n) }
 func (n *ControlSchedules) String() string                    { return AsString(n) }
 func (n *ControlJobsForSchedules) String() string             { return AsString(n) }
@@ -2199,13 +2488,20 @@ func (n *CommentOnTable) String() string                      { return AsString(
 func (n *CommitTransaction) String() string                   { return AsString(n) }
 func (n *CopyFrom) String() string                            { return AsString(n) }
 func (n *CopyTo) String() string                              { return AsString(n) }
+func (n *CreateAggregate) String() string                     { return AsString(n) }
 func (n *CreateChangefeed) String() string                    { return AsString(n) }
 func (n *CreateDatabase) String() string                      { return AsString(n) }
 func (n *CreateExtension) String() string                     { return AsString(n) }
 func (n *CreateFunction) String() string                      { return AsString(n) }
 func (n *CreateIndex) String() string                         { return AsString(n) }
+func (n *CreatePolicy) String() string                        { return AsString(n) }
+func (n *CreatePublication) String() string                   { return AsString(n) }
+func (n *CreateReplicationSlot) String() string               { return AsString(n) }
 func (n *CreateRole) String() string                          { return AsString(n) }
 func (n *CreateTable) String() string                         { return AsString(n) }
+func (n *CreateTextSearchConfig) String() string              { return AsString(n) }
+func (n *CreateTextSearchDictionary) String() string          { return AsString(n) }
+func (n *CreateTrigger) String() string                       { return AsString(n) }
 func (n *CreateTenant) String() string                        { return AsString(n) }
 func (n *CreateTenantFromReplication) String() string         { return AsString(n) }
 func (n *CreateSchema) String() string                        { return AsString(n) }
@@ -2216,11 +2512,18 @@ func (n *Deallocate) String() string                          { return AsString(
 func (n *Delete) String() string                              { return AsString(n) }
 func (n *DeclareCursor) String() string                       { return AsString(n) }
 func (n *DropDatabase) String() string                        { return AsString(n) }
+func (n *DropDomain) String() string                          { return AsString(n) }
 func (n *DropFunction) String() string                        { return AsString(n) }
 func (n *DropIndex) String() string                           { return AsString(n) }
+func (n *DropPolicy) String() string                          { return AsString(n) }
+func (n *DropPublication) String() string                     { return AsString(n) }
+func (n *DropReplicationSlot) String() string                 { return AsString(n) }
 func (n *DropOwnedBy) String() string                         { return AsString(n) }
 func (n *DropSchema) String() string                          { return AsString(n) }
 func (n *DropSequence) String() string                        { return AsString(n) }
+func (n *DropTextSearchConfig) String() string                { return AsString(n) }
+func (n *DropTextSearchDictionary) String() string            { return AsString(n) }
+func (n *DropTrigger) String() string                         { return AsString(n) }
 func (n *DropTable) String() string                           { return AsString(n) }
 func (n *DropType) String() string                            { return AsString(n) }
 func (n *DropView) String() string                            { return AsString(n) }
@@ -2235,6 +2538,7 @@ func (n *DropExternalConnection) String() string              { return AsString(
 func (n *FetchCursor) String() string                         { return AsString(n) }
 func (n *Grant) String() string                               { return AsString(n) }
 func (n *GrantRole) String() string                           { return AsString(n) }
+func (n *IdentifySystem) String() string                      { return AsString(n) }
 func (n *MoveCursor) String() string                          { return AsString(n) }
 func (n *Insert) String() string                              { return AsString(n) }
 func (n *Import) String() string                              { return AsString(n) }
@@ -2264,6 +2568,7 @@ func (n *Scrub) String() string                               { return AsString(
 func (n *Select) String() string                              { return AsString(n) }
 func (n *SelectClause) String() string                        { return AsString(n) }
 func (n *SetClusterSetting) String() string                   { return AsString(n) }
+func (n *SetConstraints) String() string                      { return AsString(n) }
 func (n *SetZoneConfig) String() string                       { return AsString(n) }
 func (n *SetSessionAuthorizationDefault) String() string      { return AsString(n) }
 func (n *SetSessionCharacteristics) String() string           { return AsString(n) }
@@ -2325,6 +2630,7 @@ func (n *ShowDefaultPrivileges) String() string               { return AsString(
 func (n *ShowCompletions) String() string                     { return AsString(n) }
 func (n *ShowCommitTimestamp) String() string                 { return AsString(n) }
 func (n *Split) String() string                               { return AsString(n) }
+func (n *StartReplication) String() string                    { return AsString(n) }
 func (n *Truncate) String() string                            { return AsString(n) }
 func (n *TenantSpec) String() string                          { return AsString(n) }
 func (n *UnionClause) String() string                         { return AsString(n) }
@@ -0,0 +1,169 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// CreateTextSearchConfig represents a CREATE TEXT SEARCH CONFIGURATION
+// statement. The options name either the parser of the configuration, or the
+// configuration it copies.
+type CreateTextSearchConfig struct {
+	Name    *UnresolvedObjectName
+	Options KVOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTextSearchConfig) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TEXT SEARCH CONFIGURATION ")
+	ctx.FormatNode(node.Name)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteByte(')')
+}
+
+// CreateTextSearchDictionary represents a CREATE TEXT SEARCH DICTIONARY
+// statement. The options include the template of the dictionary.
+type CreateTextSearchDictionary struct {
+	Name    *UnresolvedObjectName
+	Options KVOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTextSearchDictionary) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TEXT SEARCH DICTIONARY ")
+	ctx.FormatNode(node.Name)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteByte(')')
+}
+
+// AlterTextSearchConfigCmd is the kind of change made by an ALTER TEXT SEARCH
+// CONFIGURATION statement.
+type AlterTextSearchConfigCmd uint8
+
+const (
+	// AlterTextSearchConfigAddMapping adds mappings for token types which
+	// don't have one.
+	AlterTextSearchConfigAddMapping AlterTextSearchConfigCmd = iota
+	// AlterTextSearchConfigAlterMapping replaces the mappings of token types.
+	AlterTextSearchConfigAlterMapping
+	// AlterTextSearchConfigReplaceDictionary replaces a dictionary by another
+	// in the mappings of token types, or of all the token types if none are
+	// given.
+	AlterTextSearchConfigReplaceDictionary
+	// AlterTextSearchConfigDropMapping removes the mappings of token types.
+	AlterTextSearchConfigDropMapping
+)
+
+// AlterTextSearchConfig represents an ALTER TEXT SEARCH CONFIGURATION
+// statement. Dictionaries is set for AlterTextSearchConfigAddMapping and
+// AlterTextSearchConfigAlterMapping, OldDictionary and NewDictionary for
+// AlterTextSearchConfigReplaceDictionary, and IfExists for
+// AlterTextSearchConfigDropMapping.
+type AlterTextSearchConfig struct {
+	Name          *UnresolvedObjectName
+	Cmd           AlterTextSearchConfigCmd
+	TokenTypes    NameList
+	Dictionaries  TableNames
+	OldDictionary *UnresolvedObjectName
+	NewDictionary *UnresolvedObjectName
+	IfExists      bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTextSearchConfig) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER TEXT SEARCH CONFIGURATION ")
+	ctx.FormatNode(node.Name)
+	switch node.Cmd {
+	case AlterTextSearchConfigAddMapping:
+		ctx.WriteString(" ADD MAPPING FOR ")
+		ctx.FormatNode(&node.TokenTypes)
+		ctx.WriteString(" WITH ")
+		ctx.FormatNode(&node.Dictionaries)
+	case AlterTextSearchConfigAlterMapping:
+		ctx.WriteString(" ALTER MAPPING FOR ")
+		ctx.FormatNode(&node.TokenTypes)
+		ctx.WriteString(" WITH ")
+		ctx.FormatNode(&node.Dictionaries)
+	case AlterTextSearchConfigReplaceDictionary:
+		ctx.WriteString(" ALTER MAPPING")
+		if len(node.TokenTypes) > 0 {
+			ctx.WriteString(" FOR ")
+			ctx.FormatNode(&node.TokenTypes)
+		}
+		ctx.WriteString(" REPLACE ")
+		ctx.FormatNode(node.OldDictionary)
+		ctx.WriteString(" WITH ")
+		ctx.FormatNode(node.NewDictionary)
+	case AlterTextSearchConfigDropMapping:
+		ctx.WriteString(" DROP MAPPING ")
+		if node.IfExists {
+			ctx.WriteString("IF EXISTS ")
+		}
+		ctx.WriteString("FOR ")
+		ctx.FormatNode(&node.TokenTypes)
+	}
+}
+
+// AlterTextSearchDictionary represents an ALTER TEXT SEARCH DICTIONARY
+// statement, which changes the options of a dictionary.
+type AlterTextSearchDictionary struct {
+	Name    *UnresolvedObjectName
+	Options KVOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterTextSearchDictionary) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER TEXT SEARCH DICTIONARY ")
+	ctx.FormatNode(node.Name)
+	ctx.WriteString(" (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteByte(')')
+}
+
+// DropTextSearchConfig represents a DROP TEXT SEARCH CONFIGURATION statement.
+type DropTextSearchConfig struct {
+	Names        TableNames
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTextSearchConfig) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TEXT SEARCH CONFIGURATION ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
+
+// DropTextSearchDictionary represents a DROP TEXT SEARCH DICTIONARY
+// statement.
+type DropTextSearchDictionary struct {
+	Names        TableNames
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTextSearchDictionary) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TEXT SEARCH DICTIONARY ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Names)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+// TriggerActionTime specifies whether a trigger fires before or after the
+// triggering mutation is applied.
+type TriggerActionTime uint8
+
+const (
+	// TriggerActionTimeBefore indicates a BEFORE trigger.
+	TriggerActionTimeBefore TriggerActionTime = iota
+	// TriggerActionTimeAfter indicates an AFTER trigger.
+	TriggerActionTimeAfter
+)
+
+var triggerActionTimeName = [...]string{
+	TriggerActionTimeBefore: "BEFORE",
+	TriggerActionTimeAfter:  "AFTER",
+}
+
+// String implements the fmt.Stringer interface.
+func (t TriggerActionTime) String() string {
+	return triggerActionTimeName[t]
+}
+
+// TriggerEventType is a kind of mutation which causes a trigger to fire.
+type TriggerEventType uint8
+
+const (
+	// TriggerEventInsert indicates an INSERT event.
+	TriggerEventInsert TriggerEventType = iota
+	// TriggerEventUpdate indicates an UPDATE event.
+	TriggerEventUpdate
+	// TriggerEventDelete indicates a DELETE event.
+	TriggerEventDelete
+)
+
+var triggerEventTypeName = [...]string{
+	TriggerEventInsert: "INSERT",
+	TriggerEventUpdate: "UPDATE",
+	TriggerEventDelete: "DELETE",
+}
+
+// String implements the fmt.Stringer interface.
+func (t TriggerEventType) String() string {
+	return triggerEventTypeName[t]
+}
+
+// TriggerEvents is a list of events which cause a trigger to fire.
+type TriggerEvents []TriggerEventType
+
+// Format implements the NodeFormatter interface.
+func (node TriggerEvents) Format(ctx *FmtCtx) {
+	for i, e := range node {
+		if i > 0 {
+			ctx.WriteString(" OR ")
+		}
+		ctx.WriteString(e.String())
+	}
+}
+
+// Contains returns true if the list contains the given event.
+func (node TriggerEvents) Contains(event TriggerEventType) bool {
+	for _, e := range node {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateTrigger represents a CREATE TRIGGER statement.
+type CreateTrigger struct {
+	Name       Name
+	ActionTime TriggerActionTime
+	Events     TriggerEvents
+	Table      TableName
+	// ForEachRow is true for FOR EACH ROW triggers, and false for FOR EACH
+	// STATEMENT triggers.
+	ForEachRow bool
+	FuncName   *UnresolvedName
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE TRIGGER ")
+	ctx.FormatNode(&node.Name)
+	ctx.WriteByte(' ')
+	ctx.WriteString(node.ActionTime.String())
+	ctx.WriteByte(' ')
+	ctx.FormatNode(node.Events)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(&node.Table)
+	if node.ForEachRow {
+		ctx.WriteString(" FOR EACH ROW")
+	} else {
+		ctx.WriteString(" FOR EACH STATEMENT")
+	}
+	ctx.WriteString(" EXECUTE FUNCTION ")
+	ctx.FormatNode(node.FuncName)
+	ctx.WriteString("()")
+}
+
+// DropTrigger represents a DROP TRIGGER statement.
+type DropTrigger struct {
+	Name         Name
+	Table        TableName
+	IfExists     bool
+	DropBehavior DropBehavior
+}
+
+// Format implements the NodeFormatter interface.
+func (node *DropTrigger) Format(ctx *FmtCtx) {
+	ctx.WriteString("DROP TRIGGER ")
+	if node.IfExists {
+		ctx.WriteString("IF EXISTS ")
+	}
+	ctx.FormatNode(&node.Name)
+	ctx.WriteString(" ON ")
+	ctx.FormatNode(&node.Table)
+	if node.DropBehavior != DropDefault {
+		ctx.WriteByte(' ')
+		ctx.WriteString(node.DropBehavior.String())
+	}
+}
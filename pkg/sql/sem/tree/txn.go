@@ -14,6 +14,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 )
@@ -24,18 +25,30 @@ type IsolationLevel int
 // IsolationLevel values
 const (
 	UnspecifiedIsolation IsolationLevel = iota
+	ReadUncommittedIsolation
+	ReadCommittedIsolation
+	RepeatableReadIsolation
+	SnapshotIsolation
 	SerializableIsolation
 )
 
 var isolationLevelNames = [...]string{
-	UnspecifiedIsolation:  "UNSPECIFIED",
-	SerializableIsolation: "SERIALIZABLE",
+	UnspecifiedIsolation:     "UNSPECIFIED",
+	ReadUncommittedIsolation: "READ UNCOMMITTED",
+	ReadCommittedIsolation:   "READ COMMITTED",
+	RepeatableReadIsolation:  "REPEATABLE READ",
+	SnapshotIsolation:        "SNAPSHOT",
+	SerializableIsolation:    "SERIALIZABLE",
 }
 
 // IsolationLevelMap is a map from string isolation level name to isolation
 // level, in the lowercase format that set isolation_level supports.
 var IsolationLevelMap = map[string]IsolationLevel{
-	"serializable": SerializableIsolation,
+	"read uncommitted": ReadUncommittedIsolation,
+	"read committed":   ReadCommittedIsolation,
+	"repeatable read":  RepeatableReadIsolation,
+	"snapshot":         SnapshotIsolation,
+	"serializable":     SerializableIsolation,
 }
 
 func (i IsolationLevel) String() string {
@@ -45,6 +58,32 @@ func (i IsolationLevel) String() string {
 	return isolationLevelNames[i]
 }
 
+// ToKVIsoLevel converts an IsolationLevel to its isolation.Level equivalent.
+// Isolation levels that are not implemented by the KV layer are mapped to the
+// next stronger isolation level that is, which is allowed by the SQL standard.
+func (i IsolationLevel) ToKVIsoLevel() isolation.Level {
+	switch i {
+	case ReadUncommittedIsolation, ReadCommittedIsolation:
+		return isolation.ReadCommitted
+	case RepeatableReadIsolation, SnapshotIsolation:
+		return isolation.Snapshot
+	default:
+		return isolation.Serializable
+	}
+}
+
+// FromKVIsoLevel converts an isolation.Level to its SQL semantic equivalent.
+func FromKVIsoLevel(level isolation.Level) IsolationLevel {
+	switch level {
+	case isolation.ReadCommitted:
+		return ReadCommittedIsolation
+	case isolation.Snapshot:
+		return SnapshotIsolation
+	default:
+		return SerializableIsolation
+	}
+}
+
 // UserPriority holds the user priority for a transaction.
 type UserPriority int
 
@@ -585,6 +585,12 @@ func (expr *CastExpr) TypeCheck(
 		return nil, err
 	}
 
+	// Casts to domain types are never elided, because the constraints of the
+	// domain must be checked even if the base type is identical.
+	if exprType.DomainOID() != 0 {
+		canElideCast = false
+	}
+
 	// Elide the cast if it is a no-op.
 	if canElideCast && typedSubExpr.ResolvedType().Identical(exprType) {
 		return typedSubExpr, nil
@@ -893,6 +899,7 @@ var (
 	errInvalidMaxUsage     = pgerror.New(pgcode.Syntax, "MAXVALUE can only appear within a range partition expression")
 	errInvalidMinUsage     = pgerror.New(pgcode.Syntax, "MINVALUE can only appear within a range partition expression")
 	errPrivateFunction     = pgerror.New(pgcode.ReservedName, "function reserved for internal use")
+	errInvalidGroupingSet  = pgerror.New(pgcode.Syntax, "grouping sets can only appear within a GROUP BY clause")
 )
 
 // NewAggInAggError creates an error for the case when an aggregate function is
@@ -1584,6 +1591,13 @@ func (expr PartitionMaxVal) TypeCheck(
 	return nil, errInvalidMaxUsage
 }
 
+// TypeCheck implements the Expr interface.
+func (expr *GroupingSet) TypeCheck(
+	_ context.Context, _ *SemaContext, desired *types.T,
+) (TypedExpr, error) {
+	return nil, errInvalidGroupingSet
+}
+
 // TypeCheck implements the Expr interface.
 func (expr *NumVal) TypeCheck(
 	ctx context.Context, semaCtx *SemaContext, desired *types.T,
@@ -1882,6 +1896,18 @@ func (d *DJSON) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedE
 	return d, nil
 }
 
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DRange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
+// TypeCheck implements the Expr interface. It is implemented as an idempotent
+// identity function for Datum.
+func (d *DMultirange) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
+	return d, nil
+}
+
 // TypeCheck implements the Expr interface. It is implemented as an idempotent
 // identity function for Datum.
 func (d *DTSQuery) TypeCheck(_ context.Context, _ *SemaContext, _ *types.T) (TypedExpr, error) {
@@ -18,6 +18,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/errors"
+	"github.com/lib/pq/oid"
 )
 
 // ErrConflictingFunctionOption indicates that there are conflicting or
@@ -102,17 +103,24 @@ func (node *CreateFunction) Format(ctx *FmtCtx) {
 	if node.Replace {
 		ctx.WriteString("OR REPLACE ")
 	}
-	ctx.WriteString("FUNCTION ")
+	if node.IsProcedure {
+		ctx.WriteString("PROCEDURE ")
+	} else {
+		ctx.WriteString("FUNCTION ")
+	}
 	ctx.FormatNode(&node.FuncName)
 	ctx.WriteString("(")
 	ctx.FormatNode(node.Params)
 	ctx.WriteString(")\n\t")
-	ctx.WriteString("RETURNS ")
-	if node.ReturnType.IsSet {
-		ctx.WriteString("SETOF ")
+	// The return type may be omitted for a function with OUT parameters.
+	if !node.IsProcedure && node.ReturnType.Type != nil {
+		ctx.WriteString("RETURNS ")
+		if node.ReturnType.IsSet {
+			ctx.WriteString("SETOF ")
+		}
+		ctx.FormatTypeReference(node.ReturnType.Type)
+		ctx.WriteString("\n\t")
 	}
-	ctx.FormatTypeReference(node.ReturnType.Type)
-	ctx.WriteString("\n\t")
 	var funcBody FunctionBodyStr
 	for _, option := range node.Options {
 		switch t := option.(type) {
@@ -163,6 +171,22 @@ func (node *RoutineReturn) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.ReturnVal)
 }
 
+// Call represents a CALL statement, which invokes a stored procedure.
+type Call struct {
+	// Proc is the procedure being called.
+	Proc *FuncExpr
+}
+
+var _ Statement = &Call{}
+
+// Format implements the NodeFormatter interface.
+func (node *Call) Format(ctx *FmtCtx) {
+	ctx.WriteString("CALL ")
+	// Format the procedure directly, rather than with FormatNode, so that it is
+	// never wrapped in parentheses.
+	node.Proc.Format(ctx)
+}
+
 // FunctionOptions represent a list of function options.
 type FunctionOptions []FunctionOption
 
@@ -355,6 +379,18 @@ func (node *FuncParam) Format(ctx *FmtCtx) {
 	}
 }
 
+// IsInParam returns true if the parameter is an input of the routine, i.e.
+// its type is part of the routine's signature.
+func (node *FuncParam) IsInParam() bool {
+	return IsInParamClass(node.Class)
+}
+
+// IsOutParam returns true if the parameter is an output of the routine, i.e.
+// it is a column of the routine's result.
+func (node *FuncParam) IsOutParam() bool {
+	return IsOutParamClass(node.Class)
+}
+
 // FuncParamClass indicates what type of argument an arg is.
 type FuncParamClass int
 
@@ -369,14 +405,112 @@ const (
 	FunctionParamVariadic
 )
 
+// IsInParamClass returns true if a parameter of the given class is an input
+// of the routine.
+func IsInParamClass(class FuncParamClass) bool {
+	return class != FunctionParamOut
+}
+
+// IsOutParamClass returns true if a parameter of the given class is an output
+// of the routine.
+func IsOutParamClass(class FuncParamClass) bool {
+	return class == FunctionParamOut || class == FunctionParamInOut
+}
+
 // FuncReturnType represent the return type of UDF.
 type FuncReturnType struct {
 	Type  ResolvableTypeReference
 	IsSet bool
 }
 
-// DropFunction represents a DROP FUNCTION statement.
+// CreateAggregate represents a CREATE AGGREGATE statement.
+type CreateAggregate struct {
+	Replace  bool
+	FuncName FunctionName
+	Params   FuncParams
+	Options  AggregateOptions
+}
+
+// Format implements the NodeFormatter interface.
+func (node *CreateAggregate) Format(ctx *FmtCtx) {
+	ctx.WriteString("CREATE ")
+	if node.Replace {
+		ctx.WriteString("OR REPLACE ")
+	}
+	ctx.WriteString("AGGREGATE ")
+	ctx.FormatNode(&node.FuncName)
+	ctx.WriteString("(")
+	ctx.FormatNode(node.Params)
+	ctx.WriteString(") (")
+	ctx.FormatNode(&node.Options)
+	ctx.WriteString(")")
+}
+
+// AggregateOptions is a list of options of a CREATE AGGREGATE statement.
+type AggregateOptions []AggregateOption
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateOptions) Format(ctx *FmtCtx) {
+	for i := range *node {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*node)[i])
+	}
+}
+
+// AggregateOption is a single "name = value" option of a CREATE AGGREGATE
+// statement, e.g. SFUNC = f or INITCOND = '0'. Exactly one of TypeVal, StrVal
+// and ExprVal is set. TypeVal is also used for the options that name a support
+// function of the aggregate, since the grammar cannot distinguish a function
+// name from a type name. ExprVal is an ARRAY constructor, which can be given as
+// the INITCOND of an aggregate with an array state.
+type AggregateOption struct {
+	Name    Name
+	TypeVal ResolvableTypeReference
+	StrVal  *StrVal
+	ExprVal Expr
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AggregateOption) Format(ctx *FmtCtx) {
+	ctx.WriteString(strings.ToUpper(string(node.Name)))
+	ctx.WriteString(" = ")
+	if node.TypeVal != nil {
+		ctx.FormatTypeReference(node.TypeVal)
+	} else if node.ExprVal != nil {
+		ctx.FormatNode(node.ExprVal)
+	} else {
+		ctx.FormatNode(node.StrVal)
+	}
+}
+
+// UDFAggregate describes a user-defined aggregate function created with
+// CREATE AGGREGATE.
+type UDFAggregate struct {
+	// TransitionFunc is the OID of the state transition function, which is
+	// called with the current state and the arguments of each input row and
+	// returns the next state.
+	TransitionFunc oid.Oid
+	// FinalFunc is the OID of the function that computes the result of the
+	// aggregate from the final state. It is zero if the result of the aggregate
+	// is the final state.
+	FinalFunc oid.Oid
+	// CombineFunc is the OID of the function that combines two partial states.
+	// It is zero if the aggregate has no combine function.
+	CombineFunc oid.Oid
+	// StateType is the type of the state of the aggregate.
+	StateType *types.T
+	// InitCond is the string representation of the initial state. It is nil if
+	// the initial state is NULL.
+	InitCond *string
+}
+
+// DropFunction represents a DROP FUNCTION, DROP PROCEDURE or DROP AGGREGATE
+// statement.
 type DropFunction struct {
+	IsProcedure  bool
+	IsAggregate  bool
 	IfExists     bool
 	Functions    FuncObjs
 	DropBehavior DropBehavior
@@ -384,7 +518,13 @@ type DropFunction struct {
 
 // Format implements the NodeFormatter interface.
 func (node *DropFunction) Format(ctx *FmtCtx) {
-	ctx.WriteString("DROP FUNCTION ")
+	if node.IsProcedure {
+		ctx.WriteString("DROP PROCEDURE ")
+	} else if node.IsAggregate {
+		ctx.WriteString("DROP AGGREGATE ")
+	} else {
+		ctx.WriteString("DROP FUNCTION ")
+	}
 	if node.IfExists {
 		ctx.WriteString("IF EXISTS ")
 	}
@@ -426,18 +566,21 @@ func (node *FuncObj) Format(ctx *FmtCtx) {
 
 // ParamTypes returns a slice of parameter types of the function.
 func (node FuncObj) ParamTypes(ctx context.Context, res TypeReferenceResolver) ([]*types.T, error) {
-	// TODO(chengxiong): handle INOUT, OUT and VARIADIC argument classes when we
-	// support them. This is because only IN and INOUT arg types need to be
-	// considered to match a overload.
+	// Only the types of input parameters are considered to match an overload,
+	// so OUT parameters are skipped.
+	// TODO(chengxiong): handle the VARIADIC argument class when we support it.
 	var argTypes []*types.T
 	if node.Params != nil {
-		argTypes = make([]*types.T, len(node.Params))
-		for i, arg := range node.Params {
+		argTypes = make([]*types.T, 0, len(node.Params))
+		for _, arg := range node.Params {
+			if !arg.IsInParam() {
+				continue
+			}
 			typ, err := ResolveType(ctx, arg.Type, res)
 			if err != nil {
 				return nil, err
 			}
-			argTypes[i] = typ
+			argTypes = append(argTypes, typ)
 		}
 	}
 	return argTypes, nil
@@ -459,43 +602,61 @@ func (node *AlterFunctionOptions) Format(ctx *FmtCtx) {
 	}
 }
 
-// AlterFunctionRename represents a ALTER FUNCTION...RENAME statement.
+// AlterFunctionRename represents a ALTER FUNCTION...RENAME or ALTER
+// AGGREGATE...RENAME statement.
 type AlterFunctionRename struct {
-	Function FuncObj
-	NewName  Name
+	IsAggregate bool
+	Function    FuncObj
+	NewName     Name
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionRename) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	if node.IsAggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
+	} else {
+		ctx.WriteString("ALTER FUNCTION ")
+	}
 	ctx.FormatNode(&node.Function)
 	ctx.WriteString(" RENAME TO ")
 	ctx.WriteString(string(node.NewName))
 }
 
-// AlterFunctionSetSchema represents a ALTER FUNCTION...SET SCHEMA statement.
+// AlterFunctionSetSchema represents a ALTER FUNCTION...SET SCHEMA or ALTER
+// AGGREGATE...SET SCHEMA statement.
 type AlterFunctionSetSchema struct {
+	IsAggregate   bool
 	Function      FuncObj
 	NewSchemaName Name
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionSetSchema) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	if node.IsAggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
+	} else {
+		ctx.WriteString("ALTER FUNCTION ")
+	}
 	ctx.FormatNode(&node.Function)
 	ctx.WriteString(" SET SCHEMA ")
 	ctx.WriteString(string(node.NewSchemaName))
 }
 
-// AlterFunctionSetOwner represents the ALTER FUNCTION...OWNER TO statement.
+// AlterFunctionSetOwner represents the ALTER FUNCTION...OWNER TO or ALTER
+// AGGREGATE...OWNER TO statement.
 type AlterFunctionSetOwner struct {
-	Function FuncObj
-	NewOwner RoleSpec
+	IsAggregate bool
+	Function    FuncObj
+	NewOwner    RoleSpec
 }
 
 // Format implements the NodeFormatter interface.
 func (node *AlterFunctionSetOwner) Format(ctx *FmtCtx) {
-	ctx.WriteString("ALTER FUNCTION ")
+	if node.IsAggregate {
+		ctx.WriteString("ALTER AGGREGATE ")
+	} else {
+		ctx.WriteString("ALTER FUNCTION ")
+	}
 	ctx.FormatNode(&node.Function)
 	ctx.WriteString(" OWNER TO ")
 	ctx.FormatNode(&node.NewOwner)
@@ -555,12 +716,15 @@ const (
 	ColumnDefaultExprInNewView      SchemaExprContext = "DEFAULT (in CREATE VIEW)"
 	ColumnDefaultExprInSetDefault   SchemaExprContext = "DEFAULT (in SET DEFAULT)"
 	CheckConstraintExpr             SchemaExprContext = "CHECK"
+	DomainCheckExpr                 SchemaExprContext = "DOMAIN CHECK"
+	DomainDefaultExpr               SchemaExprContext = "DEFAULT (in CREATE DOMAIN)"
 	UniqueWithoutIndexPredicateExpr SchemaExprContext = "UNIQUE WITHOUT INDEX PREDICATE"
 	IndexPredicateExpr              SchemaExprContext = "INDEX PREDICATE"
 	ExpressionIndexElementExpr      SchemaExprContext = "EXPRESSION INDEX ELEMENT"
 	TTLExpirationExpr               SchemaExprContext = "TTL EXPIRATION EXPRESSION"
 	TTLDefaultExpr                  SchemaExprContext = "TTL DEFAULT"
 	TTLUpdateExpr                   SchemaExprContext = "TTL UPDATE"
+	PolicyExpr                      SchemaExprContext = "POLICY"
 )
 
 func ComputedColumnExprContext(isVirtual bool) SchemaExprContext {
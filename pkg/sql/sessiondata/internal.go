@@ -47,6 +47,10 @@ type InternalExecutorOverride struct {
 	// does **not** propagate further to "nested" executors that are spawned up
 	// by the "top" executor.
 	InjectRetryErrorsEnabled bool
+	// AllowMaterializedViewMutations, if true, allows the statement to modify
+	// the rows of materialized views. It is used by the incremental refresh of
+	// materialized views.
+	AllowMaterializedViewMutations bool
 }
 
 // NoSessionDataOverride is the empty InternalExecutorOverride which does not
@@ -271,6 +271,50 @@ func DescriptorValidationModeFromString(val string) (_ DescriptorValidationMode,
 	}
 }
 
+// PlanCacheMode controls the optimizer's decision to use a custom or generic
+// query plan when executing prepared statements.
+type PlanCacheMode int64
+
+const (
+	// PlanCacheModeForceCustom means that the optimizer always builds a custom
+	// plan, optimized with the placeholder values of each execution.
+	PlanCacheModeForceCustom PlanCacheMode = iota
+	// PlanCacheModeForceGeneric means that the optimizer builds a generic plan
+	// once, without knowledge of the placeholder values, and reuses it for
+	// every execution.
+	PlanCacheModeForceGeneric
+	// PlanCacheModeAuto means that the optimizer chooses between custom and
+	// generic plans based on their estimated costs.
+	PlanCacheModeAuto
+)
+
+func (m PlanCacheMode) String() string {
+	switch m {
+	case PlanCacheModeForceCustom:
+		return "force_custom_plan"
+	case PlanCacheModeForceGeneric:
+		return "force_generic_plan"
+	case PlanCacheModeAuto:
+		return "auto"
+	default:
+		return fmt.Sprintf("invalid (%d)", m)
+	}
+}
+
+// PlanCacheModeFromString converts a string into a PlanCacheMode.
+func PlanCacheModeFromString(val string) (_ PlanCacheMode, ok bool) {
+	switch strings.ToUpper(val) {
+	case "FORCE_CUSTOM_PLAN":
+		return PlanCacheModeForceCustom, true
+	case "FORCE_GENERIC_PLAN":
+		return PlanCacheModeForceGeneric, true
+	case "AUTO":
+		return PlanCacheModeAuto, true
+	default:
+		return 0, false
+	}
+}
+
 // QoSLevel controls the level of admission control to use for new SQL requests.
 type QoSLevel admissionpb.WorkPriority
 
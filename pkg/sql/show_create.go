@@ -90,14 +90,27 @@ func ShowCreateTable(
 	if desc.IsTemporary() {
 		f.WriteString("TEMP ")
 	}
+	if desc.IsForeignTable() {
+		f.WriteString("FOREIGN ")
+	}
 	f.WriteString("TABLE ")
 	f.FormatNode(tn)
 	f.WriteString(" (")
-	// Inaccessible columns are not displayed in SHOW CREATE TABLE.
-	for i, col := range desc.AccessibleColumns() {
-		if i != 0 {
+	// Inaccessible columns are not displayed in SHOW CREATE TABLE. Neither is
+	// the row ID column of a foreign table, which cannot be declared.
+	var skipCols catalog.TableColSet
+	if desc.IsForeignTable() {
+		skipCols = desc.GetPrimaryIndex().CollectKeyColumnIDs()
+	}
+	numCols := 0
+	for _, col := range desc.AccessibleColumns() {
+		if skipCols.Contains(col.GetID()) {
+			continue
+		}
+		if numCols != 0 {
 			f.WriteString(",")
 		}
+		numCols++
 		f.WriteString("\n\t")
 		colstr, err := schemaexpr.FormatColumnForDisplay(
 			ctx, desc, col, &p.RunParams(ctx).p.semaCtx, p.RunParams(ctx).p.SessionData(),
@@ -109,7 +122,9 @@ func ShowCreateTable(
 		f.WriteString(colstr)
 	}
 
-	if desc.IsPhysicalTable() {
+	// The primary key of a foreign table is always the hidden row ID column, so
+	// it is omitted.
+	if desc.IsPhysicalTable() && !desc.IsForeignTable() {
 		f.WriteString(",\n\tCONSTRAINT ")
 		formatQuoteNames(&f.Buffer, desc.GetPrimaryIndex().GetName())
 		f.WriteString(" ")
@@ -187,6 +202,13 @@ func ShowCreateTable(
 		return "", err
 	}
 
+	if ft := desc.GetForeignTable(); ft != nil {
+		opts := foreignTableOptions(ft)
+		f.WriteString(" OPTIONS (")
+		f.FormatNode(&opts)
+		f.WriteString(")")
+	}
+
 	if storageParams := desc.GetStorageParams(true /* spaceBetweenEqual */); len(storageParams) > 0 {
 		f.Buffer.WriteString(` WITH (`)
 		f.Buffer.WriteString(strings.Join(storageParams, ", "))
@@ -197,6 +219,12 @@ func ShowCreateTable(
 		return "", err
 	}
 
+	if err := showRowLevelSecurity(
+		ctx, tn, desc, &p.RunParams(ctx).p.semaCtx, p.RunParams(ctx).p.SessionData(), f,
+	); err != nil {
+		return "", err
+	}
+
 	if !displayOptions.IgnoreComments {
 		if err := showComments(tn, desc, selectComment(ctx, p, desc.GetID()), &f.Buffer); err != nil {
 			return "", err
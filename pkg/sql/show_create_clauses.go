@@ -491,12 +491,20 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(tree.ForeignKeyReferenceActionType[fk.OnUpdate].String())
 	}
+	formatConstraintDeferrability(buf, fk.Deferrability)
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
 	return nil
 }
 
+// formatConstraintDeferrability writes the DEFERRABLE clause for a constraint
+// with the given deferrability, if it has one.
+func formatConstraintDeferrability(buf *bytes.Buffer, d semenumpb.Deferrability) {
+	deferrability := tree.ConstraintDeferrability(d)
+	buf.WriteString(tree.AsString(&deferrability))
+}
+
 // ShowCreateSequence returns a valid SQL representation of the
 // CREATE SEQUENCE statement used to create the given sequence.
 func ShowCreateSequence(
@@ -564,6 +572,66 @@ func showCreateLocality(desc catalog.TableDescriptor, f *tree.FmtCtx) error {
 	return nil
 }
 
+// showRowLevelSecurity creates the ALTER TABLE statements that enable
+// row-level security for the table and the CREATE POLICY statements for its
+// policies, writing them to tree.FmtCtx f.
+func showRowLevelSecurity(
+	ctx context.Context,
+	tn *tree.TableName,
+	desc catalog.TableDescriptor,
+	semaCtx *tree.SemaContext,
+	sessionData *sessiondata.SessionData,
+	f *tree.FmtCtx,
+) error {
+	un := tn.ToUnresolvedObjectName()
+	showMode := func(mode tree.RowLevelSecurityMode) {
+		f.WriteString(";\n")
+		f.FormatNode(&tree.AlterTable{
+			Table: un,
+			Cmds:  tree.AlterTableCmds{&tree.AlterTableRowLevelSecurity{Mode: mode}},
+		})
+	}
+	if desc.IsRowLevelSecurityEnabled() {
+		showMode(tree.RowLevelSecurityEnable)
+	}
+	if desc.IsRowLevelSecurityForced() {
+		showMode(tree.RowLevelSecurityForce)
+	}
+	for _, policy := range desc.GetPolicies() {
+		f.WriteString(";\nCREATE POLICY ")
+		formatQuoteNames(&f.Buffer, policy.Name)
+		f.WriteString(" ON ")
+		f.FormatNode(tn)
+		f.WriteString(" AS ")
+		f.WriteString(policy.Type.String())
+		f.WriteString(" FOR ")
+		f.WriteString(policy.Command.String())
+		f.WriteString(" TO ")
+		formatQuoteNames(&f.Buffer, policy.RoleNames...)
+		for _, clause := range []struct {
+			keyword string
+			expr    string
+		}{
+			{keyword: " USING (", expr: policy.UsingExpr},
+			{keyword: " WITH CHECK (", expr: policy.WithCheckExpr},
+		} {
+			if clause.expr == "" {
+				continue
+			}
+			expr, err := schemaexpr.FormatExprForDisplay(
+				ctx, desc, clause.expr, semaCtx, sessionData, tree.FmtParsable,
+			)
+			if err != nil {
+				return err
+			}
+			f.WriteString(clause.keyword)
+			f.WriteString(expr)
+			f.WriteString(")")
+		}
+	}
+	return nil
+}
+
 // ShowCreatePartitioning returns a PARTITION BY clause for the specified
 // index, if applicable.
 func ShowCreatePartitioning(
@@ -759,6 +827,7 @@ func showConstraintClause(
 		}
 		f.WriteString(strings.Join(colNames, ", "))
 		f.WriteString(")")
+		formatConstraintDeferrability(&f.Buffer, c.Deferrability())
 		if c.IsPartial() {
 			f.WriteString(" WHERE ")
 			pred, err := schemaexpr.FormatExprForDisplay(
@@ -15,15 +15,18 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/clusterunique"
 	"github.com/cockroachdb/cockroach/pkg/sql/isql"
 	"github.com/cockroachdb/cockroach/pkg/sql/parser/statements"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/mon"
 	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/errors"
 )
@@ -41,10 +44,10 @@ func (p *planner) DeclareCursor(ctx context.Context, s *tree.DeclareCursor) (pla
 	return &delayedNode{
 		name: s.String(),
 		constructor: func(ctx context.Context, p *planner) (_ planNode, _ error) {
-			if p.extendedEvalCtx.TxnImplicit {
-				if s.Hold {
-					return nil, unimplemented.NewWithIssue(77101, "DECLARE CURSOR WITH HOLD can only be used in transaction blocks")
-				}
+			// A cursor WITH HOLD can be declared outside of a transaction block,
+			// since its rows are materialized when the implicit transaction
+			// commits.
+			if p.extendedEvalCtx.TxnImplicit && !s.Hold {
 				return nil, pgerror.Newf(pgcode.NoActiveSQLTransaction, "DECLARE CURSOR can only be used in transaction blocks")
 			}
 
@@ -172,6 +175,11 @@ type fetchNode struct {
 }
 
 func (f *fetchNode) startExec(params runParams) error {
+	// A persisted cursor WITH HOLD reads from its materialized rows rather than
+	// from its transaction.
+	if f.cursor.persisted {
+		return nil
+	}
 	// We need to make sure that we're reading at the same read sequence number
 	// that we had when we created the cursor, to preserve the "sensitivity"
 	// semantics of cursors, which demand that data written after the cursor
@@ -236,6 +244,9 @@ func (f fetchNode) Values() tree.Datums {
 func (f fetchNode) Close(ctx context.Context) {
 	// We explicitly do not pass through the Close to our Rows, because
 	// running FETCH on a CURSOR does not close it.
+	if f.cursor.persisted {
+		return
+	}
 
 	// Reset the transaction's read sequence number to what it was before the
 	// fetch began, so that subsequent reads in the transaction can still see
@@ -252,9 +263,9 @@ func (p *planner) CloseCursor(ctx context.Context, n *tree.CloseCursor) (planNod
 		name: n.String(),
 		constructor: func(ctx context.Context, p *planner) (planNode, error) {
 			if n.All {
-				return newZeroNode(nil /* columns */), p.sqlCursors.closeAll(false /* errorOnWithHold */)
+				return newZeroNode(nil /* columns */), p.sqlCursors.closeAll(ctx, true /* includePersisted */)
 			}
-			return newZeroNode(nil /* columns */), p.sqlCursors.closeCursor(n.Name)
+			return newZeroNode(nil /* columns */), p.sqlCursors.closeCursor(ctx, n.Name)
 		},
 	}, nil
 }
@@ -271,6 +282,10 @@ type sqlCursor struct {
 	created    time.Time
 	curRow     int64
 	withHold   bool
+	// persisted is true once the transaction which declared a cursor WITH
+	// HOLD has committed, and the remaining rows of the cursor have been
+	// materialized. The cursor then no longer uses txn.
+	persisted bool
 }
 
 // Next implements the Rows interface.
@@ -282,15 +297,136 @@ func (s *sqlCursor) Next(ctx context.Context) (bool, error) {
 	return more, err
 }
 
+// close closes the cursor. Unlike Close, it releases the rows of a persisted
+// cursor WITH HOLD with the given context.
+func (s *sqlCursor) close(ctx context.Context) error {
+	if held, ok := s.Rows.(*heldCursorRows); ok {
+		return held.close(ctx)
+	}
+	return s.Rows.Close()
+}
+
+// persist materializes the remaining rows of a cursor WITH HOLD into a
+// disk-backed row container, before the transaction which declared it commits.
+// The memory used by the rows is accounted against parentMon.
+func (s *sqlCursor) persist(
+	ctx context.Context, evalCtx *extendedEvalContext, parentMon *mon.BytesMonitor,
+) (retErr error) {
+	// Read the rows at the sequence number at which the cursor was declared,
+	// as FETCH does.
+	origTxnSeqNum := s.txn.GetReadSeqNum()
+	if err := s.txn.SetReadSeqNum(s.readSeqNum); err != nil {
+		return err
+	}
+	defer func() {
+		if err := s.txn.SetReadSeqNum(origTxnSeqNum); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+
+	held := &heldCursorRows{cols: s.Rows.Types()}
+	if cur := s.Rows.Cur(); cur != nil {
+		held.cur = append(tree.Datums(nil), cur...)
+	}
+	typs := make([]*types.T, len(held.cols))
+	for i := range held.cols {
+		typs[i] = held.cols[i].Typ
+	}
+	held.rows.InitWithParentMon(ctx, typs, parentMon, evalCtx, "held-cursor")
+	for {
+		more, err := s.Rows.Next(ctx)
+		if err != nil {
+			_ = held.close(ctx)
+			return err
+		}
+		if !more {
+			break
+		}
+		if err := held.rows.AddRow(ctx, s.Rows.Cur()); err != nil {
+			_ = held.close(ctx)
+			return err
+		}
+	}
+	if err := s.Rows.Close(); err != nil {
+		_ = held.close(ctx)
+		return err
+	}
+	held.iter = newRowContainerIterator(ctx, held.rows)
+	s.Rows = held
+	s.txn = nil
+	s.persisted = true
+	return nil
+}
+
+// heldCursorRows is an isql.Rows over the materialized rows of a persisted
+// cursor WITH HOLD.
+type heldCursorRows struct {
+	cols colinfo.ResultColumns
+	rows rowContainerHelper
+	iter *rowContainerIterator
+	cur  tree.Datums
+	// numRows is the number of rows returned so far.
+	numRows int
+}
+
+var _ isql.Rows = &heldCursorRows{}
+
+// Next implements the isql.Rows interface.
+func (h *heldCursorRows) Next(ctx context.Context) (bool, error) {
+	if h.iter == nil {
+		return false, nil
+	}
+	row, err := h.iter.Next()
+	if err != nil {
+		return false, err
+	}
+	if row == nil {
+		// Release the container as soon as the rows are exhausted.
+		return false, h.close(ctx)
+	}
+	h.cur = make(tree.Datums, len(row))
+	copy(h.cur, row)
+	h.numRows++
+	return true, nil
+}
+
+// Cur implements the isql.Rows interface.
+func (h *heldCursorRows) Cur() tree.Datums { return h.cur }
+
+// RowsAffected implements the isql.Rows interface.
+func (h *heldCursorRows) RowsAffected() int { return h.numRows }
+
+// Close implements the isql.Rows interface. The cursor code uses close instead,
+// which takes the caller's context.
+func (h *heldCursorRows) Close() error {
+	return h.close(context.TODO())
+}
+
+// close releases the iterator and the row container.
+func (h *heldCursorRows) close(ctx context.Context) error {
+	if h.iter != nil {
+		h.iter.Close()
+		h.iter = nil
+	}
+	h.rows.Close(ctx)
+	return nil
+}
+
+// Types implements the isql.Rows interface.
+func (h *heldCursorRows) Types() colinfo.ResultColumns { return h.cols }
+
+// HasResults implements the isql.Rows interface.
+func (h *heldCursorRows) HasResults() bool { return h.cur != nil }
+
 // sqlCursors contains a set of active cursors for a session.
 type sqlCursors interface {
-	// closeAll closes all cursors in the set. If any of the cursors were
-	// created WITH HOLD, and the errorOnWithHold flag is true, an error is
-	// returned.
-	closeAll(errorOnWithHold bool) error
+	// closeAll closes all cursors in the set. Persisted cursors WITH HOLD are
+	// only closed if includePersisted is true, since they outlive the
+	// transaction which declared them.
+	closeAll(ctx context.Context, includePersisted bool) error
 	// closeCursor closes the named cursor, returning an error if that cursor
 	// didn't exist in the set.
-	closeCursor(tree.Name) error
+	closeCursor(context.Context, tree.Name) error
 	// getCursor returns the named cursor, returning nil if that cursor
 	// didn't exist in the set.
 	getCursor(tree.Name) *sqlCursor
@@ -306,25 +442,44 @@ type cursorMap struct {
 	cursors map[tree.Name]*sqlCursor
 }
 
-func (c *cursorMap) closeAll(errorOnWithHold bool) error {
-	for n, c := range c.cursors {
-		if c.withHold && errorOnWithHold {
-			return unimplemented.NewWithIssuef(77101, "cursor %s WITH HOLD must be closed before committing", n)
+func (c *cursorMap) closeAll(ctx context.Context, includePersisted bool) error {
+	for n, cursor := range c.cursors {
+		if cursor.persisted && !includePersisted {
+			continue
 		}
-		if err := c.Close(); err != nil {
+		delete(c.cursors, n)
+		if err := cursor.close(ctx); err != nil {
 			return err
 		}
 	}
-	c.cursors = nil
 	return nil
 }
 
-func (c *cursorMap) closeCursor(s tree.Name) error {
+// persistHeldCursors persists the cursors WITH HOLD declared by the current
+// transaction, which is about to commit, so that they remain readable for the
+// lifetime of the session. It returns the names of the persisted cursors, which
+// must be closed if the transaction fails to commit after all.
+func (c *cursorMap) persistHeldCursors(
+	ctx context.Context, evalCtx *extendedEvalContext, parentMon *mon.BytesMonitor,
+) (persisted []tree.Name, _ error) {
+	for n, cursor := range c.cursors {
+		if !cursor.withHold || cursor.persisted {
+			continue
+		}
+		persisted = append(persisted, n)
+		if err := cursor.persist(ctx, evalCtx, parentMon); err != nil {
+			return persisted, err
+		}
+	}
+	return persisted, nil
+}
+
+func (c *cursorMap) closeCursor(ctx context.Context, s tree.Name) error {
 	cursor, ok := c.cursors[s]
 	if !ok {
 		return pgerror.Newf(pgcode.InvalidCursorName, "cursor %q does not exist", s)
 	}
-	err := cursor.Close()
+	err := cursor.close(ctx)
 	delete(c.cursors, s)
 	return err
 }
@@ -354,12 +509,12 @@ type connExCursorAccessor struct {
 	ex *connExecutor
 }
 
-func (c connExCursorAccessor) closeAll(errorOnWithHold bool) error {
-	return c.ex.extraTxnState.sqlCursors.closeAll(errorOnWithHold)
+func (c connExCursorAccessor) closeAll(ctx context.Context, includePersisted bool) error {
+	return c.ex.extraTxnState.sqlCursors.closeAll(ctx, includePersisted)
 }
 
-func (c connExCursorAccessor) closeCursor(s tree.Name) error {
-	return c.ex.extraTxnState.sqlCursors.closeCursor(s)
+func (c connExCursorAccessor) closeCursor(ctx context.Context, s tree.Name) error {
+	return c.ex.extraTxnState.sqlCursors.closeCursor(ctx, s)
 }
 
 func (c connExCursorAccessor) getCursor(s tree.Name) *sqlCursor {
@@ -383,9 +538,12 @@ func (p *planner) checkNoConflictingCursors(stmt tree.Statement) error {
 	// We could improve this by matching the memo metadata's list of dependent
 	// schema objects in each open cursor with the objects being changed in the
 	// schema change.
-	if len(p.sqlCursors.list()) > 0 {
-		return unimplemented.NewWithIssue(74608, "cannot run schema change "+
-			"in a transaction with open DECLARE cursors")
+	// Persisted cursors WITH HOLD no longer read from the database.
+	for _, c := range p.sqlCursors.list() {
+		if !c.persisted {
+			return unimplemented.NewWithIssue(74608, "cannot run schema change "+
+				"in a transaction with open DECLARE cursors")
+		}
 	}
 	return nil
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/errors"
 )
 
@@ -192,6 +193,14 @@ func NewUnsupportedUnvalidatedConstraintError(constraintType catconstants.Constr
 		"%v constraints cannot be marked NOT VALID", constraintType)
 }
 
+// NewUnsupportedDeferrableUniqueIndexError returns an error for a DEFERRABLE
+// unique constraint that is backed by an index. Uniqueness is enforced by the
+// index when a row is written, so such a constraint cannot be deferred.
+func NewUnsupportedDeferrableUniqueIndexError() error {
+	return unimplemented.NewWithIssueDetail(31632, "deferrable unique index",
+		"deferrable unique constraints are only supported for UNIQUE WITHOUT INDEX constraints")
+}
+
 // WrapErrorWhileConstructingObjectAlreadyExistsErr is used to wrap an error
 // when an error occurs while trying to get the colliding object for an
 // ObjectAlreadyExistsErr.
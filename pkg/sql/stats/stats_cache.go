@@ -284,6 +284,11 @@ func tableTypeCanHaveStats(table catalog.TableDescriptor) bool {
 		// Don't try to get statistics for views.
 		return false
 	}
+	if table.IsForeignTable() {
+		// Don't try to get statistics for foreign tables, whose rows are not
+		// stored in the KV layer.
+		return false
+	}
 	return true
 }
 
@@ -74,6 +74,12 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_int8:       Int,
 	oid.T_inet:       INet,
 	oid.T_interval:   Interval,
+	oid.T_int4range:  Int4Range,
+	oid.T_int8range:  Int8Range,
+	oid.T_numrange:   NumRange,
+	oid.T_tsrange:    TSRange,
+	oid.T_tstzrange:  TSTZRange,
+	oid.T_daterange:  DateRange,
 	// NOTE(sql-exp): Uncomment the line below if we support the JSON type.
 	// This would potentially require us to convert the type descriptors of
 	// existing tables.
@@ -95,6 +101,7 @@ var OidToType = map[oid.Oid]*T{
 	oid.T_timetz:       TimeTZ,
 	oid.T_timestamp:    Timestamp,
 	oid.T_timestamptz:  TimestampTZ,
+	oid.T_trigger:      Trigger,
 	oid.T_tsquery:      TSQuery,
 	oid.T_tsvector:     TSVector,
 	oid.T_unknown:      Unknown,
@@ -106,6 +113,13 @@ var OidToType = map[oid.Oid]*T{
 	oidext.T_geometry:  Geometry,
 	oidext.T_geography: Geography,
 	oidext.T_box2d:     Box2D,
+
+	oidext.T_int4multirange: Int4Multirange,
+	oidext.T_int8multirange: Int8Multirange,
+	oidext.T_nummultirange:  NumMultirange,
+	oidext.T_tsmultirange:   TSMultirange,
+	oidext.T_tstzmultirange: TSTZMultirange,
+	oidext.T_datemultirange: DateMultirange,
 }
 
 // oidToArrayOid maps scalar type Oids to their corresponding array type Oid.
@@ -125,6 +139,12 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oid.T_int4:         oid.T__int4,
 	oid.T_int8:         oid.T__int8,
 	oid.T_interval:     oid.T__interval,
+	oid.T_int4range:    oid.T__int4range,
+	oid.T_int8range:    oid.T__int8range,
+	oid.T_numrange:     oid.T__numrange,
+	oid.T_tsrange:      oid.T__tsrange,
+	oid.T_tstzrange:    oid.T__tstzrange,
+	oid.T_daterange:    oid.T__daterange,
 	oid.T_jsonb:        oid.T__jsonb,
 	oid.T_name:         oid.T__name,
 	oid.T_numeric:      oid.T__numeric,
@@ -151,6 +171,35 @@ var oidToArrayOid = map[oid.Oid]oid.Oid{
 	oidext.T_geometry:  oidext.T__geometry,
 	oidext.T_geography: oidext.T__geography,
 	oidext.T_box2d:     oidext.T__box2d,
+
+	oidext.T_int4multirange: oidext.T__int4multirange,
+	oidext.T_int8multirange: oidext.T__int8multirange,
+	oidext.T_nummultirange:  oidext.T__nummultirange,
+	oidext.T_tsmultirange:   oidext.T__tsmultirange,
+	oidext.T_tstzmultirange: oidext.T__tstzmultirange,
+	oidext.T_datemultirange: oidext.T__datemultirange,
+}
+
+// rangeOidToSubtype maps the Oids of range types to the type of the values in
+// the range.
+var rangeOidToSubtype = map[oid.Oid]*T{
+	oid.T_int4range: Int4,
+	oid.T_int8range: Int,
+	oid.T_numrange:  Decimal,
+	oid.T_tsrange:   Timestamp,
+	oid.T_tstzrange: TimestampTZ,
+	oid.T_daterange: Date,
+}
+
+// multirangeOidToRangeOid maps the Oids of multirange types to the Oids of the
+// ranges they contain.
+var multirangeOidToRangeOid = map[oid.Oid]oid.Oid{
+	oidext.T_int4multirange: oid.T_int4range,
+	oidext.T_int8multirange: oid.T_int8range,
+	oidext.T_nummultirange:  oid.T_numrange,
+	oidext.T_tsmultirange:   oid.T_tsrange,
+	oidext.T_tstzmultirange: oid.T_tstzrange,
+	oidext.T_datemultirange: oid.T_daterange,
 }
 
 // familyToOid maps each type family to a default OID value that is used when
@@ -179,6 +228,8 @@ var familyToOid = map[Family]oid.Oid{
 	TSQueryFamily:        oid.T_tsquery,
 	TSVectorFamily:       oid.T_tsvector,
 	TupleFamily:          oid.T_record,
+	RangeFamily:          oid.T_int8range,
+	MultirangeFamily:     oidext.T_int8multirange,
 	BitFamily:            oid.T_bit,
 	AnyFamily:            oid.T_anyelement,
 
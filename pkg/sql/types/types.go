@@ -203,6 +203,27 @@ type UserDefinedTypeMetadata struct {
 	// for a table. Note: this can be deleted if we migrate implicit record types
 	// to ordinary persisted composite types.
 	ImplicitRecordType bool
+
+	// DomainData is non-nil iff the metadata is for a domain type.
+	DomainData *DomainMetadata
+}
+
+// DomainMetadata is metadata about a DOMAIN needed to enforce its constraints.
+type DomainMetadata struct {
+	// NotNull is true if the domain does not allow NULL values.
+	NotNull bool
+	// DefaultExpr is the serialized default expression of the domain, or nil
+	// if the domain has no default.
+	DefaultExpr *string
+	// Checks are the CHECK constraints of the domain. Within each expression,
+	// the VALUE keyword refers to the value being checked.
+	Checks []DomainCheck
+}
+
+// DomainCheck is a CHECK constraint on a domain.
+type DomainCheck struct {
+	Name string
+	Expr string
 }
 
 // EnumMetadata is metadata about an ENUM needed for evaluation.
@@ -467,6 +488,15 @@ var (
 		},
 	}
 
+	// Trigger is the pseudo-type returned by trigger functions.
+	Trigger = &T{
+		InternalType: InternalType{
+			Family: TriggerFamily,
+			Oid:    oid.T_trigger,
+			Locale: &emptyLocale,
+		},
+	}
+
 	// EncodedKey is a special type used internally for passing encoded key data.
 	// It behaves similarly to Bytes in most circumstances, except
 	// encoding/decoding. It is currently used to pass around inverted index keys,
@@ -498,6 +528,64 @@ var (
 		},
 	}
 
+	// Int4Range is the type of a range of INT4 values.
+	Int4Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int4range, Locale: &emptyLocale}}
+
+	// Int8Range is the type of a range of INT8 values.
+	Int8Range = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_int8range, Locale: &emptyLocale}}
+
+	// NumRange is the type of a range of DECIMAL values.
+	NumRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_numrange, Locale: &emptyLocale}}
+
+	// TSRange is the type of a range of TIMESTAMP values.
+	TSRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tsrange, Locale: &emptyLocale}}
+
+	// TSTZRange is the type of a range of TIMESTAMPTZ values.
+	TSTZRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_tstzrange, Locale: &emptyLocale}}
+
+	// DateRange is the type of a range of DATE values.
+	DateRange = &T{InternalType: InternalType{
+		Family: RangeFamily, Oid: oid.T_daterange, Locale: &emptyLocale}}
+
+	// Int4Multirange is the type of a multirange of INT4RANGE values.
+	Int4Multirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_int4multirange, Locale: &emptyLocale}}
+
+	// Int8Multirange is the type of a multirange of INT8RANGE values.
+	Int8Multirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_int8multirange, Locale: &emptyLocale}}
+
+	// NumMultirange is the type of a multirange of NUMRANGE values.
+	NumMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_nummultirange, Locale: &emptyLocale}}
+
+	// TSMultirange is the type of a multirange of TSRANGE values.
+	TSMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_tsmultirange, Locale: &emptyLocale}}
+
+	// TSTZMultirange is the type of a multirange of TSTZRANGE values.
+	TSTZMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_tstzmultirange, Locale: &emptyLocale}}
+
+	// DateMultirange is the type of a multirange of DATERANGE values.
+	DateMultirange = &T{InternalType: InternalType{
+		Family: MultirangeFamily, Oid: oidext.T_datemultirange, Locale: &emptyLocale}}
+
+	// RangeTypes contains all the range types, in the same order as the
+	// multirange types in MultirangeTypes.
+	RangeTypes = []*T{Int4Range, Int8Range, NumRange, TSRange, TSTZRange, DateRange}
+
+	// MultirangeTypes contains all the multirange types, in the same order as
+	// the range types in RangeTypes.
+	MultirangeTypes = []*T{
+		Int4Multirange, Int8Multirange, NumMultirange, TSMultirange, TSTZMultirange, DateMultirange,
+	}
+
 	// Scalar contains all types that meet this criteria:
 	//
 	//   1. Scalar type (no ArrayFamily or TupleFamily types).
@@ -1125,6 +1213,17 @@ func MakeEnum(typeOID, arrayTypeOID oid.Oid) *T {
 	}}
 }
 
+// MakeDomain constructs a new instance of a domain over the given base type.
+// The returned type shares the representation of the base type; only the
+// domain OID is recorded. Note that it does not hydrate cached fields on the
+// type.
+func MakeDomain(base *T, domainOID oid.Oid) *T {
+	typ := *base
+	typ.InternalType.DomainOID = domainOID
+	typ.TypeMeta = UserDefinedTypeMetadata{}
+	return &typ
+}
+
 // MakeArray constructs a new instance of an ArrayFamily type with the given
 // element type (which may itself be an ArrayFamily type).
 func MakeArray(typ *T) *T {
@@ -1383,6 +1482,39 @@ func (t *T) ArrayContents() *T {
 	return t.InternalType.ArrayContents
 }
 
+// RangeContents returns the subtype of a range, or of the ranges in a
+// multirange. This is nil for types that are not in the RangeFamily or
+// MultirangeFamily.
+func (t *T) RangeContents() *T {
+	switch t.Family() {
+	case RangeFamily:
+		return rangeOidToSubtype[t.Oid()]
+	case MultirangeFamily:
+		return rangeOidToSubtype[multirangeOidToRangeOid[t.Oid()]]
+	}
+	return nil
+}
+
+// MultirangeContents returns the range type of the ranges in a multirange.
+// This is nil for types that are not in the MultirangeFamily.
+func (t *T) MultirangeContents() *T {
+	if t.Family() != MultirangeFamily {
+		return nil
+	}
+	return OidToType[multirangeOidToRangeOid[t.Oid()]]
+}
+
+// MakeMultirange returns the multirange type whose ranges have the given range
+// type.
+func MakeMultirange(rangeTyp *T) *T {
+	for i, typ := range RangeTypes {
+		if typ.Oid() == rangeTyp.Oid() {
+			return MultirangeTypes[i]
+		}
+	}
+	panic(errors.AssertionFailedf("unexpected range type: %s", rangeTyp))
+}
+
 // TupleContents returns a slice containing the type of each tuple field. This
 // is nil for non-TupleFamily types.
 func (t *T) TupleContents() []*T {
@@ -1421,6 +1553,25 @@ func RemapUserDefinedTypeOIDs(t *T, newOID, newArrayOID oid.Oid) {
 	}
 }
 
+// DomainOID returns the OID of the domain type if t is a domain, and 0
+// otherwise. The other properties of t, including Oid, describe the base type
+// of the domain.
+func (t *T) DomainOID() oid.Oid {
+	return t.InternalType.DomainOID
+}
+
+// DomainBaseType returns the base type of t if t is a domain, and t itself
+// otherwise.
+func (t *T) DomainBaseType() *T {
+	if t.DomainOID() == 0 {
+		return t
+	}
+	base := *t
+	base.InternalType.DomainOID = 0
+	base.TypeMeta = UserDefinedTypeMetadata{}
+	return &base
+}
+
 // UserDefined returns whether or not t is a user defined type.
 func (t *T) UserDefined() bool {
 	return IsOIDUserDefinedType(t.Oid())
@@ -1450,7 +1601,9 @@ var familyNames = map[Family]string{
 	IntFamily:            "int",
 	IntervalFamily:       "interval",
 	JsonFamily:           "jsonb",
+	MultirangeFamily:     "multirange",
 	OidFamily:            "oid",
+	RangeFamily:          "range",
 	StringFamily:         "string",
 	TimeFamily:           "time",
 	TimestampFamily:      "timestamp",
@@ -1458,6 +1611,7 @@ var familyNames = map[Family]string{
 	TimeTZFamily:         "timetz",
 	TSQueryFamily:        "tsquery",
 	TSVectorFamily:       "tsvector",
+	TriggerFamily:        "trigger",
 	TupleFamily:          "tuple",
 	UnknownFamily:        "unknown",
 	UuidFamily:           "uuid",
@@ -1528,6 +1682,9 @@ func (t *T) Name() string {
 	case OidFamily:
 		return t.SQLStandardName()
 
+	case RangeFamily, MultirangeFamily:
+		return t.PGName()
+
 	case StringFamily, CollatedStringFamily:
 		switch t.Oid() {
 		case oid.T_text:
@@ -1718,6 +1875,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		default:
 			panic(errors.AssertionFailedf("unexpected Oid: %v", errors.Safe(t.Oid())))
 		}
+	case RangeFamily, MultirangeFamily:
+		return t.PGName()
 	case StringFamily, CollatedStringFamily:
 		switch t.Oid() {
 		case oid.T_text:
@@ -1791,6 +1950,8 @@ func (t *T) SQLStandardNameWithTypmod(haveTypmod bool, typmod int) string {
 		return "uuid"
 	case VoidFamily:
 		return "void"
+	case TriggerFamily:
+		return "trigger"
 	case EnumFamily:
 		return t.TypeMeta.Name.Basename()
 	default:
@@ -1809,7 +1970,8 @@ func (t *T) InformationSchemaName() string {
 		return "ARRAY"
 	}
 	// TypeMeta attributes are populated only when it is user defined type.
-	if t.TypeMeta.Name != nil {
+	// Domains report the name of their base type.
+	if t.TypeMeta.Name != nil && t.DomainOID() == 0 {
 		return "USER-DEFINED"
 	}
 	return t.SQLStandardName()
@@ -1819,6 +1981,9 @@ func (t *T) InformationSchemaName() string {
 // reproduce the type via parsing the string as a type. It is used in error
 // messages and also to produce the output of SHOW CREATE.
 func (t *T) SQLString() string {
+	if t.DomainOID() != 0 && t.TypeMeta.Name != nil {
+		return t.TypeMeta.Name.FQName()
+	}
 	switch t.Family() {
 	case BitFamily:
 		o := t.Oid()
@@ -1951,7 +2116,7 @@ func (t *T) SQLStringForError() redact.RedactableString {
 		IntervalFamily, StringFamily, BytesFamily, TimestampTZFamily, CollatedStringFamily, OidFamily,
 		UnknownFamily, UuidFamily, INetFamily, TimeFamily, JsonFamily, TimeTZFamily, BitFamily,
 		GeometryFamily, GeographyFamily, Box2DFamily, VoidFamily, EncodedKeyFamily, TSQueryFamily,
-		TSVectorFamily, AnyFamily:
+		TSVectorFamily, RangeFamily, MultirangeFamily, TriggerFamily, AnyFamily:
 		// These types do not contain other types, and do not require redaction.
 		return redact.Sprint(redact.SafeString(t.SQLString()))
 	}
@@ -2017,6 +2182,11 @@ func (t *T) Equivalent(other *T) bool {
 			return false
 		}
 
+	case RangeFamily, MultirangeFamily:
+		if !t.RangeContents().Equivalent(other.RangeContents()) {
+			return false
+		}
+
 	case EnumFamily:
 		// If one of the types is anyenum, then allow the comparison to
 		// go through -- anyenum is used when matching overloads.
@@ -2691,6 +2861,8 @@ func IsValidArrayElementType(t *T) (valid bool, issueNum int) {
 		return false, 90886
 	case TSVectorFamily:
 		return false, 90886
+	case RangeFamily, MultirangeFamily:
+		return false, 0
 	default:
 		return true, 0
 	}
@@ -13,17 +13,20 @@ package sql
 import (
 	"context"
 
-	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 )
 
+// Unlisten stops listening for notifications on a channel, or on all channels
+// for UNLISTEN *.
 func (p *planner) Unlisten(ctx context.Context, n *tree.Unlisten) (planNode, error) {
-	p.BufferClientNotice(ctx,
-		pgerror.WithSeverity(
-			unimplemented.NewWithIssuef(41522, "CRDB does not support LISTEN, making UNLISTEN a no-op"),
-			"NOTICE",
-		),
-	)
+	if n.Star {
+		p.listenChannels.unlistenAll()
+		return newZeroNode(nil /* columns */), nil
+	}
+	channel, err := channelName(n.ChannelName)
+	if err != nil {
+		return nil, err
+	}
+	p.listenChannels.unlisten(channel)
 	return newZeroNode(nil /* columns */), nil
 }
@@ -24,6 +24,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -569,6 +570,92 @@ func BenchmarkIntentResolution(b *testing.B) {
 	}
 }
 
+// BenchmarkLockResolution benchmarks intent resolution for individual keys,
+// when the transaction holds intents and replicated locks of various
+// strengths on them, or no locks at all.
+func BenchmarkLockResolution(b *testing.B) {
+	defer log.Scope(b).Close(b)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name string
+		strs []lock.Strength
+	}{
+		{"none", nil},
+		{"intent", []lock.Strength{lock.Intent}},
+		{"shared", []lock.Strength{lock.Shared}},
+		{"exclusive", []lock.Strength{lock.Exclusive}},
+		{"shared+intent", []lock.Strength{lock.Shared, lock.Intent}},
+	} {
+		b.Run(fmt.Sprintf("locks=%s", tc.name), func(b *testing.B) {
+			eng := setupMVCCInMemPebbleWithSeparatedIntents(b)
+			defer eng.Close()
+			ts := hlc.Timestamp{WallTime: 1}
+			txn := roachpb.Transaction{
+				TxnMeta: enginepb.TxnMeta{
+					ID:             uuid.FromUint128(uint128.FromInts(0, 1)),
+					Key:            []byte("foo"),
+					WriteTimestamp: ts,
+					MinTimestamp:   ts,
+				},
+				Status:                 roachpb.PENDING,
+				ReadTimestamp:          ts,
+				GlobalUncertaintyLimit: ts,
+			}
+			keys := make([]roachpb.Key, numIntentKeys)
+			value := roachpb.MakeValueFromString("value")
+			batch := eng.NewBatch()
+			for i := range keys {
+				keys[i] = makeKey(nil, i)
+				require.NoError(b, MVCCPut(ctx, batch, nil, keys[i], ts, hlc.ClockTimestamp{}, value, nil))
+			}
+			require.NoError(b, batch.Commit(true))
+			batch.Close()
+			require.NoError(b, eng.Flush())
+
+			txn.WriteTimestamp = ts.Next()
+			batch = eng.NewBatch()
+			for _, key := range keys {
+				for _, str := range tc.strs {
+					if str == lock.Intent {
+						require.NoError(b, MVCCPut(ctx, batch, nil, key, txn.WriteTimestamp, hlc.ClockTimestamp{}, value, &txn))
+					} else {
+						require.NoError(b, MVCCAcquireLock(ctx, batch, &txn, str, key))
+					}
+				}
+			}
+			require.NoError(b, batch.Commit(true))
+			batch.Close()
+
+			lockUpdate := roachpb.LockUpdate{
+				Txn:    txn.TxnMeta,
+				Status: roachpb.COMMITTED,
+			}
+			expFound := len(tc.strs) > 0
+			batch = eng.NewBatch()
+			defer func() { batch.Close() }()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if i > 0 && i%numIntentKeys == 0 {
+					// Wrapped around.
+					b.StopTimer()
+					batch.Close()
+					batch = eng.NewBatch()
+					b.StartTimer()
+				}
+				lockUpdate.Key = keys[i%numIntentKeys]
+				found, _, _, err := MVCCResolveWriteIntent(ctx, batch, nil, lockUpdate, MVCCResolveWriteIntentOptions{})
+				if err != nil {
+					b.Fatal(err)
+				}
+				if found != expFound {
+					b.Fatalf("expected found=%t, got %t", expFound, found)
+				}
+			}
+		})
+	}
+}
+
 // BenchmarkIntentRangeResolution benchmarks ranged intent resolution with
 // various counts of mvcc versions and sparseness of intents.
 func BenchmarkIntentRangeResolution(b *testing.B) {
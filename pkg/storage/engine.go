@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
@@ -653,6 +654,13 @@ type Writer interface {
 	// decrease, we can stop tracking txnDidNotUpdateMeta and still optimize
 	// ClearIntent by always doing single-clear.
 	ClearIntent(key roachpb.Key, txnDidNotUpdateMeta bool, txnUUID uuid.UUID) error
+	// ClearLock removes a replicated, non-intent lock of the given strength
+	// held by the given transaction on the key from the db. Like ClearIntent,
+	// this is a higher-level method that makes changes in the lock table key
+	// space.
+	//
+	// It is safe to modify the contents of the arguments after it returns.
+	ClearLock(key roachpb.Key, str lock.Strength, txnUUID uuid.UUID) error
 	// ClearEngineKey removes the given point key from the engine. It does not
 	// affect range keys.  Note that clear actually removes entries from the
 	// storage engine. This is a general-purpose and low-level method that should
@@ -792,6 +800,13 @@ type Writer interface {
 	//
 	// It is safe to modify the contents of the arguments after Put returns.
 	PutIntent(ctx context.Context, key roachpb.Key, value []byte, txnUUID uuid.UUID) error
+	// PutLock puts a replicated, non-intent lock of the given strength held by
+	// the given transaction on the key, with the value provided. Like
+	// PutIntent, this is a higher-level method that makes changes in the lock
+	// table key space. Intents must be written using PutIntent.
+	//
+	// It is safe to modify the contents of the arguments after it returns.
+	PutLock(key roachpb.Key, str lock.Strength, txnUUID uuid.UUID, value []byte) error
 	// PutEngineKey sets the given key to the value provided. This is a
 	// general-purpose and low-level method that should be used sparingly,
 	// only when the other Put* methods are not applicable.
@@ -1223,6 +1238,7 @@ type EncryptionRegistries struct {
 // key, it will return nil rather than an error. Errors are returned for problem
 // at the storage layer, problem decoding the key, problem unmarshalling the
 // intent, missing transaction on the intent or multiple intents for this key.
+// Replicated locks on the key that are not intents are ignored.
 func GetIntent(reader Reader, key roachpb.Key) (*roachpb.Intent, error) {
 	// Translate this key from a regular key to one in the lock space so it can be
 	// used for queries.
@@ -1231,55 +1247,49 @@ func GetIntent(reader Reader, key roachpb.Key) (*roachpb.Intent, error) {
 	iter := reader.NewEngineIterator(IterOptions{Prefix: true, LowerBound: lbKey})
 	defer iter.Close()
 
-	valid, err := iter.SeekEngineKeyGE(EngineKey{Key: lbKey})
-	if err != nil {
-		return nil, err
-	}
-	if !valid {
-		return nil, nil
-	}
-
-	engineKey, err := iter.EngineKey()
-	if err != nil {
-		return nil, err
-	}
-	checkKey, err := keys.DecodeLockTableSingleKey(engineKey.Key)
-	if err != nil {
-		return nil, err
-	}
-	if !checkKey.Equal(key) {
-		// This should not be possible, a key and using prefix match means that it
-		// must match.
-		return nil, errors.AssertionFailedf("key does not match expected %v != %v", checkKey, key)
-	}
-	var meta enginepb.MVCCMetadata
-	v, err := iter.UnsafeValue()
-	if err != nil {
-		return nil, err
-	}
-	if err = protoutil.Unmarshal(v, &meta); err != nil {
-		return nil, err
-	}
-	if meta.Txn == nil {
-		return nil, errors.AssertionFailedf("txn is null for key %v, intent %v", key, meta)
-	}
-	intent := roachpb.MakeIntent(meta.Txn, key)
-
-	hasNext, err := iter.NextEngineKey()
-	if err != nil {
-		// We expect false on the call to next, but not an error.
-		return nil, err
-	}
-	// This should not be possible. There can only be one outstanding write
-	// intent for a key and with prefix match we don't find additional names.
-	if hasNext {
+	var intent *roachpb.Intent
+	var valid bool
+	var err error
+	for valid, err = iter.SeekEngineKeyGE(EngineKey{Key: lbKey}); valid; valid, err = iter.NextEngineKey() {
 		engineKey, err := iter.EngineKey()
 		if err != nil {
 			return nil, err
 		}
-		return nil, errors.AssertionFailedf("unexpected additional key found %v while looking for %v", engineKey, key)
+		ltKey, err := engineKey.ToLockTableKey()
+		if err != nil {
+			return nil, err
+		}
+		if !ltKey.Key.Equal(key) {
+			// This should not be possible, a key and using prefix match means that
+			// it must match.
+			return nil, errors.AssertionFailedf("key does not match expected %v != %v", ltKey.Key, key)
+		}
+		if ltKey.Strength != lock.Intent {
+			continue
+		}
+		// This should not be possible. There can only be one outstanding write
+		// intent for a key and with prefix match we don't find additional names.
+		if intent != nil {
+			return nil, errors.AssertionFailedf("unexpected additional key found %v while looking for %v", engineKey, key)
+		}
+		var meta enginepb.MVCCMetadata
+		v, err := iter.UnsafeValue()
+		if err != nil {
+			return nil, err
+		}
+		if err = protoutil.Unmarshal(v, &meta); err != nil {
+			return nil, err
+		}
+		if meta.Txn == nil {
+			return nil, errors.AssertionFailedf("txn is null for key %v, intent %v", key, meta)
+		}
+		i := roachpb.MakeIntent(meta.Txn, key)
+		intent = &i
+	}
+	if err != nil {
+		return nil, err
 	}
-	return &intent, nil
+	return intent, nil
 }
 
 // Scan returns up to max point key/value objects from start (inclusive) to end
@@ -1336,10 +1346,15 @@ func ScanIntents(
 		if err != nil {
 			return nil, err
 		}
-		lockedKey, err := keys.DecodeLockTableSingleKey(key.Key)
+		ltKey, err := key.ToLockTableKey()
 		if err != nil {
 			return nil, err
 		}
+		if ltKey.Strength != lock.Intent {
+			// Replicated locks that are not intents are not returned.
+			continue
+		}
+		lockedKey := ltKey.Key
 		v, err := iter.UnsafeValue()
 		if err != nil {
 			return nil, err
@@ -1837,6 +1852,19 @@ func ScanConflictingIntentsForDroppingLatchesEarly(
 			// not needing intent history.
 			return true /* needsIntentHistory */, nil
 		}
+		key, err := iter.EngineKey()
+		if err != nil {
+			return false, err
+		}
+		ltKey, err := key.ToLockTableKey()
+		if err != nil {
+			return false, err
+		}
+		if ltKey.Strength != lock.Intent {
+			// Replicated locks that are not intents do not conflict with
+			// non-locking reads.
+			continue
+		}
 		v, err := iter.UnsafeValue()
 		if err != nil {
 			return false, err
@@ -1884,15 +1912,7 @@ func ScanConflictingIntentsForDroppingLatchesEarly(
 		if conflictingIntent := meta.Timestamp.ToTimestamp().LessEq(ts); !conflictingIntent {
 			continue
 		}
-		key, err := iter.EngineKey()
-		if err != nil {
-			return false, err
-		}
-		lockedKey, err := keys.DecodeLockTableSingleKey(key.Key)
-		if err != nil {
-			return false, err
-		}
-		*intents = append(*intents, roachpb.MakeIntent(meta.Txn, lockedKey))
+		*intents = append(*intents, roachpb.MakeIntent(meta.Txn, ltKey.Key))
 	}
 	if err != nil {
 		return false, err
@@ -175,9 +175,9 @@ func (k EngineKey) ToLockTableKey() (LockTableKey, error) {
 	key := LockTableKey{Key: lockedKey}
 	switch len(k.Version) {
 	case engineKeyVersionLockTableLen:
-		key.Strength = lock.Strength(k.Version[0])
-		if key.Strength < lock.None || key.Strength > lock.Exclusive {
-			return LockTableKey{}, errors.Errorf("unknown strength %d", key.Strength)
+		key.Strength, err = getReplicatedLockStrengthForByte(k.Version[0])
+		if err != nil {
+			return LockTableKey{}, err
 		}
 		key.TxnUUID = k.Version[1:]
 	default:
@@ -260,6 +260,50 @@ type LockTableKey struct {
 	TxnUUID []byte
 }
 
+// replicatedLockStrengthToByte is a mapping between lock.Strength and the
+// strength byte persisted in a lock table key's encoding. Only the lock
+// strengths that can be replicated are included. Note that intents are
+// encoded with the byte value 3, which is the value that lock.Exclusive had
+// when intents were the only kind of replicated lock. This keeps the encoding
+// of existing intents unchanged.
+var replicatedLockStrengthToByte = [...]byte{
+	lock.Shared:    1,
+	lock.Exclusive: 2,
+	lock.Intent:    3,
+}
+
+// byteToReplicatedLockStrength is the inverse of replicatedLockStrengthToByte.
+var byteToReplicatedLockStrength = func() (arr [len(replicatedLockStrengthToByte)]lock.Strength) {
+	for str, b := range replicatedLockStrengthToByte {
+		if b != 0 {
+			arr[b] = lock.Strength(str)
+		}
+	}
+	return arr
+}()
+
+// getByteForReplicatedLockStrength returns the byte used to encode the
+// provided replicated lock strength in a lock table key.
+func getByteForReplicatedLockStrength(str lock.Strength) byte {
+	if int(str) < 0 || int(str) >= len(replicatedLockStrengthToByte) {
+		panic(errors.AssertionFailedf("unexpected lock strength %s", str))
+	}
+	b := replicatedLockStrengthToByte[str]
+	if b == 0 {
+		panic(errors.AssertionFailedf("unsupported replicated lock strength %s", str))
+	}
+	return b
+}
+
+// getReplicatedLockStrengthForByte is the inverse of
+// getByteForReplicatedLockStrength.
+func getReplicatedLockStrengthForByte(b byte) (lock.Strength, error) {
+	if b == 0 || int(b) >= len(byteToReplicatedLockStrength) {
+		return lock.None, errors.Errorf("unknown strength %d", b)
+	}
+	return byteToReplicatedLockStrength[b], nil
+}
+
 // ToEngineKey converts a lock table key to an EngineKey. buf is used as
 // scratch-space to avoid allocations -- its contents will be overwritten and
 // not appended to.
@@ -267,9 +311,7 @@ func (lk LockTableKey) ToEngineKey(buf []byte) (EngineKey, []byte) {
 	if len(lk.TxnUUID) != uuid.Size {
 		panic("invalid TxnUUID")
 	}
-	if lk.Strength != lock.Exclusive {
-		panic("unsupported lock strength")
-	}
+	strByte := getByteForReplicatedLockStrength(lk.Strength)
 	// The first term in estimatedLen is for LockTableSingleKey.
 	estimatedLen :=
 		(len(keys.LocalRangeLockTablePrefix) + len(keys.LockTableSingleKeyInfix) + len(lk.Key) + 3) +
@@ -285,7 +327,7 @@ func (lk LockTableKey) ToEngineKey(buf []byte) (EngineKey, []byte) {
 		// estimatedLen was an underestimate.
 		k.Version = make([]byte, engineKeyVersionLockTableLen)
 	}
-	k.Version[0] = byte(lk.Strength)
+	k.Version[0] = strByte
 	copy(k.Version[1:], lk.TxnUUID)
 	return k, buf
 }
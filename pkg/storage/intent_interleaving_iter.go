@@ -97,8 +97,12 @@ type intentInterleavingIter struct {
 
 	// intentIter is for iterating over separated intents, so that
 	// intentInterleavingIter can make them look as if they were interleaved.
-	intentIter      *pebbleIterator // EngineIterator
+	// Replicated locks that are not intents are skipped over by intentIter.
+	intentIter      intentLockTableIter // EngineIterator
 	intentIterState pebble.IterValidityState
+	// intentSeeked is set if the last seek positioned intentIter. SeekGE using
+	// prefix iteration leaves intentIter unpositioned when it is not needed.
+	intentSeeked bool
 	// The decoded key from the lock table. This is an unsafe key
 	// in that it is only valid when intentIter has not been
 	// repositioned. It is nil if the intentIter is considered to be
@@ -164,6 +168,97 @@ var intentInterleavingIterPool = sync.Pool{
 	},
 }
 
+// intentLockTableIter wraps an EngineIterator over the lock table and skips
+// over all lock table keys that do not belong to intents. The lock table may
+// also contain replicated Shared and Exclusive locks, which have no
+// corresponding provisional value and must not be surfaced as intents.
+//
+// Only the positioning methods used by intentInterleavingIter are overridden.
+type intentLockTableIter struct {
+	*pebbleIterator
+	// skippedLocks is set if a lock table key that does not belong to an intent
+	// was skipped over since the last seek.
+	skippedLocks bool
+}
+
+// SeekEngineKeyGEWithLimit implements the EngineIterator interface.
+func (i *intentLockTableIter) SeekEngineKeyGEWithLimit(
+	key EngineKey, limit roachpb.Key,
+) (pebble.IterValidityState, error) {
+	i.skippedLocks = false
+	state, err := i.pebbleIterator.SeekEngineKeyGEWithLimit(key, limit)
+	return i.skipNonIntents(state, err, +1, limit)
+}
+
+// SeekEngineKeyLTWithLimit implements the EngineIterator interface.
+func (i *intentLockTableIter) SeekEngineKeyLTWithLimit(
+	key EngineKey, limit roachpb.Key,
+) (pebble.IterValidityState, error) {
+	i.skippedLocks = false
+	state, err := i.pebbleIterator.SeekEngineKeyLTWithLimit(key, limit)
+	return i.skipNonIntents(state, err, -1, limit)
+}
+
+// NextEngineKeyWithLimit implements the EngineIterator interface.
+func (i *intentLockTableIter) NextEngineKeyWithLimit(
+	limit roachpb.Key,
+) (pebble.IterValidityState, error) {
+	state, err := i.pebbleIterator.NextEngineKeyWithLimit(limit)
+	return i.skipNonIntents(state, err, +1, limit)
+}
+
+// PrevEngineKeyWithLimit implements the EngineIterator interface.
+func (i *intentLockTableIter) PrevEngineKeyWithLimit(
+	limit roachpb.Key,
+) (pebble.IterValidityState, error) {
+	state, err := i.pebbleIterator.PrevEngineKeyWithLimit(limit)
+	return i.skipNonIntents(state, err, -1, limit)
+}
+
+// skipNonIntents steps the iterator in the provided direction until it is
+// positioned on an intent, is exhausted, or reaches the limit.
+func (i *intentLockTableIter) skipNonIntents(
+	state pebble.IterValidityState, err error, dir int, limit roachpb.Key,
+) (pebble.IterValidityState, error) {
+	for err == nil && state == pebble.IterValid {
+		engineKey, keyErr := i.pebbleIterator.UnsafeEngineKey()
+		if keyErr != nil {
+			return state, keyErr
+		}
+		if !engineKey.IsLockTableKey() ||
+			engineKey.Version[0] == replicatedLockStrengthToByte[lock.Intent] {
+			break
+		}
+		i.skippedLocks = true
+		if dir > 0 {
+			state, err = i.pebbleIterator.NextEngineKeyWithLimit(limit)
+		} else {
+			state, err = i.pebbleIterator.PrevEngineKeyWithLimit(limit)
+		}
+	}
+	return state, err
+}
+
+// mayHaveNonIntentLocks returns whether the key that the iterator was last
+// positioned at with SeekGE, using prefix iteration, may hold replicated locks
+// other than intents. Intents sort before the other locks on the same key, so
+// the other locks are only ruled out if intentIter stepped through all of the
+// key's lock table keys without finding an intent or skipping over a lock.
+func (i *intentInterleavingIter) mayHaveNonIntentLocks() bool {
+	return !i.prefix || !i.intentSeeked || i.intentKey != nil || i.intentIter.skippedLocks
+}
+
+// unusedLockTableIter returns the unfiltered iterator over the lock table if
+// it was not positioned by the last SeekGE, using prefix iteration, because
+// the key has no MVCC versions. The caller may use it to scan the lock table
+// keys of that key until the next seek, but must not close it.
+func (i *intentInterleavingIter) unusedLockTableIter() (EngineIterator, bool) {
+	if !i.prefix || i.intentSeeked || i.iterValid {
+		return nil, false
+	}
+	return i.intentIter.pebbleIterator, true
+}
+
 func isLocal(k roachpb.Key) bool {
 	return k.Compare(keys.LocalMax) < 0
 }
@@ -274,7 +369,7 @@ func newIntentInterleavingIterator(reader Reader, opts IterOptions) MVCCIterator
 		prefix:                               opts.Prefix,
 		constraint:                           constraint,
 		iter:                                 iter,
-		intentIter:                           intentIter,
+		intentIter:                           intentLockTableIter{pebbleIterator: intentIter},
 		intentKeyAsNoTimestampMVCCKeyBacking: iiIter.intentKeyAsNoTimestampMVCCKeyBacking,
 		intentKeyBuf:                         intentKeyBuf,
 		intentLimitKeyBuf:                    intentLimitKeyBuf,
@@ -505,6 +600,7 @@ func (i *intentInterleavingIter) SeekGE(key MVCCKey) {
 		intentSeekKey = nil
 		i.intentKey = nil
 	}
+	i.intentSeeked = intentSeekKey != nil
 	if intentSeekKey != nil {
 		var limitKey roachpb.Key
 		if i.iterValid && !i.prefix {
@@ -542,13 +638,14 @@ func (i *intentInterleavingIter) SeekIntentGE(key roachpb.Key, txnUUID uuid.UUID
 	var engineKey EngineKey
 	engineKey, i.intentKeyBuf = LockTableKey{
 		Key:      key,
-		Strength: lock.Exclusive,
+		Strength: lock.Intent,
 		TxnUUID:  txnUUID[:],
 	}.ToEngineKey(i.intentKeyBuf)
 	var limitKey roachpb.Key
 	if i.iterValid && !i.prefix {
 		limitKey = i.makeUpperLimitKey()
 	}
+	i.intentSeeked = true
 	iterState, err := i.intentIter.SeekEngineKeyGEWithLimit(engineKey, limitKey)
 	if err = i.tryDecodeLockKey(iterState, err); err != nil {
 		return
@@ -18,13 +18,14 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
+	"github.com/cockroachdb/errors"
 )
 
 // This file defines wrappers for Reader and Writer, and functions to do the
 // wrapping, which depend on the configuration settings above.
 
-// intentDemuxWriter implements 3 methods from the Writer interface:
-// PutIntent, ClearIntent, ClearMVCCRangeAndIntents.
+// intentDemuxWriter implements 5 methods from the Writer interface:
+// PutIntent, ClearIntent, PutLock, ClearLock, ClearMVCCRangeAndIntents.
 type intentDemuxWriter struct {
 	w Writer
 }
@@ -42,7 +43,7 @@ func (idw intentDemuxWriter) ClearIntent(
 	var engineKey EngineKey
 	engineKey, buf = LockTableKey{
 		Key:      key,
-		Strength: lock.Exclusive,
+		Strength: lock.Intent,
 		TxnUUID:  txnUUID[:],
 	}.ToEngineKey(buf)
 	if txnDidNotUpdateMeta {
@@ -60,7 +61,43 @@ func (idw intentDemuxWriter) PutIntent(
 	var engineKey EngineKey
 	engineKey, buf = LockTableKey{
 		Key:      key,
-		Strength: lock.Exclusive,
+		Strength: lock.Intent,
+		TxnUUID:  txnUUID[:],
+	}.ToEngineKey(buf)
+	return buf, idw.w.PutEngineKey(engineKey, value)
+}
+
+// ClearLock has the same behavior as Writer.ClearLock. buf is used as
+// scratch-space to avoid allocations -- its contents will be overwritten and
+// not appended to, and a possibly different buf returned.
+func (idw intentDemuxWriter) ClearLock(
+	key roachpb.Key, str lock.Strength, txnUUID uuid.UUID, buf []byte,
+) (_ []byte, _ error) {
+	if str == lock.Intent {
+		return buf, errors.AssertionFailedf("intents must be cleared using ClearIntent")
+	}
+	var engineKey EngineKey
+	engineKey, buf = LockTableKey{
+		Key:      key,
+		Strength: str,
+		TxnUUID:  txnUUID[:],
+	}.ToEngineKey(buf)
+	return buf, idw.w.ClearEngineKey(engineKey)
+}
+
+// PutLock has the same behavior as Writer.PutLock. buf is used as
+// scratch-space to avoid allocations -- its contents will be overwritten and
+// not appended to, and a possibly different buf returned.
+func (idw intentDemuxWriter) PutLock(
+	key roachpb.Key, str lock.Strength, txnUUID uuid.UUID, value []byte, buf []byte,
+) (_ []byte, _ error) {
+	if str == lock.Intent {
+		return buf, errors.AssertionFailedf("intents must be written using PutIntent")
+	}
+	var engineKey EngineKey
+	engineKey, buf = LockTableKey{
+		Key:      key,
+		Strength: str,
 		TxnUUID:  txnUUID[:],
 	}.ToEngineKey(buf)
 	return buf, idw.w.PutEngineKey(engineKey, value)
@@ -1452,6 +1452,7 @@ func MVCCPut(
 	// If we're not tracking stats for the key and we're writing a non-versioned
 	// key we can utilize a blind put to avoid reading any existing value.
 	var iter MVCCIterator
+	var ltScanner *lockTableKeyScanner
 	blind := ms == nil && timestamp.IsEmpty()
 	if !blind {
 		iter = newMVCCIterator(
@@ -1461,8 +1462,10 @@ func MVCCPut(
 			},
 		)
 		defer iter.Close()
+		ltScanner = newLockTableKeyScanner(rw, txn, lock.Intent)
+		defer ltScanner.close()
 	}
-	return mvccPutUsingIter(ctx, rw, iter, ms, key, timestamp, localTimestamp, value, txn, nil)
+	return mvccPutUsingIter(ctx, rw, iter, ltScanner, ms, key, timestamp, localTimestamp, value, txn, nil)
 }
 
 // MVCCBlindPut is a fast-path of MVCCPut. See the MVCCPut comments for details
@@ -1485,7 +1488,7 @@ func MVCCBlindPut(
 	value roachpb.Value,
 	txn *roachpb.Transaction,
 ) error {
-	return mvccPutUsingIter(ctx, writer, nil, ms, key, timestamp, localTimestamp, value, txn, nil)
+	return mvccPutUsingIter(ctx, writer, nil, nil, ms, key, timestamp, localTimestamp, value, txn, nil)
 }
 
 // MVCCDelete marks the key deleted so that it will not be returned in
@@ -1513,25 +1516,29 @@ func MVCCDelete(
 		},
 	)
 	defer iter.Close()
+	ltScanner := newLockTableKeyScanner(rw, txn, lock.Intent)
+	defer ltScanner.close()
 
 	buf := newPutBuffer()
 	defer buf.release()
 
 	// TODO(yuzefovich): can we avoid the put if the key does not exist?
 	return mvccPutInternal(
-		ctx, rw, iter, ms, key, timestamp, localTimestamp, noValue, txn, buf, nil)
+		ctx, rw, iter, ltScanner, ms, key, timestamp, localTimestamp, noValue, txn, buf, nil)
 }
 
 var noValue = roachpb.Value{}
 
 // mvccPutUsingIter sets the value for a specified key using the provided
-// MVCCIterator. The function takes a value and a valueFn, only one of which
-// should be provided. If the valueFn is nil, value's raw bytes will be set
-// for the key, else the bytes provided by the valueFn will be used.
+// MVCCIterator and lockTableKeyScanner. The function takes a value and a
+// valueFn, only one of which should be provided. If the valueFn is nil,
+// value's raw bytes will be set for the key, else the bytes provided by the
+// valueFn will be used.
 func mvccPutUsingIter(
 	ctx context.Context,
 	writer Writer,
 	iter MVCCIterator,
+	ltScanner *lockTableKeyScanner,
 	ms *enginepb.MVCCStats,
 	key roachpb.Key,
 	timestamp hlc.Timestamp,
@@ -1546,7 +1553,7 @@ func mvccPutUsingIter(
 	// Most callers don't care about the returned exReplaced value. The ones that
 	// do can call mvccPutInternal directly.
 	_, err := mvccPutInternal(
-		ctx, writer, iter, ms, key, timestamp, localTimestamp, value, txn, buf, valueFn)
+		ctx, writer, iter, ltScanner, ms, key, timestamp, localTimestamp, value, txn, buf, valueFn)
 	return err
 }
 
@@ -1760,6 +1767,7 @@ func mvccPutInternal(
 	ctx context.Context,
 	writer Writer,
 	iter MVCCIterator,
+	ltScanner *lockTableKeyScanner,
 	ms *enginepb.MVCCStats,
 	key roachpb.Key,
 	timestamp hlc.Timestamp,
@@ -1815,6 +1823,15 @@ func mvccPutInternal(
 		return ok && !buf.meta.Deleted, err
 	}
 
+	// Check for conflicting replicated locks held by other transactions on the
+	// key. Conflicting intents are detected below, using the metadata read by
+	// the intent interleaving iterator.
+	if ltScanner != nil {
+		if err := ltScanner.scanForPut(iter, key); err != nil {
+			return false, err
+		}
+	}
+
 	// Determine the read and write timestamps for the write. For a
 	// non-transactional write, these will be identical. For a transactional
 	// write, we read at the transaction's read timestamp but write intents at its
@@ -2258,6 +2275,8 @@ func MVCCIncrement(
 		},
 	)
 	defer iter.Close()
+	ltScanner := newLockTableKeyScanner(rw, txn, lock.Intent)
+	defer ltScanner.close()
 
 	var int64Val int64
 	var newInt64Val int64
@@ -2286,7 +2305,7 @@ func MVCCIncrement(
 		newValue.InitChecksum(key)
 		return newValue, nil
 	}
-	err := mvccPutUsingIter(ctx, rw, iter, ms, key, timestamp, localTimestamp, noValue, txn, valueFn)
+	err := mvccPutUsingIter(ctx, rw, iter, ltScanner, ms, key, timestamp, localTimestamp, noValue, txn, valueFn)
 
 	return newInt64Val, err
 }
@@ -2337,9 +2356,11 @@ func MVCCConditionalPut(
 		},
 	)
 	defer iter.Close()
+	ltScanner := newLockTableKeyScanner(rw, txn, lock.Intent)
+	defer ltScanner.close()
 
 	return mvccConditionalPutUsingIter(
-		ctx, rw, iter, ms, key, timestamp, localTimestamp, value, expVal, allowIfDoesNotExist, txn)
+		ctx, rw, iter, ltScanner, ms, key, timestamp, localTimestamp, value, expVal, allowIfDoesNotExist, txn)
 }
 
 // MVCCBlindConditionalPut is a fast-path of MVCCConditionalPut. See the
@@ -2364,13 +2385,14 @@ func MVCCBlindConditionalPut(
 	txn *roachpb.Transaction,
 ) error {
 	return mvccConditionalPutUsingIter(
-		ctx, writer, nil, ms, key, timestamp, localTimestamp, value, expVal, allowIfDoesNotExist, txn)
+		ctx, writer, nil, nil, ms, key, timestamp, localTimestamp, value, expVal, allowIfDoesNotExist, txn)
 }
 
 func mvccConditionalPutUsingIter(
 	ctx context.Context,
 	writer Writer,
 	iter MVCCIterator,
+	ltScanner *lockTableKeyScanner,
 	ms *enginepb.MVCCStats,
 	key roachpb.Key,
 	timestamp hlc.Timestamp,
@@ -2394,7 +2416,7 @@ func mvccConditionalPutUsingIter(
 		}
 		return value, nil
 	}
-	return mvccPutUsingIter(ctx, writer, iter, ms, key, timestamp, localTimestamp, noValue, txn, valueFn)
+	return mvccPutUsingIter(ctx, writer, iter, ltScanner, ms, key, timestamp, localTimestamp, noValue, txn, valueFn)
 }
 
 // MVCCInitPut sets the value for a specified key if the key doesn't exist. It
@@ -2424,7 +2446,9 @@ func MVCCInitPut(
 		},
 	)
 	defer iter.Close()
-	return mvccInitPutUsingIter(ctx, rw, iter, ms, key, timestamp, localTimestamp, value, failOnTombstones, txn)
+	ltScanner := newLockTableKeyScanner(rw, txn, lock.Intent)
+	defer ltScanner.close()
+	return mvccInitPutUsingIter(ctx, rw, iter, ltScanner, ms, key, timestamp, localTimestamp, value, failOnTombstones, txn)
 }
 
 // MVCCBlindInitPut is a fast-path of MVCCInitPut. See the MVCCInitPut
@@ -2447,13 +2471,14 @@ func MVCCBlindInitPut(
 	txn *roachpb.Transaction,
 ) error {
 	return mvccInitPutUsingIter(
-		ctx, rw, nil, ms, key, timestamp, localTimestamp, value, failOnTombstones, txn)
+		ctx, rw, nil, nil, ms, key, timestamp, localTimestamp, value, failOnTombstones, txn)
 }
 
 func mvccInitPutUsingIter(
 	ctx context.Context,
 	rw ReadWriter,
 	iter MVCCIterator,
+	ltScanner *lockTableKeyScanner,
 	ms *enginepb.MVCCStats,
 	key roachpb.Key,
 	timestamp hlc.Timestamp,
@@ -2477,7 +2502,243 @@ func mvccInitPutUsingIter(
 		}
 		return value, nil
 	}
-	return mvccPutUsingIter(ctx, rw, iter, ms, key, timestamp, localTimestamp, noValue, txn, valueFn)
+	return mvccPutUsingIter(ctx, rw, iter, ltScanner, ms, key, timestamp, localTimestamp, noValue, txn, valueFn)
+}
+
+// lockTableKeyScanner is used to scan the replicated lock table keys of
+// individual keys in search of locks that conflict with an access of a given
+// strength performed by a given transaction. A single lockTableKeyScanner can
+// be used to scan multiple keys.
+//
+// When the access is itself an intent write, intents are ignored by the
+// scanner, as conflicts with intents are detected by the intent interleaving
+// iterator used by the write.
+type lockTableKeyScanner struct {
+	reader Reader
+	// iter is lazily initialized on the first call to scan.
+	iter EngineIterator
+	// txn is the transaction performing the access, if any. Locks held by this
+	// transaction never conflict with the access.
+	txn *roachpb.Transaction
+	// str is the strength of the access.
+	str lock.Strength
+	// ltKeyBuf is scratch space used to construct lock table keys.
+	ltKeyBuf []byte
+	meta     enginepb.MVCCMetadata
+}
+
+func newLockTableKeyScanner(
+	reader Reader, txn *roachpb.Transaction, str lock.Strength,
+) *lockTableKeyScanner {
+	return &lockTableKeyScanner{reader: reader, txn: txn, str: str}
+}
+
+func (s *lockTableKeyScanner) close() {
+	if s.iter != nil {
+		s.iter.Close()
+	}
+}
+
+// scan scans the lock table keys of the provided key. A WriteIntentError is
+// returned if any replicated lock held on the key by another transaction
+// conflicts with the scanner's access. Otherwise, the strongest replicated lock
+// held on the key by the scanner's transaction at its current epoch is
+// returned, or lock.None if no such lock exists.
+func (s *lockTableKeyScanner) scan(key roachpb.Key) (lock.Strength, error) {
+	if s.iter == nil {
+		s.iter = s.reader.NewEngineIterator(IterOptions{Prefix: true})
+	}
+	return s.scanUsingIter(s.iter, key)
+}
+
+// scanForPut scans the lock table keys of the provided key on behalf of a
+// write, like scan, after the write has seeked iter to the key. If iter is an
+// intentInterleavingIter using prefix iteration, the scan is skipped when iter
+// has already ruled out replicated locks other than intents on the key, and
+// otherwise reuses iter's lock table iterator when possible, avoiding the
+// creation of another iterator on the write path.
+func (s *lockTableKeyScanner) scanForPut(iter MVCCIterator, key roachpb.Key) error {
+	if iiIter, ok := iter.(*intentInterleavingIter); ok {
+		if ltIter, ok := iiIter.unusedLockTableIter(); ok {
+			_, err := s.scanUsingIter(ltIter, key)
+			return err
+		}
+		if !iiIter.mayHaveNonIntentLocks() {
+			return nil
+		}
+	}
+	_, err := s.scan(key)
+	return err
+}
+
+// scanUsingIter is like scan, but uses the provided iterator over the lock
+// table.
+func (s *lockTableKeyScanner) scanUsingIter(
+	iter EngineIterator, key roachpb.Key,
+) (lock.Strength, error) {
+	var ltSeekKey roachpb.Key
+	ltSeekKey, s.ltKeyBuf = keys.LockTableSingleKey(key, s.ltKeyBuf)
+	ownStr := lock.None
+	var conflicts []roachpb.Intent
+	var valid bool
+	var err error
+	for valid, err = iter.SeekEngineKeyGE(EngineKey{Key: ltSeekKey}); valid; valid, err = iter.NextEngineKey() {
+		engineKey, keyErr := iter.UnsafeEngineKey()
+		if keyErr != nil {
+			return lock.None, keyErr
+		}
+		ltKey, keyErr := engineKey.ToLockTableKey()
+		if keyErr != nil {
+			return lock.None, keyErr
+		}
+		if ltKey.Strength == lock.Intent && s.str == lock.Intent {
+			continue
+		}
+		v, valErr := iter.UnsafeValue()
+		if valErr != nil {
+			return lock.None, valErr
+		}
+		if valErr = protoutil.Unmarshal(v, &s.meta); valErr != nil {
+			return lock.None, valErr
+		}
+		if s.meta.Txn == nil {
+			return lock.None, errors.AssertionFailedf("txn is null for lock %v on key %v", ltKey.Strength, key)
+		}
+		if s.txn != nil && s.meta.Txn.ID == s.txn.ID {
+			// Locks acquired at an earlier epoch are no longer held.
+			if s.meta.Txn.Epoch == s.txn.Epoch && ltKey.Strength > ownStr {
+				ownStr = ltKey.Strength
+			}
+			continue
+		}
+		if !replicatedLocksConflict(ltKey.Strength, s.str) {
+			continue
+		}
+		if len(conflicts) > 0 {
+			// Only report one conflicting lock per key. The in-memory lock table
+			// tracks a single discovered lock on each key, so the request waits
+			// for the locks held by other transactions one at a time.
+			continue
+		}
+		if ltKey.Strength == lock.Intent {
+			conflicts = append(conflicts, roachpb.MakeIntent(s.meta.Txn, key.Clone()))
+		} else {
+			conflicts = append(conflicts, roachpb.MakeReplicatedLock(s.meta.Txn, key.Clone(), ltKey.Strength))
+		}
+	}
+	if err != nil {
+		return lock.None, err
+	}
+	if len(conflicts) > 0 {
+		return lock.None, &kvpb.WriteIntentError{Intents: conflicts}
+	}
+	return ownStr, nil
+}
+
+// replicatedLocksConflict returns whether a replicated lock with strength held
+// conflicts with an access with strength str by a different transaction.
+// Replicated Shared locks are compatible with Shared and Update accesses. All
+// other combinations conflict.
+func replicatedLocksConflict(held, str lock.Strength) bool {
+	return !(held == lock.Shared && (str == lock.Shared || str == lock.Update))
+}
+
+// MVCCCheckForAcquireLock scans the replicated lock table to determine whether
+// a lock with the given strength, of either durability, can be acquired by the
+// transaction on the key. If a conflicting lock or intent held by a different
+// transaction is found, a WriteIntentError is returned.
+//
+// Unreplicated locks are only acquired in the in-memory lock table, which does
+// not remember uncontended replicated locks, so locking reads that acquire
+// unreplicated locks use this function to discover conflicting replicated
+// locks during evaluation.
+func MVCCCheckForAcquireLock(
+	ctx context.Context, reader Reader, txn *roachpb.Transaction, str lock.Strength, key roachpb.Key,
+) error {
+	switch str {
+	case lock.Shared, lock.Update, lock.Exclusive:
+	default:
+		return errors.AssertionFailedf("unexpected lock strength %s", str)
+	}
+	ltScanner := newLockTableKeyScanner(reader, txn, str)
+	defer ltScanner.close()
+	_, err := ltScanner.scan(key)
+	return err
+}
+
+// MVCCAcquireLock attempts to acquire a replicated lock with the given
+// strength on the key for the transaction. Replicated locks are stored in the
+// lock table keyspace alongside intents, so unlike unreplicated locks, they
+// survive lease transfers, range splits and merges, and node restarts. They
+// are released by intent resolution once the transaction is finalized.
+//
+// If a conflicting lock or intent held by a different transaction is found, a
+// WriteIntentError is returned. If the transaction already holds a lock or an
+// intent on the key with an equal or greater strength, the call is a no-op.
+//
+// Replicated locks only conflict with locking reads and writes. Non-locking
+// reads ignore them. Replicated locks are not accounted for in MVCCStats.
+func MVCCAcquireLock(
+	ctx context.Context, rw ReadWriter, txn *roachpb.Transaction, str lock.Strength, key roachpb.Key,
+) error {
+	if txn == nil {
+		return errors.AssertionFailedf("cannot acquire a replicated lock without a transaction")
+	}
+	if err := checkReplicatedLockStrength(str); err != nil {
+		return err
+	}
+	ltScanner := newLockTableKeyScanner(rw, txn, str)
+	defer ltScanner.close()
+	ownStr, err := ltScanner.scan(key)
+	if err != nil {
+		return err
+	}
+	if ownStr >= str {
+		// The transaction already holds a sufficiently strong lock on the key.
+		return nil
+	}
+
+	buf := newPutBuffer()
+	defer buf.release()
+	buf.newMeta = enginepb.MVCCMetadata{
+		Txn:       &txn.TxnMeta,
+		Timestamp: txn.WriteTimestamp.ToLegacyTimestamp(),
+	}
+	bytes, err := buf.marshalMeta(&buf.newMeta)
+	if err != nil {
+		return err
+	}
+	return rw.PutLock(key, str, txn.ID, bytes)
+}
+
+// checkReplicatedLockStrength returns an error if locks with the provided
+// strength cannot be acquired through MVCCAcquireLock.
+func checkReplicatedLockStrength(str lock.Strength) error {
+	switch str {
+	case lock.Shared, lock.Exclusive:
+		return nil
+	default:
+		return errors.AssertionFailedf("unsupported replicated lock strength %s", str)
+	}
+}
+
+// mvccReleaseLockInternal releases the replicated, non-intent lock with the
+// given strength held on the update's key by the update's transaction, if the
+// update indicates that the lock is no longer held. This is the case if the
+// transaction has been finalized, or if the lock was acquired at an earlier
+// epoch or at an ignored sequence number. Returns whether the lock was
+// released.
+func mvccReleaseLockInternal(
+	rw Writer, update roachpb.LockUpdate, str lock.Strength, meta *enginepb.MVCCMetadata,
+) (bool, error) {
+	release := update.Status.IsFinalized() ||
+		meta.Txn.Epoch < update.Txn.Epoch ||
+		(meta.Txn.Epoch == update.Txn.Epoch &&
+			enginepb.TxnSeqIsIgnored(meta.Txn.Sequence, update.IgnoredSeqNums))
+	if !release {
+		return false, nil
+	}
+	return true, rw.ClearLock(update.Key, str, update.Txn.ID)
 }
 
 // mvccKeyFormatter is an fmt.Formatter for MVCC Keys.
@@ -3050,11 +3311,13 @@ func MVCCDeleteRange(
 		},
 	)
 	defer iter.Close()
+	ltScanner := newLockTableKeyScanner(rw, txn, lock.Intent)
+	defer ltScanner.close()
 
 	var keys []roachpb.Key
 	for i, kv := range res.KVs {
 		if _, err := mvccPutInternal(
-			ctx, rw, iter, ms, kv.Key, timestamp, localTimestamp, noValue, txn, buf, nil,
+			ctx, rw, iter, ltScanner, ms, kv.Key, timestamp, localTimestamp, noValue, txn, buf, nil,
 		); err != nil {
 			return nil, nil, 0, err
 		}
@@ -3213,6 +3476,8 @@ func MVCCPredicateDeleteRange(
 		},
 	)
 	defer pointTombstoneIter.Close()
+	pointTombstoneLTScanner := newLockTableKeyScanner(rw, nil /* txn */, lock.Intent)
+	defer pointTombstoneLTScanner.close()
 	pointTombstoneBuf := newPutBuffer()
 	defer pointTombstoneBuf.release()
 
@@ -3235,8 +3500,8 @@ func MVCCPredicateDeleteRange(
 		} else {
 			// Use Point tombstones
 			for i := int64(0); i < runSize; i++ {
-				if _, err := mvccPutInternal(ctx, rw, pointTombstoneIter, ms, buf[i], endTime, localTimestamp, noValue,
-					nil, pointTombstoneBuf, nil); err != nil {
+				if _, err := mvccPutInternal(ctx, rw, pointTombstoneIter, pointTombstoneLTScanner, ms, buf[i],
+					endTime, localTimestamp, noValue, nil, pointTombstoneBuf, nil); err != nil {
 					return err
 				}
 			}
@@ -4258,23 +4523,89 @@ func MVCCResolveWriteIntent(
 		return false, 0, &roachpb.Span{Key: intent.Key}, nil
 	}
 
-	iterAndBuf := GetBufUsingIter(rw.NewMVCCIterator(MVCCKeyAndIntentsIterKind, IterOptions{
+	// Iterate over the lock table entries of the key, to resolve both the intent
+	// and the replicated locks that the transaction holds on it. The MVCC
+	// versions of the key are only read through mvccIter if the intent needs to
+	// be rewritten or removed.
+	ltStart, _ := keys.LockTableSingleKey(intent.Key, nil)
+	engineIter := rw.NewEngineIterator(IterOptions{Prefix: true, LowerBound: ltStart})
+	var mvccIter MVCCIterator
+	iterOpts := IterOptions{
 		KeyTypes: IterKeyTypePointsAndRanges,
 		Prefix:   true,
-	}))
-	iterAndBuf.iter.SeekIntentGE(intent.Key, intent.Txn.ID)
+	}
+	if rw.ConsistentIterators() {
+		mvccIter = rw.NewMVCCIterator(MVCCKeyIterKind, iterOpts)
+	} else {
+		// For correctness, we need mvccIter to be consistent with engineIter.
+		mvccIter = newPebbleIteratorByCloning(engineIter.CloneContext(), iterOpts, StandardDurability)
+	}
+	iterAndBuf := GetBufUsingIter(mvccIter)
+	sepIter := &separatedIntentAndVersionIter{
+		engineIter: engineIter,
+		mvccIter:   iterAndBuf.iter,
+	}
 	// Production code will use a buffered writer, which makes the numBytes
 	// calculation accurate. Note that an inaccurate numBytes (e.g. 0 in the
 	// case of an unbuffered writer) does not affect any safety properties of
 	// the database.
 	beforeBytes := rw.BufferedSize()
-	ok, err = mvccResolveWriteIntent(ctx, rw, iterAndBuf.iter, ms, intent, iterAndBuf.buf)
-	numBytes = int64(rw.BufferedSize() - beforeBytes)
+	ok, err = mvccResolveLocksForKey(ctx, rw, sepIter, ltStart, ms, intent, iterAndBuf.buf)
 	// Using defer would be more convenient, but it is measurably slower.
+	engineIter.Close()
 	iterAndBuf.Cleanup()
+	numBytes = int64(rw.BufferedSize() - beforeBytes)
 	return ok, numBytes, nil, err
 }
 
+// mvccResolveLocksForKey resolves the intent and releases the replicated locks
+// held by the update's transaction on the update's key, using a single scan of
+// the key's lock table entries starting at ltStart. Returns whether any intent
+// or lock was resolved.
+func mvccResolveLocksForKey(
+	ctx context.Context,
+	rw ReadWriter,
+	sepIter *separatedIntentAndVersionIter,
+	ltStart roachpb.Key,
+	ms *enginepb.MVCCStats,
+	intent roachpb.LockUpdate,
+	buf *putBuffer,
+) (resolved bool, _ error) {
+	for sepIter.seekEngineKeyGE(EngineKey{Key: ltStart}); ; sepIter.nextEngineKey() {
+		if valid, err := sepIter.Valid(); err != nil {
+			return false, err
+		} else if !valid {
+			break
+		}
+		meta := &buf.meta
+		if err := sepIter.ValueProto(meta); err != nil {
+			return false, err
+		}
+		if meta.Txn == nil {
+			return false, errors.Errorf("intent with no txn")
+		}
+		if intent.Txn.ID != meta.Txn.ID {
+			// Lock held by a different txn, so ignore.
+			continue
+		}
+		var ok bool
+		var err error
+		if sepIter.lockStr != lock.Intent {
+			ok, err = mvccReleaseLockInternal(rw, intent, sepIter.lockStr, meta)
+		} else {
+			// Stash the parsed meta so don't need to parse it again in
+			// mvccResolveWriteIntent.
+			sepIter.meta = meta
+			ok, err = mvccResolveWriteIntent(ctx, rw, sepIter, ms, intent, buf)
+		}
+		if err != nil {
+			return false, err
+		}
+		resolved = resolved || ok
+	}
+	return resolved, nil
+}
+
 // iterForKeyVersions provides a subset of the functionality of MVCCIterator.
 // The expected use-case is when the iter is already positioned at the intent
 // (if one exists) for a particular key, or some version, and positioning
@@ -4320,6 +4651,9 @@ type separatedIntentAndVersionIter struct {
 	engineIterValid bool
 	engineIterErr   error
 	intentKey       roachpb.Key
+	// The strength of the lock that engineIter is positioned at. Only intents
+	// are exposed through the iterForKeyVersions interface.
+	lockStr lock.Strength
 }
 
 var _ iterForKeyVersions = &separatedIntentAndVersionIter{}
@@ -4346,11 +4680,13 @@ func (s *separatedIntentAndVersionIter) initIntentKey() {
 			s.engineIterValid = false
 			return
 		}
-		if s.intentKey, err = keys.DecodeLockTableSingleKey(engineKey.Key); err != nil {
+		ltKey, err := engineKey.ToLockTableKey()
+		if err != nil {
 			s.engineIterErr = err
 			s.engineIterValid = false
 			return
 		}
+		s.intentKey, s.lockStr = ltKey.Key, ltKey.Strength
 	}
 }
 
@@ -5128,6 +5464,23 @@ func MVCCResolveWriteIntentRange(
 			sepIter.nextEngineKey()
 			continue
 		}
+		if sepIter.lockStr != lock.Intent {
+			// A replicated lock held by the txn that is not an intent. Release it,
+			// if it is no longer held.
+			lastResolvedKey = append(lastResolvedKey[:0], sepIter.intentKey...)
+			intent.Key = lastResolvedKey
+			beforeBytes := rw.BufferedSize()
+			released, err := mvccReleaseLockInternal(rw, intent, sepIter.lockStr, meta)
+			if err != nil {
+				return 0, 0, nil, 0, err
+			}
+			if released {
+				numKeys++
+			}
+			numBytes += int64(rw.BufferedSize() - beforeBytes)
+			sepIter.nextEngineKey()
+			continue
+		}
 		// Stash the parsed meta so don't need to parse it again in
 		// mvccResolveWriteIntent. This parsing can be ~10% of the resolution cost
 		// in some benchmarks.
@@ -27,6 +27,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvpb"
 	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/isolation"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage/enginepb"
@@ -2639,6 +2640,105 @@ func TestMVCCResolveTxn(t *testing.T) {
 	}
 }
 
+// TestMVCCReplicatedLocks verifies that replicated Shared and Exclusive locks
+// conflict with locking accesses by other transactions, are ignored by
+// non-locking reads, and are released by intent resolution.
+func TestMVCCReplicatedLocks(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	engine := NewDefaultInMemForTesting()
+	defer engine.Close()
+
+	numLockTableKeys := func() int {
+		iter := engine.NewEngineIterator(IterOptions{
+			LowerBound: keys.LockTableSingleKeyStart,
+			UpperBound: keys.LockTableSingleKeyEnd,
+		})
+		defer iter.Close()
+		var n int
+		valid, err := iter.SeekEngineKeyGE(EngineKey{Key: keys.LockTableSingleKeyStart})
+		for ; valid; valid, err = iter.NextEngineKey() {
+			n++
+		}
+		require.NoError(t, err)
+		return n
+	}
+	requireWriteIntentError := func(err error) {
+		t.Helper()
+		require.Error(t, err)
+		require.True(t, errors.HasType(err, (*kvpb.WriteIntentError)(nil)), "unexpected error: %v", err)
+	}
+
+	// Both transactions can acquire Shared locks on the same key. Re-acquiring
+	// a lock is a no-op.
+	require.NoError(t, MVCCAcquireLock(ctx, engine, txn1, lock.Shared, testKey1))
+	require.NoError(t, MVCCAcquireLock(ctx, engine, txn1, lock.Shared, testKey1))
+	require.NoError(t, MVCCAcquireLock(ctx, engine, txn2, lock.Shared, testKey1))
+	require.Equal(t, 2, numLockTableKeys())
+
+	// Exclusive locks conflict with Shared locks held by other transactions.
+	requireWriteIntentError(MVCCCheckForAcquireLock(ctx, engine, txn2, lock.Exclusive, testKey1))
+	requireWriteIntentError(MVCCAcquireLock(ctx, engine, txn2, lock.Exclusive, testKey1))
+
+	// Update locks, which are only acquired with the Unreplicated durability,
+	// are compatible with Shared locks.
+	require.NoError(t, MVCCCheckForAcquireLock(ctx, engine, txn2, lock.Update, testKey1))
+
+	// Replicated Update locks are not supported.
+	require.Error(t, MVCCAcquireLock(ctx, engine, txn1, lock.Update, testKey2))
+
+	// Non-locking reads ignore replicated locks.
+	_, err := MVCCGet(ctx, engine, testKey1, txn2TS, MVCCGetOptions{})
+	require.NoError(t, err)
+
+	// Writes conflict with locks held by other transactions.
+	requireWriteIntentError(MVCCPut(ctx, engine, nil, testKey1, txn2.ReadTimestamp, hlc.ClockTimestamp{}, value1, txn2))
+	requireWriteIntentError(MVCCPut(ctx, engine, nil, testKey1, txn2TS, hlc.ClockTimestamp{}, value1, nil))
+
+	// Resolving txn1's intents releases its lock, after which txn2 can write to
+	// the key, as its own Shared lock does not conflict with the write.
+	ok, _, _, err := MVCCResolveWriteIntent(ctx, engine, nil,
+		roachpb.MakeLockUpdate(txn1Commit, roachpb.Span{Key: testKey1}),
+		MVCCResolveWriteIntentOptions{})
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, 1, numLockTableKeys())
+	require.NoError(t, MVCCPut(ctx, engine, nil, testKey1, txn2.ReadTimestamp, hlc.ClockTimestamp{}, value1, txn2))
+
+	// An Exclusive lock held by txn2 on another key conflicts with txn1.
+	require.NoError(t, MVCCAcquireLock(ctx, engine, txn2, lock.Exclusive, testKey2))
+	err = MVCCCheckForAcquireLock(ctx, engine, txn1, lock.Shared, testKey2)
+	requireWriteIntentError(err)
+	var wiErr *kvpb.WriteIntentError
+	require.True(t, errors.As(err, &wiErr))
+	require.Len(t, wiErr.Intents, 1)
+	require.Equal(t, lock.Exclusive, wiErr.Intents[0].LockStrength())
+
+	// Ranged intent resolution releases txn2's locks and resolves its intent.
+	_, _, _, _, err = MVCCResolveWriteIntentRange(ctx, engine, nil,
+		roachpb.MakeLockUpdate(txn2Commit, roachpb.Span{Key: testKey1, EndKey: testKey3}),
+		MVCCResolveWriteIntentRangeOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 0, numLockTableKeys())
+	require.NoError(t, MVCCAcquireLock(ctx, engine, txn1, lock.Exclusive, testKey2))
+
+	// Writes to keys with existing versions also conflict with locks held by
+	// other transactions, and succeed once the locks are released.
+	ts3 := hlc.Timestamp{Logical: 3}
+	require.NoError(t, MVCCAcquireLock(ctx, engine, txn1, lock.Shared, testKey1))
+	requireWriteIntentError(MVCCPut(ctx, engine, nil, testKey1, ts3, hlc.ClockTimestamp{}, value2, nil))
+	requireWriteIntentError(MVCCPut(ctx, engine, nil, testKey2, ts3, hlc.ClockTimestamp{}, value2, nil))
+	_, _, _, _, err = MVCCResolveWriteIntentRange(ctx, engine, nil,
+		roachpb.MakeLockUpdate(txn1Commit, roachpb.Span{Key: testKey1, EndKey: testKey3}),
+		MVCCResolveWriteIntentRangeOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 0, numLockTableKeys())
+	require.NoError(t, MVCCPut(ctx, engine, nil, testKey1, ts3, hlc.ClockTimestamp{}, value2, nil))
+	require.NoError(t, MVCCPut(ctx, engine, nil, testKey2, ts3, hlc.ClockTimestamp{}, value2, nil))
+}
+
 // TestMVCCResolveNewerIntent verifies that resolving a newer intent
 // than the committing transaction aborts the intent.
 func TestMVCCResolveNewerIntent(t *testing.T) {
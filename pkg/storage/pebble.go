@@ -30,6 +30,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/cloud"
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
@@ -1439,6 +1440,12 @@ func (p *Pebble) ClearIntent(key roachpb.Key, txnDidNotUpdateMeta bool, txnUUID
 	return err
 }
 
+// ClearLock implements the Engine interface.
+func (p *Pebble) ClearLock(key roachpb.Key, str lock.Strength, txnUUID uuid.UUID) error {
+	_, err := p.wrappedIntentWriter.ClearLock(key, str, txnUUID, nil)
+	return err
+}
+
 // ClearEngineKey implements the Engine interface.
 func (p *Pebble) ClearEngineKey(key EngineKey) error {
 	if len(key.Key) == 0 {
@@ -1573,6 +1580,14 @@ func (p *Pebble) PutIntent(
 	return err
 }
 
+// PutLock implements the Engine interface.
+func (p *Pebble) PutLock(
+	key roachpb.Key, str lock.Strength, txnUUID uuid.UUID, value []byte,
+) error {
+	_, err := p.wrappedIntentWriter.PutLock(key, str, txnUUID, value, nil)
+	return err
+}
+
 // PutEngineKey implements the Engine interface.
 func (p *Pebble) PutEngineKey(key EngineKey, value []byte) error {
 	if len(key.Key) == 0 {
@@ -2315,6 +2330,10 @@ func (p *pebbleReadOnly) ClearIntent(
 	panic("not implemented")
 }
 
+func (p *pebbleReadOnly) ClearLock(key roachpb.Key, str lock.Strength, txnUUID uuid.UUID) error {
+	panic("not implemented")
+}
+
 func (p *pebbleReadOnly) ClearEngineKey(key EngineKey) error {
 	panic("not implemented")
 }
@@ -2383,6 +2402,12 @@ func (p *pebbleReadOnly) PutIntent(
 	panic("not implemented")
 }
 
+func (p *pebbleReadOnly) PutLock(
+	key roachpb.Key, str lock.Strength, txnUUID uuid.UUID, value []byte,
+) error {
+	panic("not implemented")
+}
+
 func (p *pebbleReadOnly) PutEngineKey(key EngineKey, value []byte) error {
 	panic("not implemented")
 }
@@ -14,6 +14,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/storage/pebbleiter"
@@ -291,6 +292,13 @@ func (p *pebbleBatch) ClearIntent(
 	return err
 }
 
+// ClearLock implements the Batch interface.
+func (p *pebbleBatch) ClearLock(key roachpb.Key, str lock.Strength, txnUUID uuid.UUID) error {
+	var err error
+	p.scratch, err = p.wrappedIntentWriter.ClearLock(key, str, txnUUID, p.scratch)
+	return err
+}
+
 // ClearEngineKey implements the Batch interface.
 func (p *pebbleBatch) ClearEngineKey(key EngineKey) error {
 	if len(key.Key) == 0 {
@@ -509,6 +517,15 @@ func (p *pebbleBatch) PutIntent(
 	return err
 }
 
+// PutLock implements the Batch interface.
+func (p *pebbleBatch) PutLock(
+	key roachpb.Key, str lock.Strength, txnUUID uuid.UUID, value []byte,
+) error {
+	var err error
+	p.scratch, err = p.wrappedIntentWriter.PutLock(key, str, txnUUID, value, p.scratch)
+	return err
+}
+
 // PutEngineKey implements the Batch interface.
 func (p *pebbleBatch) PutEngineKey(key EngineKey, value []byte) error {
 	if len(key.Key) == 0 {
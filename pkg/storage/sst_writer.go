@@ -16,6 +16,7 @@ import (
 	"io"
 
 	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv/kvserver/concurrency/lock"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
@@ -297,6 +298,13 @@ func (fw *SSTWriter) PutIntent(
 	return fw.put(MVCCKey{Key: key}, value)
 }
 
+// PutLock implements the Writer interface.
+func (fw *SSTWriter) PutLock(
+	key roachpb.Key, str lock.Strength, txnUUID uuid.UUID, value []byte,
+) error {
+	panic("PutLock is unsupported")
+}
+
 // PutEngineKey implements the Writer interface.
 // An error is returned if it is not greater than any previously added entry
 // (according to the comparator configured during writer creation). `Close`
@@ -356,6 +364,11 @@ func (fw *SSTWriter) ClearIntent(
 	panic("ClearIntent is unsupported")
 }
 
+// ClearLock implements the Writer interface.
+func (fw *SSTWriter) ClearLock(key roachpb.Key, str lock.Strength, txnUUID uuid.UUID) error {
+	panic("ClearLock is unsupported")
+}
+
 // ClearEngineKey implements the Writer interface. An error is returned if it is
 // not greater than any previous point key passed to this Writer (according to
 // the comparator configured during writer creation). `Close` cannot have been
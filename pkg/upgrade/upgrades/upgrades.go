@@ -316,6 +316,18 @@ var upgrades = []upgradebase.Upgrade{
 		upgrade.NoPrecondition,
 		NoTenantUpgradeFunc,
 	),
+	upgrade.NewTenantUpgrade(
+		"create system.notifications table",
+		toCV(clusterversion.V23_2_ListenNotify),
+		upgrade.NoPrecondition,
+		systemNotificationsTableMigration,
+	),
+	upgrade.NewTenantUpgrade(
+		"create system.replication_slots table",
+		toCV(clusterversion.V23_2_LogicalReplication),
+		upgrade.NoPrecondition,
+		systemReplicationSlotsTableMigration,
+	),
 }
 
 func init() {
@@ -0,0 +1,48 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+// Package lsn implements Postgres log sequence numbers, which identify
+// positions in a logical replication stream.
+package lsn
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+)
+
+// LSN is a log sequence number. Postgres uses it as a byte offset into the
+// write-ahead log. See pgrepl for how they are derived from MVCC timestamps.
+type LSN uint64
+
+// String returns the LSN in the format used by Postgres, which is two
+// hexadecimal numbers for the upper and lower 32 bits separated by a slash.
+func (l LSN) String() string {
+	return fmt.Sprintf("%X/%X", uint32(l>>32), uint32(l))
+}
+
+// ParseLSN parses an LSN in the format produced by String.
+func ParseLSN(s string) (LSN, error) {
+	hi, lo, ok := strings.Cut(s, "/")
+	if !ok {
+		return 0, errors.Newf("invalid LSN %q", s)
+	}
+	hiVal, err := strconv.ParseUint(hi, 16, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid LSN %q", s)
+	}
+	loVal, err := strconv.ParseUint(lo, 16, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid LSN %q", s)
+	}
+	return LSN(hiVal<<32 | loVal), nil
+}
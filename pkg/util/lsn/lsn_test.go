@@ -0,0 +1,46 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package lsn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLSN(t *testing.T) {
+	for _, tc := range []struct {
+		in  string
+		out LSN
+		err bool
+	}{
+		{in: "0/0", out: 0},
+		{in: "0/16B3748", out: 0x16B3748},
+		{in: "1/0", out: 1 << 32},
+		{in: "FFFFFFFF/FFFFFFFF", out: LSN(^uint64(0))},
+		{in: "179A1D9C/4C8E5A10", out: 0x179A1D9C4C8E5A10},
+		{in: "", err: true},
+		{in: "16B3748", err: true},
+		{in: "1/G", err: true},
+		{in: "100000000/0", err: true},
+	} {
+		t.Run(tc.in, func(t *testing.T) {
+			l, err := ParseLSN(tc.in)
+			if tc.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.out, l)
+			require.Equal(t, tc.in, l.String())
+		})
+	}
+}
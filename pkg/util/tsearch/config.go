@@ -10,22 +10,190 @@
 
 package tsearch
 
-import "strings"
+import (
+	"strings"
+	"unicode"
 
-// ValidConfig returns an error if the input string is not a supported and valid
-// text search config.
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// TokenType is the type of a token produced by the text search parser. The
+// types and their IDs are those of the default parser of Postgres, so that
+// configurations can be mapped the same way, even though our parser only
+// produces a few of them (see TSParse).
+type TokenType int
+
+const (
+	asciiWordToken TokenType = 1
+	wordToken      TokenType = 2
+	numWordToken   TokenType = 3
+	uintToken      TokenType = 19
+)
+
+// numTokenTypes is the number of token types of the default parser.
+const numTokenTypes = 23
+
+// tokenTypeNames are the names of the token types, indexed by their ID minus
+// one.
+var tokenTypeNames = [numTokenTypes]string{
+	"asciiword", "word", "numword", "asciihword", "hword", "numhword",
+	"hword_asciipart", "hword_part", "hword_numpart", "email", "protocol",
+	"url", "host", "url_path", "file", "sfloat", "float", "int", "uint",
+	"version", "tag", "entity", "blank",
+}
+
+// TokenTypeByName returns the token type with the given name.
+func TokenTypeByName(name string) (TokenType, error) {
+	lower := strings.ToLower(name)
+	for i, n := range tokenTypeNames {
+		if n == lower {
+			return TokenType(i + 1), nil
+		}
+	}
+	return 0, pgerror.Newf(pgcode.InvalidParameterValue, "token type %q does not exist", name)
+}
+
+// String returns the name of the token type.
+func (t TokenType) String() string {
+	if t < 1 || t > numTokenTypes {
+		return "unknown"
+	}
+	return tokenTypeNames[t-1]
+}
+
+// TokenTypes returns the token types of the default parser, ordered by ID.
+func TokenTypes() []TokenType {
+	types := make([]TokenType, numTokenTypes)
+	for i := range types {
+		types[i] = TokenType(i + 1)
+	}
+	return types
+}
+
+// tokenTypeOf returns the type of a token produced by TSParse.
+func tokenTypeOf(token string) TokenType {
+	ascii, letters, digits := true, true, true
+	for _, r := range token {
+		if r > unicode.MaxASCII {
+			ascii = false
+		}
+		if unicode.IsDigit(r) {
+			letters = false
+		} else {
+			digits = false
+		}
+	}
+	switch {
+	case digits:
+		return uintToken
+	case letters && ascii:
+		return asciiWordToken
+	case letters:
+		return wordToken
+	default:
+		return numWordToken
+	}
+}
+
+// Config is a text search configuration, which maps each token type to the
+// dictionaries which are consulted, in order, to normalize tokens of that
+// type into lexemes. Tokens of types without dictionaries, and tokens which
+// no dictionary recognizes, are discarded.
+type Config struct {
+	name  string
+	dicts [numTokenTypes][]*Dictionary
+}
+
+// NewConfig returns a text search configuration with the given name and no
+// mappings.
+func NewConfig(name string) *Config {
+	return &Config{name: name}
+}
+
+// Name returns the name of the configuration, which is qualified with its
+// database and schema for user-defined configurations, and with pg_catalog for
+// built-in configurations.
+func (c *Config) Name() string {
+	return c.name
+}
+
+// SetMapping sets the dictionaries which are consulted for tokens of the given
+// type.
+func (c *Config) SetMapping(t TokenType, dicts []*Dictionary) {
+	c.dicts[t-1] = dicts
+}
+
+// Mapping returns the dictionaries which are consulted for tokens of the given
+// type.
+func (c *Config) Mapping(t TokenType) []*Dictionary {
+	return c.dicts[t-1]
+}
+
+// lexize normalizes the longest prefix of the given tokens which the
+// dictionaries mapped to the type of the first token recognize. See
+// Dictionary.lexize.
+func (c *Config) lexize(tokens []string) (lexemes []string, n int) {
+	for _, d := range c.dicts[tokenTypeOf(tokens[0])-1] {
+		if lexemes, n = d.lexize(tokens); n > 0 {
+			return lexemes, n
+		}
+	}
+	return nil, 0
+}
+
+// builtinConfigs are the configurations defined in pg_catalog: the simple
+// configuration, and a configuration for each language with a stemmer.
+var builtinConfigs = makeBuiltinConfigs()
+
+func makeBuiltinConfigs() map[string]*Config {
+	configs := make(map[string]*Config, len(stemmers)+1)
+	configs["simple"] = newBuiltinConfig("simple", builtinDictionaries["simple"])
+	for lang := range stemmers {
+		configs[lang] = newBuiltinConfig(lang, builtinDictionaries[lang+"_stem"])
+	}
+	return configs
+}
+
+// newBuiltinConfig returns a built-in configuration, which normalizes tokens of
+// all types with the given dictionary.
+func newBuiltinConfig(name string, dict *Dictionary) *Config {
+	c := NewConfig("pg_catalog." + name)
+	for t := TokenType(1); t <= numTokenTypes; t++ {
+		c.SetMapping(t, []*Dictionary{dict})
+	}
+	return c
+}
+
+// BuiltinConfig returns the built-in configuration with the given name, which
+// may be qualified with pg_catalog.
+func BuiltinConfig(name string) (*Config, bool) {
+	c, ok := builtinConfigs[GetConfigKey(name)]
+	return c, ok
+}
+
+// UndefinedConfigError returns the error for a reference to a configuration
+// which does not exist.
+func UndefinedConfigError(name string) error {
+	return pgerror.Newf(pgcode.UndefinedObject, "text search configuration %q does not exist", name)
+}
+
+// ValidConfig returns an error if the input string is not a valid text search
+// config: either a built-in config, or a user-defined config qualified with the
+// schema it's in. User-defined configs are resolved when they're used, so they
+// aren't checked to exist here.
 func ValidConfig(input string) error {
-	input = GetConfigKey(input)
-	_, err := getStemmer(input)
-	return err
+	if _, ok := BuiltinConfig(input); ok {
+		return nil
+	}
+	if strings.Contains(input, ".") && !strings.HasPrefix(input, "pg_catalog.") {
+		return nil
+	}
+	return UndefinedConfigError(input)
 }
 
-// GetConfigKey returns a config that can be used as a key to look up stemmers
-// and stopwords from an input config value. This is simulating the more
-// advanced customizable dictionaries and configs that Postgres has, which
-// allows user-defined text search configurations: because of this, configs can
-// have schema prefixes. Because we don't (yet?) allow this, we just have to
-// trim off any `pg_catalog.` prefix if it exists.
+// GetConfigKey returns the name of a built-in config without any `pg_catalog.`
+// prefix.
 func GetConfigKey(config string) string {
 	return strings.TrimPrefix(config, "pg_catalog.")
 }
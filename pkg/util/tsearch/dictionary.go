@@ -0,0 +1,382 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/blevesearch/snowballstem"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// Template is the template of a text search dictionary, which determines how
+// the dictionary turns tokens into lexemes.
+type Template string
+
+const (
+	// SimpleTemplate is the template of dictionaries which lowercase tokens,
+	// and discard stop words.
+	SimpleTemplate Template = "simple"
+	// SnowballTemplate is the template of dictionaries which discard stop
+	// words, and stem the other tokens with the snowball stemmer of a language.
+	SnowballTemplate Template = "snowball"
+	// SynonymTemplate is the template of dictionaries which replace words with
+	// their synonyms.
+	SynonymTemplate Template = "synonym"
+	// ThesaurusTemplate is the template of dictionaries which replace phrases
+	// with other phrases.
+	ThesaurusTemplate Template = "thesaurus"
+)
+
+// templateOptions are the options accepted by each template.
+var templateOptions = map[Template][]string{
+	SimpleTemplate:    {"stopwords", "accept"},
+	SnowballTemplate:  {"language", "stopwords"},
+	SynonymTemplate:   {"synonyms", "casesensitive"},
+	ThesaurusTemplate: {"dictionary", "thesaurus"},
+}
+
+// DictionaryOption is an option of a text search dictionary, as given to
+// CREATE TEXT SEARCH DICTIONARY.
+type DictionaryOption struct {
+	Name  string
+	Value string
+}
+
+// Dictionary is a text search dictionary, which recognizes tokens and
+// normalizes them into lexemes.
+type Dictionary struct {
+	name     string
+	template Template
+
+	// stopwords are the words which are recognized and discarded by simple and
+	// snowball dictionaries.
+	stopwords map[string]struct{}
+	// accept is false for simple dictionaries which don't recognize the words
+	// that are not stop words, so that they are passed on to the next
+	// dictionary.
+	accept bool
+	// stem is the stemmer of a snowball dictionary.
+	stem func(env *snowballstem.Env) bool
+	// synonyms maps words to their synonyms, for synonym dictionaries.
+	synonyms      map[string]string
+	caseSensitive bool
+	// thesaurus is the list of phrases replaced by a thesaurus dictionary,
+	// longest first.
+	thesaurus []thesaurusEntry
+	// subDictionary normalizes the words of the phrases of a thesaurus
+	// dictionary.
+	subDictionary *Dictionary
+}
+
+// thesaurusEntry is a phrase replaced by a thesaurus dictionary. Both phrases
+// are normalized by the subdictionary of the thesaurus.
+type thesaurusEntry struct {
+	sample     []string
+	substitute []string
+}
+
+// builtinDictionaries are the dictionaries defined in pg_catalog: the simple
+// dictionary, and a snowball dictionary for each language with a stemmer,
+// which is named after the language with a "_stem" suffix.
+var builtinDictionaries = makeBuiltinDictionaries()
+
+func makeBuiltinDictionaries() map[string]*Dictionary {
+	dicts := make(map[string]*Dictionary, len(stemmers)+1)
+	dicts["simple"] = &Dictionary{name: "simple", template: SimpleTemplate, accept: true}
+	for lang, stem := range stemmers {
+		name := lang + "_stem"
+		dicts[name] = &Dictionary{
+			name:      name,
+			template:  SnowballTemplate,
+			stopwords: stopwordsMap[lang],
+			stem:      stem,
+		}
+	}
+	return dicts
+}
+
+// BuiltinDictionary returns the built-in dictionary with the given name, which
+// may be qualified with pg_catalog.
+func BuiltinDictionary(name string) (*Dictionary, bool) {
+	d, ok := builtinDictionaries[strings.TrimPrefix(name, "pg_catalog.")]
+	return d, ok
+}
+
+// UndefinedDictionaryError returns the error for a reference to a dictionary
+// which does not exist.
+func UndefinedDictionaryError(name string) error {
+	return pgerror.Newf(pgcode.UndefinedObject, "text search dictionary %q does not exist", name)
+}
+
+// NewDictionary returns a text search dictionary with the given name, which is
+// created from the given template and options. It returns an error if the
+// template does not exist, or the options are not valid for it.
+func NewDictionary(name string, template string, options []DictionaryOption) (*Dictionary, error) {
+	d := &Dictionary{name: name, template: Template(strings.ToLower(template)), accept: true}
+	valid, ok := templateOptions[d.template]
+	if !ok {
+		return nil, pgerror.Newf(pgcode.UndefinedObject, "text search template %q does not exist", template)
+	}
+	opts := make(map[string]string, len(options))
+	for _, opt := range options {
+		key := strings.ToLower(opt.Name)
+		if !isValidOption(valid, key) {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"unrecognized %s dictionary parameter: %q", d.template, opt.Name)
+		}
+		if _, ok := opts[key]; ok {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"%s dictionary parameter %q specified more than once", d.template, opt.Name)
+		}
+		opts[key] = opt.Value
+	}
+
+	var err error
+	if v, ok := opts["stopwords"]; ok {
+		if d.stopwords, err = getStopwords(v); err != nil {
+			return nil, err
+		}
+	}
+	switch d.template {
+	case SimpleTemplate:
+		if v, ok := opts["accept"]; ok {
+			if d.accept, err = parseDictionaryBool("accept", v); err != nil {
+				return nil, err
+			}
+		}
+	case SnowballTemplate:
+		v, ok := opts["language"]
+		if !ok {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "missing language parameter")
+		}
+		if d.stem, ok = stemmers[strings.ToLower(v)]; !ok {
+			return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+				"no snowball stemmer is available for language %q", v)
+		}
+	case SynonymTemplate:
+		if v, ok := opts["casesensitive"]; ok {
+			if d.caseSensitive, err = parseDictionaryBool("casesensitive", v); err != nil {
+				return nil, err
+			}
+		}
+		v, ok := opts["synonyms"]
+		if !ok {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "missing synonyms parameter")
+		}
+		if err := d.parseSynonyms(v); err != nil {
+			return nil, err
+		}
+	case ThesaurusTemplate:
+		v, ok := opts["dictionary"]
+		if !ok {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "missing dictionary parameter")
+		}
+		if d.subDictionary, ok = BuiltinDictionary(v); !ok {
+			return nil, UndefinedDictionaryError(v)
+		}
+		if v, ok = opts["thesaurus"]; !ok {
+			return nil, pgerror.New(pgcode.InvalidParameterValue, "missing thesaurus parameter")
+		}
+		if err := d.parseThesaurus(v); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func isValidOption(valid []string, key string) bool {
+	for _, v := range valid {
+		if v == key {
+			return true
+		}
+	}
+	return false
+}
+
+func parseDictionaryBool(key, value string) (bool, error) {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, pgerror.Newf(pgcode.InvalidParameterValue,
+			"%s requires a Boolean value", key)
+	}
+	return b, nil
+}
+
+func getStopwords(name string) (map[string]struct{}, error) {
+	stopwords, ok := stopwordsMap[strings.ToLower(name)]
+	if !ok {
+		return nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"stop word list %q does not exist", name)
+	}
+	return stopwords, nil
+}
+
+// splitEntries splits the entries of the list of synonyms or phrases of a
+// dictionary, which are separated by commas or newlines.
+func splitEntries(list string) []string {
+	var entries []string
+	for _, e := range strings.FieldsFunc(list, func(r rune) bool {
+		return r == ',' || r == '\n'
+	}) {
+		if e = strings.TrimSpace(e); e != "" {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+// parseSynonyms parses the synonyms of a synonym dictionary, which are given
+// as a list of entries, each of which is a word followed by its synonym.
+func (d *Dictionary) parseSynonyms(list string) error {
+	entries := splitEntries(list)
+	d.synonyms = make(map[string]string, len(entries))
+	for _, e := range entries {
+		words := strings.Fields(e)
+		if len(words) != 2 {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid synonym entry %q: expected a word followed by its synonym", e)
+		}
+		word, synonym := words[0], words[1]
+		if !d.caseSensitive {
+			word, synonym = strings.ToLower(word), strings.ToLower(synonym)
+		}
+		d.synonyms[word] = synonym
+	}
+	return nil
+}
+
+// parseThesaurus parses the phrases of a thesaurus dictionary, which are given
+// as a list of entries of the form "sample phrase : substitute phrase".
+func (d *Dictionary) parseThesaurus(list string) error {
+	for _, e := range splitEntries(list) {
+		sample, substitute, ok := strings.Cut(e, ":")
+		if !ok {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid thesaurus entry %q: expected a sample phrase and a substitute phrase separated by :", e)
+		}
+		var entry thesaurusEntry
+		for _, word := range TSParse(sample) {
+			lexemes, ok := d.subDictionary.Lexize(word)
+			if !ok {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"thesaurus sample word %q isn't recognized by subdictionary %q", word, d.subDictionary.name)
+			}
+			if len(lexemes) == 0 {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"thesaurus sample word %q is a stop word", word)
+			}
+			entry.sample = append(entry.sample, lexemes[0])
+		}
+		if len(entry.sample) == 0 {
+			return pgerror.Newf(pgcode.InvalidParameterValue,
+				"invalid thesaurus entry %q: the sample phrase is empty", e)
+		}
+		for _, word := range TSParse(substitute) {
+			lexemes, ok := d.subDictionary.Lexize(word)
+			if !ok {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"thesaurus substitute word %q isn't recognized by subdictionary %q", word, d.subDictionary.name)
+			}
+			entry.substitute = append(entry.substitute, lexemes...)
+		}
+		d.thesaurus = append(d.thesaurus, entry)
+	}
+	// Match the longest phrases first.
+	sort.SliceStable(d.thesaurus, func(i, j int) bool {
+		return len(d.thesaurus[i].sample) > len(d.thesaurus[j].sample)
+	})
+	return nil
+}
+
+// Name returns the name of the dictionary.
+func (d *Dictionary) Name() string {
+	return d.name
+}
+
+// Template returns the template of the dictionary.
+func (d *Dictionary) Template() Template {
+	return d.template
+}
+
+// Lexize implements ts_lexize, which normalizes a single token with the
+// dictionary. It returns false if the dictionary doesn't recognize the token,
+// and no lexemes if the token is a stop word.
+func (d *Dictionary) Lexize(token string) (lexemes []string, ok bool) {
+	lexemes, n := d.lexize([]string{token})
+	return lexemes, n > 0
+}
+
+// lexize normalizes the longest prefix of the given tokens which the
+// dictionary recognizes, and returns the resulting lexemes along with the
+// length of the prefix. A length of zero means that the dictionary doesn't
+// recognize the first token, so that the next dictionary should be consulted.
+// No lexemes along with a nonzero length means that the tokens are stop words.
+func (d *Dictionary) lexize(tokens []string) (lexemes []string, n int) {
+	switch d.template {
+	case SimpleTemplate:
+		lower := strings.ToLower(tokens[0])
+		if _, ok := d.stopwords[lower]; ok {
+			return nil, 1
+		}
+		if !d.accept {
+			return nil, 0
+		}
+		return []string{lower}, 1
+	case SnowballTemplate:
+		lower := strings.ToLower(tokens[0])
+		if _, ok := d.stopwords[lower]; ok {
+			return nil, 1
+		}
+		env := snowballstem.NewEnv(lower)
+		d.stem(env)
+		return []string{env.Current()}, 1
+	case SynonymTemplate:
+		word := tokens[0]
+		if !d.caseSensitive {
+			word = strings.ToLower(word)
+		}
+		if synonym, ok := d.synonyms[word]; ok {
+			return []string{synonym}, 1
+		}
+		return nil, 0
+	case ThesaurusTemplate:
+		// The tokens are normalized by the subdictionary as they are needed.
+		var normalized []string
+		for _, e := range d.thesaurus {
+			if len(e.sample) > len(tokens) {
+				continue
+			}
+			matched := true
+			for i := range e.sample {
+				for len(normalized) <= i {
+					var lexeme string
+					if lexemes, ok := d.subDictionary.Lexize(tokens[len(normalized)]); ok && len(lexemes) > 0 {
+						lexeme = lexemes[0]
+					}
+					normalized = append(normalized, lexeme)
+				}
+				if normalized[i] != e.sample[i] {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				return e.substitute, len(e.sample)
+			}
+		}
+		return nil, 0
+	}
+	return nil, 0
+}
@@ -0,0 +1,156 @@
+// Copyright 2023 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tsearch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDictionary(t *testing.T) {
+	tcs := []struct {
+		template string
+		options  []DictionaryOption
+		err      string
+	}{
+		{template: "simple"},
+		{template: "simple", options: []DictionaryOption{{"StopWords", "english"}, {"accept", "false"}}},
+		{template: "snowball", options: []DictionaryOption{{"language", "french"}}},
+		{template: "synonym", options: []DictionaryOption{{"synonyms", "a b"}}},
+		{template: "thesaurus", options: []DictionaryOption{{"dictionary", "english_stem"}, {"thesaurus", "big apple : nyc"}}},
+
+		{template: "ispell", err: `text search template "ispell" does not exist`},
+		{template: "simple", options: []DictionaryOption{{"language", "english"}}, err: `unrecognized simple dictionary parameter: "language"`},
+		{template: "simple", options: []DictionaryOption{{"accept", "true"}, {"accept", "false"}}, err: `simple dictionary parameter "accept" specified more than once`},
+		{template: "simple", options: []DictionaryOption{{"accept", "maybe"}}, err: `accept requires a Boolean value`},
+		{template: "simple", options: []DictionaryOption{{"stopwords", "klingon"}}, err: `stop word list "klingon" does not exist`},
+		{template: "snowball", err: `missing language parameter`},
+		{template: "snowball", options: []DictionaryOption{{"language", "nepali"}}, err: `no snowball stemmer is available for language "nepali"`},
+		{template: "synonym", err: `missing synonyms parameter`},
+		{template: "synonym", options: []DictionaryOption{{"synonyms", "a b c"}}, err: `invalid synonym entry "a b c"`},
+		{template: "thesaurus", options: []DictionaryOption{{"thesaurus", "a : b"}}, err: `missing dictionary parameter`},
+		{template: "thesaurus", options: []DictionaryOption{{"dictionary", "nope"}, {"thesaurus", "a : b"}}, err: `text search dictionary "nope" does not exist`},
+		{template: "thesaurus", options: []DictionaryOption{{"dictionary", "simple"}}, err: `missing thesaurus parameter`},
+		{template: "thesaurus", options: []DictionaryOption{{"dictionary", "simple"}, {"thesaurus", "a b"}}, err: `invalid thesaurus entry "a b"`},
+		{template: "thesaurus", options: []DictionaryOption{{"dictionary", "english_stem"}, {"thesaurus", "the cat : feline"}}, err: `thesaurus sample word "the" is a stop word`},
+	}
+	for _, tc := range tcs {
+		_, err := NewDictionary("d", tc.template, tc.options)
+		if tc.err == "" {
+			assert.NoError(t, err, "%s %v", tc.template, tc.options)
+		} else {
+			assert.ErrorContains(t, err, tc.err, "%s %v", tc.template, tc.options)
+		}
+	}
+}
+
+func TestDictionaryLexize(t *testing.T) {
+	newDict := func(template string, options ...DictionaryOption) *Dictionary {
+		d, err := NewDictionary("d", template, options)
+		require.NoError(t, err)
+		return d
+	}
+	english, _ := BuiltinDictionary("pg_catalog.english_stem")
+	simple, _ := BuiltinDictionary("simple")
+
+	tcs := []struct {
+		dict     *Dictionary
+		token    string
+		expected []string
+		ok       bool
+	}{
+		{simple, "Foo", []string{"foo"}, true},
+		{simple, "the", []string{"the"}, true},
+		{english, "Running", []string{"run"}, true},
+		{english, "the", nil, true},
+		{newDict("simple", DictionaryOption{"stopwords", "english"}), "The", nil, true},
+		{newDict("simple", DictionaryOption{"accept", "false"}), "foo", nil, false},
+		{newDict("snowball", DictionaryOption{"language", "english"}), "the", []string{"the"}, true},
+		{newDict("synonym", DictionaryOption{"synonyms", "Postgres pgsql, postgresql pgsql"}), "POSTGRES", []string{"pgsql"}, true},
+		{newDict("synonym", DictionaryOption{"synonyms", "Postgres pgsql"}), "mysql", nil, false},
+		{newDict("synonym", DictionaryOption{"synonyms", "Postgres pgsql"}, DictionaryOption{"casesensitive", "true"}), "postgres", nil, false},
+		{newDict("thesaurus", DictionaryOption{"dictionary", "english_stem"}, DictionaryOption{"thesaurus", "cats : felines"}), "cat", []string{"felin"}, true},
+	}
+	for _, tc := range tcs {
+		lexemes, ok := tc.dict.Lexize(tc.token)
+		assert.Equal(t, tc.ok, ok, "%s(%s)", tc.dict.Template(), tc.token)
+		assert.Equal(t, tc.expected, lexemes, "%s(%s)", tc.dict.Template(), tc.token)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	english, _ := BuiltinDictionary("english_stem")
+	simple, _ := BuiltinDictionary("simple")
+	synonyms, err := NewDictionary("syn", "synonym", []DictionaryOption{{"synonyms", "crdb cockroachdb"}})
+	require.NoError(t, err)
+	thesaurus, err := NewDictionary("thes", "thesaurus", []DictionaryOption{
+		{"dictionary", "english_stem"},
+		{"thesaurus", "supernovae stars : sn, supernovae : sn2"},
+	})
+	require.NoError(t, err)
+
+	config := NewConfig("db.public.cfg")
+	asciiWord, err := TokenTypeByName("asciiword")
+	require.NoError(t, err)
+	config.SetMapping(asciiWord, []*Dictionary{thesaurus, synonyms, english})
+	uintType, err := TokenTypeByName("UINT")
+	require.NoError(t, err)
+	config.SetMapping(uintType, []*Dictionary{simple})
+	_, err = TokenTypeByName("nope")
+	require.EqualError(t, err, `token type "nope" does not exist`)
+
+	tcs := []struct {
+		input    string
+		tsvector string
+		tsquery  string
+	}{
+		{"crdb is 42", `'42':3 'cockroachdb':1`, `'cockroachdb' & '42'`},
+		{"Supernovae stars shine", `'shine':3 'sn':1`, `'sn2' & 'star' & 'shine'`},
+		{"the supernovae", `'sn2':2`, `'sn2'`},
+		// Words with letters outside of ASCII, and words mixing letters and
+		// digits, are not mapped.
+		{"café a1 running", `'run':3`, `'run'`},
+	}
+	for _, tc := range tcs {
+		vector, err := DocumentToTSVector(config, tc.input)
+		require.NoError(t, err)
+		assert.Equal(t, tc.tsvector, vector.String(), tc.input)
+		query, err := PlainToTSQuery(config, tc.input)
+		require.NoError(t, err)
+		assert.Equal(t, tc.tsquery, query.String(), tc.input)
+	}
+}
+
+func TestBuiltinConfig(t *testing.T) {
+	for _, name := range []string{"simple", "english", "pg_catalog.english"} {
+		_, ok := BuiltinConfig(name)
+		assert.True(t, ok, name)
+		assert.NoError(t, ValidConfig(name))
+	}
+	_, ok := BuiltinConfig("public.english")
+	assert.False(t, ok)
+	assert.NoError(t, ValidConfig("public.english"))
+	assert.EqualError(t, ValidConfig("blah"), `text search configuration "blah" does not exist`)
+	assert.EqualError(t, ValidConfig("pg_catalog.blah"), `text search configuration "pg_catalog.blah" does not exist`)
+
+	english, _ := BuiltinConfig("english")
+	assert.Equal(t, "pg_catalog.english", english.Name())
+	for _, tokenType := range TokenTypes() {
+		mapping := english.Mapping(tokenType)
+		require.Len(t, mapping, 1, tokenType.String())
+		assert.Equal(t, "english_stem", mapping[0].Name())
+	}
+	vector, err := DocumentToTSVector(english, "The cats are running")
+	require.NoError(t, err)
+	assert.Equal(t, `'cat':2 'run':4`, vector.String())
+}
@@ -26,44 +26,23 @@ import (
 	"github.com/blevesearch/snowballstem/spanish"
 	"github.com/blevesearch/snowballstem/swedish"
 	"github.com/blevesearch/snowballstem/turkish"
-	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
-	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 )
 
-func getStemmer(config string) (func(env *snowballstem.Env) bool, error) {
-	switch config {
-	case "simple":
-		return func(env *snowballstem.Env) bool {
-			return true
-		}, nil
-	case "english":
-		return english.Stem, nil
-	case "danish":
-		return danish.Stem, nil
-	case "dutch":
-		return dutch.Stem, nil
-	case "finnish":
-		return finnish.Stem, nil
-	case "french":
-		return french.Stem, nil
-	case "german":
-		return german.Stem, nil
-	case "hungarian":
-		return hungarian.Stem, nil
-	case "italian":
-		return italian.Stem, nil
-	case "norwegian":
-		return norwegian.Stem, nil
-	case "portuguese":
-		return portuguese.Stem, nil
-	case "russian":
-		return russian.Stem, nil
-	case "spanish":
-		return spanish.Stem, nil
-	case "swedish":
-		return swedish.Stem, nil
-	case "turkish":
-		return turkish.Stem, nil
-	}
-	return nil, pgerror.Newf(pgcode.UndefinedObject, "text search configuration %q does not exist", config)
+// stemmers are the snowball stemmers of the languages which have built-in
+// text search configurations and dictionaries.
+var stemmers = map[string]func(env *snowballstem.Env) bool{
+	"danish":     danish.Stem,
+	"dutch":      dutch.Stem,
+	"english":    english.Stem,
+	"finnish":    finnish.Stem,
+	"french":     french.Stem,
+	"german":     german.Stem,
+	"hungarian":  hungarian.Stem,
+	"italian":    italian.Stem,
+	"norwegian":  norwegian.Stem,
+	"portuguese": portuguese.Stem,
+	"russian":    russian.Stem,
+	"spanish":    spanish.Stem,
+	"swedish":    swedish.Stem,
+	"turkish":    turkish.Stem,
 }
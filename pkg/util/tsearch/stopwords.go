@@ -20,10 +20,11 @@ import (
 //go:embed stopwords/*
 var stopwordFS embed.FS
 
-var stopwordsMap map[string]map[string]struct{}
+// stopwordsMap maps the names of the built-in stop word lists to their words.
+var stopwordsMap = loadStopwords()
 
-func init() {
-	stopwordsMap = make(map[string]map[string]struct{})
+func loadStopwords() map[string]map[string]struct{} {
+	stopwordsMap := make(map[string]map[string]struct{})
 	dir, err := stopwordFS.ReadDir("stopwords")
 	if err != nil {
 		panic("error loading stopwords: " + err.Error())
@@ -45,4 +46,5 @@ func init() {
 	}
 	// The simple text search config has no stopwords.
 	stopwordsMap["simple"] = nil
+	return stopwordsMap
 }
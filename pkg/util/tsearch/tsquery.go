@@ -415,21 +415,21 @@ func (p *tsQueryParser) syntaxError() (*tsNode, error) {
 
 // ToTSQuery implements the to_tsquery builtin, which lexes an input, performs
 // stopwording and normalization on the tokens, and returns a parsed query.
-func ToTSQuery(config string, input string) (TSQuery, error) {
+func ToTSQuery(config *Config, input string) (TSQuery, error) {
 	return toTSQuery(config, invalid, input)
 }
 
 // PlainToTSQuery implements the plainto_tsquery builtin, which lexes an input,
 // performs stopwording and normalization on the tokens, and returns a parsed
 // query, interposing the & operator between each token.
-func PlainToTSQuery(config string, input string) (TSQuery, error) {
+func PlainToTSQuery(config *Config, input string) (TSQuery, error) {
 	return toTSQuery(config, and, input)
 }
 
 // PhraseToTSQuery implements the phraseto_tsquery builtin, which lexes an input,
 // performs stopwording and normalization on the tokens, and returns a parsed
 // query, interposing the <-> operator between each token.
-func PhraseToTSQuery(config string, input string) (TSQuery, error) {
+func PhraseToTSQuery(config *Config, input string) (TSQuery, error) {
 	return toTSQuery(config, followedby, input)
 }
 
@@ -437,7 +437,7 @@ func PhraseToTSQuery(config string, input string) (TSQuery, error) {
 // performs stopwording and normalization on the tokens, and returns a parsed
 // query. If the interpose operator is not invalid, it's interposed between each
 // token in the input.
-func toTSQuery(config string, interpose tsOperator, input string) (TSQuery, error) {
+func toTSQuery(config *Config, interpose tsOperator, input string) (TSQuery, error) {
 	vector, err := lexTSQuery(input)
 	if err != nil {
 		return TSQuery{}, err
@@ -475,38 +475,42 @@ func toTSQuery(config string, interpose tsOperator, input string) (TSQuery, erro
 			continue
 		}
 
-		// When we support more than just the simple configuration, we'll also
-		// want to remove stopwords, which will affect the interposing, but we can
-		// worry about that later.
-		// Additionally, if we're doing phraseto_tsquery, if we remove a stopword,
-		// we need to make sure to increase the "followedN" of the followedby
-		// operator. For example, phraseto_tsquery('hello a deer') will return
-		// 'hello <2> deer', since the a stopword would be removed.
-
+		// Stopwords, and tokens which no dictionary recognizes, are replaced by
+		// empty lexemes, which are removed by cleanupStopwords once the query is
+		// parsed. This takes care of adjusting the distance of the followedby
+		// operators around them: phraseto_tsquery('hello a deer') returns
+		// 'hello <2> deer', since the a stopword is removed.
 		tokInterpose := interpose
 		if tokInterpose == invalid {
 			tokInterpose = followedby
 		}
-		for j := range lexemeTokens {
-			if j > 0 {
-				// We found more than one lexeme in our token, so we need to add all of them
-				// to the query, connected by our interpose operator.
-				// If we aren't running with an interpose, like in to_tsquery, Postgres
-				// uses the <-> operator to connect multiple lexemes from a single token.
-				term := tsTerm{operator: tokInterpose}
-				if tokInterpose == followedby {
-					term.followedN = 1
-				}
-				tokens = append(tokens, term)
-			}
-			lexeme, stopWord, err := TSLexize(config, lexemeTokens[j])
-			if err != nil {
-				return TSQuery{}, err
+		first := true
+		for j := 0; j < len(lexemeTokens); {
+			lexemes, n := config.lexize(lexemeTokens[j:])
+			if n == 0 {
+				n = 1
 			}
-			if stopWord {
+			j += n
+			if len(lexemes) == 0 {
+				lexemes = []string{""}
 				foundStopwords = true
 			}
-			tokens = append(tokens, tsTerm{lexeme: lexeme, positions: tok.positions})
+			for _, lexeme := range lexemes {
+				if !first {
+					// We found more than one lexeme in our token, so we need to add all
+					// of them to the query, connected by our interpose operator.
+					// If we aren't running with an interpose, like in to_tsquery,
+					// Postgres uses the <-> operator to connect multiple lexemes from a
+					// single token.
+					term := tsTerm{operator: tokInterpose}
+					if tokInterpose == followedby {
+						term.followedN = 1
+					}
+					tokens = append(tokens, term)
+				}
+				first = false
+				tokens = append(tokens, tsTerm{lexeme: lexeme, positions: tok.positions})
+			}
 		}
 	}
 
@@ -18,7 +18,6 @@ import (
 	"unicode"
 	"unicode/utf8"
 
-	"github.com/blevesearch/snowballstem"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/errors"
@@ -401,52 +400,34 @@ func TSParse(input string) []string {
 	})
 }
 
-// TSLexize implements the "dictionary" construct that's exposed via ts_lexize.
-// It gets invoked once per input token to produce an output lexeme during
-// routines like to_tsvector and to_tsquery.
-// It can return true in the second parameter to indicate a stopword was found.
-func TSLexize(config string, token string) (lexeme string, stopWord bool, err error) {
-	stopwords, ok := stopwordsMap[config]
-	if !ok {
-		return "", false, pgerror.Newf(pgcode.UndefinedObject, "text search configuration %q does not exist", config)
-	}
-
-	lower := strings.ToLower(token)
-	if _, ok := stopwords[lower]; ok {
-		return "", true, nil
-	}
-	stemmer, err := getStemmer(config)
-	if err != nil {
-		return "", false, err
-	}
-	env := snowballstem.NewEnv(lower)
-	stemmer(env)
-	return env.Current(), false, nil
-}
-
-// DocumentToTSVector parses an input document into lexemes, removes stop words,
-// stems and normalizes the lexemes, and returns a TSVector annotated with
-// lexeme positions according to a text search configuration passed by name.
-func DocumentToTSVector(config string, input string) (TSVector, error) {
+// DocumentToTSVector parses an input document into tokens, normalizes them
+// into lexemes with the dictionaries of a text search configuration, which
+// remove stop words, and returns a TSVector annotated with lexeme positions.
+// Tokens which no dictionary of the configuration recognizes are discarded.
+func DocumentToTSVector(config *Config, input string) (TSVector, error) {
 	tokens := TSParse(input)
 	vector := make(TSVector, 0, len(tokens))
-	for i := range tokens {
-		lexeme, stopWord, err := TSLexize(config, tokens[i])
-		if err != nil {
-			return nil, err
-		}
-		if stopWord {
-			continue
+	for i := 0; i < len(tokens); {
+		lexemes, n := config.lexize(tokens[i:])
+		if n == 0 {
+			n = 1
 		}
-
-		term := tsTerm{lexeme: lexeme}
-		pos := i + 1
-		if i > maxTSVectorPosition {
-			// Postgres silently truncates positions larger than 16383 to 16383.
-			pos = maxTSVectorPosition
+		for j := range lexemes {
+			// The lexemes which replace a phrase take the positions of its
+			// tokens, with any extra lexemes at the position of its last token.
+			pos := i + 1 + j
+			if j >= n {
+				pos = i + n
+			}
+			if pos > maxTSVectorPosition {
+				// Postgres silently truncates positions larger than 16383 to 16383.
+				pos = maxTSVectorPosition
+			}
+			term := tsTerm{lexeme: lexemes[j]}
+			term.positions = []tsPosition{{position: uint16(pos)}}
+			vector = append(vector, term)
 		}
-		term.positions = []tsPosition{{position: uint16(pos)}}
-		vector = append(vector, term)
+		i += n
 	}
 	return normalizeTSVector(vector)
 }